@@ -18,6 +18,8 @@ import (
 	"github.com/steveyegge/beads/internal/beads"
 	"github.com/steveyegge/beads/internal/config"
 	"github.com/steveyegge/beads/internal/debug"
+	"github.com/steveyegge/beads/internal/export"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/utils"
 )
@@ -432,7 +434,16 @@ func validateJSONLIntegrity(ctx context.Context, jsonlPath string) (bool, error)
 	return false, nil
 }
 
-func writeJSONLAtomic(jsonlPath string, issues []*types.Issue) ([]string, error) {
+// jsonlTimestampFormat returns the export.timestamp_format for code that
+// rewrites issues.jsonl in place, falling back to the default without a store
+func jsonlTimestampFormat() types.TimestampFormat {
+	if store == nil {
+		return export.DefaultTimestampFormat
+	}
+	return export.LoadTimestampFormat(rootCtx, store)
+}
+
+func writeJSONLAtomic(jsonlPath string, issues []*types.Issue, format types.TimestampFormat) ([]string, error) {
 	// Sort issues by ID for consistent output
 	sort.Slice(issues, func(i, j int) bool {
 		return issues[i].ID < issues[j].ID
@@ -454,7 +465,7 @@ func writeJSONLAtomic(jsonlPath string, issues []*types.Issue) ([]string, error)
 	}()
 
 	// Write all issues as JSONL (timestamp-only deduplication DISABLED - bd-160)
-	encoder := json.NewEncoder(f)
+	encoder := export.NewIssueEncoder(f, format)
 	skippedCount := 0
 	exportedIDs := make([]string, 0, len(issues))
 	
@@ -608,12 +619,15 @@ func flushToJSONLWithState(state flushState) {
 		flushMutex.Unlock()
 	}
 
+	// The JSONL is shared through git, so private issues stay out of it
+	ctx = sqlite.WithPublicOnly(ctx)
+
 	// Determine which issues to export
 	var dirtyIDs []string
 
 	if fullExport {
 		// Full export: get ALL issues (needed after ID-changing operations like renumber)
-		allIssues, err2 := store.SearchIssues(ctx, "", types.IssueFilter{})
+		allIssues, err2 := store.SearchIssues(ctx, "", types.IssueFilter{IncludeSnoozed: true})
 		if err2 != nil {
 			recordFailure(fmt.Errorf("failed to get all issues: %w", err2))
 			return
@@ -678,8 +692,8 @@ func flushToJSONLWithState(state flushState) {
 			recordFailure(fmt.Errorf("failed to get issue %s: %w", issueID, err))
 			return
 		}
-		if issue == nil {
-			// Issue was deleted, remove from map
+		if issue == nil || issue.Draft {
+			// Issue was deleted (or is a local-only draft), remove from map
 			delete(issueMap, issueID)
 			continue
 		}
@@ -743,7 +757,7 @@ func flushToJSONLWithState(state flushState) {
 	}
 
 	// Write atomically using common helper
-	exportedIDs, err := writeJSONLAtomic(jsonlPath, issues)
+	exportedIDs, err := writeJSONLAtomic(jsonlPath, issues, export.LoadTimestampFormat(ctx, store))
 	if err != nil {
 		recordFailure(err)
 		return
@@ -12,6 +12,7 @@ import (
 	"runtime"
 	"strings"
 
+	"github.com/steveyegge/beads/internal/export"
 	"github.com/steveyegge/beads/internal/git"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/syncbranch"
@@ -277,6 +278,9 @@ func importFromGit(ctx context.Context, dbFilePath string, store storage.Storage
 	if err != nil {
 		return fmt.Errorf("failed to read from git: %w", err)
 	}
+	if jsonlData, err = export.DecompressJSONL(jsonlData); err != nil {
+		return err
+	}
 
 	// Parse JSONL data
 	scanner := bufio.NewScanner(bytes.NewReader(jsonlData))
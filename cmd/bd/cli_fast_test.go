@@ -506,6 +506,76 @@ func TestCLI_Import(t *testing.T) {
 	}
 }
 
+func TestCLI_ExportImportGzip(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping slow CLI test in short mode")
+	}
+	// Note: Not using t.Parallel() because inProcessMutex serializes execution anyway
+	tmpDir := setupCLITestDB(t)
+	runBDInProcess(t, tmpDir, "create", "Gzip test", "-p", "1", "-d", "Round-trips through a .gz export")
+
+	// A .gz output path compresses the export
+	exportFile := filepath.Join(tmpDir, "export.jsonl.gz")
+	runBDInProcess(t, tmpDir, "export", "-o", exportFile)
+	data, err := os.ReadFile(exportFile)
+	if err != nil {
+		t.Fatalf("Export file not created: %v", err)
+	}
+	if len(data) < 2 || data[0] != 0x1f || data[1] != 0x8b {
+		t.Fatalf("Expected a gzipped export, got %q", data)
+	}
+
+	// Import detects the compression on its own
+	tmpDir2 := createTempDirWithCleanup(t)
+	runBDInProcess(t, tmpDir2, "init", "--prefix", "test", "--quiet")
+	runBDInProcess(t, tmpDir2, "import", "-i", exportFile)
+
+	out := runBDInProcess(t, tmpDir2, "list", "--json")
+	var issues []map[string]interface{}
+	if err := json.Unmarshal([]byte(out), &issues); err != nil {
+		t.Fatalf("Failed to parse list output: %v\n%s", err, out)
+	}
+	if len(issues) != 1 || issues[0]["title"] != "Gzip test" || issues[0]["description"] != "Round-trips through a .gz export" {
+		t.Errorf("Expected the issue to round-trip, got %v", issues)
+	}
+}
+
+func TestCLI_ExportImportEncrypted(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping slow CLI test in short mode")
+	}
+	// Note: Not using t.Parallel() because inProcessMutex serializes execution anyway
+	tmpDir := setupCLITestDB(t)
+	runBDInProcess(t, tmpDir, "create", "Encrypted test", "-p", "1")
+
+	t.Setenv("BEADS_EXPORT_KEY", "s3cret")
+	// Flag values outlive the in-process run
+	t.Cleanup(func() { _ = exportCmd.Flags().Set("encrypt", "false") })
+	exportFile := filepath.Join(tmpDir, "export.jsonl.enc")
+	runBDInProcess(t, tmpDir, "export", "--encrypt", "-o", exportFile)
+	data, err := os.ReadFile(exportFile)
+	if err != nil {
+		t.Fatalf("Export file not created: %v", err)
+	}
+	if strings.Contains(string(data), "Encrypted test") {
+		t.Fatalf("Expected an encrypted export, got %q", data)
+	}
+
+	// Import decrypts with the same key
+	tmpDir2 := createTempDirWithCleanup(t)
+	runBDInProcess(t, tmpDir2, "init", "--prefix", "test", "--quiet")
+	runBDInProcess(t, tmpDir2, "import", "-i", exportFile)
+
+	out := runBDInProcess(t, tmpDir2, "list", "--json")
+	var issues []map[string]interface{}
+	if err := json.Unmarshal([]byte(out), &issues); err != nil {
+		t.Fatalf("Failed to parse list output: %v\n%s", err, out)
+	}
+	if len(issues) != 1 || issues[0]["title"] != "Encrypted test" {
+		t.Errorf("Expected the issue to round-trip, got %v", issues)
+	}
+}
+
 var testBD string
 
 func init() {
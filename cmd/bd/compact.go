@@ -13,6 +13,7 @@ import (
 	"github.com/steveyegge/beads/internal/compact"
 	"github.com/steveyegge/beads/internal/configfile"
 	"github.com/steveyegge/beads/internal/deletions"
+	"github.com/steveyegge/beads/internal/export"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -1051,7 +1052,7 @@ func pruneExpiredTombstones() (*TombstonePruneResult, error) {
 	}
 	tempPath := tempFile.Name()
 
-	encoder := json.NewEncoder(tempFile)
+	encoder := export.NewIssueEncoder(tempFile, jsonlTimestampFormat())
 	for _, issue := range kept {
 		if err := encoder.Encode(issue); err != nil {
 			_ = tempFile.Close()
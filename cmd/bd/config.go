@@ -7,6 +7,8 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/syncbranch"
 )
 
@@ -24,6 +26,11 @@ Common namespaces:
   - custom.*     Custom integration settings
   - status.*     Issue status configuration
 
+Only keys bd knows about can be set, and values are checked (e.g. issue_prefix
+must be up to 20 lowercase letters, digits and hyphens, starting with a letter),
+so a typo fails instead of silently doing nothing. Use the custom.* namespace
+for settings of your own.
+
 Custom Status States:
   You can define custom status states for multi-step pipelines using the
   status.custom config key. Statuses should be comma-separated.
@@ -203,4 +210,24 @@ func init() {
 	configCmd.AddCommand(configListCmd)
 	configCmd.AddCommand(configUnsetCmd)
 	rootCmd.AddCommand(configCmd)
+
+	// Database config keys the CLI itself reads (see storage.RegisterConfigKey)
+	orphanModes := []string{string(sqlite.OrphanStrict), string(sqlite.OrphanResurrect), string(sqlite.OrphanSkip), string(sqlite.OrphanAllow)}
+	for key, validate := range map[string]func(string) error{
+		ConfigKeyHintsDoctor:               storage.ValidateBoolConfig,
+		"daemon.auto_commit":               storage.ValidateBoolConfig,
+		"daemon.auto_push":                 storage.ValidateBoolConfig,
+		"team.enabled":                     storage.ValidateBoolConfig,
+		"team.sync_branch":                 syncbranch.ValidateBranchName,
+		"contributor.auto_route":           storage.ValidateBoolConfig,
+		"contributor.planning_repo":        nil,
+		"sync.remote":                      nil,
+		"repos.additional":                 nil,
+		"deletions.auto_compact":           storage.ValidateBoolConfig,
+		"deletions.auto_compact_threshold": storage.ValidateIntConfig,
+		"deletions.retention_days":         storage.ValidateIntConfig,
+		"import.missing_parents":           storage.ValidateOneOf(orphanModes...),
+	} {
+		storage.RegisterConfigKey(key, validate)
+	}
 }
@@ -15,13 +15,13 @@ func TestConfigCommands(t *testing.T) {
 	defer cleanup()
 
 	// Test SetConfig
-	err := store.SetConfig(ctx, "test.key", "test-value")
+	err := store.SetConfig(ctx, "custom.test_key", "test-value")
 	if err != nil {
 		t.Fatalf("SetConfig failed: %v", err)
 	}
 
 	// Test GetConfig
-	value, err := store.GetConfig(ctx, "test.key")
+	value, err := store.GetConfig(ctx, "custom.test_key")
 	if err != nil {
 		t.Fatalf("GetConfig failed: %v", err)
 	}
@@ -30,7 +30,7 @@ func TestConfigCommands(t *testing.T) {
 	}
 
 	// Test GetConfig for non-existent key
-	value, err = store.GetConfig(ctx, "nonexistent.key")
+	value, err = store.GetConfig(ctx, "custom.nonexistent")
 	if err != nil {
 		t.Fatalf("GetConfig for nonexistent key failed: %v", err)
 	}
@@ -39,11 +39,11 @@ func TestConfigCommands(t *testing.T) {
 	}
 
 	// Test SetConfig update
-	err = store.SetConfig(ctx, "test.key", "updated-value")
+	err = store.SetConfig(ctx, "custom.test_key", "updated-value")
 	if err != nil {
 		t.Fatalf("SetConfig update failed: %v", err)
 	}
-	value, err = store.GetConfig(ctx, "test.key")
+	value, err = store.GetConfig(ctx, "custom.test_key")
 	if err != nil {
 		t.Fatalf("GetConfig after update failed: %v", err)
 	}
@@ -71,8 +71,8 @@ func TestConfigCommands(t *testing.T) {
 		t.Errorf("Expected at least 3 config entries, got %d", len(config))
 	}
 
-	if config["test.key"] != "updated-value" {
-		t.Errorf("Expected 'updated-value' for test.key, got '%s'", config["test.key"])
+	if config["custom.test_key"] != "updated-value" {
+		t.Errorf("Expected 'updated-value' for custom.test_key, got '%s'", config["custom.test_key"])
 	}
 	if config["jira.url"] != "https://example.atlassian.net" {
 		t.Errorf("Expected jira.url in config, got '%s'", config["jira.url"])
@@ -82,12 +82,12 @@ func TestConfigCommands(t *testing.T) {
 	}
 
 	// Test DeleteConfig
-	err = store.DeleteConfig(ctx, "test.key")
+	err = store.DeleteConfig(ctx, "custom.test_key")
 	if err != nil {
 		t.Fatalf("DeleteConfig failed: %v", err)
 	}
 
-	value, err = store.GetConfig(ctx, "test.key")
+	value, err = store.GetConfig(ctx, "custom.test_key")
 	if err != nil {
 		t.Fatalf("GetConfig after delete failed: %v", err)
 	}
@@ -96,7 +96,7 @@ func TestConfigCommands(t *testing.T) {
 	}
 
 	// Test DeleteConfig for non-existent key (should not error)
-	err = store.DeleteConfig(ctx, "nonexistent.key")
+	err = store.DeleteConfig(ctx, "custom.nonexistent")
 	if err != nil {
 		t.Fatalf("DeleteConfig for nonexistent key failed: %v", err)
 	}
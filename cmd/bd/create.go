@@ -35,6 +35,18 @@ var createCmd = &cobra.Command{
 			return
 		}
 
+		// Multi-issue templates create their whole graph in one go
+		if fromTemplate != "" {
+			tmpl, err := loadTemplate(fromTemplate)
+			if err != nil {
+				FatalError("%v", err)
+			}
+			if len(tmpl.Issues) > 0 {
+				createFromTemplateGraph(cmd, tmpl)
+				return
+			}
+		}
+
 		// Original single-issue creation logic
 		// Get title from flag or positional argument
 		titleFlag, _ := cmd.Flags().GetString("title")
@@ -95,16 +107,21 @@ var createCmd = &cobra.Command{
 			acceptance = tmpl.AcceptanceCriteria
 		}
 		
-		// Parse priority (supports both "1" and "P1" formats)
-		priorityStr, _ := cmd.Flags().GetString("priority")
-		priority, err := validation.ValidatePriority(priorityStr)
-		if err != nil {
-			FatalError("%v", err)
-		}
-		if cmd.Flags().Changed("priority") == false && tmpl != nil {
+		// Parse priority (supports both "1" and "P1" formats). Left unset, the
+		// store applies the project's defaults.priority.
+		priority := types.PriorityUnset
+		if cmd.Flags().Changed("priority") {
+			priorityStr, _ := cmd.Flags().GetString("priority")
+			var err error
+			priority, err = validation.ValidatePriority(priorityStr)
+			if err != nil {
+				FatalError("%v", err)
+			}
+		} else if tmpl != nil {
 			priority = tmpl.Priority
 		}
 
+		// Likewise an empty type picks up defaults.issue_type
 		issueType, _ := cmd.Flags().GetString("type")
 		if !cmd.Flags().Changed("type") && tmpl != nil && tmpl.Type != "" {
 			// Flag not explicitly set and template has a type, use template
@@ -121,6 +138,9 @@ var createCmd = &cobra.Command{
 		if len(labels) == 0 && tmpl != nil && len(tmpl.Labels) > 0 {
 			labels = tmpl.Labels
 		}
+		if len(labels) == 0 {
+			labels = nil // no labels given: use defaults.labels
+		}
 
 		explicitID, _ := cmd.Flags().GetString("id")
 		parentID, _ := cmd.Flags().GetString("parent")
@@ -276,6 +296,7 @@ var createCmd = &cobra.Command{
 			Assignee:           assignee,
 			ExternalRef:        externalRefPtr,
 			EstimatedMinutes:   estimatedMinutes,
+			Labels:             labels,
 		}
 
 		ctx := rootCtx
@@ -331,13 +352,6 @@ var createCmd = &cobra.Command{
 			}
 		}
 
-		// Add labels if specified
-		for _, label := range labels {
-			if err := store.AddLabel(ctx, issue.ID, label, actor); err != nil {
-				WarnError("failed to add label %s: %v", label, err)
-			}
-		}
-
 		// Add dependencies if specified (format: type:id or just id for default "blocks" type)
 		for _, depSpec := range deps {
 			// Skip empty specs (e.g., from trailing commas)
@@ -402,9 +416,10 @@ var createCmd = &cobra.Command{
 func init() {
 	createCmd.Flags().StringP("file", "f", "", "Create multiple issues from markdown file")
 	createCmd.Flags().String("from-template", "", "Create issue from template (e.g., 'epic', 'bug', 'feature')")
+	createCmd.Flags().StringArray("var", nil, "Template variable as key=value, substituted for {{key}} in multi-issue templates (repeatable)")
 	createCmd.Flags().String("title", "", "Issue title (alternative to positional argument)")
-	registerPriorityFlag(createCmd, "2")
-	createCmd.Flags().StringP("type", "t", "task", "Issue type (bug|feature|task|epic|chore)")
+	registerPriorityFlag(createCmd, "")
+	createCmd.Flags().StringP("type", "t", "", "Issue type (bug|feature|task|epic|chore, default: defaults.issue_type or task)")
 	registerCommonIssueFlags(createCmd)
 	createCmd.Flags().StringSliceP("labels", "l", []string{}, "Labels (comma-separated)")
 	createCmd.Flags().StringSlice("label", []string{}, "Alias for --labels")
@@ -14,8 +14,10 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/cmd/bd/doctor"
 	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/config"
 	"github.com/steveyegge/beads/internal/daemon"
 	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
 )
 
@@ -127,6 +129,13 @@ Run 'bd daemon' with no flags to see available options.`,
 			os.Exit(1)
 		}
 
+		// A database server already serves every client; there's nothing to run
+		if dbConn != "" {
+			fmt.Fprintf(os.Stderr, "Error: the daemon serves local SQLite databases only\n")
+			fmt.Fprintf(os.Stderr, "bd connects to --db %s directly, without a daemon\n", redactConn(dbConn))
+			os.Exit(1)
+		}
+
 		// Skip daemon-running check if we're the forked child (BD_DAEMON_FOREGROUND=1)
 		// because the check happens in the parent process before forking
 		if os.Getenv("BD_DAEMON_FOREGROUND") != "1" {
@@ -373,6 +382,20 @@ func runDaemonLoop(interval time.Duration, autoCommit, autoPush, localMode bool,
 	defer func() { _ = store.Close() }()
 	log.log("Database opened: %s", daemonDBPath)
 
+	// Detect the database file being replaced underneath us (e.g. by a git merge)
+	// so long-running reads don't keep serving the old inode. On busy repos,
+	// freshness-poll-interval checks on a timer instead of before every read.
+	freshnessOpts := storage.FreshnessOptions{PollInterval: config.GetDuration("freshness-poll-interval")}
+	if err := storage.EnableFreshnessChecking(store, freshnessOpts); err != nil {
+		log.log("Warning: failed to enable freshness checking: %v", err)
+	}
+
+	// Bound each store operation so a runaway query can't wedge the daemon
+	if queryTimeout := config.GetDuration("query-timeout"); queryTimeout > 0 {
+		store.SetQueryTimeout(queryTimeout)
+		log.log("Query timeout: %v", queryTimeout)
+	}
+
 	// Auto-upgrade .beads/.gitignore if outdated
 	gitignoreCheck := doctor.CheckGitignore()
 	if gitignoreCheck.Status == "warning" || gitignoreCheck.Status == "error" {
@@ -496,6 +519,12 @@ func runDaemonLoop(interval time.Duration, autoCommit, autoPush, localMode bool,
 	parentPID := computeDaemonParentPID()
 	log.log("Monitoring parent process (PID %d)", parentPID)
 
+	// Deliver events to configured notifiers (webhook, log, ...)
+	go runNotifiers(ctx, store, log)
+
+	// Hand new unassigned issues to the triage rotation, if configured
+	go runAutoAssign(ctx, store, log)
+
 	// Choose event loop based on BEADS_DAEMON_MODE
 	daemonMode := os.Getenv("BEADS_DAEMON_MODE")
 	if daemonMode == "" {
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+)
+
+// autoAssignInterval is how often the daemon hands unassigned issues to the
+// triage rotation
+const autoAssignInterval = 30 * time.Second
+
+// runAutoAssign runs sqlite.AutoAssign every autoAssignInterval until ctx is
+// done. It does nothing while assign.rotation is unset, so the rotation can
+// be configured without restarting the daemon.
+func runAutoAssign(ctx context.Context, store storage.Storage, log daemonLogger) {
+	sqliteStore, ok := store.(*sqlite.SQLiteStorage)
+	if !ok {
+		return
+	}
+
+	ticker := time.NewTicker(autoAssignInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			assigned, err := sqliteStore.AutoAssign(ctx)
+			if err != nil {
+				log.log("Auto-assign: %v", err)
+			} else if assigned > 0 {
+				log.log("Auto-assign: assigned %d issue(s)", assigned)
+			}
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/steveyegge/beads/internal/rpc"
 	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
 )
 
 // runEventDrivenLoop implements event-driven daemon architecture.
@@ -107,6 +108,13 @@ func runEventDrivenLoop(
 		case <-healthTicker.C:
 			// Periodic health validation (not sync)
 			checkDaemonHealth(ctx, store, log)
+			if generateRecurringIssues(ctx, store, log) {
+				exportDebouncer.Trigger()
+			}
+			if wakeSnoozedIssues(ctx, store, log) {
+				exportDebouncer.Trigger()
+			}
+			markOverdueIssues(ctx, store, log)
 
 		case <-parentCheckTicker.C:
 			// Check if parent process is still alive
@@ -165,6 +173,59 @@ func runEventDrivenLoop(
 	}
 }
 
+// generateRecurringIssues creates the instances of recurring issues that
+// have come due, reporting whether any were created
+func generateRecurringIssues(ctx context.Context, store storage.Storage, log daemonLogger) bool {
+	sqliteStore, ok := store.(*sqlite.SQLiteStorage)
+	if !ok {
+		return false
+	}
+	created, err := sqliteStore.GenerateRecurring(ctx, time.Now())
+	if err != nil {
+		log.log("Recurring issues: generation failed: %v", err)
+		return false
+	}
+	if len(created) > 0 {
+		log.log("Recurring issues: created %v", created)
+	}
+	return len(created) > 0
+}
+
+// wakeSnoozedIssues reopens the snoozed issues whose wake date has passed,
+// reporting whether any were woken
+func wakeSnoozedIssues(ctx context.Context, store storage.Storage, log daemonLogger) bool {
+	sqliteStore, ok := store.(*sqlite.SQLiteStorage)
+	if !ok {
+		return false
+	}
+	woken, err := sqliteStore.WakeSnoozed(ctx, time.Now())
+	if err != nil {
+		log.log("Snoozed issues: wake failed: %v", err)
+		return false
+	}
+	if len(woken) > 0 {
+		log.log("Snoozed issues: woke %v", woken)
+	}
+	return len(woken) > 0
+}
+
+// markOverdueIssues records an overdue event for the open issues whose due
+// date has passed. The events aren't exported, so no export is needed.
+func markOverdueIssues(ctx context.Context, store storage.Storage, log daemonLogger) {
+	sqliteStore, ok := store.(*sqlite.SQLiteStorage)
+	if !ok {
+		return
+	}
+	overdue, err := sqliteStore.MarkOverdue(ctx, time.Now())
+	if err != nil {
+		log.log("Overdue issues: check failed: %v", err)
+		return
+	}
+	if len(overdue) > 0 {
+		log.log("Overdue issues: %v", overdue)
+	}
+}
+
 // checkDaemonHealth performs periodic health validation.
 // Separate from sync operations - just validates state.
 //
@@ -101,7 +101,7 @@ func TestCreateLocalSyncFunc(t *testing.T) {
 	defer testStore.Close()
 
 	// Initialize the database with a prefix
-	if err := testStore.SetConfig(ctx, "issue_prefix", "TEST"); err != nil {
+	if err := testStore.SetConfig(ctx, "issue_prefix", "test"); err != nil {
 		t.Fatalf("Failed to set issue prefix: %v", err)
 	}
 
@@ -171,7 +171,7 @@ func TestCreateLocalExportFunc(t *testing.T) {
 	defer testStore.Close()
 
 	// Initialize the database with a prefix
-	if err := testStore.SetConfig(ctx, "issue_prefix", "TEST"); err != nil {
+	if err := testStore.SetConfig(ctx, "issue_prefix", "test"); err != nil {
 		t.Fatalf("Failed to set issue prefix: %v", err)
 	}
 
@@ -243,7 +243,7 @@ func TestCreateLocalAutoImportFunc(t *testing.T) {
 	defer testStore.Close()
 
 	// Initialize the database with a prefix
-	if err := testStore.SetConfig(ctx, "issue_prefix", "TEST"); err != nil {
+	if err := testStore.SetConfig(ctx, "issue_prefix", "test"); err != nil {
 		t.Fatalf("Failed to set issue prefix: %v", err)
 	}
 
@@ -252,7 +252,7 @@ func TestCreateLocalAutoImportFunc(t *testing.T) {
 	dbPath = testDBPath
 
 	// Write a JSONL file directly (simulating external modification)
-	jsonlContent := `{"id":"TEST-abc","title":"Imported issue","description":"From JSONL","status":"open","priority":1,"issue_type":"task","created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-01T00:00:00Z"}
+	jsonlContent := `{"id":"test-abc","title":"Imported issue","description":"From JSONL","status":"open","priority":1,"issue_type":"task","created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-01T00:00:00Z"}
 `
 	if err := os.WriteFile(jsonlPath, []byte(jsonlContent), 0644); err != nil {
 		t.Fatalf("Failed to write JSONL: %v", err)
@@ -358,7 +358,7 @@ func TestLocalModeInNonGitDirectory(t *testing.T) {
 	defer testStore.Close()
 
 	// Initialize the database with a prefix
-	if err := testStore.SetConfig(ctx, "issue_prefix", "TEST"); err != nil {
+	if err := testStore.SetConfig(ctx, "issue_prefix", "test"); err != nil {
 		t.Fatalf("Failed to set issue prefix: %v", err)
 	}
 
@@ -429,7 +429,7 @@ func TestLocalModeExportImportRoundTrip(t *testing.T) {
 	defer testStore.Close()
 
 	// Initialize the database with a prefix
-	if err := testStore.SetConfig(ctx, "issue_prefix", "TEST"); err != nil {
+	if err := testStore.SetConfig(ctx, "issue_prefix", "test"); err != nil {
 		t.Fatalf("Failed to set issue prefix: %v", err)
 	}
 
@@ -468,7 +468,7 @@ func TestLocalModeExportImportRoundTrip(t *testing.T) {
 
 	// Modify JSONL externally (add a new issue)
 	content, _ := os.ReadFile(jsonlPath)
-	newIssue := `{"id":"TEST-ext","title":"External issue","description":"Added externally","status":"open","priority":1,"issue_type":"task","created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-01T00:00:00Z"}
+	newIssue := `{"id":"test-ext","title":"External issue","description":"Added externally","status":"open","priority":1,"issue_type":"task","created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-01T00:00:00Z"}
 `
 	content = append(content, []byte(newIssue)...)
 	if err := os.WriteFile(jsonlPath, content, 0644); err != nil {
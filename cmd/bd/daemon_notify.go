@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/notify"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+)
+
+// notifyInterval is how often the daemon checks the event log for new events
+const notifyInterval = time.Second
+
+// runNotifiers delivers events to the notifiers enabled in config (see
+// notify.LoadRegistry) until ctx is done. Events recorded before the daemon
+// started are not sent. Returns immediately if no notifier is enabled.
+func runNotifiers(ctx context.Context, store storage.Storage, log daemonLogger) {
+	sqliteStore, ok := store.(*sqlite.SQLiteStorage)
+	if !ok {
+		return
+	}
+	registry, err := notify.LoadRegistry(ctx, sqliteStore, log.log)
+	if err != nil {
+		log.log("Notifications: failed to load notifiers: %v", err)
+		return
+	}
+	names := registry.Names()
+	if len(names) == 0 {
+		return
+	}
+	revision, err := sqliteStore.CurrentRevision(ctx)
+	if err != nil {
+		log.log("Notifications: failed to read event log: %v", err)
+		return
+	}
+	log.log("Notifications: sending events to %s", strings.Join(names, ", "))
+
+	ticker := time.NewTicker(notifyInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if revision, err = registry.DispatchSince(ctx, sqliteStore, revision); err != nil {
+				log.log("Notifications: %v", err)
+			}
+		}
+	}
+}
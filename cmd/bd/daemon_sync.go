@@ -13,6 +13,7 @@ import (
 
 	"github.com/steveyegge/beads/internal/beads"
 	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/export"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
@@ -22,6 +23,9 @@ import (
 // If multi-repo mode is configured, routes issues to their respective JSONL files.
 // Otherwise, exports to a single JSONL file.
 func exportToJSONLWithStore(ctx context.Context, store storage.Storage, jsonlPath string) error {
+	// The JSONL is shared through git, so private issues stay out of it
+	ctx = sqlite.WithPublicOnly(ctx)
+
 	// Try multi-repo export first
 	sqliteStore, ok := store.(*sqlite.SQLiteStorage)
 	if ok {
@@ -37,7 +41,7 @@ func exportToJSONLWithStore(ctx context.Context, store storage.Storage, jsonlPat
 
 	// Single-repo mode - use existing logic
 	// Get all issues
-	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{IncludeSnoozed: true})
 	if err != nil {
 		return fmt.Errorf("failed to get issues: %w", err)
 	}
@@ -89,6 +93,11 @@ func exportToJSONLWithStore(ctx context.Context, store storage.Storage, jsonlPat
 		issue.Comments = comments
 	}
 
+	// Populate links for all issues
+	if err := populateLinks(ctx, store, issues); err != nil {
+		return err
+	}
+
 	// Create temp file for atomic write
 	dir := filepath.Dir(jsonlPath)
 	base := filepath.Base(jsonlPath)
@@ -108,8 +117,9 @@ func exportToJSONLWithStore(ctx context.Context, store storage.Storage, jsonlPat
 	}()
 
 	// Write JSONL
+	format := export.LoadTimestampFormat(ctx, store)
 	for _, issue := range issues {
-		data, marshalErr := json.Marshal(issue)
+		data, marshalErr := export.MarshalIssue(issue, format)
 		if marshalErr != nil {
 			writeErr = fmt.Errorf("failed to marshal issue %s: %w", issue.ID, marshalErr)
 			return writeErr
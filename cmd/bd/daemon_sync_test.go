@@ -709,3 +709,47 @@ func TestUpdateExportMetadataInvalidKeySuffix(t *testing.T) {
 		t.Errorf("expected unsanitized key %s to NOT be set", unsanitizedKey)
 	}
 }
+
+func TestExportToJSONLWithStoreLeavesOutPrivateIssues(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, ".beads", "beads.db")
+	jsonlPath := filepath.Join(tmpDir, ".beads", "issues.jsonl")
+
+	store, err := sqlite.New(context.Background(), dbPath)
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	defer store.Close()
+
+	ctx := context.Background()
+	if err := store.SetConfig(ctx, "issue_prefix", "test"); err != nil {
+		t.Fatalf("failed to set issue_prefix: %v", err)
+	}
+
+	for _, id := range []string{"test-1", "test-2"} {
+		issue := &types.Issue{ID: id, Title: "Issue " + id, IssueType: types.TypeTask, Priority: 2, Status: types.StatusOpen}
+		if err := store.CreateIssue(ctx, issue, "alice"); err != nil {
+			t.Fatalf("failed to create issue: %v", err)
+		}
+	}
+	if err := store.SetVisibility(ctx, "test-2", types.VisibilityPrivate, nil, "alice"); err != nil {
+		t.Fatalf("SetVisibility failed: %v", err)
+	}
+
+	// The daemon exports with no actor, and must still keep alice's private
+	// issue out of the JSONL that goes to git
+	if err := exportToJSONLWithStore(ctx, store, jsonlPath); err != nil {
+		t.Fatalf("exportToJSONLWithStore failed: %v", err)
+	}
+	data, err := os.ReadFile(jsonlPath)
+	if err != nil {
+		t.Fatalf("failed to read JSONL: %v", err)
+	}
+	var exported types.Issue
+	if err := json.Unmarshal(data, &exported); err != nil {
+		t.Fatalf("expected a single exported issue: %v", err)
+	}
+	if exported.ID != "test-1" {
+		t.Errorf("expected only test-1 exported, got %s", exported.ID)
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/backend"
+)
+
+// dbConn holds --db when it names a database server (postgres://...) rather
+// than a SQLite file; dbPath is then empty. bd talks to the server directly:
+// there's no daemon, and no JSONL to sync, since everyone shares the server.
+var dbConn string
+
+// resolveDBConn interprets --db as a connection string (see
+// storage.ParseConnString). A sqlite:// string resolves to its file path, so
+// the rest of bd, which works with dbPath, sees a plain path; a server
+// string moves to dbConn.
+func resolveDBConn() error {
+	dbConn = ""
+	if dbPath == "" {
+		return nil
+	}
+	cfg, err := storage.ParseConnString(dbPath)
+	if err != nil {
+		return err
+	}
+	if cfg.Backend == storage.BackendSQLite {
+		dbPath = cfg.Path
+	} else {
+		dbConn, dbPath = dbPath, ""
+	}
+	return nil
+}
+
+// openStore opens the direct-mode store: the server named by dbConn if set,
+// or else the SQLite file at dbPath
+func openStore(ctx context.Context) (storage.Storage, error) {
+	conn := dbPath
+	if dbConn != "" {
+		conn = dbConn
+	}
+	return backend.OpenWithTimeout(ctx, conn, lockTimeout)
+}
+
+// initServerStore sets up direct mode against the server named by dbConn,
+// with the daemon and JSONL auto-sync turned off
+func initServerStore() {
+	noDaemon = true
+	autoFlushEnabled = false
+	autoImportEnabled = false
+
+	var err error
+	if store, err = openStore(rootCtx); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to open database: %v\n", err)
+		os.Exit(1)
+	}
+	storeMutex.Lock()
+	storeActive = true
+	storeMutex.Unlock()
+
+	if actor == "" {
+		if user := os.Getenv("USER"); user != "" {
+			actor = user
+		} else {
+			actor = "unknown"
+		}
+	}
+}
+
+// redactConn returns conn with any password replaced, for messages
+func redactConn(conn string) string {
+	if u, err := url.Parse(conn); err == nil && u.User != nil {
+		if _, ok := u.User.Password(); ok {
+			u.User = url.UserPassword(u.User.Username(), "xxxxx")
+			return u.String()
+		}
+	}
+	return conn
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/deletions"
+	"github.com/steveyegge/beads/internal/export"
 	"github.com/steveyegge/beads/internal/rpc"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
@@ -243,10 +244,13 @@ Force: Delete and orphan dependents
 		// Actually delete
 		// 0. Record deletion in manifest FIRST (before any DB changes)
 		// This ensures deletion propagates via git sync even if DB operations fail
+		// Drafts were never synced, so there is nothing to propagate
 		deleteActor := getActorWithGit()
-		if err := recordDeletion(issueID, deleteActor, "manual delete"); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: failed to record deletion: %v\n", err)
-			os.Exit(1)
+		if !issue.Draft {
+			if err := recordDeletion(issueID, deleteActor, "manual delete"); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: failed to record deletion: %v\n", err)
+				os.Exit(1)
+			}
 		}
 		// 1. Update text references in connected issues (all text fields)
 		updatedIssueCount := 0
@@ -397,7 +401,7 @@ func removeIssueFromJSONL(issueID string) error {
 	if err != nil {
 		return fmt.Errorf("failed to create temp file: %w", err)
 	}
-	enc := json.NewEncoder(out)
+	enc := export.NewIssueEncoder(out, jsonlTimestampFormat())
 	for _, iss := range issues {
 		if err := enc.Encode(iss); err != nil {
 			_ = out.Close()
@@ -510,8 +514,15 @@ func deleteBatch(_ *cobra.Command, issueIDs []string, force bool, dryRun bool, c
 	}
 	// Record deletions in manifest FIRST (before any DB changes)
 	// This ensures deletion propagates via git sync even if DB operations fail
+	// Drafts were never synced, so they are left out of the manifest
 	deleteActor := getActorWithGit()
-	if err := recordDeletions(issueIDs, deleteActor, reason); err != nil {
+	synced := make([]string, 0, len(issueIDs))
+	for _, id := range issueIDs {
+		if !issues[id].Draft {
+			synced = append(synced, id)
+		}
+	}
+	if err := recordDeletions(synced, deleteActor, reason); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: failed to record deletions: %v\n", err)
 		os.Exit(1)
 	}
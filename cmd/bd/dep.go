@@ -27,6 +27,7 @@ var depAddCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		CheckReadonly("dep add")
 		depType, _ := cmd.Flags().GetString("type")
+		note, _ := cmd.Flags().GetString("note")
 
 		ctx := rootCtx
 		
@@ -75,6 +76,7 @@ var depAddCmd = &cobra.Command{
 				FromID:  fromID,
 				ToID:    toID,
 				DepType: depType,
+				Note:    note,
 			}
 
 			resp, err := daemonClient.AddDependency(depArgs)
@@ -99,6 +101,7 @@ var depAddCmd = &cobra.Command{
 			IssueID:     fromID,
 			DependsOnID: toID,
 			Type:        types.DependencyType(depType),
+			Note:        note,
 		}
 
 		if err := store.AddDependency(ctx, dep, actor); err != nil {
@@ -738,6 +741,7 @@ func mergeBidirectionalTrees(downTree, upTree []*types.TreeNode, rootID string)
 
 func init() {
 	depAddCmd.Flags().StringP("type", "t", "blocks", "Dependency type (blocks|related|parent-child|discovered-from)")
+	depAddCmd.Flags().String("note", "", "Why the dependency exists (shown as an edge label in 'bd list --format dot')")
 	// Note: --json flag is defined as a persistent flag in main.go, not here
 
 	// Note: --json flag is defined as a persistent flag in main.go, not here
@@ -50,7 +50,7 @@ NOTE: Review detected issues carefully before using --clean. False positives are
 		ctx := rootCtx
 
 		// Get all issues
-		allIssues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+		allIssues, err := store.SearchIssues(ctx, "", types.IssueFilter{IncludeSnoozed: true})
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error fetching issues: %v\n", err)
 			os.Exit(1)
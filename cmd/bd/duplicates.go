@@ -47,7 +47,7 @@ Example:
 		}
 
 		// Get all issues
-		allIssues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+		allIssues, err := store.SearchIssues(ctx, "", types.IssueFilter{IncludeSnoozed: true})
 		if err != nil {
 		fmt.Fprintf(os.Stderr, "Error fetching issues: %v\n", err)
 		os.Exit(1)
@@ -1,24 +1,32 @@
 package main
 
 import (
+	"compress/gzip"
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/compression"
 	"github.com/steveyegge/beads/internal/debug"
+	"github.com/steveyegge/beads/internal/export"
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/util"
 	"github.com/steveyegge/beads/internal/validation"
 )
 
-// countIssuesInJSONL counts the number of issues in a JSONL file
+// countIssuesInJSONL counts the number of issues in a JSONL file, which may
+// be encrypted or gzipped
 func countIssuesInJSONL(path string) (int, error) {
 	// #nosec G304 - controlled path from config
 	file, err := os.Open(path)
@@ -31,8 +39,16 @@ func countIssuesInJSONL(path string) (int, error) {
 		}
 	}()
 
+	decrypted, err := export.NewDecryptingReader(file, os.Getenv(export.EncryptionKeyEnv))
+	if err != nil {
+		return 0, err
+	}
+	in, err := export.NewJSONLReader(decrypted)
+	if err != nil {
+		return 0, err
+	}
 	count := 0
-	decoder := json.NewDecoder(file)
+	decoder := json.NewDecoder(in)
 	for {
 		var issue types.Issue
 		if err := decoder.Decode(&issue); err != nil {
@@ -47,7 +63,8 @@ func countIssuesInJSONL(path string) (int, error) {
 	return count, nil
 }
 
-// getIssueIDsFromJSONL reads a JSONL file and returns a set of issue IDs
+// getIssueIDsFromJSONL reads a JSONL file, which may be encrypted or
+// gzipped, and returns a set of issue IDs
 func getIssueIDsFromJSONL(path string) (map[string]bool, error) {
 	// #nosec G304 - controlled path from config
 	file, err := os.Open(path)
@@ -60,8 +77,16 @@ func getIssueIDsFromJSONL(path string) (map[string]bool, error) {
 		}
 	}()
 
+	decrypted, err := export.NewDecryptingReader(file, os.Getenv(export.EncryptionKeyEnv))
+	if err != nil {
+		return nil, err
+	}
+	in, err := export.NewJSONLReader(decrypted)
+	if err != nil {
+		return nil, err
+	}
 	ids := make(map[string]bool)
-	decoder := json.NewDecoder(file)
+	decoder := json.NewDecoder(in)
 	lineNum := 0
 	for {
 		var issue types.Issue
@@ -116,17 +141,38 @@ var exportCmd = &cobra.Command{
 	Long: `Export all issues to JSON Lines format (one JSON object per line).
 Issues are sorted by ID for consistent diffs.
 
-Output to stdout by default, or use -o flag for file output.
+Output to stdout by default, or use -o flag for file output. --gzip
+compresses the output, as does an -o path ending in .gz; 'bd import' and
+auto-import detect gzipped JSONL on their own.
+
+Exports are plaintext unless --encrypt is given, which encrypts the output
+with the key in $BEADS_EXPORT_KEY; 'bd import' decrypts it with the same
+variable. The JSONL in .beads is shared through git and merged there, so it
+can't be encrypted.
+
+For large shared repos, --shard-by splits the export into one JSONL file per
+shard (<key>.shard.jsonl) under the -o directory, so concurrent changes to
+different shards don't conflict in git. Shard by issue ID prefix ("prefix") or by the leading digits
+of the ID hash ("hash"). Set export.shard_by in config to make it the default
+for directory outputs. 'bd import -i <dir>' reads all shard files.
 
 Examples:
   bd export --status open -o open-issues.jsonl
   bd export --type bug --priority-max 1
-  bd export --created-after 2025-01-01 --assignee alice`,
+  bd export --created-after 2025-01-01 --assignee alice
+  bd export -o backup.jsonl.gz
+  BEADS_EXPORT_KEY=... bd export --encrypt -o backup.jsonl.enc
+  bd export --shard-by hash --shard-digits 1 -o .beads/issues`,
 	Run: func(cmd *cobra.Command, args []string) {
 		format, _ := cmd.Flags().GetString("format")
 		output, _ := cmd.Flags().GetString("output")
 		statusFilter, _ := cmd.Flags().GetString("status")
 		force, _ := cmd.Flags().GetBool("force")
+		compressDescriptions, _ := cmd.Flags().GetBool("compress-descriptions")
+		withConfig, _ := cmd.Flags().GetBool("with-config")
+		gzipOutput, _ := cmd.Flags().GetBool("gzip")
+		gzipOutput = gzipOutput || export.IsGzipPath(output)
+		encryptOutput, _ := cmd.Flags().GetBool("encrypt")
 
 		// Additional filter flags
 		assignee, _ := cmd.Flags().GetString("assignee")
@@ -140,6 +186,33 @@ Examples:
 		updatedAfter, _ := cmd.Flags().GetString("updated-after")
 		updatedBefore, _ := cmd.Flags().GetString("updated-before")
 
+		shardMode, shardDigits, err := resolveShardMode(cmd, output)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		sharded := shardMode != export.ShardNone
+
+		if withConfig && (output == "" || sharded) {
+			fmt.Fprintf(os.Stderr, "Error: --with-config requires --output to a single JSONL file\n")
+			os.Exit(1)
+		}
+		if gzipOutput && sharded {
+			fmt.Fprintf(os.Stderr, "Error: --gzip can't be combined with --shard-by\n")
+			os.Exit(1)
+		}
+		encryptionKey := os.Getenv(export.EncryptionKeyEnv)
+		if encryptOutput {
+			if encryptionKey == "" {
+				fmt.Fprintf(os.Stderr, "Error: --encrypt requires the key in $%s\n", export.EncryptionKeyEnv)
+				os.Exit(1)
+			}
+			if sharded || isSharedExportPath(output) {
+				fmt.Fprintf(os.Stderr, "Error: --encrypt can't be used for the JSONL shared through git\n")
+				os.Exit(1)
+			}
+		}
+
 		debug.Logf("Debug: export flags - output=%q, force=%v\n", output, force)
 
 		if format != "jsonl" {
@@ -183,7 +256,7 @@ Examples:
 		// - No status filter → include tombstones for sync propagation
 		// - --status=tombstone → include only tombstones (filter handles this)
 		// - --status=<other> → exclude tombstones (user wants specific status)
-		filter := types.IssueFilter{}
+		filter := types.IssueFilter{IncludeSnoozed: true}
 		if statusFilter != "" {
 			status := types.Status(statusFilter)
 			filter.Status = &status
@@ -261,6 +334,10 @@ Examples:
 
 		// Get all issues
 		ctx := rootCtx
+		if isSharedExportPath(output) {
+			// The JSONL is shared through git, so private issues stay out of it
+			ctx = sqlite.WithPublicOnly(ctx)
+		}
 		issues, err := store.SearchIssues(ctx, "", filter)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -268,7 +345,8 @@ Examples:
 		}
 
 		// Safety check: prevent exporting empty database over non-empty JSONL
-		if len(issues) == 0 && output != "" && !force {
+		// (sharded exports run their own check in exportShards)
+		if len(issues) == 0 && output != "" && !force && !sharded {
 			existingCount, err := countIssuesInJSONL(output)
 			if err != nil {
 				// If we can't read the file, it might not exist yet, which is fine
@@ -287,7 +365,7 @@ Examples:
 		}
 
 		// Safety check: prevent exporting stale database that would lose issues
-		if output != "" && !force {
+		if output != "" && !force && !sharded {
 			debug.Logf("Debug: checking staleness - output=%s, force=%v\n", output, force)
 			
 			// Read existing JSONL to get issue IDs
@@ -303,10 +381,17 @@ Examples:
 					dbIDs[issue.ID] = true
 				}
 				
-				// Check if JSONL has any issues that DB doesn't have
+				// Check if JSONL has any issues that DB doesn't have. Issues
+				// made private since the last export are left out, not lost.
+				sqliteStore, _ := store.(*sqlite.SQLiteStorage)
 				var missingIDs []string
 				for id := range jsonlIDs {
 					if !dbIDs[id] {
+						if sqliteStore != nil {
+							if visibility, _, err := sqliteStore.Visibility(rootCtx, id); err == nil && visibility == types.VisibilityPrivate {
+								continue
+							}
+						}
 						missingIDs = append(missingIDs, id)
 					}
 				}
@@ -370,6 +455,30 @@ Examples:
 			issue.Labels = labels
 		}
 
+		// Populate links for all issues
+		if err := populateLinks(ctx, store, issues); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		// Descriptions come back from the store plain; re-encode large ones
+		// only when a compact (non-git-readable) export was requested
+		if compressDescriptions {
+			threshold := exportCompressionThreshold(ctx)
+			for _, issue := range issues {
+				issue.Description, err = compression.CompressAbove(issue.Description, threshold)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error compressing description of %s: %v\n", issue.ID, err)
+					os.Exit(1)
+				}
+			}
+		}
+
+		if sharded {
+			exportShards(output, issues, shardMode, shardDigits, force)
+			return
+		}
+
 		// Open output
 		out := os.Stdout
 		var tempFile *os.File
@@ -406,7 +515,18 @@ Examples:
 		}
 
 		// Write JSONL (timestamp-only deduplication DISABLED due to bd-160)
-		encoder := json.NewEncoder(out)
+		var w io.Writer = out
+		var enc io.WriteCloser
+		if encryptOutput {
+			enc = export.NewEncryptedWriter(w, encryptionKey)
+			w = enc
+		}
+		var gz *gzip.Writer
+		if gzipOutput {
+			gz = gzip.NewWriter(w)
+			w = gz
+		}
+		encoder := export.NewIssueEncoder(w, export.LoadTimestampFormat(ctx, store))
 		exportedIDs := make([]string, 0, len(issues))
 		skippedCount := 0
 		for _, issue := range issues {
@@ -417,6 +537,18 @@ Examples:
 
 			exportedIDs = append(exportedIDs, issue.ID)
 		}
+		if gz != nil {
+			if err := gz.Close(); err != nil {
+				fmt.Fprintf(os.Stderr, "Error finishing gzip stream: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		if enc != nil {
+			if err := enc.Close(); err != nil {
+				fmt.Fprintf(os.Stderr, "Error encrypting export: %v\n", err)
+				os.Exit(1)
+			}
+		}
 
 		// Report skipped issues if any (helps debugging bd-159)
 		if skippedCount > 0 && (output == "" || output == findJSONLPath()) {
@@ -463,6 +595,18 @@ Examples:
 				os.Exit(1)
 			}
 
+			// Write the config section next to the issues (see export.ConfigSnapshot)
+			if withConfig {
+				config, err := store.GetAllConfig(ctx)
+				if err == nil {
+					err = export.WriteConfigSnapshot(finalPath, config)
+				}
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error exporting config: %v\n", err)
+					os.Exit(1)
+				}
+			}
+
 			// Set appropriate file permissions (0600: rw-------)
 			// Skip chmod for symlinks - os.Chmod follows symlinks and would change the target's
 			// permissions, which may be in a read-only location (e.g., /nix/store on NixOS).
@@ -516,11 +660,76 @@ Examples:
 	},
 }
 
+// isSharedExportPath reports whether output is in the .beads directory, and
+// so shared through git, like the JSONL auto-flush writes
+func isSharedExportPath(output string) bool {
+	jsonlPath := findJSONLPath()
+	if output == "" || jsonlPath == "" {
+		return false
+	}
+	absOutput, err := filepath.Abs(output)
+	if err != nil {
+		return false
+	}
+	absBeadsDir, err := filepath.Abs(filepath.Dir(jsonlPath))
+	if err != nil {
+		return false
+	}
+	return absOutput == absBeadsDir || strings.HasPrefix(absOutput, absBeadsDir+string(filepath.Separator))
+}
+
+// defaultExportCompressionThreshold is the --compress-descriptions threshold
+// when compression.description_threshold is not configured
+const defaultExportCompressionThreshold = 4096
+
+// exportCompressionThreshold returns the size in bytes above which
+// --compress-descriptions compresses a description
+func exportCompressionThreshold(ctx context.Context) int {
+	value, err := store.GetConfig(ctx, sqlite.DescriptionCompressionConfigKey)
+	if err != nil || strings.TrimSpace(value) == "" {
+		return defaultExportCompressionThreshold
+	}
+	threshold, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || threshold <= 0 {
+		return defaultExportCompressionThreshold
+	}
+	return threshold
+}
+
+// populateLinks attaches issue links for export. Stores without link support
+// (the --no-db memory store) leave whatever Links the issues already carry.
+func populateLinks(ctx context.Context, s storage.Storage, issues []*types.Issue) error {
+	linkStore, ok := s.(interface {
+		GetLinksForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.Link, error)
+	})
+	if !ok {
+		return nil
+	}
+	issueIDs := make([]string, len(issues))
+	for i, issue := range issues {
+		issueIDs[i] = issue.ID
+	}
+	allLinks, err := linkStore.GetLinksForIssues(ctx, issueIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get links: %w", err)
+	}
+	for _, issue := range issues {
+		issue.Links = allLinks[issue.ID]
+	}
+	return nil
+}
+
 func init() {
 	exportCmd.Flags().StringP("format", "f", "jsonl", "Export format (jsonl)")
 	exportCmd.Flags().StringP("output", "o", "", "Output file (default: stdout)")
 	exportCmd.Flags().StringP("status", "s", "", "Filter by status")
 	exportCmd.Flags().Bool("force", false, "Force export even if database is empty")
+	exportCmd.Flags().String("shard-by", "none", "Split export into one file per shard under the -o directory: none, prefix, hash (default: export.shard_by config)")
+	exportCmd.Flags().Int("shard-digits", 1, "Leading ID hash digits per shard with --shard-by hash (1-2)")
+	exportCmd.Flags().Bool("with-config", false, "Also write all config (prefix, flags, defaults) to <output>.config.json for 'bd import --config'")
+	exportCmd.Flags().Bool("gzip", false, "Compress the output with gzip (default for -o paths ending in .gz)")
+	exportCmd.Flags().Bool("encrypt", false, "Encrypt the output with the key in $BEADS_EXPORT_KEY (default: plaintext)")
+	exportCmd.Flags().Bool("compress-descriptions", false, "Write large descriptions zstd-compressed (threshold: compression.description_threshold config, default 4096 bytes)")
 	exportCmd.Flags().BoolVar(&jsonOutput, "json", false, "Output export statistics in JSON format")
 
 	// Filter flags
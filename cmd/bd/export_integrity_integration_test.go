@@ -58,7 +58,7 @@ func TestExportIntegrityAfterJSONLTruncation(t *testing.T) {
 	}
 	
 	// Step 1: Export all issues
-	exportedIDs, err := writeJSONLAtomic(jsonlPath, allIssues)
+	exportedIDs, err := writeJSONLAtomic(jsonlPath, allIssues, types.TimestampRFC3339Nano)
 	if err != nil {
 		t.Fatalf("initial export failed: %v", err)
 	}
@@ -112,7 +112,7 @@ func TestExportIntegrityAfterJSONLTruncation(t *testing.T) {
 	}
 	
 	// Step 4: Export all issues again
-	exportedIDs2, err := writeJSONLAtomic(jsonlPath, allIssues)
+	exportedIDs2, err := writeJSONLAtomic(jsonlPath, allIssues, types.TimestampRFC3339Nano)
 	if err != nil {
 		t.Fatalf("second export failed: %v", err)
 	}
@@ -189,7 +189,7 @@ func TestExportIntegrityAfterJSONLDeletion(t *testing.T) {
 		t.Fatalf("failed to create issue: %v", err)
 	}
 	
-	_, err = writeJSONLAtomic(jsonlPath, []*types.Issue{issue})
+	_, err = writeJSONLAtomic(jsonlPath, []*types.Issue{issue}, types.TimestampRFC3339Nano)
 	if err != nil {
 		t.Fatalf("export failed: %v", err)
 	}
@@ -230,7 +230,7 @@ func TestExportIntegrityAfterJSONLDeletion(t *testing.T) {
 	}
 	
 	// Export again should recreate JSONL
-	_, err = writeJSONLAtomic(jsonlPath, []*types.Issue{issue})
+	_, err = writeJSONLAtomic(jsonlPath, []*types.Issue{issue}, types.TimestampRFC3339Nano)
 	if err != nil {
 		t.Fatalf("export after deletion failed: %v", err)
 	}
@@ -291,7 +291,7 @@ func TestMultipleExportsStayConsistent(t *testing.T) {
 	
 	// Export multiple times and verify consistency
 	for iteration := 0; iteration < 3; iteration++ {
-		exportedIDs, err := writeJSONLAtomic(jsonlPath, issues)
+		exportedIDs, err := writeJSONLAtomic(jsonlPath, issues, types.TimestampRFC3339Nano)
 		if err != nil {
 			t.Fatalf("export iteration %d failed: %v", iteration, err)
 		}
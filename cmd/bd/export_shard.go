@@ -0,0 +1,136 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/export"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// resolveShardMode determines whether bd export should shard its output.
+// The --shard-by flag wins; otherwise export.shard_by from config applies, but
+// only when the output is a directory (not a .jsonl file), so setting the
+// config never redirects exports aimed at a single file.
+func resolveShardMode(cmd *cobra.Command, output string) (export.ShardMode, int, error) {
+	cfg, err := export.LoadConfig(rootCtx, store, false)
+	if err != nil {
+		return export.ShardNone, 0, err
+	}
+	mode, digits := cfg.ShardBy, cfg.ShardDigits
+	explicit := cmd.Flags().Changed("shard-by")
+
+	if explicit {
+		flagMode, _ := cmd.Flags().GetString("shard-by")
+		mode = export.ShardMode(flagMode)
+		if !mode.IsValid() {
+			return export.ShardNone, 0, fmt.Errorf("invalid --shard-by %q (valid: none, prefix, hash)", flagMode)
+		}
+	}
+	if cmd.Flags().Changed("shard-digits") {
+		digits, _ = cmd.Flags().GetInt("shard-digits")
+		if digits < 1 || digits > export.MaxShardDigits {
+			return export.ShardNone, 0, fmt.Errorf("--shard-digits must be between 1 and %d", export.MaxShardDigits)
+		}
+	}
+
+	if mode == export.ShardNone {
+		return mode, digits, nil
+	}
+	if output == "" || strings.HasSuffix(output, ".jsonl") {
+		if explicit {
+			return export.ShardNone, 0, fmt.Errorf("sharded export needs -o to name a directory, got %q", output)
+		}
+		return export.ShardNone, digits, nil
+	}
+	return mode, digits, nil
+}
+
+// getIssueIDsFromShards returns the set of issue IDs across every shard in dir
+func getIssueIDsFromShards(dir string) (map[string]bool, error) {
+	paths, err := export.ShardFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[string]bool)
+	for _, path := range paths {
+		shardIDs, err := getIssueIDsFromJSONL(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		for id := range shardIDs {
+			ids[id] = true
+		}
+	}
+	return ids, nil
+}
+
+// exportShards writes issues as a sharded export under dir, applying the same
+// safety check as a single-file export: refuse (unless force) to drop issues
+// that the existing shards contain but the database does not.
+func exportShards(dir string, issues []*types.Issue, mode export.ShardMode, digits int, force bool) {
+	if err := validateExportPath(dir); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if !force {
+		existingIDs, err := getIssueIDsFromShards(dir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to read existing shards for staleness check: %v\n", err)
+		}
+		dbIDs := make(map[string]bool, len(issues))
+		for _, issue := range issues {
+			dbIDs[issue.ID] = true
+		}
+		var missingIDs []string
+		for id := range existingIDs {
+			if !dbIDs[id] {
+				missingIDs = append(missingIDs, id)
+			}
+		}
+		if len(missingIDs) > 0 {
+			sort.Strings(missingIDs)
+			fmt.Fprintf(os.Stderr, "Error: refusing to export stale database that would lose issues\n")
+			fmt.Fprintf(os.Stderr, "  Shards in %s have %d issue(s) missing from the database, e.g. %s\n", dir, len(missingIDs), missingIDs[0])
+			fmt.Fprintf(os.Stderr, "Hint: run 'bd import -i %s' first, or use --force to override\n", dir)
+			os.Exit(1)
+		}
+	}
+
+	counts, err := export.WriteShards(dir, issues, mode, digits, export.LoadTimestampFormat(rootCtx, store))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing shards: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Verify the shards contain exactly what we exported
+	writtenIDs, err := getIssueIDsFromShards(dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: Export verification failed: %v\n", err)
+		os.Exit(1)
+	}
+	if len(writtenIDs) != len(issues) {
+		fmt.Fprintf(os.Stderr, "Error: Export verification failed\n")
+		fmt.Fprintf(os.Stderr, "  Expected: %d issues\n", len(issues))
+		fmt.Fprintf(os.Stderr, "  Shards: %d issues\n", len(writtenIDs))
+		os.Exit(1)
+	}
+
+	if jsonOutput {
+		stats := map[string]interface{}{
+			"success":      true,
+			"exported":     len(issues),
+			"total_issues": len(issues),
+			"output_dir":   dir,
+			"shard_by":     string(mode),
+			"shards":       counts,
+		}
+		data, _ := json.MarshalIndent(stats, "", "  ")
+		fmt.Fprintln(os.Stderr, string(data))
+	}
+}
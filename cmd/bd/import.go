@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -14,6 +15,8 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/debug"
+	"github.com/steveyegge/beads/internal/export"
+	"github.com/steveyegge/beads/internal/importer"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/utils"
@@ -98,7 +101,16 @@ NOTE: Import requires direct database access and does not work with daemon mode.
 		force, _ := cmd.Flags().GetBool("force")
 		noGitHistory, _ := cmd.Flags().GetBool("no-git-history")
 		ignoreDeletions, _ := cmd.Flags().GetBool("ignore-deletions")
+		confirmMassDelete, _ := cmd.Flags().GetBool("confirm-mass-delete")
 		protectLeftSnapshot, _ := cmd.Flags().GetBool("protect-left-snapshot")
+		deletionMode, _ := cmd.Flags().GetString("deletion-mode")
+		configMode, _ := cmd.Flags().GetString("config")
+		preview, _ := cmd.Flags().GetBool("preview")
+
+		if !export.ConfigImportMode(configMode).IsValid() {
+			fmt.Fprintf(os.Stderr, "Error: invalid --config %q (must be none, missing, or merge)\n", configMode)
+			os.Exit(1)
+		}
 
 		// Check if stdin is being used interactively (not piped)
 		if input == "" && term.IsTerminal(int(os.Stdin.Fd())) {
@@ -113,8 +125,19 @@ NOTE: Import requires direct database access and does not work with daemon mode.
 		}
 
 		// Open input
-		in := os.Stdin
-		if input != "" {
+		var in io.Reader = os.Stdin
+		info, err := os.Stat(input)
+		isDir := input != "" && err == nil && info.IsDir()
+		if isDir {
+			// Sharded export (bd export --shard-by): read every shard file
+			shards, err := export.OpenShards(input)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error opening shard directory: %v\n", err)
+				os.Exit(1)
+			}
+			defer func() { _ = shards.Close() }()
+			in = shards
+		} else if input != "" {
 			// #nosec G304 - user-provided file path is intentional
 			f, err := os.Open(input)
 			if err != nil {
@@ -128,6 +151,19 @@ NOTE: Import requires direct database access and does not work with daemon mode.
 			}()
 			in = f
 		}
+		if !isDir {
+			// Encrypted (bd export --encrypt) and gzipped (bd export --gzip)
+			// input are detected from their magic bytes
+			var err error
+			if in, err = export.NewDecryptingReader(in, os.Getenv(export.EncryptionKeyEnv)); err != nil {
+				fmt.Fprintf(os.Stderr, "Error reading input: %v\n", err)
+				os.Exit(1)
+			}
+			if in, err = export.NewJSONLReader(in); err != nil {
+				fmt.Fprintf(os.Stderr, "Error reading input: %v\n", err)
+				os.Exit(1)
+			}
+		}
 
 		// Phase 1: Read and parse all JSONL
 		ctx := rootCtx
@@ -171,8 +207,8 @@ NOTE: Import requires direct database access and does not work with daemon mode.
 				// Re-open the input file to read the merged content
 				if input != "" {
 					// Close current file handle
-					if in != os.Stdin {
-						_ = in.Close()
+					if f, ok := in.(*os.File); ok && f != os.Stdin {
+						_ = f.Close()
 					}
 
 					// Re-open the merged file
@@ -214,6 +250,39 @@ NOTE: Import requires direct database access and does not work with daemon mode.
 			os.Exit(1)
 		}
 
+		if preview {
+			sqliteStore, ok := store.(*sqlite.SQLiteStorage)
+			if !ok {
+				fmt.Fprintf(os.Stderr, "Error: --preview requires direct database access (use --no-daemon)\n")
+				os.Exit(1)
+			}
+			result, err := sqliteStore.PreviewImportIssues(ctx, allIssues)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error previewing import: %v\n", err)
+				os.Exit(1)
+			}
+			printImportPreview(result)
+			return
+		}
+
+		// Apply the export's config section first, so an imported issue_prefix
+		// takes precedence over detecting one (see bd export --with-config)
+		if input != "" && !dryRun && configMode != string(export.ConfigImportNone) {
+			snapshot, err := export.ReadConfigSnapshot(input)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			keys, err := export.ApplyConfigSnapshot(ctx, store, snapshot, export.ConfigImportMode(configMode))
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error importing config: %v\n", err)
+				os.Exit(1)
+			}
+			if len(keys) > 0 {
+				fmt.Fprintf(os.Stderr, "✓ Imported %d config key(s): %s\n", len(keys), strings.Join(keys, ", "))
+			}
+		}
+
 		// Check if database needs initialization (prefix not set)
 		// Detect prefix from the imported issues (bd-8an fix)
 		initCtx := rootCtx
@@ -258,7 +327,19 @@ NOTE: Import requires direct database access and does not work with daemon mode.
 			ClearDuplicateExternalRefs: clearDuplicateExternalRefs,
 			OrphanHandling:             orphanHandling,
 			NoGitHistory:               noGitHistory,
+			DeletionMode:               importer.DeletionMode(deletionMode),
 			IgnoreDeletions:            ignoreDeletions,
+			AllowMassDeletion:          confirmMassDelete,
+		}
+		// Show progress for interactive imports; the line is cleared once done
+		if !jsonOutput && term.IsTerminal(int(os.Stderr.Fd())) {
+			opts.OnProgress = func(done, total int) {
+				if done < total {
+					fmt.Fprintf(os.Stderr, "\rImporting issues: %d/%d", done, total)
+				} else {
+					fmt.Fprintf(os.Stderr, "\r\033[K")
+				}
+			}
 		}
 
 		// If --protect-left-snapshot is set, read the left snapshot and build ID set
@@ -455,7 +536,7 @@ NOTE: Import requires direct database access and does not work with daemon mode.
 			fmt.Fprintf(os.Stderr, "\n=== Post-Import Duplicate Detection ===\n")
 
 			// Get all issues (fresh after import)
-			allIssues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+			allIssues, err := store.SearchIssues(ctx, "", types.IssueFilter{IncludeSnoozed: true})
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error fetching issues for deduplication: %v\n", err)
 				os.Exit(1)
@@ -780,18 +861,57 @@ func detectPrefixFromIssues(issues []*types.Issue) string {
 	return commonPrefix
 }
 
+// printImportPreview reports what an import would change (bd import --preview)
+func printImportPreview(preview sqlite.ImportPreview) {
+	if jsonOutput {
+		outputJSON(preview)
+		return
+	}
+
+	fmt.Printf("Import preview (no changes made):\n")
+	fmt.Printf("  Created:   %d\n", len(preview.Created))
+	fmt.Printf("  Updated:   %d\n", len(preview.Updated))
+	fmt.Printf("  Deleted:   %d\n", len(preview.Deleted))
+	fmt.Printf("  Unchanged: %d\n", preview.Unchanged)
+	if preview.Skipped > 0 {
+		fmt.Printf("  Skipped:   %d\n", preview.Skipped)
+	}
+	if len(preview.StatusChanges) > 0 {
+		fmt.Printf("\nStatus changes:\n")
+		for _, change := range preview.StatusChanges {
+			fmt.Printf("  %s: %s → %s  %s\n", change.IssueID, change.From, change.To, change.Title)
+		}
+	}
+	if len(preview.Cycles) > 0 {
+		fmt.Printf("\n⚠ Dependency cycles introduced:\n")
+		for _, cycle := range preview.Cycles {
+			fmt.Printf("  %s\n", strings.Join(cycle, " → "))
+		}
+	}
+	if len(preview.MissingDependencies) > 0 {
+		fmt.Printf("\n⚠ Dependencies on missing issues:\n")
+		for _, dep := range preview.MissingDependencies {
+			fmt.Printf("  %s → %s (%s)\n", dep.IssueID, dep.DependsOnID, dep.Type)
+		}
+	}
+}
+
 func init() {
-	importCmd.Flags().StringP("input", "i", "", "Input file (default: stdin)")
+	importCmd.Flags().StringP("input", "i", "", "Input file or sharded export directory (default: stdin)")
 	importCmd.Flags().BoolP("skip-existing", "s", false, "Skip existing issues instead of updating them")
 	importCmd.Flags().Bool("strict", false, "Fail on dependency errors instead of treating them as warnings")
 	importCmd.Flags().Bool("dedupe-after", false, "Detect and report content duplicates after import")
 	importCmd.Flags().Bool("dry-run", false, "Preview collision detection without making changes")
+	importCmd.Flags().Bool("preview", false, "Report what the import would create, update, delete, and break (status changes, cycles, missing dependencies) without making changes")
 	importCmd.Flags().Bool("rename-on-import", false, "Rename imported issues to match database prefix (updates all references)")
 	importCmd.Flags().Bool("clear-duplicate-external-refs", false, "Clear duplicate external_ref values (keeps first occurrence)")
 	importCmd.Flags().String("orphan-handling", "", "How to handle missing parent issues: strict/resurrect/skip/allow (default: use config or 'allow')")
 	importCmd.Flags().Bool("force", false, "Force metadata update even when database is already in sync with JSONL")
 	importCmd.Flags().Bool("no-git-history", false, "Skip git history backfill for deletions (use during JSONL filename migrations)")
 	importCmd.Flags().Bool("ignore-deletions", false, "Import issues even if they're in the deletions manifest")
+	importCmd.Flags().Bool("confirm-mass-delete", false, "Proceed even if the import would delete more issues than import.max_delete_percent allows")
+	importCmd.Flags().String("deletion-mode", "", "How deletions are detected: tombstone-only/infer-from-absence (default: infer-from-absence)")
+	importCmd.Flags().String("config", "none", "Apply the config section written by 'bd export --with-config': none, missing (only unset keys), merge (overwrite)")
 	importCmd.Flags().Bool("protect-left-snapshot", false, "Protect issues in left snapshot from git-history-backfill (bd-sync-deletion fix)")
 	importCmd.Flags().BoolVar(&jsonOutput, "json", false, "Output import statistics in JSON format")
 	rootCmd.AddCommand(importCmd)
@@ -168,6 +168,9 @@ type ImportOptions struct {
 	NoGitHistory               bool              // Skip git history backfill for deletions (prevents spurious deletion during JSONL migrations)
 	IgnoreDeletions            bool              // Import issues even if they're in the deletions manifest
 	ProtectLocalExportIDs      map[string]bool   // IDs from left snapshot to protect from git-history-backfill (bd-sync-deletion fix)
+	DeletionMode               importer.DeletionMode // How deletions are detected (empty = importer.InferFromAbsence)
+	OnProgress                 func(done, total int) // Called as issues are imported (see importer.Options.OnProgress)
+	AllowMassDeletion          bool                  // Proceed even if the import would delete most issues (see importer.ErrMassDeletionGuard)
 }
 
 // ImportResult contains statistics about the import operation
@@ -231,6 +234,9 @@ func importIssuesCore(ctx context.Context, dbPath string, store storage.Storage,
 		NoGitHistory:               opts.NoGitHistory,
 		IgnoreDeletions:            opts.IgnoreDeletions,
 		ProtectLocalExportIDs:      opts.ProtectLocalExportIDs,
+		DeletionMode:               opts.DeletionMode,
+		OnProgress:                 opts.OnProgress,
+		AllowMassDeletion:          opts.AllowMassDeletion,
 	}
 
 	// Delegate to the importer package
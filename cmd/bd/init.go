@@ -108,7 +108,7 @@ With --stealth: configures global git settings for invisible beads usage:
 				fmt.Fprintf(os.Stderr, "Error: failed to get current directory: %v\n", err)
 				os.Exit(1)
 			}
-			prefix = filepath.Base(cwd)
+			prefix = prefixFromDirName(filepath.Base(cwd))
 		}
 
 		// Normalize prefix: strip trailing hyphens
@@ -160,7 +160,7 @@ func TestInitCommand(t *testing.T) {
 
 			expectedPrefix := tt.prefix
 			if expectedPrefix == "" {
-				expectedPrefix = filepath.Base(tmpDir)
+				expectedPrefix = prefixFromDirName(filepath.Base(tmpDir))
 			} else {
 				expectedPrefix = strings.TrimRight(expectedPrefix, "-")
 			}
@@ -401,7 +401,7 @@ func countDBIssuesFast(ctx context.Context, store storage.Storage) (int, error)
 	}
 
 	// Fallback: load all issues and count them (slow but always works)
-	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{IncludeSnoozed: true})
 	if err != nil {
 		return 0, fmt.Errorf("failed to count database issues: %w", err)
 	}
@@ -458,7 +458,7 @@ func dbNeedsExport(ctx context.Context, store storage.Storage, jsonlPath string)
 // This is used to compare DB content with JSONL content without relying on timestamps.
 func computeDBHash(ctx context.Context, store storage.Storage) (string, error) {
 	// Get all issues from DB
-	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{IncludeSnoozed: true})
 	if err != nil {
 		return "", fmt.Errorf("failed to get issues: %w", err)
 	}
@@ -495,6 +495,11 @@ func computeDBHash(ctx context.Context, store storage.Storage) (string, error) {
 		issue.Comments = comments
 	}
 
+	// Populate links
+	if err := populateLinks(ctx, store, issues); err != nil {
+		return "", err
+	}
+
 	// Serialize to JSON and hash
 	var buf bytes.Buffer
 	encoder := json.NewEncoder(&buf)
@@ -262,7 +262,7 @@ var jiraStatusCmd = &cobra.Command{
 		configured := jiraURL != "" && jiraProject != ""
 
 		// Count issues with Jira links
-		allIssues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+		allIssues, err := store.SearchIssues(ctx, "", types.IssueFilter{IncludeSnoozed: true})
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
@@ -472,7 +472,7 @@ func doPushToJira(ctx context.Context, dryRun bool, createOnly bool, updateRefs
 	}
 
 	// Get all issues
-	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{IncludeSnoozed: true})
 	if err != nil {
 		return stats, fmt.Errorf("failed to get issues: %w", err)
 	}
@@ -610,7 +610,7 @@ func detectJiraConflicts(ctx context.Context) ([]JiraConflict, error) {
 	}
 
 	// Get all issues with Jira refs that were updated since last sync
-	allIssues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	allIssues, err := store.SearchIssues(ctx, "", types.IssueFilter{IncludeSnoozed: true})
 	if err != nil {
 		return nil, err
 	}
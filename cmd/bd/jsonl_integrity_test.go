@@ -61,7 +61,7 @@ func TestJSONLIntegrityValidation(t *testing.T) {
 	
 	// Export to JSONL
 	issues := []*types.Issue{issue}
-	exportedIDs, err := writeJSONLAtomic(jsonlPath, issues)
+	exportedIDs, err := writeJSONLAtomic(jsonlPath, issues, types.TimestampRFC3339Nano)
 	if err != nil {
 		t.Fatalf("failed to write JSONL: %v", err)
 	}
@@ -246,7 +246,7 @@ var labelListAllCmd = &cobra.Command{
 			}
 		} else {
 			// Direct mode
-			issues, err = store.SearchIssues(ctx, "", types.IssueFilter{})
+			issues, err = store.SearchIssues(ctx, "", types.IssueFilter{IncludeSnoozed: true})
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 				os.Exit(1)
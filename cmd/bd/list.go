@@ -101,6 +101,7 @@ var listCmd = &cobra.Command{
 		formatStr, _ := cmd.Flags().GetString("format")
 		labels, _ := cmd.Flags().GetStringSlice("label")
 		labelsAny, _ := cmd.Flags().GetStringSlice("label-any")
+		labelGlobs, _ := cmd.Flags().GetStringSlice("label-glob")
 		titleSearch, _ := cmd.Flags().GetString("title")
 		idFilter, _ := cmd.Flags().GetString("id")
 		longFormat, _ := cmd.Flags().GetBool("long")
@@ -124,6 +125,11 @@ var listCmd = &cobra.Command{
 		emptyDesc, _ := cmd.Flags().GetBool("empty-description")
 		noAssignee, _ := cmd.Flags().GetBool("no-assignee")
 		noLabels, _ := cmd.Flags().GetBool("no-labels")
+		isRoot, _ := cmd.Flags().GetBool("root")
+		isLeaf, _ := cmd.Flags().GetBool("leaf")
+		includeSnoozed, _ := cmd.Flags().GetBool("include-snoozed")
+		olderThan, _ := cmd.Flags().GetString("older-than")
+		stalerThan, _ := cmd.Flags().GetString("staler-than")
 		
 		// Priority range flags
 		priorityMinStr, _ := cmd.Flags().GetString("priority-min")
@@ -134,6 +140,7 @@ var listCmd = &cobra.Command{
 		// Normalize labels: trim, dedupe, remove empty
 		labels = util.NormalizeLabels(labels)
 	labelsAny = util.NormalizeLabels(labelsAny)
+		labelGlobs = util.NormalizeLabels(labelGlobs)
 
 		filter := types.IssueFilter{
 			Limit: limit,
@@ -165,6 +172,9 @@ var listCmd = &cobra.Command{
 		if len(labelsAny) > 0 {
 			filter.LabelsAny = labelsAny
 		}
+		if len(labelGlobs) > 0 {
+			filter.LabelGlobs = labelGlobs
+		}
 		if titleSearch != "" {
 			filter.TitleSearch = titleSearch
 		}
@@ -246,6 +256,27 @@ var listCmd = &cobra.Command{
 		if noLabels {
 			filter.NoLabels = true
 		}
+		filter.IsRoot = isRoot
+		filter.IsLeaf = isLeaf
+		filter.IncludeSnoozed = includeSnoozed
+
+		// Age ranges
+		if olderThan != "" {
+			d, err := parseDuration(olderThan)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error parsing --older-than: %v\n", err)
+				os.Exit(1)
+			}
+			filter.OlderThan = d
+		}
+		if stalerThan != "" {
+			d, err := parseDuration(stalerThan)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error parsing --staler-than: %v\n", err)
+				os.Exit(1)
+			}
+			filter.StalerThan = d
+		}
 		
 		// Priority ranges
 		if cmd.Flags().Changed("priority-min") {
@@ -298,6 +329,9 @@ var listCmd = &cobra.Command{
 			if len(labelsAny) > 0 {
 				listArgs.LabelsAny = labelsAny
 			}
+			if len(labelGlobs) > 0 {
+				listArgs.LabelGlobs = labelGlobs
+			}
 			// Forward title search via Query field (searches title/description/id)
 			if titleSearch != "" {
 			 listArgs.Query = titleSearch
@@ -335,6 +369,11 @@ var listCmd = &cobra.Command{
 			listArgs.EmptyDescription = filter.EmptyDescription
 			listArgs.NoAssignee = filter.NoAssignee
 			listArgs.NoLabels = filter.NoLabels
+			listArgs.IsRoot = filter.IsRoot
+			listArgs.IsLeaf = filter.IsLeaf
+			listArgs.IncludeSnoozed = filter.IncludeSnoozed
+			listArgs.OlderThan = filter.OlderThan
+			listArgs.StalerThan = filter.StalerThan
 			
 			// Priority range
 			listArgs.PriorityMin = filter.PriorityMin
@@ -449,6 +488,7 @@ var listCmd = &cobra.Command{
 			}
 
 			// Build response with counts
+			now := time.Now()
 			issuesWithCounts := make([]*types.IssueWithCounts, len(issues))
 			for i, issue := range issues {
 				counts := depCounts[issue.ID]
@@ -459,6 +499,8 @@ var listCmd = &cobra.Command{
 					Issue:           issue,
 					DependencyCount: counts.DependencyCount,
 					DependentCount:  counts.DependentCount,
+					AgeDays:         issue.AgeDays(now),
+					StaleDays:       issue.StaleDays(now),
 				}
 			}
 			outputJSON(issuesWithCounts)
@@ -522,6 +564,7 @@ func init() {
 	listCmd.Flags().StringP("type", "t", "", "Filter by type (bug, feature, task, epic, chore)")
 	listCmd.Flags().StringSliceP("label", "l", []string{}, "Filter by labels (AND: must have ALL). Can combine with --label-any")
 	listCmd.Flags().StringSlice("label-any", []string{}, "Filter by labels (OR: must have AT LEAST ONE). Can combine with --label")
+	listCmd.Flags().StringSlice("label-glob", []string{}, "Filter by label patterns, e.g. 'area/*' (AND: must match ALL). Can combine with --label")
 	listCmd.Flags().String("title", "", "Filter by title text (case-insensitive substring match)")
 	listCmd.Flags().String("id", "", "Filter by specific issue IDs (comma-separated, e.g., bd-1,bd-5,bd-10)")
 	listCmd.Flags().IntP("limit", "n", 0, "Limit results")
@@ -548,6 +591,13 @@ func init() {
 	listCmd.Flags().Bool("empty-description", false, "Filter issues with empty or missing description")
 	listCmd.Flags().Bool("no-assignee", false, "Filter issues with no assignee")
 	listCmd.Flags().Bool("no-labels", false, "Filter issues with no labels")
+	listCmd.Flags().Bool("root", false, "Filter issues that depend on nothing")
+	listCmd.Flags().Bool("leaf", false, "Filter issues that nothing depends on")
+	listCmd.Flags().Bool("include-snoozed", false, "Include snoozed issues (hidden unless --status snoozed)")
+
+	// Age ranges
+	listCmd.Flags().String("older-than", "", "Filter issues created longer ago than this (e.g., 30d, 2w, 12h)")
+	listCmd.Flags().String("staler-than", "", "Filter issues not updated for longer than this (e.g., 30d, 2w, 12h)")
 	
 	// Priority ranges
 	listCmd.Flags().String("priority-min", "", "Filter by minimum priority (inclusive, 0-4 or P0-P4)")
@@ -624,8 +674,13 @@ func outputDotFormat(ctx context.Context, store storage.Storage, issues []*types
 					color = "gray"
 					style = "dashed"
 				}
+				// Append the edge's note, if any, beneath its type
+				label := string(dep.Type)
+				if dep.Note != "" {
+					label += "\n" + dep.Note
+				}
 				fmt.Printf("  %q -> %q [label=%q, color=%s, style=%s];\n",
-					issue.ID, dep.DependsOnID, dep.Type, color, style)
+					issue.ID, dep.DependsOnID, label, color, style)
 			}
 		}
 	}
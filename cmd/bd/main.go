@@ -21,7 +21,6 @@ import (
 	"github.com/steveyegge/beads/internal/rpc"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/memory"
-	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/utils"
 )
 
@@ -117,7 +116,7 @@ func init() {
 	}
 
 	// Register persistent flags
-	rootCmd.PersistentFlags().StringVar(&dbPath, "db", "", "Database path (default: auto-discover .beads/*.db)")
+	rootCmd.PersistentFlags().StringVar(&dbPath, "db", "", "Database path or connection string, e.g. sqlite://path or postgres://host/db (default: auto-discover .beads/*.db)")
 	rootCmd.PersistentFlags().StringVar(&actor, "actor", "", "Actor name for audit trail (default: $BD_ACTOR or $USER)")
 	rootCmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
 	rootCmd.PersistentFlags().BoolVar(&noDaemon, "no-daemon", false, "Force direct storage mode, bypass daemon if running")
@@ -186,6 +185,11 @@ var rootCmd = &cobra.Command{
 		if !cmd.Flags().Changed("db") && dbPath == "" {
 			dbPath = config.GetString("db")
 		}
+		// --db also takes a connection string (sqlite:// or postgres://)
+		if err := resolveDBConn(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid --db: %v\n", err)
+			os.Exit(1)
+		}
 		if !cmd.Flags().Changed("actor") && actor == "" {
 			actor = config.GetString("actor")
 		}
@@ -297,6 +301,12 @@ var rootCmd = &cobra.Command{
 			return
 		}
 
+		// A database server is used directly, without daemon or local files
+		if dbConn != "" {
+			initServerStore()
+			return
+		}
+
 		// Initialize database path
 		if dbPath == "" {
 			// Use public API to find database (same logic as extensions)
@@ -566,7 +576,7 @@ var rootCmd = &cobra.Command{
 
 		// Fall back to direct storage access
 		var err error
-		store, err = sqlite.NewWithTimeout(rootCtx, dbPath, lockTimeout)
+		store, err = openStore(rootCtx)
 		if err != nil {
 			// Check for fresh clone scenario (bd-dmb)
 			beadsDir := filepath.Dir(dbPath)
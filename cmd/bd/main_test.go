@@ -291,6 +291,78 @@ func TestAutoFlushJSONLContent(t *testing.T) {
 	storeMutex.Unlock()
 }
 
+// TestAutoFlushOmitsLocalFields verifies the incremental flush leaves out
+// fields that are local to the database, like the full export does
+func TestAutoFlushOmitsLocalFields(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	oldRootCtx := rootCtx
+	rootCtx = ctx
+	defer func() { rootCtx = oldRootCtx }()
+
+	tmpDir := t.TempDir()
+	dbPath = filepath.Join(tmpDir, "test.db")
+	jsonlPath := filepath.Join(tmpDir, "issues.jsonl")
+
+	testStore := newTestStore(t, dbPath)
+	store = testStore
+	storeMutex.Lock()
+	storeActive = true
+	storeMutex.Unlock()
+	defer func() {
+		storeMutex.Lock()
+		storeActive = false
+		storeMutex.Unlock()
+	}()
+
+	issue := &types.Issue{
+		ID:        "test-local-1",
+		Title:     "Watched issue",
+		Status:    types.StatusOpen,
+		Priority:  1,
+		IssueType: types.TypeTask,
+	}
+	if err := testStore.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("Failed to create issue: %v", err)
+	}
+	if err := testStore.WatchIssue(ctx, issue.ID, "alice"); err != nil {
+		t.Fatalf("Failed to watch issue: %v", err)
+	}
+	if err := testStore.SetSection(ctx, issue.ID, "summary", "Why it matters", "test"); err != nil {
+		t.Fatalf("Failed to set section: %v", err)
+	}
+	if err := testStore.MarkIssueDirty(ctx, issue.ID); err != nil {
+		t.Fatalf("Failed to mark issue dirty: %v", err)
+	}
+
+	flushMutex.Lock()
+	isDirty = true
+	flushMutex.Unlock()
+
+	flushToJSONL()
+
+	data, err := os.ReadFile(jsonlPath)
+	if err != nil {
+		t.Fatalf("Failed to read JSONL: %v", err)
+	}
+	var line map[string]interface{}
+	if err := json.Unmarshal(bytes.TrimSpace(data), &line); err != nil {
+		t.Fatalf("Failed to parse JSONL: %v", err)
+	}
+	if line["id"] != issue.ID {
+		t.Fatalf("Expected %s in JSONL, got %v", issue.ID, line["id"])
+	}
+	if desc, _ := line["description"].(string); !strings.Contains(desc, "Why it matters") {
+		t.Errorf("Expected the sections to be flushed as the description, got %q", desc)
+	}
+	for _, field := range []string{"watchers", "sections", "version"} {
+		if _, ok := line[field]; ok {
+			t.Errorf("Expected %q to be left out of the flushed JSONL, got %v", field, line[field])
+		}
+	}
+}
+
 // TestAutoImportIfNewer tests that auto-import triggers when JSONL is newer than DB
 func TestAutoImportIfNewer(t *testing.T) {
 	// FIX: Initialize rootCtx for auto-import operations
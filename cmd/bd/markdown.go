@@ -333,6 +333,7 @@ func createIssuesFromMarkdown(_ *cobra.Command, filepath string) {
 			Priority:           template.Priority,
 			IssueType:          template.IssueType,
 			Assignee:           template.Assignee,
+			Labels:             template.Labels,
 		}
 
 		if err := store.CreateIssue(ctx, issue, actor); err != nil {
@@ -341,13 +342,6 @@ func createIssuesFromMarkdown(_ *cobra.Command, filepath string) {
 			continue
 		}
 
-		// Add labels
-		for _, label := range template.Labels {
-			if err := store.AddLabel(ctx, issue.ID, label, actor); err != nil {
-				fmt.Fprintf(os.Stderr, "Warning: failed to add label %s to %s: %v\n", label, issue.ID, err)
-			}
-		}
-
 		// Add dependencies
 		for _, depSpec := range template.Dependencies {
 			depSpec = strings.TrimSpace(depSpec)
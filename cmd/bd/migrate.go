@@ -31,6 +31,7 @@ This command:
 - Updates schema version metadata
 - Migrates sequential IDs to hash-based IDs (with --to-hash-ids)
 - Enables separate branch workflow (with --to-separate-branch)
+- Re-applies compression.description_threshold to stored descriptions (with --recompress-descriptions)
 - Removes stale databases (with confirmation)`,
 	Run: func(cmd *cobra.Command, _ []string) {
 		autoYes, _ := cmd.Flags().GetBool("yes")
@@ -40,6 +41,7 @@ This command:
 		toHashIDs, _ := cmd.Flags().GetBool("to-hash-ids")
 		inspect, _ := cmd.Flags().GetBool("inspect")
 		toSeparateBranch, _ := cmd.Flags().GetString("to-separate-branch")
+		recompress, _ := cmd.Flags().GetBool("recompress-descriptions")
 
 		// Block writes in readonly mode (migration modifies data, --inspect is read-only)
 		if !dryRun && !inspect {
@@ -52,6 +54,12 @@ This command:
 			return
 		}
 
+		// Handle --recompress-descriptions
+		if recompress {
+			handleRecompressDescriptions()
+			return
+		}
+
 		// Handle --inspect flag (show migration plan for AI agents)
 		if inspect {
 			handleInspect()
@@ -288,7 +296,7 @@ This command:
 			prefix, err := store.GetConfig(ctx, "issue_prefix")
 			if err != nil || prefix == "" {
 				// Get first issue to detect prefix
-				issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+				issues, err := store.SearchIssues(ctx, "", types.IssueFilter{IncludeSnoozed: true})
 				if err == nil && len(issues) > 0 {
 					detectedPrefix := utils.ExtractIssuePrefix(issues[0].ID)
 					if detectedPrefix != "" {
@@ -395,7 +403,7 @@ This command:
 			}
 			
 			ctx := rootCtx
-			issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+			issues, err := store.SearchIssues(ctx, "", types.IssueFilter{IncludeSnoozed: true})
 			if err != nil {
 			_ = store.Close()
 			if jsonOutput {
@@ -578,6 +586,60 @@ func formatDBList(dbs []*dbInfo) []map[string]string {
 	return result
 }
 
+// handleRecompressDescriptions compresses or decompresses existing descriptions
+// after compression.description_threshold has changed
+func handleRecompressDescriptions() {
+	foundDB := beads.FindDatabasePath()
+	if foundDB == "" {
+		if jsonOutput {
+			outputJSON(map[string]interface{}{
+				"error":   "no_database",
+				"message": "No beads database found. Run 'bd init' first.",
+			})
+		} else {
+			fmt.Fprintf(os.Stderr, "Error: no beads database found\n")
+			fmt.Fprintf(os.Stderr, "Hint: run 'bd init' to initialize bd\n")
+		}
+		os.Exit(1)
+	}
+
+	store, err := sqlite.New(rootCtx, foundDB)
+	if err != nil {
+		if jsonOutput {
+			outputJSON(map[string]interface{}{
+				"error":   "open_failed",
+				"message": err.Error(),
+			})
+		} else {
+			fmt.Fprintf(os.Stderr, "Error: failed to open database: %v\n", err)
+		}
+		os.Exit(1)
+	}
+	defer func() { _ = store.Close() }()
+
+	changed, err := store.RecompressDescriptions(rootCtx)
+	if err != nil {
+		if jsonOutput {
+			outputJSON(map[string]interface{}{
+				"error":   "recompress_failed",
+				"message": err.Error(),
+			})
+		} else {
+			fmt.Fprintf(os.Stderr, "Error: failed to recompress descriptions: %v\n", err)
+		}
+		os.Exit(1)
+	}
+
+	if jsonOutput {
+		outputJSON(map[string]interface{}{
+			"status":    "success",
+			"rewritten": changed,
+		})
+	} else {
+		color.Green("✓ Rewrote %d description(s)\n", changed)
+	}
+}
+
 func handleUpdateRepoID(dryRun bool, autoYes bool) {
 	// Find database
 	foundDB := beads.FindDatabasePath()
@@ -841,7 +903,7 @@ func handleInspect() {
 		missingConfig = append(missingConfig, "issue_prefix")
 	}
 
-	// Get registered migrations (all migrations are idempotent and run on every open)
+	// Get registered migrations (applied versions are recorded in schema_migrations)
 	registeredMigrations := sqlite.ListMigrations()
 	
 	// Build invariants list
@@ -852,7 +914,7 @@ func handleInspect() {
 	if issueCount > 0 && prefix == "" {
 		// Detect prefix from first issue (efficient query for just 1 issue)
 		detectedPrefix := ""
-		if issues, err := store.SearchIssues(ctx, "", types.IssueFilter{}); err == nil && len(issues) > 0 {
+		if issues, err := store.SearchIssues(ctx, "", types.IssueFilter{IncludeSnoozed: true}); err == nil && len(issues) > 0 {
 			detectedPrefix = utils.ExtractIssuePrefix(issues[0].ID)
 		}
 		warnings = append(warnings, fmt.Sprintf("issue_prefix config not set - may break commands after migration (detected: %s)", detectedPrefix))
@@ -1064,6 +1126,7 @@ func init() {
 	migrateCmd.Flags().Bool("to-hash-ids", false, "Migrate sequential IDs to hash-based IDs")
 	migrateCmd.Flags().Bool("inspect", false, "Show migration plan and database state for AI agent analysis")
 	migrateCmd.Flags().String("to-separate-branch", "", "Enable separate branch workflow (e.g., 'beads-metadata')")
+	migrateCmd.Flags().Bool("recompress-descriptions", false, "Rewrite stored descriptions to match compression.description_threshold")
 	migrateCmd.Flags().BoolVar(&jsonOutput, "json", false, "Output migration statistics in JSON format")
 	rootCmd.AddCommand(migrateCmd)
 }
@@ -106,7 +106,7 @@ WARNING: Backup your database before running this command, even though it create
 		defer func() { _ = store.Close() }()
 		
 		// Get all issues using SearchIssues with empty query and no filters
-		issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+		issues, err := store.SearchIssues(ctx, "", types.IssueFilter{IncludeSnoozed: true})
 		if err != nil {
 			if jsonOutput {
 				outputJSON(map[string]interface{}{
@@ -9,6 +9,7 @@ import (
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/deletions"
+	"github.com/steveyegge/beads/internal/export"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -205,7 +206,7 @@ Examples:
 		}
 		defer file.Close()
 
-		encoder := json.NewEncoder(file)
+		encoder := export.NewIssueEncoder(file, jsonlTimestampFormat())
 		var migratedIDs []string
 		for _, record := range toMigrate {
 			tombstone := convertDeletionRecordToTombstone(record)
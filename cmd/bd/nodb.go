@@ -10,6 +10,8 @@ import (
 
 	"github.com/steveyegge/beads/internal/config"
 	"github.com/steveyegge/beads/internal/debug"
+	"github.com/steveyegge/beads/internal/export"
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/memory"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/utils"
@@ -157,9 +159,16 @@ func detectPrefix(_ string, memStore *memory.MemoryStorage) (string, error) {
 		return "bd", nil // Ultimate fallback
 	}
 
-	prefix := filepath.Base(cwd)
+	return prefixFromDirName(filepath.Base(cwd)), nil
+}
+
+// prefixFromDirName derives an issue prefix from a directory name: lowercase
+// letters, digits and single hyphens, starting with a letter and cut to
+// storage.MaxIssuePrefixLen. Names that leave nothing valid (e.g. "001")
+// fall back to "bd".
+func prefixFromDirName(name string) string {
 	// Sanitize prefix (remove special characters, use only alphanumeric and hyphens)
-	prefix = strings.Map(func(r rune) rune {
+	prefix := strings.Map(func(r rune) rune {
 		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' {
 			return r
 		}
@@ -167,13 +176,20 @@ func detectPrefix(_ string, memStore *memory.MemoryStorage) (string, error) {
 			return r + ('a' - 'A') // Convert to lowercase
 		}
 		return -1 // Remove character
-	}, prefix)
-
-	if prefix == "" {
-		prefix = "bd"
+	}, name)
+	for strings.Contains(prefix, "--") {
+		prefix = strings.ReplaceAll(prefix, "--", "-")
 	}
+	prefix = strings.TrimLeft(prefix, "0123456789-")
+	if len(prefix) > storage.MaxIssuePrefixLen {
+		prefix = prefix[:storage.MaxIssuePrefixLen]
+	}
+	prefix = strings.TrimRight(prefix, "-")
 
-	return prefix, nil
+	if storage.ValidateIssuePrefix(prefix) != nil {
+		return "bd"
+	}
+	return prefix
 }
 
 // extractIssuePrefix extracts the prefix from an issue ID like "bd-123" -> "bd"
@@ -214,7 +230,7 @@ func writeIssuesToJSONL(memStore *memory.MemoryStorage, beadsDir string) error {
 	issues := memStore.GetAllIssues()
 
 	// Write atomically using common helper (handles temp file + rename + permissions)
-	if _, err := writeJSONLAtomic(jsonlPath, issues); err != nil {
+	if _, err := writeJSONLAtomic(jsonlPath, issues, export.LoadTimestampFormat(rootCtx, memStore)); err != nil {
 		return err
 	}
 
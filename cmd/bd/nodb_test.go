@@ -158,6 +158,23 @@ func TestDetectPrefix(t *testing.T) {
 	})
 }
 
+func TestPrefixFromDirName(t *testing.T) {
+	tests := map[string]string{
+		"myproject":                  "myproject",
+		"My_Project":                 "myproject",
+		"beads-vscode":               "beads-vscode",
+		"2024--plans":                "plans",
+		"a-really-long-project-name": "a-really-long-projec",
+		"001":                        "bd",
+		"":                           "bd",
+	}
+	for name, want := range tests {
+		if got := prefixFromDirName(name); got != want {
+			t.Errorf("prefixFromDirName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
 func TestWriteIssuesToJSONL(t *testing.T) {
 	tempDir := t.TempDir()
 	beadsDir := filepath.Join(tempDir, ".beads")
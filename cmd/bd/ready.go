@@ -20,6 +20,7 @@ var readyCmd = &cobra.Command{
 		sortPolicy, _ := cmd.Flags().GetString("sort")
 		labels, _ := cmd.Flags().GetStringSlice("label")
 		labelsAny, _ := cmd.Flags().GetStringSlice("label-any")
+		excludeClaimed, _ := cmd.Flags().GetBool("exclude-claimed")
 		// Use global jsonOutput set by PersistentPreRun (respects config.yaml + env vars)
 
 		// Normalize labels: trim, dedupe, remove empty
@@ -28,11 +29,12 @@ var readyCmd = &cobra.Command{
 
 		filter := types.WorkFilter{
 			// Leave Status empty to get both 'open' and 'in_progress' (bd-165)
-			Limit:      limit,
-			Unassigned: unassigned,
-			SortPolicy: types.SortPolicy(sortPolicy),
-			Labels:     labels,
-			LabelsAny:  labelsAny,
+			Limit:          limit,
+			Unassigned:     unassigned,
+			SortPolicy:     types.SortPolicy(sortPolicy),
+			Labels:         labels,
+			LabelsAny:      labelsAny,
+			ExcludeClaimed: excludeClaimed,
 		}
 		// Use Changed() to properly handle P0 (priority=0)
 		if cmd.Flags().Changed("priority") {
@@ -44,18 +46,19 @@ var readyCmd = &cobra.Command{
 		}
 		// Validate sort policy
 		if !filter.SortPolicy.IsValid() {
-			fmt.Fprintf(os.Stderr, "Error: invalid sort policy '%s'. Valid values: hybrid, priority, oldest\n", sortPolicy)
+			fmt.Fprintf(os.Stderr, "Error: invalid sort policy '%s'. Valid values: hybrid, priority, oldest, ready_score\n", sortPolicy)
 			os.Exit(1)
 		}
 		// If daemon is running, use RPC
 		if daemonClient != nil {
 			readyArgs := &rpc.ReadyArgs{
-				Assignee:   assignee,
-				Unassigned: unassigned,
-				Limit:      limit,
-				SortPolicy: sortPolicy,
-				Labels:     labels,
-				LabelsAny:  labelsAny,
+				Assignee:       assignee,
+				Unassigned:     unassigned,
+				Limit:          limit,
+				SortPolicy:     sortPolicy,
+				Labels:         labels,
+				LabelsAny:      labelsAny,
+				ExcludeClaimed: excludeClaimed,
 			}
 			if cmd.Flags().Changed("priority") {
 				priority, _ := cmd.Flags().GetInt("priority")
@@ -112,6 +115,9 @@ var readyCmd = &cobra.Command{
 				if issue.Assignee != "" {
 					fmt.Printf("   Assignee: %s\n", issue.Assignee)
 				}
+				if issue.ReadyScore != nil {
+					fmt.Printf("   Score: %.1f\n", *issue.ReadyScore)
+				}
 			}
 			fmt.Println()
 			return
@@ -183,6 +189,9 @@ var readyCmd = &cobra.Command{
 			if issue.Assignee != "" {
 				fmt.Printf("   Assignee: %s\n", issue.Assignee)
 			}
+			if issue.ReadyScore != nil {
+				fmt.Printf("   Score: %.1f\n", *issue.ReadyScore)
+			}
 		}
 		fmt.Println()
 
@@ -234,6 +243,9 @@ var blockedCmd = &cobra.Command{
 			}
 			fmt.Printf("  Blocked by %d open dependencies: %v\n",
 				issue.BlockedByCount, blockedBy)
+			if issue.ExternalBlockedReason != nil {
+				fmt.Printf("  Blocked externally: %s\n", *issue.ExternalBlockedReason)
+			}
 			fmt.Println()
 		}
 	},
@@ -327,9 +339,10 @@ func init() {
 	readyCmd.Flags().IntP("priority", "p", 0, "Filter by priority")
 	readyCmd.Flags().StringP("assignee", "a", "", "Filter by assignee")
 	readyCmd.Flags().BoolP("unassigned", "u", false, "Show only unassigned issues")
-	readyCmd.Flags().StringP("sort", "s", "hybrid", "Sort policy: hybrid (default), priority, oldest")
+	readyCmd.Flags().StringP("sort", "s", "hybrid", "Sort policy: hybrid (default), priority, oldest, ready_score (weighted by priority, age and issues blocked)")
 	readyCmd.Flags().StringSliceP("label", "l", []string{}, "Filter by labels (AND: must have ALL). Can combine with --label-any")
 	readyCmd.Flags().StringSlice("label-any", []string{}, "Filter by labels (OR: must have AT LEAST ONE). Can combine with --label")
+	readyCmd.Flags().Bool("exclude-claimed", false, "Hide issues currently claimed by an agent")
 	rootCmd.AddCommand(readyCmd)
 	rootCmd.AddCommand(blockedCmd)
 	rootCmd.AddCommand(statsCmd)
@@ -86,7 +86,7 @@ NOTE: This is a rare operation. Most users never need this command.`,
 		newPrefix = strings.TrimRight(newPrefix, "-")
 
 		// Check for multiple prefixes first
-		issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+		issues, err := store.SearchIssues(ctx, "", types.IssueFilter{IncludeSnoozed: true})
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: failed to list issues: %v\n", err)
 			os.Exit(1)
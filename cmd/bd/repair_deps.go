@@ -44,7 +44,7 @@ Interactive mode with --interactive prompts for each orphan.`,
 		}
 
 		// Get all issues to check existence
-		issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+		issues, err := store.SearchIssues(ctx, "", types.IssueFilter{IncludeSnoozed: true})
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: failed to list issues: %v\n", err)
 			os.Exit(1)
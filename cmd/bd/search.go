@@ -298,6 +298,7 @@ Examples:
 			}
 
 			// Build response with counts
+			now := time.Now()
 			issuesWithCounts := make([]*types.IssueWithCounts, len(issues))
 			for i, issue := range issues {
 				counts := depCounts[issue.ID]
@@ -308,6 +309,8 @@ Examples:
 					Issue:           issue,
 					DependencyCount: counts.DependencyCount,
 					DependentCount:  counts.DependentCount,
+					AgeDays:         issue.AgeDays(now),
+					StaleDays:       issue.StaleDays(now),
 				}
 			}
 			outputJSON(issuesWithCounts)
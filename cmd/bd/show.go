@@ -923,6 +923,7 @@ var closeCmd = &cobra.Command{
 			reason = "Closed"
 		}
 		jsonOutput, _ := cmd.Flags().GetBool("json")
+		force, _ := cmd.Flags().GetBool("force")
 
 		ctx := rootCtx
 
@@ -959,6 +960,7 @@ var closeCmd = &cobra.Command{
 				closeArgs := &rpc.CloseArgs{
 					ID:     id,
 					Reason: reason,
+					Force:  force,
 				}
 				resp, err := daemonClient.CloseIssue(closeArgs)
 				if err != nil {
@@ -984,6 +986,9 @@ var closeCmd = &cobra.Command{
 		}
 
 		// Direct mode
+		if force {
+			ctx = sqlite.WithForceClose(ctx)
+		}
 		closedIssues := []*types.Issue{}
 		for _, id := range resolvedIDs {
 			if err := store.CloseIssue(ctx, id, reason, actor); err != nil {
@@ -1040,5 +1045,6 @@ func init() {
 
 	closeCmd.Flags().StringP("reason", "r", "", "Reason for closing")
 	closeCmd.Flags().Bool("json", false, "Output JSON format")
+	closeCmd.Flags().Bool("force", false, "Close even if open children remain (when close.require_closed_children is set)")
 	rootCmd.AddCommand(closeCmd)
 }
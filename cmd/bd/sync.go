@@ -19,8 +19,10 @@ import (
 	"github.com/steveyegge/beads/internal/configfile"
 	"github.com/steveyegge/beads/internal/debug"
 	"github.com/steveyegge/beads/internal/deletions"
+	"github.com/steveyegge/beads/internal/export"
 	"github.com/steveyegge/beads/internal/git"
 	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/syncbranch"
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -1279,9 +1281,11 @@ func exportToJSONL(ctx context.Context, jsonlPath string) error {
 	if err := ensureStoreActive(); err != nil {
 		return fmt.Errorf("failed to initialize store: %w", err)
 	}
+	// The JSONL is shared through git, so private issues stay out of it
+	ctx = sqlite.WithPublicOnly(ctx)
 
 	// Get all issues
-	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{IncludeSnoozed: true})
 	if err != nil {
 		return fmt.Errorf("failed to get issues: %w", err)
 	}
@@ -1334,6 +1338,11 @@ func exportToJSONL(ctx context.Context, jsonlPath string) error {
 		issue.Comments = comments
 	}
 
+	// Populate links for all issues
+	if err := populateLinks(ctx, store, issues); err != nil {
+		return err
+	}
+
 	// Create temp file for atomic write
 	dir := filepath.Dir(jsonlPath)
 	base := filepath.Base(jsonlPath)
@@ -1348,7 +1357,7 @@ func exportToJSONL(ctx context.Context, jsonlPath string) error {
 	}()
 
 	// Write JSONL
-	encoder := json.NewEncoder(tempFile)
+	encoder := export.NewIssueEncoder(tempFile, export.LoadTimestampFormat(ctx, store))
 	exportedIDs := make([]string, 0, len(issues))
 	for _, issue := range issues {
 		if err := encoder.Encode(issue); err != nil {
@@ -9,6 +9,8 @@ import (
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
 	"gopkg.in/yaml.v3"
 )
 
@@ -24,6 +26,9 @@ type Template struct {
 	Labels             []string `yaml:"labels" json:"labels"`
 	Design             string   `yaml:"design" json:"design"`
 	AcceptanceCriteria string   `yaml:"acceptance_criteria" json:"acceptance_criteria"`
+	// Issues and Edges make a multi-issue template: bd create --from-template
+	// creates the whole graph instead of a single issue
+	types.TemplateGraph `yaml:",inline"`
 }
 
 var templateCmd = &cobra.Command{
@@ -128,6 +133,19 @@ var templateShowCmd = &cobra.Command{
 		if tmpl.AcceptanceCriteria != "" {
 			fmt.Printf("\n%s\n%s\n", green("Acceptance Criteria:"), tmpl.AcceptanceCriteria)
 		}
+		if len(tmpl.Issues) > 0 {
+			fmt.Printf("\n%s\n", green("Issues:"))
+			for _, issue := range tmpl.Issues {
+				fmt.Printf("  %s: %s\n", blue(issue.Name), issue.Title)
+			}
+			for _, edge := range tmpl.Edges {
+				depType := edge.Type
+				if depType == "" {
+					depType = types.DepBlocks
+				}
+				fmt.Printf("  %s → %s (%s)\n", edge.From, edge.To, depType)
+			}
+		}
 	},
 }
 
@@ -309,3 +327,41 @@ func isBuiltinTemplate(name string) bool {
 	}
 	return builtins[name]
 }
+
+// createFromTemplateGraph creates every issue of a multi-issue template with
+// the --var values substituted, all or nothing
+func createFromTemplateGraph(cmd *cobra.Command, tmpl *Template) {
+	vars := make(map[string]string)
+	varFlags, _ := cmd.Flags().GetStringArray("var")
+	for _, v := range varFlags {
+		key, value, ok := strings.Cut(v, "=")
+		if !ok || key == "" {
+			FatalError("invalid --var %q (expected key=value)", v)
+		}
+		vars[key] = value
+	}
+
+	if err := ensureDirectMode("multi-issue templates require direct database access"); err != nil {
+		FatalError("%v", err)
+	}
+	sqliteStore, ok := store.(*sqlite.SQLiteStorage)
+	if !ok {
+		FatalError("multi-issue templates require a SQLite database")
+	}
+
+	created, err := sqliteStore.CreateFromTemplate(rootCtx, &tmpl.TemplateGraph, vars, actor)
+	if err != nil {
+		FatalError("template %s: %v", tmpl.Name, err)
+	}
+	markDirtyAndScheduleFlush()
+
+	if jsonOutput {
+		outputJSON(created)
+		return
+	}
+	green := color.New(color.FgGreen).SprintFunc()
+	fmt.Printf("%s Created %d issues from template %s\n", green("✓"), len(created), tmpl.Name)
+	for _, issue := range tmpl.Issues {
+		fmt.Printf("  %s: %s  %s\n", issue.Name, created[issue.Name].ID, created[issue.Name].Title)
+	}
+}
@@ -9,6 +9,8 @@ require (
 	github.com/charmbracelet/lipgloss v1.1.0
 	github.com/fatih/color v1.18.0
 	github.com/fsnotify/fsnotify v1.9.0
+	github.com/klauspost/compress v1.18.0
+	github.com/lib/pq v1.10.9
 	github.com/ncruces/go-sqlite3 v0.30.1
 	github.com/spf13/cobra v1.10.2
 	github.com/spf13/viper v1.21.0
@@ -13,6 +13,8 @@ import (
 	"time"
 
 	"github.com/steveyegge/beads/internal/debug"
+	"github.com/steveyegge/beads/internal/export"
+	"github.com/steveyegge/beads/internal/merge"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/utils"
@@ -108,6 +110,21 @@ func AutoImportIfNewer(ctx context.Context, store storage.Storage, dbPath string
 
 	notify.Debugf("auto-import triggered (hash changed)")
 
+	// Gzipped JSONL (bd export --gzip) is hashed as stored but parsed plain
+	if jsonlData, err = export.DecompressJSONL(jsonlData); err != nil {
+		notify.Errorf("Auto-import skipped: %v", err)
+		return err
+	}
+
+	if merge.HasConflictMarkers(jsonlData) {
+		if resolved, ok := resolveMergeConflicts(ctx, store, jsonlPath, notify); ok {
+			jsonlData = resolved
+			hasher.Reset()
+			hasher.Write(jsonlData)
+			currentHash = hex.EncodeToString(hasher.Sum(nil))
+		}
+	}
+
 	if err := checkForMergeConflicts(jsonlData, jsonlPath); err != nil {
 		notify.Errorf("%v", err)
 		return err
@@ -197,6 +214,31 @@ func showRemapping(allIssues []*types.Issue, idMapping map[string]string, notify
 	notify.Infof("")
 }
 
+// resolveMergeConflicts rewrites a conflicted JSONL file using the strategy
+// configured under merge.ConflictStrategyConfigKey and returns its new
+// contents. It reports false if no strategy is configured or resolution
+// failed, leaving the conflict for checkForMergeConflicts to report.
+func resolveMergeConflicts(ctx context.Context, store storage.Storage, jsonlPath string, notify Notifier) ([]byte, bool) {
+	value, err := store.GetConfig(ctx, merge.ConflictStrategyConfigKey)
+	if err != nil || value == "" {
+		return nil, false
+	}
+	report, err := merge.ResolveJSONLConflictsWithStrategy(ctx, jsonlPath, merge.ConflictStrategy(value))
+	if err != nil {
+		notify.Warnf("automatic conflict resolution failed: %v", err)
+		return nil, false
+	}
+	notify.Infof("Resolved %d merge conflict hunk(s) in %s (%s: kept %d ours, %d theirs)",
+		report.Hunks, jsonlPath, value, report.KeptOurs, report.KeptTheirs)
+
+	data, err := os.ReadFile(jsonlPath) // #nosec G304 - controlled path from config
+	if err != nil {
+		notify.Warnf("failed to re-read %s after resolving conflicts: %v", jsonlPath, err)
+		return nil, false
+	}
+	return data, true
+}
+
 func checkForMergeConflicts(jsonlData []byte, jsonlPath string) error {
 	lines := bytes.Split(jsonlData, []byte("\n"))
 	for _, line := range lines {
@@ -210,7 +252,9 @@ func checkForMergeConflicts(jsonlData []byte, jsonlPath string) error {
 				"To resolve:\n"+
 				"  1. Resolve the merge conflict in your Git client, OR\n"+
 				"  2. Export from database to regenerate clean JSONL:\n"+
-				"     bd export -o %s\n\n"+
+				"     bd export -o %s\n"+
+				"  3. Let auto-import merge both sides, newest update winning:\n"+
+				"     bd config set import.conflict_strategy newest\n\n"+
 				"After resolving, commit the fixed JSONL file.\n", jsonlPath, jsonlPath)
 		}
 	}
@@ -1,6 +1,7 @@
 package autoimport
 
 import (
+	"compress/gzip"
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
@@ -11,6 +12,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/steveyegge/beads/internal/merge"
 	"github.com/steveyegge/beads/internal/storage/memory"
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -182,6 +184,56 @@ func TestAutoImportIfNewer_ChangedHash(t *testing.T) {
 	}
 }
 
+func TestAutoImportIfNewer_Gzipped(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "bd.db")
+	jsonlPath := filepath.Join(tmpDir, "issues.jsonl")
+
+	// Written by bd export --gzip
+	issue := &types.Issue{
+		ID:        "test-1",
+		Title:     "Test Issue",
+		Status:    types.StatusOpen,
+		Priority:  1,
+		IssueType: types.TypeTask,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	f, err := os.Create(jsonlPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gz := gzip.NewWriter(f)
+	json.NewEncoder(gz).Encode(issue)
+	gz.Close()
+	f.Close()
+
+	store := memory.New("")
+	ctx := context.Background()
+	notify := &testNotifier{}
+	var receivedIssues []*types.Issue
+	importFunc := func(ctx context.Context, issues []*types.Issue) (int, int, map[string]string, error) {
+		receivedIssues = issues
+		return 1, 0, nil, nil
+	}
+
+	if err := AutoImportIfNewer(ctx, store, dbPath, notify, importFunc, nil); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(receivedIssues) != 1 || receivedIssues[0].ID != "test-1" {
+		t.Fatalf("Expected issue test-1 from the gzipped JSONL, got %v", receivedIssues)
+	}
+
+	// The hash is of the file as stored, so an unchanged file is skipped
+	receivedIssues = nil
+	if err := AutoImportIfNewer(ctx, store, dbPath, notify, importFunc, nil); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if receivedIssues != nil {
+		t.Error("Import should not be called again for an unchanged gzipped JSONL")
+	}
+}
+
 func TestAutoImportIfNewer_MergeConflict(t *testing.T) {
 	tmpDir, err := os.MkdirTemp("", "bd-autoimport-test-*")
 	if err != nil {
@@ -222,6 +274,42 @@ func TestAutoImportIfNewer_MergeConflict(t *testing.T) {
 	}
 }
 
+func TestAutoImportIfNewer_ResolvesMergeConflict(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "bd.db")
+	jsonlPath := filepath.Join(tmpDir, "issues.jsonl")
+
+	conflictData := `{"id":"test-1","title":"Issue 1"}
+<<<<<<< HEAD
+{"id":"test-2","title":"Local version","updated_at":"2025-01-01T00:00:00Z"}
+=======
+{"id":"test-2","title":"Remote version","updated_at":"2025-01-02T00:00:00Z"}
+>>>>>>> main
+`
+	if err := os.WriteFile(jsonlPath, []byte(conflictData), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	store := memory.New("")
+	ctx := context.Background()
+	if err := store.SetConfig(ctx, merge.ConflictStrategyConfigKey, string(merge.StrategyNewest)); err != nil {
+		t.Fatal(err)
+	}
+
+	var receivedIssues []*types.Issue
+	importFunc := func(ctx context.Context, issues []*types.Issue) (int, int, map[string]string, error) {
+		receivedIssues = issues
+		return len(issues), 0, nil, nil
+	}
+
+	if err := AutoImportIfNewer(ctx, store, dbPath, &testNotifier{}, importFunc, nil); err != nil {
+		t.Fatalf("Expected conflict to be resolved, got: %v", err)
+	}
+	if len(receivedIssues) != 2 || receivedIssues[1].Title != "Remote version" {
+		t.Errorf("Expected newer remote version to be imported, got %+v", receivedIssues)
+	}
+}
+
 func TestAutoImportIfNewer_WithRemapping(t *testing.T) {
 	tmpDir, err := os.MkdirTemp("", "bd-autoimport-test-*")
 	if err != nil {
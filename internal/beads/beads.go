@@ -238,6 +238,7 @@ const (
 	EventCreated           = types.EventCreated
 	EventUpdated           = types.EventUpdated
 	EventStatusChanged     = types.EventStatusChanged
+	EventPriorityChanged   = types.EventPriorityChanged
 	EventCommented         = types.EventCommented
 	EventClosed            = types.EventClosed
 	EventReopened          = types.EventReopened
@@ -245,7 +246,11 @@ const (
 	EventDependencyRemoved = types.EventDependencyRemoved
 	EventLabelAdded        = types.EventLabelAdded
 	EventLabelRemoved      = types.EventLabelRemoved
+	EventLinkAdded         = types.EventLinkAdded
+	EventLinkRemoved       = types.EventLinkRemoved
+	EventPublished         = types.EventPublished
 	EventCompacted         = types.EventCompacted
+	EventVisibilityChanged = types.EventVisibilityChanged
 )
 
 // Storage provides the minimal interface for extension orchestration
@@ -583,7 +588,7 @@ func FindAllDatabases() []DatabaseInfo {
 				ctx := context.Background()
 				store, err := sqlite.New(ctx, dbPath)
 				if err == nil {
-					if issues, err := store.SearchIssues(ctx, "", types.IssueFilter{}); err == nil {
+					if issues, err := store.SearchIssues(ctx, "", types.IssueFilter{IncludeSnoozed: true}); err == nil {
 						issueCount = len(issues)
 					}
 					_ = store.Close()
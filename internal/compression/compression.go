@@ -0,0 +1,72 @@
+// Package compression provides transparent zstd compression for large text
+// fields such as issue descriptions.
+//
+// Compressed text is stored as Marker followed by the base64-encoded zstd
+// frame, so it stays valid UTF-8 in SQLite TEXT columns and JSONL files and is
+// recognized (and decompressed) wherever it is read back.
+package compression
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Marker prefixes compressed text
+const Marker = "bd-zstd:"
+
+var (
+	encoder, _ = zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedBetterCompression))
+	decoder, _ = zstd.NewReader(nil)
+)
+
+// IsCompressed reports whether s was produced by Compress
+func IsCompressed(s string) bool {
+	return strings.HasPrefix(s, Marker)
+}
+
+// Compress returns s compressed with zstd and tagged with Marker.
+// Already-compressed input is returned unchanged.
+func Compress(s string) string {
+	if IsCompressed(s) {
+		return s
+	}
+	frame := encoder.EncodeAll([]byte(s), nil)
+	return Marker + base64.StdEncoding.EncodeToString(frame)
+}
+
+// Decompress returns the original text for compressed s. Text without Marker
+// is returned unchanged.
+func Decompress(s string) (string, error) {
+	if !IsCompressed(s) {
+		return s, nil
+	}
+	frame, err := base64.StdEncoding.DecodeString(s[len(Marker):])
+	if err != nil {
+		return "", fmt.Errorf("failed to decode compressed text: %w", err)
+	}
+	data, err := decoder.DecodeAll(frame, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decompress text: %w", err)
+	}
+	return string(data), nil
+}
+
+// CompressAbove compresses s if it is at least threshold bytes and compressing
+// actually makes it smaller; otherwise it returns s decompressed. A threshold
+// of 0 or less disables compression.
+func CompressAbove(s string, threshold int) (string, error) {
+	plain, err := Decompress(s)
+	if err != nil {
+		return "", err
+	}
+	if threshold <= 0 || len(plain) < threshold {
+		return plain, nil
+	}
+	if compressed := Compress(plain); len(compressed) < len(plain) {
+		return compressed, nil
+	}
+	return plain, nil
+}
@@ -0,0 +1,77 @@
+package compression
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRoundTrip(t *testing.T) {
+	inputs := []string{
+		"",
+		"short",
+		"unicode: héllo wörld ✓ 日本語",
+		strings.Repeat("panic: runtime error\n\tgoroutine 1 [running]:\n\tmain.main()\n", 200),
+	}
+	for _, in := range inputs {
+		c := Compress(in)
+		if !IsCompressed(c) {
+			t.Errorf("expected compressed output to carry marker, got %q", c)
+		}
+		out, err := Decompress(c)
+		if err != nil {
+			t.Fatalf("Decompress failed: %v", err)
+		}
+		if out != in {
+			t.Errorf("round trip mismatch: got %q, want %q", out, in)
+		}
+		if Compress(c) != c {
+			t.Error("expected Compress to leave compressed input unchanged")
+		}
+	}
+}
+
+func TestDecompressPlain(t *testing.T) {
+	out, err := Decompress("plain text")
+	if err != nil || out != "plain text" {
+		t.Errorf("expected plain text unchanged, got %q, %v", out, err)
+	}
+	if _, err := Decompress(Marker + "not base64!"); err == nil {
+		t.Error("expected error for corrupt compressed text")
+	}
+}
+
+func TestCompressAbove(t *testing.T) {
+	trace := strings.Repeat("at com.example.Foo.bar(Foo.java:42)\n", 100)
+
+	tests := []struct {
+		name       string
+		in         string
+		threshold  int
+		compressed bool
+	}{
+		{"disabled", trace, 0, false},
+		{"below threshold", "small", 100, false},
+		{"above threshold", trace, 100, true},
+		{"incompressible stays plain", "abcdefghijklmnopqrstuvwxyz0123456789", 10, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := CompressAbove(tt.in, tt.threshold)
+			if err != nil {
+				t.Fatalf("CompressAbove failed: %v", err)
+			}
+			if IsCompressed(out) != tt.compressed {
+				t.Errorf("expected compressed=%v, got %q", tt.compressed, out)
+			}
+			if tt.compressed && len(out) >= len(tt.in) {
+				t.Errorf("expected compressed output smaller than %d bytes, got %d", len(tt.in), len(out))
+			}
+		})
+	}
+
+	// Disabling compression decompresses existing values
+	out, err := CompressAbove(Compress(trace), 0)
+	if err != nil || out != trace {
+		t.Errorf("expected compressed input to come back plain when disabled")
+	}
+}
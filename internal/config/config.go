@@ -84,6 +84,8 @@ func Initialize() error {
 	v.SetDefault("actor", "")
 	v.SetDefault("issue-prefix", "")
 	v.SetDefault("lock-timeout", "30s")
+	v.SetDefault("query-timeout", "0s") // Per-operation store timeout for the daemon; 0 disables
+	v.SetDefault("freshness-poll-interval", "0s") // Daemon database replacement check period; 0 checks on every read
 	
 	// Additional environment variables (not prefixed with BD_)
 	// These are bound explicitly for backward compatibility
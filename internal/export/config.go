@@ -6,6 +6,7 @@ import (
 	"strconv"
 
 	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
 )
 
 // ConfigStore defines the minimal storage interface needed for config
@@ -14,6 +15,21 @@ type ConfigStore interface {
 	SetConfig(ctx context.Context, key, value string) error
 }
 
+// Register the export config keys (see storage.RegisterConfigKey)
+func init() {
+	policies := []string{string(PolicyStrict), string(PolicyBestEffort), string(PolicyPartial), string(PolicyRequiredCore)}
+	storage.RegisterConfigKey(ConfigKeyErrorPolicy, storage.ValidateOneOf(policies...))
+	storage.RegisterConfigKey(ConfigKeyAutoExportPolicy, storage.ValidateOneOf(policies...))
+	storage.RegisterConfigKey(ConfigKeyRetryAttempts, storage.ValidateIntConfig)
+	storage.RegisterConfigKey(ConfigKeyRetryBackoffMS, storage.ValidateIntConfig)
+	storage.RegisterConfigKey(ConfigKeySkipEncodingErrors, storage.ValidateBoolConfig)
+	storage.RegisterConfigKey(ConfigKeyWriteManifest, storage.ValidateBoolConfig)
+	storage.RegisterConfigKey(ConfigKeyShardBy, storage.ValidateOneOf(string(ShardNone), string(ShardByPrefix), string(ShardByHash)))
+	storage.RegisterConfigKey(ConfigKeyShardDigits, storage.ValidateIntConfig)
+	storage.RegisterConfigKey(ConfigKeyTimestampFormat, storage.ValidateOneOf(
+		string(types.TimestampRFC3339Nano), string(types.TimestampRFC3339), string(types.TimestampUnixMillis)))
+}
+
 // LoadConfig reads export configuration from storage
 func LoadConfig(ctx context.Context, store ConfigStore, isAutoExport bool) (*Config, error) {
 	cfg := &Config{
@@ -23,6 +39,9 @@ func LoadConfig(ctx context.Context, store ConfigStore, isAutoExport bool) (*Con
 		SkipEncodingErrors: DefaultSkipEncodingErrors,
 		WriteManifest:      DefaultWriteManifest,
 		IsAutoExport:       isAutoExport,
+		ShardBy:            DefaultShardBy,
+		ShardDigits:        DefaultShardDigits,
+		TimestampFormat:    DefaultTimestampFormat,
 	}
 
 	// Load error policy
@@ -73,6 +92,21 @@ func LoadConfig(ctx context.Context, store ConfigStore, isAutoExport bool) (*Con
 		}
 	}
 
+	// Load sharding
+	if val, err := store.GetConfig(ctx, ConfigKeyShardBy); err == nil && val != "" {
+		if mode := ShardMode(val); mode.IsValid() {
+			cfg.ShardBy = mode
+		}
+	}
+	if val, err := store.GetConfig(ctx, ConfigKeyShardDigits); err == nil && val != "" {
+		if digits, err := strconv.Atoi(val); err == nil && digits >= 1 && digits <= MaxShardDigits {
+			cfg.ShardDigits = digits
+		}
+	}
+
+	// Load timestamp format
+	cfg.TimestampFormat = LoadTimestampFormat(ctx, store)
+
 	return cfg, nil
 }
 
@@ -111,6 +145,22 @@ func SetSkipEncodingErrors(ctx context.Context, store storage.Storage, skip bool
 	return store.SetConfig(ctx, ConfigKeySkipEncodingErrors, strconv.FormatBool(skip))
 }
 
+// SetShardBy sets how exports are split across JSONL files
+func SetShardBy(ctx context.Context, store storage.Storage, mode ShardMode) error {
+	if !mode.IsValid() {
+		return fmt.Errorf("invalid shard mode: %s (valid: none, prefix, hash)", mode)
+	}
+	return store.SetConfig(ctx, ConfigKeyShardBy, string(mode))
+}
+
+// SetTimestampFormat sets how timestamps are written to JSONL exports
+func SetTimestampFormat(ctx context.Context, store storage.Storage, format types.TimestampFormat) error {
+	if !format.IsValid() {
+		return fmt.Errorf("invalid timestamp format: %s (valid: rfc3339nano, rfc3339, unix_ms)", format)
+	}
+	return store.SetConfig(ctx, ConfigKeyTimestampFormat, string(format))
+}
+
 // SetWriteManifest sets whether to write export manifests
 func SetWriteManifest(ctx context.Context, store storage.Storage, write bool) error {
 	return store.SetConfig(ctx, ConfigKeyWriteManifest, strconv.FormatBool(write))
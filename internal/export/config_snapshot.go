@@ -0,0 +1,133 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ConfigSnapshot is the config section of a full export: every config key
+// (issue prefix, flags, defaults, ...) written next to the issues JSONL so
+// a new database can be stood up from the export alone
+type ConfigSnapshot struct {
+	ExportedAt time.Time         `json:"exported_at"`
+	Config     map[string]string `json:"config"`
+}
+
+// ConfigImportMode controls how ApplyConfigSnapshot treats existing config
+type ConfigImportMode string
+
+const (
+	// ConfigImportNone ignores the snapshot, leaving config untouched
+	ConfigImportNone ConfigImportMode = "none"
+	// ConfigImportMissing only sets keys that are not already set
+	ConfigImportMissing ConfigImportMode = "missing"
+	// ConfigImportMerge sets every key in the snapshot, overwriting local
+	// values; keys only set locally are kept
+	ConfigImportMerge ConfigImportMode = "merge"
+)
+
+// IsValid checks if the config import mode is valid
+func (m ConfigImportMode) IsValid() bool {
+	switch m {
+	case ConfigImportNone, ConfigImportMissing, ConfigImportMerge:
+		return true
+	}
+	return false
+}
+
+// ConfigSnapshotPath returns the path of the config snapshot for the JSONL
+// export at jsonlPath
+func ConfigSnapshotPath(jsonlPath string) string {
+	return strings.TrimSuffix(jsonlPath, ".jsonl") + ".config.json"
+}
+
+// WriteConfigSnapshot writes config as the config snapshot alongside the
+// JSONL export at jsonlPath
+func WriteConfigSnapshot(jsonlPath string, config map[string]string) error {
+	snapshotPath := ConfigSnapshotPath(jsonlPath)
+
+	data, err := json.MarshalIndent(&ConfigSnapshot{ExportedAt: time.Now(), Config: config}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal config snapshot: %w", err)
+	}
+
+	// Write to a temp file and rename for an atomic replace
+	tempFile, err := os.CreateTemp(filepath.Dir(snapshotPath), filepath.Base(snapshotPath)+".tmp.*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp config snapshot file: %w", err)
+	}
+	tempPath := tempFile.Name()
+	defer func() {
+		_ = tempFile.Close()
+		_ = os.Remove(tempPath)
+	}()
+
+	if _, err := tempFile.Write(data); err != nil {
+		return fmt.Errorf("failed to write config snapshot: %w", err)
+	}
+	_ = tempFile.Close()
+
+	if err := os.Rename(tempPath, snapshotPath); err != nil {
+		return fmt.Errorf("failed to replace config snapshot file: %w", err)
+	}
+	return nil
+}
+
+// ReadConfigSnapshot reads the config snapshot alongside the JSONL export at
+// jsonlPath. It returns nil without error if the export has none.
+func ReadConfigSnapshot(jsonlPath string) (*ConfigSnapshot, error) {
+	// #nosec G304 - path derived from the user-provided export path
+	data, err := os.ReadFile(ConfigSnapshotPath(jsonlPath))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config snapshot: %w", err)
+	}
+
+	var snapshot ConfigSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to parse config snapshot: %w", err)
+	}
+	return &snapshot, nil
+}
+
+// ApplyConfigSnapshot writes the snapshot's config to store according to
+// mode and returns the keys it set, sorted
+func ApplyConfigSnapshot(ctx context.Context, store ConfigStore, snapshot *ConfigSnapshot, mode ConfigImportMode) ([]string, error) {
+	if !mode.IsValid() {
+		return nil, fmt.Errorf("invalid config import mode %q (must be none, missing, or merge)", mode)
+	}
+	if snapshot == nil || mode == ConfigImportNone {
+		return nil, nil
+	}
+
+	keys := make([]string, 0, len(snapshot.Config))
+	for key := range snapshot.Config {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var set []string
+	for _, key := range keys {
+		value := snapshot.Config[key]
+		existing, err := store.GetConfig(ctx, key)
+		if err != nil {
+			return set, fmt.Errorf("failed to read config %s: %w", key, err)
+		}
+		if existing == value || (mode == ConfigImportMissing && existing != "") {
+			continue
+		}
+		if err := store.SetConfig(ctx, key, value); err != nil {
+			return set, fmt.Errorf("failed to set config %s: %w", key, err)
+		}
+		set = append(set, key)
+	}
+	return set, nil
+}
@@ -0,0 +1,71 @@
+package export
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// mapConfigStore is an in-memory ConfigStore
+type mapConfigStore map[string]string
+
+func (m mapConfigStore) GetConfig(ctx context.Context, key string) (string, error) {
+	return m[key], nil
+}
+
+func (m mapConfigStore) SetConfig(ctx context.Context, key, value string) error {
+	m[key] = value
+	return nil
+}
+
+func TestConfigSnapshotRoundTrip(t *testing.T) {
+	jsonlPath := filepath.Join(t.TempDir(), "issues.jsonl")
+
+	if snapshot, err := ReadConfigSnapshot(jsonlPath); err != nil || snapshot != nil {
+		t.Fatalf("Expected no snapshot before export, got %+v (%v)", snapshot, err)
+	}
+
+	exported := map[string]string{"issue_prefix": "bd", "status.auto_unblock": "true", "wip.limit": "3"}
+	if err := WriteConfigSnapshot(jsonlPath, exported); err != nil {
+		t.Fatalf("WriteConfigSnapshot failed: %v", err)
+	}
+	snapshot, err := ReadConfigSnapshot(jsonlPath)
+	if err != nil {
+		t.Fatalf("ReadConfigSnapshot failed: %v", err)
+	}
+	if !reflect.DeepEqual(snapshot.Config, exported) {
+		t.Errorf("Expected %v, got %v", exported, snapshot.Config)
+	}
+
+	ctx := context.Background()
+	tests := []struct {
+		mode ConfigImportMode
+		set  []string
+		want mapConfigStore
+	}{
+		{ConfigImportNone, nil,
+			mapConfigStore{"issue_prefix": "mirror", "local.only": "x"}},
+		{ConfigImportMissing, []string{"status.auto_unblock", "wip.limit"},
+			mapConfigStore{"issue_prefix": "mirror", "local.only": "x", "status.auto_unblock": "true", "wip.limit": "3"}},
+		{ConfigImportMerge, []string{"issue_prefix", "status.auto_unblock", "wip.limit"},
+			mapConfigStore{"issue_prefix": "bd", "local.only": "x", "status.auto_unblock": "true", "wip.limit": "3"}},
+	}
+	for _, tt := range tests {
+		store := mapConfigStore{"issue_prefix": "mirror", "local.only": "x"}
+		set, err := ApplyConfigSnapshot(ctx, store, snapshot, tt.mode)
+		if err != nil {
+			t.Fatalf("ApplyConfigSnapshot(%s) failed: %v", tt.mode, err)
+		}
+		if !reflect.DeepEqual(set, tt.set) {
+			t.Errorf("ApplyConfigSnapshot(%s) set %v, want %v", tt.mode, set, tt.set)
+		}
+		if !reflect.DeepEqual(store, tt.want) {
+			t.Errorf("ApplyConfigSnapshot(%s) left %v, want %v", tt.mode, store, tt.want)
+		}
+	}
+
+	if _, err := ApplyConfigSnapshot(ctx, mapConfigStore{}, snapshot, "everything"); err == nil {
+		t.Error("Expected error for invalid mode")
+	}
+}
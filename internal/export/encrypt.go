@@ -0,0 +1,143 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// EncryptionKeyEnv names the environment variable holding the key that
+// bd export --encrypt encrypts with and bd import decrypts with. It is taken
+// from the environment rather than a flag to keep it out of shell history.
+const EncryptionKeyEnv = "BEADS_EXPORT_KEY"
+
+// ErrBadKey indicates encrypted JSONL could not be decrypted: the key is
+// wrong, or the file was changed after it was written
+var ErrBadKey = errors.New("wrong encryption key or corrupt encrypted JSONL")
+
+// ErrMissingKey indicates encrypted JSONL was read without a key
+var ErrMissingKey = fmt.Errorf("JSONL is encrypted; set %s to its key", EncryptionKeyEnv)
+
+// encryptedMagic starts encrypted JSONL. It is followed by the key
+// derivation salt, the nonce, and the JSONL sealed with AES-256-GCM as a
+// single message, so any change to the file is detected.
+const encryptedMagic = "BDENC1\n"
+
+const (
+	encryptionSaltSize   = 16
+	encryptionIterations = 10_000
+)
+
+// isEncrypted reports whether data starts with encryptedMagic
+func isEncrypted(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(encryptedMagic))
+}
+
+// newGCM derives the AES-256-GCM cipher for key and salt
+func newGCM(key string, salt []byte) (cipher.AEAD, error) {
+	derived, err := pbkdf2.Key(sha512.New, key, salt, encryptionIterations, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(derived)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// NewEncryptedWriter returns a writer that encrypts what is written to it
+// with key, writing the result to w when closed. The JSONL is held in memory
+// until then, since GCM authenticates it as one message.
+func NewEncryptedWriter(w io.Writer, key string) io.WriteCloser {
+	return &encryptedWriter{w: w, key: key}
+}
+
+type encryptedWriter struct {
+	w   io.Writer
+	key string
+	buf bytes.Buffer
+}
+
+func (e *encryptedWriter) Write(p []byte) (int, error) {
+	return e.buf.Write(p)
+}
+
+func (e *encryptedWriter) Close() error {
+	salt := make([]byte, encryptionSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return err
+	}
+	gcm, err := newGCM(e.key, salt)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	out := append([]byte(encryptedMagic), salt...)
+	out = append(out, nonce...)
+	out = gcm.Seal(out, nonce, e.buf.Bytes(), []byte(encryptedMagic))
+	if _, err := e.w.Write(out); err != nil {
+		return fmt.Errorf("failed to write encrypted JSONL: %w", err)
+	}
+	return nil
+}
+
+// DecryptJSONL returns data decrypted with key if it is encrypted, and as it
+// is otherwise. It fails with ErrMissingKey if data is encrypted and key is
+// empty, and with ErrBadKey if key doesn't decrypt it.
+func DecryptJSONL(data []byte, key string) ([]byte, error) {
+	if !isEncrypted(data) {
+		return data, nil
+	}
+	if key == "" {
+		return nil, ErrMissingKey
+	}
+	rest := data[len(encryptedMagic):]
+	if len(rest) < encryptionSaltSize {
+		return nil, ErrBadKey
+	}
+	salt, rest := rest[:encryptionSaltSize], rest[encryptionSaltSize:]
+	gcm, err := newGCM(key, salt)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) < gcm.NonceSize() {
+		return nil, ErrBadKey
+	}
+	nonce, sealed := rest[:gcm.NonceSize()], rest[gcm.NonceSize():]
+	plain, err := gcm.Open(nil, nonce, sealed, []byte(encryptedMagic))
+	if err != nil {
+		return nil, ErrBadKey
+	}
+	return plain, nil
+}
+
+// NewDecryptingReader returns a reader of the JSONL in r, decrypted with key
+// if it is encrypted (see DecryptJSONL). Plain and gzipped JSONL are passed
+// through unchanged, so wrap the result in NewJSONLReader to decompress it.
+func NewDecryptingReader(r io.Reader, key string) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	if magic, err := br.Peek(len(encryptedMagic)); err != nil || !isEncrypted(magic) {
+		return br, nil
+	}
+	data, err := io.ReadAll(br)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read encrypted JSONL: %w", err)
+	}
+	plain, err := DecryptJSONL(data, key)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(plain), nil
+}
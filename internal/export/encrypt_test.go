@@ -0,0 +1,88 @@
+package export
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestEncryptedJSONL(t *testing.T) {
+	plain := []byte(`{"id":"bd-1","title":"Salary bands"}` + "\n")
+	var encrypted bytes.Buffer
+	w := NewEncryptedWriter(&encrypted, "s3cret")
+	if _, err := w.Write(plain); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if bytes.Contains(encrypted.Bytes(), []byte("Salary")) {
+		t.Fatal("Expected the export to be encrypted")
+	}
+
+	got, err := DecryptJSONL(encrypted.Bytes(), "s3cret")
+	if err != nil {
+		t.Fatalf("DecryptJSONL failed: %v", err)
+	}
+	if !bytes.Equal(got, plain) {
+		t.Errorf("Expected %q, got %q", plain, got)
+	}
+	if _, err := DecryptJSONL(encrypted.Bytes(), "wrong"); !errors.Is(err, ErrBadKey) {
+		t.Errorf("Expected ErrBadKey for the wrong key, got %v", err)
+	}
+	if _, err := DecryptJSONL(encrypted.Bytes(), ""); !errors.Is(err, ErrMissingKey) {
+		t.Errorf("Expected ErrMissingKey without a key, got %v", err)
+	}
+	tampered := append([]byte(nil), encrypted.Bytes()...)
+	tampered[len(tampered)-1] ^= 1
+	if _, err := DecryptJSONL(tampered, "s3cret"); !errors.Is(err, ErrBadKey) {
+		t.Errorf("Expected ErrBadKey for a changed file, got %v", err)
+	}
+
+	// Plaintext passes through, with or without a key
+	if got, err := DecryptJSONL(plain, "s3cret"); err != nil || !bytes.Equal(got, plain) {
+		t.Errorf("Expected plaintext unchanged, got %q (err %v)", got, err)
+	}
+}
+
+func TestDecryptingReader(t *testing.T) {
+	plain := []byte(`{"id":"bd-1"}` + "\n")
+
+	// bd export --gzip --encrypt compresses, then encrypts
+	var encrypted bytes.Buffer
+	enc := NewEncryptedWriter(&encrypted, "s3cret")
+	gz := gzip.NewWriter(enc)
+	if _, err := gz.Write(plain); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("gzip Close failed: %v", err)
+	}
+	if err := enc.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	for name, input := range map[string][]byte{"plain": plain, "encrypted gzip": encrypted.Bytes()} {
+		decrypted, err := NewDecryptingReader(bytes.NewReader(input), "s3cret")
+		if err != nil {
+			t.Fatalf("%s: NewDecryptingReader failed: %v", name, err)
+		}
+		r, err := NewJSONLReader(decrypted)
+		if err != nil {
+			t.Fatalf("%s: NewJSONLReader failed: %v", name, err)
+		}
+		got, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatalf("%s: ReadAll failed: %v", name, err)
+		}
+		if !bytes.Equal(got, plain) {
+			t.Errorf("%s: Expected %q, got %q", name, plain, got)
+		}
+	}
+
+	if _, err := NewDecryptingReader(bytes.NewReader(encrypted.Bytes()), "wrong"); !errors.Is(err, ErrBadKey) {
+		t.Errorf("Expected ErrBadKey for the wrong key, got %v", err)
+	}
+}
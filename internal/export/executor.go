@@ -13,6 +13,7 @@ const (
 	DataTypeCore     DataType = "core"       // Issues and dependencies
 	DataTypeLabels   DataType = "labels"     // Issue labels
 	DataTypeComments DataType = "comments"   // Issue comments
+	DataTypeLinks    DataType = "links"      // Issue links
 )
 
 // FetchResult holds the result of a data fetch operation
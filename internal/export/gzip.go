@@ -0,0 +1,56 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// GzipExtension marks a gzipped JSONL file. bd export compresses output
+// whose path ends in it; readers don't rely on the name, but detect gzip
+// from the magic bytes at the start of the stream.
+const GzipExtension = ".gz"
+
+// IsGzipPath reports whether path names a gzipped JSONL file
+func IsGzipPath(path string) bool {
+	return strings.HasSuffix(path, GzipExtension)
+}
+
+// isGzip reports whether data starts with the gzip magic bytes
+func isGzip(data []byte) bool {
+	return len(data) >= 2 && data[0] == 0x1f && data[1] == 0x8b
+}
+
+// NewJSONLReader returns a reader of the JSONL in r, decompressing it on
+// the fly if it is gzipped. Plain JSONL is passed through unchanged.
+func NewJSONLReader(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	if magic, err := br.Peek(2); err == nil && isGzip(magic) {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open gzip stream: %w", err)
+		}
+		return gz, nil
+	}
+	return br, nil
+}
+
+// DecompressJSONL returns data decompressed if it is gzipped, and as it is
+// otherwise, for callers that read the whole file at once
+func DecompressJSONL(data []byte) ([]byte, error) {
+	if !isGzip(data) {
+		return data, nil
+	}
+	r, err := NewJSONLReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	decompressed, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress JSONL: %w", err)
+	}
+	return decompressed, nil
+}
@@ -0,0 +1,54 @@
+package export
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"testing"
+)
+
+func TestJSONLReader(t *testing.T) {
+	plain := []byte(`{"id":"bd-1"}` + "\n" + `{"id":"bd-2"}` + "\n")
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	if _, err := gz.Write(plain); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	for name, input := range map[string][]byte{"plain": plain, "gzip": compressed.Bytes()} {
+		r, err := NewJSONLReader(bytes.NewReader(input))
+		if err != nil {
+			t.Fatalf("%s: NewJSONLReader failed: %v", name, err)
+		}
+		got, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatalf("%s: ReadAll failed: %v", name, err)
+		}
+		if !bytes.Equal(got, plain) {
+			t.Errorf("%s: Expected %q, got %q", name, plain, got)
+		}
+
+		got, err = DecompressJSONL(input)
+		if err != nil {
+			t.Fatalf("%s: DecompressJSONL failed: %v", name, err)
+		}
+		if !bytes.Equal(got, plain) {
+			t.Errorf("%s: Expected %q from DecompressJSONL, got %q", name, plain, got)
+		}
+	}
+
+	// Empty input is plain, empty JSONL
+	if got, err := DecompressJSONL(nil); err != nil || len(got) != 0 {
+		t.Errorf("Expected empty output for empty input, got %q (err %v)", got, err)
+	}
+	if _, err := DecompressJSONL([]byte{0x1f, 0x8b, 0x00}); err == nil {
+		t.Error("Expected a truncated gzip stream to fail")
+	}
+
+	if !IsGzipPath("backup/issues.jsonl.gz") || IsGzipPath("issues.jsonl") {
+		t.Error("IsGzipPath misclassified a path")
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"time"
+
+	"github.com/steveyegge/beads/internal/types"
 )
 
 // ErrorPolicy defines how export operations handle errors
@@ -31,6 +33,9 @@ const (
 	ConfigKeySkipEncodingErrors = "export.skip_encoding_errors"
 	ConfigKeyWriteManifest      = "export.write_manifest"
 	ConfigKeyAutoExportPolicy   = "auto_export.error_policy"
+	ConfigKeyShardBy            = "export.shard_by"
+	ConfigKeyShardDigits        = "export.shard_digits"
+	ConfigKeyTimestampFormat    = "export.timestamp_format"
 )
 
 // Default values
@@ -41,6 +46,9 @@ const (
 	DefaultSkipEncodingErrors = false
 	DefaultWriteManifest      = false
 	DefaultAutoExportPolicy   = PolicyBestEffort
+	DefaultShardBy            = ShardNone
+	DefaultShardDigits        = 1
+	DefaultTimestampFormat    = types.TimestampRFC3339Nano
 )
 
 // Config holds export error handling configuration
@@ -51,6 +59,9 @@ type Config struct {
 	SkipEncodingErrors  bool
 	WriteManifest       bool
 	IsAutoExport        bool // If true, may use different policy
+	ShardBy             ShardMode // How to split the export across files (see shard.go)
+	ShardDigits         int       // Leading ID hash digits per shard for ShardByHash
+	TimestampFormat     types.TimestampFormat // How timestamps are written (see timestamps.go)
 }
 
 // Manifest tracks export completeness and failures
@@ -0,0 +1,206 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// ShardMode selects how a sharded export splits issues across JSONL files
+type ShardMode string
+
+const (
+	// ShardNone writes a single JSONL file (default)
+	ShardNone ShardMode = "none"
+
+	// ShardByPrefix writes one file per issue ID prefix (e.g. "bd.shard.jsonl", "web.shard.jsonl")
+	ShardByPrefix ShardMode = "prefix"
+
+	// ShardByHash writes one file per leading hash digit(s) of the issue ID
+	// (e.g. "bd-a3f8" goes to "a.shard.jsonl" with 1 digit, "a3.shard.jsonl" with 2)
+	ShardByHash ShardMode = "hash"
+)
+
+// ShardFileSuffix ends every shard file name. Only files carrying it are
+// treated as shards, so other JSONL files sharing the directory (issues.jsonl,
+// deletions.jsonl) are never read as shards or removed as stale ones.
+const ShardFileSuffix = ".shard.jsonl"
+
+// MaxShardDigits caps ShardByHash at 36^2 possible shard files
+const MaxShardDigits = 2
+
+// IsValid checks if the shard mode is valid
+func (m ShardMode) IsValid() bool {
+	switch m {
+	case ShardNone, ShardByPrefix, ShardByHash:
+		return true
+	}
+	return false
+}
+
+// ShardKey returns the shard name for an issue ID. Hierarchical child IDs
+// (bd-a3f8.1) land in the same shard as their parent.
+func ShardKey(id string, mode ShardMode, digits int) string {
+	if mode == ShardByPrefix {
+		if prefix := utils.ExtractIssuePrefix(id); prefix != "" {
+			return prefix
+		}
+		return "other"
+	}
+
+	// ShardByHash: leading characters of the suffix after the prefix
+	suffix := id
+	if prefix := utils.ExtractIssuePrefix(id); prefix != "" {
+		suffix = strings.TrimPrefix(id[len(prefix):], "-")
+	}
+	if dot := strings.Index(suffix, "."); dot >= 0 {
+		suffix = suffix[:dot]
+	}
+	if digits < 1 {
+		digits = 1
+	}
+	if digits > MaxShardDigits {
+		digits = MaxShardDigits
+	}
+	if len(suffix) < digits {
+		return "other"
+	}
+	return strings.ToLower(suffix[:digits])
+}
+
+// WriteShards writes issues into dir as one JSONL file per shard, each sorted
+// by ID, and returns the number of issues written to each file. Every shard is
+// replaced atomically, and shard files left over from a previous export that
+// received no issues this time are removed so the directory always reflects
+// exactly the exported set. Timestamps are written in format.
+func WriteShards(dir string, issues []*types.Issue, mode ShardMode, digits int, format types.TimestampFormat) (map[string]int, error) {
+	if mode == ShardNone || !mode.IsValid() {
+		return nil, fmt.Errorf("invalid shard mode: %s (valid: prefix, hash)", mode)
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create shard directory: %w", err)
+	}
+
+	shards := make(map[string][]*types.Issue)
+	for _, issue := range issues {
+		key := ShardKey(issue.ID, mode, digits)
+		shards[key] = append(shards[key], issue)
+	}
+
+	counts := make(map[string]int, len(shards))
+	for key, shardIssues := range shards {
+		sort.Slice(shardIssues, func(i, j int) bool {
+			return shardIssues[i].ID < shardIssues[j].ID
+		})
+		name := key + ShardFileSuffix
+		if err := writeShardFile(filepath.Join(dir, name), shardIssues, format); err != nil {
+			return nil, err
+		}
+		counts[name] = len(shardIssues)
+	}
+
+	// Remove stale shards
+	existing, err := ShardFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range existing {
+		if _, ok := counts[filepath.Base(path)]; !ok {
+			if err := os.Remove(path); err != nil {
+				return nil, fmt.Errorf("failed to remove stale shard %s: %w", path, err)
+			}
+		}
+	}
+
+	return counts, nil
+}
+
+// writeShardFile atomically replaces path with issues encoded as JSONL
+func writeShardFile(path string, issues []*types.Issue, format types.TimestampFormat) error {
+	tempFile, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp.*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp shard file: %w", err)
+	}
+	tempPath := tempFile.Name()
+	defer func() {
+		_ = tempFile.Close()
+		_ = os.Remove(tempPath)
+	}()
+
+	encoder := NewIssueEncoder(tempFile, format)
+	for _, issue := range issues {
+		if err := encoder.Encode(issue); err != nil {
+			return fmt.Errorf("failed to encode issue %s: %w", issue.ID, err)
+		}
+	}
+	if err := tempFile.Close(); err != nil {
+		return fmt.Errorf("failed to close temp shard file: %w", err)
+	}
+
+	if err := os.Rename(tempPath, path); err != nil {
+		return fmt.Errorf("failed to replace shard file %s: %w", path, err)
+	}
+	if err := os.Chmod(path, 0600); err != nil {
+		// Non-fatal, just log
+		fmt.Fprintf(os.Stderr, "Warning: failed to set shard permissions: %v\n", err)
+	}
+	return nil
+}
+
+// ShardFiles returns the shard files in dir, sorted by name
+func ShardFiles(dir string) ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+ShardFileSuffix))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list shard files: %w", err)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// OpenShards returns a reader over every shard file in dir, concatenated in
+// name order, so a sharded export can be imported like a single JSONL file.
+func OpenShards(dir string) (io.ReadCloser, error) {
+	paths, err := ShardFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []*os.File
+	var readers []io.Reader
+	for _, path := range paths {
+		// #nosec G304 - path comes from listing the user-provided shard directory
+		f, err := os.Open(path)
+		if err != nil {
+			for _, opened := range files {
+				_ = opened.Close()
+			}
+			return nil, fmt.Errorf("failed to open shard %s: %w", path, err)
+		}
+		files = append(files, f)
+		// A shard missing its trailing newline must not merge into the next one
+		readers = append(readers, f, strings.NewReader("\n"))
+	}
+	return &shardReader{Reader: io.MultiReader(readers...), files: files}, nil
+}
+
+// shardReader closes every underlying shard file on Close
+type shardReader struct {
+	io.Reader
+	files []*os.File
+}
+
+func (r *shardReader) Close() error {
+	var firstErr error
+	for _, f := range r.files {
+		if err := f.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
@@ -0,0 +1,153 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestShardKey(t *testing.T) {
+	tests := []struct {
+		id     string
+		mode   ShardMode
+		digits int
+		want   string
+	}{
+		{"bd-a3f8", ShardByHash, 1, "a"},
+		{"bd-a3f8", ShardByHash, 2, "a3"},
+		{"bd-A3F8", ShardByHash, 1, "a"},
+		{"bd-a3f8.1.2", ShardByHash, 2, "a3"}, // children stay with their parent
+		{"web-app-1b2c", ShardByHash, 1, "1"},
+		{"bd-a3f8", ShardByHash, 5, "a3"}, // capped at MaxShardDigits
+		{"bd-a3f8", ShardByPrefix, 1, "bd"},
+		{"web-app-1b2c", ShardByPrefix, 1, "web-app"},
+	}
+	for _, tt := range tests {
+		if got := ShardKey(tt.id, tt.mode, tt.digits); got != tt.want {
+			t.Errorf("ShardKey(%q, %s, %d) = %q, want %q", tt.id, tt.mode, tt.digits, got, tt.want)
+		}
+	}
+}
+
+func TestWriteShardsRoundTrip(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "issues")
+
+	issues := []*types.Issue{
+		{ID: "bd-b2", Title: "B2"},
+		{ID: "bd-a1", Title: "A1"},
+		{ID: "bd-a9", Title: "A9"},
+		{ID: "bd-c3", Title: "C3"},
+	}
+	counts, err := WriteShards(dir, issues, ShardByHash, 1, DefaultTimestampFormat)
+	if err != nil {
+		t.Fatalf("WriteShards failed: %v", err)
+	}
+	want := map[string]int{"a.shard.jsonl": 2, "b.shard.jsonl": 1, "c.shard.jsonl": 1}
+	if len(counts) != len(want) {
+		t.Fatalf("expected shards %v, got %v", want, counts)
+	}
+	for name, n := range want {
+		if counts[name] != n {
+			t.Errorf("expected %d issues in %s, got %d", n, name, counts[name])
+		}
+	}
+
+	// Shards are sorted by ID
+	f, err := os.Open(filepath.Join(dir, "a.shard.jsonl"))
+	if err != nil {
+		t.Fatalf("failed to open shard: %v", err)
+	}
+	shardIDs := readIDs(t, f)
+	_ = f.Close()
+	if len(shardIDs) != 2 || shardIDs[0] != "bd-a1" || shardIDs[1] != "bd-a9" {
+		t.Errorf("expected shard a.shard.jsonl to hold [bd-a1 bd-a9], got %v", shardIDs)
+	}
+
+	// Re-exporting without the c shard removes the stale file
+	if _, err := WriteShards(dir, issues[:3], ShardByHash, 1, DefaultTimestampFormat); err != nil {
+		t.Fatalf("WriteShards failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "c.shard.jsonl")); !os.IsNotExist(err) {
+		t.Errorf("expected stale shard c.shard.jsonl to be removed, got %v", err)
+	}
+
+	// OpenShards reads every issue back
+	r, err := OpenShards(dir)
+	if err != nil {
+		t.Fatalf("OpenShards failed: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	ids := readIDs(t, r)
+	if len(ids) != 3 || ids[0] != "bd-a1" || ids[1] != "bd-a9" || ids[2] != "bd-b2" {
+		t.Errorf("expected [bd-a1 bd-a9 bd-b2], got %v", ids)
+	}
+}
+
+// readIDs decodes JSONL issues from r and returns their IDs in order
+func readIDs(t *testing.T, r io.Reader) []string {
+	t.Helper()
+	var ids []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var issue types.Issue
+		if err := json.Unmarshal(scanner.Bytes(), &issue); err != nil {
+			t.Fatalf("failed to decode %q: %v", scanner.Text(), err)
+		}
+		ids = append(ids, issue.ID)
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("scan failed: %v", err)
+	}
+	return ids
+}
+
+func TestShardsIgnoreOtherJSONLFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	// A shard directory inside .beads shares it with the main export and the
+	// deletions manifest
+	others := map[string]string{
+		"issues.jsonl":    `{"id":"bd-x1","title":"Main export"}` + "\n",
+		"deletions.jsonl": `{"id":"bd-gone","ts":"2025-01-01T00:00:00Z","by":"alice"}` + "\n",
+	}
+	for name, content := range others {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	issues := []*types.Issue{{ID: "bd-a1", Title: "A1"}}
+	if _, err := WriteShards(dir, issues, ShardByHash, 1, DefaultTimestampFormat); err != nil {
+		t.Fatalf("WriteShards failed: %v", err)
+	}
+	for name, content := range others {
+		got, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil || string(got) != content {
+			t.Errorf("expected %s to survive WriteShards untouched, got %q (err %v)", name, got, err)
+		}
+	}
+
+	r, err := OpenShards(dir)
+	if err != nil {
+		t.Fatalf("OpenShards failed: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+	if ids := readIDs(t, r); len(ids) != 1 || ids[0] != "bd-a1" {
+		t.Errorf("expected OpenShards to read only [bd-a1], got %v", ids)
+	}
+}
+
+func TestWriteShardsRejectsNone(t *testing.T) {
+	if _, err := WriteShards(t.TempDir(), nil, ShardNone, 1, DefaultTimestampFormat); err == nil {
+		t.Error("expected error for ShardNone")
+	}
+}
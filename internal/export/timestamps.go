@@ -0,0 +1,66 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// LoadTimestampFormat returns the configured export timestamp format, or
+// DefaultTimestampFormat if it is unset or invalid
+func LoadTimestampFormat(ctx context.Context, store ConfigStore) types.TimestampFormat {
+	if val, err := store.GetConfig(ctx, ConfigKeyTimestampFormat); err == nil && val != "" {
+		if format := types.TimestampFormat(val); format.IsValid() {
+			return format
+		}
+	}
+	return DefaultTimestampFormat
+}
+
+// IssueEncoder writes issues as JSONL lines with timestamps in a fixed
+// format. Every writer of issues.jsonl should go through it so the file
+// doesn't flip formats depending on which code path flushed it last.
+type IssueEncoder struct {
+	w      io.Writer
+	format types.TimestampFormat
+}
+
+// NewIssueEncoder returns an encoder writing to w in the given format
+func NewIssueEncoder(w io.Writer, format types.TimestampFormat) *IssueEncoder {
+	return &IssueEncoder{w: w, format: format}
+}
+
+// Encode writes issue as one JSON line
+func (e *IssueEncoder) Encode(issue *types.Issue) error {
+	data, err := MarshalIssue(issue, e.format)
+	if err != nil {
+		return err
+	}
+	_, err = e.w.Write(append(data, '\n'))
+	return err
+}
+
+// MarshalIssue encodes issue as JSON with timestamps in format. Version and
+// Watchers are left out, since they are local to the database, and so are
+// Sections, which the description already carries as Markdown.
+func MarshalIssue(issue *types.Issue, format types.TimestampFormat) ([]byte, error) {
+	if issue.Version != 0 || issue.Watchers != nil || issue.Sections != nil {
+		local := *issue
+		local.Version = 0
+		local.Watchers = nil
+		local.Sections = nil
+		issue = &local
+	}
+	data, err := json.Marshal(issue)
+	if err != nil {
+		return nil, err
+	}
+	data, err = types.FormatTimestamps(data, format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to format timestamps: %w", err)
+	}
+	return data, nil
+}
@@ -3,6 +3,7 @@ package importer
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -12,6 +13,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/steveyegge/beads/internal/compression"
 	"github.com/steveyegge/beads/internal/deletions"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
@@ -33,6 +35,29 @@ const (
 	OrphanAllow = sqlite.OrphanAllow
 )
 
+// DeletionMode controls how import decides that an issue in the database was
+// deleted elsewhere
+type DeletionMode string
+
+const (
+	// InferFromAbsence applies explicit deletions and, unless NoGitHistory is
+	// set, also tombstones issues missing from the JSONL that git history shows
+	// were once exported (default)
+	InferFromAbsence DeletionMode = "infer-from-absence"
+	// TombstoneOnly applies only explicit deletions: tombstones in the JSONL and
+	// records in deletions.jsonl. Absence from the JSONL never deletes.
+	TombstoneOnly DeletionMode = "tombstone-only"
+)
+
+// IsValid reports whether m is a known deletion mode (empty means the default)
+func (m DeletionMode) IsValid() bool {
+	switch m {
+	case "", InferFromAbsence, TombstoneOnly:
+		return true
+	}
+	return false
+}
+
 // Options contains import configuration
 type Options struct {
 	DryRun                     bool           // Preview changes without applying them
@@ -45,6 +70,40 @@ type Options struct {
 	NoGitHistory               bool           // Skip git history backfill for deletions (prevents spurious deletion during JSONL migrations)
 	IgnoreDeletions            bool           // Import issues even if they're in the deletions manifest
 	ProtectLocalExportIDs      map[string]bool // IDs from left snapshot to protect from git-history-backfill (bd-sync-deletion fix)
+	DeletionMode               DeletionMode   // How deletions are detected (empty = InferFromAbsence)
+	AllowMassDeletion          bool           // Proceed even if the import would delete more issues than MassDeletionPercentConfigKey allows
+
+	// OnProgress, if set, is called as issues are created or updated with how
+	// many of the total have been handled, every ProgressInterval issues and
+	// once at the end. It runs between batches, never inside a transaction.
+	OnProgress func(done, total int)
+}
+
+// ProgressInterval is how many issues pass between Options.OnProgress calls
+const ProgressInterval = 100
+
+// importBatchSize caps how many new issues are created per transaction, so
+// progress is reported between batches and cancelling the context only
+// rolls back the batch in flight
+const importBatchSize = 500
+
+// progressReporter throttles calls to Options.OnProgress
+type progressReporter struct {
+	fn       func(done, total int)
+	total    int
+	reported int
+}
+
+// set records that done issues have been handled, reporting it if enough
+// have passed since the last report or the import is complete
+func (p *progressReporter) set(done int) {
+	if p.fn == nil || done <= p.reported {
+		return
+	}
+	if done-p.reported >= ProgressInterval || done == p.total {
+		p.reported = done
+		p.fn(done, p.total)
+	}
 }
 
 // Result contains statistics about the import operation
@@ -89,11 +148,25 @@ type Result struct {
 // - issues: Parsed issues from JSONL
 // - opts: Import options
 func ImportIssues(ctx context.Context, dbPath string, store storage.Storage, issues []*types.Issue, opts Options) (*Result, error) {
+	if !opts.DeletionMode.IsValid() {
+		return nil, fmt.Errorf("invalid deletion mode %q (valid: %s, %s)", opts.DeletionMode, InferFromAbsence, TombstoneOnly)
+	}
+
 	result := &Result{
 		IDMapping:        make(map[string]string),
 		MismatchPrefixes: make(map[string]int),
 	}
 
+	// Decode descriptions from a --compress-descriptions export so hashes and
+	// comparisons below see plain text; the store re-compresses per its own config
+	for _, issue := range issues {
+		plain, err := compression.Decompress(issue.Description)
+		if err != nil {
+			return nil, fmt.Errorf("issue %s: %w", issue.ID, err)
+		}
+		issue.Description = plain
+	}
+
 	// Compute content hashes for all incoming issues (bd-95)
 	// Always recompute to avoid stale/incorrect JSONL hashes (bd-1231)
 	for _, issue := range issues {
@@ -199,6 +272,27 @@ func ImportIssues(ctx context.Context, dbPath string, store storage.Storage, iss
 		return result, nil
 	}
 
+	// Refuse wholesale deletion before changing anything: it is the mark of
+	// a stale or reset JSONL rather than real deletions
+	if err := checkMassDeletion(ctx, sqliteStore, issues, opts); err != nil {
+		return result, err
+	}
+
+	// Work out the purge of deleted issues now too, so that the guard on its
+	// git history fallback also refuses before anything changes
+	var purge *purgePlan
+	if !opts.DryRun {
+		purge, err = planPurge(ctx, sqliteStore, dbPath, issues, opts, result)
+		if err != nil {
+			// The mass deletion guard needs confirmation to proceed
+			if errors.Is(err, ErrMassDeletionGuard) {
+				return result, err
+			}
+			// Non-fatal - just log warning
+			fmt.Fprintf(os.Stderr, "Warning: failed to purge deleted issues: %v\n", err)
+		}
+	}
+
 	// Upsert issues (create new or update existing)
 	if err := upsertIssues(ctx, sqliteStore, issues, opts, result); err != nil {
 		return nil, err
@@ -219,13 +313,15 @@ func ImportIssues(ctx context.Context, dbPath string, store storage.Storage, iss
 		return nil, err
 	}
 
+	// Import links
+	if err := importLinks(ctx, sqliteStore, issues, opts); err != nil {
+		return nil, err
+	}
+
 	// Purge deleted issues from DB based on deletions manifest
 	// Issues that are in the manifest but not in JSONL should be deleted from DB
-	if !opts.DryRun {
-		if err := purgeDeletedIssues(ctx, sqliteStore, dbPath, issues, opts, result); err != nil {
-			// Non-fatal - just log warning
-			fmt.Fprintf(os.Stderr, "Warning: failed to purge deleted issues: %v\n", err)
-		}
+	if purge != nil {
+		applyPurge(ctx, sqliteStore, purge, result)
 	}
 
 	// Checkpoint WAL to ensure data persistence and reduce WAL file size
@@ -474,6 +570,12 @@ func handleRename(ctx context.Context, s *sqlite.SQLiteStorage, existing *types.
 		return "", fmt.Errorf("failed to delete old ID %s: %w", oldID, err)
 	}
 
+	// Keep the labels exactly as exported rather than picking up the
+	// project's creation defaults
+	if incoming.Labels == nil {
+		incoming.Labels = []string{}
+	}
+
 	// Create with new ID
 	if err := s.CreateIssue(ctx, incoming, "import-rename"); err != nil {
 		// If UNIQUE constraint error, it's likely another clone created it concurrently
@@ -498,7 +600,7 @@ func handleRename(ctx context.Context, s *sqlite.SQLiteStorage, existing *types.
 func upsertIssues(ctx context.Context, sqliteStore *sqlite.SQLiteStorage, issues []*types.Issue, opts Options, result *Result) error {
 	// Get all DB issues once - include tombstones to prevent UNIQUE constraint violations
 	// when trying to create issues that were previously deleted (bd-sync-tombstone-fix)
-	dbIssues, err := sqliteStore.SearchIssues(ctx, "", types.IssueFilter{IncludeTombstones: true})
+	dbIssues, err := sqliteStore.SearchIssues(ctx, "", types.IssueFilter{IncludeTombstones: true, IncludeSnoozed: true})
 	if err != nil {
 		return fmt.Errorf("failed to get DB issues: %w", err)
 	}
@@ -517,8 +619,15 @@ func upsertIssues(ctx context.Context, sqliteStore *sqlite.SQLiteStorage, issues
 	// Track what we need to create
 	var newIssues []*types.Issue
 	seenHashes := make(map[string]bool)
+	progress := &progressReporter{fn: opts.OnProgress, total: len(issues)}
+
+	for i, incoming := range issues {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		// Issues handled so far, less those queued for creation below
+		progress.set(i - len(newIssues))
 
-	for _, incoming := range issues {
 		hash := incoming.ContentHash
 		if hash == "" {
 			// Shouldn't happen (computed earlier), but be defensive
@@ -569,6 +678,12 @@ func upsertIssues(ctx context.Context, sqliteStore *sqlite.SQLiteStorage, issues
 					} else {
 					 updates["external_ref"] = nil
 				}
+
+					if incoming.DueDate != nil {
+						updates["due_date"] = *incoming.DueDate
+					} else {
+						updates["due_date"] = nil
+					}
 					
 					// Only update if data actually changed
 					if IssueDataChanged(existing, updates) {
@@ -653,6 +768,12 @@ func upsertIssues(ctx context.Context, sqliteStore *sqlite.SQLiteStorage, issues
 				 updates["external_ref"] = nil
 			}
 
+				if incoming.DueDate != nil {
+					updates["due_date"] = *incoming.DueDate
+				} else {
+					updates["due_date"] = nil
+				}
+
 				// Only update if data actually changed
 				if IssueDataChanged(existingWithID, updates) {
 					if err := sqliteStore.UpdateIssue(ctx, incoming.ID, updates, "import"); err != nil {
@@ -708,6 +829,7 @@ if opts.OrphanHandling == sqlite.OrphanSkip {
 	}
 	newIssues = filteredNewIssues
 }
+progress.set(len(issues) - len(newIssues))
 
 // Batch create all new issues
 // Sort by hierarchy depth to ensure parents are created before children
@@ -722,6 +844,7 @@ if len(newIssues) > 0 {
 })
 
 // Create in batches by depth level (max depth 3)
+		createdBefore := result.Created
 		for depth := 0; depth <= 3; depth++ {
     var batchForDepth []*types.Issue
     for _, issue := range newIssues {
@@ -729,19 +852,28 @@ if len(newIssues) > 0 {
      batchForDepth = append(batchForDepth, issue)
 				}
 			}
-			if len(batchForDepth) > 0 {
-				batchOpts := sqlite.BatchCreateOptions{
-					OrphanHandling:       opts.OrphanHandling,
-					SkipPrefixValidation: opts.SkipPrefixValidation,
+			batchOpts := sqlite.BatchCreateOptions{
+				OrphanHandling:       opts.OrphanHandling,
+				SkipPrefixValidation: opts.SkipPrefixValidation,
+			}
+			// Each batch is its own transaction; a cancelled context rolls
+			// back the one in flight and stops before the next
+			for start := 0; start < len(batchForDepth); start += importBatchSize {
+				end := min(start+importBatchSize, len(batchForDepth))
+				if err := ctx.Err(); err != nil {
+					return err
 				}
-				if err := sqliteStore.CreateIssuesWithFullOptions(ctx, batchForDepth, "import", batchOpts); err != nil {
+				if err := sqliteStore.CreateIssuesWithFullOptions(ctx, batchForDepth[start:end], "import", batchOpts); err != nil {
 					return fmt.Errorf("error creating depth-%d issues: %w", depth, err)
 				}
-				result.Created += len(batchForDepth)
+				result.Created += end - start
+				progress.set(len(issues) - len(newIssues) + result.Created - createdBefore)
 			}
 		}
 	}
 
+	progress.set(len(issues))
+
 	// REMOVED (bd-c7af): Counter sync after import - no longer needed with hash IDs
 
 	return nil
@@ -870,16 +1002,75 @@ func importComments(ctx context.Context, sqliteStore *sqlite.SQLiteStorage, issu
 	return nil
 }
 
+// importLinks imports links for issues, adding missing URLs and updating the
+// title and kind of existing ones
+func importLinks(ctx context.Context, sqliteStore *sqlite.SQLiteStorage, issues []*types.Issue, opts Options) error {
+	for _, issue := range issues {
+		if len(issue.Links) == 0 {
+			continue
+		}
+
+		currentLinks, err := sqliteStore.GetLinks(ctx, issue.ID)
+		if err != nil {
+			return fmt.Errorf("error getting links for %s: %w", issue.ID, err)
+		}
+		currentByURL := make(map[string]*types.Link)
+		for _, link := range currentLinks {
+			currentByURL[link.URL] = link
+		}
+
+		for _, link := range issue.Links {
+			if current, ok := currentByURL[link.URL]; ok && current.Title == link.Title && current.Kind == link.Kind {
+				continue
+			}
+			if _, err := sqliteStore.AddLink(ctx, issue.ID, link.URL, link.Title, link.Kind, "import"); err != nil {
+				if opts.Strict {
+					return fmt.Errorf("error adding link %s to %s: %w", link.URL, issue.ID, err)
+				}
+				continue
+			}
+		}
+	}
+
+	return nil
+}
+
+// purgePlan is what purgeDeletedIssues tombstones, worked out by planPurge
+// before the import changes anything, so that the mass deletion guard on the
+// git history fallback can still refuse the whole import
+type purgePlan struct {
+	deletionsPath string
+	fromManifest  []deletions.DeletionRecord // Manifest entries for DB issues missing from the JSONL
+	fromGit       []string                   // DB issues git history shows were deleted
+}
+
 // purgeDeletedIssues converts DB issues to tombstones if they are in the deletions
 // manifest but not in the incoming JSONL. This enables deletion propagation across clones.
 // Also uses git history fallback for deletions that were pruned from the manifest,
-// unless opts.NoGitHistory is set (useful during JSONL filename migrations).
+// unless opts.NoGitHistory is set (useful during JSONL filename migrations) or
+// opts.DeletionMode is TombstoneOnly.
 //
 // Note (bd-dve): With inline tombstones, most deletions are now handled during import
 // via convertDeletionToTombstone. This function primarily handles:
 // 1. DB-only issues that need to be tombstoned (not in JSONL at all)
 // 2. Git history fallback for pruned deletions
+//
+// ImportIssues calls planPurge and applyPurge separately, planning before it
+// upserts anything.
 func purgeDeletedIssues(ctx context.Context, sqliteStore *sqlite.SQLiteStorage, dbPath string, jsonlIssues []*types.Issue, opts Options, result *Result) error {
+	plan, err := planPurge(ctx, sqliteStore, dbPath, jsonlIssues, opts, result)
+	if err != nil {
+		return err
+	}
+	applyPurge(ctx, sqliteStore, plan, result)
+	return nil
+}
+
+// planPurge works out which DB issues purgeDeletedIssues tombstones, without
+// changing the database. It returns a *MassDeletionError if the git history
+// fallback would tombstone more issues than MassDeletionPercentConfigKey
+// allows.
+func planPurge(ctx context.Context, sqliteStore *sqlite.SQLiteStorage, dbPath string, jsonlIssues []*types.Issue, opts Options, result *Result) (*purgePlan, error) {
 	// Get deletions manifest path (same directory as database)
 	beadsDir := filepath.Dir(dbPath)
 	deletionsPath := deletions.DefaultPath(beadsDir)
@@ -887,7 +1078,7 @@ func purgeDeletedIssues(ctx context.Context, sqliteStore *sqlite.SQLiteStorage,
 	// Load deletions manifest (gracefully handles missing/empty file)
 	loadResult, err := deletions.LoadDeletions(deletionsPath)
 	if err != nil {
-		return fmt.Errorf("failed to load deletions manifest: %w", err)
+		return nil, fmt.Errorf("failed to load deletions manifest: %w", err)
 	}
 
 	// Log any warnings from loading
@@ -901,12 +1092,16 @@ func purgeDeletedIssues(ctx context.Context, sqliteStore *sqlite.SQLiteStorage,
 		jsonlIDs[issue.ID] = true
 	}
 
-	// Get all DB issues (exclude existing tombstones - they're already deleted)
-	dbIssues, err := sqliteStore.SearchIssues(ctx, "", types.IssueFilter{})
+	// Get all DB issues (exclude existing tombstones - they're already deleted).
+	// Private issues are never in the shared JSONL, so their absence from it
+	// isn't a deletion (see sqlite.WithPublicOnly).
+	dbIssues, err := sqliteStore.SearchIssues(sqlite.WithPublicOnly(ctx), "", types.IssueFilter{IncludeSnoozed: true})
 	if err != nil {
-		return fmt.Errorf("failed to get DB issues: %w", err)
+		return nil, fmt.Errorf("failed to get DB issues: %w", err)
 	}
 
+	plan := &purgePlan{deletionsPath: deletionsPath}
+
 	// Collect IDs that need git history check (not in JSONL, not in manifest)
 	var needGitCheck []string
 
@@ -940,20 +1135,7 @@ func purgeDeletedIssues(ctx context.Context, sqliteStore *sqlite.SQLiteStorage,
 			}
 
 			// Issue is in deletions manifest - convert to tombstone (bd-dve)
-			if err := sqliteStore.CreateTombstone(ctx, dbIssue.ID, del.Actor, del.Reason); err != nil {
-				fmt.Fprintf(os.Stderr, "Warning: failed to create tombstone for %s: %v\n", dbIssue.ID, err)
-				continue
-			}
-
-			// Log the tombstone creation with metadata
-			fmt.Fprintf(os.Stderr, "Tombstoned %s (deleted %s by %s", dbIssue.ID, del.Timestamp.Format("2006-01-02 15:04:05"), del.Actor)
-			if del.Reason != "" {
-				fmt.Fprintf(os.Stderr, ", reason: %s", del.Reason)
-			}
-			fmt.Fprintf(os.Stderr, ")\n")
-
-			result.Purged++
-			result.PurgedIDs = append(result.PurgedIDs, dbIssue.ID)
+			plan.fromManifest = append(plan.fromManifest, del)
 		} else {
 			// Not in JSONL and not in deletions manifest
 			// This could be:
@@ -974,6 +1156,11 @@ func purgeDeletedIssues(ctx context.Context, sqliteStore *sqlite.SQLiteStorage,
 		}
 	}
 
+	// Absence alone never deletes in TombstoneOnly mode
+	if opts.DeletionMode == TombstoneOnly {
+		return plan, nil
+	}
+
 	// Git history fallback for potential pruned deletions
 	// Skip if --no-git-history flag is set (prevents spurious deletions during JSONL migrations)
 	if len(needGitCheck) > 0 && !opts.NoGitHistory {
@@ -989,15 +1176,15 @@ func purgeDeletedIssues(ctx context.Context, sqliteStore *sqlite.SQLiteStorage,
 		if deleteCount > 0 && totalDBIssues > 0 {
 			deletePercent := float64(deleteCount) / float64(totalDBIssues) * 100
 
-			// Abort if would delete >50% of issues - this is almost certainly a reset
-			if deletePercent > 50 {
-				fmt.Fprintf(os.Stderr, "Warning: git-history-backfill would tombstone %d of %d issues (%.1f%%) - aborting\n",
-					deleteCount, totalDBIssues, deletePercent)
+			// Abort if would delete more than MassDeletionPercentConfigKey
+			// allows (50% by default) - this is almost certainly a reset
+			limit := massDeletionPercent(ctx, sqliteStore)
+			if !opts.AllowMassDeletion && deleteCount*100 > limit*totalDBIssues {
+				fmt.Fprintf(os.Stderr, "Warning: git-history-backfill would tombstone %d of %d issues (%.1f%%, limit %d%%) - aborting\n",
+					deleteCount, totalDBIssues, deletePercent, limit)
 				fmt.Fprintf(os.Stderr, "This usually means the JSONL was reset (git reset, branch switch, etc.)\n")
-				fmt.Fprintf(os.Stderr, "If these are legitimate deletions, add them to deletions.jsonl manually\n")
 				// Don't delete anything - abort the backfill
-				deleteCount = 0
-				deletedViaGit = nil
+				return nil, &MassDeletionError{Count: deleteCount, Total: totalDBIssues, LimitPercent: limit}
 			} else if deleteCount > 10 {
 				// Warn (but proceed) if deleting >10 issues
 				fmt.Fprintf(os.Stderr, "Warning: git-history-backfill will tombstone %d issues (%.1f%% of %d total)\n",
@@ -1023,34 +1210,57 @@ func purgeDeletedIssues(ctx context.Context, sqliteStore *sqlite.SQLiteStorage,
 					continue
 				}
 			}
-
-			// Backfill the deletions manifest (self-healing)
-			backfillRecord := deletions.DeletionRecord{
-				ID:        id,
-				Timestamp: time.Now().UTC(),
-				Actor:     "git-history-backfill",
-				Reason:    "recovered from git history (pruned from manifest)",
-			}
-			if err := deletions.AppendDeletion(deletionsPath, backfillRecord); err != nil {
-				fmt.Fprintf(os.Stderr, "Warning: failed to backfill deletion record for %s: %v\n", id, err)
-			}
-
-			// Convert to tombstone (bd-dve)
-			if err := sqliteStore.CreateTombstone(ctx, id, "git-history-backfill", "recovered from git history (pruned from manifest)"); err != nil {
-				fmt.Fprintf(os.Stderr, "Warning: failed to create tombstone for %s (git-recovered): %v\n", id, err)
-				continue
-			}
-
-			fmt.Fprintf(os.Stderr, "Tombstoned %s (recovered from git history, pruned from manifest)\n", id)
-			result.Purged++
-			result.PurgedIDs = append(result.PurgedIDs, id)
+			plan.fromGit = append(plan.fromGit, id)
 		}
 	} else if len(needGitCheck) > 0 && opts.NoGitHistory {
 		// Log that we skipped git history check due to flag
 		fmt.Fprintf(os.Stderr, "Skipped git history check for %d issue(s) (--no-git-history flag set)\n", len(needGitCheck))
 	}
 
-	return nil
+	return plan, nil
+}
+
+// applyPurge tombstones the issues in plan. Failures are logged and skipped.
+func applyPurge(ctx context.Context, sqliteStore *sqlite.SQLiteStorage, plan *purgePlan, result *Result) {
+	for _, del := range plan.fromManifest {
+		if err := sqliteStore.CreateTombstone(ctx, del.ID, del.Actor, del.Reason); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to create tombstone for %s: %v\n", del.ID, err)
+			continue
+		}
+
+		// Log the tombstone creation with metadata
+		fmt.Fprintf(os.Stderr, "Tombstoned %s (deleted %s by %s", del.ID, del.Timestamp.Format("2006-01-02 15:04:05"), del.Actor)
+		if del.Reason != "" {
+			fmt.Fprintf(os.Stderr, ", reason: %s", del.Reason)
+		}
+		fmt.Fprintf(os.Stderr, ")\n")
+
+		result.Purged++
+		result.PurgedIDs = append(result.PurgedIDs, del.ID)
+	}
+
+	for _, id := range plan.fromGit {
+		// Backfill the deletions manifest (self-healing)
+		backfillRecord := deletions.DeletionRecord{
+			ID:        id,
+			Timestamp: time.Now().UTC(),
+			Actor:     "git-history-backfill",
+			Reason:    "recovered from git history (pruned from manifest)",
+		}
+		if err := deletions.AppendDeletion(plan.deletionsPath, backfillRecord); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to backfill deletion record for %s: %v\n", id, err)
+		}
+
+		// Convert to tombstone (bd-dve)
+		if err := sqliteStore.CreateTombstone(ctx, id, "git-history-backfill", "recovered from git history (pruned from manifest)"); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to create tombstone for %s (git-recovered): %v\n", id, err)
+			continue
+		}
+
+		fmt.Fprintf(os.Stderr, "Tombstoned %s (recovered from git history, pruned from manifest)\n", id)
+		result.Purged++
+		result.PurgedIDs = append(result.PurgedIDs, id)
+	}
 }
 
 // checkGitHistoryForDeletions checks if IDs were ever in the JSONL history.
@@ -2,6 +2,7 @@ package importer
 
 import (
 	"context"
+	"errors"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -688,8 +689,9 @@ func TestMassDeletionSafetyGuard(t *testing.T) {
 
 	// Now try to import the reset JSONL WITH git history enabled
 	// This should trigger the safety guard since 8/10 = 80% > 50%
+	// bd-mass01 was also edited, and the refused import must not apply that
 	resetIssues := []*types.Issue{
-		{ID: "bd-mass01", Title: "Issue 1", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now},
+		{ID: "bd-mass01", Title: "Issue 1 (edited)", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now.Add(time.Hour)},
 		{ID: "bd-mass02", Title: "Issue 2", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now},
 	}
 
@@ -700,15 +702,17 @@ func TestMassDeletionSafetyGuard(t *testing.T) {
 		NoGitHistory:         false, // Enable git history - this is the test!
 	}
 
-	result, err := ImportIssues(ctx, dbPath, store, resetIssues, opts)
-	if err != nil {
-		t.Fatalf("import failed: %v", err)
-	}
+	_, err = ImportIssues(ctx, dbPath, store, resetIssues, opts)
 
-	// The safety guard should have prevented any purges
+	// The safety guard should have refused the import
 	// because 8/10 = 80% > 50% threshold
-	t.Logf("Import result: created=%d, updated=%d, unchanged=%d, purged=%d",
-		result.Created, result.Updated, result.Unchanged, result.Purged)
+	if !errors.Is(err, ErrMassDeletionGuard) {
+		t.Fatalf("Expected ErrMassDeletionGuard, got %v", err)
+	}
+	var massErr *MassDeletionError
+	if !errors.As(err, &massErr) || massErr.Count != 8 || massErr.Total != 10 {
+		t.Errorf("Expected 8 of 10 deletions refused, got %+v", massErr)
+	}
 
 	// Verify all 10 issues are STILL in DB (safety guard prevented deletion)
 	dbIssues, err = store.SearchIssues(ctx, "", types.IssueFilter{})
@@ -721,8 +725,148 @@ func TestMassDeletionSafetyGuard(t *testing.T) {
 	if len(dbIssues) != 10 {
 		t.Errorf("Expected 10 issues in DB (safety guard should prevent purge), got %d", len(dbIssues))
 	}
+	edited, err := store.GetIssue(ctx, "bd-mass01")
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if edited.Title != "Issue 1" {
+		t.Errorf("Expected the refused import to change nothing, got title %q", edited.Title)
+	}
+}
 
-	if result.Purged != 0 {
-		t.Errorf("Expected 0 purged issues (safety guard), got %d (IDs: %v)", result.Purged, result.PurgedIDs)
+// TestDeletionModeBranchMerge replays the branch-merge scenario behind the
+// erroneous-deletion bug: a feature branch rewrites issues.jsonl from a stale
+// export, and merging it drops bd-merge2 from the JSONL even though nobody
+// deleted it. Only bd-merge4, recorded in deletions.jsonl, was really deleted.
+// InferFromAbsence tombstones both (git history shows bd-merge2 was exported);
+// TombstoneOnly only applies the explicit deletion.
+func TestDeletionModeBranchMerge(t *testing.T) {
+	tests := []struct {
+		name          string
+		mode          DeletionMode
+		noGitHistory  bool
+		wantTombstone map[string]bool
+	}{
+		{"tombstone-only", TombstoneOnly, false, map[string]bool{"bd-merge4": true}},
+		{"infer-from-absence", InferFromAbsence, false, map[string]bool{"bd-merge2": true, "bd-merge4": true}},
+		{"infer-from-absence without git history", InferFromAbsence, true, map[string]bool{"bd-merge4": true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoDir := filepath.Join(t.TempDir(), "test-repo")
+			beadsDir := filepath.Join(repoDir, ".beads")
+			if err := os.MkdirAll(beadsDir, 0755); err != nil {
+				t.Fatalf("failed to create .beads dir: %v", err)
+			}
+			git := func(args ...string) {
+				cmd := exec.Command("git", args...)
+				cmd.Dir = repoDir
+				if out, err := cmd.CombinedOutput(); err != nil {
+					t.Fatalf("git %v failed: %v\n%s", args, err, out)
+				}
+			}
+			git("init", "-b", "main")
+			git("config", "user.email", "test@test.com")
+			git("config", "user.name", "Test User")
+
+			line := func(id, status string) string {
+				closedAt := ""
+				if status == "closed" {
+					closedAt = `,"closed_at":"2025-01-02T00:00:00Z"`
+				}
+				return `{"id":"` + id + `","title":"` + id + `","status":"` + status + `","priority":1,"issue_type":"task","created_at":"2025-01-01T00:00:00Z","updated_at":"2025-01-01T00:00:00Z"` + closedAt + "}\n"
+			}
+			jsonlPath := filepath.Join(beadsDir, "issues.jsonl")
+
+			// main: three exported issues
+			if err := os.WriteFile(jsonlPath, []byte(line("bd-merge1", "open")+line("bd-merge2", "closed")+line("bd-merge3", "open")), 0644); err != nil {
+				t.Fatalf("failed to write JSONL: %v", err)
+			}
+			git("add", ".beads/issues.jsonl")
+			git("commit", "-m", "Export three issues")
+
+			// feature: rewritten from a stale export that lacks bd-merge2
+			git("checkout", "-b", "feature")
+			if err := os.WriteFile(jsonlPath, []byte(line("bd-merge1", "open")+line("bd-merge3", "open")), 0644); err != nil {
+				t.Fatalf("failed to write JSONL: %v", err)
+			}
+			git("commit", "-am", "Stale export on feature branch")
+			git("checkout", "main")
+			git("merge", "feature")
+
+			ctx := context.Background()
+			dbPath := filepath.Join(beadsDir, "beads.db")
+			store, err := sqlite.New(ctx, dbPath)
+			if err != nil {
+				t.Fatalf("failed to create store: %v", err)
+			}
+			defer store.Close()
+			if err := store.SetConfig(ctx, "issue_prefix", "bd"); err != nil {
+				t.Fatalf("failed to set prefix: %v", err)
+			}
+
+			// The local DB still has everything from before the merge
+			now := time.Now()
+			for _, id := range []string{"bd-merge1", "bd-merge2", "bd-merge3", "bd-merge4"} {
+				issue := &types.Issue{ID: id, Title: id, Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now}
+				if id == "bd-merge2" || id == "bd-merge4" {
+					issue.Status = types.StatusClosed
+					issue.ClosedAt = &now
+				}
+				if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+					t.Fatalf("failed to create issue %s: %v", id, err)
+				}
+			}
+
+			// The only real deletion, recorded explicitly
+			deletionsPath := deletions.DefaultPath(beadsDir)
+			if err := deletions.AppendDeletion(deletionsPath, deletions.DeletionRecord{
+				ID: "bd-merge4", Timestamp: now.Add(time.Minute).UTC(), Actor: "alice", Reason: "duplicate",
+			}); err != nil {
+				t.Fatalf("failed to write deletions: %v", err)
+			}
+
+			// Import the merged JSONL
+			merged := []*types.Issue{
+				{ID: "bd-merge1", Title: "bd-merge1", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now},
+				{ID: "bd-merge3", Title: "bd-merge3", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask, CreatedAt: now, UpdatedAt: now},
+			}
+			result, err := ImportIssues(ctx, dbPath, store, merged, Options{
+				SkipPrefixValidation: true,
+				NoGitHistory:         tt.noGitHistory,
+				DeletionMode:         tt.mode,
+			})
+			if err != nil {
+				t.Fatalf("import failed: %v", err)
+			}
+
+			for _, id := range []string{"bd-merge1", "bd-merge2", "bd-merge3", "bd-merge4"} {
+				issue, err := store.GetIssue(ctx, id)
+				if err != nil {
+					t.Fatalf("GetIssue(%s) failed: %v", id, err)
+				}
+				if issue == nil {
+					t.Fatalf("expected %s to still exist", id)
+				}
+				if got := issue.Status == types.StatusTombstone; got != tt.wantTombstone[id] {
+					t.Errorf("%s: expected tombstone=%v, got status %s (purged: %v)", id, tt.wantTombstone[id], issue.Status, result.PurgedIDs)
+				}
+			}
+		})
+	}
+}
+
+func TestImportIssues_InvalidDeletionMode(t *testing.T) {
+	ctx := context.Background()
+	dbPath := filepath.Join(t.TempDir(), "beads.db")
+	store, err := sqlite.New(ctx, dbPath)
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	defer store.Close()
+
+	if _, err := ImportIssues(ctx, dbPath, store, nil, Options{DeletionMode: "absence"}); err == nil {
+		t.Error("expected error for invalid deletion mode")
 	}
 }
@@ -5,6 +5,8 @@ package importer
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"strings"
 	"testing"
 	"time"
@@ -606,6 +608,66 @@ func TestImportIssues_Basic(t *testing.T) {
 	}
 }
 
+func TestImportIssues_Progress(t *testing.T) {
+	ctx := context.Background()
+	tmpDB := t.TempDir() + "/test.db"
+	store, err := sqlite.New(ctx, tmpDB)
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer store.Close()
+	if err := store.SetConfig(ctx, "issue_prefix", "test"); err != nil {
+		t.Fatalf("Failed to set prefix: %v", err)
+	}
+
+	makeIssues := func(n int, prefix string) []*types.Issue {
+		issues := make([]*types.Issue, n)
+		for i := range issues {
+			issues[i] = &types.Issue{
+				ID:        fmt.Sprintf("test-%s%d", prefix, i),
+				Title:     fmt.Sprintf("Issue %s%d", prefix, i),
+				Status:    types.StatusOpen,
+				Priority:  2,
+				IssueType: types.TypeTask,
+			}
+		}
+		return issues
+	}
+
+	var reports [][2]int
+	_, err = ImportIssues(ctx, tmpDB, store, makeIssues(250, "a"), Options{
+		OnProgress: func(done, total int) { reports = append(reports, [2]int{done, total}) },
+	})
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if len(reports) == 0 || reports[len(reports)-1] != [2]int{250, 250} {
+		t.Fatalf("Expected progress to end at 250/250, got %v", reports)
+	}
+	for i := 1; i < len(reports); i++ {
+		if reports[i][0] <= reports[i-1][0] {
+			t.Errorf("Expected increasing progress, got %v", reports)
+		}
+	}
+
+	// Cancelling from the callback stops before the next batch
+	cancelCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	_, err = ImportIssues(cancelCtx, tmpDB, store, makeIssues(2*importBatchSize, "b"), Options{
+		OnProgress: func(done, total int) { cancel() },
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+	imported, err := store.SearchIssues(ctx, "Issue b", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(imported) != importBatchSize {
+		t.Errorf("Expected only the first batch of %d imported, got %d", importBatchSize, len(imported))
+	}
+}
+
 func TestImportIssues_Update(t *testing.T) {
 	ctx := context.Background()
 	
@@ -670,6 +732,60 @@ func TestImportIssues_Update(t *testing.T) {
 	}
 }
 
+func TestImportIssues_DueDateOnlyChange(t *testing.T) {
+	ctx := context.Background()
+
+	tmpDB := t.TempDir() + "/test.db"
+	store, err := sqlite.New(ctx, tmpDB)
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer store.Close()
+
+	if err := store.SetConfig(ctx, "issue_prefix", "test"); err != nil {
+		t.Fatalf("Failed to set prefix: %v", err)
+	}
+
+	due := time.Date(2026, 3, 1, 17, 0, 0, 0, time.UTC)
+	issue := &types.Issue{
+		ID:        "test-due1",
+		Title:     "Ship it",
+		Status:    types.StatusOpen,
+		Priority:  1,
+		IssueType: types.TypeTask,
+		DueDate:   &due,
+	}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("Failed to create initial issue: %v", err)
+	}
+
+	// Same issue, only the due date moved
+	moved := due.Add(7 * 24 * time.Hour)
+	incoming := *issue
+	incoming.DueDate = &moved
+	incoming.UpdatedAt = time.Now().Add(time.Hour)
+	incoming.ContentHash = incoming.ComputeContentHash()
+	if incoming.ContentHash == issue.ComputeContentHash() {
+		t.Fatal("Expected the due date to change the content hash")
+	}
+
+	result, err := ImportIssues(ctx, tmpDB, store, []*types.Issue{&incoming}, Options{})
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if result.Updated != 1 || result.Unchanged != 0 {
+		t.Errorf("Expected 1 updated and 0 unchanged, got %d updated and %d unchanged", result.Updated, result.Unchanged)
+	}
+
+	retrieved, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve issue: %v", err)
+	}
+	if retrieved.DueDate == nil || !retrieved.DueDate.Equal(moved) {
+		t.Errorf("Expected due date %v, got %v", moved, retrieved.DueDate)
+	}
+}
+
 func TestImportIssues_DryRun(t *testing.T) {
 	ctx := context.Background()
 	
@@ -0,0 +1,112 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ErrMassDeletionGuard indicates an import was refused because it would
+// delete too many of the existing issues at once (see MassDeletionError)
+var ErrMassDeletionGuard = errors.New("import would delete too many issues")
+
+// MassDeletionPercentConfigKey is the config key for the largest share of
+// the database's live issues, as a whole percentage, one import may tombstone
+// before it is refused with ErrMassDeletionGuard unless
+// Options.AllowMassDeletion is set. Unset or invalid values use
+// DefaultMassDeletionPercent; 100 turns the guard off.
+const MassDeletionPercentConfigKey = "import.max_delete_percent"
+
+// DefaultMassDeletionPercent is used when MassDeletionPercentConfigKey is
+// unset or invalid
+const DefaultMassDeletionPercent = 50
+
+func init() {
+	storage.RegisterConfigKey(MassDeletionPercentConfigKey, func(value string) error {
+		if percent, err := strconv.Atoi(strings.TrimSpace(value)); err != nil || percent < 0 || percent > 100 {
+			return fmt.Errorf("%q is not a percentage (0-100)", value)
+		}
+		return nil
+	})
+}
+
+// massDeletionMinCount is the fewest deletions the guard ever refuses, so
+// deleting one or two issues from a tiny database isn't mistaken for a
+// wholesale wipe
+const massDeletionMinCount = 3
+
+// MassDeletionError is returned by ImportIssues when the import would
+// tombstone more than the configured share of the live issues, which is how
+// a stale or reset JSONL (e.g. after a bad branch merge) looks, counting
+// both incoming tombstones and deletions found through git history. Both are
+// checked before the import writes anything, so nothing is changed. It
+// matches ErrMassDeletionGuard with errors.Is.
+type MassDeletionError struct {
+	Count        int // Live issues the import would tombstone
+	Total        int // Live issues in the database
+	LimitPercent int // MassDeletionPercentConfigKey in effect
+}
+
+func (e *MassDeletionError) Error() string {
+	return fmt.Sprintf("import would delete %d of %d issues (%.1f%%, limit %d%%); if this is intended, rerun with --confirm-mass-delete",
+		e.Count, e.Total, float64(e.Count)/float64(e.Total)*100, e.LimitPercent)
+}
+
+// Is reports whether target is ErrMassDeletionGuard
+func (e *MassDeletionError) Is(target error) bool {
+	return target == ErrMassDeletionGuard
+}
+
+// massDeletionPercent reads MassDeletionPercentConfigKey
+func massDeletionPercent(ctx context.Context, sqliteStore *sqlite.SQLiteStorage) int {
+	value, err := sqliteStore.GetConfig(ctx, MassDeletionPercentConfigKey)
+	if err != nil {
+		return DefaultMassDeletionPercent
+	}
+	percent, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || percent < 0 || percent > 100 {
+		return DefaultMassDeletionPercent
+	}
+	return percent
+}
+
+// checkMassDeletion returns a *MassDeletionError if importing issues would
+// tombstone more live issues than MassDeletionPercentConfigKey allows. The
+// deletions counted are incoming tombstones, including those converted from
+// the deletions manifest, for issues that are live in the database; the git
+// history fallback in planPurge has its own guard.
+func checkMassDeletion(ctx context.Context, sqliteStore *sqlite.SQLiteStorage, issues []*types.Issue, opts Options) error {
+	if opts.AllowMassDeletion || opts.DryRun {
+		return nil
+	}
+	live, err := sqliteStore.SearchIssues(ctx, "", types.IssueFilter{IncludeSnoozed: true})
+	if err != nil {
+		return fmt.Errorf("failed to get DB issues: %w", err)
+	}
+	liveIDs := make(map[string]bool, len(live))
+	for _, issue := range live {
+		liveIDs[issue.ID] = true
+	}
+
+	count := 0
+	for _, issue := range issues {
+		if issue.IsTombstone() && liveIDs[issue.ID] {
+			count++
+			delete(liveIDs, issue.ID) // Count each issue once
+		}
+	}
+	if count < massDeletionMinCount {
+		return nil
+	}
+	limit := massDeletionPercent(ctx, sqliteStore)
+	if count*100 > limit*len(live) {
+		return &MassDeletionError{Count: count, Total: len(live), LimitPercent: limit}
+	}
+	return nil
+}
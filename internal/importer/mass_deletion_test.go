@@ -0,0 +1,99 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// TestMassDeletionGuard imports a stale JSONL that tombstones all but one of
+// the database's issues. TestMassDeletionSafetyGuard covers the git history
+// fallback, where the JSONL is simply near-empty.
+func TestMassDeletionGuard(t *testing.T) {
+	ctx := context.Background()
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "beads.db")
+	store, err := sqlite.New(ctx, dbPath)
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	defer store.Close()
+	if err := store.SetConfig(ctx, "issue_prefix", "test"); err != nil {
+		t.Fatalf("failed to set prefix: %v", err)
+	}
+
+	var existing []*types.Issue
+	for i := 0; i < 10; i++ {
+		issue := &types.Issue{
+			ID:        fmt.Sprintf("test-mass%d", i),
+			Title:     fmt.Sprintf("Issue %d", i),
+			Status:    types.StatusOpen,
+			Priority:  2,
+			IssueType: types.TypeTask,
+		}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("failed to create issue %s: %v", issue.ID, err)
+		}
+		existing = append(existing, issue)
+	}
+
+	liveCount := func() int {
+		t.Helper()
+		issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+		if err != nil {
+			t.Fatalf("SearchIssues failed: %v", err)
+		}
+		return len(issues)
+	}
+	// Each import gets fresh copies, since the importer mutates issues
+	stale := func() []*types.Issue {
+		deletedAt := time.Now().Add(time.Minute).UTC()
+		var issues []*types.Issue
+		for i, issue := range existing {
+			copied := *issue
+			if i > 0 {
+				copied.Status = types.StatusTombstone
+				copied.DeletedAt = &deletedAt
+				copied.UpdatedAt = deletedAt
+			}
+			issues = append(issues, &copied)
+		}
+		return issues
+	}
+
+	_, err = ImportIssues(ctx, dbPath, store, stale(), Options{NoGitHistory: true})
+	if !errors.Is(err, ErrMassDeletionGuard) {
+		t.Fatalf("Expected ErrMassDeletionGuard, got %v", err)
+	}
+	var massErr *MassDeletionError
+	if !errors.As(err, &massErr) || massErr.Count != 9 || massErr.Total != 10 || massErr.LimitPercent != DefaultMassDeletionPercent {
+		t.Errorf("Expected 9 of 10 deletions refused at %d%%, got %+v", DefaultMassDeletionPercent, massErr)
+	}
+	if n := liveCount(); n != 10 {
+		t.Fatalf("Expected the guard to leave all 10 issues, got %d", n)
+	}
+
+	// A higher limit lets it through
+	if err := store.SetConfig(ctx, MassDeletionPercentConfigKey, "95"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	if err := checkMassDeletion(ctx, store, stale(), Options{}); err != nil {
+		t.Errorf("Expected 90%% to be within a 95%% limit, got %v", err)
+	}
+	if err := store.SetConfig(ctx, MassDeletionPercentConfigKey, "50"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+
+	if _, err := ImportIssues(ctx, dbPath, store, stale(), Options{NoGitHistory: true, AllowMassDeletion: true}); err != nil {
+		t.Fatalf("Expected confirmed import to proceed, got %v", err)
+	}
+	if n := liveCount(); n != 1 {
+		t.Errorf("Expected confirmed import to delete 9 issues, got %d left", n)
+	}
+}
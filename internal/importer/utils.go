@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/utils"
@@ -85,6 +86,17 @@ func (fc *fieldComparator) equalPtrStr(existing *string, newVal interface{}) boo
 	return *existing == s
 }
 
+func (fc *fieldComparator) equalPtrTime(existing *time.Time, newVal interface{}) bool {
+	switch t := newVal.(type) {
+	case nil:
+		return existing == nil
+	case time.Time:
+		return existing != nil && existing.Equal(t)
+	default:
+		return false
+	}
+}
+
 func (fc *fieldComparator) equalStatus(existing types.Status, newVal interface{}) bool {
 	switch t := newVal.(type) {
 	case types.Status:
@@ -134,6 +146,8 @@ func (fc *fieldComparator) checkFieldChanged(key string, existing *types.Issue,
 		return !fc.equalStr(existing.Assignee, newVal)
 	case "external_ref":
 		return !fc.equalPtrStr(existing.ExternalRef, newVal)
+	case "due_date":
+		return !fc.equalPtrTime(existing.DueDate, newVal)
 	default:
 		return false
 	}
@@ -0,0 +1,284 @@
+package merge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ConflictStrategy decides which side wins when both sides of a conflict
+// hunk contain the same issue
+type ConflictStrategy string
+
+// Conflict strategy constants
+const (
+	// StrategyNewest keeps the version with the later updated_at (ours on a tie)
+	StrategyNewest ConflictStrategy = "newest"
+	// StrategyOurs keeps the version from the current branch
+	StrategyOurs ConflictStrategy = "ours"
+	// StrategyTheirs keeps the version from the branch being merged in
+	StrategyTheirs ConflictStrategy = "theirs"
+)
+
+// ConflictStrategyConfigKey is the config key that lets auto-import resolve
+// conflict markers in the JSONL file with the given ConflictStrategy. Unset,
+// auto-import refuses to load a conflicted file.
+const ConflictStrategyConfigKey = "import.conflict_strategy"
+
+func init() {
+	storage.RegisterConfigKey(ConflictStrategyConfigKey, storage.ValidateOneOf(
+		string(StrategyNewest), string(StrategyOurs), string(StrategyTheirs)))
+}
+
+// IsValid checks if the conflict strategy is supported
+func (s ConflictStrategy) IsValid() bool {
+	switch s {
+	case StrategyNewest, StrategyOurs, StrategyTheirs:
+		return true
+	}
+	return false
+}
+
+// ResolveReport summarizes a ResolveJSONLConflicts run
+type ResolveReport struct {
+	Path       string // File that was resolved
+	Hunks      int    // Conflict hunks found
+	Issues     int    // Issues in the resolved file
+	KeptOurs   int    // Issues on both sides resolved to ours
+	KeptTheirs int    // Issues on both sides resolved to theirs
+}
+
+var (
+	markerOurs   = []byte("<<<<<<< ")
+	markerBase   = []byte("||||||| ")
+	markerSep    = []byte("=======")
+	markerTheirs = []byte(">>>>>>> ")
+)
+
+// HasConflictMarkers reports whether JSONL data contains git conflict
+// markers. Markers only count as standalone lines, so issue text quoting them
+// inside a JSON string is not mistaken for a conflict.
+func HasConflictMarkers(data []byte) bool {
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		trimmed := bytes.TrimSpace(line)
+		if bytes.HasPrefix(trimmed, markerOurs) ||
+			bytes.Equal(trimmed, markerSep) ||
+			bytes.HasPrefix(trimmed, markerTheirs) {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveJSONLConflicts rewrites the JSONL file at path, resolving git
+// conflict markers with StrategyNewest. See ResolveJSONLConflictsWithStrategy.
+func ResolveJSONLConflicts(ctx context.Context, path string) (ResolveReport, error) {
+	return ResolveJSONLConflictsWithStrategy(ctx, path, StrategyNewest)
+}
+
+// ResolveJSONLConflictsWithStrategy rewrites the JSONL file at path without
+// git conflict markers. Both sides of every hunk are parsed and merged issue
+// by issue: issues present on one side only are kept, and issues present on
+// both are decided by strategy. Lines outside hunks are kept as they are and
+// diff3 base sections are dropped. The file is replaced atomically, and left
+// untouched if any line in a hunk is not a valid issue.
+func ResolveJSONLConflictsWithStrategy(ctx context.Context, path string, strategy ConflictStrategy) (ResolveReport, error) {
+	report := ResolveReport{Path: path}
+	if !strategy.IsValid() {
+		return report, fmt.Errorf("invalid conflict strategy: %s (valid: newest, ours, theirs)", strategy)
+	}
+
+	data, err := os.ReadFile(path) // #nosec G304 - caller-controlled JSONL path
+	if err != nil {
+		return report, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	resolved, err := resolveConflicts(ctx, data, strategy, &report)
+	if err != nil {
+		return report, fmt.Errorf("failed to resolve conflicts in %s: %w", path, err)
+	}
+	if report.Hunks == 0 {
+		return report, nil
+	}
+
+	if err := writeFileAtomic(path, resolved); err != nil {
+		return report, err
+	}
+	return report, nil
+}
+
+// conflictLine is one JSONL line with the fields needed to merge it
+type conflictLine struct {
+	id        string
+	updatedAt string
+	raw       []byte
+}
+
+func parseConflictLine(raw []byte) (conflictLine, error) {
+	normalized, err := types.NormalizeTimestamps(raw)
+	if err != nil {
+		return conflictLine{}, err
+	}
+	var fields struct {
+		ID        string `json:"id"`
+		UpdatedAt string `json:"updated_at"`
+	}
+	if err := json.Unmarshal(normalized, &fields); err != nil {
+		return conflictLine{}, err
+	}
+	if fields.ID == "" {
+		return conflictLine{}, fmt.Errorf("missing id")
+	}
+	return conflictLine{id: fields.ID, updatedAt: fields.UpdatedAt, raw: raw}, nil
+}
+
+// resolveConflicts returns data with every conflict hunk replaced by its
+// merged issues, updating report as it goes
+func resolveConflicts(ctx context.Context, data []byte, strategy ConflictStrategy, report *ResolveReport) ([]byte, error) {
+	const (
+		outside = iota
+		inOurs
+		inBase
+		inTheirs
+	)
+
+	var out [][]byte
+	var ours, theirs []conflictLine
+	state := outside
+
+	for i, line := range bytes.Split(data, []byte("\n")) {
+		lineNo := i + 1
+		trimmed := bytes.TrimSpace(line)
+
+		switch {
+		case bytes.HasPrefix(trimmed, markerOurs):
+			if state != outside {
+				return nil, fmt.Errorf("line %d: nested conflict marker", lineNo)
+			}
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			state = inOurs
+			ours, theirs = nil, nil
+			report.Hunks++
+			continue
+		case bytes.HasPrefix(trimmed, markerBase) && state == inOurs:
+			state = inBase
+			continue
+		case bytes.Equal(trimmed, markerSep):
+			if state != inOurs && state != inBase {
+				return nil, fmt.Errorf("line %d: unexpected conflict separator", lineNo)
+			}
+			state = inTheirs
+			continue
+		case bytes.HasPrefix(trimmed, markerTheirs):
+			if state != inTheirs {
+				return nil, fmt.Errorf("line %d: unexpected end of conflict", lineNo)
+			}
+			state = outside
+			for _, l := range mergeConflictSides(ours, theirs, strategy, report) {
+				out = append(out, l.raw)
+			}
+			continue
+		}
+
+		if state == outside {
+			out = append(out, line)
+			continue
+		}
+		if state == inBase || len(trimmed) == 0 {
+			continue
+		}
+		parsed, err := parseConflictLine(trimmed)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		if state == inOurs {
+			ours = append(ours, parsed)
+		} else {
+			theirs = append(theirs, parsed)
+		}
+	}
+	if state != outside {
+		return nil, fmt.Errorf("unterminated conflict hunk")
+	}
+
+	resolved := bytes.Join(out, []byte("\n"))
+	for _, line := range out {
+		if len(bytes.TrimSpace(line)) > 0 {
+			report.Issues++
+		}
+	}
+	return resolved, nil
+}
+
+// mergeConflictSides merges the issues of one hunk: ours in order, then
+// issues only theirs has, with issues on both sides decided by strategy
+func mergeConflictSides(ours, theirs []conflictLine, strategy ConflictStrategy, report *ResolveReport) []conflictLine {
+	theirsByID := make(map[string]conflictLine, len(theirs))
+	for _, l := range theirs {
+		theirsByID[l.id] = l
+	}
+
+	merged := make([]conflictLine, 0, len(ours)+len(theirs))
+	seen := make(map[string]bool, len(ours))
+	for _, o := range ours {
+		seen[o.id] = true
+		t, both := theirsByID[o.id]
+		if !both {
+			merged = append(merged, o)
+			continue
+		}
+		if strategy == StrategyTheirs || (strategy == StrategyNewest && !isTimeAfter(o.updatedAt, t.updatedAt)) {
+			merged = append(merged, t)
+			report.KeptTheirs++
+		} else {
+			merged = append(merged, o)
+			report.KeptOurs++
+		}
+	}
+	for _, t := range theirs {
+		if !seen[t.id] {
+			merged = append(merged, t)
+		}
+	}
+	return merged
+}
+
+// writeFileAtomic replaces path with data via a temp file in the same directory
+func writeFileAtomic(path string, data []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to write temp file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to close temp file: %w", err)
+	}
+	if err := os.Chmod(tmpPath, info.Mode().Perm()); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to set permissions: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to replace %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package merge
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const conflictedJSONL = `{"id":"bd-1","title":"Unchanged","updated_at":"2025-01-01T00:00:00Z"}
+<<<<<<< HEAD
+{"id":"bd-2","title":"Ours newer","updated_at":"2025-01-03T00:00:00Z"}
+{"id":"bd-3","title":"Ours older","updated_at":"2025-01-01T00:00:00Z"}
+{"id":"bd-4","title":"Only ours","updated_at":"2025-01-01T00:00:00Z"}
+||||||| base
+{"id":"bd-2","title":"Base","updated_at":"2024-12-31T00:00:00Z"}
+=======
+{"id":"bd-2","title":"Theirs older","updated_at":"2025-01-02T00:00:00Z"}
+{"id":"bd-3","title":"Theirs newer","updated_at":1735776000000}
+{"id":"bd-5","title":"Only theirs","updated_at":"2025-01-01T00:00:00Z"}
+>>>>>>> feature
+{"id":"bd-6","title":"After","updated_at":"2025-01-01T00:00:00Z"}
+`
+
+func TestResolveJSONLConflicts(t *testing.T) {
+	tests := []struct {
+		strategy ConflictStrategy
+		want     []string
+		ours     int
+		theirs   int
+	}{
+		{StrategyNewest, []string{"Unchanged", "Ours newer", "Theirs newer", "Only ours", "Only theirs", "After"}, 1, 1},
+		{StrategyOurs, []string{"Unchanged", "Ours newer", "Ours older", "Only ours", "Only theirs", "After"}, 2, 0},
+		{StrategyTheirs, []string{"Unchanged", "Theirs older", "Theirs newer", "Only ours", "Only theirs", "After"}, 0, 2},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.strategy), func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "issues.jsonl")
+			if err := os.WriteFile(path, []byte(conflictedJSONL), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			report, err := ResolveJSONLConflictsWithStrategy(context.Background(), path, tt.strategy)
+			if err != nil {
+				t.Fatalf("ResolveJSONLConflictsWithStrategy failed: %v", err)
+			}
+			if report.Hunks != 1 || report.Issues != 6 || report.KeptOurs != tt.ours || report.KeptTheirs != tt.theirs {
+				t.Errorf("Unexpected report: %+v", report)
+			}
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if HasConflictMarkers(data) {
+				t.Fatalf("Expected markers to be gone, got:\n%s", data)
+			}
+			lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+			if len(lines) != len(tt.want) {
+				t.Fatalf("Expected %d lines, got %d:\n%s", len(tt.want), len(lines), data)
+			}
+			for i, title := range tt.want {
+				if !strings.Contains(lines[i], `"title":"`+title+`"`) {
+					t.Errorf("Line %d: expected %q, got %s", i+1, title, lines[i])
+				}
+			}
+		})
+	}
+}
+
+func TestResolveJSONLConflictsErrors(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	clean := filepath.Join(dir, "clean.jsonl")
+	if err := os.WriteFile(clean, []byte(`{"id":"bd-1"}`+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	report, err := ResolveJSONLConflicts(ctx, clean)
+	if err != nil || report.Hunks != 0 {
+		t.Errorf("Expected clean file to be left alone, got %+v, %v", report, err)
+	}
+
+	broken := filepath.Join(dir, "broken.jsonl")
+	content := "<<<<<<< HEAD\nnot json\n=======\n{\"id\":\"bd-1\"}\n>>>>>>> main\n"
+	if err := os.WriteFile(broken, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ResolveJSONLConflicts(ctx, broken); err == nil {
+		t.Error("Expected error for unparseable line in hunk")
+	}
+	if data, _ := os.ReadFile(broken); string(data) != content {
+		t.Error("Expected file to be untouched after a failed resolution")
+	}
+
+	if _, err := ResolveJSONLConflictsWithStrategy(ctx, clean, "random"); err == nil {
+		t.Error("Expected error for invalid strategy")
+	}
+}
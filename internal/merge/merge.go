@@ -32,6 +32,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
 	"time"
 
 	"github.com/steveyegge/beads/internal/types"
@@ -51,12 +52,21 @@ type Issue struct {
 	ClosedAt     string       `json:"closed_at,omitempty"`
 	CreatedBy    string       `json:"created_by,omitempty"`
 	Dependencies []Dependency `json:"dependencies,omitempty"`
+	Labels       []string     `json:"labels,omitempty"`
+	Comments     []Comment    `json:"comments,omitempty"`
+	Links        []Link       `json:"links,omitempty"`
 	RawLine      string       `json:"-"` // Store original line for conflict output
 	// Tombstone fields (bd-0ih): inline soft-delete support for merge
 	DeletedAt    string `json:"deleted_at,omitempty"`    // When the issue was deleted
 	DeletedBy    string `json:"deleted_by,omitempty"`    // Who deleted the issue
 	DeleteReason string `json:"delete_reason,omitempty"` // Why the issue was deleted
 	OriginalType string `json:"original_type,omitempty"` // Issue type before deletion
+	// Fields added since the merge driver was vendored; see types.Issue
+	Draft                 bool   `json:"draft,omitempty"`
+	PercentComplete       int    `json:"percent_complete,omitempty"`
+	ExternalBlockedReason string `json:"external_blocked_reason,omitempty"`
+	SnoozedUntil          string `json:"snoozed_until,omitempty"`
+	DueDate               string `json:"due_date,omitempty"`
 }
 
 // Dependency represents an issue dependency
@@ -66,6 +76,26 @@ type Dependency struct {
 	Type        string `json:"type"`
 	CreatedAt   string `json:"created_at"`
 	CreatedBy   string `json:"created_by"`
+	Note        string `json:"note,omitempty"`
+}
+
+// Comment represents a comment on an issue
+type Comment struct {
+	ID        int64  `json:"id"`
+	IssueID   string `json:"issue_id"`
+	Author    string `json:"author"`
+	Text      string `json:"text"`
+	CreatedAt string `json:"created_at"`
+}
+
+// Link represents a URL attached to an issue
+type Link struct {
+	ID        int64  `json:"id"`
+	IssueID   string `json:"issue_id"`
+	URL       string `json:"url"`
+	Title     string `json:"title,omitempty"`
+	Kind      string `json:"kind"`
+	CreatedAt string `json:"created_at"`
 }
 
 // IssueKey uniquely identifies an issue for matching
@@ -217,8 +247,13 @@ func readIssues(path string) ([]Issue, error) {
 			continue
 		}
 
+		// Timestamps are compared as RFC 3339 strings
+		data, err := types.NormalizeTimestamps([]byte(line))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse line %d: %w", lineNum, err)
+		}
 		var issue Issue
-		if err := json.Unmarshal([]byte(line), &issue); err != nil {
+		if err := json.Unmarshal(data, &issue); err != nil {
 			return nil, fmt.Errorf("failed to parse line %d: %w", lineNum, err)
 		}
 		issue.RawLine = line
@@ -527,6 +562,34 @@ func mergeIssue(base, left, right Issue) (Issue, string) {
 	// Merge dependencies - combine and deduplicate
 	result.Dependencies = mergeDependencies(left.Dependencies, right.Dependencies)
 
+	// Merge labels - additions on either side are kept, removals on either side win
+	result.Labels = mergeLabels(base.Labels, left.Labels, right.Labels)
+
+	// Merge comments - append-only, so the union of both sides
+	result.Comments = mergeComments(left.Comments, right.Comments)
+
+	// Merge links - additions on either side are kept, removals on either side win
+	result.Links = mergeLinks(base.Links, left.Links, right.Links)
+
+	// Merge draft - standard 3-way; a bool can't conflict
+	if base.Draft == left.Draft {
+		result.Draft = right.Draft
+	} else {
+		result.Draft = left.Draft
+	}
+
+	// Merge percent_complete - on conflict, the further progress wins
+	result.PercentComplete = mergePercentComplete(base.PercentComplete, left.PercentComplete, right.PercentComplete)
+
+	// Merge external_blocked_reason and due_date - on conflict, side with latest updated_at wins
+	result.ExternalBlockedReason = mergeFieldByUpdatedAt(base.ExternalBlockedReason, left.ExternalBlockedReason, right.ExternalBlockedReason, left.UpdatedAt, right.UpdatedAt)
+	result.DueDate = mergeFieldByUpdatedAt(base.DueDate, left.DueDate, right.DueDate, left.UpdatedAt, right.UpdatedAt)
+
+	// Merge snoozed_until - only if status is snoozed, as with closed_at
+	if result.Status == string(types.StatusSnoozed) {
+		result.SnoozedUntil = mergeFieldByUpdatedAt(base.SnoozedUntil, left.SnoozedUntil, right.SnoozedUntil, left.UpdatedAt, right.UpdatedAt)
+	}
+
 	// bd-1sn: If status became tombstone via mergeStatus safety fallback,
 	// copy tombstone fields from whichever side has them
 	if result.Status == StatusTombstone {
@@ -663,6 +726,21 @@ func mergePriority(base, left, right int) int {
 	return right
 }
 
+// mergePercentComplete handles percent_complete merging - on conflict, the
+// higher value wins, since progress made on either side still happened
+func mergePercentComplete(base, left, right int) int {
+	if base == left && base != right {
+		return right
+	}
+	if base == right && base != left {
+		return left
+	}
+	if left > right {
+		return left
+	}
+	return right
+}
+
 // isTimeAfter returns true if t1 is after t2
 func isTimeAfter(t1, t2 string) bool {
 	if t1 == "" {
@@ -741,23 +819,114 @@ func maxTime(t1, t2 string) string {
 	return t2
 }
 
+func mergeLabels(base, left, right []string) []string {
+	inBase := make(map[string]bool, len(base))
+	for _, label := range base {
+		inBase[label] = true
+	}
+	inLeft := make(map[string]bool, len(left))
+	for _, label := range left {
+		inLeft[label] = true
+	}
+	inRight := make(map[string]bool, len(right))
+	for _, label := range right {
+		inRight[label] = true
+	}
+
+	var result []string
+	for _, label := range left {
+		// Keep unless the right side removed it
+		if inRight[label] || !inBase[label] {
+			result = append(result, label)
+		}
+	}
+	for _, label := range right {
+		// Add what only the right side added
+		if !inLeft[label] && !inBase[label] {
+			result = append(result, label)
+		}
+	}
+	return result
+}
+
+// mergeComments returns the comments of left followed by those only right
+// has, oldest first. Comment IDs are assigned by each database, so the same
+// comment can have different IDs on each side and different comments the
+// same ID; comments match on author, text and time instead.
+func mergeComments(left, right []Comment) []Comment {
+	key := func(c Comment) string {
+		return c.Author + "\x00" + c.Text + "\x00" + c.CreatedAt
+	}
+	seen := make(map[string]bool, len(left))
+	for _, c := range left {
+		seen[key(c)] = true
+	}
+	result := append([]Comment(nil), left...)
+	for _, c := range right {
+		if !seen[key(c)] {
+			seen[key(c)] = true
+			result = append(result, c)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return isTimeAfter(result[j].CreatedAt, result[i].CreatedAt)
+	})
+	return result
+}
+
+// mergeLinks merges links like mergeLabels, matching them on URL: like
+// comment IDs, link IDs are assigned by each database. A link on both sides
+// keeps the left's title and kind.
+func mergeLinks(base, left, right []Link) []Link {
+	inBase := make(map[string]bool, len(base))
+	for _, link := range base {
+		inBase[link.URL] = true
+	}
+	inLeft := make(map[string]bool, len(left))
+	for _, link := range left {
+		inLeft[link.URL] = true
+	}
+	inRight := make(map[string]bool, len(right))
+	for _, link := range right {
+		inRight[link.URL] = true
+	}
+
+	var result []Link
+	for _, link := range left {
+		// Keep unless the right side removed it
+		if inRight[link.URL] || !inBase[link.URL] {
+			result = append(result, link)
+		}
+	}
+	for _, link := range right {
+		// Add what only the right side added
+		if !inLeft[link.URL] && !inBase[link.URL] {
+			result = append(result, link)
+		}
+	}
+	return result
+}
+
 func mergeDependencies(left, right []Dependency) []Dependency {
-	seen := make(map[string]bool)
+	seen := make(map[string]int)
 	var result []Dependency
 
 	for _, dep := range left {
 		key := fmt.Sprintf("%s:%s:%s", dep.IssueID, dep.DependsOnID, dep.Type)
-		if !seen[key] {
-			seen[key] = true
+		if _, ok := seen[key]; !ok {
+			seen[key] = len(result)
 			result = append(result, dep)
 		}
 	}
 
 	for _, dep := range right {
 		key := fmt.Sprintf("%s:%s:%s", dep.IssueID, dep.DependsOnID, dep.Type)
-		if !seen[key] {
-			seen[key] = true
+		if i, ok := seen[key]; !ok {
+			seen[key] = len(result)
 			result = append(result, dep)
+		} else if result[i].Note == "" {
+			// Keep a note only the right side wrote
+			result[i].Note = dep.Note
 		}
 	}
 
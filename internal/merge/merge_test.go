@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -231,6 +232,228 @@ func TestMergeDependencies(t *testing.T) {
 	}
 }
 
+// TestMergeLabels tests three-way label merging
+func TestMergeLabels(t *testing.T) {
+	tests := []struct {
+		name     string
+		base     []string
+		left     []string
+		right    []string
+		expected []string
+	}{
+		{
+			name:     "no changes",
+			base:     []string{"backend"},
+			left:     []string{"backend"},
+			right:    []string{"backend"},
+			expected: []string{"backend"},
+		},
+		{
+			name:     "additions on both sides",
+			base:     []string{"backend"},
+			left:     []string{"backend", "urgent"},
+			right:    []string{"backend", "sprint-3"},
+			expected: []string{"backend", "urgent", "sprint-3"},
+		},
+		{
+			name:     "removal on one side wins",
+			base:     []string{"backend", "urgent"},
+			left:     []string{"backend", "urgent"},
+			right:    []string{"backend"},
+			expected: []string{"backend"},
+		},
+		{
+			name:     "same label added on both sides",
+			base:     nil,
+			left:     []string{"backend"},
+			right:    []string{"backend"},
+			expected: []string{"backend"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := mergeLabels(tt.base, tt.left, tt.right)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("mergeLabels() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMergeComments(t *testing.T) {
+	shared := Comment{ID: 1, IssueID: "bd-1", Author: "alice", Text: "Looks good", CreatedAt: "2024-01-01T00:00:00Z"}
+	// Each side numbered its new comment 2
+	left := Comment{ID: 2, IssueID: "bd-1", Author: "bob", Text: "From left", CreatedAt: "2024-01-03T00:00:00Z"}
+	right := Comment{ID: 2, IssueID: "bd-1", Author: "carol", Text: "From right", CreatedAt: "2024-01-02T00:00:00Z"}
+	// The same comment with an ID of its own on the right
+	sharedRight := shared
+	sharedRight.ID = 7
+
+	result := mergeComments([]Comment{shared, left}, []Comment{sharedRight, right})
+	expected := []Comment{shared, right, left}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("mergeComments() = %v, want %v", result, expected)
+	}
+}
+
+func TestMergeLinks(t *testing.T) {
+	docs := Link{ID: 1, IssueID: "bd-1", URL: "https://example.com/docs", Kind: "doc", CreatedAt: "2024-01-01T00:00:00Z"}
+	pr := Link{ID: 2, IssueID: "bd-1", URL: "https://example.com/pr/1", Kind: "pr", CreatedAt: "2024-01-02T00:00:00Z"}
+	// Added on the right and numbered 2 there too
+	design := Link{ID: 2, IssueID: "bd-1", URL: "https://example.com/design", Kind: "design", CreatedAt: "2024-01-03T00:00:00Z"}
+
+	// The left removed the docs link and added the PR
+	result := mergeLinks([]Link{docs}, []Link{pr}, []Link{docs, design})
+	expected := []Link{pr, design}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("mergeLinks() = %v, want %v", result, expected)
+	}
+}
+
+func TestMergeDependencies_Note(t *testing.T) {
+	dep := Dependency{IssueID: "bd-1", DependsOnID: "bd-2", Type: "blocks", CreatedAt: "2024-01-01T00:00:00Z", CreatedBy: "alice"}
+	noted := dep
+	noted.Note = "shared schema"
+
+	for _, tt := range []struct {
+		name        string
+		left, right Dependency
+	}{
+		{"note on left", noted, dep},
+		{"note on right", dep, noted},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := mergeDependencies([]Dependency{tt.left}, []Dependency{tt.right})
+			if len(result) != 1 || result[0].Note != "shared schema" {
+				t.Errorf("mergeDependencies() = %v, want the note kept", result)
+			}
+		})
+	}
+}
+
+func TestMergeIssue_Draft(t *testing.T) {
+	base := Issue{ID: "bd-1", Draft: true}
+	left := Issue{ID: "bd-1", Draft: true}
+	right := Issue{ID: "bd-1", Draft: false}
+
+	result, _ := mergeIssue(base, left, right)
+	if result.Draft {
+		t.Error("Expected the right side's publish to carry through the merge")
+	}
+	result, _ = mergeIssue(base, right, left)
+	if result.Draft {
+		t.Error("Expected the left side's publish to carry through the merge")
+	}
+}
+
+func TestMergeIssue_PercentComplete(t *testing.T) {
+	tests := []struct {
+		name              string
+		base, left, right int
+		expected          int
+	}{
+		{"only right changed", 20, 20, 60, 60},
+		{"only left lowered", 60, 40, 60, 40},
+		{"both changed, further progress wins", 20, 50, 80, 80},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, _ := mergeIssue(
+				Issue{ID: "bd-1", PercentComplete: tt.base},
+				Issue{ID: "bd-1", PercentComplete: tt.left},
+				Issue{ID: "bd-1", PercentComplete: tt.right},
+			)
+			if result.PercentComplete != tt.expected {
+				t.Errorf("PercentComplete = %d, want %d", result.PercentComplete, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMergeIssue_DueDate(t *testing.T) {
+	base := Issue{ID: "bd-1", DueDate: "2024-03-01T00:00:00Z", UpdatedAt: "2024-01-01T00:00:00Z"}
+	left := Issue{ID: "bd-1", DueDate: "2024-03-15T00:00:00Z", UpdatedAt: "2024-01-03T00:00:00Z"}
+	right := Issue{ID: "bd-1", DueDate: "2024-04-01T00:00:00Z", UpdatedAt: "2024-01-02T00:00:00Z"}
+
+	// Both sides moved it, so the later update wins
+	result, _ := mergeIssue(base, left, right)
+	if result.DueDate != left.DueDate {
+		t.Errorf("DueDate = %q, want %q", result.DueDate, left.DueDate)
+	}
+	// A due date cleared on one side stays cleared
+	right.DueDate = ""
+	result, _ = mergeIssue(base, base, right)
+	if result.DueDate != "" {
+		t.Errorf("DueDate = %q, want it cleared", result.DueDate)
+	}
+}
+
+func TestMergeIssue_ExternalBlockedReason(t *testing.T) {
+	base := Issue{ID: "bd-1"}
+	left := Issue{ID: "bd-1", ExternalBlockedReason: "waiting on vendor"}
+
+	result, _ := mergeIssue(base, left, base)
+	if result.ExternalBlockedReason != "waiting on vendor" {
+		t.Errorf("ExternalBlockedReason = %q, want the left's reason", result.ExternalBlockedReason)
+	}
+}
+
+func TestMergeIssue_SnoozedUntil(t *testing.T) {
+	base := Issue{ID: "bd-1", Status: "open"}
+	left := Issue{ID: "bd-1", Status: "snoozed", SnoozedUntil: "2024-02-01T00:00:00Z"}
+
+	result, _ := mergeIssue(base, left, base)
+	if result.Status != "snoozed" || result.SnoozedUntil != left.SnoozedUntil {
+		t.Errorf("Expected the snooze kept, got status %q until %q", result.Status, result.SnoozedUntil)
+	}
+	// Closing on the other side wakes the issue, so the wake date goes
+	closed := Issue{ID: "bd-1", Status: "closed", ClosedAt: "2024-01-05T00:00:00Z"}
+	result, _ = mergeIssue(base, left, closed)
+	if result.SnoozedUntil != "" {
+		t.Errorf("Expected no snoozed_until on a closed issue, got %q", result.SnoozedUntil)
+	}
+}
+
+// TestMerge3Way_KeepsNewerFields checks that fields added to types.Issue
+// after the merge driver was vendored survive a merge that doesn't touch them
+func TestMerge3Way_KeepsNewerFields(t *testing.T) {
+	dir := t.TempDir()
+	line := `{"id":"bd-1","title":"Ship it","status":"snoozed","priority":2,"issue_type":"task","created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-02T00:00:00Z",` +
+		`"dependencies":[{"issue_id":"bd-1","depends_on_id":"bd-2","type":"blocks","created_at":"2024-01-01T00:00:00Z","created_by":"alice","note":"shared schema"}],` +
+		`"links":[{"id":1,"issue_id":"bd-1","url":"https://example.com/pr/1","kind":"pr","created_at":"2024-01-01T00:00:00Z"}],` +
+		`"draft":true,"percent_complete":40,"external_blocked_reason":"vendor","snoozed_until":"2024-02-01T00:00:00Z","due_date":"2024-03-01T00:00:00Z"}`
+	var paths []string
+	for _, name := range []string{"base", "left", "right"} {
+		path := filepath.Join(dir, name+".jsonl")
+		if err := os.WriteFile(path, []byte(line+"\n"), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+		paths = append(paths, path)
+	}
+	output := filepath.Join(dir, "output.jsonl")
+	if err := Merge3Way(output, paths[0], paths[1], paths[2], false); err != nil {
+		t.Fatalf("Merge3Way failed: %v", err)
+	}
+
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+	var want, got map[string]interface{}
+	if err := json.Unmarshal([]byte(line), &want); err != nil {
+		t.Fatalf("Failed to parse input: %v", err)
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Failed to parse output: %v", err)
+	}
+	for _, field := range []string{"dependencies", "links", "draft", "percent_complete", "external_blocked_reason", "snoozed_until", "due_date"} {
+		if !reflect.DeepEqual(got[field], want[field]) {
+			t.Errorf("%s = %v after merge, want %v", field, got[field], want[field])
+		}
+	}
+}
+
 // TestMaxTime tests timestamp merging (max wins)
 func TestMaxTime(t *testing.T) {
 	tests := []struct {
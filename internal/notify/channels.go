@@ -0,0 +1,69 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// DefaultWebhookTimeout bounds each webhook request when no Client is set
+const DefaultWebhookTimeout = 10 * time.Second
+
+// WebhookNotifier POSTs each event as JSON to URL. Any non-2xx response is
+// an error.
+type WebhookNotifier struct {
+	URL    string
+	Client *http.Client // nil uses a client with DefaultWebhookTimeout
+}
+
+// NewWebhookNotifier creates a webhook notifier posting to url
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{URL: url}
+}
+
+// Notify posts event to the webhook
+func (w *WebhookNotifier) Notify(ctx context.Context, event types.Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := w.Client
+	if client == nil {
+		client = &http.Client{Timeout: DefaultWebhookTimeout}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// LogNotifier writes a one-line summary of each event with Logf, such as
+// the daemon's log
+type LogNotifier struct {
+	Logf func(format string, args ...interface{})
+}
+
+// Notify logs event
+func (l *LogNotifier) Notify(_ context.Context, event types.Event) error {
+	l.Logf("Event %d: %s %s by %s", event.ID, event.EventType, event.IssueID, event.Actor)
+	return nil
+}
@@ -0,0 +1,71 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+// Configuration keys for the built-in notifiers
+const (
+	ConfigKeyWebhookURL    = "notify.webhook.url"
+	ConfigKeyWebhookEvents = "notify.webhook.events"
+	ConfigKeyLog           = "notify.log"
+	ConfigKeyLogEvents     = "notify.log.events"
+)
+
+func init() {
+	storage.RegisterConfigKey(ConfigKeyWebhookURL, nil)
+	storage.RegisterConfigKey(ConfigKeyWebhookEvents, nil)
+	storage.RegisterConfigKey(ConfigKeyLog, storage.ValidateBoolConfig)
+	storage.RegisterConfigKey(ConfigKeyLogEvents, nil)
+}
+
+// ConfigStore defines the minimal storage interface needed for config
+type ConfigStore interface {
+	GetConfig(ctx context.Context, key string) (string, error)
+}
+
+// LoadRegistry builds a registry of the built-in notifiers enabled in
+// config: "webhook" if ConfigKeyWebhookURL is set, and "log" (writing with
+// logf) if ConfigKeyLog is "true". Each notifier's events key, a
+// comma-separated list of event types, becomes its filter.
+func LoadRegistry(ctx context.Context, store ConfigStore, logf func(format string, args ...interface{})) (*Registry, error) {
+	get := func(key string) (string, error) {
+		value, err := store.GetConfig(ctx, key)
+		if err != nil {
+			return "", fmt.Errorf("failed to read config %s: %w", key, err)
+		}
+		return strings.TrimSpace(value), nil
+	}
+
+	registry := NewRegistry()
+
+	url, err := get(ConfigKeyWebhookURL)
+	if err != nil {
+		return nil, err
+	}
+	if url != "" {
+		events, err := get(ConfigKeyWebhookEvents)
+		if err != nil {
+			return nil, err
+		}
+		registry.Register("webhook", NewWebhookNotifier(url), ParseFilter(events))
+	}
+
+	enabled, err := get(ConfigKeyLog)
+	if err != nil {
+		return nil, err
+	}
+	if enabled == "true" {
+		events, err := get(ConfigKeyLogEvents)
+		if err != nil {
+			return nil, err
+		}
+		registry.Register("log", &LogNotifier{Logf: logf}, ParseFilter(events))
+	}
+
+	return registry, nil
+}
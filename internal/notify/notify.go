@@ -0,0 +1,149 @@
+// Package notify delivers issue events to pluggable notification channels.
+//
+// A Notifier is one channel (webhook, log, chat, email, ...). Notifiers are
+// added to a Registry together with a Filter choosing the events they care
+// about; the daemon feeds the registry every new event from the store's
+// event log, so core code only records events and never knows who is told.
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Notifier delivers one event to a notification channel
+type Notifier interface {
+	Notify(ctx context.Context, event types.Event) error
+}
+
+// NotifierFunc adapts a function to the Notifier interface
+type NotifierFunc func(ctx context.Context, event types.Event) error
+
+// Notify calls f(ctx, event)
+func (f NotifierFunc) Notify(ctx context.Context, event types.Event) error {
+	return f(ctx, event)
+}
+
+// Filter selects the events a notifier receives. The zero Filter matches
+// every event.
+type Filter struct {
+	EventTypes []types.EventType // Only these event types; empty means all
+}
+
+// ParseFilter parses a comma-separated list of event types, as stored in
+// the notify.<name>.events config keys
+func ParseFilter(s string) Filter {
+	var filter Filter
+	for _, eventType := range strings.Split(s, ",") {
+		if eventType = strings.TrimSpace(eventType); eventType != "" {
+			filter.EventTypes = append(filter.EventTypes, types.EventType(eventType))
+		}
+	}
+	return filter
+}
+
+// Matches reports whether event passes the filter
+func (f Filter) Matches(event types.Event) bool {
+	return len(f.EventTypes) == 0 || slices.Contains(f.EventTypes, event.EventType)
+}
+
+// registration is a notifier added to a Registry
+type registration struct {
+	name     string
+	notifier Notifier
+	filter   Filter
+}
+
+// Registry fans events out to the registered notifiers whose filters match.
+// It is safe for concurrent use.
+type Registry struct {
+	mu            sync.RWMutex
+	registrations []registration
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register adds notifier under name, replacing any notifier already
+// registered with that name
+func (r *Registry) Register(name string, notifier Notifier, filter Filter) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.registrations {
+		if r.registrations[i].name == name {
+			r.registrations[i] = registration{name: name, notifier: notifier, filter: filter}
+			return
+		}
+	}
+	r.registrations = append(r.registrations, registration{name: name, notifier: notifier, filter: filter})
+}
+
+// Names returns the names of the registered notifiers in registration order
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, len(r.registrations))
+	for i, reg := range r.registrations {
+		names[i] = reg.name
+	}
+	return names
+}
+
+// Dispatch sends event to every notifier whose filter matches it. A failing
+// notifier does not stop the others; their errors are joined.
+func (r *Registry) Dispatch(ctx context.Context, event types.Event) error {
+	r.mu.RLock()
+	registrations := slices.Clone(r.registrations)
+	r.mu.RUnlock()
+
+	var errs []error
+	for _, reg := range registrations {
+		if !reg.filter.Matches(event) {
+			continue
+		}
+		if err := reg.notifier.Notify(ctx, event); err != nil {
+			errs = append(errs, fmt.Errorf("notifier %s: %w", reg.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// EventSource is an event log readable by revision, such as
+// sqlite.SQLiteStorage
+type EventSource interface {
+	EventsSince(ctx context.Context, sinceRevision int64, limit int) ([]*types.Event, error)
+}
+
+// dispatchBatch is how many events DispatchSince reads at a time
+const dispatchBatch = 500
+
+// DispatchSince dispatches every event in src after sinceRevision, oldest
+// first, and returns the revision of the last event dispatched. Delivery is
+// at most once: events whose notifiers fail are not retried, and their
+// errors are joined into the returned error.
+func (r *Registry) DispatchSince(ctx context.Context, src EventSource, sinceRevision int64) (int64, error) {
+	var errs []error
+	for {
+		events, err := src.EventsSince(ctx, sinceRevision, dispatchBatch)
+		if err != nil {
+			return sinceRevision, errors.Join(append(errs, err)...)
+		}
+		for _, event := range events {
+			if err := r.Dispatch(ctx, *event); err != nil {
+				errs = append(errs, fmt.Errorf("event %d: %w", event.ID, err))
+			}
+			sinceRevision = event.ID
+		}
+		if len(events) < dispatchBatch {
+			return sinceRevision, errors.Join(errs...)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// fakeNotifier records the events it receives
+type fakeNotifier struct {
+	mu     sync.Mutex
+	events []types.Event
+	err    error
+}
+
+func (f *fakeNotifier) Notify(ctx context.Context, event types.Event) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.events = append(f.events, event)
+	return f.err
+}
+
+func (f *fakeNotifier) eventTypes() []types.EventType {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var eventTypes []types.EventType
+	for _, event := range f.events {
+		eventTypes = append(eventTypes, event.EventType)
+	}
+	return eventTypes
+}
+
+// mapConfigStore is an in-memory ConfigStore
+type mapConfigStore map[string]string
+
+func (m mapConfigStore) GetConfig(ctx context.Context, key string) (string, error) {
+	return m[key], nil
+}
+
+func TestDispatchSince(t *testing.T) {
+	ctx := context.Background()
+	store, err := sqlite.New(ctx, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer store.Close()
+	if err := store.SetConfig(ctx, "issue_prefix", "bd"); err != nil {
+		t.Fatalf("Failed to set prefix: %v", err)
+	}
+
+	start, err := store.CurrentRevision(ctx)
+	if err != nil {
+		t.Fatalf("CurrentRevision failed: %v", err)
+	}
+
+	all := &fakeNotifier{}
+	closes := &fakeNotifier{}
+	failing := &fakeNotifier{err: errors.New("channel down")}
+	registry := NewRegistry()
+	registry.Register("all", all, Filter{})
+	registry.Register("closes", closes, ParseFilter("closed, reopened"))
+	registry.Register("failing", failing, ParseFilter("created"))
+
+	issue := &types.Issue{Title: "Notify me", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := store.CloseIssue(ctx, issue.ID, "done", "test"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+
+	revision, err := registry.DispatchSince(ctx, store, start)
+	if err == nil {
+		t.Error("Expected the failing notifier's error to be reported")
+	}
+	if want := []types.EventType{types.EventCreated, types.EventClosed}; !reflect.DeepEqual(all.eventTypes(), want) {
+		t.Errorf("Expected unfiltered notifier to get %v, got %v", want, all.eventTypes())
+	}
+	if want := []types.EventType{types.EventClosed}; !reflect.DeepEqual(closes.eventTypes(), want) {
+		t.Errorf("Expected filtered notifier to get %v, got %v", want, closes.eventTypes())
+	}
+	if len(failing.eventTypes()) != 1 {
+		t.Errorf("Expected failing notifier to be called once, got %d", len(failing.eventTypes()))
+	}
+	if all.events[0].IssueID != issue.ID {
+		t.Errorf("Expected events for %s, got %s", issue.ID, all.events[0].IssueID)
+	}
+
+	// Nothing new since the returned revision
+	if revision, err = registry.DispatchSince(ctx, store, revision); err != nil {
+		t.Fatalf("DispatchSince failed: %v", err)
+	}
+	if len(all.eventTypes()) != 2 {
+		t.Errorf("Expected no events to be redelivered, got %v", all.eventTypes())
+	}
+}
+
+func TestLoadRegistryWebhook(t *testing.T) {
+	received := make(chan types.Event, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event types.Event
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received <- event
+	}))
+	defer server.Close()
+
+	var logged []string
+	logf := func(format string, args ...interface{}) { logged = append(logged, format) }
+
+	registry, err := LoadRegistry(context.Background(), mapConfigStore{
+		ConfigKeyWebhookURL:    server.URL,
+		ConfigKeyWebhookEvents: "closed",
+		ConfigKeyLog:           "true",
+	}, logf)
+	if err != nil {
+		t.Fatalf("LoadRegistry failed: %v", err)
+	}
+	if want := []string{"webhook", "log"}; !reflect.DeepEqual(registry.Names(), want) {
+		t.Fatalf("Expected notifiers %v, got %v", want, registry.Names())
+	}
+
+	ctx := context.Background()
+	if err := registry.Dispatch(ctx, types.Event{ID: 1, IssueID: "bd-1", EventType: types.EventCreated}); err != nil {
+		t.Fatalf("Dispatch failed: %v", err)
+	}
+	if err := registry.Dispatch(ctx, types.Event{ID: 2, IssueID: "bd-1", EventType: types.EventClosed}); err != nil {
+		t.Fatalf("Dispatch failed: %v", err)
+	}
+	if event := <-received; event.ID != 2 {
+		t.Errorf("Expected webhook to get only the closed event, got %+v", event)
+	}
+	if len(logged) != 2 {
+		t.Errorf("Expected both events to be logged, got %d", len(logged))
+	}
+
+	// Nothing enabled
+	registry, err = LoadRegistry(ctx, mapConfigStore{}, logf)
+	if err != nil || len(registry.Names()) != 0 {
+		t.Errorf("Expected no notifiers, got %v (%v)", registry.Names(), err)
+	}
+}
@@ -2,6 +2,7 @@ package rpc
 
 import (
 	"encoding/json"
+	"time"
 )
 
 // Operation constants for all bd commands
@@ -64,7 +65,7 @@ type CreateArgs struct {
 	Title              string   `json:"title"`
 	Description        string   `json:"description,omitempty"`
 	IssueType          string   `json:"issue_type"`
-	Priority           int      `json:"priority"`
+	Priority           int      `json:"priority"` // types.PriorityUnset applies the project default
 	Design             string   `json:"design,omitempty"`
 	AcceptanceCriteria string   `json:"acceptance_criteria,omitempty"`
 	Assignee           string   `json:"assignee,omitempty"`
@@ -72,6 +73,7 @@ type CreateArgs struct {
 	EstimatedMinutes   *int     `json:"estimated_minutes,omitempty"` // Time estimate in minutes
 	Labels             []string `json:"labels,omitempty"`
 	Dependencies       []string `json:"dependencies,omitempty"`
+	IdempotencyKey     string   `json:"idempotency_key,omitempty"` // Makes retries return the issue already created
 }
 
 // UpdateArgs represents arguments for the update operation
@@ -90,12 +92,14 @@ type UpdateArgs struct {
 	AddLabels          []string `json:"add_labels,omitempty"`
 	RemoveLabels       []string `json:"remove_labels,omitempty"`
 	SetLabels          []string `json:"set_labels,omitempty"`
+	ExpectedVersion    *int     `json:"expected_version,omitempty"` // Fail if the issue is no longer at this version
 }
 
 // CloseArgs represents arguments for the close operation
 type CloseArgs struct {
 	ID     string `json:"id"`
 	Reason string `json:"reason,omitempty"`
+	Force  bool   `json:"force,omitempty"` // Close even if open children remain
 }
 
 // DeleteArgs represents arguments for the delete operation
@@ -109,16 +113,17 @@ type DeleteArgs struct {
 
 // ListArgs represents arguments for the list operation
 type ListArgs struct {
-	Query     string   `json:"query,omitempty"`
-	Status    string   `json:"status,omitempty"`
-	Priority  *int     `json:"priority,omitempty"`
-	IssueType string   `json:"issue_type,omitempty"`
-	Assignee  string   `json:"assignee,omitempty"`
-	Label     string   `json:"label,omitempty"`      // Deprecated: use Labels
-	Labels    []string `json:"labels,omitempty"`     // AND semantics
-	LabelsAny []string `json:"labels_any,omitempty"` // OR semantics
-	IDs       []string `json:"ids,omitempty"`        // Filter by specific issue IDs
-	Limit     int      `json:"limit,omitempty"`
+	Query      string   `json:"query,omitempty"`
+	Status     string   `json:"status,omitempty"`
+	Priority   *int     `json:"priority,omitempty"`
+	IssueType  string   `json:"issue_type,omitempty"`
+	Assignee   string   `json:"assignee,omitempty"`
+	Label      string   `json:"label,omitempty"`       // Deprecated: use Labels
+	Labels     []string `json:"labels,omitempty"`      // AND semantics
+	LabelsAny  []string `json:"labels_any,omitempty"`  // OR semantics
+	LabelGlobs []string `json:"label_globs,omitempty"` // AND semantics over label patterns
+	IDs        []string `json:"ids,omitempty"`         // Filter by specific issue IDs
+	Limit      int      `json:"limit,omitempty"`
 	
 	// Pattern matching
 	TitleContains       string `json:"title_contains,omitempty"`
@@ -138,9 +143,19 @@ type ListArgs struct {
 	NoAssignee       bool `json:"no_assignee,omitempty"`
 	NoLabels         bool `json:"no_labels,omitempty"`
 	
+	// Dependency graph position
+	IsRoot bool `json:"is_root,omitempty"`
+	IsLeaf bool `json:"is_leaf,omitempty"`
+
+	// Age (nanoseconds; see types.IssueFilter.OlderThan)
+	OlderThan  time.Duration `json:"older_than,omitempty"`
+	StalerThan time.Duration `json:"staler_than,omitempty"`
+
 	// Priority range
 	PriorityMin *int `json:"priority_min,omitempty"`
 	PriorityMax *int `json:"priority_max,omitempty"`
+
+	IncludeSnoozed bool `json:"include_snoozed,omitempty"` // Show snoozed issues
 }
 
 // CountArgs represents arguments for the count operation
@@ -193,13 +208,14 @@ type ResolveIDArgs struct {
 
 // ReadyArgs represents arguments for the ready operation
 type ReadyArgs struct {
-	Assignee   string   `json:"assignee,omitempty"`
-	Unassigned bool     `json:"unassigned,omitempty"`
-	Priority   *int     `json:"priority,omitempty"`
-	Limit      int      `json:"limit,omitempty"`
-	SortPolicy string   `json:"sort_policy,omitempty"`
-	Labels     []string `json:"labels,omitempty"`
-	LabelsAny  []string `json:"labels_any,omitempty"`
+	Assignee       string   `json:"assignee,omitempty"`
+	Unassigned     bool     `json:"unassigned,omitempty"`
+	Priority       *int     `json:"priority,omitempty"`
+	Limit          int      `json:"limit,omitempty"`
+	SortPolicy     string   `json:"sort_policy,omitempty"`
+	Labels         []string `json:"labels,omitempty"`
+	LabelsAny      []string `json:"labels_any,omitempty"`
+	ExcludeClaimed bool     `json:"exclude_claimed,omitempty"`
 }
 
 // StaleArgs represents arguments for the stale command
@@ -214,6 +230,7 @@ type DepAddArgs struct {
 	FromID  string `json:"from_id"`
 	ToID    string `json:"to_id"`
 	DepType string `json:"dep_type"`
+	Note    string `json:"note,omitempty"`
 }
 
 // DepRemoveArgs represents arguments for removing a dependency
@@ -366,7 +383,8 @@ type CompactStatsData struct {
 
 // ExportArgs represents arguments for the export operation
 type ExportArgs struct {
-	JSONLPath string `json:"jsonl_path"` // Path to export JSONL file
+	JSONLPath string `json:"jsonl_path"`     // Path to export JSONL file
+	Gzip      bool   `json:"gzip,omitempty"` // Compress the output; implied by a JSONLPath ending in .gz
 }
 
 // ImportArgs represents arguments for the import operation
@@ -189,6 +189,46 @@ func TestCreateIssue(t *testing.T) {
 	}
 }
 
+func TestCreateIssueIdempotencyKey(t *testing.T) {
+	_, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	blocker, err := client.Create(&CreateArgs{Title: "Blocker", IssueType: "task", Priority: 2})
+	if err != nil || !blocker.Success {
+		t.Fatalf("Create blocker failed: %v %s", err, blocker.Error)
+	}
+	var blockerIssue types.Issue
+	if err := json.Unmarshal(blocker.Data, &blockerIssue); err != nil {
+		t.Fatalf("Failed to unmarshal issue: %v", err)
+	}
+
+	args := &CreateArgs{
+		Title:          "Retried",
+		IssueType:      "task",
+		Priority:       2,
+		Dependencies:   []string{blockerIssue.ID},
+		IdempotencyKey: "retry-1",
+	}
+	var ids []string
+	for i := 0; i < 2; i++ {
+		resp, err := client.Create(args)
+		if err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+		if !resp.Success {
+			t.Fatalf("Expected success on attempt %d, got error: %s", i+1, resp.Error)
+		}
+		var issue types.Issue
+		if err := json.Unmarshal(resp.Data, &issue); err != nil {
+			t.Fatalf("Failed to unmarshal issue: %v", err)
+		}
+		ids = append(ids, issue.ID)
+	}
+	if ids[0] != ids[1] {
+		t.Errorf("Expected the retry to return %s, got %s", ids[0], ids[1])
+	}
+}
+
 func TestUpdateIssue(t *testing.T) {
 	_, client, cleanup := setupTestServer(t)
 	defer cleanup()
@@ -54,6 +54,10 @@ type Server struct {
 	recentMutations   []MutationEvent
 	recentMutationsMu sync.RWMutex
 	maxMutationBuffer int
+	// Per-subscriber mutation feeds (see Subscribe)
+	subscriptionsMu sync.Mutex
+	subscriptions   map[int64]*Subscription
+	nextSubID       int64
 }
 
 // Mutation event types
@@ -62,13 +66,15 @@ const (
 	MutationUpdate  = "update"
 	MutationDelete  = "delete"
 	MutationComment = "comment"
+	MutationGap     = "gap" // Subscription overflow marker, see Subscription
 )
 
 // MutationEvent represents a database mutation for event-driven sync
 type MutationEvent struct {
-	Type      string    // One of: MutationCreate, MutationUpdate, MutationDelete, MutationComment
-	IssueID   string    // e.g., "bd-42"
+	Type      string // One of: MutationCreate, MutationUpdate, MutationDelete, MutationComment, MutationGap
+	IssueID   string // e.g., "bd-42"
 	Timestamp time.Time
+	Missed    int64 `json:",omitempty"` // MutationGap only: how many events were dropped
 }
 
 // NewServer creates a new RPC server
@@ -113,6 +119,7 @@ func NewServer(socketPath string, store storage.Storage, workspacePath string, d
 		mutationChan:      make(chan MutationEvent, mutationBufferSize), // Configurable buffer
 		recentMutations:   make([]MutationEvent, 0, 100),
 		maxMutationBuffer: 100,
+		subscriptions:     make(map[int64]*Subscription),
 	}
 	s.lastActivityTime.Store(time.Now())
 	return s
@@ -145,6 +152,8 @@ func (s *Server) emitMutation(eventType, issueID string) {
 		s.recentMutations = s.recentMutations[1:]
 	}
 	s.recentMutationsMu.Unlock()
+
+	s.publish(event)
 }
 
 // MutationChan returns the mutation event channel for the daemon to consume
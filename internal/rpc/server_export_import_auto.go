@@ -1,9 +1,11 @@
 package rpc
 
 import (
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -32,7 +34,9 @@ func (s *Server) handleExport(req *Request) Response {
 	}
 
 	store := s.storage
-	ctx := s.reqCtx(req)
+	// The JSONL is shared through git, so private issues stay out of it,
+	// whoever asked for the export
+	ctx := sqlite.WithPublicOnly(s.reqCtx(req))
 
 	// Load export configuration (user-initiated export, not auto)
 	cfg, err := export.LoadConfig(ctx, store, false)
@@ -50,7 +54,7 @@ func (s *Server) handleExport(req *Request) Response {
 	}
 
 	// Get all issues (core operation, always fail-fast)
-	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{IncludeSnoozed: true})
 	if err != nil {
 		return Response{
 			Success: false,
@@ -136,6 +140,32 @@ func (s *Server) handleExport(req *Request) Response {
 		issue.Comments = allComments[issue.ID]
 	}
 
+	// Populate links for all issues (enrichment data)
+	var allLinks map[string][]*types.Link
+	result = export.FetchWithPolicy(ctx, cfg, export.DataTypeLinks, "get links", func() error {
+		var err error
+		allLinks, err = getLinksForIssues(ctx, store, issueIDs)
+		return err
+	})
+	if result.Err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("failed to get links: %v", result.Err),
+		}
+	}
+	if !result.Success {
+		// Links fetch failed but policy allows continuing
+		allLinks = make(map[string][]*types.Link) // Empty map
+		if manifest != nil {
+			manifest.PartialData = append(manifest.PartialData, "links")
+			manifest.Warnings = append(manifest.Warnings, result.Warnings...)
+			manifest.Complete = false
+		}
+	}
+	for _, issue := range issues {
+		issue.Links = allLinks[issue.ID]
+	}
+
 	// Create temp file for atomic write
 	dir := filepath.Dir(exportArgs.JSONLPath)
 	base := filepath.Base(exportArgs.JSONLPath)
@@ -153,7 +183,13 @@ func (s *Server) handleExport(req *Request) Response {
 	}()
 
 	// Write JSONL
-	encoder := json.NewEncoder(tempFile)
+	var w io.Writer = tempFile
+	var gz *gzip.Writer
+	if exportArgs.Gzip || export.IsGzipPath(exportArgs.JSONLPath) {
+		gz = gzip.NewWriter(tempFile)
+		w = gz
+	}
+	encoder := export.NewIssueEncoder(w, cfg.TimestampFormat)
 	exportedIDs := make([]string, 0, len(issues))
 	var encodingWarnings []string
 	for _, issue := range issues {
@@ -181,6 +217,15 @@ func (s *Server) handleExport(req *Request) Response {
 		exportedIDs = append(exportedIDs, issue.ID)
 	}
 
+	if gz != nil {
+		if err := gz.Close(); err != nil {
+			return Response{
+				Success: false,
+				Error:   fmt.Sprintf("failed to finish gzip stream: %v", err),
+			}
+		}
+	}
+
 	// Close temp file before rename
 	_ = tempFile.Close()
 
@@ -328,10 +373,23 @@ func (s *Server) checkAndAutoImportIfStale(req *Request) error {
 	notify := autoimport.NewStderrNotifier(debug.Enabled())
 
 	importFunc := func(ctx context.Context, issues []*types.Issue) (created, updated int, idMapping map[string]string, err error) {
-		// Use the importer package to perform the actual import
-		result, err := importer.ImportIssues(ctx, dbPath, store, issues, importer.Options{
-			RenameOnImport: true, // Auto-rename prefix mismatches
-			// Note: SkipPrefixValidation is false by default, so we validate and rename
+		// Use the importer package to perform the actual import. Freshness
+		// checking is paused so reads made mid-import don't reconnect to a
+		// half-written database.
+		var result *importer.Result
+		start := time.Now()
+		err = sqliteStore.WithFreshnessPaused(func() error {
+			var err error
+			result, err = importer.ImportIssues(ctx, dbPath, store, issues, importer.Options{
+				RenameOnImport: true, // Auto-rename prefix mismatches
+				// Note: SkipPrefixValidation is false by default, so we validate and rename
+				OnProgress: func(done, total int) {
+					elapsed := time.Since(start)
+					eta := time.Duration(float64(elapsed) * float64(total-done) / float64(done))
+					debug.Logf("auto-import %d/%d issues (ETA %s)", done, total, eta.Round(time.Second))
+				},
+			})
+			return err
 		})
 		if err != nil {
 			return 0, 0, nil, err
@@ -441,6 +499,9 @@ func hasUncommittedBeadsFiles(workspacePath string) bool {
 // triggerExport exports all issues to JSONL after auto-import remaps IDs
 // CRITICAL: Must populate all issue data (deps, labels, comments) to prevent data loss
 func (s *Server) triggerExport(ctx context.Context, store storage.Storage, dbPath string) error {
+	// The JSONL is shared through git, so private issues stay out of it
+	ctx = sqlite.WithPublicOnly(ctx)
+
 	// Find JSONL path using database directory
 	// Use FindJSONLInDir to prefer issues.jsonl over other .jsonl files (bd-tqo fix)
 	dbDir := filepath.Dir(dbPath)
@@ -465,7 +526,7 @@ func (s *Server) triggerExport(ctx context.Context, store storage.Storage, dbPat
 	}
 
 	// Export to JSONL (this will update the file with remapped IDs)
-	allIssues, err := sqliteStore.SearchIssues(ctx, "", types.IssueFilter{})
+	allIssues, err := sqliteStore.SearchIssues(ctx, "", types.IssueFilter{IncludeSnoozed: true})
 	if err != nil {
 		return fmt.Errorf("failed to fetch issues for export: %w", err)
 	}
@@ -532,6 +593,24 @@ func (s *Server) triggerExport(ctx context.Context, store storage.Storage, dbPat
 		issue.Comments = allComments[issue.ID]
 	}
 
+	// Populate links for all issues (enrichment data)
+	var allLinks map[string][]*types.Link
+	result = export.FetchWithPolicy(ctx, cfg, export.DataTypeLinks, "get links", func() error {
+		var err error
+		allLinks, err = getLinksForIssues(ctx, store, issueIDs)
+		return err
+	})
+	if result.Err != nil {
+		return fmt.Errorf("failed to get links: %w", result.Err)
+	}
+	if !result.Success {
+		// Links fetch failed but policy allows continuing
+		allLinks = make(map[string][]*types.Link) // Empty map
+	}
+	for _, issue := range allIssues {
+		issue.Links = allLinks[issue.ID]
+	}
+
 	// Write to JSONL file with atomic replace (temp file + rename)
 	dir := filepath.Dir(jsonlPath)
 	base := filepath.Base(jsonlPath)
@@ -545,7 +624,7 @@ func (s *Server) triggerExport(ctx context.Context, store storage.Storage, dbPat
 		_ = os.Remove(tempPath)
 	}()
 
-	encoder := json.NewEncoder(tempFile)
+	encoder := export.NewIssueEncoder(tempFile, cfg.TimestampFormat)
 	for _, issue := range allIssues {
 		if err := encoder.Encode(issue); err != nil {
 			return fmt.Errorf("failed to encode issue %s: %w", issue.ID, err)
@@ -562,3 +641,13 @@ func (s *Server) triggerExport(ctx context.Context, store storage.Storage, dbPat
 
 	return nil
 }
+
+// getLinksForIssues returns issue links from stores that support them (SQLite);
+// other stores have none
+func getLinksForIssues(ctx context.Context, store storage.Storage, issueIDs []string) (map[string][]*types.Link, error) {
+	sqliteStore, ok := store.(*sqlite.SQLiteStorage)
+	if !ok {
+		return make(map[string][]*types.Link), nil
+	}
+	return sqliteStore.GetLinksForIssues(ctx, issueIDs)
+}
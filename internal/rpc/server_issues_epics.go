@@ -2,6 +2,7 @@ package rpc
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -108,6 +109,28 @@ func (s *Server) handleCreate(req *Request) Response {
 	}
 	ctx := s.reqCtx(req)
 
+	// A retried create whose first attempt committed returns that issue
+	// without generating another child ID or re-adding dependencies
+	if createArgs.IdempotencyKey != "" {
+		if sqliteStore, ok := store.(*sqlite.SQLiteStorage); ok {
+			existing, err := sqliteStore.IdempotentIssue(ctx, createArgs.IdempotencyKey)
+			if err != nil {
+				return Response{
+					Success: false,
+					Error:   fmt.Sprintf("failed to look up idempotency key: %v", err),
+				}
+			}
+			if existing != nil {
+				data, _ := json.Marshal(existing)
+				return Response{
+					Success: true,
+					Data:    data,
+				}
+			}
+		}
+		ctx = sqlite.WithIdempotencyKey(ctx, createArgs.IdempotencyKey)
+	}
+
 	// If parent is specified, generate child ID
 	issueID := createArgs.ID
 	if createArgs.Parent != "" {
@@ -146,6 +169,7 @@ func (s *Server) handleCreate(req *Request) Response {
 		Assignee:           strValue(assignee),
 		ExternalRef:        externalRef,
 		EstimatedMinutes:   createArgs.EstimatedMinutes,
+		Labels:             createArgs.Labels,
 		Status:             types.StatusOpen,
 	}
 	
@@ -206,16 +230,6 @@ func (s *Server) handleCreate(req *Request) Response {
 		}
 	}
 
-	// Add labels if specified
-	for _, label := range createArgs.Labels {
-		if err := store.AddLabel(ctx, issue.ID, label, s.reqActor(req)); err != nil {
-			return Response{
-				Success: false,
-				Error:   fmt.Sprintf("failed to add label %s: %v", label, err),
-			}
-		}
-	}
-
 	// Add dependencies if specified
 	for _, depSpec := range createArgs.Dependencies {
 		depSpec = strings.TrimSpace(depSpec)
@@ -291,10 +305,23 @@ func (s *Server) handleUpdate(req *Request) Response {
 	ctx := s.reqCtx(req)
 	updates := updatesFromArgs(updateArgs)
 	actor := s.reqActor(req)
+	if updateArgs.ExpectedVersion != nil {
+		ctx = sqlite.WithExpectedVersion(ctx, *updateArgs.ExpectedVersion)
+	}
 
 	// Apply regular field updates if any
 	if len(updates) > 0 {
 		if err := store.UpdateIssue(ctx, updateArgs.ID, updates, actor); err != nil {
+			// Send back the current issue so the client can re-apply its edits
+			var stale *sqlite.StaleUpdateError
+			if errors.As(err, &stale) {
+				data, _ := json.Marshal(stale.Current)
+				return Response{
+					Success: false,
+					Data:    data,
+					Error:   fmt.Sprintf("failed to update issue: %v", err),
+				}
+			}
 			return Response{
 				Success: false,
 				Error:   fmt.Sprintf("failed to update issue: %v", err),
@@ -391,6 +418,9 @@ func (s *Server) handleClose(req *Request) Response {
 	}
 
 	ctx := s.reqCtx(req)
+	if closeArgs.Force {
+		ctx = sqlite.WithForceClose(ctx)
+	}
 	if err := store.CloseIssue(ctx, closeArgs.ID, closeArgs.Reason, s.reqActor(req)); err != nil {
 		return Response{
 			Success: false,
@@ -551,6 +581,9 @@ func (s *Server) handleList(req *Request) Response {
 	if len(labelsAny) > 0 {
 		filter.LabelsAny = labelsAny
 	}
+	if labelGlobs := util.NormalizeLabels(listArgs.LabelGlobs); len(labelGlobs) > 0 {
+		filter.LabelGlobs = labelGlobs
+	}
 	if len(listArgs.IDs) > 0 {
 		ids := util.NormalizeLabels(listArgs.IDs)
 		if len(ids) > 0 {
@@ -629,6 +662,11 @@ func (s *Server) handleList(req *Request) Response {
 	filter.EmptyDescription = listArgs.EmptyDescription
 	filter.NoAssignee = listArgs.NoAssignee
 	filter.NoLabels = listArgs.NoLabels
+	filter.IsRoot = listArgs.IsRoot
+	filter.IsLeaf = listArgs.IsLeaf
+	filter.IncludeSnoozed = listArgs.IncludeSnoozed
+	filter.OlderThan = listArgs.OlderThan
+	filter.StalerThan = listArgs.StalerThan
 	
 	// Priority range
 	filter.PriorityMin = listArgs.PriorityMin
@@ -666,6 +704,7 @@ func (s *Server) handleList(req *Request) Response {
 	depCounts, _ := store.GetDependencyCounts(ctx, issueIDs)
 
 	// Build response with counts
+	now := time.Now()
 	issuesWithCounts := make([]*types.IssueWithCounts, len(issues))
 	for i, issue := range issues {
 		counts := depCounts[issue.ID]
@@ -676,6 +715,8 @@ func (s *Server) handleList(req *Request) Response {
 			Issue:           issue,
 			DependencyCount: counts.DependencyCount,
 			DependentCount:  counts.DependentCount,
+			AgeDays:         issue.AgeDays(now),
+			StaleDays:       issue.StaleDays(now),
 		}
 	}
 
@@ -1049,13 +1090,14 @@ func (s *Server) handleReady(req *Request) Response {
 	}
 
 	wf := types.WorkFilter{
-		Status:     types.StatusOpen,
-		Priority:   readyArgs.Priority,
-		Unassigned: readyArgs.Unassigned,
-		Limit:      readyArgs.Limit,
-		SortPolicy: types.SortPolicy(readyArgs.SortPolicy),
-		Labels:     util.NormalizeLabels(readyArgs.Labels),
-		LabelsAny:  util.NormalizeLabels(readyArgs.LabelsAny),
+		Status:         types.StatusOpen,
+		Priority:       readyArgs.Priority,
+		Unassigned:     readyArgs.Unassigned,
+		Limit:          readyArgs.Limit,
+		SortPolicy:     types.SortPolicy(readyArgs.SortPolicy),
+		Labels:         util.NormalizeLabels(readyArgs.Labels),
+		LabelsAny:      util.NormalizeLabels(readyArgs.LabelsAny),
+		ExcludeClaimed: readyArgs.ExcludeClaimed,
 	}
 	if readyArgs.Assignee != "" && !readyArgs.Unassigned {
 		wf.Assignee = &readyArgs.Assignee
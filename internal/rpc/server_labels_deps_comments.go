@@ -30,6 +30,7 @@ func (s *Server) handleDepAdd(req *Request) Response {
 		IssueID:     depArgs.FromID,
 		DependsOnID: depArgs.ToID,
 		Type:        types.DependencyType(depArgs.DepType),
+		Note:        depArgs.Note,
 	}
 
 	ctx := s.reqCtx(req)
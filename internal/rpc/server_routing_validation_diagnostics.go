@@ -11,6 +11,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
 	"golang.org/x/mod/semver"
 )
@@ -238,8 +239,13 @@ func (s *Server) handleRequest(req *Request) Response {
 }
 
 // Adapter helpers
-func (s *Server) reqCtx(_ *Request) context.Context {
-	return context.Background()
+func (s *Server) reqCtx(req *Request) context.Context {
+	ctx := context.Background()
+	// Reads on behalf of a client leave out private issues it doesn't own
+	if req != nil && req.Actor != "" {
+		ctx = sqlite.WithActor(ctx, req.Actor)
+	}
+	return ctx
 }
 
 func (s *Server) reqActor(req *Request) string {
@@ -310,7 +316,13 @@ func (s *Server) handleHealth(req *Request) Response {
 	status := "healthy"
 	dbError := ""
 
-	_, pingErr := store.GetStatistics(healthCtx)
+	// Readiness probe: a trivial query is enough to confirm the connection is live
+	var pingErr error
+	if sqliteStore, ok := store.(*sqlite.SQLiteStorage); ok {
+		pingErr = sqliteStore.Ping(healthCtx)
+	} else {
+		_, pingErr = store.GetStatistics(healthCtx)
+	}
 	dbResponseMs := time.Since(start).Seconds() * 1000
 
 	if pingErr != nil {
@@ -356,6 +368,7 @@ func (s *Server) handleMetrics(_ *Request) Response {
 	snapshot := s.metrics.Snapshot(
 		int(atomic.LoadInt32(&s.activeConns)),
 	)
+	snapshot.Subscribers = s.SubscriberStats()
 
 	data, _ := json.Marshal(snapshot)
 	return Response{
@@ -0,0 +1,146 @@
+package rpc
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultSubscriptionBuffer is the buffer size Subscribe uses when given 0
+const DefaultSubscriptionBuffer = 256
+
+// Subscription is one subscriber's bounded feed of mutation events.
+//
+// Producers never block on a subscriber. When a subscriber falls behind and
+// its buffer is full, the oldest buffered event is dropped to make room, and
+// the next Drain starts with a MutationGap event whose Missed field counts
+// the dropped events. A subscriber that sees a gap has lost events and
+// should resync from the store, e.g. with ChangesSince.
+type Subscription struct {
+	id     int64
+	server *Server
+
+	mu      sync.Mutex
+	buf     []MutationEvent // ring buffer
+	start   int             // index of the oldest buffered event
+	count   int
+	missed  int64 // dropped since the last Drain
+	dropped int64 // dropped over the subscription's lifetime
+	closed  bool
+
+	ready chan struct{}
+}
+
+// SubscriberStats reports how far one subscriber is behind
+type SubscriberStats struct {
+	ID      int64 `json:"id"`
+	Lag     int   `json:"lag"`     // Events buffered and not yet drained
+	Dropped int64 `json:"dropped"` // Events dropped on overflow since subscribing
+}
+
+// Subscribe registers a new subscriber to mutation events with room for
+// bufferSize undrained events (DefaultSubscriptionBuffer if 0 or less).
+// Close the subscription when done.
+func (s *Server) Subscribe(bufferSize int) *Subscription {
+	if bufferSize <= 0 {
+		bufferSize = DefaultSubscriptionBuffer
+	}
+
+	s.subscriptionsMu.Lock()
+	defer s.subscriptionsMu.Unlock()
+	s.nextSubID++
+	sub := &Subscription{
+		id:     s.nextSubID,
+		server: s,
+		buf:    make([]MutationEvent, bufferSize),
+		ready:  make(chan struct{}, 1),
+	}
+	s.subscriptions[sub.id] = sub
+	return sub
+}
+
+// publish delivers event to every subscriber without blocking
+func (s *Server) publish(event MutationEvent) {
+	s.subscriptionsMu.Lock()
+	defer s.subscriptionsMu.Unlock()
+	for _, sub := range s.subscriptions {
+		sub.push(event)
+	}
+}
+
+// SubscriberStats returns the lag of every open subscription, by ID
+func (s *Server) SubscriberStats() []SubscriberStats {
+	s.subscriptionsMu.Lock()
+	subs := make([]*Subscription, 0, len(s.subscriptions))
+	for _, sub := range s.subscriptions {
+		subs = append(subs, sub)
+	}
+	s.subscriptionsMu.Unlock()
+
+	stats := make([]SubscriberStats, 0, len(subs))
+	for _, sub := range subs {
+		sub.mu.Lock()
+		stats = append(stats, SubscriberStats{ID: sub.id, Lag: sub.count, Dropped: sub.dropped})
+		sub.mu.Unlock()
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].ID < stats[j].ID })
+	return stats
+}
+
+// push appends event, dropping the oldest buffered event if full
+func (sub *Subscription) push(event MutationEvent) {
+	sub.mu.Lock()
+	if sub.closed {
+		sub.mu.Unlock()
+		return
+	}
+	if sub.count == len(sub.buf) {
+		sub.start = (sub.start + 1) % len(sub.buf)
+		sub.count--
+		sub.missed++
+		sub.dropped++
+	}
+	sub.buf[(sub.start+sub.count)%len(sub.buf)] = event
+	sub.count++
+	sub.mu.Unlock()
+
+	select {
+	case sub.ready <- struct{}{}:
+	default:
+	}
+}
+
+// Ready returns a channel that receives when events may be waiting to be
+// drained
+func (sub *Subscription) Ready() <-chan struct{} {
+	return sub.ready
+}
+
+// Drain returns and removes every buffered event, oldest first, preceded by
+// a MutationGap event if any were dropped since the last Drain
+func (sub *Subscription) Drain() []MutationEvent {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+
+	var events []MutationEvent
+	if sub.missed > 0 {
+		events = append(events, MutationEvent{Type: MutationGap, Timestamp: time.Now(), Missed: sub.missed})
+		sub.missed = 0
+	}
+	for i := 0; i < sub.count; i++ {
+		events = append(events, sub.buf[(sub.start+i)%len(sub.buf)])
+	}
+	sub.start, sub.count = 0, 0
+	return events
+}
+
+// Close unregisters the subscription; later events are not delivered to it
+func (sub *Subscription) Close() {
+	sub.server.subscriptionsMu.Lock()
+	delete(sub.server.subscriptions, sub.id)
+	sub.server.subscriptionsMu.Unlock()
+
+	sub.mu.Lock()
+	sub.closed = true
+	sub.mu.Unlock()
+}
@@ -0,0 +1,81 @@
+package rpc
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage/memory"
+)
+
+func TestSubscriptionDelivers(t *testing.T) {
+	store := memory.New("/tmp/test.jsonl")
+	server := NewServer("/tmp/test.sock", store, "/tmp", "/tmp/test.db")
+
+	sub := server.Subscribe(4)
+	defer sub.Close()
+
+	server.emitMutation(MutationCreate, "bd-1")
+	server.emitMutation(MutationUpdate, "bd-1")
+
+	select {
+	case <-sub.Ready():
+	default:
+		t.Fatal("expected Ready to signal after emit")
+	}
+
+	events := sub.Drain()
+	if len(events) != 2 || events[0].Type != MutationCreate || events[1].Type != MutationUpdate {
+		t.Fatalf("expected create then update, got %+v", events)
+	}
+	if events := sub.Drain(); len(events) != 0 {
+		t.Errorf("expected empty drain, got %+v", events)
+	}
+}
+
+func TestSubscriptionOverflowDropsOldestWithGap(t *testing.T) {
+	store := memory.New("/tmp/test.jsonl")
+	server := NewServer("/tmp/test.sock", store, "/tmp", "/tmp/test.db")
+
+	slow := server.Subscribe(3)
+	defer slow.Close()
+	fast := server.Subscribe(10)
+	defer fast.Close()
+
+	for i := 1; i <= 5; i++ {
+		server.emitMutation(MutationUpdate, fmt.Sprintf("bd-%d", i))
+	}
+
+	stats := server.SubscriberStats()
+	if len(stats) != 2 || stats[0].Lag != 3 || stats[0].Dropped != 2 || stats[1].Lag != 5 || stats[1].Dropped != 0 {
+		t.Fatalf("unexpected subscriber stats: %+v", stats)
+	}
+
+	events := slow.Drain()
+	if len(events) != 4 || events[0].Type != MutationGap || events[0].Missed != 2 {
+		t.Fatalf("expected gap marker for 2 missed events, got %+v", events)
+	}
+	for i, want := range []string{"bd-3", "bd-4", "bd-5"} {
+		if events[i+1].IssueID != want {
+			t.Errorf("expected newest events kept, got %+v", events[1:])
+			break
+		}
+	}
+
+	// The gap is reported once; the fast subscriber is unaffected
+	server.emitMutation(MutationUpdate, "bd-6")
+	if events := slow.Drain(); len(events) != 1 || events[0].Type == MutationGap {
+		t.Errorf("expected single event without gap, got %+v", events)
+	}
+	if events := fast.Drain(); len(events) != 6 || events[0].Type == MutationGap {
+		t.Errorf("expected all 6 events for fast subscriber, got %d", len(events))
+	}
+
+	slow.Close()
+	server.emitMutation(MutationUpdate, "bd-7")
+	if events := slow.Drain(); len(events) != 0 {
+		t.Errorf("expected no events after Close, got %+v", events)
+	}
+	if stats := server.SubscriberStats(); len(stats) != 1 {
+		t.Errorf("expected closed subscriber removed from stats, got %+v", stats)
+	}
+}
@@ -0,0 +1,59 @@
+// Package backend opens a storage backend chosen by connection string (see
+// storage.ParseConnString). It is separate from package storage, which the
+// backends themselves import.
+package backend
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	// Register the "postgres" database/sql driver postgres.New looks for
+	_ "github.com/lib/pq"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/postgres"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+)
+
+// Open opens the store for conn: "sqlite://path" or a bare path opens a
+// SQLite database, and "postgres://..." connects to a Postgres server. Only
+// the SQLite store implements storage.FreshnessChecker; the Postgres one
+// returns postgres.ErrUnsupported from the operations it lacks.
+func Open(ctx context.Context, conn string) (storage.Storage, error) {
+	return open(ctx, conn, func(path string) (*sqlite.SQLiteStorage, error) {
+		return sqlite.New(ctx, path)
+	})
+}
+
+// OpenWithTimeout is Open with the SQLite busy timeout given, as for
+// sqlite.NewWithTimeout. Postgres ignores it.
+func OpenWithTimeout(ctx context.Context, conn string, busyTimeout time.Duration) (storage.Storage, error) {
+	return open(ctx, conn, func(path string) (*sqlite.SQLiteStorage, error) {
+		return sqlite.NewWithTimeout(ctx, path, busyTimeout)
+	})
+}
+
+func open(ctx context.Context, conn string, openSQLite func(path string) (*sqlite.SQLiteStorage, error)) (storage.Storage, error) {
+	cfg, err := storage.ParseConnString(conn)
+	if err != nil {
+		return nil, err
+	}
+	switch cfg.Backend {
+	// Return a nil interface, not a nil pointer, on error
+	case storage.BackendSQLite:
+		store, err := openSQLite(cfg.Path)
+		if err != nil {
+			return nil, err
+		}
+		return store, nil
+	case storage.BackendPostgres:
+		store, err := postgres.New(ctx, conn)
+		if err != nil {
+			return nil, err
+		}
+		return store, nil
+	default:
+		return nil, fmt.Errorf("unsupported storage backend %q", cfg.Backend)
+	}
+}
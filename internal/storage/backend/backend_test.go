@@ -0,0 +1,46 @@
+package backend
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+)
+
+func TestOpen(t *testing.T) {
+	ctx := context.Background()
+
+	store, err := Open(ctx, "sqlite://"+filepath.Join(t.TempDir(), "beads.db"))
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer store.Close()
+	if _, ok := store.(*sqlite.SQLiteStorage); !ok {
+		t.Errorf("Expected a SQLite store, got %T", store)
+	}
+	if err := storage.EnableFreshnessChecking(store, storage.FreshnessOptions{}); err != nil {
+		t.Errorf("EnableFreshnessChecking failed: %v", err)
+	}
+	if err := store.SetConfig(ctx, "issue_prefix", "bd"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	if prefix, err := store.GetConfig(ctx, "issue_prefix"); err != nil || prefix != "bd" {
+		t.Errorf("Expected prefix bd, got %q (err %v)", prefix, err)
+	}
+
+	// The Postgres driver is linked, so this gets as far as connecting
+	store, err = Open(ctx, "postgres://beads@127.0.0.1:1/beads?sslmode=disable&connect_timeout=1")
+	if err == nil || strings.Contains(err.Error(), "no Postgres driver") {
+		t.Errorf("Expected a connection error, got %v", err)
+	}
+	if store != nil {
+		t.Errorf("Expected a nil store on error, got %T", store)
+	}
+
+	if _, err := Open(ctx, "mysql://localhost/beads"); err == nil {
+		t.Error("Expected an unsupported scheme to fail")
+	}
+}
@@ -0,0 +1,187 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrUnknownConfigKey is returned by SetConfig for keys no package has
+// registered with RegisterConfigKey, which are usually typos
+var ErrUnknownConfigKey = errors.New("unknown config key")
+
+// ErrInvalidConfigValue is returned by SetConfig, GetConfigInt and
+// GetConfigBool for values a key's validator rejects
+var ErrInvalidConfigValue = errors.New("invalid config value")
+
+// IssuePrefixConfigKey is the config key for the prefix of generated issue IDs
+const IssuePrefixConfigKey = "issue_prefix"
+
+// configKeys is the registry behind RegisterConfigKey. Validators are keyed by
+// config key, or, for namespaces, by the key prefix before the "*".
+var configKeys = struct {
+	sync.RWMutex
+	exact      map[string]func(string) error
+	namespaces map[string]func(string) error
+}{
+	exact:      make(map[string]func(string) error),
+	namespaces: make(map[string]func(string) error),
+}
+
+// Keys shared by every backend, and namespaces left free-form for integrations
+func init() {
+	RegisterConfigKey(IssuePrefixConfigKey, ValidateIssuePrefix)
+	for _, namespace := range []string{"jira.*", "linear.*", "github.*", "custom.*"} {
+		RegisterConfigKey(namespace, nil)
+	}
+}
+
+// RegisterConfigKey declares key as a config key SetConfig accepts, with
+// validate checking its values (nil accepts any value). A key ending in ".*"
+// registers a whole namespace, e.g. "jira.*" covers "jira.url". Packages
+// register the keys they read from init functions; registering a key twice
+// panics.
+func RegisterConfigKey(key string, validate func(string) error) {
+	configKeys.Lock()
+	defer configKeys.Unlock()
+
+	registry, name := configKeys.exact, key
+	if strings.HasSuffix(key, ".*") {
+		registry, name = configKeys.namespaces, strings.TrimSuffix(key, "*")
+	}
+	if _, ok := registry[name]; ok {
+		panic(fmt.Sprintf("config key %q registered twice", key))
+	}
+	registry[name] = validate
+}
+
+// ValidateConfig returns an error matching ErrUnknownConfigKey if key isn't
+// registered, or ErrInvalidConfigValue if its validator rejects value.
+// Exact registrations take precedence over namespaces, and longer
+// namespaces over shorter ones.
+func ValidateConfig(key, value string) error {
+	validate, ok := configValidator(key)
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownConfigKey, key)
+	}
+	if validate == nil {
+		return nil
+	}
+	if err := validate(value); err != nil {
+		return fmt.Errorf("%w for %s: %v", ErrInvalidConfigValue, key, err)
+	}
+	return nil
+}
+
+// configValidator looks up key's validator, reporting whether it is registered
+func configValidator(key string) (func(string) error, bool) {
+	configKeys.RLock()
+	defer configKeys.RUnlock()
+
+	if validate, ok := configKeys.exact[key]; ok {
+		return validate, true
+	}
+	longest := ""
+	for namespace := range configKeys.namespaces {
+		if strings.HasPrefix(key, namespace) && len(key) > len(namespace) && len(namespace) > len(longest) {
+			longest = namespace
+		}
+	}
+	if longest == "" {
+		return nil, false
+	}
+	return configKeys.namespaces[longest], true
+}
+
+// issuePrefixPattern matches valid issue prefixes: a lowercase letter, then
+// lowercase letters and digits, with single hyphens between words
+var issuePrefixPattern = regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)*$`)
+
+// MaxIssuePrefixLen is the longest issue prefix ValidateIssuePrefix accepts.
+// It leaves room for multi-part prefixes such as "beads-vscode".
+const MaxIssuePrefixLen = 20
+
+// ValidateIssuePrefix checks an issue prefix: up to MaxIssuePrefixLen
+// lowercase letters, digits and inner hyphens, starting with a letter. The
+// hyphen separating the prefix from the rest of an ID is added when IDs are
+// generated, so a trailing one is ignored.
+func ValidateIssuePrefix(prefix string) error {
+	prefix = strings.TrimSuffix(prefix, "-")
+	if prefix == "" {
+		return fmt.Errorf("issue prefix cannot be empty")
+	}
+	if len(prefix) > MaxIssuePrefixLen {
+		return fmt.Errorf("issue prefix %q is too long (max %d characters)", prefix, MaxIssuePrefixLen)
+	}
+	if !issuePrefixPattern.MatchString(prefix) {
+		return fmt.Errorf("issue prefix %q must start with a lowercase letter and contain only lowercase letters, digits and single hyphens", prefix)
+	}
+	return nil
+}
+
+// ValidateBoolConfig accepts the values strconv.ParseBool does
+func ValidateBoolConfig(value string) error {
+	_, err := ParseConfigBool(value)
+	return err
+}
+
+// ValidateIntConfig accepts whole numbers of zero or more
+func ValidateIntConfig(value string) error {
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return fmt.Errorf("%q is not a whole number", value)
+	}
+	if n < 0 {
+		return fmt.Errorf("%d is negative", n)
+	}
+	return nil
+}
+
+// ValidateFloatConfig accepts numbers of zero or more
+func ValidateFloatConfig(value string) error {
+	f, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return fmt.Errorf("%q is not a number", value)
+	}
+	if f < 0 {
+		return fmt.Errorf("%v is negative", f)
+	}
+	return nil
+}
+
+// ValidateDurationConfig accepts positive Go durations, e.g. "24h"
+func ValidateDurationConfig(value string) error {
+	d, err := time.ParseDuration(strings.TrimSpace(value))
+	if err != nil {
+		return fmt.Errorf("%q is not a duration (e.g. 24h)", value)
+	}
+	if d <= 0 {
+		return fmt.Errorf("%v is not positive", d)
+	}
+	return nil
+}
+
+// ValidateOneOf returns a validator accepting only the given values
+func ValidateOneOf(values ...string) func(string) error {
+	return func(value string) error {
+		for _, v := range values {
+			if value == v {
+				return nil
+			}
+		}
+		return fmt.Errorf("%q must be one of %s", value, strings.Join(values, ", "))
+	}
+}
+
+// ParseConfigBool parses a boolean config value, ignoring surrounding space
+func ParseConfigBool(value string) (bool, error) {
+	b, err := strconv.ParseBool(strings.TrimSpace(value))
+	if err != nil {
+		return false, fmt.Errorf("%q is not true or false", value)
+	}
+	return b, nil
+}
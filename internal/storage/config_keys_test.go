@@ -0,0 +1,44 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateConfig(t *testing.T) {
+	RegisterConfigKey("test.flag", ValidateBoolConfig)
+	RegisterConfigKey("test.ns.*", nil)
+
+	tests := []struct {
+		key, value string
+		want       error
+	}{
+		{"issue_prefix", "bd", nil},
+		{"issue_prefix", "bd-", nil},
+		{"issue_prefix", "beads-vscode", nil},
+		{"issue_prefix", "", ErrInvalidConfigValue},
+		{"issue_prefix", "My App", ErrInvalidConfigValue},
+		{"issue_prefix", "9lives", ErrInvalidConfigValue},
+		{"issue_prefix", "a--b", ErrInvalidConfigValue},
+		{"issue_prefix", "abcdefghijklmnopqrstu", ErrInvalidConfigValue},
+		{"issue_prefx", "bd", ErrUnknownConfigKey},
+		{"test.flag", "true", nil},
+		{"test.flag", "yes", ErrInvalidConfigValue},
+		{"test.ns.anything", "x", nil},
+		{"test.ns.", "x", ErrUnknownConfigKey},
+		{"jira.url", "https://example.atlassian.net", nil},
+	}
+	for _, tt := range tests {
+		err := ValidateConfig(tt.key, tt.value)
+		if (tt.want == nil && err != nil) || (tt.want != nil && !errors.Is(err, tt.want)) {
+			t.Errorf("ValidateConfig(%q, %q) = %v, want %v", tt.key, tt.value, err, tt.want)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected registering a key twice to panic")
+		}
+	}()
+	RegisterConfigKey("test.flag", nil)
+}
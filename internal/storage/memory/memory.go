@@ -189,6 +189,14 @@ func (m *MemoryStorage) CreateIssue(ctx context.Context, issue *types.Issue, act
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	// Fill fields the caller left unset with the built-in defaults
+	if issue.Priority == types.PriorityUnset {
+		issue.Priority = types.DefaultPriority
+	}
+	if issue.IssueType == "" {
+		issue.IssueType = types.DefaultIssueType
+	}
+
 	// Validate
 	if err := issue.Validate(); err != nil {
 		return fmt.Errorf("validation failed: %w", err)
@@ -219,6 +227,9 @@ func (m *MemoryStorage) CreateIssue(ctx context.Context, issue *types.Issue, act
 	// Store issue
 	m.issues[issue.ID] = issue
 	m.dirty[issue.ID] = true
+	if len(issue.Labels) > 0 {
+		m.labels[issue.ID] = append([]string(nil), issue.Labels...)
+	}
 
 	// Index external ref for O(1) lookup
 	if issue.ExternalRef != nil && *issue.ExternalRef != "" {
@@ -505,16 +516,49 @@ func (m *MemoryStorage) DeleteIssue(ctx context.Context, id string) error {
 
 // SearchIssues finds issues matching query and filters
 func (m *MemoryStorage) SearchIssues(ctx context.Context, query string, filter types.IssueFilter) ([]*types.Issue, error) {
+	sortKeys := filter.SortBy
+	if len(sortKeys) == 0 {
+		sortKeys = types.DefaultSortKeys
+	}
+	if err := types.ValidateSortKeys(sortKeys); err != nil {
+		return nil, err
+	}
+	if err := types.ValidateFields(filter.Fields); err != nil {
+		return nil, err
+	}
+
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
 	var results []*types.Issue
 
+	now := time.Now()
+
+	// Issues something depends on, for leaf detection
+	var dependedOn map[string]bool
+	if filter.IsLeaf {
+		dependedOn = make(map[string]bool)
+		for _, deps := range m.dependencies {
+			for _, dep := range deps {
+				dependedOn[dep.DependsOnID] = true
+			}
+		}
+	}
+
 	for _, issue := range m.issues {
 		// Apply filters
+		if issue.Draft && !filter.IncludeDrafts {
+			continue
+		}
+		if filter.MinPercent != nil && issue.PercentComplete < *filter.MinPercent {
+			continue
+		}
 		if filter.Status != nil && issue.Status != *filter.Status {
 			continue
 		}
+		if filter.Status == nil && !filter.IncludeSnoozed && issue.Status == types.StatusSnoozed {
+			continue
+		}
 		if filter.Priority != nil && issue.Priority != *filter.Priority {
 			continue
 		}
@@ -557,6 +601,52 @@ func (m *MemoryStorage) SearchIssues(ctx context.Context, query string, filter t
 			}
 		}
 
+		// Label filtering (glob): must have a label matching each glob
+		if len(filter.LabelGlobs) > 0 {
+			matchesAll := true
+			for _, glob := range filter.LabelGlobs {
+				found := false
+				for _, label := range m.labels[issue.ID] {
+					if matchLabelGlob(glob, label) {
+						found = true
+						break
+					}
+				}
+				if !found {
+					matchesAll = false
+					break
+				}
+			}
+			if !matchesAll {
+				continue
+			}
+		}
+
+		// Dependency graph position
+		if filter.IsRoot && len(m.dependencies[issue.ID]) > 0 {
+			continue
+		}
+		if filter.IsLeaf && dependedOn[issue.ID] {
+			continue
+		}
+
+		// Age
+		if filter.OlderThan > 0 && !issue.CreatedAt.Before(now.Add(-filter.OlderThan)) {
+			continue
+		}
+		if filter.StalerThan > 0 && !issue.UpdatedAt.Before(now.Add(-filter.StalerThan)) {
+			continue
+		}
+
+		// Scheduling
+		if filter.DueBefore != nil && (issue.DueDate == nil || !issue.DueDate.Before(*filter.DueBefore)) {
+			continue
+		}
+		if filter.Overdue && (issue.DueDate == nil || !issue.DueDate.Before(now) ||
+			issue.Status == types.StatusClosed || issue.Status == types.StatusTombstone) {
+			continue
+		}
+
 		// ID filtering
 		if len(filter.IDs) > 0 {
 			found := false
@@ -583,22 +673,157 @@ func (m *MemoryStorage) SearchIssues(ctx context.Context, query string, filter t
 		results = append(results, &issueCopy)
 	}
 
-	// Sort by priority, then by created_at
+	for _, key := range sortKeys {
+		if key.Field == types.SortByReadyScore {
+			m.setReadyScores(results, now)
+			break
+		}
+	}
+
+	// Sort by the requested keys, then by ID (matches SQLite)
 	sort.Slice(results, func(i, j int) bool {
-		if results[i].Priority != results[j].Priority {
-			return results[i].Priority < results[j].Priority
+		for _, key := range sortKeys {
+			if c := compareIssueField(results[i], results[j], key.Field); c != 0 {
+				if key.Descending {
+					return c > 0
+				}
+				return c < 0
+			}
 		}
-		return results[i].CreatedAt.After(results[j].CreatedAt)
+		return results[i].ID < results[j].ID
 	})
 
-	// Apply limit
+	// Apply offset and limit
+	if filter.Offset > 0 {
+		if filter.Offset >= len(results) {
+			results = nil
+		} else {
+			results = results[filter.Offset:]
+		}
+	}
 	if filter.Limit > 0 && len(results) > filter.Limit {
 		results = results[:filter.Limit]
 	}
 
+	if len(filter.Fields) > 0 {
+		for i, issue := range results {
+			results[i] = types.ProjectIssue(issue, filter.Fields)
+		}
+	}
+
 	return results, nil
 }
 
+// compareIssueField compares a and b on field, returning -1, 0 or 1. A nil
+// closed_at sorts before any timestamp, like NULL in SQLite.
+func compareIssueField(a, b *types.Issue, field types.SortField) int {
+	switch field {
+	case types.SortByPriority:
+		return compareInts(a.Priority, b.Priority)
+	case types.SortByCreatedAt:
+		return a.CreatedAt.Compare(b.CreatedAt)
+	case types.SortByUpdatedAt:
+		return a.UpdatedAt.Compare(b.UpdatedAt)
+	case types.SortByClosedAt:
+		switch {
+		case a.ClosedAt == nil && b.ClosedAt == nil:
+			return 0
+		case a.ClosedAt == nil:
+			return -1
+		case b.ClosedAt == nil:
+			return 1
+		}
+		return a.ClosedAt.Compare(*b.ClosedAt)
+	case types.SortByStatus:
+		return strings.Compare(string(a.Status), string(b.Status))
+	case types.SortByIssueType:
+		return strings.Compare(string(a.IssueType), string(b.IssueType))
+	case types.SortByAssignee:
+		return strings.Compare(a.Assignee, b.Assignee)
+	case types.SortByTitle:
+		return strings.Compare(a.Title, b.Title)
+	case types.SortByID:
+		return strings.Compare(a.ID, b.ID)
+	case types.SortByReadyScore:
+		// Higher scores first, matching SQLite
+		var sa, sb float64
+		if a.ReadyScore != nil {
+			sa = *a.ReadyScore
+		}
+		if b.ReadyScore != nil {
+			sb = *b.ReadyScore
+		}
+		switch {
+		case sa > sb:
+			return -1
+		case sa < sb:
+			return 1
+		}
+	}
+	return 0
+}
+
+// setReadyScores sets ReadyScore on issues using the default weights. The
+// caller must hold at least a read lock.
+func (m *MemoryStorage) setReadyScores(issues []*types.Issue, now time.Time) {
+	dependents := make(map[string]int)
+	for issueID, deps := range m.dependencies {
+		dependent, ok := m.issues[issueID]
+		if !ok || dependent.Status == types.StatusClosed || dependent.Status == types.StatusTombstone {
+			continue
+		}
+		for _, dep := range deps {
+			if dep.Type == types.DepBlocks {
+				dependents[dep.DependsOnID]++
+			}
+		}
+	}
+	for _, issue := range issues {
+		ageDays := now.Sub(issue.CreatedAt).Hours() / 24
+		score := types.DefaultReadyScoreWeights.Score(issue.Priority, ageDays, dependents[issue.ID])
+		issue.ReadyScore = &score
+	}
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// matchLabelGlob reports whether label matches glob, where '*' matches any
+// run of characters (including '/') and '?' any one character. Like the
+// SQLite store's LIKE translation, case is ignored.
+func matchLabelGlob(glob, label string) bool {
+	g, l := []rune(strings.ToLower(glob)), []rune(strings.ToLower(label))
+	gi, li := 0, 0
+	star, starLi := -1, 0
+	for li < len(l) {
+		switch {
+		case gi < len(g) && (g[gi] == '?' || g[gi] == l[li]):
+			gi++
+			li++
+		case gi < len(g) && g[gi] == '*':
+			star, starLi = gi, li
+			gi++
+		case star >= 0:
+			// Let the last '*' absorb one more character and retry
+			starLi++
+			gi, li = star+1, starLi
+		default:
+			return false
+		}
+	}
+	for gi < len(g) && g[gi] == '*' {
+		gi++
+	}
+	return gi == len(g)
+}
+
 // AddDependency adds a dependency between issues
 func (m *MemoryStorage) AddDependency(ctx context.Context, dep *types.Dependency, actor string) error {
 	m.mu.Lock()
@@ -916,6 +1141,11 @@ func (m *MemoryStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 			continue
 		}
 
+		// Externally blocked issues are never ready
+		if issue.IsExternallyBlocked() {
+			continue
+		}
+
 		// Unassigned takes precedence over Assignee filter
 		if filter.Unassigned {
 			if issue.Assignee != "" {
@@ -995,6 +1225,17 @@ func (m *MemoryStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 	}
 
 	switch sortPolicy {
+	case types.SortPolicyReadyScore:
+		m.setReadyScores(results, time.Now())
+		sort.Slice(results, func(i, j int) bool {
+			if c := compareIssueField(results[i], results[j], types.SortByReadyScore); c != 0 {
+				return c < 0
+			}
+			if results[i].Priority != results[j].Priority {
+				return results[i].Priority < results[j].Priority
+			}
+			return results[i].CreatedAt.Before(results[j].CreatedAt)
+		})
 	case types.SortPolicyOldest:
 		sort.Slice(results, func(i, j int) bool {
 			return results[i].CreatedAt.Before(results[j].CreatedAt)
@@ -1053,7 +1294,7 @@ func (m *MemoryStorage) getOpenBlockers(issueID string) []string {
 			continue
 		}
 		switch blocker.Status {
-		case types.StatusOpen, types.StatusInProgress, types.StatusBlocked:
+		case types.StatusOpen, types.StatusInProgress, types.StatusBlocked, types.StatusSnoozed:
 			blockers = append(blockers, blocker.ID)
 		}
 	}
@@ -1282,6 +1523,10 @@ func (m *MemoryStorage) GetNextChildID(ctx context.Context, parentID string) (st
 
 // Config
 func (m *MemoryStorage) SetConfig(ctx context.Context, key, value string) error {
+	if err := storage.ValidateConfig(key, value); err != nil {
+		return err
+	}
+
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -409,6 +409,30 @@ func TestSearchIssues(t *testing.T) {
 	}
 }
 
+func TestSearchIssuesFieldProjection(t *testing.T) {
+	store := setupTestMemory(t)
+	defer store.Close()
+
+	ctx := context.Background()
+	issue := &types.Issue{Title: "Picker entry", Description: "long", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeBug}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	results, err := store.SearchIssues(ctx, "", types.IssueFilter{Fields: []string{"title"}})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != issue.ID || results[0].Title != "Picker entry" ||
+		results[0].Description != "" || results[0].Status != "" {
+		t.Errorf("Expected only id and title, got %+v", results)
+	}
+
+	if _, err := store.SearchIssues(ctx, "", types.IssueFilter{Fields: []string{"comments"}}); err == nil {
+		t.Error("Expected error projecting comments")
+	}
+}
+
 func TestDependencies(t *testing.T) {
 	store := setupTestMemory(t)
 	defer store.Close()
@@ -764,12 +788,12 @@ func TestConfigOperations(t *testing.T) {
 	ctx := context.Background()
 
 	// Set config
-	if err := store.SetConfig(ctx, "test_key", "test_value"); err != nil {
+	if err := store.SetConfig(ctx, "custom.test_key", "test_value"); err != nil {
 		t.Fatalf("SetConfig failed: %v", err)
 	}
 
 	// Get config
-	value, err := store.GetConfig(ctx, "test_key")
+	value, err := store.GetConfig(ctx, "custom.test_key")
 	if err != nil {
 		t.Fatalf("GetConfig failed: %v", err)
 	}
@@ -789,11 +813,11 @@ func TestConfigOperations(t *testing.T) {
 	}
 
 	// Delete config
-	if err := store.DeleteConfig(ctx, "test_key"); err != nil {
+	if err := store.DeleteConfig(ctx, "custom.test_key"); err != nil {
 		t.Fatalf("DeleteConfig failed: %v", err)
 	}
 
-	value, err = store.GetConfig(ctx, "test_key")
+	value, err = store.GetConfig(ctx, "custom.test_key")
 	if err != nil {
 		t.Fatalf("GetConfig failed: %v", err)
 	}
@@ -1021,3 +1045,27 @@ func TestGetIssueByExternalRefLoadFromIssues(t *testing.T) {
 		t.Errorf("Expected to find bd-2 by external ref jira#200")
 	}
 }
+
+func TestMatchLabelGlob(t *testing.T) {
+	tests := []struct {
+		glob, label string
+		want        bool
+	}{
+		{"area/*", "area/backend", true},
+		{"area/*", "area/ui/forms", true},
+		{"area/*", "area", false},
+		{"area/u?", "area/ui", true},
+		{"area/u?", "area/uix", false},
+		{"*end", "backend", true},
+		{"a*b*c", "aXbYbc", true},
+		{"Area/*", "area/ui", true},
+		{"area_*", "area/ui", false},
+		{"", "", true},
+		{"*", "", true},
+	}
+	for _, tt := range tests {
+		if got := matchLabelGlob(tt.glob, tt.label); got != tt.want {
+			t.Errorf("matchLabelGlob(%q, %q): expected %v, got %v", tt.glob, tt.label, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,333 @@
+// Package postgres implements storage.Storage on a Postgres server, for
+// teams sharing one beads database where a SQLite file on a network
+// filesystem would be fragile. Issues, labels and config are supported;
+// dependencies, comments, events and the JSONL sync bookkeeping return
+// ErrUnsupported for now.
+//
+// The package talks to the server through database/sql and does not link a
+// driver itself: the binary must import one that registers as "pgx" (e.g.
+// github.com/jackc/pgx/v5/stdlib) or "postgres" (e.g. github.com/lib/pq, as
+// package backend does).
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// driverNames are the database/sql drivers New looks for, in order
+var driverNames = []string{"pgx", "postgres"}
+
+// schema creates the tables on first connect. Issues are stored whole as
+// JSON in data, with the fields SearchIssues filters on copied into columns.
+const schema = `
+CREATE TABLE IF NOT EXISTS issues (
+	id          TEXT PRIMARY KEY,
+	title       TEXT NOT NULL,
+	description TEXT NOT NULL DEFAULT '',
+	status      TEXT NOT NULL,
+	priority    INTEGER NOT NULL,
+	issue_type  TEXT NOT NULL,
+	assignee    TEXT NOT NULL DEFAULT '',
+	draft       BOOLEAN NOT NULL DEFAULT FALSE,
+	created_at  TIMESTAMPTZ NOT NULL,
+	updated_at  TIMESTAMPTZ NOT NULL,
+	data        JSONB NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_issues_status ON issues (status);
+CREATE TABLE IF NOT EXISTS config (
+	key   TEXT PRIMARY KEY,
+	value TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS issue_counters (
+	prefix  TEXT PRIMARY KEY,
+	last_id INTEGER NOT NULL
+);
+CREATE TABLE IF NOT EXISTS metadata (
+	key   TEXT PRIMARY KEY,
+	value TEXT NOT NULL
+);
+`
+
+// PostgresStorage implements storage.Storage on a Postgres database
+type PostgresStorage struct {
+	db *sql.DB
+}
+
+// New connects to the Postgres database at dsn (a postgres:// URL or a
+// key=value connection string) and creates the beads tables if needed
+func New(ctx context.Context, dsn string) (*PostgresStorage, error) {
+	driver := registeredDriver()
+	if driver == "" {
+		return nil, fmt.Errorf("no Postgres driver is linked into this binary (need one registered as %s)", strings.Join(driverNames, " or "))
+	}
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	if _, err := db.ExecContext(ctx, schema); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to initialize schema: %w", err)
+	}
+	return &PostgresStorage{db: db}, nil
+}
+
+// registeredDriver returns the first of driverNames registered with
+// database/sql, or "" if there is none
+func registeredDriver() string {
+	registered := make(map[string]bool)
+	for _, name := range sql.Drivers() {
+		registered[name] = true
+	}
+	for _, name := range driverNames {
+		if registered[name] {
+			return name
+		}
+	}
+	return ""
+}
+
+// Close closes the database connection
+func (s *PostgresStorage) Close() error {
+	return s.db.Close()
+}
+
+// CreateIssue creates an issue, filling in defaults, timestamps and, if it
+// has none, a sequential ID under the issue_prefix config ("bd" if unset)
+func (s *PostgresStorage) CreateIssue(ctx context.Context, issue *types.Issue, actor string) error {
+	if issue.Priority == types.PriorityUnset {
+		issue.Priority = types.DefaultPriority
+	}
+	if issue.IssueType == "" {
+		issue.IssueType = types.DefaultIssueType
+	}
+	if err := issue.Validate(); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	now := time.Now()
+	if issue.CreatedAt.IsZero() {
+		issue.CreatedAt = now
+	}
+	issue.UpdatedAt = now
+
+	if issue.ID == "" {
+		id, err := s.nextID(ctx)
+		if err != nil {
+			return err
+		}
+		issue.ID = id
+	}
+
+	data, err := json.Marshal(issue)
+	if err != nil {
+		return fmt.Errorf("failed to encode issue: %w", err)
+	}
+	result, err := s.db.ExecContext(ctx, `
+		INSERT INTO issues (id, title, description, status, priority, issue_type, assignee, draft, created_at, updated_at, data)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (id) DO NOTHING
+	`, issue.ID, issue.Title, issue.Description, string(issue.Status), issue.Priority, string(issue.IssueType),
+		issue.Assignee, issue.Draft, issue.CreatedAt, issue.UpdatedAt, string(data))
+	if err != nil {
+		return fmt.Errorf("failed to insert issue: %w", err)
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("issue %s already exists", issue.ID)
+	}
+	return nil
+}
+
+// nextID allocates the next sequential issue ID for the configured prefix
+func (s *PostgresStorage) nextID(ctx context.Context) (string, error) {
+	prefix, err := s.GetConfig(ctx, "issue_prefix")
+	if err != nil {
+		return "", err
+	}
+	if prefix == "" {
+		prefix = "bd"
+	}
+	var n int
+	err = s.db.QueryRowContext(ctx, `
+		INSERT INTO issue_counters (prefix, last_id) VALUES ($1, 1)
+		ON CONFLICT (prefix) DO UPDATE SET last_id = issue_counters.last_id + 1
+		RETURNING last_id
+	`, prefix).Scan(&n)
+	if err != nil {
+		return "", fmt.Errorf("failed to allocate issue ID: %w", err)
+	}
+	return fmt.Sprintf("%s-%d", prefix, n), nil
+}
+
+// GetIssue returns the issue with the given ID, or nil if there is none
+func (s *PostgresStorage) GetIssue(ctx context.Context, id string) (*types.Issue, error) {
+	var data []byte
+	err := s.db.QueryRowContext(ctx, `SELECT data FROM issues WHERE id = $1`, id).Scan(&data)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue: %w", err)
+	}
+	return decodeIssue(data)
+}
+
+// SearchIssues returns the issues matching query (a case-insensitive
+// substring of the title, description or ID) and filter, ordered by
+// priority and then newest first. Only the Status, Priority, IssueType,
+// Assignee, IDs, Limit, Offset, IncludeTombstones, IncludeDrafts and
+// IncludeSnoozed filters are supported; any other filter is an error rather
+// than ignored.
+func (s *PostgresStorage) SearchIssues(ctx context.Context, query string, filter types.IssueFilter) ([]*types.Issue, error) {
+	where, args, err := searchClauses(query, filter)
+	if err != nil {
+		return nil, err
+	}
+	sqlQuery := `SELECT data FROM issues`
+	if len(where) > 0 {
+		sqlQuery += ` WHERE ` + strings.Join(where, " AND ")
+	}
+	sqlQuery += ` ORDER BY priority ASC, created_at DESC, id ASC`
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		sqlQuery += fmt.Sprintf(` LIMIT $%d`, len(args))
+	}
+	if filter.Offset > 0 {
+		args = append(args, filter.Offset)
+		sqlQuery += fmt.Sprintf(` OFFSET $%d`, len(args))
+	}
+
+	rows, err := s.db.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search issues: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var issues []*types.Issue
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("failed to scan issue: %w", err)
+		}
+		issue, err := decodeIssue(data)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, issue)
+	}
+	return issues, rows.Err()
+}
+
+// searchClauses returns the WHERE conditions, and their args, for
+// SearchIssues
+func searchClauses(query string, filter types.IssueFilter) ([]string, []interface{}, error) {
+	// Refuse filters this backend can't evaluate instead of silently
+	// returning too many issues
+	supported := types.IssueFilter{
+		Status:            filter.Status,
+		Priority:          filter.Priority,
+		IssueType:         filter.IssueType,
+		Assignee:          filter.Assignee,
+		IDs:               filter.IDs,
+		Limit:             filter.Limit,
+		Offset:            filter.Offset,
+		IncludeTombstones: filter.IncludeTombstones,
+		IncludeDrafts:     filter.IncludeDrafts,
+		IncludeSnoozed:    filter.IncludeSnoozed,
+	}
+	if !reflect.DeepEqual(filter, supported) {
+		return nil, nil, fmt.Errorf("issue filter not supported by the postgres backend (supported: status, priority, type, assignee, IDs, limit, offset)")
+	}
+
+	var where []string
+	var args []interface{}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if query != "" {
+		pattern := arg("%" + query + "%")
+		where = append(where, fmt.Sprintf("(title ILIKE %s OR description ILIKE %s OR id ILIKE %s)", pattern, pattern, pattern))
+	}
+	if filter.Status != nil {
+		where = append(where, "status = "+arg(string(*filter.Status)))
+	} else {
+		if !filter.IncludeTombstones {
+			where = append(where, "status <> "+arg(string(types.StatusTombstone)))
+		}
+		if !filter.IncludeSnoozed {
+			where = append(where, "status <> "+arg(string(types.StatusSnoozed)))
+		}
+	}
+	if !filter.IncludeDrafts {
+		where = append(where, "NOT draft")
+	}
+	if filter.Priority != nil {
+		where = append(where, "priority = "+arg(*filter.Priority))
+	}
+	if filter.IssueType != nil {
+		where = append(where, "issue_type = "+arg(string(*filter.IssueType)))
+	}
+	if filter.Assignee != nil {
+		where = append(where, "assignee = "+arg(*filter.Assignee))
+	}
+	if len(filter.IDs) > 0 {
+		placeholders := make([]string, len(filter.IDs))
+		for i, id := range filter.IDs {
+			placeholders[i] = arg(id)
+		}
+		where = append(where, "id IN ("+strings.Join(placeholders, ", ")+")")
+	}
+	return where, args, nil
+}
+
+// decodeIssue decodes an issue stored in the data column
+func decodeIssue(data []byte) (*types.Issue, error) {
+	var issue types.Issue
+	if err := json.Unmarshal(data, &issue); err != nil {
+		return nil, fmt.Errorf("failed to decode issue: %w", err)
+	}
+	return &issue, nil
+}
+
+// SetConfig sets a configuration value, rejecting unregistered keys and
+// invalid values (see storage.RegisterConfigKey)
+func (s *PostgresStorage) SetConfig(ctx context.Context, key, value string) error {
+	if err := storage.ValidateConfig(key, value); err != nil {
+		return err
+	}
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO config (key, value) VALUES ($1, $2)
+		ON CONFLICT (key) DO UPDATE SET value = excluded.value
+	`, key, value)
+	if err != nil {
+		return fmt.Errorf("failed to set config: %w", err)
+	}
+	return nil
+}
+
+// GetConfig returns a configuration value, or "" if it is unset
+func (s *PostgresStorage) GetConfig(ctx context.Context, key string) (string, error) {
+	var value string
+	err := s.db.QueryRowContext(ctx, `SELECT value FROM config WHERE key = $1`, key).Scan(&value)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get config: %w", err)
+	}
+	return value, nil
+}
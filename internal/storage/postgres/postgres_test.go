@@ -0,0 +1,82 @@
+package postgres
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestSearchClauses(t *testing.T) {
+	status := types.StatusOpen
+	priority := 1
+	where, args, err := searchClauses("login", types.IssueFilter{
+		Status:   &status,
+		Priority: &priority,
+		IDs:      []string{"bd-1", "bd-2"},
+		Limit:    10,
+	})
+	if err != nil {
+		t.Fatalf("searchClauses failed: %v", err)
+	}
+	wantWhere := []string{
+		"(title ILIKE $1 OR description ILIKE $1 OR id ILIKE $1)",
+		"status = $2",
+		"NOT draft",
+		"priority = $3",
+		"id IN ($4, $5)",
+	}
+	if !reflect.DeepEqual(where, wantWhere) {
+		t.Errorf("Expected clauses %q, got %q", wantWhere, where)
+	}
+	wantArgs := []interface{}{"%login%", "open", 1, "bd-1", "bd-2"}
+	if !reflect.DeepEqual(args, wantArgs) {
+		t.Errorf("Expected args %v, got %v", wantArgs, args)
+	}
+
+	// Without a status, tombstones and snoozed issues are hidden
+	where, _, err = searchClauses("", types.IssueFilter{IncludeDrafts: true})
+	if err != nil {
+		t.Fatalf("searchClauses failed: %v", err)
+	}
+	if got := strings.Join(where, " AND "); got != "status <> $1 AND status <> $2" {
+		t.Errorf("Unexpected default clauses %q", got)
+	}
+
+	if _, _, err := searchClauses("", types.IssueFilter{Labels: []string{"ui"}}); err == nil {
+		t.Error("Expected an unsupported filter to be rejected")
+	}
+}
+
+func TestApplyUpdates(t *testing.T) {
+	ref := "gh-1"
+	issue := &types.Issue{ID: "bd-1", Title: "Old", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, ExternalRef: &ref}
+
+	err := applyUpdates(issue, map[string]interface{}{
+		"title":        "New",
+		"status":       types.StatusClosed,
+		"priority":     0,
+		"external_ref": nil,
+	})
+	if err != nil {
+		t.Fatalf("applyUpdates failed: %v", err)
+	}
+	if issue.ID != "bd-1" || issue.Title != "New" || issue.Priority != 0 || issue.ExternalRef != nil {
+		t.Errorf("Unexpected issue after update: %+v", issue)
+	}
+	if issue.Status != types.StatusClosed || issue.ClosedAt == nil {
+		t.Errorf("Expected the issue closed with closed_at set, got %s %v", issue.Status, issue.ClosedAt)
+	}
+
+	if err := applyUpdates(issue, map[string]interface{}{"status": "open"}); err != nil {
+		t.Fatalf("applyUpdates failed: %v", err)
+	}
+	if issue.ClosedAt != nil {
+		t.Error("Expected reopening to clear closed_at")
+	}
+
+	if err := applyUpdates(issue, map[string]interface{}{"no_such_field": 1}); err == nil {
+		t.Error("Expected an unknown field to be rejected")
+	}
+}
@@ -0,0 +1,506 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ErrUnsupported is returned (wrapped) by the storage.Storage operations the
+// Postgres backend doesn't implement yet, such as dependencies, comments,
+// events, ready work and the JSONL sync bookkeeping
+var ErrUnsupported = errors.New("not supported by the postgres backend")
+
+var _ storage.Storage = (*PostgresStorage)(nil)
+
+func unsupported(op string) error {
+	return fmt.Errorf("%s: %w", op, ErrUnsupported)
+}
+
+// issueJSONFields are the JSONL field names of types.Issue, which are the
+// keys UpdateIssue accepts
+var issueJSONFields = func() map[string]bool {
+	fields := make(map[string]bool)
+	t := reflect.TypeOf(types.Issue{})
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			fields[name] = true
+		}
+	}
+	return fields
+}()
+
+// CreateIssues creates each issue in turn, stopping at the first failure.
+// Unlike the SQLite backend it is not atomic.
+func (s *PostgresStorage) CreateIssues(ctx context.Context, issues []*types.Issue, actor string) error {
+	for _, issue := range issues {
+		if err := s.CreateIssue(ctx, issue, actor); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetIssueByExternalRef returns the issue with the given external reference,
+// or nil if there is none
+func (s *PostgresStorage) GetIssueByExternalRef(ctx context.Context, externalRef string) (*types.Issue, error) {
+	var data []byte
+	err := s.db.QueryRowContext(ctx, `SELECT data FROM issues WHERE data->>'external_ref' = $1 LIMIT 1`, externalRef).Scan(&data)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue by external ref: %w", err)
+	}
+	return decodeIssue(data)
+}
+
+// UpdateIssue sets the given fields, keyed by their JSONL names, keeping
+// closed_at in step with the status
+func (s *PostgresStorage) UpdateIssue(ctx context.Context, id string, updates map[string]interface{}, actor string) error {
+	return s.modifyIssue(ctx, id, func(issue *types.Issue) error {
+		return applyUpdates(issue, updates)
+	})
+}
+
+// applyUpdates sets the fields in updates on issue, going through the
+// JSONL encoding so any field can be set by name
+func applyUpdates(issue *types.Issue, updates map[string]interface{}) error {
+	fields, err := issueMap(issue)
+	if err != nil {
+		return err
+	}
+	for key, value := range updates {
+		if !issueJSONFields[key] {
+			return fmt.Errorf("invalid field for update: %s", key)
+		}
+		if value == nil {
+			delete(fields, key)
+		} else {
+			fields[key] = value
+		}
+	}
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return fmt.Errorf("failed to encode issue: %w", err)
+	}
+	var updated types.Issue
+	if err := json.Unmarshal(data, &updated); err != nil {
+		return fmt.Errorf("invalid update: %w", err)
+	}
+	if updated.Status != types.StatusClosed {
+		updated.ClosedAt = nil
+	} else if updated.ClosedAt == nil {
+		now := time.Now()
+		updated.ClosedAt = &now
+	}
+	*issue = updated
+	return nil
+}
+
+// CloseIssue closes an issue with reason
+func (s *PostgresStorage) CloseIssue(ctx context.Context, id string, reason string, actor string) error {
+	return s.modifyIssue(ctx, id, func(issue *types.Issue) error {
+		now := time.Now()
+		issue.Status = types.StatusClosed
+		issue.ClosedAt = &now
+		issue.CloseReason = reason
+		return nil
+	})
+}
+
+// DeleteIssue permanently removes an issue
+func (s *PostgresStorage) DeleteIssue(ctx context.Context, id string) error {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM issues WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete issue: %w", err)
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("issue not found: %s", id)
+	}
+	return nil
+}
+
+// modifyIssue applies fn to issue id under a row lock and saves the result
+func (s *PostgresStorage) modifyIssue(ctx context.Context, id string, fn func(issue *types.Issue) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var data []byte
+	err = tx.QueryRowContext(ctx, `SELECT data FROM issues WHERE id = $1 FOR UPDATE`, id).Scan(&data)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("issue %s not found", id)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get issue: %w", err)
+	}
+	issue, err := decodeIssue(data)
+	if err != nil {
+		return err
+	}
+	if err := fn(issue); err != nil {
+		return err
+	}
+	issue.ID = id
+	issue.UpdatedAt = time.Now()
+	if err := issue.Validate(); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	if data, err = json.Marshal(issue); err != nil {
+		return fmt.Errorf("failed to encode issue: %w", err)
+	}
+	_, err = tx.ExecContext(ctx, `
+		UPDATE issues SET title = $2, description = $3, status = $4, priority = $5, issue_type = $6,
+			assignee = $7, draft = $8, updated_at = $9, data = $10
+		WHERE id = $1
+	`, id, issue.Title, issue.Description, string(issue.Status), issue.Priority, string(issue.IssueType),
+		issue.Assignee, issue.Draft, issue.UpdatedAt, string(data))
+	if err != nil {
+		return fmt.Errorf("failed to update issue: %w", err)
+	}
+	return tx.Commit()
+}
+
+// issueMap returns issue in its JSONL encoding, keyed by field name
+func issueMap(issue *types.Issue) (map[string]interface{}, error) {
+	data, err := json.Marshal(issue)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode issue: %w", err)
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("failed to encode issue: %w", err)
+	}
+	return fields, nil
+}
+
+// AddLabel adds a label to an issue; adding one it already has is a no-op
+func (s *PostgresStorage) AddLabel(ctx context.Context, issueID, label, actor string) error {
+	return s.modifyIssue(ctx, issueID, func(issue *types.Issue) error {
+		for _, existing := range issue.Labels {
+			if existing == label {
+				return nil
+			}
+		}
+		issue.Labels = append(issue.Labels, label)
+		sort.Strings(issue.Labels)
+		return nil
+	})
+}
+
+// RemoveLabel removes a label from an issue
+func (s *PostgresStorage) RemoveLabel(ctx context.Context, issueID, label, actor string) error {
+	return s.modifyIssue(ctx, issueID, func(issue *types.Issue) error {
+		labels := issue.Labels[:0]
+		for _, existing := range issue.Labels {
+			if existing != label {
+				labels = append(labels, existing)
+			}
+		}
+		issue.Labels = labels
+		return nil
+	})
+}
+
+// GetLabels returns an issue's labels
+func (s *PostgresStorage) GetLabels(ctx context.Context, issueID string) ([]string, error) {
+	issue, err := s.GetIssue(ctx, issueID)
+	if err != nil || issue == nil {
+		return nil, err
+	}
+	return issue.Labels, nil
+}
+
+// GetLabelsForIssues returns the labels of each of issueIDs that has any
+func (s *PostgresStorage) GetLabelsForIssues(ctx context.Context, issueIDs []string) (map[string][]string, error) {
+	result := make(map[string][]string)
+	if len(issueIDs) == 0 {
+		return result, nil
+	}
+	issues, err := s.SearchIssues(ctx, "", types.IssueFilter{IDs: issueIDs, IncludeTombstones: true, IncludeDrafts: true, IncludeSnoozed: true})
+	if err != nil {
+		return nil, err
+	}
+	for _, issue := range issues {
+		if len(issue.Labels) > 0 {
+			result[issue.ID] = issue.Labels
+		}
+	}
+	return result, nil
+}
+
+// GetIssuesByLabel returns the issues with label
+func (s *PostgresStorage) GetIssuesByLabel(ctx context.Context, label string) ([]*types.Issue, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT data FROM issues WHERE data->'labels' @> jsonb_build_array($1::text)
+		ORDER BY priority ASC, created_at DESC, id ASC
+	`, label)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issues by label: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var issues []*types.Issue
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("failed to scan issue: %w", err)
+		}
+		issue, err := decodeIssue(data)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, issue)
+	}
+	return issues, rows.Err()
+}
+
+// GetNextChildID allocates the next hierarchical child ID under parentID,
+// e.g. bd-1.1, bd-1.2
+func (s *PostgresStorage) GetNextChildID(ctx context.Context, parentID string) (string, error) {
+	parent, err := s.GetIssue(ctx, parentID)
+	if err != nil {
+		return "", err
+	}
+	if parent == nil {
+		return "", fmt.Errorf("parent issue %s does not exist", parentID)
+	}
+	if strings.Count(parentID, ".") >= 3 {
+		return "", fmt.Errorf("maximum hierarchy depth (3) exceeded for parent %s", parentID)
+	}
+	var n int
+	err = s.db.QueryRowContext(ctx, `
+		INSERT INTO issue_counters (prefix, last_id) VALUES ($1, 1)
+		ON CONFLICT (prefix) DO UPDATE SET last_id = issue_counters.last_id + 1
+		RETURNING last_id
+	`, "child:"+parentID).Scan(&n)
+	if err != nil {
+		return "", fmt.Errorf("failed to allocate child ID: %w", err)
+	}
+	return fmt.Sprintf("%s.%d", parentID, n), nil
+}
+
+// GetAllConfig returns every configuration value
+func (s *PostgresStorage) GetAllConfig(ctx context.Context) (map[string]string, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT key, value FROM config ORDER BY key`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	config := make(map[string]string)
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, fmt.Errorf("failed to scan config: %w", err)
+		}
+		config[key] = value
+	}
+	return config, rows.Err()
+}
+
+// DeleteConfig removes a configuration value
+func (s *PostgresStorage) DeleteConfig(ctx context.Context, key string) error {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM config WHERE key = $1`, key); err != nil {
+		return fmt.Errorf("failed to delete config: %w", err)
+	}
+	return nil
+}
+
+// GetCustomStatuses returns the statuses listed in the status.custom config
+func (s *PostgresStorage) GetCustomStatuses(ctx context.Context) ([]string, error) {
+	value, err := s.GetConfig(ctx, "status.custom")
+	if err != nil || value == "" {
+		return nil, err
+	}
+	var statuses []string
+	for _, status := range strings.Split(value, ",") {
+		if status = strings.TrimSpace(status); status != "" {
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses, nil
+}
+
+// SetMetadata sets an internal metadata value
+func (s *PostgresStorage) SetMetadata(ctx context.Context, key, value string) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO metadata (key, value) VALUES ($1, $2)
+		ON CONFLICT (key) DO UPDATE SET value = excluded.value
+	`, key, value)
+	if err != nil {
+		return fmt.Errorf("failed to set metadata: %w", err)
+	}
+	return nil
+}
+
+// GetMetadata returns an internal metadata value, or "" if it is unset
+func (s *PostgresStorage) GetMetadata(ctx context.Context, key string) (string, error) {
+	var value string
+	err := s.db.QueryRowContext(ctx, `SELECT value FROM metadata WHERE key = $1`, key).Scan(&value)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get metadata: %w", err)
+	}
+	return value, nil
+}
+
+// Path returns "": a Postgres store has no local database file
+func (s *PostgresStorage) Path() string {
+	return ""
+}
+
+// UnderlyingDB returns the connection pool
+func (s *PostgresStorage) UnderlyingDB() *sql.DB {
+	return s.db
+}
+
+// UnderlyingConn returns a single connection from the pool, which the
+// caller must close
+func (s *PostgresStorage) UnderlyingConn(ctx context.Context) (*sql.Conn, error) {
+	return s.db.Conn(ctx)
+}
+
+func (s *PostgresStorage) AddDependency(ctx context.Context, dep *types.Dependency, actor string) error {
+	return unsupported("add dependency")
+}
+
+func (s *PostgresStorage) RemoveDependency(ctx context.Context, issueID, dependsOnID string, actor string) error {
+	return unsupported("remove dependency")
+}
+
+func (s *PostgresStorage) GetDependencies(ctx context.Context, issueID string) ([]*types.Issue, error) {
+	return nil, unsupported("get dependencies")
+}
+
+func (s *PostgresStorage) GetDependents(ctx context.Context, issueID string) ([]*types.Issue, error) {
+	return nil, unsupported("get dependents")
+}
+
+func (s *PostgresStorage) GetDependencyRecords(ctx context.Context, issueID string) ([]*types.Dependency, error) {
+	return nil, unsupported("get dependency records")
+}
+
+func (s *PostgresStorage) GetAllDependencyRecords(ctx context.Context) (map[string][]*types.Dependency, error) {
+	return nil, unsupported("get dependency records")
+}
+
+func (s *PostgresStorage) GetDependencyCounts(ctx context.Context, issueIDs []string) (map[string]*types.DependencyCounts, error) {
+	return nil, unsupported("get dependency counts")
+}
+
+func (s *PostgresStorage) GetDependencyTree(ctx context.Context, issueID string, maxDepth int, showAllPaths bool, reverse bool) ([]*types.TreeNode, error) {
+	return nil, unsupported("get dependency tree")
+}
+
+func (s *PostgresStorage) DetectCycles(ctx context.Context) ([][]*types.Issue, error) {
+	return nil, unsupported("detect cycles")
+}
+
+func (s *PostgresStorage) GetReadyWork(ctx context.Context, filter types.WorkFilter) ([]*types.Issue, error) {
+	return nil, unsupported("get ready work")
+}
+
+func (s *PostgresStorage) GetBlockedIssues(ctx context.Context) ([]*types.BlockedIssue, error) {
+	return nil, unsupported("get blocked issues")
+}
+
+func (s *PostgresStorage) GetEpicsEligibleForClosure(ctx context.Context) ([]*types.EpicStatus, error) {
+	return nil, unsupported("get epics eligible for closure")
+}
+
+func (s *PostgresStorage) GetStaleIssues(ctx context.Context, filter types.StaleFilter) ([]*types.Issue, error) {
+	return nil, unsupported("get stale issues")
+}
+
+func (s *PostgresStorage) AddComment(ctx context.Context, issueID, actor, comment string) error {
+	return unsupported("add event")
+}
+
+func (s *PostgresStorage) GetEvents(ctx context.Context, issueID string, limit int) ([]*types.Event, error) {
+	return nil, unsupported("get events")
+}
+
+func (s *PostgresStorage) AddIssueComment(ctx context.Context, issueID, author, text string) (*types.Comment, error) {
+	return nil, unsupported("add comment")
+}
+
+func (s *PostgresStorage) GetIssueComments(ctx context.Context, issueID string) ([]*types.Comment, error) {
+	return nil, unsupported("get comments")
+}
+
+func (s *PostgresStorage) GetCommentsForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.Comment, error) {
+	return nil, unsupported("get comments")
+}
+
+func (s *PostgresStorage) GetStatistics(ctx context.Context) (*types.Statistics, error) {
+	return nil, unsupported("get statistics")
+}
+
+func (s *PostgresStorage) GetDirtyIssues(ctx context.Context) ([]string, error) {
+	return nil, unsupported("get dirty issues")
+}
+
+func (s *PostgresStorage) GetDirtyIssueHash(ctx context.Context, issueID string) (string, error) {
+	return "", unsupported("get dirty issue hash")
+}
+
+func (s *PostgresStorage) ClearDirtyIssues(ctx context.Context) error {
+	return unsupported("clear dirty issues")
+}
+
+func (s *PostgresStorage) ClearDirtyIssuesByID(ctx context.Context, issueIDs []string) error {
+	return unsupported("clear dirty issues")
+}
+
+func (s *PostgresStorage) GetExportHash(ctx context.Context, issueID string) (string, error) {
+	return "", unsupported("get export hash")
+}
+
+func (s *PostgresStorage) SetExportHash(ctx context.Context, issueID, contentHash string) error {
+	return unsupported("set export hash")
+}
+
+func (s *PostgresStorage) ClearAllExportHashes(ctx context.Context) error {
+	return unsupported("clear export hashes")
+}
+
+func (s *PostgresStorage) GetJSONLFileHash(ctx context.Context) (string, error) {
+	return "", unsupported("get JSONL file hash")
+}
+
+func (s *PostgresStorage) SetJSONLFileHash(ctx context.Context, fileHash string) error {
+	return unsupported("set JSONL file hash")
+}
+
+func (s *PostgresStorage) UpdateIssueID(ctx context.Context, oldID, newID string, issue *types.Issue, actor string) error {
+	return unsupported("rename issue")
+}
+
+func (s *PostgresStorage) RenameDependencyPrefix(ctx context.Context, oldPrefix, newPrefix string) error {
+	return unsupported("rename dependency prefix")
+}
+
+func (s *PostgresStorage) RenameCounterPrefix(ctx context.Context, oldPrefix, newPrefix string) error {
+	return unsupported("rename counter prefix")
+}
+
+func (s *PostgresStorage) RunInTransaction(ctx context.Context, fn func(tx storage.Transaction) error) error {
+	return unsupported("transactions")
+}
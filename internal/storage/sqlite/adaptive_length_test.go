@@ -152,7 +152,7 @@ func TestGetAdaptiveIDLength_Integration(t *testing.T) {
 	ctx := context.Background()
 	
 	// Get a dedicated connection for this test
-	conn, err := db.db.Conn(ctx)
+	conn, err := db.db().Conn(ctx)
 	if err != nil {
 		t.Fatalf("Failed to get connection: %v", err)
 	}
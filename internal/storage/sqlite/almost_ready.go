@@ -0,0 +1,117 @@
+// Package sqlite - issues about to become ready
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// AlmostReady returns the open issues that are blocked only by a few open
+// 'blocks' dependencies, at most AlmostReadyMaxBlockersConfigKey of them,
+// along with those remaining blockers. Finishing the blockers makes the
+// issue ready, so agents can prioritize the last blocker in the way of
+// downstream work.
+//
+// Issues that would stay blocked once their blockers close are left out:
+// those waiting on something outside beads (see SetExternalBlock) and
+// children of a blocked parent. The results are narrowed by filter as in
+// SearchIssues, and ordered the same way.
+func (s *SQLiteStorage) AlmostReady(ctx context.Context, filter types.IssueFilter) ([]types.AlmostReadyIssue, error) {
+	defer s.beginRead()()
+	maxBlockers, err := readAlmostReadyMaxBlockers(ctx, s.db())
+	if err != nil {
+		return nil, err
+	}
+
+	queryCtx, cancel := s.withQueryTimeout(ctx)
+	defer cancel()
+
+	// Only issues in blocked_issues_cache can be almost ready, which keeps
+	// this from scanning the whole dependency table
+	rows, err := s.db().QueryContext(queryCtx, `
+		SELECT i.id, GROUP_CONCAT(d.depends_on_id, ',')
+		FROM blocked_issues_cache c
+		JOIN issues i ON i.id = c.issue_id
+		JOIN dependencies d ON d.issue_id = i.id AND d.type = ?
+		JOIN issues blocker ON blocker.id = d.depends_on_id
+		WHERE i.status = ?
+		  AND i.external_blocked_reason IS NULL
+		  AND blocker.status IN (?, ?, ?, ?)
+		  AND NOT EXISTS (
+		      SELECT 1 FROM dependencies p
+		      JOIN blocked_issues_cache pc ON pc.issue_id = p.depends_on_id
+		      WHERE p.issue_id = i.id AND p.type = ?
+		  )
+		GROUP BY i.id
+		HAVING COUNT(*) <= ?
+	`, types.DepBlocks, types.StatusOpen, types.StatusOpen, types.StatusInProgress, types.StatusBlocked,
+		types.StatusSnoozed, types.DepParentChild, maxBlockers)
+	if err != nil {
+		return nil, withContextError(queryCtx, wrapDBError("query almost ready issues", err))
+	}
+	defer func() { _ = rows.Close() }()
+
+	blockers := make(map[string][]string)
+	for rows.Next() {
+		var id, blockerIDs string
+		if err := rows.Scan(&id, &blockerIDs); err != nil {
+			return nil, wrapDBError("scan almost ready issue", err)
+		}
+		remaining := strings.Split(blockerIDs, ",")
+		sort.Strings(remaining)
+		blockers[id] = remaining
+	}
+	if err := rows.Err(); err != nil {
+		return nil, withContextError(queryCtx, wrapDBError("iterate almost ready issues", err))
+	}
+
+	// Narrow the candidates to those matching filter
+	ids := make([]string, 0, len(blockers))
+	if len(filter.IDs) > 0 {
+		for _, id := range filter.IDs {
+			if _, ok := blockers[id]; ok {
+				ids = append(ids, id)
+			}
+		}
+	} else {
+		for id := range blockers {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	filter.IDs = ids
+	issues, err := s.SearchIssues(ctx, "", filter)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]types.AlmostReadyIssue, 0, len(issues))
+	for _, issue := range issues {
+		result = append(result, types.AlmostReadyIssue{Issue: *issue, RemainingBlockers: blockers[issue.ID]})
+	}
+	return result, nil
+}
+
+// readAlmostReadyMaxBlockers returns AlmostReadyMaxBlockersConfigKey, or
+// DefaultAlmostReadyMaxBlockers if it is unset or invalid
+func readAlmostReadyMaxBlockers(ctx context.Context, q queryer) (int, error) {
+	var value string
+	err := q.QueryRowContext(ctx, `SELECT value FROM config WHERE key = ?`, AlmostReadyMaxBlockersConfigKey).Scan(&value)
+	if err == sql.ErrNoRows {
+		return DefaultAlmostReadyMaxBlockers, nil
+	} else if err != nil {
+		return 0, wrapDBError("read almost ready threshold", err)
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || n < 1 {
+		return DefaultAlmostReadyMaxBlockers, nil
+	}
+	return n, nil
+}
@@ -0,0 +1,100 @@
+package sqlite
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestAlmostReady(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	newIssue := func(title string) string {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		return issue.ID
+	}
+	addDep := func(issueID, dependsOnID string, depType types.DependencyType) {
+		dep := &types.Dependency{IssueID: issueID, DependsOnID: dependsOnID, Type: depType}
+		if err := store.AddDependency(ctx, dep, "test"); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+	}
+	almostReady := func(filter types.IssueFilter) map[string][]string {
+		t.Helper()
+		issues, err := store.AlmostReady(ctx, filter)
+		if err != nil {
+			t.Fatalf("AlmostReady failed: %v", err)
+		}
+		got := make(map[string][]string)
+		for _, issue := range issues {
+			got[issue.ID] = issue.RemainingBlockers
+		}
+		return got
+	}
+
+	a, b, done := newIssue("Blocker A"), newIssue("Blocker B"), newIssue("Done")
+	oneLeft := newIssue("One blocker")
+	addDep(oneLeft, a, types.DepBlocks)
+	twoLeft := newIssue("Two blockers")
+	addDep(twoLeft, a, types.DepBlocks)
+	addDep(twoLeft, b, types.DepBlocks)
+	oneOfTwoClosed := newIssue("One of two blockers closed")
+	addDep(oneOfTwoClosed, done, types.DepBlocks)
+	addDep(oneOfTwoClosed, b, types.DepBlocks)
+	if err := store.CloseIssue(ctx, done, "done", "test"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+
+	// A child of a blocked parent stays blocked after its own blocker closes
+	child := newIssue("Child of blocked parent")
+	addDep(child, oneLeft, types.DepParentChild)
+	addDep(child, b, types.DepBlocks)
+
+	external := newIssue("Externally blocked")
+	addDep(external, a, types.DepBlocks)
+	if err := store.SetExternalBlock(ctx, external, "waiting on vendor", "test"); err != nil {
+		t.Fatalf("SetExternalBlock failed: %v", err)
+	}
+
+	want := map[string][]string{oneLeft: {a}, oneOfTwoClosed: {b}}
+	if got := almostReady(types.IssueFilter{}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	if err := store.AddLabel(ctx, oneOfTwoClosed, "backend", "test"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+	want = map[string][]string{oneOfTwoClosed: {b}}
+	if got := almostReady(types.IssueFilter{Labels: []string{"backend"}}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v with a label filter, got %v", want, got)
+	}
+	if got := almostReady(types.IssueFilter{IDs: []string{twoLeft, oneLeft}}); !reflect.DeepEqual(got, map[string][]string{oneLeft: {a}}) {
+		t.Errorf("Expected only %s with an ID filter, got %v", oneLeft, got)
+	}
+
+	if err := store.SetConfig(ctx, AlmostReadyMaxBlockersConfigKey, "2"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	remaining := []string{a, b}
+	if a > b {
+		remaining = []string{b, a}
+	}
+	want = map[string][]string{oneLeft: {a}, oneOfTwoClosed: {b}, twoLeft: remaining}
+	if got := almostReady(types.IssueFilter{}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v with a threshold of 2, got %v", want, got)
+	}
+
+	// Invalid thresholds fall back to the default
+	if err := store.SetConfig(ctx, AlmostReadyMaxBlockersConfigKey, "0"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	if got := almostReady(types.IssueFilter{}); len(got) != 2 {
+		t.Errorf("Expected the default threshold for an invalid config value, got %v", got)
+	}
+}
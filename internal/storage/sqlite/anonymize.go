@@ -0,0 +1,179 @@
+// Package sqlite - anonymized exports for sharing reproductions
+package sqlite
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/export"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// AnonymizeMode selects how ExportAnonymized replaces free text
+type AnonymizeMode string
+
+const (
+	// AnonymizeHash replaces text with a short hash of it, so equal texts
+	// stay equal (the default)
+	AnonymizeHash AnonymizeMode = "hash"
+	// AnonymizeLorem replaces text with lorem ipsum of the same word count
+	AnonymizeLorem AnonymizeMode = "lorem"
+)
+
+// AnonymizeOptions configures ExportAnonymized
+type AnonymizeOptions struct {
+	// Mode is how titles, descriptions and other free text are replaced.
+	// Empty means AnonymizeHash.
+	Mode AnonymizeMode
+
+	// Salt is mixed into every replacement. The same database, options and
+	// salt always give the same output; a secret salt stops anyone from
+	// confirming a guessed title against its hash.
+	Salt string
+
+	// KeepLabels exports labels as they are instead of hashing them
+	KeepLabels bool
+}
+
+// ExportAnonymized writes every issue (including tombstones, excluding
+// drafts) to w as JSONL like a regular export, with sensitive content
+// replaced. IDs, statuses, priorities, issue types, timestamps and the full
+// dependency graph are kept, so structural bugs still reproduce.
+//
+// Titles, descriptions, design, acceptance criteria, notes, close and delete
+// reasons are replaced according to opts.Mode; assignees, labels (unless
+// KeepLabels) and the actors on dependencies and tombstones become
+// pseudonyms. Comments, links and external refs are dropped. Replacements are
+// deterministic: the same input and options always produce the same output.
+func (s *SQLiteStorage) ExportAnonymized(ctx context.Context, w io.Writer, opts AnonymizeOptions) error {
+	switch opts.Mode {
+	case "":
+		opts.Mode = AnonymizeHash
+	case AnonymizeHash, AnonymizeLorem:
+	default:
+		return fmt.Errorf("invalid anonymize mode %q (must be %s or %s)", opts.Mode, AnonymizeHash, AnonymizeLorem)
+	}
+
+	issues, err := s.SearchIssues(ctx, "", types.IssueFilter{IncludeTombstones: true, IncludeSnoozed: true})
+	if err != nil {
+		return fmt.Errorf("failed to query issues: %w", err)
+	}
+	allDeps, err := s.GetAllDependencyRecords(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get dependencies: %w", err)
+	}
+	sort.Slice(issues, func(i, j int) bool {
+		return issues[i].ID < issues[j].ID
+	})
+
+	a := anonymizer{opts: opts}
+	encoder := export.NewIssueEncoder(w, export.LoadTimestampFormat(ctx, s))
+	for _, issue := range issues {
+		anon := a.issue(issue, allDeps[issue.ID])
+		if err := encoder.Encode(anon); err != nil {
+			return fmt.Errorf("failed to encode issue %s: %w", issue.ID, err)
+		}
+	}
+	return nil
+}
+
+// anonymizer replaces sensitive values deterministically
+type anonymizer struct {
+	opts AnonymizeOptions
+}
+
+// issue returns the anonymized copy of issue with deps attached
+func (a anonymizer) issue(issue *types.Issue, deps []*types.Dependency) *types.Issue {
+	anon := &types.Issue{
+		ID:                 issue.ID,
+		Title:              a.text("title", issue.Title),
+		Description:        a.text("description", issue.Description),
+		Design:             a.text("design", issue.Design),
+		AcceptanceCriteria: a.text("acceptance_criteria", issue.AcceptanceCriteria),
+		Notes:              a.text("notes", issue.Notes),
+		Status:             issue.Status,
+		Priority:           issue.Priority,
+		IssueType:          issue.IssueType,
+		Assignee:           a.pseudonym("user", issue.Assignee),
+		EstimatedMinutes:   issue.EstimatedMinutes,
+		CreatedAt:          issue.CreatedAt,
+		UpdatedAt:          issue.UpdatedAt,
+		ClosedAt:           issue.ClosedAt,
+		CloseReason:        a.text("close_reason", issue.CloseReason),
+		DeletedAt:          issue.DeletedAt,
+		DeletedBy:          a.pseudonym("user", issue.DeletedBy),
+		DeleteReason:       a.text("delete_reason", issue.DeleteReason),
+		OriginalType:       issue.OriginalType,
+	}
+
+	for _, label := range issue.Labels {
+		if !a.opts.KeepLabels {
+			label = a.pseudonym("label", label)
+		}
+		anon.Labels = append(anon.Labels, label)
+	}
+	sort.Strings(anon.Labels)
+
+	for _, dep := range deps {
+		anonDep := *dep
+		anonDep.CreatedBy = a.pseudonym("user", dep.CreatedBy)
+		anon.Dependencies = append(anon.Dependencies, &anonDep)
+	}
+	return anon
+}
+
+// digest hashes value for field with the salt
+func (a anonymizer) digest(field, value string) []byte {
+	h := sha256.New()
+	h.Write([]byte(a.opts.Salt))
+	h.Write([]byte{0})
+	h.Write([]byte(field))
+	h.Write([]byte{0})
+	h.Write([]byte(value))
+	return h.Sum(nil)
+}
+
+// pseudonym replaces a name-like value (assignee, actor, label) with kind-
+// followed by a short hash. Empty stays empty.
+func (a anonymizer) pseudonym(kind, value string) string {
+	if value == "" {
+		return ""
+	}
+	return kind + "-" + hex.EncodeToString(a.digest(kind, value)[:4])
+}
+
+// text replaces free text in field according to the mode. Empty stays empty.
+func (a anonymizer) text(field, value string) string {
+	if value == "" {
+		return ""
+	}
+	sum := a.digest(field, value)
+	if a.opts.Mode != AnonymizeLorem {
+		return hex.EncodeToString(sum[:6])
+	}
+
+	// Same number of words, chosen by a generator seeded from the hash
+	words := len(strings.Fields(value))
+	if words == 0 {
+		words = 1
+	}
+	seed := binary.BigEndian.Uint64(sum[:8])
+	out := make([]string, words)
+	for i := range out {
+		seed = seed*6364136223846793005 + 1442695040888963407
+		out[i] = loremWords[(seed>>33)%uint64(len(loremWords))]
+	}
+	return strings.Join(out, " ")
+}
+
+var loremWords = strings.Fields(`lorem ipsum dolor sit amet consectetur adipiscing elit sed do
+	eiusmod tempor incididunt ut labore et dolore magna aliqua enim ad minim veniam quis
+	nostrud exercitation ullamco laboris nisi aliquip ex ea commodo consequat duis aute irure
+	in reprehenderit voluptate velit esse cillum fugiat nulla pariatur excepteur sint occaecat
+	cupidatat non proident sunt culpa qui officia deserunt mollit anim id est laborum`)
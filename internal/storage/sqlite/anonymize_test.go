@@ -0,0 +1,103 @@
+package sqlite
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestExportAnonymized(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	secret := &types.Issue{
+		Title:       "Leak in payroll service",
+		Description: "Customer ACME reported salaries visible",
+		Status:      types.StatusOpen,
+		Priority:    0,
+		IssueType:   types.TypeBug,
+		Assignee:    "alice",
+		Labels:      []string{"security"},
+	}
+	blocker := &types.Issue{Title: "Rotate payroll credentials", Status: types.StatusInProgress, Priority: 1, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{secret, blocker} {
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	dep := &types.Dependency{IssueID: secret.ID, DependsOnID: blocker.ID, Type: types.DepBlocks}
+	if err := store.AddDependency(ctx, dep, "bob"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+	if _, err := store.AddIssueComment(ctx, secret.ID, "alice", "the password is hunter2"); err != nil {
+		t.Fatalf("AddIssueComment failed: %v", err)
+	}
+
+	exportWith := func(opts AnonymizeOptions) string {
+		var buf bytes.Buffer
+		if err := store.ExportAnonymized(ctx, &buf, opts); err != nil {
+			t.Fatalf("ExportAnonymized failed: %v", err)
+		}
+		return buf.String()
+	}
+
+	out := exportWith(AnonymizeOptions{Salt: "s1"})
+	for _, sensitive := range []string{"payroll", "ACME", "alice", "bob", "security", "hunter2"} {
+		if strings.Contains(out, sensitive) {
+			t.Errorf("Expected %q to be anonymized, got %s", sensitive, out)
+		}
+	}
+	if again := exportWith(AnonymizeOptions{Salt: "s1"}); again != out {
+		t.Error("Expected identical output for identical input and options")
+	}
+	if other := exportWith(AnonymizeOptions{Salt: "s2"}); other == out {
+		t.Error("Expected a different salt to change the output")
+	}
+
+	// Structure is preserved
+	var issues []*types.Issue
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		var issue types.Issue
+		if err := json.Unmarshal(scanner.Bytes(), &issue); err != nil {
+			t.Fatalf("failed to parse export line: %v", err)
+		}
+		issues = append(issues, &issue)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("Expected 2 issues, got %d", len(issues))
+	}
+	var got *types.Issue
+	for _, issue := range issues {
+		if issue.ID == secret.ID {
+			got = issue
+		}
+	}
+	if got == nil {
+		t.Fatalf("Expected issue %s in export", secret.ID)
+	}
+	if got.Status != types.StatusOpen || got.Priority != 0 || got.IssueType != types.TypeBug || got.Assignee == "" {
+		t.Errorf("Expected structure to be preserved, got %+v", got)
+	}
+	if len(got.Dependencies) != 1 || got.Dependencies[0].DependsOnID != blocker.ID || got.Dependencies[0].Type != types.DepBlocks {
+		t.Errorf("Expected blocks dependency on %s, got %+v", blocker.ID, got.Dependencies)
+	}
+	if len(got.Comments) != 0 {
+		t.Errorf("Expected comments to be dropped, got %d", len(got.Comments))
+	}
+
+	// Lorem mode keeps the word count; KeepLabels keeps labels
+	lorem := exportWith(AnonymizeOptions{Mode: AnonymizeLorem, KeepLabels: true})
+	if strings.Contains(lorem, "payroll") || !strings.Contains(lorem, `"security"`) {
+		t.Errorf("Expected lorem text with labels kept, got %s", lorem)
+	}
+
+	if err := store.ExportAnonymized(ctx, &bytes.Buffer{}, AnonymizeOptions{Mode: "rot13"}); err == nil {
+		t.Error("Expected error for invalid mode")
+	}
+}
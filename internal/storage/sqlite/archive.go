@@ -0,0 +1,609 @@
+// Package sqlite - portable archives of a whole store
+package sqlite
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/deletions"
+	"github.com/steveyegge/beads/internal/export"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Files in an archive written by ExportArchive
+const (
+	archiveIssuesFile       = "issues.jsonl"
+	archiveDependenciesFile = "dependencies.jsonl"
+	archiveDeletionsFile    = "deletions.jsonl"
+	archiveEventsFile       = "events.jsonl"
+	archiveConfigFile       = "config.json"
+)
+
+// maxArchiveFileSize bounds each file ImportArchive reads, so a corrupt or
+// hostile archive can't exhaust memory
+const maxArchiveFileSize = 1 << 30
+
+// ImportMode selects how ImportArchive combines an archive with what the
+// store already holds
+type ImportMode string
+
+const (
+	// ImportMerge upserts the archived issues by ID and sets the archived
+	// config keys, keeping issues and keys only the store has
+	ImportMerge ImportMode = "merge"
+	// ImportReplace wipes the store's issues and config first, leaving it
+	// holding exactly what the archive does
+	ImportReplace ImportMode = "replace"
+)
+
+// IsValid checks if the import mode is valid
+func (m ImportMode) IsValid() bool {
+	switch m {
+	case ImportMerge, ImportReplace:
+		return true
+	}
+	return false
+}
+
+// ArchiveImportResult reports what ImportArchive restored
+type ArchiveImportResult struct {
+	Issues       int      `json:"issues"`
+	Dependencies int      `json:"dependencies"`
+	Deletions    int      `json:"deletions"`
+	Events       int      `json:"events"`                // Events not already recorded
+	ConfigKeys   []string `json:"config_keys,omitempty"` // Keys set, sorted
+}
+
+// ExportArchive writes the whole store to w as a gzipped tar archive that
+// ImportArchive restores, for moving a project to another machine or seeding
+// CI in one step. The archive holds
+//
+//   - issues.jsonl: every issue, tombstones included, with its labels,
+//     comments and links, in the export timestamp format
+//   - dependencies.jsonl: every dependency, one per line
+//   - deletions.jsonl: the deletions manifest next to the database, if any
+//   - events.jsonl: every event, oldest first, so History survives the move
+//   - config.json: every config key, as an export.ConfigSnapshot
+//
+// Issue versions, event ids and local state such as dirty flags are not
+// archived.
+func (s *SQLiteStorage) ExportArchive(ctx context.Context, w io.Writer) error {
+	issues, err := s.SearchIssues(ctx, "", types.IssueFilter{IncludeTombstones: true, IncludeSnoozed: true})
+	if err != nil {
+		return fmt.Errorf("failed to query issues: %w", err)
+	}
+	sort.Slice(issues, func(i, j int) bool {
+		return issues[i].ID < issues[j].ID
+	})
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+
+	allDeps, err := s.GetAllDependencyRecords(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get dependencies: %w", err)
+	}
+	allLabels, err := s.GetLabelsForIssues(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to get labels: %w", err)
+	}
+	allComments, err := s.GetCommentsForIssues(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to get comments: %w", err)
+	}
+	allLinks, err := s.GetLinksForIssues(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to get links: %w", err)
+	}
+	config, err := s.GetAllConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get config: %w", err)
+	}
+	deletionRecords, err := s.archivedDeletions()
+	if err != nil {
+		return err
+	}
+	events, err := s.EventsSince(ctx, 0, 0)
+	if err != nil {
+		return err
+	}
+
+	var issuesBuf bytes.Buffer
+	encoder := export.NewIssueEncoder(&issuesBuf, export.LoadTimestampFormat(ctx, s))
+	for _, issue := range issues {
+		issue.Labels = allLabels[issue.ID]
+		issue.Comments = allComments[issue.ID]
+		issue.Links = allLinks[issue.ID]
+		if err := encoder.Encode(issue); err != nil {
+			return fmt.Errorf("failed to encode issue %s: %w", issue.ID, err)
+		}
+	}
+
+	var depsBuf bytes.Buffer
+	depsEncoder := json.NewEncoder(&depsBuf)
+	for _, id := range ids {
+		for _, dep := range allDeps[id] {
+			if err := depsEncoder.Encode(dep); err != nil {
+				return fmt.Errorf("failed to encode dependency %s -> %s: %w", dep.IssueID, dep.DependsOnID, err)
+			}
+		}
+	}
+
+	var deletionsBuf bytes.Buffer
+	deletionsEncoder := json.NewEncoder(&deletionsBuf)
+	for _, record := range deletionRecords {
+		if err := deletionsEncoder.Encode(record); err != nil {
+			return fmt.Errorf("failed to encode deletion of %s: %w", record.ID, err)
+		}
+	}
+
+	var eventsBuf bytes.Buffer
+	eventsEncoder := json.NewEncoder(&eventsBuf)
+	for _, event := range events {
+		if err := eventsEncoder.Encode(event); err != nil {
+			return fmt.Errorf("failed to encode event %d: %w", event.ID, err)
+		}
+	}
+
+	now := time.Now()
+	configData, err := json.MarshalIndent(&export.ConfigSnapshot{ExportedAt: now, Config: config}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	files := []struct {
+		name string
+		data []byte
+	}{
+		{archiveIssuesFile, issuesBuf.Bytes()},
+		{archiveDependenciesFile, depsBuf.Bytes()},
+		{archiveDeletionsFile, deletionsBuf.Bytes()},
+		{archiveEventsFile, eventsBuf.Bytes()},
+		{archiveConfigFile, configData},
+	}
+	for _, f := range files {
+		header := &tar.Header{Name: f.name, Mode: 0644, Size: int64(len(f.data)), ModTime: now}
+		if err := tw.WriteHeader(header); err != nil {
+			return fmt.Errorf("failed to write archive header for %s: %w", f.name, err)
+		}
+		if _, err := tw.Write(f.data); err != nil {
+			return fmt.Errorf("failed to write %s to archive: %w", f.name, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to finish archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("failed to finish archive: %w", err)
+	}
+	return nil
+}
+
+// ImportArchive restores an archive written by ExportArchive, combining it
+// with the store's contents according to mode. Issues, dependencies, events
+// and config are written in one transaction, so a bad archive leaves the
+// store untouched. Restored issues are marked dirty for the next JSONL
+// export. Restoring records no events of its own: the archived events are
+// copied in with new ids, skipping any already recorded. Archived deletions
+// are added to the deletions manifest next to the database (replacing it
+// under ImportReplace) once the transaction commits; in-memory stores have no
+// manifest and skip them.
+func (s *SQLiteStorage) ImportArchive(ctx context.Context, r io.Reader, mode ImportMode) (*ArchiveImportResult, error) {
+	if !mode.IsValid() {
+		return nil, fmt.Errorf("invalid import mode %q (must be merge or replace)", mode)
+	}
+
+	files, err := readArchive(r)
+	if err != nil {
+		return nil, err
+	}
+	issuesData, ok := files[archiveIssuesFile]
+	if !ok {
+		return nil, fmt.Errorf("invalid archive: missing %s", archiveIssuesFile)
+	}
+	var issues []*types.Issue
+	err = decodeArchiveLines(archiveIssuesFile, issuesData, func(line []byte) error {
+		var issue types.Issue
+		if err := json.Unmarshal(line, &issue); err != nil {
+			return err
+		}
+		issues = append(issues, &issue)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	var deps []*types.Dependency
+	err = decodeArchiveLines(archiveDependenciesFile, files[archiveDependenciesFile], func(line []byte) error {
+		var dep types.Dependency
+		if err := json.Unmarshal(line, &dep); err != nil {
+			return err
+		}
+		deps = append(deps, &dep)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	var deletionRecords []deletions.DeletionRecord
+	err = decodeArchiveLines(archiveDeletionsFile, files[archiveDeletionsFile], func(line []byte) error {
+		var record deletions.DeletionRecord
+		if err := json.Unmarshal(line, &record); err != nil {
+			return err
+		}
+		deletionRecords = append(deletionRecords, record)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	var events []*types.Event
+	err = decodeArchiveLines(archiveEventsFile, files[archiveEventsFile], func(line []byte) error {
+		var event types.Event
+		if err := json.Unmarshal(line, &event); err != nil {
+			return err
+		}
+		events = append(events, &event)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	var snapshot *export.ConfigSnapshot
+	if data, ok := files[archiveConfigFile]; ok {
+		snapshot = &export.ConfigSnapshot{}
+		if err := json.Unmarshal(data, snapshot); err != nil {
+			return nil, fmt.Errorf("invalid archive: failed to parse %s: %w", archiveConfigFile, err)
+		}
+	}
+
+	result := &ArchiveImportResult{Issues: len(issues), Dependencies: len(deps)}
+	err = s.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		t := tx.(*sqliteTxStorage)
+		// Issues may depend on issues later in the archive
+		if _, err := t.conn.ExecContext(ctx, `PRAGMA defer_foreign_keys = ON`); err != nil {
+			return wrapDBError("defer foreign keys", err)
+		}
+
+		if mode == ImportReplace {
+			if _, err := t.conn.ExecContext(ctx, `DELETE FROM issues`); err != nil {
+				return wrapDBError("clear issues", err)
+			}
+			if err := clearFTS(ctx, t.conn); err != nil {
+				return err
+			}
+			if _, err := t.conn.ExecContext(ctx, `DELETE FROM config`); err != nil {
+				return wrapDBError("clear config", err)
+			}
+		}
+
+		// Config first, so the issue prefix and description threshold apply
+		if snapshot != nil {
+			set, err := export.ApplyConfigSnapshot(ctx, t, snapshot, export.ConfigImportMerge)
+			if err != nil {
+				return err
+			}
+			result.ConfigKeys = set
+		}
+
+		for _, issue := range issues {
+			if err := restoreIssue(ctx, t, issue); err != nil {
+				return fmt.Errorf("failed to restore %s: %w", issue.ID, err)
+			}
+		}
+		for _, dep := range deps {
+			_, err := t.conn.ExecContext(ctx, `
+				INSERT INTO dependencies (issue_id, depends_on_id, type, created_at, created_by, note)
+				VALUES (?, ?, ?, ?, ?, ?)
+			`, dep.IssueID, dep.DependsOnID, dep.Type, dep.CreatedAt, dep.CreatedBy, dep.Note)
+			if err != nil {
+				return wrapDBError(fmt.Sprintf("restore dependency %s -> %s", dep.IssueID, dep.DependsOnID), err)
+			}
+		}
+		copied, err := copyEvents(ctx, t.conn, events)
+		if err != nil {
+			return err
+		}
+		result.Events = copied
+		return s.rebuildBlockedCache(ctx, t.conn)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(deletionRecords) > 0 || mode == ImportReplace {
+		n, err := s.restoreDeletions(deletionRecords, mode)
+		if err != nil {
+			return result, err
+		}
+		result.Deletions = n
+	}
+	return result, nil
+}
+
+// restoreIssue writes an archived issue, replacing any existing issue with
+// its ID along with that issue's labels, comments, links and outgoing
+// dependencies
+func restoreIssue(ctx context.Context, t *sqliteTxStorage, issue *types.Issue) error {
+	if issue.ID == "" {
+		return fmt.Errorf("issue has no ID")
+	}
+	if issue.ContentHash == "" {
+		issue.ContentHash = issue.ComputeContentHash()
+	}
+	sourceRepo := issue.SourceRepo
+	if sourceRepo == "" {
+		sourceRepo = "." // Default to primary repo
+	}
+	description, err := storedDescription(ctx, t.conn, issue.Description)
+	if err != nil {
+		return err
+	}
+
+	// Upsert rather than replace, which would cascade-delete the rows of
+	// other issues that reference this one
+	_, err = t.conn.ExecContext(ctx, `
+		INSERT INTO issues (
+			id, content_hash, title, description, design, acceptance_criteria, notes,
+			status, priority, issue_type, assignee, estimated_minutes,
+			created_at, updated_at, closed_at, external_ref, source_repo, close_reason,
+			deleted_at, deleted_by, delete_reason, original_type, draft, percent_complete, external_blocked_reason, snoozed_until, due_date
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			content_hash = excluded.content_hash, title = excluded.title,
+			description = excluded.description, design = excluded.design,
+			acceptance_criteria = excluded.acceptance_criteria, notes = excluded.notes,
+			status = excluded.status, priority = excluded.priority,
+			issue_type = excluded.issue_type, assignee = excluded.assignee,
+			estimated_minutes = excluded.estimated_minutes, created_at = excluded.created_at,
+			updated_at = excluded.updated_at, closed_at = excluded.closed_at,
+			external_ref = excluded.external_ref, source_repo = excluded.source_repo,
+			close_reason = excluded.close_reason, deleted_at = excluded.deleted_at,
+			deleted_by = excluded.deleted_by, delete_reason = excluded.delete_reason,
+			original_type = excluded.original_type, draft = excluded.draft,
+			percent_complete = excluded.percent_complete,
+			external_blocked_reason = excluded.external_blocked_reason,
+			snoozed_until = excluded.snoozed_until, due_date = excluded.due_date
+	`,
+		issue.ID, issue.ContentHash, issue.Title, description, issue.Design,
+		issue.AcceptanceCriteria, issue.Notes, issue.Status,
+		issue.Priority, issue.IssueType, issue.Assignee,
+		issue.EstimatedMinutes, issue.CreatedAt, issue.UpdatedAt,
+		issue.ClosedAt, issue.ExternalRef, sourceRepo, issue.CloseReason,
+		issue.DeletedAt, issue.DeletedBy, issue.DeleteReason, issue.OriginalType, issue.Draft, issue.PercentComplete, issue.ExternalBlockedReason, issue.SnoozedUntil, issue.DueDate,
+	)
+	if err != nil {
+		return wrapDBError("write issue", err)
+	}
+
+	for _, table := range []string{"labels", "issue_links", "dependencies"} {
+		// #nosec G201 - table names are fixed above
+		if _, err := t.conn.ExecContext(ctx, fmt.Sprintf(`DELETE FROM %s WHERE issue_id = ?`, table), issue.ID); err != nil {
+			return wrapDBError("clear "+table, err)
+		}
+	}
+	for _, label := range issue.Labels {
+		if _, err := t.conn.ExecContext(ctx, `INSERT OR IGNORE INTO labels (issue_id, label) VALUES (?, ?)`, issue.ID, label); err != nil {
+			return wrapDBError("write label", err)
+		}
+	}
+	if err := restoreComments(ctx, t.conn, issue.ID, issue.Comments); err != nil {
+		return err
+	}
+	for _, link := range issue.Links {
+		_, err := t.conn.ExecContext(ctx, `INSERT OR IGNORE INTO issue_links (issue_id, url, title, kind, created_at) VALUES (?, ?, ?, ?, ?)`,
+			issue.ID, link.URL, link.Title, link.Kind, link.CreatedAt)
+		if err != nil {
+			return wrapDBError("write link", err)
+		}
+	}
+	if err := syncFTS(ctx, t.conn, issue.ID); err != nil {
+		return err
+	}
+	return markDirty(ctx, t.conn, issue.ID)
+}
+
+// restoreComments replaces an issue's comments with comments, keeping the
+// row, and so the ID, of each comment the issue already has. A comment is
+// the same if its author, text and time match. New comments keep their ID
+// when it is free here, as when restoring a purged issue, and get a fresh
+// one otherwise.
+func restoreComments(ctx context.Context, conn queryExecer, issueID string, comments []*types.Comment) error {
+	key := func(author, text string, createdAt time.Time) string {
+		return author + "\x00" + text + "\x00" + createdAt.UTC().Format(time.RFC3339Nano)
+	}
+	rows, err := conn.QueryContext(ctx, `SELECT id, author, text, created_at FROM comments WHERE issue_id = ?`, issueID)
+	if err != nil {
+		return wrapDBError("query comments", err)
+	}
+	existing := make(map[string][]int64)
+	for rows.Next() {
+		var id int64
+		var author, text string
+		var createdAt time.Time
+		if err := rows.Scan(&id, &author, &text, &createdAt); err != nil {
+			_ = rows.Close()
+			return wrapDBError("scan comment", err)
+		}
+		k := key(author, text, createdAt)
+		existing[k] = append(existing[k], id)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return wrapDBError("query comments", err)
+	}
+
+	var added []*types.Comment
+	for _, comment := range comments {
+		k := key(comment.Author, comment.Text, comment.CreatedAt)
+		if ids := existing[k]; len(ids) > 0 {
+			existing[k] = ids[1:]
+			continue
+		}
+		added = append(added, comment)
+	}
+	for _, ids := range existing {
+		for _, id := range ids {
+			if _, err := conn.ExecContext(ctx, `DELETE FROM comments WHERE id = ?`, id); err != nil {
+				return wrapDBError("delete comment", err)
+			}
+		}
+	}
+	for _, comment := range added {
+		var taken bool
+		if comment.ID > 0 {
+			err := conn.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM comments WHERE id = ?)`, comment.ID).Scan(&taken)
+			if err != nil {
+				return wrapDBError("check comment id", err)
+			}
+		}
+		if comment.ID > 0 && !taken {
+			_, err = conn.ExecContext(ctx, `INSERT INTO comments (id, issue_id, author, text, created_at) VALUES (?, ?, ?, ?, ?)`,
+				comment.ID, issueID, comment.Author, comment.Text, comment.CreatedAt)
+		} else {
+			_, err = conn.ExecContext(ctx, `INSERT INTO comments (issue_id, author, text, created_at) VALUES (?, ?, ?, ?)`,
+				issueID, comment.Author, comment.Text, comment.CreatedAt)
+		}
+		if err != nil {
+			return wrapDBError("write comment", err)
+		}
+	}
+	return nil
+}
+
+// deletionsPath returns the path of the deletions manifest next to the
+// database, or "" for in-memory stores
+func (s *SQLiteStorage) deletionsPath() string {
+	if s.isInMemory {
+		return ""
+	}
+	return deletions.DefaultPath(filepath.Dir(s.dbPath))
+}
+
+// archivedDeletions returns the deletions manifest's records for
+// ExportArchive, oldest first
+func (s *SQLiteStorage) archivedDeletions() ([]deletions.DeletionRecord, error) {
+	path := s.deletionsPath()
+	if path == "" {
+		return nil, nil
+	}
+	loaded, err := deletions.LoadDeletions(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load deletions manifest: %w", err)
+	}
+	records := make([]deletions.DeletionRecord, 0, len(loaded.Records))
+	for _, record := range loaded.Records {
+		records = append(records, record)
+	}
+	sortDeletions(records)
+	return records, nil
+}
+
+// restoreDeletions writes archived deletion records to the deletions
+// manifest, merging them into it or replacing it according to mode, and
+// returns how many it holds afterwards. Under ImportMerge the record already
+// in the manifest wins for an ID in both.
+func (s *SQLiteStorage) restoreDeletions(records []deletions.DeletionRecord, mode ImportMode) (int, error) {
+	path := s.deletionsPath()
+	if path == "" {
+		return 0, nil
+	}
+	if mode == ImportMerge {
+		loaded, err := deletions.LoadDeletions(path)
+		if err != nil {
+			return 0, fmt.Errorf("failed to load deletions manifest: %w", err)
+		}
+		for _, record := range records {
+			if _, ok := loaded.Records[record.ID]; !ok {
+				loaded.Records[record.ID] = record
+			}
+		}
+		records = make([]deletions.DeletionRecord, 0, len(loaded.Records))
+		for _, record := range loaded.Records {
+			records = append(records, record)
+		}
+	}
+	sortDeletions(records)
+	if err := deletions.WriteDeletions(path, records); err != nil {
+		return 0, fmt.Errorf("failed to write deletions manifest: %w", err)
+	}
+	return len(records), nil
+}
+
+// sortDeletions orders deletion records oldest first, then by ID
+func sortDeletions(records []deletions.DeletionRecord) {
+	sort.Slice(records, func(i, j int) bool {
+		if !records[i].Timestamp.Equal(records[j].Timestamp) {
+			return records[i].Timestamp.Before(records[j].Timestamp)
+		}
+		return records[i].ID < records[j].ID
+	})
+}
+
+// readArchive reads the files of a gzipped tar archive by name
+func readArchive(r io.Reader) (map[string][]byte, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid archive: %w", err)
+	}
+	defer func() { _ = gz.Close() }()
+
+	files := make(map[string][]byte)
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid archive: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		if header.Size > maxArchiveFileSize {
+			return nil, fmt.Errorf("invalid archive: %s is too large (%d bytes)", header.Name, header.Size)
+		}
+		data, err := io.ReadAll(io.LimitReader(tr, maxArchiveFileSize))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s from archive: %w", header.Name, err)
+		}
+		files[strings.TrimPrefix(header.Name, "./")] = data
+	}
+	return files, nil
+}
+
+// decodeArchiveLines passes each non-blank line of an archived JSONL file to
+// decode
+func decodeArchiveLines(name string, data []byte, decode func(line []byte) error) error {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), maxArchiveFileSize)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		if err := decode(line); err != nil {
+			return fmt.Errorf("invalid archive: %s line %d: %w", name, lineNum, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("invalid archive: failed to read %s: %w", name, err)
+	}
+	return nil
+}
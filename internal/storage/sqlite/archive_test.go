@@ -0,0 +1,161 @@
+package sqlite
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/deletions"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestArchiveRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	src, cleanupSrc := setupTestDB(t)
+	defer cleanupSrc()
+	if err := src.SetConfig(ctx, "issue_prefix", "proj"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	if err := src.SetConfig(ctx, "custom.owner", "platform"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+
+	create := func(store *SQLiteStorage, title string) *types.Issue {
+		t.Helper()
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		return issue
+	}
+	blocker := create(src, "Blocker")
+	blocked := create(src, "Blocked")
+	if err := src.AddDependency(ctx, &types.Dependency{IssueID: blocked.ID, DependsOnID: blocker.ID, Type: types.DepBlocks}, "test"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+	if err := src.AddLabel(ctx, blocked.ID, "backend", "test"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+	if _, err := src.AddIssueComment(ctx, blocked.ID, "alice", "Waiting on the blocker"); err != nil {
+		t.Fatalf("AddIssueComment failed: %v", err)
+	}
+	if _, err := src.AddLink(ctx, blocked.ID, "https://example.com/design", "Design", "doc", "test"); err != nil {
+		t.Fatalf("AddLink failed: %v", err)
+	}
+	deleted := deletions.DeletionRecord{ID: "proj-gone", Timestamp: time.Now().UTC().Truncate(time.Second), Actor: "bob"}
+	if err := deletions.AppendDeletion(deletions.DefaultPath(filepath.Dir(src.Path())), deleted); err != nil {
+		t.Fatalf("AppendDeletion failed: %v", err)
+	}
+
+	var archive bytes.Buffer
+	if err := src.ExportArchive(ctx, &archive); err != nil {
+		t.Fatalf("ExportArchive failed: %v", err)
+	}
+
+	t.Run("replace", func(t *testing.T) {
+		dst, cleanup := setupTestDB(t)
+		defer cleanup()
+		local := create(dst, "Local only")
+
+		result, err := dst.ImportArchive(ctx, bytes.NewReader(archive.Bytes()), ImportReplace)
+		if err != nil {
+			t.Fatalf("ImportArchive failed: %v", err)
+		}
+		if result.Issues != 2 || result.Dependencies != 1 || result.Deletions != 1 {
+			t.Errorf("Expected 2 issues, 1 dependency and 1 deletion, got %+v", result)
+		}
+		if got, _ := dst.GetIssue(ctx, local.ID); got != nil {
+			t.Errorf("Expected replace to remove %s", local.ID)
+		}
+		for key, want := range map[string]string{"issue_prefix": "proj", "custom.owner": "platform"} {
+			if got, err := dst.GetConfig(ctx, key); err != nil || got != want {
+				t.Errorf("Expected config %s=%q, got %q (err %v)", key, want, got, err)
+			}
+		}
+
+		got, err := dst.GetIssue(ctx, blocked.ID)
+		if err != nil || got == nil {
+			t.Fatalf("Expected %s to be restored (err %v)", blocked.ID, err)
+		}
+		if got.Title != blocked.Title || !got.CreatedAt.Equal(blocked.CreatedAt) {
+			t.Errorf("Expected restored issue to match, got %+v", got)
+		}
+		if labels, _ := dst.GetLabels(ctx, blocked.ID); !reflect.DeepEqual(labels, []string{"backend"}) {
+			t.Errorf("Expected labels [backend], got %v", labels)
+		}
+		if comments, _ := dst.GetIssueComments(ctx, blocked.ID); len(comments) != 1 || comments[0].Text != "Waiting on the blocker" {
+			t.Errorf("Expected the comment to be restored, got %v", comments)
+		}
+		if links, _ := dst.GetLinks(ctx, blocked.ID); len(links) != 1 || links[0].URL != "https://example.com/design" {
+			t.Errorf("Expected the link to be restored, got %v", links)
+		}
+		ready, err := dst.GetReadyWork(ctx, types.WorkFilter{})
+		if err != nil {
+			t.Fatalf("GetReadyWork failed: %v", err)
+		}
+		for _, issue := range ready {
+			if issue.ID == blocked.ID {
+				t.Errorf("Expected %s to stay blocked by the restored dependency", blocked.ID)
+			}
+		}
+
+		loaded, err := deletions.LoadDeletions(deletions.DefaultPath(filepath.Dir(dst.Path())))
+		if err != nil {
+			t.Fatalf("LoadDeletions failed: %v", err)
+		}
+		if record, ok := loaded.Records[deleted.ID]; !ok || record.Actor != "bob" {
+			t.Errorf("Expected deletion of %s to be restored, got %v", deleted.ID, loaded.Records)
+		}
+	})
+
+	t.Run("merge", func(t *testing.T) {
+		dst, cleanup := setupTestDB(t)
+		defer cleanup()
+		local := create(dst, "Local only")
+		if err := dst.SetConfig(ctx, "custom.team", "infra"); err != nil {
+			t.Fatalf("SetConfig failed: %v", err)
+		}
+
+		if _, err := dst.ImportArchive(ctx, bytes.NewReader(archive.Bytes()), ImportMerge); err != nil {
+			t.Fatalf("ImportArchive failed: %v", err)
+		}
+		// Importing again upserts rather than duplicating
+		if _, err := dst.ImportArchive(ctx, bytes.NewReader(archive.Bytes()), ImportMerge); err != nil {
+			t.Fatalf("Second ImportArchive failed: %v", err)
+		}
+		for _, id := range []string{local.ID, blocker.ID, blocked.ID} {
+			if got, _ := dst.GetIssue(ctx, id); got == nil {
+				t.Errorf("Expected %s after merge", id)
+			}
+		}
+		if comments, _ := dst.GetIssueComments(ctx, blocked.ID); len(comments) != 1 {
+			t.Errorf("Expected one comment after re-importing, got %d", len(comments))
+		}
+		if got, _ := dst.GetConfig(ctx, "custom.team"); got != "infra" {
+			t.Errorf("Expected merge to keep local config, got %q", got)
+		}
+		if got, _ := dst.GetConfig(ctx, "issue_prefix"); got != "proj" {
+			t.Errorf("Expected merge to set archived config, got %q", got)
+		}
+	})
+
+	t.Run("invalid archive", func(t *testing.T) {
+		dst, cleanup := setupTestDB(t)
+		defer cleanup()
+		local := create(dst, "Local only")
+
+		truncated := archive.Bytes()[:archive.Len()/2]
+		if _, err := dst.ImportArchive(ctx, bytes.NewReader(truncated), ImportReplace); err == nil {
+			t.Fatal("Expected an error for a truncated archive")
+		}
+		if got, _ := dst.GetIssue(ctx, local.ID); got == nil {
+			t.Errorf("Expected a failed import to leave %s alone", local.ID)
+		}
+		if _, err := dst.ImportArchive(ctx, bytes.NewReader(archive.Bytes()), "overwrite"); err == nil {
+			t.Error("Expected an error for an invalid mode")
+		}
+	})
+}
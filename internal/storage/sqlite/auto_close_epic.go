@@ -0,0 +1,118 @@
+// Package sqlite - automatic closing of finished epics
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// AutoCloseEpicActor is the actor recorded on epics closed by
+// AutoCloseEpicConfigKey
+const AutoCloseEpicActor = "beads-autoclose-epic"
+
+// autoCloseEpics closes the open epics that are parents of id and have no
+// open children left, if AutoCloseEpicConfigKey is enabled and id is closed.
+// Closing an epic can finish its own parent epic, so this walks up through
+// nested epics, visiting each issue at most once so a malformed parent-child
+// cycle can't loop. Epics that are already closed are left alone. It must run
+// in the same transaction as the close of id, after the blocked cache update.
+func (s *SQLiteStorage) autoCloseEpics(ctx context.Context, q queryExecer, id string) error {
+	enabled, err := flagEnabled(ctx, q, AutoCloseEpicConfigKey)
+	if err != nil || !enabled {
+		return err
+	}
+
+	var status types.Status
+	if err := q.QueryRowContext(ctx, `SELECT status FROM issues WHERE id = ?`, id).Scan(&status); err != nil {
+		return wrapDBError("check closed status", err)
+	}
+	if status != types.StatusClosed {
+		return nil
+	}
+
+	visited := map[string]bool{id: true}
+	queue := []string{id}
+	for len(queue) > 0 {
+		childID := queue[0]
+		queue = queue[1:]
+
+		epics, err := finishedParentEpics(ctx, q, childID)
+		if err != nil {
+			return err
+		}
+		for _, epicID := range epics {
+			if visited[epicID] {
+				continue
+			}
+			visited[epicID] = true
+			if err := s.autoCloseEpic(ctx, q, epicID, childID); err != nil {
+				return err
+			}
+			queue = append(queue, epicID)
+		}
+	}
+	return nil
+}
+
+// finishedParentEpics returns the open epics that are parents of childID and
+// have no open children
+func finishedParentEpics(ctx context.Context, q queryer, childID string) ([]string, error) {
+	rows, err := q.QueryContext(ctx, `
+		SELECT p.id
+		FROM dependencies d
+		JOIN issues p ON p.id = d.depends_on_id
+		WHERE d.issue_id = ? AND d.type = ? AND p.issue_type = ?
+		  AND p.status NOT IN (?, ?)
+		  AND NOT EXISTS (
+			SELECT 1 FROM dependencies cd
+			JOIN issues c ON c.id = cd.issue_id
+			WHERE cd.depends_on_id = p.id AND cd.type = ?
+			  AND c.status NOT IN (?, ?))
+		ORDER BY p.id
+	`, childID, types.DepParentChild, types.TypeEpic, types.StatusClosed, types.StatusTombstone,
+		types.DepParentChild, types.StatusClosed, types.StatusTombstone)
+	if err != nil {
+		return nil, wrapDBError("query finished epics", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var epics []string
+	for rows.Next() {
+		var epicID string
+		if err := rows.Scan(&epicID); err != nil {
+			return nil, wrapDBError("scan finished epic", err)
+		}
+		epics = append(epics, epicID)
+	}
+	return epics, wrapDBError("iterate finished epics", rows.Err())
+}
+
+// autoCloseEpic closes epicID because closing childID finished it
+func (s *SQLiteStorage) autoCloseEpic(ctx context.Context, q queryExecer, epicID, childID string) error {
+	now := s.now()
+	reason := fmt.Sprintf("Auto-closed: last open child %s closed", childID)
+	if _, err := q.ExecContext(ctx, `
+		UPDATE issues SET status = ?, closed_at = ?, updated_at = ?, close_reason = ?
+		WHERE id = ?
+	`, types.StatusClosed, now, now, reason, epicID); err != nil {
+		return wrapDBErrorf(err, "auto-close %s", epicID)
+	}
+
+	if _, err := q.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, comment)
+		VALUES (?, ?, ?, ?)
+	`, epicID, types.EventClosed, AutoCloseEpicActor, reason); err != nil {
+		return fmt.Errorf("failed to record event: %w", err)
+	}
+
+	if err := markDirty(ctx, q, epicID); err != nil {
+		return fmt.Errorf("failed to mark issue dirty: %w", err)
+	}
+
+	if err := s.invalidateBlockedCache(ctx, q, epicID); err != nil {
+		return fmt.Errorf("failed to invalidate blocked cache: %w", err)
+	}
+	return s.autoUnblockDependents(ctx, q, epicID)
+}
@@ -0,0 +1,118 @@
+package sqlite
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestAutoCloseEpic(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	newIssue := func(title string, issueType types.IssueType) *types.Issue {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: issueType}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		return issue
+	}
+	childOf := func(child, parent *types.Issue) {
+		dep := &types.Dependency{IssueID: child.ID, DependsOnID: parent.ID, Type: types.DepParentChild}
+		if err := store.AddDependency(ctx, dep, "test"); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+	}
+	get := func(issue *types.Issue) *types.Issue {
+		got, err := store.GetIssue(ctx, issue.ID)
+		if err != nil {
+			t.Fatalf("GetIssue failed: %v", err)
+		}
+		return got
+	}
+
+	outer := newIssue("Outer epic", types.TypeEpic)
+	inner := newIssue("Inner epic", types.TypeEpic)
+	feature := newIssue("Feature parent", types.TypeFeature)
+	taskA := newIssue("Task A", types.TypeTask)
+	taskB := newIssue("Task B", types.TypeTask)
+	taskC := newIssue("Task C", types.TypeTask)
+	childOf(inner, outer)
+	childOf(taskA, inner)
+	childOf(taskB, inner)
+	childOf(taskC, feature)
+
+	// Disabled by default
+	if err := store.CloseIssue(ctx, taskC.ID, "done", "test"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	if err := store.SetFlag(ctx, AutoCloseEpicConfigKey, true); err != nil {
+		t.Fatalf("SetFlag failed: %v", err)
+	}
+
+	// Closing one of two children leaves the epic open
+	if err := store.CloseIssue(ctx, taskA.ID, "done", "test"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	if got := get(inner); got.Status != types.StatusOpen {
+		t.Errorf("Expected inner epic to stay open, got %s", got.Status)
+	}
+
+	// Closing the last child through UpdateIssue closes both nested epics
+	if err := store.UpdateIssue(ctx, taskB.ID, map[string]interface{}{"status": types.StatusClosed}, "test"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	got := get(inner)
+	if got.Status != types.StatusClosed || !strings.Contains(got.CloseReason, taskB.ID) {
+		t.Errorf("Expected inner epic closed citing %s, got %s %q", taskB.ID, got.Status, got.CloseReason)
+	}
+	got = get(outer)
+	if got.Status != types.StatusClosed || !strings.Contains(got.CloseReason, inner.ID) {
+		t.Errorf("Expected outer epic closed citing %s, got %s %q", inner.ID, got.Status, got.CloseReason)
+	}
+	events, err := store.GetEvents(ctx, outer.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	closes := 0
+	for _, e := range events {
+		if e.EventType == types.EventClosed && e.Actor == AutoCloseEpicActor {
+			closes++
+		}
+	}
+	if closes != 1 {
+		t.Errorf("Expected one auto-close event on outer epic, got %d", closes)
+	}
+
+	// Non-epic parents are never auto-closed
+	if got := get(feature); got.Status != types.StatusOpen {
+		t.Errorf("Expected feature parent to stay open, got %s", got.Status)
+	}
+
+	// Reclosing a child of an already closed epic is a no-op, also in transactions
+	err = store.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		if err := tx.UpdateIssue(ctx, taskB.ID, map[string]interface{}{"status": types.StatusOpen}, "test"); err != nil {
+			return err
+		}
+		return tx.CloseIssue(ctx, taskB.ID, "done again", "test")
+	})
+	if err != nil {
+		t.Fatalf("RunInTransaction failed: %v", err)
+	}
+	events, err = store.GetEvents(ctx, inner.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	closes = 0
+	for _, e := range events {
+		if e.EventType == types.EventClosed {
+			closes++
+		}
+	}
+	if closes != 1 {
+		t.Errorf("Expected inner epic to be closed once, got %d close events", closes)
+	}
+}
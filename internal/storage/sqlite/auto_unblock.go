@@ -0,0 +1,88 @@
+// Package sqlite - automatic unblocking of dependents
+package sqlite
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// AutoUnblockActor is the actor recorded on status changes made by
+// AutoUnblockConfigKey
+const AutoUnblockActor = "beads-autounblock"
+
+// queryExecer can both query and execute; *sql.Tx and *sql.Conn implement it
+type queryExecer interface {
+	queryer
+	execer
+}
+
+// autoUnblockDependents moves the blocks-dependents of id that are in the
+// blocked status and no longer blocked by anything back to open, if
+// AutoUnblockConfigKey is enabled. It must run after the blocked cache has
+// been updated for id's status change, in the same transaction. Dependents in
+// any other status are left alone.
+func (s *SQLiteStorage) autoUnblockDependents(ctx context.Context, q queryExecer, id string) error {
+	enabled, err := flagEnabled(ctx, q, AutoUnblockConfigKey)
+	if err != nil || !enabled {
+		return err
+	}
+
+	rows, err := q.QueryContext(ctx, `
+		SELECT i.id
+		FROM dependencies d
+		JOIN issues i ON i.id = d.issue_id
+		WHERE d.depends_on_id = ? AND d.type = ? AND i.status = ?
+		  AND NOT EXISTS (SELECT 1 FROM blocked_issues_cache c WHERE c.issue_id = i.id)
+		  AND i.external_blocked_reason IS NULL
+		ORDER BY i.id
+	`, id, types.DepBlocks, types.StatusBlocked)
+	if err != nil {
+		return wrapDBError("query unblocked dependents", err)
+	}
+	var unblocked []string
+	for rows.Next() {
+		var dependentID string
+		if err := rows.Scan(&dependentID); err != nil {
+			_ = rows.Close()
+			return wrapDBError("scan unblocked dependent", err)
+		}
+		unblocked = append(unblocked, dependentID)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return wrapDBError("iterate unblocked dependents", err)
+	}
+	if len(unblocked) == 0 {
+		return nil
+	}
+
+	now := s.now()
+	newData, _ := json.Marshal(map[string]interface{}{"status": types.StatusOpen})
+	for _, dependentID := range unblocked {
+		if _, err := q.ExecContext(ctx, `UPDATE issues SET status = ?, updated_at = ? WHERE id = ?`,
+			types.StatusOpen, now, dependentID); err != nil {
+			return wrapDBErrorf(err, "unblock %s", dependentID)
+		}
+
+		oldData, _ := json.Marshal(map[string]interface{}{"id": dependentID, "status": types.StatusBlocked})
+		_, err := q.ExecContext(ctx, `
+			INSERT INTO events (issue_id, event_type, actor, old_value, new_value, comment)
+			VALUES (?, ?, ?, ?, ?, ?)
+		`, dependentID, types.EventStatusChanged, AutoUnblockActor, string(oldData), string(newData),
+			fmt.Sprintf("Unblocked: last blocker %s is no longer open", id))
+		if err != nil {
+			return fmt.Errorf("failed to record event: %w", err)
+		}
+
+		if err := markDirty(ctx, q, dependentID); err != nil {
+			return fmt.Errorf("failed to mark issue dirty: %w", err)
+		}
+	}
+
+	// Blocked and open issues both block their dependents, so the blocked
+	// cache needs no further update
+	return nil
+}
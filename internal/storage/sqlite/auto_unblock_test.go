@@ -0,0 +1,129 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestAutoUnblock(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	newIssue := func(title string, status types.Status) *types.Issue {
+		issue := &types.Issue{Title: title, Status: status, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		return issue
+	}
+	blocks := func(issue, blocker *types.Issue) {
+		dep := &types.Dependency{IssueID: issue.ID, DependsOnID: blocker.ID, Type: types.DepBlocks}
+		if err := store.AddDependency(ctx, dep, "test"); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+	}
+	status := func(issue *types.Issue) types.Status {
+		got, err := store.GetIssue(ctx, issue.ID)
+		if err != nil {
+			t.Fatalf("GetIssue failed: %v", err)
+		}
+		return got.Status
+	}
+
+	blockerA := newIssue("Blocker A", types.StatusOpen)
+	blockerB := newIssue("Blocker B", types.StatusOpen)
+	waiting := newIssue("Waiting on A and B", types.StatusBlocked)
+	working := newIssue("In progress on A", types.StatusInProgress)
+	blocks(waiting, blockerA)
+	blocks(waiting, blockerB)
+	blocks(working, blockerA)
+
+	// Disabled by default
+	if err := store.CloseIssue(ctx, blockerA.ID, "done", "test"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	if err := store.CloseIssue(ctx, blockerB.ID, "done", "test"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	if got := status(waiting); got != types.StatusBlocked {
+		t.Errorf("Expected blocked without auto-unblock, got %s", got)
+	}
+
+	if err := store.SetFlag(ctx, AutoUnblockConfigKey, true); err != nil {
+		t.Fatalf("SetFlag failed: %v", err)
+	}
+	for _, blocker := range []*types.Issue{blockerA, blockerB} {
+		if err := store.UpdateIssue(ctx, blocker.ID, map[string]interface{}{"status": types.StatusOpen}, "test"); err != nil {
+			t.Fatalf("UpdateIssue failed: %v", err)
+		}
+	}
+
+	// Closing one of two blockers leaves the issue blocked
+	if err := store.CloseIssue(ctx, blockerA.ID, "done", "test"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	if got := status(waiting); got != types.StatusBlocked {
+		t.Errorf("Expected blocked while B is open, got %s", got)
+	}
+	if got := status(working); got != types.StatusInProgress {
+		t.Errorf("Expected in_progress issue to be left alone, got %s", got)
+	}
+
+	// Closing the last blocker reopens it
+	if err := store.UpdateIssue(ctx, blockerB.ID, map[string]interface{}{"status": types.StatusClosed}, "test"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if got := status(waiting); got != types.StatusOpen {
+		t.Errorf("Expected open after last blocker closed, got %s", got)
+	}
+	events, err := store.GetEvents(ctx, waiting.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	unblockEvents := 0
+	for _, event := range events {
+		if event.Actor == AutoUnblockActor && event.EventType == types.EventStatusChanged {
+			unblockEvents++
+		}
+	}
+	if unblockEvents != 1 {
+		t.Errorf("Expected 1 status change by %s, got %d", AutoUnblockActor, unblockEvents)
+	}
+}
+
+func TestAutoUnblockInTransaction(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	if err := store.SetFlag(ctx, AutoUnblockConfigKey, true); err != nil {
+		t.Fatalf("SetFlag failed: %v", err)
+	}
+	blocker := &types.Issue{Title: "Blocker", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	waiting := &types.Issue{Title: "Waiting", Status: types.StatusBlocked, Priority: 2, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{blocker, waiting} {
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	dep := &types.Dependency{IssueID: waiting.ID, DependsOnID: blocker.ID, Type: types.DepBlocks}
+	if err := store.AddDependency(ctx, dep, "test"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+
+	err := store.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		return tx.CloseIssue(ctx, blocker.ID, "done", "test")
+	})
+	if err != nil {
+		t.Fatalf("RunInTransaction failed: %v", err)
+	}
+	got, err := store.GetIssue(ctx, waiting.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.Status != types.StatusOpen {
+		t.Errorf("Expected open, got %s", got.Status)
+	}
+}
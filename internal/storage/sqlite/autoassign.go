@@ -0,0 +1,125 @@
+// Package sqlite - round-robin auto-assignment of new issues
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// AutoAssignActor is the actor recorded on assignments made by AutoAssign
+const AutoAssignActor = "beads-autoassign"
+
+// NoAutoAssignLabel exempts an issue from AutoAssign
+const NoAutoAssignLabel = "no-autoassign"
+
+// autoAssignLastKey is the metadata key holding the assignee AutoAssign last
+// handed an issue to, so the rotation carries on across runs
+const autoAssignLastKey = "autoassign.last"
+
+// AutoAssign assigns every open, unassigned issue round-robin across the
+// rotation in AutoAssignRotationConfigKey, highest priority and oldest
+// first, and returns how many it assigned. Each assignment is recorded as an
+// update by AutoAssignActor.
+//
+// Assignees at or over WIPLimitConfigKey are skipped; once everyone in the
+// rotation is over capacity, the remaining issues are left unassigned for a
+// later run. Drafts and issues labeled NoAutoAssignLabel are never touched.
+// Running it again assigns nothing new, and the rotation resumes after the
+// last assignee, even if the rotation has been edited in between.
+func (s *SQLiteStorage) AutoAssign(ctx context.Context) (int, error) {
+	assigned := 0
+	err := s.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		t := tx.(*sqliteTxStorage)
+		assigned = 0
+
+		value, err := t.GetConfig(ctx, AutoAssignRotationConfigKey)
+		if err != nil {
+			return err
+		}
+		rotation := parseRotation(value)
+		if len(rotation) == 0 {
+			return nil
+		}
+
+		ids, err := autoAssignCandidates(ctx, t.conn)
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+
+		limit, err := readWIPLimit(ctx, t.conn)
+		if err != nil {
+			return err
+		}
+		wip, err := queryWIPCounts(ctx, t.conn)
+		if err != nil {
+			return err
+		}
+
+		last, err := t.GetMetadata(ctx, autoAssignLastKey)
+		if err != nil {
+			return err
+		}
+		next := slices.Index(rotation, last) + 1 // 0 if last is gone from the rotation
+
+		for _, id := range ids {
+			assignee := ""
+			for i := 0; i < len(rotation); i++ {
+				candidate := rotation[(next+i)%len(rotation)]
+				if limit == 0 || wip[candidate] < limit {
+					assignee = candidate
+					next = (next + i + 1) % len(rotation)
+					break
+				}
+			}
+			if assignee == "" {
+				break // Everyone is over their WIP limit
+			}
+			if err := t.UpdateIssue(ctx, id, map[string]interface{}{"assignee": assignee}, AutoAssignActor); err != nil {
+				return fmt.Errorf("failed to assign %s to %s: %w", id, assignee, err)
+			}
+			last = assignee
+			assigned++
+		}
+		if assigned == 0 {
+			return nil
+		}
+		return t.SetMetadata(ctx, autoAssignLastKey, last)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return assigned, nil
+}
+
+// parseRotation splits a comma-separated rotation, dropping blanks and
+// repeated names
+func parseRotation(value string) []string {
+	var rotation []string
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name != "" && !slices.Contains(rotation, name) {
+			rotation = append(rotation, name)
+		}
+	}
+	return rotation
+}
+
+// autoAssignCandidates returns the IDs of the issues AutoAssign may assign,
+// in assignment order
+func autoAssignCandidates(ctx context.Context, q queryer) ([]string, error) {
+	rows, err := q.QueryContext(ctx, `
+		SELECT id FROM issues i
+		WHERE status = ? AND (assignee IS NULL OR assignee = '') AND draft = 0
+		  AND NOT EXISTS (SELECT 1 FROM labels l WHERE l.issue_id = i.id AND l.label = ?)
+		ORDER BY priority ASC, created_at ASC, id ASC
+	`, types.StatusOpen, NoAutoAssignLabel)
+	if err != nil {
+		return nil, wrapDBError("query unassigned issues", err)
+	}
+	return scanStrings(rows)
+}
@@ -0,0 +1,92 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestAutoAssign(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	newIssue := func(title string, priority int, status types.Status, assignee string, labels ...string) *types.Issue {
+		issue := &types.Issue{Title: title, Status: status, Priority: priority, IssueType: types.TypeTask, Assignee: assignee}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		for _, label := range labels {
+			if err := store.AddLabel(ctx, issue.ID, label, "test"); err != nil {
+				t.Fatalf("AddLabel failed: %v", err)
+			}
+		}
+		return issue
+	}
+	assigneeOf := func(issue *types.Issue) string {
+		got, err := store.GetIssue(ctx, issue.ID)
+		if err != nil {
+			t.Fatalf("GetIssue failed: %v", err)
+		}
+		return got.Assignee
+	}
+
+	first := newIssue("First", 0, types.StatusOpen, "")
+	second := newIssue("Second", 1, types.StatusOpen, "")
+	third := newIssue("Third", 2, types.StatusOpen, "")
+	skipped := newIssue("Manual triage", 0, types.StatusOpen, "", NoAutoAssignLabel)
+	taken := newIssue("Taken", 0, types.StatusOpen, "dave")
+
+	// No rotation configured: nothing happens
+	if n, err := store.AutoAssign(ctx); err != nil || n != 0 {
+		t.Fatalf("Expected no assignments without a rotation, got %d (%v)", n, err)
+	}
+
+	// bob is at his WIP limit, so he is skipped
+	if err := store.SetConfig(ctx, WIPLimitConfigKey, "1"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	newIssue("Bob's work", 2, types.StatusInProgress, "bob")
+	if err := store.SetConfig(ctx, AutoAssignRotationConfigKey, "alice, bob, carol"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+
+	n, err := store.AutoAssign(ctx)
+	if err != nil {
+		t.Fatalf("AutoAssign failed: %v", err)
+	}
+	if n != 3 {
+		t.Errorf("Expected 3 assignments, got %d", n)
+	}
+	for issue, want := range map[*types.Issue]string{first: "alice", second: "carol", third: "alice", skipped: "", taken: "dave"} {
+		if got := assigneeOf(issue); got != want {
+			t.Errorf("Expected %s assigned to %q, got %q", issue.Title, want, got)
+		}
+	}
+
+	events, err := store.GetEvents(ctx, first.ID, 10)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	recorded := false
+	for _, event := range events {
+		recorded = recorded || (event.Actor == AutoAssignActor && event.EventType == types.EventUpdated)
+	}
+	if !recorded {
+		t.Errorf("Expected an update event by %s", AutoAssignActor)
+	}
+
+	// Idempotent: a second run assigns nothing
+	if n, err := store.AutoAssign(ctx); err != nil || n != 0 {
+		t.Errorf("Expected no new assignments, got %d (%v)", n, err)
+	}
+
+	// The rotation resumes after the last assignee
+	fourth := newIssue("Fourth", 2, types.StatusOpen, "")
+	if n, err := store.AutoAssign(ctx); err != nil || n != 1 {
+		t.Fatalf("Expected 1 assignment, got %d (%v)", n, err)
+	}
+	if got := assigneeOf(fourth); got != "carol" {
+		t.Errorf("Expected rotation to continue with carol, got %q", got)
+	}
+}
@@ -2,6 +2,7 @@ package sqlite
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"testing"
 	"time"
@@ -143,6 +144,30 @@ func TestBatchCreateIssues(t *testing.T) {
 		}
 	})
 
+	t.Run("rolls back a large batch on a constraint violation", func(t *testing.T) {
+		existing := &types.Issue{ID: "bd-taken", Title: "Already here", Priority: 1, IssueType: "task", Status: "open"}
+		if err := s.CreateIssue(ctx, existing, "test-actor"); err != nil {
+			t.Fatalf("failed to create issue: %v", err)
+		}
+
+		issues := make([]*types.Issue, 10000)
+		for i := range issues {
+			issues[i] = &types.Issue{ID: fmt.Sprintf("bd-bulk%d", i), Title: fmt.Sprintf("Bulk issue %d", i), Priority: 2, IssueType: "task", Status: "open"}
+		}
+		issues[5000].ID = existing.ID // Passes validation, fails the primary key
+
+		if err := s.CreateIssues(ctx, issues, "test-actor"); err == nil {
+			t.Fatal("expected the duplicate ID to fail the batch")
+		}
+		var count int
+		if err := s.db().QueryRow(`SELECT COUNT(*) FROM issues WHERE id LIKE 'bd-bulk%'`).Scan(&count); err != nil {
+			t.Fatalf("failed to count issues: %v", err)
+		}
+		if count != 0 {
+			t.Errorf("expected no issues from the failed batch, got %d", count)
+		}
+	})
+
 	t.Run("handles empty batch", func(t *testing.T) {
 		var issues []*types.Issue
 		err := s.CreateIssues(ctx, issues, "test-actor")
@@ -222,7 +247,7 @@ func TestBatchCreateIssues(t *testing.T) {
 
 		// Verify issue is marked dirty
 		var count int
-		err = s.db.QueryRow(`SELECT COUNT(*) FROM dirty_issues WHERE issue_id = ?`, issues[0].ID).Scan(&count)
+		err = s.db().QueryRow(`SELECT COUNT(*) FROM dirty_issues WHERE issue_id = ?`, issues[0].ID).Scan(&count)
 		if err != nil {
 			t.Fatalf("failed to check dirty status: %v", err)
 		}
@@ -258,7 +283,7 @@ func TestGenerateBatchIDs(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("generates unique IDs for batch", func(t *testing.T) {
-		conn, err := s.db.Conn(ctx)
+		conn, err := s.db().Conn(ctx)
 		if err != nil {
 			t.Fatalf("failed to get connection: %v", err)
 		}
@@ -289,7 +314,7 @@ func TestGenerateBatchIDs(t *testing.T) {
 	})
 
 	t.Run("validates explicit IDs match prefix", func(t *testing.T) {
-		conn, err := s.db.Conn(ctx)
+		conn, err := s.db().Conn(ctx)
 		if err != nil {
 			t.Fatalf("failed to get connection: %v", err)
 		}
@@ -306,7 +331,7 @@ func TestGenerateBatchIDs(t *testing.T) {
 	})
 
 	t.Run("skips prefix validation when flag is set", func(t *testing.T) {
-		conn, err := s.db.Conn(ctx)
+		conn, err := s.db().Conn(ctx)
 		if err != nil {
 			t.Fatalf("failed to get connection: %v", err)
 		}
@@ -330,7 +355,7 @@ func TestBulkOperations(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("bulkInsertIssues", func(t *testing.T) {
-		conn, err := s.db.Conn(ctx)
+		conn, err := s.db().Conn(ctx)
 		if err != nil {
 			t.Fatalf("failed to get connection: %v", err)
 		}
@@ -388,7 +413,7 @@ func TestBulkOperations(t *testing.T) {
 	})
 
 	t.Run("bulkRecordEvents", func(t *testing.T) {
-		conn, err := s.db.Conn(ctx)
+		conn, err := s.db().Conn(ctx)
 		if err != nil {
 			t.Fatalf("failed to get connection: %v", err)
 		}
@@ -423,7 +448,7 @@ func TestBulkOperations(t *testing.T) {
 		// Verify events were recorded
 		for _, issue := range issues {
 			var count int
-			err := s.db.QueryRow(`SELECT COUNT(*) FROM events WHERE issue_id = ? AND event_type = ?`,
+			err := s.db().QueryRow(`SELECT COUNT(*) FROM events WHERE issue_id = ? AND event_type = ?`,
 				issue.ID, types.EventCreated).Scan(&count)
 			if err != nil {
 				t.Fatalf("failed to check events: %v", err)
@@ -435,7 +460,7 @@ func TestBulkOperations(t *testing.T) {
 	})
 
 	t.Run("bulkMarkDirty", func(t *testing.T) {
-		conn, err := s.db.Conn(ctx)
+		conn, err := s.db().Conn(ctx)
 		if err != nil {
 			t.Fatalf("failed to get connection: %v", err)
 		}
@@ -470,7 +495,7 @@ func TestBulkOperations(t *testing.T) {
 		// Verify issues are marked dirty
 		for _, issue := range issues {
 			var count int
-			err := s.db.QueryRow(`SELECT COUNT(*) FROM dirty_issues WHERE issue_id = ?`, issue.ID).Scan(&count)
+			err := s.db().QueryRow(`SELECT COUNT(*) FROM dirty_issues WHERE issue_id = ?`, issue.ID).Scan(&count)
 			if err != nil {
 				t.Fatalf("failed to check dirty status: %v", err)
 			}
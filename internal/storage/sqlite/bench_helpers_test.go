@@ -76,7 +76,7 @@ func getCachedOrGenerateDB(b *testing.B, cacheKey string, generateFn func(contex
 		b.Fatalf("Failed to create benchmark cache directory: %v", err)
 	}
 
-	dbPath := fmt.Sprintf("%s/%s.db", benchCacheDir, cacheKey)
+	dbPath := fmt.Sprintf("%s/%s.db()", benchCacheDir, cacheKey)
 
 	// Check if cached database exists
 	if stat, err := os.Stat(dbPath); err == nil {
@@ -13,7 +13,7 @@
 //   - It has a 'blocks' dependency on an open/in_progress/blocked issue (direct blocking)
 //   - Its parent is blocked and it's connected via 'parent-child' dependency (transitive blocking)
 //
-// The cache is maintained automatically by invalidating and rebuilding whenever:
+// The cache is maintained automatically whenever:
 //   - A 'blocks' or 'parent-child' dependency is added or removed
 //   - Any issue's status changes (affects whether it blocks others)
 //   - An issue is closed (closed issues don't block others)
@@ -23,14 +23,19 @@
 //
 // # Cache Invalidation Strategy
 //
-// On any triggering change, the entire cache is rebuilt from scratch (DELETE + INSERT).
-// This full-rebuild approach is chosen because:
-//   - Rebuild is fast (<50ms even on 10K databases) due to optimized CTE logic
-//   - Simpler implementation than incremental updates
-//   - Dependency changes are rare compared to reads
-//   - Guarantees consistency - no risk of partial/stale updates
+// Single-issue changes update the cache incrementally: only the issues whose
+// blocked state can depend on the change are recomputed. For a status change
+// that is the issue's 'blocks' dependents; for a dependency change it is the
+// dependent side of the edge. Either way the children of those issues are
+// included via 'parent-child' links, since they inherit blockage. Closing a
+// blocker on a large graph therefore touches a handful of rows instead of
+// rewriting the whole table.
 //
-// The rebuild happens within the same transaction as the triggering change, ensuring
+// Batch deletes and RebuildReadyCache rebuild the entire cache from scratch
+// (DELETE + INSERT), which is fast (<50ms even on 10K databases) and repairs
+// any drift, e.g. after dependencies were written directly by an import.
+//
+// Both happen within the same transaction as the triggering change, ensuring
 // atomicity and consistency. The cache can never be in an inconsistent state visible
 // to queries.
 //
@@ -50,9 +55,9 @@
 //   - Speedup: 25x
 //
 // Write overhead:
-//   - Cache rebuild: <50ms (full DELETE + INSERT)
+//   - Incremental update: proportional to the affected subgraph
+//   - Full rebuild: <50ms (full DELETE + INSERT)
 //   - Only triggered on dependency/status changes (rare operations)
-//   - Trade-off: slower writes for much faster reads
 //
 // # Edge Cases Handled
 //
@@ -78,8 +83,7 @@
 //
 // # Future Optimizations
 //
-// If rebuild becomes a bottleneck in very large databases (>100K issues):
-//   - Consider incremental updates for specific dependency types
+// If full rebuilds become a bottleneck in very large databases (>100K issues):
 //   - Add indexes to dependencies table for CTE performance
 //   - Implement dirty tracking to avoid rebuilds when cache is unchanged
 //
@@ -90,6 +94,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 )
 
 // execer is an interface for types that can execute SQL queries
@@ -103,7 +108,7 @@ type execer interface {
 func (s *SQLiteStorage) rebuildBlockedCache(ctx context.Context, exec execer) error {
 	// Use direct db connection if no execer provided
 	if exec == nil {
-		exec = s.db
+		exec = s.db()
 	}
 
 	// Clear the cache
@@ -121,7 +126,7 @@ func (s *SQLiteStorage) rebuildBlockedCache(ctx context.Context, exec execer) er
 		    FROM dependencies d
 		    JOIN issues blocker ON d.depends_on_id = blocker.id
 		    WHERE d.type = 'blocks'
-		      AND blocker.status IN ('open', 'in_progress', 'blocked')
+		      AND blocker.status IN ('open', 'in_progress', 'blocked', 'snoozed')
 		  ),
 
 		  -- Step 2: Propagate blockage to all descendants via parent-child
@@ -149,8 +154,109 @@ func (s *SQLiteStorage) rebuildBlockedCache(ctx context.Context, exec execer) er
 	return nil
 }
 
-// invalidateBlockedCache rebuilds the blocked issues cache
-// Called when dependencies change or issue status changes
-func (s *SQLiteStorage) invalidateBlockedCache(ctx context.Context, exec execer) error {
-	return s.rebuildBlockedCache(ctx, exec)
+// affectedBlockedCTE defines affected(issue_id, depth): the changed issues
+// (bound to %s), their 'blocks' dependents, and all parent-child descendants
+// of those, i.e. every issue whose blocked state can depend on the change.
+const affectedBlockedCTE = `
+	affected(issue_id, depth) AS (
+	  SELECT id, 0 FROM issues
+	  WHERE id IN (%[1]s)
+	     OR id IN (SELECT issue_id FROM dependencies WHERE type = 'blocks' AND depends_on_id IN (%[1]s))
+
+	  UNION
+
+	  SELECT d.issue_id, a.depth + 1
+	  FROM affected a
+	  JOIN dependencies d ON d.depends_on_id = a.issue_id
+	  WHERE d.type = 'parent-child'
+	    AND a.depth < 50
+	)`
+
+// updateBlockedCache recomputes the blocked_issues_cache entries of the issues
+// affected by a status or dependency change on ids, leaving the rest alone.
+// Issues outside the affected set keep valid entries because their blocked
+// state can't depend on ids, and the affected set is closed under
+// parent-child, so a recomputed issue's parent is either recomputed too or
+// still correct in the cache.
+func (s *SQLiteStorage) updateBlockedCache(ctx context.Context, exec execer, ids []string) error {
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(ids)), ",")
+	affected := fmt.Sprintf(affectedBlockedCTE, placeholders)
+	args := make([]interface{}, 0, 2*len(ids))
+	for i := 0; i < 2; i++ {
+		for _, id := range ids {
+			args = append(args, id)
+		}
+	}
+
+	// #nosec G201 - safe SQL with controlled formatting
+	if _, err := exec.ExecContext(ctx, fmt.Sprintf(`
+		WITH RECURSIVE %s
+		DELETE FROM blocked_issues_cache
+		WHERE issue_id IN (SELECT issue_id FROM affected)
+	`, affected), args...); err != nil {
+		return fmt.Errorf("failed to clear affected blocked_issues_cache entries: %w", err)
+	}
+
+	// Same rules as rebuildBlockedCache, restricted to the affected issues;
+	// a parent outside the set is taken from the (still valid) cache
+	// #nosec G201 - safe SQL with controlled formatting
+	query := fmt.Sprintf(`
+		INSERT OR IGNORE INTO blocked_issues_cache (issue_id)
+		WITH RECURSIVE %s,
+		  blocked(issue_id, depth) AS (
+		    SELECT DISTINCT a.issue_id, 0
+		    FROM affected a
+		    WHERE EXISTS (
+		      SELECT 1 FROM dependencies d
+		      JOIN issues blocker ON d.depends_on_id = blocker.id
+		      WHERE d.issue_id = a.issue_id
+		        AND d.type = 'blocks'
+		        AND blocker.status IN ('open', 'in_progress', 'blocked', 'snoozed')
+		    ) OR EXISTS (
+		      SELECT 1 FROM dependencies d
+		      JOIN blocked_issues_cache c ON c.issue_id = d.depends_on_id
+		      WHERE d.issue_id = a.issue_id
+		        AND d.type = 'parent-child'
+		    )
+
+		    UNION ALL
+
+		    SELECT d.issue_id, b.depth + 1
+		    FROM blocked b
+		    JOIN dependencies d ON d.depends_on_id = b.issue_id
+		    WHERE d.type = 'parent-child'
+		      AND d.issue_id IN (SELECT issue_id FROM affected)
+		      AND b.depth < 50
+		  )
+		SELECT DISTINCT issue_id FROM blocked
+	`, affected)
+	if _, err := exec.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to update blocked_issues_cache: %w", err)
+	}
+
+	return nil
+}
+
+// invalidateBlockedCache brings the blocked issues cache up to date after a
+// status or dependency change. Given the changed issues (the dependent side
+// for a dependency change) it updates only the affected entries; with no ids
+// it rebuilds the whole cache.
+func (s *SQLiteStorage) invalidateBlockedCache(ctx context.Context, exec execer, ids ...string) error {
+	if len(ids) == 0 {
+		return s.rebuildBlockedCache(ctx, exec)
+	}
+	if exec == nil {
+		exec = s.db()
+	}
+	return s.updateBlockedCache(ctx, exec, ids)
+}
+
+// RebuildReadyCache recomputes the blocked issues cache that GetReadyWork
+// filters on from scratch. Writes keep the cache current incrementally; use
+// this to repair it after dependencies or statuses were changed outside the
+// storage API.
+func (s *SQLiteStorage) RebuildReadyCache(ctx context.Context) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		return s.rebuildBlockedCache(ctx, tx)
+	})
 }
@@ -13,7 +13,7 @@ func getCachedBlockedIssues(t *testing.T, store *SQLiteStorage) map[string]bool
 	t.Helper()
 	ctx := context.Background()
 
-	rows, err := store.db.QueryContext(ctx, "SELECT issue_id FROM blocked_issues_cache")
+	rows, err := store.db().QueryContext(ctx, "SELECT issue_id FROM blocked_issues_cache")
 	if err != nil {
 		t.Fatalf("Failed to query blocked_issues_cache: %v", err)
 	}
@@ -374,3 +374,125 @@ func TestMultipleBlockersInCache(t *testing.T) {
 		t.Errorf("Expected %s to be removed from cache (both blockers closed)", blocked.ID)
 	}
 }
+
+// TestCloseBlockerFlipsOnlyDependents checks that closing a blocker updates
+// exactly its dependent's cache entry and matches a full rebuild
+func TestCloseBlockerFlipsOnlyDependents(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// blocker1 → task1, blocker2 → task2 ← (parent) epic2 ← child2
+	newIssue := func(title string, issueType types.IssueType) *types.Issue {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 1, IssueType: issueType}
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		return issue
+	}
+	blocker1 := newIssue("Blocker 1", types.TypeTask)
+	task1 := newIssue("Task 1", types.TypeTask)
+	blocker2 := newIssue("Blocker 2", types.TypeTask)
+	epic2 := newIssue("Epic 2", types.TypeEpic)
+	child2 := newIssue("Child 2", types.TypeTask)
+	for _, dep := range []*types.Dependency{
+		{IssueID: task1.ID, DependsOnID: blocker1.ID, Type: types.DepBlocks},
+		{IssueID: epic2.ID, DependsOnID: blocker2.ID, Type: types.DepBlocks},
+		{IssueID: child2.ID, DependsOnID: epic2.ID, Type: types.DepParentChild},
+	} {
+		if err := store.AddDependency(ctx, dep, "test-user"); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+	}
+
+	before := getCachedBlockedIssues(t, store)
+	if len(before) != 3 || !before[task1.ID] || !before[epic2.ID] || !before[child2.ID] {
+		t.Fatalf("Expected task1, epic2 and child2 blocked, got %v", before)
+	}
+
+	if err := store.CloseIssue(ctx, blocker1.ID, "Done", "test-user"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+
+	after := getCachedBlockedIssues(t, store)
+	for id := range before {
+		if before[id] != after[id] && id != task1.ID {
+			t.Errorf("Expected only %s to flip, but %s did too", task1.ID, id)
+		}
+	}
+	if after[task1.ID] {
+		t.Errorf("Expected %s to be ready after its blocker closed", task1.ID)
+	}
+	if len(after) != 2 {
+		t.Errorf("Expected 2 blocked issues after close, got %v", after)
+	}
+
+	// Closing the epic's blocker unblocks the subtree
+	if err := store.CloseIssue(ctx, blocker2.ID, "Done", "test-user"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	if after := getCachedBlockedIssues(t, store); len(after) != 0 {
+		t.Errorf("Expected no blocked issues, got %v", after)
+	}
+
+	// Re-parenting child2 under a blocked task blocks it again
+	if err := store.UpdateIssue(ctx, blocker1.ID, map[string]interface{}{"status": string(types.StatusOpen)}, "test-user"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if err := store.RemoveDependency(ctx, child2.ID, epic2.ID, "test-user"); err != nil {
+		t.Fatalf("RemoveDependency failed: %v", err)
+	}
+	dep := &types.Dependency{IssueID: child2.ID, DependsOnID: task1.ID, Type: types.DepParentChild}
+	if err := store.AddDependency(ctx, dep, "test-user"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+	incremental := getCachedBlockedIssues(t, store)
+	if len(incremental) != 2 || !incremental[task1.ID] || !incremental[child2.ID] {
+		t.Errorf("Expected task1 and child2 blocked, got %v", incremental)
+	}
+
+	if err := store.RebuildReadyCache(ctx); err != nil {
+		t.Fatalf("RebuildReadyCache failed: %v", err)
+	}
+	rebuilt := getCachedBlockedIssues(t, store)
+	if len(rebuilt) != len(incremental) {
+		t.Errorf("Expected incremental cache %v to match full rebuild %v", incremental, rebuilt)
+	}
+	for id := range rebuilt {
+		if !incremental[id] {
+			t.Errorf("Expected incremental cache %v to match full rebuild %v", incremental, rebuilt)
+		}
+	}
+}
+
+// TestRebuildReadyCacheRepairsDrift tests that RebuildReadyCache restores entries
+// lost to changes made outside the storage API
+func TestRebuildReadyCacheRepairsDrift(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	blocker := &types.Issue{Title: "Blocker", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	blocked := &types.Issue{Title: "Blocked", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	store.CreateIssue(ctx, blocker, "test-user")
+	store.CreateIssue(ctx, blocked, "test-user")
+
+	// Write the dependency directly, bypassing cache maintenance
+	if _, err := store.db().ExecContext(ctx, `
+		INSERT INTO dependencies (issue_id, depends_on_id, type, created_by) VALUES (?, ?, 'blocks', 'test-user')
+	`, blocked.ID, blocker.ID); err != nil {
+		t.Fatalf("failed to insert dependency: %v", err)
+	}
+	if cached := getCachedBlockedIssues(t, store); cached[blocked.ID] {
+		t.Fatal("Expected cache to miss a dependency written directly")
+	}
+
+	if err := store.RebuildReadyCache(ctx); err != nil {
+		t.Fatalf("RebuildReadyCache failed: %v", err)
+	}
+	if cached := getCachedBlockedIssues(t, store); !cached[blocked.ID] {
+		t.Errorf("Expected %s in cache after RebuildReadyCache", blocked.ID)
+	}
+}
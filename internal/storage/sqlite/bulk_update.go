@@ -0,0 +1,100 @@
+// Package sqlite - bulk updates of issues selected by filter
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// UpdateByFilter applies changes to every issue matching filter, e.g. "set
+// priority 1 on everything labeled urgent", and returns how many issues were
+// updated. It runs in a single transaction: each issue goes through the same
+// validation and event recording as UpdateIssue, and if any update fails none
+// are applied.
+//
+// To guard against accidental mass updates, filter must set at least one
+// criterion; paging (Limit, Offset) and the Include* flags alone don't count.
+func (s *SQLiteStorage) UpdateByFilter(ctx context.Context, filter types.IssueFilter, changes map[string]interface{}, actor string) (int, error) {
+	if isEmptyIssueFilter(filter) {
+		return 0, fmt.Errorf("bulk update requires at least one filter criterion")
+	}
+	if len(changes) == 0 {
+		return 0, fmt.Errorf("bulk update requires at least one change")
+	}
+
+	// Validate up front so bad changes fail even when nothing matches
+	customStatuses, err := s.GetCustomStatuses(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get custom statuses: %w", err)
+	}
+	for key, value := range changes {
+		if !allowedUpdateFields[key] {
+			return 0, fmt.Errorf("invalid field for update: %s", key)
+		}
+		if err := validateFieldUpdateWithCustomStatuses(key, value, customStatuses); err != nil {
+			return 0, fmt.Errorf("failed to validate field update: %w", err)
+		}
+	}
+
+	count := 0
+	err = s.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		issues, err := tx.SearchIssues(ctx, "", filter)
+		if err != nil {
+			return err
+		}
+		for _, issue := range issues {
+			if err := tx.UpdateIssue(ctx, issue.ID, changes, actor); err != nil {
+				return fmt.Errorf("failed to update %s: %w", issue.ID, err)
+			}
+		}
+		count = len(issues)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// isEmptyIssueFilter reports whether filter would match every issue. Only
+// the criteria listed here narrow the match set: Limit and Offset page
+// through it, the Include* flags widen it, and SortBy and Fields shape the
+// result, so none of those count.
+func isEmptyIssueFilter(filter types.IssueFilter) bool {
+	narrows := filter.Status != nil ||
+		filter.Priority != nil ||
+		filter.IssueType != nil ||
+		filter.Assignee != nil ||
+		filter.Watcher != nil ||
+		len(filter.Labels) > 0 ||
+		len(filter.LabelsAny) > 0 ||
+		len(filter.LabelGlobs) > 0 ||
+		filter.TitleSearch != "" ||
+		len(filter.IDs) > 0 ||
+		filter.TitleContains != "" ||
+		filter.DescriptionContains != "" ||
+		filter.NotesContains != "" ||
+		len(filter.SectionContains) > 0 ||
+		filter.CreatedAfter != nil ||
+		filter.CreatedBefore != nil ||
+		filter.UpdatedAfter != nil ||
+		filter.UpdatedBefore != nil ||
+		filter.ClosedAfter != nil ||
+		filter.ClosedBefore != nil ||
+		filter.EmptyDescription ||
+		filter.NoAssignee ||
+		filter.NoLabels ||
+		filter.IsRoot ||
+		filter.IsLeaf ||
+		filter.PriorityMin != nil ||
+		filter.PriorityMax != nil ||
+		filter.MinPercent != nil ||
+		filter.OlderThan != 0 ||
+		filter.StalerThan != 0 ||
+		filter.DueBefore != nil ||
+		filter.Overdue
+	return !narrows
+}
@@ -0,0 +1,162 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestUpdateByFilter(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	var urgent, other []*types.Issue
+	for i := 0; i < 3; i++ {
+		issue := &types.Issue{Title: "Urgent", Status: types.StatusOpen, Priority: 3, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		if err := store.AddLabel(ctx, issue.ID, "urgent", "test"); err != nil {
+			t.Fatalf("AddLabel failed: %v", err)
+		}
+		urgent = append(urgent, issue)
+	}
+	for i := 0; i < 2; i++ {
+		issue := &types.Issue{Title: "Other", Status: types.StatusOpen, Priority: 3, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		other = append(other, issue)
+	}
+
+	count, err := store.UpdateByFilter(ctx, types.IssueFilter{Labels: []string{"urgent"}}, map[string]interface{}{"priority": 1}, "bulk")
+	if err != nil {
+		t.Fatalf("UpdateByFilter failed: %v", err)
+	}
+	if count != len(urgent) {
+		t.Errorf("Expected %d issues updated, got %d", len(urgent), count)
+	}
+
+	for _, issue := range urgent {
+		got, err := store.GetIssue(ctx, issue.ID)
+		if err != nil {
+			t.Fatalf("GetIssue failed: %v", err)
+		}
+		if got.Priority != 1 {
+			t.Errorf("Expected %s priority 1, got %d", issue.ID, got.Priority)
+		}
+		events, err := store.GetEvents(ctx, issue.ID, 0)
+		if err != nil {
+			t.Fatalf("GetEvents failed: %v", err)
+		}
+		found := false
+		for _, event := range events {
+			if event.EventType == types.EventUpdated && event.Actor == "bulk" {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Expected an update event by bulk on %s", issue.ID)
+		}
+	}
+	for _, issue := range other {
+		got, err := store.GetIssue(ctx, issue.ID)
+		if err != nil {
+			t.Fatalf("GetIssue failed: %v", err)
+		}
+		if got.Priority != 3 {
+			t.Errorf("Expected unmatched %s to keep priority 3, got %d", issue.ID, got.Priority)
+		}
+	}
+}
+
+func TestUpdateByFilterRejects(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Task", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	open := types.StatusOpen
+
+	tests := []struct {
+		name    string
+		filter  types.IssueFilter
+		changes map[string]interface{}
+	}{
+		{"empty filter", types.IssueFilter{}, map[string]interface{}{"priority": 1}},
+		{"limit only", types.IssueFilter{Limit: 10, IncludeTombstones: true, Labels: []string{}}, map[string]interface{}{"priority": 1}},
+		{"offset only", types.IssueFilter{Offset: 1}, map[string]interface{}{"priority": 1}},
+		{"include drafts only", types.IssueFilter{IncludeDrafts: true}, map[string]interface{}{"priority": 1}},
+		{"include snoozed only", types.IssueFilter{IncludeSnoozed: true, SortBy: []types.SortKey{{Field: "priority"}}}, map[string]interface{}{"priority": 1}},
+		{"no changes", types.IssueFilter{Status: &open}, nil},
+		{"invalid field", types.IssueFilter{Status: &open}, map[string]interface{}{"id": "bd-other"}},
+		{"invalid value", types.IssueFilter{Status: &open}, map[string]interface{}{"priority": 9}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			count, err := store.UpdateByFilter(ctx, tt.filter, tt.changes, "bulk")
+			if err == nil {
+				t.Errorf("Expected error, got %d issues updated", count)
+			}
+		})
+	}
+
+	got, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.Priority != 2 {
+		t.Errorf("Expected rejected updates to leave priority 2, got %d", got.Priority)
+	}
+}
+
+func TestUpdateByFilterPaging(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	for i := 0; i < 4; i++ {
+		issue := &types.Issue{Title: "Task", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	sortBy := []types.SortKey{{Field: types.SortByPriority}}
+	all, err := store.SearchIssues(ctx, "", types.IssueFilter{SortBy: sortBy})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	updated := func() []string {
+		one := 1
+		issues, err := store.SearchIssues(ctx, "", types.IssueFilter{Priority: &one, SortBy: sortBy})
+		if err != nil {
+			t.Fatalf("SearchIssues failed: %v", err)
+		}
+		var ids []string
+		for _, issue := range issues {
+			ids = append(ids, issue.ID)
+		}
+		return ids
+	}
+
+	// Offset pages through the match set without narrowing it: on its own
+	// it would update every issue but the first
+	if _, err := store.UpdateByFilter(ctx, types.IssueFilter{Offset: 1, SortBy: sortBy}, map[string]interface{}{"priority": 1}, "bulk"); err == nil {
+		t.Error("Expected a filter with only Offset to be rejected")
+	}
+	if ids := updated(); len(ids) != 0 {
+		t.Errorf("Expected the rejected update to change nothing, got %v", ids)
+	}
+
+	// With a criterion, Limit and Offset select a page of the matches
+	open := types.StatusOpen
+	count, err := store.UpdateByFilter(ctx, types.IssueFilter{Status: &open, SortBy: sortBy, Limit: 2, Offset: 1}, map[string]interface{}{"priority": 1}, "bulk")
+	if err != nil {
+		t.Fatalf("UpdateByFilter failed: %v", err)
+	}
+	if ids := updated(); count != 2 || !sameIDs(ids, []string{all[1].ID, all[2].ID}) {
+		t.Errorf("Expected %s and %s updated, got %d: %v", all[1].ID, all[2].ID, count, ids)
+	}
+}
@@ -0,0 +1,127 @@
+// Package sqlite - retrying operations that fail with SQLITE_BUSY
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
+
+// RetryPolicy controls how an operation failing with SQLITE_BUSY is retried.
+// Delays start at InitialDelay and double after each retry up to MaxDelay;
+// each sleep is a random duration between half the delay and the full delay,
+// so contending processes don't retry in lockstep.
+type RetryPolicy struct {
+	MaxRetries   int           // Retries after the first attempt; 0 fails on the first busy error
+	InitialDelay time.Duration // Delay before the first retry
+	MaxDelay     time.Duration // Upper bound on any single delay (0 means no bound)
+}
+
+// DefaultReadRetryPolicy retries reads briefly: a read only loses to a
+// writer holding the lock for a checkpoint or schema change, which is short.
+var DefaultReadRetryPolicy = RetryPolicy{MaxRetries: 5, InitialDelay: 5 * time.Millisecond, MaxDelay: 200 * time.Millisecond}
+
+// DefaultWriteRetryPolicy matches the backoff BEGIN IMMEDIATE has always used (bd-ola6)
+var DefaultWriteRetryPolicy = RetryPolicy{MaxRetries: 5, InitialDelay: 10 * time.Millisecond, MaxDelay: time.Second}
+
+// Stats reports store-level counters since the store was opened
+type Stats struct {
+	ReadRetries     int64 `json:"read_retries"`      // Reads retried after SQLITE_BUSY
+	ReadBusyErrors  int64 `json:"read_busy_errors"`  // Reads that stayed busy after all retries
+	WriteRetries    int64 `json:"write_retries"`     // Write transactions retried after SQLITE_BUSY
+	WriteBusyErrors int64 `json:"write_busy_errors"` // Write transactions that stayed busy after all retries
+}
+
+// SetReadRetryPolicy sets how reads (GetIssue, SearchIssues, GetReadyWork,
+// GetBlockedIssues) are retried when the database is busy. This is separate
+// from busy_timeout, which SQLite applies inside a single statement.
+func (s *SQLiteStorage) SetReadRetryPolicy(p RetryPolicy) {
+	s.readRetry.Store(&p)
+}
+
+// ReadRetryPolicy returns the policy set by SetReadRetryPolicy, or DefaultReadRetryPolicy
+func (s *SQLiteStorage) ReadRetryPolicy() RetryPolicy {
+	if p := s.readRetry.Load(); p != nil {
+		return *p
+	}
+	return DefaultReadRetryPolicy
+}
+
+// SetWriteRetryPolicy sets how starting a write transaction (BEGIN
+// IMMEDIATE) is retried when another connection holds the write lock.
+func (s *SQLiteStorage) SetWriteRetryPolicy(p RetryPolicy) {
+	s.writeRetry.Store(&p)
+}
+
+// WriteRetryPolicy returns the policy set by SetWriteRetryPolicy, or DefaultWriteRetryPolicy
+func (s *SQLiteStorage) WriteRetryPolicy() RetryPolicy {
+	if p := s.writeRetry.Load(); p != nil {
+		return *p
+	}
+	return DefaultWriteRetryPolicy
+}
+
+// Stats returns the store's busy-retry counters
+func (s *SQLiteStorage) Stats() Stats {
+	return Stats{
+		ReadRetries:     s.readRetries.Load(),
+		ReadBusyErrors:  s.readBusyErrors.Load(),
+		WriteRetries:    s.writeRetries.Load(),
+		WriteBusyErrors: s.writeBusyErrors.Load(),
+	}
+}
+
+// withReadRetry runs the read op, retrying it under the read policy while it
+// fails with a busy error
+func (s *SQLiteStorage) withReadRetry(ctx context.Context, op func() error) error {
+	retries, err := retryBusy(ctx, s.ReadRetryPolicy(), op)
+	s.readRetries.Add(int64(retries))
+	if err != nil && IsBusyError(err) {
+		s.readBusyErrors.Add(1)
+		return fmt.Errorf("read failed after %d retries: %w", retries, err)
+	}
+	return err
+}
+
+// beginImmediate starts an IMMEDIATE transaction on conn, retrying under the
+// write policy while another connection holds the write lock
+func (s *SQLiteStorage) beginImmediate(ctx context.Context, conn execer) error {
+	retries, err := retryBusy(ctx, s.WriteRetryPolicy(), func() error {
+		_, err := conn.ExecContext(ctx, "BEGIN IMMEDIATE")
+		return err
+	})
+	s.writeRetries.Add(int64(retries))
+	if err != nil && IsBusyError(err) {
+		s.writeBusyErrors.Add(1)
+		return fmt.Errorf("database busy after %d retries: %w", retries, err)
+	}
+	return err
+}
+
+// retryBusy calls op until it succeeds, fails with a non-busy error, the
+// policy's retries run out or ctx is done. It returns the number of retries
+// made and op's last error.
+func retryBusy(ctx context.Context, p RetryPolicy, op func() error) (int, error) {
+	delay := p.InitialDelay
+	for retries := 0; ; retries++ {
+		err := op()
+		if err == nil || !IsBusyError(err) || retries >= p.MaxRetries {
+			return retries, err
+		}
+
+		if p.MaxDelay > 0 && delay > p.MaxDelay {
+			delay = p.MaxDelay
+		}
+		sleep := delay
+		if half := delay / 2; half > 0 {
+			sleep = half + rand.N(delay-half+1) // #nosec G404 -- jitter, not security
+		}
+		select {
+		case <-time.After(sleep):
+			delay *= 2
+		case <-ctx.Done():
+			return retries, ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestReadRetryPolicy(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+	store.SetReadRetryPolicy(RetryPolicy{MaxRetries: 3, InitialDelay: time.Millisecond})
+
+	busy := errors.New("database is locked (5) (SQLITE_BUSY)")
+
+	// Succeeds once the lock clears
+	attempts := 0
+	err := store.withReadRetry(ctx, func() error {
+		attempts++
+		if attempts < 3 {
+			return busy
+		}
+		return nil
+	})
+	if err != nil || attempts != 3 {
+		t.Fatalf("Expected success on the third attempt, got attempts=%d err=%v", attempts, err)
+	}
+
+	// Gives up after MaxRetries with a wrapped error
+	attempts = 0
+	err = store.withReadRetry(ctx, func() error {
+		attempts++
+		return busy
+	})
+	if !errors.Is(err, busy) || attempts != 4 {
+		t.Fatalf("Expected wrapped busy error after 4 attempts, got attempts=%d err=%v", attempts, err)
+	}
+
+	// Other errors are not retried
+	attempts = 0
+	other := errors.New("no such table")
+	if err := store.withReadRetry(ctx, func() error { attempts++; return other }); err != other || attempts != 1 {
+		t.Fatalf("Expected immediate failure, got attempts=%d err=%v", attempts, err)
+	}
+
+	stats := store.Stats()
+	if stats.ReadRetries != 5 || stats.ReadBusyErrors != 1 {
+		t.Errorf("Expected 5 read retries and 1 busy error, got %+v", stats)
+	}
+	if stats.WriteRetries != 0 || stats.WriteBusyErrors != 0 {
+		t.Errorf("Expected no write retries, got %+v", stats)
+	}
+}
+
+func TestReadsUnderWriteLoad(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping stress test in short mode")
+	}
+	ctx := context.Background()
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	// Separate stores act like separate processes sharing the database; a
+	// tiny busy_timeout makes SQLite give up quickly so contention surfaces
+	// as SQLITE_BUSY rather than waiting inside the driver.
+	open := func() *SQLiteStorage {
+		store, err := NewWithTimeout(ctx, dbPath, time.Millisecond)
+		if err != nil {
+			t.Fatalf("Failed to create database: %v", err)
+		}
+		t.Cleanup(func() { _ = store.Close() })
+		store.SetReadRetryPolicy(RetryPolicy{MaxRetries: 50, InitialDelay: time.Millisecond, MaxDelay: 20 * time.Millisecond})
+		store.SetWriteRetryPolicy(RetryPolicy{MaxRetries: 200, InitialDelay: time.Millisecond, MaxDelay: 20 * time.Millisecond})
+		return store
+	}
+	reader := open()
+	if err := reader.SetConfig(ctx, "issue_prefix", "bd"); err != nil {
+		t.Fatalf("Failed to set prefix: %v", err)
+	}
+
+	seed := &types.Issue{Title: "Seed", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	if err := reader.CreateIssue(ctx, seed, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	const writers, writesEach, readers, readsEach = 4, 25, 4, 50
+	var wg sync.WaitGroup
+	readErrs := make(chan error, 3*readers*readsEach)
+
+	// Open every writer before any writes start: opening runs schema setup,
+	// which isn't retried and would trip the tiny busy_timeout under load
+	writerStores := make([]*SQLiteStorage, writers)
+	for w := range writerStores {
+		writerStores[w] = open()
+	}
+	for w, writer := range writerStores {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < writesEach; i++ {
+				issue := &types.Issue{Title: fmt.Sprintf("Load %d-%d", w, i), Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+				if err := writer.CreateIssue(ctx, issue, "test"); err != nil {
+					t.Errorf("CreateIssue failed: %v", err)
+					return
+				}
+			}
+		}(w)
+	}
+	for r := 0; r < readers; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < readsEach; i++ {
+				if _, err := reader.GetIssue(ctx, seed.ID); err != nil {
+					readErrs <- fmt.Errorf("GetIssue: %w", err)
+				}
+				if _, err := reader.SearchIssues(ctx, "", types.IssueFilter{}); err != nil {
+					readErrs <- fmt.Errorf("SearchIssues: %w", err)
+				}
+				if _, err := reader.GetReadyWork(ctx, types.WorkFilter{}); err != nil {
+					readErrs <- fmt.Errorf("GetReadyWork: %w", err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(readErrs)
+
+	for err := range readErrs {
+		t.Errorf("Expected reads to succeed under write load, got %v", err)
+	}
+	if stats := reader.Stats(); stats.ReadBusyErrors != 0 {
+		t.Errorf("Expected no reads to give up, got %+v", stats)
+	}
+
+	issues, err := reader.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(issues) != 1+writers*writesEach {
+		t.Errorf("Expected %d issues, got %d", 1+writers*writesEach, len(issues))
+	}
+}
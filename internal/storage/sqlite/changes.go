@@ -0,0 +1,301 @@
+// Package sqlite - change summaries between revisions of the event log
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ChangeSet summarizes what happened to issues after a given revision.
+//
+// A revision is the id of an entry in the events table, which increases
+// monotonically across the whole database. Persist Revision and pass it to the
+// next ChangesSince call to get only what happened in between.
+type ChangeSet struct {
+	SinceRevision int64          `json:"since_revision"`
+	Revision      int64          `json:"revision"` // Current revision (latest event id)
+	Created       []*types.Issue `json:"created"`
+	Updated       []IssueChange  `json:"updated"`        // Field edits, excluding status
+	StatusChanged []StatusChange `json:"status_changed"` // Includes closes and reopens
+	Deleted       []*types.Issue `json:"deleted"`        // Tombstones
+}
+
+// IssueChange lists the fields of one issue that differ from their value at
+// the start of the range
+type IssueChange struct {
+	IssueID string        `json:"issue_id"`
+	Title   string        `json:"title"`
+	Fields  []FieldChange `json:"fields"`
+}
+
+// FieldChange is a single field's value before and after the range, using the
+// field's JSONL name and encoding
+type FieldChange struct {
+	Field string      `json:"field"`
+	Old   interface{} `json:"old"`
+	New   interface{} `json:"new"`
+}
+
+// StatusChange records an issue's status before and after the range. From is
+// empty when the earlier status isn't known, as when an issue was closed
+// without a snapshot and no earlier event records its status.
+type StatusChange struct {
+	IssueID string       `json:"issue_id"`
+	Title   string       `json:"title"`
+	From    types.Status `json:"from"`
+	To      types.Status `json:"to"`
+}
+
+// issueHistory accumulates the events seen for one issue within the range
+type issueHistory struct {
+	created bool
+	deleted bool
+	// before holds each touched field's value at the start of the range, taken
+	// from the first event snapshot that covers it
+	before map[string]interface{}
+	order  []string // Fields in the order first touched
+	// closedAt is the id of a close event that recorded no snapshot, when it
+	// was the first to touch status; the status before it is looked up from
+	// the issue's earlier events
+	closedAt int64
+}
+
+// ChangesSince returns the issues created, updated, status-changed and deleted
+// after sinceRevision, along with the current revision.
+//
+// Field diffs compare each touched field's value at the start of the range
+// with its current value; fields that were edited and then changed back are
+// not reported. Issues created and deleted within the range are omitted.
+// Hard-deleted issues are not reported, since their events are removed along
+// with them.
+func (s *SQLiteStorage) ChangesSince(ctx context.Context, sinceRevision int64) (ChangeSet, error) {
+	ctx, cancel := s.withQueryTimeout(ctx)
+	defer cancel()
+
+	changes := ChangeSet{SinceRevision: sinceRevision}
+
+	var revision sql.NullInt64
+	if err := s.db().QueryRowContext(ctx, `SELECT MAX(id) FROM events`).Scan(&revision); err != nil {
+		return changes, withContextError(ctx, wrapDBError("get current revision", err))
+	}
+	changes.Revision = revision.Int64
+	if sinceRevision >= changes.Revision {
+		return changes, nil
+	}
+
+	histories, ids, err := s.collectHistories(ctx, sinceRevision, changes.Revision)
+	if err != nil {
+		return changes, err
+	}
+	for _, id := range ids {
+		if h := histories[id]; h.closedAt != 0 {
+			status, err := statusBefore(ctx, s.db(), id, h.closedAt)
+			if err != nil {
+				return changes, err
+			}
+			if status != "" {
+				h.before["status"] = string(status)
+			}
+		}
+	}
+
+	for _, id := range ids {
+		h := histories[id]
+		issue, err := s.GetIssue(ctx, id)
+		if err != nil {
+			return changes, err
+		}
+		if issue == nil {
+			continue // Hard-deleted since
+		}
+
+		deleted := h.deleted || issue.Status == types.StatusTombstone
+		switch {
+		case h.created && deleted:
+			continue
+		case h.created:
+			changes.Created = append(changes.Created, issue)
+			continue
+		case deleted:
+			changes.Deleted = append(changes.Deleted, issue)
+			continue
+		}
+
+		current, err := issueFields(issue)
+		if err != nil {
+			return changes, err
+		}
+
+		var fields []FieldChange
+		for _, field := range h.order {
+			old, now := h.before[field], current[field]
+			if reflect.DeepEqual(old, now) {
+				continue
+			}
+			if field == "status" {
+				from, _ := old.(string)
+				changes.StatusChanged = append(changes.StatusChanged, StatusChange{
+					IssueID: id,
+					Title:   issue.Title,
+					From:    types.Status(from),
+					To:      issue.Status,
+				})
+				continue
+			}
+			fields = append(fields, FieldChange{Field: field, Old: old, New: now})
+		}
+		if len(fields) > 0 {
+			changes.Updated = append(changes.Updated, IssueChange{IssueID: id, Title: issue.Title, Fields: fields})
+		}
+	}
+
+	return changes, nil
+}
+
+// collectHistories reads the events in (since, until] and returns the
+// per-issue history along with the affected issue IDs, sorted
+func (s *SQLiteStorage) collectHistories(ctx context.Context, since, until int64) (map[string]*issueHistory, []string, error) {
+	rows, err := s.db().QueryContext(ctx, `
+		SELECT id, issue_id, event_type, old_value, new_value
+		FROM events
+		WHERE id > ? AND id <= ?
+		ORDER BY id
+	`, since, until)
+	if err != nil {
+		return nil, nil, withContextError(ctx, wrapDBError("query events", err))
+	}
+	defer func() { _ = rows.Close() }()
+
+	histories := make(map[string]*issueHistory)
+	var ids []string
+	for rows.Next() {
+		var eventID int64
+		var issueID string
+		var eventType types.EventType
+		var oldValue, newValue sql.NullString
+		if err := rows.Scan(&eventID, &issueID, &eventType, &oldValue, &newValue); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan event: %w", err)
+		}
+
+		h, ok := histories[issueID]
+		if !ok {
+			h = &issueHistory{before: make(map[string]interface{})}
+			histories[issueID] = h
+			ids = append(ids, issueID)
+		}
+
+		switch eventType {
+		case types.EventCreated:
+			h.created = true
+		case "deleted":
+			h.deleted = true
+		case types.EventUpdated, types.EventStatusChanged, types.EventPriorityChanged, types.EventClosed, types.EventReopened:
+			h.recordUpdate(eventID, eventType, oldValue, newValue)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, withContextError(ctx, wrapDBError("iterate events", err))
+	}
+
+	sort.Strings(ids)
+	return histories, ids, nil
+}
+
+// recordUpdate notes the fields touched by an update event, keeping the
+// earliest known prior value of each
+func (h *issueHistory) recordUpdate(eventID int64, eventType types.EventType, oldValue, newValue sql.NullString) {
+	var snapshot, updates map[string]interface{}
+	if oldValue.Valid {
+		_ = json.Unmarshal([]byte(oldValue.String), &snapshot)
+	}
+	if newValue.Valid {
+		_ = json.Unmarshal([]byte(newValue.String), &updates)
+	}
+
+	// CloseIssue records only the reason, so the status it closed from is
+	// found later from the issue's earlier events (see statusBefore)
+	if eventType == types.EventClosed && snapshot == nil {
+		if _, seen := h.before["status"]; !seen {
+			h.closedAt = eventID
+		}
+		h.touch("status", nil)
+		return
+	}
+
+	fields := make([]string, 0, len(updates))
+	for field := range updates {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		h.touch(field, snapshot[field])
+	}
+}
+
+// statusBefore returns the status issueID had just before event eventID,
+// from the latest earlier event that records it, or "" if none does
+func statusBefore(ctx context.Context, q queryer, issueID string, eventID int64) (types.Status, error) {
+	rows, err := q.QueryContext(ctx, `
+		SELECT event_type, new_value
+		FROM events
+		WHERE issue_id = ? AND id < ?
+		ORDER BY id DESC
+	`, issueID, eventID)
+	if err != nil {
+		return "", withContextError(ctx, wrapDBError("query earlier events", err))
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var eventType types.EventType
+		var newValue sql.NullString
+		if err := rows.Scan(&eventType, &newValue); err != nil {
+			return "", fmt.Errorf("failed to scan event: %w", err)
+		}
+		if !newValue.Valid {
+			if eventType == types.EventClosed {
+				return types.StatusClosed, nil
+			}
+			continue
+		}
+		var values map[string]interface{}
+		if err := json.Unmarshal([]byte(newValue.String), &values); err != nil {
+			continue
+		}
+		if status, ok := values["status"].(string); ok && status != "" {
+			return types.Status(status), nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return "", withContextError(ctx, wrapDBError("iterate earlier events", err))
+	}
+	return "", nil
+}
+
+func (h *issueHistory) touch(field string, before interface{}) {
+	if _, seen := h.before[field]; seen {
+		return
+	}
+	h.before[field] = before
+	h.order = append(h.order, field)
+}
+
+// issueFields returns issue in its JSONL encoding, as a map keyed by field
+// name, so it can be compared with the snapshots stored in events
+func issueFields(issue *types.Issue) (map[string]interface{}, error) {
+	data, err := json.Marshal(issue)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal issue %s: %w", issue.ID, err)
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal issue %s: %w", issue.ID, err)
+	}
+	return fields, nil
+}
@@ -0,0 +1,134 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestChangesSince(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	existing := &types.Issue{Title: "Existing", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	closing := &types.Issue{Title: "Closing", Status: types.StatusInProgress, Priority: 1, IssueType: types.TypeTask}
+	reverted := &types.Issue{Title: "Reverted", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	doomed := &types.Issue{Title: "Doomed", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{existing, closing, reverted, doomed} {
+		if err := store.CreateIssue(ctx, issue, "setup"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	start, err := store.ChangesSince(ctx, 0)
+	if err != nil {
+		t.Fatalf("ChangesSince failed: %v", err)
+	}
+	if len(start.Created) != 4 {
+		t.Fatalf("Expected 4 created issues from revision 0, got %d", len(start.Created))
+	}
+
+	// Changes after the checkpoint
+	if err := store.UpdateIssue(ctx, existing.ID, map[string]interface{}{"title": "Renamed"}, "agent"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if err := store.UpdateIssue(ctx, existing.ID, map[string]interface{}{"title": "Renamed again", "priority": 0}, "agent"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if err := store.CloseIssue(ctx, closing.ID, "done", "agent"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	if err := store.UpdateIssue(ctx, reverted.ID, map[string]interface{}{"notes": "temp"}, "agent"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if err := store.UpdateIssue(ctx, reverted.ID, map[string]interface{}{"notes": ""}, "agent"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if err := store.CreateTombstone(ctx, doomed.ID, "agent", "duplicate"); err != nil {
+		t.Fatalf("CreateTombstone failed: %v", err)
+	}
+	added := &types.Issue{Title: "Added", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeBug}
+	if err := store.CreateIssue(ctx, added, "agent"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	changes, err := store.ChangesSince(ctx, start.Revision)
+	if err != nil {
+		t.Fatalf("ChangesSince failed: %v", err)
+	}
+	if changes.Revision <= start.Revision {
+		t.Errorf("Expected revision to advance past %d, got %d", start.Revision, changes.Revision)
+	}
+
+	if len(changes.Created) != 1 || changes.Created[0].ID != added.ID {
+		t.Errorf("Expected only %s created, got %+v", added.ID, changes.Created)
+	}
+	if len(changes.Deleted) != 1 || changes.Deleted[0].ID != doomed.ID {
+		t.Errorf("Expected only %s deleted, got %+v", doomed.ID, changes.Deleted)
+	}
+
+	if len(changes.StatusChanged) != 1 {
+		t.Fatalf("Expected 1 status change, got %+v", changes.StatusChanged)
+	}
+	sc := changes.StatusChanged[0]
+	if sc.IssueID != closing.ID || sc.From != types.StatusInProgress || sc.To != types.StatusClosed {
+		t.Errorf("Expected %s in_progress -> closed, got %+v", closing.ID, sc)
+	}
+
+	// Only the net edit to existing is reported; the reverted notes edit is not
+	if len(changes.Updated) != 1 || changes.Updated[0].IssueID != existing.ID {
+		t.Fatalf("Expected only %s updated, got %+v", existing.ID, changes.Updated)
+	}
+	diffs := make(map[string]FieldChange)
+	for _, f := range changes.Updated[0].Fields {
+		diffs[f.Field] = f
+	}
+	if f := diffs["title"]; f.Old != "Existing" || f.New != "Renamed again" {
+		t.Errorf("Expected title Existing -> Renamed again, got %+v", f)
+	}
+	if f := diffs["priority"]; f.Old != float64(2) || f.New != float64(0) {
+		t.Errorf("Expected priority 2 -> 0, got %+v", f)
+	}
+
+	// Nothing new since the latest revision
+	empty, err := store.ChangesSince(ctx, changes.Revision)
+	if err != nil {
+		t.Fatalf("ChangesSince failed: %v", err)
+	}
+	if empty.Revision != changes.Revision || len(empty.Created)+len(empty.Updated)+len(empty.StatusChanged)+len(empty.Deleted) != 0 {
+		t.Errorf("Expected no changes since %d, got %+v", changes.Revision, empty)
+	}
+}
+
+func TestChangesSinceCloseFromUnknownStatus(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	// An issue with no events recording its status, as after a raw import
+	issue := &types.Issue{Title: "Imported", Status: types.StatusBlocked, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "setup"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if _, err := store.db().ExecContext(ctx, `DELETE FROM events WHERE issue_id = ?`, issue.ID); err != nil {
+		t.Fatalf("Failed to delete events: %v", err)
+	}
+	start, err := store.ChangesSince(ctx, 0)
+	if err != nil {
+		t.Fatalf("ChangesSince failed: %v", err)
+	}
+
+	if err := store.CloseIssue(ctx, issue.ID, "done", "agent"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	changes, err := store.ChangesSince(ctx, start.Revision)
+	if err != nil {
+		t.Fatalf("ChangesSince failed: %v", err)
+	}
+	if len(changes.StatusChanged) != 1 {
+		t.Fatalf("Expected 1 status change, got %+v", changes.StatusChanged)
+	}
+	if sc := changes.StatusChanged[0]; sc.From != "" || sc.To != types.StatusClosed {
+		t.Errorf("Expected an unknown status -> closed, got %+v", sc)
+	}
+}
@@ -75,7 +75,7 @@ func TestMigrateChildCountersTable(t *testing.T) {
 		s := newTestStore(t, "")
 		defer s.Close()
 
-		db := s.db
+		db := s.db()
 
 		// Run migration twice
 		err := migrations.MigrateChildCountersTable(db)
@@ -133,7 +133,7 @@ func TestMigrateChildCountersTable(t *testing.T) {
 
 		// Verify child_counters has entry for parent
 		var lastChild int
-		err = s.db.QueryRow(`
+		err = s.db().QueryRow(`
 			SELECT last_child FROM child_counters WHERE parent_id = ?
 		`, "bd-parent").Scan(&lastChild)
 		if err != nil {
@@ -151,7 +151,7 @@ func TestMigrateChildCountersTable(t *testing.T) {
 
 		// Verify child_counters entry was CASCADE deleted
 		var count int
-		err = s.db.QueryRow(`
+		err = s.db().QueryRow(`
 			SELECT COUNT(*) FROM child_counters WHERE parent_id = ?
 		`, "bd-parent").Scan(&count)
 		if err != nil {
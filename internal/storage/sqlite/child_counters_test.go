@@ -16,7 +16,7 @@ func TestChildCountersTableExists(t *testing.T) {
 	
 	// Verify table exists by querying it
 	var count int
-	err := store.db.QueryRowContext(ctx, 
+	err := store.db().QueryRowContext(ctx, 
 		`SELECT COUNT(*) FROM sqlite_master WHERE type='table' AND name='child_counters'`).Scan(&count)
 	if err != nil {
 		t.Fatalf("failed to check for child_counters table: %v", err)
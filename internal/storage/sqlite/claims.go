@@ -0,0 +1,128 @@
+// Package sqlite - cooperative issue claims for multi-agent coordination
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Claim is an agent's cooperative hold on an issue
+type Claim struct {
+	IssueID   string    `json:"issue_id"`
+	Agent     string    `json:"agent"`
+	ClaimedAt time.Time `json:"claimed_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// ClaimIssue atomically claims issue id for agent for the next ttl. It returns
+// true if the claim was taken: the issue was unclaimed, its previous claim had
+// expired, or agent already held it (in which case the claim is renewed). It
+// returns false if another agent holds an unexpired claim.
+//
+// Claims are advisory: they don't stop anyone from updating the issue, but
+// agents can skip claimed work via WorkFilter.ExcludeClaimed. Because every
+// claim expires, a crashed agent never blocks an issue for longer than ttl.
+func (s *SQLiteStorage) ClaimIssue(ctx context.Context, id, agent string, ttl time.Duration) (bool, error) {
+	if agent == "" {
+		return false, fmt.Errorf("claim requires an agent")
+	}
+	if ttl <= 0 {
+		return false, fmt.Errorf("claim ttl must be positive, got %s", ttl)
+	}
+
+	var claimed bool
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		if err := requireIssue(ctx, tx, id); err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+		result, err := tx.ExecContext(ctx, `
+			INSERT INTO issue_claims (issue_id, agent, claimed_at, expires_at)
+			VALUES (?, ?, ?, ?)
+			ON CONFLICT (issue_id) DO UPDATE SET
+				agent = excluded.agent,
+				claimed_at = excluded.claimed_at,
+				expires_at = excluded.expires_at
+			WHERE issue_claims.agent = excluded.agent
+			   OR julianday(issue_claims.expires_at) <= julianday(excluded.claimed_at)
+		`, id, agent, now, now.Add(ttl))
+		if err != nil {
+			return wrapDBError("claim issue", err)
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return wrapDBError("claim issue", err)
+		}
+		claimed = rows > 0
+		return nil
+	})
+	return claimed, err
+}
+
+// RefreshClaim extends agent's claim on issue id to expire ttl from now.
+// It fails with ErrConflict if agent does not hold an unexpired claim, so an
+// agent that let its claim lapse must re-claim rather than silently steal it back.
+func (s *SQLiteStorage) RefreshClaim(ctx context.Context, id, agent string, ttl time.Duration) error {
+	if ttl <= 0 {
+		return fmt.Errorf("claim ttl must be positive, got %s", ttl)
+	}
+
+	now := time.Now().UTC()
+	result, err := s.db().ExecContext(ctx, `
+		UPDATE issue_claims SET expires_at = ?
+		WHERE issue_id = ? AND agent = ? AND julianday(expires_at) > julianday(?)
+	`, now.Add(ttl), id, agent, now)
+	if err != nil {
+		return wrapDBError("refresh claim", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return wrapDBError("refresh claim", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("refresh claim on %s: %w: not held by %s", id, ErrConflict, agent)
+	}
+	return nil
+}
+
+// ReleaseClaim drops agent's claim on issue id. Releasing a claim that agent
+// doesn't hold (never claimed, expired and taken by someone else, or already
+// released) is a no-op.
+func (s *SQLiteStorage) ReleaseClaim(ctx context.Context, id, agent string) error {
+	_, err := s.db().ExecContext(ctx, `
+		DELETE FROM issue_claims WHERE issue_id = ? AND agent = ?
+	`, id, agent)
+	return wrapDBError("release claim", err)
+}
+
+// GetClaim returns the unexpired claim on issue id, or nil if it is unclaimed
+func (s *SQLiteStorage) GetClaim(ctx context.Context, id string) (*Claim, error) {
+	var claim Claim
+	err := s.db().QueryRowContext(ctx, `
+		SELECT issue_id, agent, claimed_at, expires_at
+		FROM issue_claims
+		WHERE issue_id = ? AND julianday(expires_at) > julianday(?)
+	`, id, time.Now().UTC()).Scan(&claim.IssueID, &claim.Agent, &claim.ClaimedAt, &claim.ExpiresAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, wrapDBError("get claim", err)
+	}
+	return &claim, nil
+}
+
+// requireIssue returns an error wrapping ErrNotFound if issue id does not exist
+func requireIssue(ctx context.Context, q queryer, id string) error {
+	var exists bool
+	if err := q.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM issues WHERE id = ?)`, id).Scan(&exists); err != nil {
+		return wrapDBError("check issue", err)
+	}
+	if !exists {
+		return fmt.Errorf("issue %s: %w", id, ErrNotFound)
+	}
+	return nil
+}
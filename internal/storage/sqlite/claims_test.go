@@ -0,0 +1,162 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestClaimIssue(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Shared work", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	ok, err := store.ClaimIssue(ctx, issue.ID, "agent-a", time.Minute)
+	if err != nil || !ok {
+		t.Fatalf("Expected agent-a to claim, got ok=%v err=%v", ok, err)
+	}
+	ok, err = store.ClaimIssue(ctx, issue.ID, "agent-b", time.Minute)
+	if err != nil || ok {
+		t.Fatalf("Expected agent-b to be refused, got ok=%v err=%v", ok, err)
+	}
+	// Re-claiming your own claim renews it
+	ok, err = store.ClaimIssue(ctx, issue.ID, "agent-a", time.Minute)
+	if err != nil || !ok {
+		t.Fatalf("Expected agent-a to renew its claim, got ok=%v err=%v", ok, err)
+	}
+
+	claim, err := store.GetClaim(ctx, issue.ID)
+	if err != nil || claim == nil || claim.Agent != "agent-a" {
+		t.Fatalf("Expected claim held by agent-a, got %+v err=%v", claim, err)
+	}
+
+	if err := store.RefreshClaim(ctx, issue.ID, "agent-b", time.Minute); !errors.Is(err, ErrConflict) {
+		t.Errorf("Expected ErrConflict refreshing someone else's claim, got %v", err)
+	}
+	if err := store.RefreshClaim(ctx, issue.ID, "agent-a", time.Hour); err != nil {
+		t.Errorf("RefreshClaim failed: %v", err)
+	}
+
+	// Releasing someone else's claim does nothing
+	if err := store.ReleaseClaim(ctx, issue.ID, "agent-b"); err != nil {
+		t.Fatalf("ReleaseClaim failed: %v", err)
+	}
+	if claim, _ := store.GetClaim(ctx, issue.ID); claim == nil {
+		t.Fatal("Expected claim to survive release by another agent")
+	}
+
+	if err := store.ReleaseClaim(ctx, issue.ID, "agent-a"); err != nil {
+		t.Fatalf("ReleaseClaim failed: %v", err)
+	}
+	ok, err = store.ClaimIssue(ctx, issue.ID, "agent-b", time.Minute)
+	if err != nil || !ok {
+		t.Errorf("Expected agent-b to claim after release, got ok=%v err=%v", ok, err)
+	}
+
+	if _, err := store.ClaimIssue(ctx, "bd-missing", "agent-a", time.Minute); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound claiming a missing issue, got %v", err)
+	}
+}
+
+func TestClaimExpires(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Abandoned", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	if ok, err := store.ClaimIssue(ctx, issue.ID, "crashed-agent", 20*time.Millisecond); err != nil || !ok {
+		t.Fatalf("Expected claim, got ok=%v err=%v", ok, err)
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	if claim, _ := store.GetClaim(ctx, issue.ID); claim != nil {
+		t.Errorf("Expected expired claim to be ignored, got %+v", claim)
+	}
+	if err := store.RefreshClaim(ctx, issue.ID, "crashed-agent", time.Minute); !errors.Is(err, ErrConflict) {
+		t.Errorf("Expected ErrConflict refreshing an expired claim, got %v", err)
+	}
+	ok, err := store.ClaimIssue(ctx, issue.ID, "agent-b", time.Minute)
+	if err != nil || !ok {
+		t.Errorf("Expected agent-b to take over the expired claim, got ok=%v err=%v", ok, err)
+	}
+}
+
+func TestClaimIssueConcurrent(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Contended", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	const agents = 8
+	var wg sync.WaitGroup
+	results := make(chan bool, agents)
+	for i := 0; i < agents; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ok, err := store.ClaimIssue(ctx, issue.ID, string(rune('a'+i)), time.Minute)
+			if err != nil {
+				t.Errorf("ClaimIssue failed: %v", err)
+			}
+			results <- ok
+		}(i)
+	}
+	wg.Wait()
+	close(results)
+
+	winners := 0
+	for ok := range results {
+		if ok {
+			winners++
+		}
+	}
+	if winners != 1 {
+		t.Errorf("Expected exactly one agent to win the claim, got %d", winners)
+	}
+}
+
+func TestReadyWorkExcludeClaimed(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	claimed := &types.Issue{Title: "Claimed", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	free := &types.Issue{Title: "Free", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{claimed, free} {
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	if ok, err := store.ClaimIssue(ctx, claimed.ID, "agent-a", time.Minute); err != nil || !ok {
+		t.Fatalf("Expected claim, got ok=%v err=%v", ok, err)
+	}
+
+	all, err := store.GetReadyWork(ctx, types.WorkFilter{})
+	if err != nil {
+		t.Fatalf("GetReadyWork failed: %v", err)
+	}
+	if len(all) != 2 {
+		t.Errorf("Expected claimed issues in ready work by default, got %d issues", len(all))
+	}
+
+	unclaimed, err := store.GetReadyWork(ctx, types.WorkFilter{ExcludeClaimed: true})
+	if err != nil {
+		t.Fatalf("GetReadyWork failed: %v", err)
+	}
+	if len(unclaimed) != 1 || unclaimed[0].ID != free.ID {
+		t.Errorf("Expected only %s with ExcludeClaimed, got %v", free.ID, unclaimed)
+	}
+}
@@ -0,0 +1,23 @@
+// Package sqlite - injectable clock for time-relative queries
+package sqlite
+
+import "time"
+
+// SetClock replaces the function the store uses for "now" in time-relative
+// queries such as IssueFilter.OlderThan. Tests use it to pin the current
+// time; nil restores time.Now.
+func (s *SQLiteStorage) SetClock(now func() time.Time) {
+	if now == nil {
+		s.clock.Store(nil)
+		return
+	}
+	s.clock.Store(&now)
+}
+
+// now returns the current time according to the store's clock
+func (s *SQLiteStorage) now() time.Time {
+	if now := s.clock.Load(); now != nil {
+		return (*now)()
+	}
+	return time.Now()
+}
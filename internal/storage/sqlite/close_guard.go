@@ -0,0 +1,96 @@
+// Package sqlite - guard against closing parents with open children
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// OpenChildrenError is returned when closing a parent that still has open
+// children while RequireClosedChildrenConfigKey is enabled. It matches
+// ErrOpenChildren with errors.Is.
+type OpenChildrenError struct {
+	ParentID string
+	Children []string // IDs of the open children, sorted
+}
+
+func (e *OpenChildrenError) Error() string {
+	return fmt.Sprintf("cannot close %s: %d open child issue(s): %s (use force to close anyway)",
+		e.ParentID, len(e.Children), strings.Join(e.Children, ", "))
+}
+
+// Is reports whether target is ErrOpenChildren
+func (e *OpenChildrenError) Is(target error) bool {
+	return target == ErrOpenChildren
+}
+
+type forceCloseKey struct{}
+
+// WithForceClose returns a context under which CloseIssue and UpdateIssue
+// close parents even if they have open children
+func WithForceClose(ctx context.Context) context.Context {
+	return context.WithValue(ctx, forceCloseKey{}, true)
+}
+
+func isForceClose(ctx context.Context) bool {
+	force, _ := ctx.Value(forceCloseKey{}).(bool)
+	return force
+}
+
+// queryer is an interface for types that can run SQL queries
+// *sql.DB, *sql.Conn and *sql.Tx all implement this interface
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// checkOpenChildren returns an *OpenChildrenError if closing id must be refused
+// because RequireClosedChildrenConfigKey is enabled and id has open children.
+// It runs on q so the check sees the same transaction as the close.
+func checkOpenChildren(ctx context.Context, q queryer, id string) error {
+	if isForceClose(ctx) {
+		return nil
+	}
+
+	enabled, err := flagEnabled(ctx, q, RequireClosedChildrenConfigKey)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return nil
+	}
+
+	rows, err := q.QueryContext(ctx, `
+		SELECT i.id
+		FROM dependencies d
+		JOIN issues i ON i.id = d.issue_id
+		WHERE d.depends_on_id = ? AND d.type = ?
+		  AND i.status NOT IN (?, ?)
+		ORDER BY i.id
+	`, id, types.DepParentChild, types.StatusClosed, types.StatusTombstone)
+	if err != nil {
+		return wrapDBError("query open children", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var children []string
+	for rows.Next() {
+		var childID string
+		if err := rows.Scan(&childID); err != nil {
+			return wrapDBError("scan open child", err)
+		}
+		children = append(children, childID)
+	}
+	if err := rows.Err(); err != nil {
+		return wrapDBError("iterate open children", err)
+	}
+
+	if len(children) > 0 {
+		return &OpenChildrenError{ParentID: id, Children: children}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestCloseWithOpenChildren(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	epic := &types.Issue{Title: "Epic", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeEpic}
+	done := &types.Issue{Title: "Done child", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	open := &types.Issue{Title: "Open child", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{epic, done, open} {
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	for _, child := range []*types.Issue{done, open} {
+		dep := &types.Dependency{IssueID: child.ID, DependsOnID: epic.ID, Type: types.DepParentChild}
+		if err := store.AddDependency(ctx, dep, "test"); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+	}
+	if err := store.CloseIssue(ctx, done.ID, "done", "test"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+
+	// The rule is off by default
+	if err := store.CloseIssue(ctx, epic.ID, "early", "test"); err != nil {
+		t.Fatalf("Expected close to succeed with rule disabled, got %v", err)
+	}
+	if err := store.UpdateIssue(ctx, epic.ID, map[string]interface{}{"status": string(types.StatusOpen)}, "test"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+
+	if err := store.SetConfig(ctx, RequireClosedChildrenConfigKey, "true"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+
+	err := store.CloseIssue(ctx, epic.ID, "early", "test")
+	if !errors.Is(err, ErrOpenChildren) {
+		t.Fatalf("Expected ErrOpenChildren from CloseIssue, got %v", err)
+	}
+	var openErr *OpenChildrenError
+	if !errors.As(err, &openErr) || len(openErr.Children) != 1 || openErr.Children[0] != open.ID {
+		t.Errorf("Expected open children [%s], got %v", open.ID, err)
+	}
+
+	err = store.UpdateIssue(ctx, epic.ID, map[string]interface{}{"status": string(types.StatusClosed)}, "test")
+	if !errors.Is(err, ErrOpenChildren) {
+		t.Errorf("Expected ErrOpenChildren from UpdateIssue, got %v", err)
+	}
+
+	err = store.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		return tx.CloseIssue(ctx, epic.ID, "early", "test")
+	})
+	if !errors.Is(err, ErrOpenChildren) {
+		t.Errorf("Expected ErrOpenChildren from transaction CloseIssue, got %v", err)
+	}
+
+	got, _ := store.GetIssue(ctx, epic.ID)
+	if got.Status != types.StatusOpen {
+		t.Fatalf("Refused close must leave the epic open, got %s", got.Status)
+	}
+
+	// Other updates to the parent are unaffected
+	if err := store.UpdateIssue(ctx, epic.ID, map[string]interface{}{"priority": 0}, "test"); err != nil {
+		t.Errorf("Expected non-close update to succeed, got %v", err)
+	}
+
+	// Forcing overrides the rule
+	if err := store.CloseIssue(WithForceClose(ctx), epic.ID, "forced", "test"); err != nil {
+		t.Fatalf("Expected forced close to succeed, got %v", err)
+	}
+	got, _ = store.GetIssue(ctx, epic.ID)
+	if got.Status != types.StatusClosed {
+		t.Errorf("Expected epic closed, got %s", got.Status)
+	}
+}
@@ -0,0 +1,108 @@
+// Package sqlite - transitive blocker closures
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Ancestors returns every issue that transitively blocks id: its blockers,
+// their blockers, and so on. It answers "what must I finish first?". Only
+// blocks edges are followed; the issue itself is never included, even when
+// it sits on a cycle.
+func (s *SQLiteStorage) Ancestors(ctx context.Context, id string) ([]*types.Issue, error) {
+	return s.AncestorsToDepth(ctx, id, 0)
+}
+
+// Descendants returns every issue that id transitively blocks. It answers
+// "if I fix this, what unblocks?". Only blocks edges are followed; the issue
+// itself is never included, even when it sits on a cycle.
+func (s *SQLiteStorage) Descendants(ctx context.Context, id string) ([]*types.Issue, error) {
+	return s.DescendantsToDepth(ctx, id, 0)
+}
+
+// AncestorsToDepth is Ancestors limited to blockers at most maxDepth edges
+// away (0 means no limit)
+func (s *SQLiteStorage) AncestorsToDepth(ctx context.Context, id string, maxDepth int) ([]*types.Issue, error) {
+	return s.blockerClosure(ctx, id, maxDepth, false)
+}
+
+// DescendantsToDepth is Descendants limited to issues at most maxDepth edges
+// away (0 means no limit)
+func (s *SQLiteStorage) DescendantsToDepth(ctx context.Context, id string, maxDepth int) ([]*types.Issue, error) {
+	return s.blockerClosure(ctx, id, maxDepth, true)
+}
+
+// blockerClosure walks blocks edges from id with a recursive CTE, towards
+// blockers or, when down is set, towards the issues they block. Issues are
+// returned by priority.
+//
+// Cycles are cut by the CTE's UNION, which drops rows already produced:
+// without a depth limit a row is just an issue ID, so each issue is visited
+// once. With a limit, rows carry their depth, so an issue on a cycle is
+// revisited at most maxDepth times.
+func (s *SQLiteStorage) blockerClosure(ctx context.Context, id string, maxDepth int, down bool) ([]*types.Issue, error) {
+	defer s.beginRead()()
+	ctx, cancel := s.withQueryTimeout(ctx)
+	defer cancel()
+
+	if err := requireVisibleIssue(ctx, s.db(), id); err != nil {
+		return nil, err
+	}
+
+	from, to := "issue_id", "depends_on_id"
+	if down {
+		from, to = to, from
+	}
+
+	var closureSQL string
+	args := []interface{}{id, types.DepBlocks}
+	if maxDepth > 0 {
+		closureSQL = fmt.Sprintf(`
+			closure(id, depth) AS (
+				SELECT ?, 0
+				UNION
+				SELECT d.%[2]s, c.depth + 1
+				FROM dependencies d
+				JOIN closure c ON d.%[1]s = c.id
+				WHERE d.type = ? AND c.depth < ?
+			)`, from, to)
+		args = append(args, maxDepth)
+	} else {
+		closureSQL = fmt.Sprintf(`
+			closure(id) AS (
+				SELECT ?
+				UNION
+				SELECT d.%[2]s
+				FROM dependencies d
+				JOIN closure c ON d.%[1]s = c.id
+				WHERE d.type = ?
+			)`, from, to)
+	}
+	args = append(args, id)
+	visibleSQL := ""
+	if visible, visibleArgs := visibleClause(ctx, "i.id"); visible != "" {
+		visibleSQL = " AND " + visible
+		args = append(args, visibleArgs...)
+	}
+
+	// #nosec G201 -- column names are fixed above
+	rows, err := s.db().QueryContext(ctx, fmt.Sprintf(`
+		WITH RECURSIVE %s
+		SELECT i.id, i.content_hash, i.title, i.description, i.design, i.acceptance_criteria, i.notes,
+		       i.status, i.priority, i.issue_type, i.assignee, i.estimated_minutes,
+		       i.created_at, i.updated_at, i.closed_at, i.external_ref, i.source_repo, i.close_reason,
+		       i.deleted_at, i.deleted_by, i.delete_reason, i.original_type, i.draft, i.percent_complete, i.external_blocked_reason, i.snoozed_until, i.due_date, i.version
+		FROM issues i
+		WHERE i.id IN (SELECT id FROM closure) AND i.id != ?%s
+		ORDER BY i.priority ASC, i.id ASC
+	`, closureSQL, visibleSQL), args...)
+	if err != nil {
+		return nil, withContextError(ctx, fmt.Errorf("failed to get blocker closure: %w", err))
+	}
+	defer func() { _ = rows.Close() }()
+
+	return s.scanIssues(ctx, rows)
+}
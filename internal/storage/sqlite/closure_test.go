@@ -0,0 +1,97 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestBlockerClosure(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	newIssue := func(title string) string {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		return issue.ID
+	}
+	addDep := func(issueID, dependsOnID string, depType types.DependencyType) {
+		dep := &types.Dependency{IssueID: issueID, DependsOnID: dependsOnID, Type: depType}
+		if err := store.AddDependency(ctx, dep, "test"); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+	}
+	ids := func(issues []*types.Issue, err error) []string {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("closure failed: %v", err)
+		}
+		var got []string
+		for _, issue := range issues {
+			got = append(got, issue.ID)
+		}
+		return got
+	}
+
+	// schema blocks api blocks ui blocks release; docs is only related to api
+	schema, api, ui, release, docs := newIssue("Schema"), newIssue("API"), newIssue("UI"), newIssue("Release"), newIssue("Docs")
+	addDep(api, schema, types.DepBlocks)
+	addDep(ui, api, types.DepBlocks)
+	addDep(release, ui, types.DepBlocks)
+	addDep(release, api, types.DepBlocks)
+	addDep(docs, api, types.DepRelated)
+
+	if got, want := ids(store.Ancestors(ctx, release)), []string{schema, api, ui}; !sameIDs(got, want) {
+		t.Errorf("Expected ancestors %v, got %v", want, got)
+	}
+	if got, want := ids(store.Descendants(ctx, schema)), []string{api, ui, release}; !sameIDs(got, want) {
+		t.Errorf("Expected descendants %v, got %v", want, got)
+	}
+	if got := ids(store.Descendants(ctx, release)); len(got) != 0 {
+		t.Errorf("Expected no descendants, got %v", got)
+	}
+	if got, want := ids(store.DescendantsToDepth(ctx, schema, 1)), []string{api}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected depth-limited descendants %v, got %v", want, got)
+	}
+	if got, want := ids(store.AncestorsToDepth(ctx, release, 2)), []string{schema, api, ui}; !sameIDs(got, want) {
+		t.Errorf("Expected depth-limited ancestors %v, got %v", want, got)
+	}
+
+	// A cycle (which AddDependency would refuse) must not loop forever
+	if _, err := store.db().ExecContext(ctx, `INSERT INTO dependencies (issue_id, depends_on_id, type, created_by) VALUES (?, ?, ?, 'test')`,
+		schema, release, types.DepBlocks); err != nil {
+		t.Fatalf("Failed to insert cycle: %v", err)
+	}
+	if got, want := ids(store.Ancestors(ctx, release)), []string{schema, api, ui}; !sameIDs(got, want) {
+		t.Errorf("Expected ancestors through cycle %v, got %v", want, got)
+	}
+	if got, want := ids(store.DescendantsToDepth(ctx, schema, 10)), []string{api, ui, release}; !sameIDs(got, want) {
+		t.Errorf("Expected depth-limited descendants through cycle %v, got %v", want, got)
+	}
+
+	if _, err := store.Ancestors(ctx, "bd-missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+// sameIDs reports whether got and want hold the same IDs in any order
+func sameIDs(got, want []string) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	seen := make(map[string]bool, len(got))
+	for _, id := range got {
+		seen[id] = true
+	}
+	for _, id := range want {
+		if !seen[id] {
+			return false
+		}
+	}
+	return true
+}
@@ -2,6 +2,7 @@ package sqlite
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 
 	"github.com/steveyegge/beads/internal/types"
@@ -11,7 +12,7 @@ import (
 func (s *SQLiteStorage) AddIssueComment(ctx context.Context, issueID, author, text string) (*types.Comment, error) {
 	// Verify issue exists
 	var exists bool
-	err := s.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM issues WHERE id = ?)`, issueID).Scan(&exists)
+	err := s.db().QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM issues WHERE id = ?)`, issueID).Scan(&exists)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check issue existence: %w", err)
 	}
@@ -20,23 +21,29 @@ func (s *SQLiteStorage) AddIssueComment(ctx context.Context, issueID, author, te
 	}
 
 	// Insert comment
-	result, err := s.db.ExecContext(ctx, `
-		INSERT INTO comments (issue_id, author, text, created_at)
-		VALUES (?, ?, ?, CURRENT_TIMESTAMP)
-	`, issueID, author, text)
-	if err != nil {
-		return nil, fmt.Errorf("failed to insert comment: %w", err)
-	}
+	var commentID int64
+	err = s.withTx(ctx, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, `
+			INSERT INTO comments (issue_id, author, text, created_at)
+			VALUES (?, ?, ?, CURRENT_TIMESTAMP)
+		`, issueID, author, text)
+		if err != nil {
+			return fmt.Errorf("failed to insert comment: %w", err)
+		}
 
-	// Get the inserted comment ID
-	commentID, err := result.LastInsertId()
+		// Get the inserted comment ID
+		if commentID, err = result.LastInsertId(); err != nil {
+			return fmt.Errorf("failed to get comment ID: %w", err)
+		}
+		return syncFTS(ctx, tx, issueID)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get comment ID: %w", err)
+		return nil, err
 	}
 
 	// Fetch the complete comment
 	comment := &types.Comment{}
-	err = s.db.QueryRowContext(ctx, `
+	err = s.db().QueryRowContext(ctx, `
 		SELECT id, issue_id, author, text, created_at
 		FROM comments WHERE id = ?
 	`, commentID).Scan(&comment.ID, &comment.IssueID, &comment.Author, &comment.Text, &comment.CreatedAt)
@@ -54,12 +61,22 @@ func (s *SQLiteStorage) AddIssueComment(ctx context.Context, issueID, author, te
 
 // GetIssueComments retrieves all comments for an issue
 func (s *SQLiteStorage) GetIssueComments(ctx context.Context, issueID string) ([]*types.Comment, error) {
-	rows, err := s.db.QueryContext(ctx, `
+	return issueComments(ctx, s.db(), issueID)
+}
+
+// issueComments runs GetIssueComments' query on q
+func issueComments(ctx context.Context, q queryer, issueID string) ([]*types.Comment, error) {
+	query := `
 		SELECT id, issue_id, author, text, created_at
 		FROM comments
-		WHERE issue_id = ?
-		ORDER BY created_at ASC
-	`, issueID)
+		WHERE issue_id = ?`
+	args := []interface{}{issueID}
+	// A private issue the requesting actor doesn't own has no comments to them
+	if visible, visibleArgs := visibleClause(ctx, "comments.issue_id"); visible != "" {
+		query += " AND " + visible
+		args = append(args, visibleArgs...)
+	}
+	rows, err := q.QueryContext(ctx, query+" ORDER BY created_at ASC", args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query comments: %w", err)
 	}
@@ -85,6 +102,11 @@ func (s *SQLiteStorage) GetIssueComments(ctx context.Context, issueID string) ([
 // GetCommentsForIssues fetches comments for multiple issues in a single query
 // Returns a map of issue_id -> []*Comment
 func (s *SQLiteStorage) GetCommentsForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.Comment, error) {
+	return commentsForIssues(ctx, s.db(), issueIDs)
+}
+
+// commentsForIssues is GetCommentsForIssues on q
+func commentsForIssues(ctx context.Context, q queryer, issueIDs []string) (map[string][]*types.Comment, error) {
 	if len(issueIDs) == 0 {
 		return make(map[string][]*types.Comment), nil
 	}
@@ -102,7 +124,7 @@ func (s *SQLiteStorage) GetCommentsForIssues(ctx context.Context, issueIDs []str
 		ORDER BY issue_id, created_at ASC
 	`, buildPlaceholders(len(issueIDs))) // #nosec G201 -- placeholders are generated internally
 
-	rows, err := s.db.QueryContext(ctx, query, placeholders...)
+	rows, err := q.QueryContext(ctx, query, placeholders...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to batch get comments: %w", err)
 	}
@@ -284,7 +284,7 @@ func TestAddIssueCommentMarksDirty(t *testing.T) {
 
 	// Verify issue is marked dirty
 	var exists bool
-	err = store.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM dirty_issues WHERE issue_id = ?)`, issue.ID).Scan(&exists)
+	err = store.db().QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM dirty_issues WHERE issue_id = ?)`, issue.ID).Scan(&exists)
 	if err != nil {
 		t.Fatalf("Failed to check dirty flag: %v", err)
 	}
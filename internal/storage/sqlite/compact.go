@@ -89,7 +89,7 @@ func (s *SQLiteStorage) GetTier1Candidates(ctx context.Context) ([]*CompactionCa
 		ORDER BY i.closed_at ASC
 	`
 
-	rows, err := s.db.QueryContext(ctx, query, depthStr, depthStr, daysStr)
+	rows, err := s.db().QueryContext(ctx, query, depthStr, depthStr, daysStr)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query tier1 candidates: %w", err)
 	}
@@ -166,7 +166,7 @@ func (s *SQLiteStorage) GetTier2Candidates(ctx context.Context) ([]*CompactionCa
 		ORDER BY i.closed_at ASC
 	`
 
-	rows, err := s.db.QueryContext(ctx, query, daysStr, commitsStr)
+	rows, err := s.db().QueryContext(ctx, query, daysStr, commitsStr)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query tier2 candidates: %w", err)
 	}
@@ -197,7 +197,7 @@ func (s *SQLiteStorage) CheckEligibility(ctx context.Context, issueID string, ti
 	var closedAt sql.NullTime
 	var compactionLevel int
 	
-	err := s.db.QueryRowContext(ctx, `
+	err := s.db().QueryRowContext(ctx, `
 		SELECT status, closed_at, COALESCE(compaction_level, 0)
 		FROM issues
 		WHERE id = ?
@@ -59,7 +59,7 @@ func BenchmarkGetTier2Candidates(b *testing.B) {
 			b.Fatalf("Failed to create issue: %v", err)
 		}
 
-		_, err := store.db.ExecContext(ctx, `
+		_, err := store.db().ExecContext(ctx, `
 			UPDATE issues 
 			SET compaction_level = 1, 
 			    compacted_at = datetime('now', '-95 days'),
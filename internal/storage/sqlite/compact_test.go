@@ -119,7 +119,7 @@ func TestGetTier2Candidates(t *testing.T) {
 	}
 
 	// Set compaction level to 1
-	_, err := store.db.ExecContext(ctx, `
+	_, err := store.db().ExecContext(ctx, `
 		UPDATE issues 
 		SET compaction_level = 1, 
 		    compacted_at = datetime('now', '-95 days'),
@@ -232,7 +232,7 @@ func TestCheckEligibilityAlreadyCompacted(t *testing.T) {
 	}
 
 	// Mark as compacted
-	_, err := store.db.ExecContext(ctx, `
+	_, err := store.db().ExecContext(ctx, `
 		UPDATE issues SET compaction_level = 1 WHERE id = ?
 	`, "bd-1")
 	if err != nil {
@@ -295,7 +295,7 @@ func TestTier1NoCircularDeps(t *testing.T) {
 
 	// Create circular dependency: 1->2->3->1
 	// Note: the AddDependency validation should prevent this, but let's test the query handles it
-	_, err := store.db.ExecContext(ctx, `
+	_, err := store.db().ExecContext(ctx, `
 		INSERT INTO dependencies (issue_id, depends_on_id, type, created_by) VALUES
 			('bd-1', 'bd-2', 'blocks', 'test'),
 			('bd-2', 'bd-3', 'blocks', 'test'),
@@ -345,7 +345,7 @@ func TestApplyCompaction(t *testing.T) {
 	var compactedAt sql.NullTime
 	var compactedAtCommit sql.NullString
 	var storedSize int
-	err = store.db.QueryRowContext(ctx, `
+	err = store.db().QueryRowContext(ctx, `
 		SELECT COALESCE(compaction_level, 0), compacted_at, compacted_at_commit, COALESCE(original_size, 0)
 		FROM issues WHERE id = ?
 	`, issue.ID).Scan(&compactionLevel, &compactedAt, &compactedAtCommit, &storedSize)
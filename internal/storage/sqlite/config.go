@@ -3,12 +3,24 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"strconv"
 	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
 )
 
-// SetConfig sets a configuration value
+// SetConfig sets a configuration value. Keys must be registered with
+// storage.RegisterConfigKey and values pass its validator, so typos and bad
+// values fail with storage.ErrUnknownConfigKey or
+// storage.ErrInvalidConfigValue instead of being stored.
 func (s *SQLiteStorage) SetConfig(ctx context.Context, key, value string) error {
-	_, err := s.db.ExecContext(ctx, `
+	if err := storage.ValidateConfig(key, value); err != nil {
+		return err
+	}
+	_, err := s.db().ExecContext(ctx, `
 		INSERT INTO config (key, value) VALUES (?, ?)
 		ON CONFLICT (key) DO UPDATE SET value = excluded.value
 	`, key, value)
@@ -18,16 +30,44 @@ func (s *SQLiteStorage) SetConfig(ctx context.Context, key, value string) error
 // GetConfig gets a configuration value
 func (s *SQLiteStorage) GetConfig(ctx context.Context, key string) (string, error) {
 	var value string
-	err := s.db.QueryRowContext(ctx, `SELECT value FROM config WHERE key = ?`, key).Scan(&value)
+	err := s.db().QueryRowContext(ctx, `SELECT value FROM config WHERE key = ?`, key).Scan(&value)
 	if err == sql.ErrNoRows {
 		return "", nil
 	}
 	return value, wrapDBError("get config", err)
 }
 
+// GetConfigInt returns key's value as an int, or def if it is unset. A value
+// that isn't a whole number is an error matching storage.ErrInvalidConfigValue.
+func (s *SQLiteStorage) GetConfigInt(ctx context.Context, key string, def int) (int, error) {
+	value, err := s.GetConfig(ctx, key)
+	if err != nil || strings.TrimSpace(value) == "" {
+		return def, err
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return def, fmt.Errorf("%w for %s: %q is not a whole number", storage.ErrInvalidConfigValue, key, value)
+	}
+	return n, nil
+}
+
+// GetConfigBool returns key's value as a bool, or def if it is unset. A value
+// strconv.ParseBool rejects is an error matching storage.ErrInvalidConfigValue.
+func (s *SQLiteStorage) GetConfigBool(ctx context.Context, key string, def bool) (bool, error) {
+	value, err := s.GetConfig(ctx, key)
+	if err != nil || strings.TrimSpace(value) == "" {
+		return def, err
+	}
+	b, err := storage.ParseConfigBool(value)
+	if err != nil {
+		return def, fmt.Errorf("%w for %s: %v", storage.ErrInvalidConfigValue, key, err)
+	}
+	return b, nil
+}
+
 // GetAllConfig gets all configuration key-value pairs
 func (s *SQLiteStorage) GetAllConfig(ctx context.Context) (map[string]string, error) {
-	rows, err := s.db.QueryContext(ctx, `SELECT key, value FROM config ORDER BY key`)
+	rows, err := s.db().QueryContext(ctx, `SELECT key, value FROM config ORDER BY key`)
 	if err != nil {
 		return nil, wrapDBError("query all config", err)
 	}
@@ -46,7 +86,7 @@ func (s *SQLiteStorage) GetAllConfig(ctx context.Context) (map[string]string, er
 
 // DeleteConfig deletes a configuration value
 func (s *SQLiteStorage) DeleteConfig(ctx context.Context, key string) error {
-	_, err := s.db.ExecContext(ctx, `DELETE FROM config WHERE key = ?`, key)
+	_, err := s.db().ExecContext(ctx, `DELETE FROM config WHERE key = ?`, key)
 	return wrapDBError("delete config", err)
 }
 
@@ -78,7 +118,7 @@ func (s *SQLiteStorage) GetOrphanHandling(ctx context.Context) OrphanHandling {
 
 // SetMetadata sets a metadata value (for internal state like import hashes)
 func (s *SQLiteStorage) SetMetadata(ctx context.Context, key, value string) error {
-	_, err := s.db.ExecContext(ctx, `
+	_, err := s.db().ExecContext(ctx, `
 		INSERT INTO metadata (key, value) VALUES (?, ?)
 		ON CONFLICT (key) DO UPDATE SET value = excluded.value
 	`, key, value)
@@ -88,16 +128,142 @@ func (s *SQLiteStorage) SetMetadata(ctx context.Context, key, value string) erro
 // GetMetadata gets a metadata value (for internal state like import hashes)
 func (s *SQLiteStorage) GetMetadata(ctx context.Context, key string) (string, error) {
 	var value string
-	err := s.db.QueryRowContext(ctx, `SELECT value FROM metadata WHERE key = ?`, key).Scan(&value)
+	err := s.db().QueryRowContext(ctx, `SELECT value FROM metadata WHERE key = ?`, key).Scan(&value)
 	if err == sql.ErrNoRows {
 		return "", nil
 	}
 	return value, wrapDBError("get metadata", err)
 }
 
+// CaseInsensitiveLabelsConfigKey is the config key that turns on query-time label
+// normalization. When "true", label filters ignore case and surrounding whitespace,
+// so filtering by "backend" also matches "Backend" and " backend".
+const CaseInsensitiveLabelsConfigKey = "labels.case_insensitive"
+
+// caseInsensitiveLabels reports whether query-time label normalization is enabled.
+// Defaults to false (exact matching) if unset or unreadable.
+func (s *SQLiteStorage) caseInsensitiveLabels(ctx context.Context) bool {
+	return s.IsEnabled(ctx, CaseInsensitiveLabelsConfigKey)
+}
+
+// RequireClosedChildrenConfigKey is the config key that stops a parent from
+// being closed while any of its parent-child children are still open. When
+// "true", closing such a parent fails with ErrOpenChildren unless forced with
+// WithForceClose.
+const RequireClosedChildrenConfigKey = "close.require_closed_children"
+
+// AutoUnblockConfigKey is the config key that, when "true", moves issues in
+// the blocked status back to open once a status change leaves them with no
+// open blockers (see AutoUnblockActor)
+const AutoUnblockConfigKey = "status.auto_unblock"
+
+// AutoCloseEpicConfigKey is the config key that, when "true", closes an epic
+// once its last open parent-child child closes (see AutoCloseEpicActor)
+const AutoCloseEpicConfigKey = "close.auto_close_epics"
+
+// CloseOnPercentCompleteConfigKey is the config key that, when "true", closes
+// an issue when an update sets its percent_complete to 100 without also
+// setting a status
+const CloseOnPercentCompleteConfigKey = "close.on_percent_complete"
+
+// IDCollisionRetriesConfigKey is the config key for how many times CreateIssue
+// regenerates an auto-generated ID that collides with an existing issue before
+// giving up with ErrDuplicateID. Defaults to DefaultIDCollisionRetries.
+const IDCollisionRetriesConfigKey = "id.collision_retries"
+
+// DefaultIDCollisionRetries is used when IDCollisionRetriesConfigKey is unset or invalid
+const DefaultIDCollisionRetries = 3
+
+// DescriptionCompressionConfigKey is the config key for the size in bytes at
+// which issue descriptions are stored zstd-compressed (see descriptions.go).
+// Unset or 0 disables compression. Reads decompress transparently either way.
+const DescriptionCompressionConfigKey = "compression.description_threshold"
+
+// WIPLimitConfigKey is the config key for the maximum number of in-progress
+// issues per assignee (see checkWIPLimit). Unset, invalid or 0 means no limit.
+const WIPLimitConfigKey = "wip.limit"
+
+// AutoAssignRotationConfigKey is the config key for the comma-separated
+// rotation of assignees AutoAssign hands unassigned open issues to. Unset or
+// empty disables auto-assignment.
+const AutoAssignRotationConfigKey = "assign.rotation"
+
+// IdempotencyWindowConfigKey is the config key for how long CreateIssue
+// remembers an idempotency key (see WithIdempotencyKey), as a Go duration.
+// Unset or invalid values use DefaultIdempotencyWindow.
+const IdempotencyWindowConfigKey = "create.idempotency_window"
+
+// DefaultIdempotencyWindow is used when IdempotencyWindowConfigKey is unset
+// or invalid
+const DefaultIdempotencyWindow = 24 * time.Hour
+
+// AlmostReadyMaxBlockersConfigKey is the config key for the most open
+// blockers an issue can have left and still count as almost ready (see
+// AlmostReady). Unset or invalid values use DefaultAlmostReadyMaxBlockers.
+const AlmostReadyMaxBlockersConfigKey = "ready.almost_max_blockers"
+
+// DefaultAlmostReadyMaxBlockers is used when AlmostReadyMaxBlockersConfigKey
+// is unset or invalid
+const DefaultAlmostReadyMaxBlockers = 1
+
+// SLA targets are configured per priority under SLAConfigPrefix, as
+// "sla.p<N>.response" (time to first response) and "sla.p<N>.resolution"
+// (time to close), e.g. "sla.p0.response" = "4h". Values are Go durations or
+// a whole number of days ("3d") or weeks ("2w"). Unset or invalid values
+// mean no target.
+const SLAConfigPrefix = "sla."
+
+// Config keys for the complexity limits on search filters (see
+// checkFilterComplexity). Unset or invalid values use the defaults below;
+// 0 disables a limit.
+const (
+	// FilterMaxLabelClausesConfigKey caps Labels, LabelsAny and LabelGlobs entries
+	FilterMaxLabelClausesConfigKey = "search.max_label_clauses"
+	// FilterMaxWildcardsConfigKey caps LIKE wildcards (% and _) across the
+	// free-text search terms
+	FilterMaxWildcardsConfigKey = "search.max_wildcards"
+)
+
+// Default search filter complexity limits
+const (
+	DefaultFilterMaxLabelClauses = 100
+	DefaultFilterMaxWildcards    = 32
+)
+
+// Config keys for the project's creation defaults (see defaults.go). Each is
+// applied by CreateIssue only to fields the caller left unset.
+const (
+	// DefaultPriorityConfigKey is the priority for new issues ("0"-"4" or "P0"-"P4")
+	DefaultPriorityConfigKey = "defaults.priority"
+	// DefaultIssueTypeConfigKey is the issue type for new issues
+	DefaultIssueTypeConfigKey = "defaults.issue_type"
+	// DefaultLabelsConfigKey is a comma-separated list of labels for new issues
+	DefaultLabelsConfigKey = "defaults.labels"
+)
+
+// Config keys for the ready score weights (see types.ReadyScoreWeights).
+// Unset or invalid weights use types.DefaultReadyScoreWeights.
+const (
+	ReadyScorePriorityWeightConfigKey   = "ready.score.priority_weight"
+	ReadyScoreAgeWeightConfigKey        = "ready.score.age_weight"
+	ReadyScoreDependentsWeightConfigKey = "ready.score.dependents_weight"
+)
+
 // CustomStatusConfigKey is the config key for custom status states
 const CustomStatusConfigKey = "status.custom"
 
+// StatusMetaConfigPrefix prefixes the config keys holding display metadata for
+// each status, e.g. "status.meta.review" = "category=doing,color=#f0ad4e,order=3"
+// (see StatusMeta). Unset fields keep their defaults.
+const StatusMetaConfigPrefix = "status.meta."
+
+// StatusTransitionsConfigPrefix prefixes the config keys restricting the
+// status changes TransitionStatus allows: "status.transitions.<from>" is a
+// comma-separated list of the statuses issues in <from> may move to, e.g.
+// "status.transitions.closed" = "open" (empty allows none). Statuses without
+// a key may move to any status.
+const StatusTransitionsConfigPrefix = "status.transitions."
+
 // GetCustomStatuses retrieves the list of custom status states from config.
 // Custom statuses are stored as comma-separated values in the "status.custom" config key.
 // Returns an empty slice if no custom statuses are configured.
@@ -128,3 +294,68 @@ func parseCustomStatuses(value string) []string {
 	}
 	return result
 }
+
+// Register the config keys this package reads (see storage.RegisterConfigKey)
+func init() {
+	for flag := range knownFlags {
+		storage.RegisterConfigKey(flag, storage.ValidateBoolConfig)
+	}
+	keys := map[string]func(string) error{
+		IDCollisionRetriesConfigKey:         storage.ValidateIntConfig,
+		DescriptionCompressionConfigKey:     storage.ValidateIntConfig,
+		WIPLimitConfigKey:                   storage.ValidateIntConfig,
+		AutoAssignRotationConfigKey:         nil,
+		IdempotencyWindowConfigKey:          storage.ValidateDurationConfig,
+		AlmostReadyMaxBlockersConfigKey:     storage.ValidateIntConfig,
+		FilterMaxLabelClausesConfigKey:      storage.ValidateIntConfig,
+		FilterMaxWildcardsConfigKey:         storage.ValidateIntConfig,
+		DefaultPriorityConfigKey:            validateDefaultPriority,
+		DefaultIssueTypeConfigKey:           validateDefaultIssueType,
+		DefaultLabelsConfigKey:              nil,
+		ReadyScorePriorityWeightConfigKey:   storage.ValidateFloatConfig,
+		ReadyScoreAgeWeightConfigKey:        storage.ValidateFloatConfig,
+		ReadyScoreDependentsWeightConfigKey: storage.ValidateFloatConfig,
+		CustomStatusConfigKey:               nil,
+		StatusMetaConfigPrefix + "*":        nil,
+		StatusTransitionsConfigPrefix + "*": nil,
+		SLAConfigPrefix + "*":               nil,
+		DuplicateNormalizationConfigKey:     nil,
+		"import.orphan_handling": storage.ValidateOneOf(
+			string(OrphanStrict), string(OrphanResurrect), string(OrphanSkip), string(OrphanAllow)),
+		// Adaptive ID length (see adaptive_length.go)
+		"max_collision_prob": storage.ValidateFloatConfig,
+		"min_hash_length":    storage.ValidateIntConfig,
+		"max_hash_length":    storage.ValidateIntConfig,
+		// Compaction settings seeded by the compaction_config migration
+		"compaction_enabled":       storage.ValidateBoolConfig,
+		"auto_compact_enabled":     storage.ValidateBoolConfig,
+		"compact_tier1_days":       storage.ValidateIntConfig,
+		"compact_tier1_dep_levels": storage.ValidateIntConfig,
+		"compact_tier2_days":       storage.ValidateIntConfig,
+		"compact_tier2_dep_levels": storage.ValidateIntConfig,
+		"compact_tier2_commits":    storage.ValidateIntConfig,
+		"compact_model":            nil,
+		"compact_batch_size":       storage.ValidateIntConfig,
+		"compact_parallel_workers": storage.ValidateIntConfig,
+	}
+	for key, validate := range keys {
+		storage.RegisterConfigKey(key, validate)
+	}
+}
+
+// validateDefaultPriority accepts DefaultPriorityConfigKey values: "0"-"4" or "P0"-"P4"
+func validateDefaultPriority(value string) error {
+	p, err := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(value)), "P"))
+	if err != nil || p < minPriority || p > maxPriority {
+		return fmt.Errorf("%q is not a priority (0-4 or P0-P4)", value)
+	}
+	return nil
+}
+
+// validateDefaultIssueType accepts DefaultIssueTypeConfigKey values
+func validateDefaultIssueType(value string) error {
+	if !types.IssueType(strings.ToLower(strings.TrimSpace(value))).IsValid() {
+		return fmt.Errorf("%q is not an issue type", value)
+	}
+	return nil
+}
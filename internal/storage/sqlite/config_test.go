@@ -0,0 +1,58 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+func TestSetConfigValidation(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	if err := store.SetConfig(ctx, "issue_prefx", "bd"); !errors.Is(err, storage.ErrUnknownConfigKey) {
+		t.Errorf("Expected ErrUnknownConfigKey for a typo, got %v", err)
+	}
+	if err := store.SetConfig(ctx, "issue_prefix", "My Project"); !errors.Is(err, storage.ErrInvalidConfigValue) {
+		t.Errorf("Expected ErrInvalidConfigValue for a bad prefix, got %v", err)
+	}
+	if prefix, _ := store.GetConfig(ctx, "issue_prefix"); prefix != "bd" {
+		t.Errorf("Expected rejected prefix to leave bd, got %q", prefix)
+	}
+	err := store.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		return tx.SetConfig(ctx, WIPLimitConfigKey, "three")
+	})
+	if !errors.Is(err, storage.ErrInvalidConfigValue) {
+		t.Errorf("Expected ErrInvalidConfigValue in a transaction, got %v", err)
+	}
+
+	// Typed getters
+	if n, err := store.GetConfigInt(ctx, WIPLimitConfigKey, 5); n != 5 || err != nil {
+		t.Errorf("Expected default 5 for unset key, got %d (err %v)", n, err)
+	}
+	if err := store.SetConfig(ctx, WIPLimitConfigKey, "3"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	if n, err := store.GetConfigInt(ctx, WIPLimitConfigKey, 5); n != 3 || err != nil {
+		t.Errorf("Expected 3, got %d (err %v)", n, err)
+	}
+	if err := store.SetConfig(ctx, AutoUnblockConfigKey, "true"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	if on, err := store.GetConfigBool(ctx, AutoUnblockConfigKey, false); !on || err != nil {
+		t.Errorf("Expected true, got %v (err %v)", on, err)
+	}
+
+	// Values stored before validation existed still error cleanly
+	if _, err := store.db().ExecContext(ctx, `UPDATE config SET value = 'lots' WHERE key = ?`, WIPLimitConfigKey); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	if n, err := store.GetConfigInt(ctx, WIPLimitConfigKey, 5); n != 5 || !errors.Is(err, storage.ErrInvalidConfigValue) {
+		t.Errorf("Expected default and ErrInvalidConfigValue, got %d (err %v)", n, err)
+	}
+	if _, err := store.GetConfigBool(ctx, WIPLimitConfigKey, false); !errors.Is(err, storage.ErrInvalidConfigValue) {
+		t.Errorf("Expected ErrInvalidConfigValue, got %v", err)
+	}
+}
@@ -0,0 +1,177 @@
+// Package sqlite - critical path through an epic
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// CriticalPath returns the longest chain of blocks dependencies among the
+// open descendants of epicID (following parent-child links down through
+// nested epics), weighted by estimated_minutes, together with its total
+// estimate. The IDs are in the order the work has to happen: each issue
+// blocks the next. Issues without an estimate count as zero; closed and
+// tombstoned issues are done and left out. Ties go to the path that sorts
+// first by ID.
+//
+// A blocks cycle among the descendants makes the path unbounded, so it is
+// reported as an error wrapping ErrCycle instead.
+func (s *SQLiteStorage) CriticalPath(ctx context.Context, epicID string) ([]string, time.Duration, error) {
+	var exists bool
+	if err := s.db().QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM issues WHERE id = ?)`, epicID).Scan(&exists); err != nil {
+		return nil, 0, wrapDBError("check epic", err)
+	}
+	if !exists {
+		return nil, 0, fmt.Errorf("issue %s: %w", epicID, ErrNotFound)
+	}
+
+	// UNION (not UNION ALL) keeps a malformed parent-child cycle from recursing forever
+	rows, err := s.db().QueryContext(ctx, `
+		WITH RECURSIVE descendants(id) AS (
+			SELECT issue_id FROM dependencies WHERE depends_on_id = ? AND type = ?
+			UNION
+			SELECT d.issue_id FROM dependencies d
+			JOIN descendants ds ON d.depends_on_id = ds.id
+			WHERE d.type = ?
+		)
+		SELECT i.id, i.estimated_minutes
+		FROM issues i
+		JOIN descendants ds ON ds.id = i.id
+		WHERE i.id != ? AND i.status NOT IN (?, ?)
+	`, epicID, types.DepParentChild, types.DepParentChild, epicID, types.StatusClosed, types.StatusTombstone)
+	if err != nil {
+		return nil, 0, wrapDBError("query epic descendants", err)
+	}
+	estimates := make(map[string]int64)
+	var ids []string
+	for rows.Next() {
+		var id string
+		var minutes sql.NullInt64
+		if err := rows.Scan(&id, &minutes); err != nil {
+			_ = rows.Close()
+			return nil, 0, wrapDBError("scan epic descendant", err)
+		}
+		estimates[id] = minutes.Int64
+		ids = append(ids, id)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, 0, wrapDBError("iterate epic descendants", err)
+	}
+	if len(ids) == 0 {
+		return nil, 0, nil
+	}
+	sort.Strings(ids)
+
+	// next[y] lists the descendants that y blocks
+	next := make(map[string][]string)
+	rows, err = s.db().QueryContext(ctx, fmt.Sprintf(`
+		SELECT issue_id, depends_on_id FROM dependencies
+		WHERE type = ? AND issue_id IN (%[1]s) AND depends_on_id IN (%[1]s)
+		ORDER BY issue_id
+	`, buildPlaceholders(len(ids))), append(append([]interface{}{types.DepBlocks}, stringArgs(ids)...), stringArgs(ids)...)...)
+	if err != nil {
+		return nil, 0, wrapDBError("query epic dependencies", err)
+	}
+	for rows.Next() {
+		var issueID, blockerID string
+		if err := rows.Scan(&issueID, &blockerID); err != nil {
+			_ = rows.Close()
+			return nil, 0, wrapDBError("scan epic dependency", err)
+		}
+		next[blockerID] = append(next[blockerID], issueID)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, 0, wrapDBError("iterate epic dependencies", err)
+	}
+
+	path, minutes, err := longestPath(ids, estimates, next)
+	if err != nil {
+		return nil, 0, err
+	}
+	return path, time.Duration(minutes) * time.Minute, nil
+}
+
+// stringArgs converts ids to query arguments
+func stringArgs(ids []string) []interface{} {
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	return args
+}
+
+// longestPath finds the heaviest path through the DAG given by next, where
+// each node weighs weight[node]. ids must be sorted, which makes ties
+// deterministic. Returns an error wrapping ErrCycle if next has a cycle.
+func longestPath(ids []string, weight map[string]int64, next map[string][]string) ([]string, int64, error) {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int, len(ids))
+	best := make(map[string]int64, len(ids))       // heaviest path starting at the node
+	successor := make(map[string]string, len(ids)) // next node on that path
+	var stack []string
+
+	var visit func(id string) error
+	visit = func(id string) error {
+		switch state[id] {
+		case done:
+			return nil
+		case visiting:
+			start := 0
+			for i, onStack := range stack {
+				if onStack == id {
+					start = i
+				}
+			}
+			return fmt.Errorf("%w: %s", ErrCycle, strings.Join(append(stack[start:], id), " -> "))
+		}
+		state[id] = visiting
+		stack = append(stack, id)
+
+		succs := append([]string(nil), next[id]...)
+		sort.Strings(succs)
+		for _, succ := range succs {
+			if err := visit(succ); err != nil {
+				return err
+			}
+			if successor[id] == "" || best[succ] > best[successor[id]] {
+				successor[id] = succ
+			}
+		}
+		best[id] = weight[id]
+		if succ := successor[id]; succ != "" {
+			best[id] += best[succ]
+		}
+
+		stack = stack[:len(stack)-1]
+		state[id] = done
+		return nil
+	}
+
+	start := ""
+	for _, id := range ids {
+		if err := visit(id); err != nil {
+			return nil, 0, err
+		}
+		if start == "" || best[id] > best[start] {
+			start = id
+		}
+	}
+
+	var path []string
+	for id := start; id != ""; id = successor[id] {
+		path = append(path, id)
+	}
+	return path, best[start], nil
+}
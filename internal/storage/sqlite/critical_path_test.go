@@ -0,0 +1,86 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestCriticalPath(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	newIssue := func(title string, issueType types.IssueType, minutes int) *types.Issue {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: issueType}
+		if minutes > 0 {
+			issue.EstimatedMinutes = &minutes
+		}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		return issue
+	}
+	addDep := func(issue, dependsOn *types.Issue, depType types.DependencyType) {
+		dep := &types.Dependency{IssueID: issue.ID, DependsOnID: dependsOn.ID, Type: depType}
+		if err := store.AddDependency(ctx, dep, "test"); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+	}
+
+	epic := newIssue("Epic", types.TypeEpic, 0)
+	sub := newIssue("Sub-epic", types.TypeEpic, 0)
+	design := newIssue("Design", types.TypeTask, 60)
+	build := newIssue("Build", types.TypeTask, 240)
+	docs := newIssue("Docs", types.TypeTask, 30)
+	review := newIssue("Review", types.TypeTask, 0)
+	outside := newIssue("Outside", types.TypeTask, 10000)
+	for _, child := range []*types.Issue{sub, design, docs} {
+		addDep(child, epic, types.DepParentChild)
+	}
+	addDep(build, sub, types.DepParentChild)
+	addDep(review, sub, types.DepParentChild)
+	addDep(build, design, types.DepBlocks)
+	addDep(docs, design, types.DepBlocks)
+	addDep(review, build, types.DepBlocks)
+	addDep(design, outside, types.DepBlocks) // not a descendant, ignored
+
+	path, total, err := store.CriticalPath(ctx, epic.ID)
+	if err != nil {
+		t.Fatalf("CriticalPath failed: %v", err)
+	}
+	if want := []string{design.ID, build.ID, review.ID}; !reflect.DeepEqual(path, want) {
+		t.Errorf("Expected path %v, got %v", want, path)
+	}
+	if total != 300*time.Minute {
+		t.Errorf("Expected 5h total, got %v", total)
+	}
+
+	// Closed work drops out of the path
+	if err := store.CloseIssue(ctx, design.ID, "done", "test"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	path, total, err = store.CriticalPath(ctx, epic.ID)
+	if err != nil {
+		t.Fatalf("CriticalPath failed: %v", err)
+	}
+	if want := []string{build.ID, review.ID}; !reflect.DeepEqual(path, want) || total != 240*time.Minute {
+		t.Errorf("Expected path %v (4h), got %v (%v)", want, path, total)
+	}
+
+	// Cycles are reported, not followed
+	if _, err := store.db().ExecContext(ctx, `INSERT INTO dependencies (issue_id, depends_on_id, type, created_by) VALUES (?, ?, ?, ?)`,
+		build.ID, review.ID, types.DepBlocks, "test"); err != nil {
+		t.Fatalf("failed to insert cycle: %v", err)
+	}
+	if _, _, err := store.CriticalPath(ctx, epic.ID); !errors.Is(err, ErrCycle) {
+		t.Errorf("Expected ErrCycle, got %v", err)
+	}
+
+	if _, _, err := store.CriticalPath(ctx, "bd-missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
@@ -28,7 +28,7 @@ func TestDetectCyclesSimple(t *testing.T) {
 
 	// Manually create a cycle by inserting directly into dependencies table
 	// (bypassing AddDependency's cycle prevention)
-	_, err := store.db.ExecContext(ctx, `
+	_, err := store.db().ExecContext(ctx, `
 		INSERT INTO dependencies (issue_id, depends_on_id, type, created_by, created_at)
 		VALUES (?, ?, ?, 'test-user', CURRENT_TIMESTAMP)
 	`, issue1.ID, issue2.ID, types.DepBlocks)
@@ -36,7 +36,7 @@ func TestDetectCyclesSimple(t *testing.T) {
 		t.Fatalf("Insert dependency failed: %v", err)
 	}
 
-	_, err = store.db.ExecContext(ctx, `
+	_, err = store.db().ExecContext(ctx, `
 		INSERT INTO dependencies (issue_id, depends_on_id, type, created_by, created_at)
 		VALUES (?, ?, ?, 'test-user', CURRENT_TIMESTAMP)
 	`, issue2.ID, issue1.ID, types.DepBlocks)
@@ -99,7 +99,7 @@ func TestDetectCyclesComplex(t *testing.T) {
 	// Create cycle: 0→1→2→3→0
 	for i := 0; i < 4; i++ {
 		nextIdx := (i + 1) % 4
-		_, err := store.db.ExecContext(ctx, `
+		_, err := store.db().ExecContext(ctx, `
 			INSERT INTO dependencies (issue_id, depends_on_id, type, created_by, created_at)
 			VALUES (?, ?, ?, 'test-user', CURRENT_TIMESTAMP)
 		`, issues[i].ID, issues[nextIdx].ID, types.DepBlocks)
@@ -150,7 +150,7 @@ func TestDetectCyclesSelfLoop(t *testing.T) {
 	}
 
 	// Create self-loop
-	_, err := store.db.ExecContext(ctx, `
+	_, err := store.db().ExecContext(ctx, `
 		INSERT INTO dependencies (issue_id, depends_on_id, type, created_by, created_at)
 		VALUES (?, ?, ?, 'test-user', CURRENT_TIMESTAMP)
 	`, issue.ID, issue.ID, types.DepBlocks)
@@ -215,14 +215,14 @@ func TestDetectCyclesMultipleIndependent(t *testing.T) {
 	}
 
 	// Create first cycle: 0→1→0
-	_, err := store.db.ExecContext(ctx, `
+	_, err := store.db().ExecContext(ctx, `
 		INSERT INTO dependencies (issue_id, depends_on_id, type, created_by, created_at)
 		VALUES (?, ?, ?, 'test-user', CURRENT_TIMESTAMP)
 	`, cycle1[0].ID, cycle1[1].ID, types.DepBlocks)
 	if err != nil {
 		t.Fatalf("Insert dependency failed: %v", err)
 	}
-	_, err = store.db.ExecContext(ctx, `
+	_, err = store.db().ExecContext(ctx, `
 		INSERT INTO dependencies (issue_id, depends_on_id, type, created_by, created_at)
 		VALUES (?, ?, ?, 'test-user', CURRENT_TIMESTAMP)
 	`, cycle1[1].ID, cycle1[0].ID, types.DepBlocks)
@@ -231,14 +231,14 @@ func TestDetectCyclesMultipleIndependent(t *testing.T) {
 	}
 
 	// Create second cycle: 0→1→0
-	_, err = store.db.ExecContext(ctx, `
+	_, err = store.db().ExecContext(ctx, `
 		INSERT INTO dependencies (issue_id, depends_on_id, type, created_by, created_at)
 		VALUES (?, ?, ?, 'test-user', CURRENT_TIMESTAMP)
 	`, cycle2[0].ID, cycle2[1].ID, types.DepBlocks)
 	if err != nil {
 		t.Fatalf("Insert dependency failed: %v", err)
 	}
-	_, err = store.db.ExecContext(ctx, `
+	_, err = store.db().ExecContext(ctx, `
 		INSERT INTO dependencies (issue_id, depends_on_id, type, created_by, created_at)
 		VALUES (?, ?, ?, 'test-user', CURRENT_TIMESTAMP)
 	`, cycle2[1].ID, cycle2[0].ID, types.DepBlocks)
@@ -297,7 +297,7 @@ func TestDetectCyclesAcyclicGraph(t *testing.T) {
 
 	// Create chain: 0→1→2→3 (no cycle)
 	for i := 0; i < 3; i++ {
-		_, err := store.db.ExecContext(ctx, `
+		_, err := store.db().ExecContext(ctx, `
 			INSERT INTO dependencies (issue_id, depends_on_id, type, created_by, created_at)
 			VALUES (?, ?, ?, 'test-user', CURRENT_TIMESTAMP)
 		`, issues[i].ID, issues[i+1].ID, types.DepBlocks)
@@ -384,7 +384,7 @@ func TestDetectCyclesDiamond(t *testing.T) {
 	// Create dependencies: A→B, A→C, B→D, C→D
 	deps := [][2]int{{0, 1}, {0, 2}, {1, 3}, {2, 3}}
 	for _, dep := range deps {
-		_, err := store.db.ExecContext(ctx, `
+		_, err := store.db().ExecContext(ctx, `
 			INSERT INTO dependencies (issue_id, depends_on_id, type, created_by, created_at)
 			VALUES (?, ?, ?, 'test-user', CURRENT_TIMESTAMP)
 		`, issues[dep[0]].ID, issues[dep[1]].ID, types.DepBlocks)
@@ -429,7 +429,7 @@ func TestDetectCyclesLongCycle(t *testing.T) {
 	// Create cycle: 0→1→2→...→9→0
 	for i := 0; i < cycleLength; i++ {
 		nextIdx := (i + 1) % cycleLength
-		_, err := store.db.ExecContext(ctx, `
+		_, err := store.db().ExecContext(ctx, `
 			INSERT INTO dependencies (issue_id, depends_on_id, type, created_by, created_at)
 			VALUES (?, ?, ?, 'test-user', CURRENT_TIMESTAMP)
 		`, issues[i].ID, issues[nextIdx].ID, types.DepBlocks)
@@ -480,7 +480,7 @@ func TestDetectCyclesMixedTypes(t *testing.T) {
 	depTypes := []types.DependencyType{types.DepBlocks, types.DepRelated, types.DepParentChild}
 	for i := 0; i < 3; i++ {
 		nextIdx := (i + 1) % 3
-		_, err := store.db.ExecContext(ctx, `
+		_, err := store.db().ExecContext(ctx, `
 			INSERT INTO dependencies (issue_id, depends_on_id, type, created_by, created_at)
 			VALUES (?, ?, ?, 'test-user', CURRENT_TIMESTAMP)
 		`, issues[i].ID, issues[nextIdx].ID, depTypes[i])
@@ -0,0 +1,96 @@
+// Package sqlite - per-project defaults for new issues
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// createDefaults holds the configured defaults for new issues. Zero values
+// mean "not configured".
+type createDefaults struct {
+	priority  *int
+	issueType types.IssueType
+	labels    []string
+}
+
+// loadCreateDefaults reads the creation defaults on q. Invalid values are
+// ignored so a bad config entry falls back to the built-in defaults instead
+// of blocking every create.
+func loadCreateDefaults(ctx context.Context, q queryer) (createDefaults, error) {
+	var d createDefaults
+	rows, err := q.QueryContext(ctx, `SELECT key, value FROM config WHERE key IN (?, ?, ?)`,
+		DefaultPriorityConfigKey, DefaultIssueTypeConfigKey, DefaultLabelsConfigKey)
+	if err != nil {
+		return d, wrapDBError("get creation defaults", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return d, wrapDBError("scan creation defaults", err)
+		}
+		value = strings.TrimSpace(value)
+		switch key {
+		case DefaultPriorityConfigKey:
+			p, err := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(value), "P"))
+			if err == nil && p >= minPriority && p <= maxPriority {
+				d.priority = &p
+			}
+		case DefaultIssueTypeConfigKey:
+			if t := types.IssueType(strings.ToLower(value)); t.IsValid() {
+				d.issueType = t
+			}
+		case DefaultLabelsConfigKey:
+			d.labels = parseCustomStatuses(value)
+		}
+	}
+	return d, wrapDBError("iterate creation defaults", rows.Err())
+}
+
+// apply fills the fields of issue the caller left unset: a PriorityUnset
+// priority, an empty issue type and nil labels. An empty but non-nil Labels
+// slice means "no labels" and is kept. Defaults are copied onto the issue, so
+// later config changes don't affect issues that already exist.
+func (d createDefaults) apply(issue *types.Issue) {
+	if issue.Priority == types.PriorityUnset {
+		issue.Priority = types.DefaultPriority
+		if d.priority != nil {
+			issue.Priority = *d.priority
+		}
+	}
+	if issue.IssueType == "" {
+		issue.IssueType = types.DefaultIssueType
+		if d.issueType != "" {
+			issue.IssueType = d.issueType
+		}
+	}
+	if issue.Labels == nil && len(d.labels) > 0 {
+		issue.Labels = append([]string(nil), d.labels...)
+	}
+}
+
+// applyCreateDefaults loads the creation defaults on q and applies them to issue
+func applyCreateDefaults(ctx context.Context, q queryer, issue *types.Issue) error {
+	d, err := loadCreateDefaults(ctx, q)
+	if err != nil {
+		return err
+	}
+	d.apply(issue)
+	return nil
+}
+
+// insertIssueLabels stores the labels an issue was created with
+func insertIssueLabels(ctx context.Context, exec execer, issue *types.Issue) error {
+	for _, label := range issue.Labels {
+		if _, err := exec.ExecContext(ctx, `INSERT OR IGNORE INTO labels (issue_id, label) VALUES (?, ?)`, issue.ID, label); err != nil {
+			return fmt.Errorf("failed to add label %s: %w", label, err)
+		}
+	}
+	return nil
+}
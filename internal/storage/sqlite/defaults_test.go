@@ -0,0 +1,126 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestCreateIssueDefaults(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	// Built-in defaults without any config
+	issue := &types.Issue{Title: "Plain", Status: types.StatusOpen, Priority: types.PriorityUnset}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if issue.Priority != types.DefaultPriority || issue.IssueType != types.DefaultIssueType {
+		t.Errorf("Expected P%d %s, got P%d %s", types.DefaultPriority, types.DefaultIssueType, issue.Priority, issue.IssueType)
+	}
+
+	for key, value := range map[string]string{
+		DefaultPriorityConfigKey:  "P1",
+		DefaultIssueTypeConfigKey: "bug",
+		DefaultLabelsConfigKey:    "triage, backend",
+	} {
+		if err := store.SetConfig(ctx, key, value); err != nil {
+			t.Fatalf("SetConfig failed: %v", err)
+		}
+	}
+
+	defaulted := &types.Issue{Title: "Defaulted", Status: types.StatusOpen, Priority: types.PriorityUnset}
+	if err := store.CreateIssue(ctx, defaulted, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	got, err := store.GetIssue(ctx, defaulted.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.Priority != 1 || got.IssueType != types.TypeBug {
+		t.Errorf("Expected P1 bug, got P%d %s", got.Priority, got.IssueType)
+	}
+	labels, err := store.GetLabels(ctx, defaulted.ID)
+	if err != nil {
+		t.Fatalf("GetLabels failed: %v", err)
+	}
+	if !reflect.DeepEqual(labels, []string{"backend", "triage"}) {
+		t.Errorf("Expected default labels, got %v", labels)
+	}
+
+	// Explicit values win, including P0 and an explicit empty label set
+	explicit := &types.Issue{Title: "Explicit", Status: types.StatusOpen, Priority: 0, IssueType: types.TypeFeature, Labels: []string{}}
+	if err := store.CreateIssue(ctx, explicit, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	got, err = store.GetIssue(ctx, explicit.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.Priority != 0 || got.IssueType != types.TypeFeature {
+		t.Errorf("Expected P0 feature, got P%d %s", got.Priority, got.IssueType)
+	}
+	labels, err = store.GetLabels(ctx, explicit.ID)
+	if err != nil {
+		t.Fatalf("GetLabels failed: %v", err)
+	}
+	if len(labels) != 0 {
+		t.Errorf("Expected no labels, got %v", labels)
+	}
+
+	// Changing the defaults leaves existing issues alone
+	if err := store.SetConfig(ctx, DefaultPriorityConfigKey, "4"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	got, err = store.GetIssue(ctx, defaulted.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.Priority != 1 {
+		t.Errorf("Expected existing issue to keep P1, got P%d", got.Priority)
+	}
+
+	// SetConfig refuses invalid values, and any already stored fall back to
+	// the built-in defaults
+	if err := store.SetConfig(ctx, DefaultIssueTypeConfigKey, "story"); !errors.Is(err, storage.ErrInvalidConfigValue) {
+		t.Fatalf("Expected ErrInvalidConfigValue, got %v", err)
+	}
+	if _, err := store.db().ExecContext(ctx, `INSERT OR REPLACE INTO config (key, value) VALUES (?, ?)`, DefaultIssueTypeConfigKey, "story"); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	fallback := &types.Issue{Title: "Fallback", Status: types.StatusOpen, Priority: types.PriorityUnset}
+	if err := store.CreateIssue(ctx, fallback, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if fallback.Priority != 4 || fallback.IssueType != types.DefaultIssueType {
+		t.Errorf("Expected P4 %s, got P%d %s", types.DefaultIssueType, fallback.Priority, fallback.IssueType)
+	}
+}
+
+func TestCreateIssueDefaultsInTransaction(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	if err := store.SetConfig(ctx, DefaultLabelsConfigKey, "triage"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+
+	issue := &types.Issue{Title: "In tx", Status: types.StatusOpen, Priority: types.PriorityUnset}
+	err := store.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		return tx.CreateIssue(ctx, issue, "test")
+	})
+	if err != nil {
+		t.Fatalf("RunInTransaction failed: %v", err)
+	}
+	labels, err := store.GetLabels(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetLabels failed: %v", err)
+	}
+	if !reflect.DeepEqual(labels, []string{"triage"}) {
+		t.Errorf("Expected [triage], got %v", labels)
+	}
+}
@@ -4,7 +4,9 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"io"
 	"strings"
 	"time"
 
@@ -17,6 +19,75 @@ const (
 	maxDependencyDepth = 100
 )
 
+// CycleError is returned by AddDependency when the new edge would close a
+// dependency cycle. It matches ErrCycle with errors.Is.
+type CycleError struct {
+	// Path is the cycle the edge would create, starting and ending with the
+	// dependent issue: [A, B, A] for A depends on B when B already depends
+	// on A, and [A, A] for a self-dependency
+	Path []string
+}
+
+func (e *CycleError) Error() string {
+	if len(e.Path) == 2 && e.Path[0] == e.Path[1] {
+		return fmt.Sprintf("issue %s cannot depend on itself", e.Path[0])
+	}
+	return fmt.Sprintf("cannot add dependency: would create a cycle (%s)", strings.Join(e.Path, " → "))
+}
+
+// Is reports whether target is ErrCycle
+func (e *CycleError) Is(target error) bool {
+	return target == ErrCycle
+}
+
+// checkNewDependencyCycle returns a *CycleError if adding "issueID depends on
+// dependsOnID" would create a cycle, i.e. if dependsOnID already reaches
+// issueID through dependencies of any type, following them at most
+// maxDependencyDepth deep. Run it on the transaction that inserts the edge so
+// a concurrent insert can't complete a cycle in between.
+func checkNewDependencyCycle(ctx context.Context, q queryer, issueID, dependsOnID string) error {
+	if issueID == dependsOnID {
+		return &CycleError{Path: []string{issueID, issueID}}
+	}
+
+	// Track the path as a string to work around SQLite's lack of arrays, as
+	// DetectCycles does, and don't revisit issues already on it
+	var path string
+	err := q.QueryRowContext(ctx, `
+		WITH RECURSIVE paths AS (
+			SELECT
+				depends_on_id,
+				issue_id || '→' || depends_on_id as path,
+				1 as depth
+			FROM dependencies
+			WHERE issue_id = ?
+
+			UNION ALL
+
+			SELECT
+				d.depends_on_id,
+				p.path || '→' || d.depends_on_id,
+				p.depth + 1
+			FROM dependencies d
+			JOIN paths p ON d.issue_id = p.depends_on_id
+			WHERE p.depth < ?
+			AND p.depends_on_id != ?
+			AND instr('→' || p.path || '→', '→' || d.depends_on_id || '→') = 0
+		)
+		SELECT path FROM paths
+		WHERE depends_on_id = ?
+		ORDER BY depth
+		LIMIT 1
+	`, dependsOnID, maxDependencyDepth, issueID, issueID).Scan(&path)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check for cycles: %w", err)
+	}
+	return &CycleError{Path: append([]string{issueID}, strings.Split(path, "→")...)}
+}
+
 // AddDependency adds a dependency between issues with cycle prevention
 func (s *SQLiteStorage) AddDependency(ctx context.Context, dep *types.Dependency, actor string) error {
 	// Validate dependency type
@@ -43,7 +114,7 @@ func (s *SQLiteStorage) AddDependency(ctx context.Context, dep *types.Dependency
 
 	// Prevent self-dependency
 	if dep.IssueID == dep.DependsOnID {
-		return fmt.Errorf("issue cannot depend on itself")
+		return &CycleError{Path: []string{dep.IssueID, dep.IssueID}}
 	}
 
 	// Validate parent-child dependency direction
@@ -84,61 +155,31 @@ func (s *SQLiteStorage) AddDependency(ctx context.Context, dep *types.Dependency
 	//    on B and B depends on A (directly or through other issues), which should be done first?
 	//
 	// Implementation: We use a recursive CTE to traverse from DependsOnID to see if we can
-	// reach IssueID. If yes, adding "IssueID depends on DependsOnID" would complete a cycle.
-	// We check ALL dependency types because cross-type cycles (e.g., A blocks B, B parent-child A)
-	// are just as problematic as single-type cycles.
+	// reach IssueID. If yes, adding "IssueID depends on DependsOnID" would complete a cycle,
+	// reported as a *CycleError with the path. We check ALL dependency types because
+	// cross-type cycles (e.g., A blocks B, B parent-child A) are just as problematic as
+	// single-type cycles.
 	//
 	// The traversal is depth-limited to maxDependencyDepth (100) to prevent infinite loops
 	// and excessive query cost. We check before inserting to avoid unnecessary write on failure.
-	var cycleExists bool
-	err = tx.QueryRowContext(ctx, `
-		WITH RECURSIVE paths AS (
-			SELECT
-				issue_id,
-				depends_on_id,
-				1 as depth
-			FROM dependencies
-			WHERE issue_id = ?
-
-			UNION ALL
-
-			SELECT
-				d.issue_id,
-				d.depends_on_id,
-				p.depth + 1
-			FROM dependencies d
-			JOIN paths p ON d.issue_id = p.depends_on_id
-			WHERE p.depth < ?
-		)
-		SELECT EXISTS(
-			SELECT 1 FROM paths
-			WHERE depends_on_id = ?
-		)
-	`, dep.DependsOnID, maxDependencyDepth, dep.IssueID).Scan(&cycleExists)
-
-	if err != nil {
-		return fmt.Errorf("failed to check for cycles: %w", err)
-	}
-
-	if cycleExists {
-		return fmt.Errorf("cannot add dependency: would create a cycle (%s → %s → ... → %s)",
-			dep.IssueID, dep.DependsOnID, dep.IssueID)
+	if err := checkNewDependencyCycle(ctx, tx, dep.IssueID, dep.DependsOnID); err != nil {
+		return err
 	}
 
 	// Insert dependency
 	_, err = tx.ExecContext(ctx, `
-		INSERT INTO dependencies (issue_id, depends_on_id, type, created_at, created_by)
-		VALUES (?, ?, ?, ?, ?)
-	`, dep.IssueID, dep.DependsOnID, dep.Type, dep.CreatedAt, dep.CreatedBy)
+		INSERT INTO dependencies (issue_id, depends_on_id, type, created_at, created_by, note)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, dep.IssueID, dep.DependsOnID, dep.Type, dep.CreatedAt, dep.CreatedBy, dep.Note)
 	if err != nil {
 		return fmt.Errorf("failed to add dependency: %w", err)
 	}
 
-	// Record event
+	// Record event (new_value holds the edge so Undo can remove it)
 	_, err = tx.ExecContext(ctx, `
-		INSERT INTO events (issue_id, event_type, actor, comment)
-		VALUES (?, ?, ?, ?)
-	`, dep.IssueID, types.EventDependencyAdded, actor,
+		INSERT INTO events (issue_id, event_type, actor, new_value, comment)
+		VALUES (?, ?, ?, ?, ?)
+	`, dep.IssueID, types.EventDependencyAdded, actor, depEdgeJSON(dep.IssueID, dep.DependsOnID, dep.Type),
 		fmt.Sprintf("Added dependency: %s %s %s", dep.IssueID, dep.Type, dep.DependsOnID))
 	if err != nil {
 		return fmt.Errorf("failed to record event: %w", err)
@@ -153,7 +194,7 @@ func (s *SQLiteStorage) AddDependency(ctx context.Context, dep *types.Dependency
 		// Invalidate blocked issues cache since dependencies changed (bd-5qim)
 		// Only invalidate for 'blocks' and 'parent-child' types since they affect blocking
 		if dep.Type == types.DepBlocks || dep.Type == types.DepParentChild {
-			if err := s.invalidateBlockedCache(ctx, tx); err != nil {
+			if err := s.invalidateBlockedCache(ctx, tx, dep.IssueID); err != nil {
 				return fmt.Errorf("failed to invalidate blocked cache: %w", err)
 			}
 		}
@@ -193,10 +234,11 @@ func (s *SQLiteStorage) RemoveDependency(ctx context.Context, issueID, dependsOn
 			return fmt.Errorf("dependency from %s to %s does not exist", issueID, dependsOnID)
 		}
 
+		// old_value holds the removed edge so Undo can restore it
 		_, err = tx.ExecContext(ctx, `
-			INSERT INTO events (issue_id, event_type, actor, comment)
-			VALUES (?, ?, ?, ?)
-		`, issueID, types.EventDependencyRemoved, actor,
+			INSERT INTO events (issue_id, event_type, actor, old_value, comment)
+			VALUES (?, ?, ?, ?, ?)
+		`, issueID, types.EventDependencyRemoved, actor, depEdgeJSON(issueID, dependsOnID, depType),
 			fmt.Sprintf("Removed dependency on %s", dependsOnID))
 		if err != nil {
 			return fmt.Errorf("failed to record event: %w", err)
@@ -209,7 +251,7 @@ func (s *SQLiteStorage) RemoveDependency(ctx context.Context, issueID, dependsOn
 
 		// Invalidate blocked issues cache if this was a blocking dependency (bd-5qim)
 		if needsCacheInvalidation {
-			if err := s.invalidateBlockedCache(ctx, tx); err != nil {
+			if err := s.invalidateBlockedCache(ctx, tx, issueID); err != nil {
 				return fmt.Errorf("failed to invalidate blocked cache: %w", err)
 			}
 		}
@@ -220,44 +262,60 @@ func (s *SQLiteStorage) RemoveDependency(ctx context.Context, issueID, dependsOn
 
 // GetDependenciesWithMetadata returns issues that this issue depends on, including dependency type
 func (s *SQLiteStorage) GetDependenciesWithMetadata(ctx context.Context, issueID string) ([]*types.IssueWithDependencyMetadata, error) {
-	rows, err := s.db.QueryContext(ctx, `
+	return dependenciesWithMetadata(ctx, s.db(), issueID)
+}
+
+// dependenciesWithMetadata runs GetDependenciesWithMetadata's query on q
+func dependenciesWithMetadata(ctx context.Context, q queryer, issueID string) ([]*types.IssueWithDependencyMetadata, error) {
+	visibleSQL, args := visibleEdgeClause(ctx, "d.issue_id", "i.id")
+	args = append([]interface{}{issueID}, args...)
+	// #nosec G201 - visibleSQL is built by visibleClause
+	rows, err := q.QueryContext(ctx, fmt.Sprintf(`
 		SELECT i.id, i.content_hash, i.title, i.description, i.design, i.acceptance_criteria, i.notes,
 		       i.status, i.priority, i.issue_type, i.assignee, i.estimated_minutes,
 		       i.created_at, i.updated_at, i.closed_at, i.external_ref, i.source_repo,
-		       i.deleted_at, i.deleted_by, i.delete_reason, i.original_type,
+		       i.deleted_at, i.deleted_by, i.delete_reason, i.original_type, i.draft, i.percent_complete, i.external_blocked_reason, i.snoozed_until, i.due_date, i.version,
 		       d.type
 		FROM issues i
 		JOIN dependencies d ON i.id = d.depends_on_id
-		WHERE d.issue_id = ?
+		WHERE d.issue_id = ?%s
 		ORDER BY i.priority ASC
-	`, issueID)
+	`, visibleSQL), args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get dependencies with metadata: %w", err)
 	}
 	defer func() { _ = rows.Close() }()
 
-	return s.scanIssuesWithDependencyType(ctx, rows)
+	return scanIssuesWithDependencyType(ctx, q, rows)
 }
 
 // GetDependentsWithMetadata returns issues that depend on this issue, including dependency type
 func (s *SQLiteStorage) GetDependentsWithMetadata(ctx context.Context, issueID string) ([]*types.IssueWithDependencyMetadata, error) {
-	rows, err := s.db.QueryContext(ctx, `
+	return dependentsWithMetadata(ctx, s.db(), issueID)
+}
+
+// dependentsWithMetadata runs GetDependentsWithMetadata's query on q
+func dependentsWithMetadata(ctx context.Context, q queryer, issueID string) ([]*types.IssueWithDependencyMetadata, error) {
+	visibleSQL, args := visibleEdgeClause(ctx, "d.depends_on_id", "i.id")
+	args = append([]interface{}{issueID}, args...)
+	// #nosec G201 - visibleSQL is built by visibleClause
+	rows, err := q.QueryContext(ctx, fmt.Sprintf(`
 		SELECT i.id, i.content_hash, i.title, i.description, i.design, i.acceptance_criteria, i.notes,
 		       i.status, i.priority, i.issue_type, i.assignee, i.estimated_minutes,
 		       i.created_at, i.updated_at, i.closed_at, i.external_ref, i.source_repo,
-		       i.deleted_at, i.deleted_by, i.delete_reason, i.original_type,
+		       i.deleted_at, i.deleted_by, i.delete_reason, i.original_type, i.draft, i.percent_complete, i.external_blocked_reason, i.snoozed_until, i.due_date, i.version,
 		       d.type
 		FROM issues i
 		JOIN dependencies d ON i.id = d.issue_id
-		WHERE d.depends_on_id = ?
+		WHERE d.depends_on_id = ?%s
 		ORDER BY i.priority ASC
-	`, issueID)
+	`, visibleSQL), args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get dependents with metadata: %w", err)
 	}
 	defer func() { _ = rows.Close() }()
 
-	return s.scanIssuesWithDependencyType(ctx, rows)
+	return scanIssuesWithDependencyType(ctx, q, rows)
 }
 
 // GetDependencies returns issues that this issue depends on
@@ -266,13 +324,7 @@ func (s *SQLiteStorage) GetDependencies(ctx context.Context, issueID string) ([]
 	if err != nil {
 		return nil, err
 	}
-
-	// Convert to plain Issue slice for backward compatibility
-	issues := make([]*types.Issue, len(issuesWithMeta))
-	for i, iwm := range issuesWithMeta {
-		issues[i] = &iwm.Issue
-	}
-	return issues, nil
+	return plainIssues(issuesWithMeta), nil
 }
 
 // GetDependents returns issues that depend on this issue
@@ -281,13 +333,17 @@ func (s *SQLiteStorage) GetDependents(ctx context.Context, issueID string) ([]*t
 	if err != nil {
 		return nil, err
 	}
+	return plainIssues(issuesWithMeta), nil
+}
 
-	// Convert to plain Issue slice for backward compatibility
+// plainIssues converts issues with dependency metadata to a plain Issue
+// slice, for backward compatibility
+func plainIssues(issuesWithMeta []*types.IssueWithDependencyMetadata) []*types.Issue {
 	issues := make([]*types.Issue, len(issuesWithMeta))
 	for i, iwm := range issuesWithMeta {
 		issues[i] = &iwm.Issue
 	}
-	return issues, nil
+	return issues
 }
 
 // GetDependencyCounts returns dependency and dependent counts for multiple issues in a single query
@@ -331,7 +387,7 @@ func (s *SQLiteStorage) GetDependencyCounts(ctx context.Context, issueIDs []stri
 		GROUP BY issue_id
 	`, inClause, inClause)
 
-	rows, err := s.db.QueryContext(ctx, query, args...)
+	rows, err := s.db().QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get dependency counts: %w", err)
 	}
@@ -366,12 +422,15 @@ func (s *SQLiteStorage) GetDependencyCounts(ctx context.Context, issueIDs []stri
 
 // GetDependencyRecords returns raw dependency records for an issue
 func (s *SQLiteStorage) GetDependencyRecords(ctx context.Context, issueID string) ([]*types.Dependency, error) {
-	rows, err := s.db.QueryContext(ctx, `
-		SELECT issue_id, depends_on_id, type, created_at, created_by
+	visibleSQL, args := visibleEdgeClause(ctx, "issue_id", "depends_on_id")
+	args = append([]interface{}{issueID}, args...)
+	// #nosec G201 - visibleSQL is built by visibleClause
+	rows, err := s.db().QueryContext(ctx, fmt.Sprintf(`
+		SELECT issue_id, depends_on_id, type, created_at, created_by, note
 		FROM dependencies
-		WHERE issue_id = ?
+		WHERE issue_id = ?%s
 		ORDER BY created_at ASC
-	`, issueID)
+	`, visibleSQL), args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get dependency records: %w", err)
 	}
@@ -386,6 +445,7 @@ func (s *SQLiteStorage) GetDependencyRecords(ctx context.Context, issueID string
 			&dep.Type,
 			&dep.CreatedAt,
 			&dep.CreatedBy,
+			&dep.Note,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan dependency: %w", err)
@@ -399,11 +459,14 @@ func (s *SQLiteStorage) GetDependencyRecords(ctx context.Context, issueID string
 // GetAllDependencyRecords returns all dependency records grouped by issue ID
 // This is optimized for bulk export operations to avoid N+1 queries
 func (s *SQLiteStorage) GetAllDependencyRecords(ctx context.Context) (map[string][]*types.Dependency, error) {
-	rows, err := s.db.QueryContext(ctx, `
-		SELECT issue_id, depends_on_id, type, created_at, created_by
+	visibleSQL, args := visibleEdgeClause(ctx, "issue_id", "depends_on_id")
+	// #nosec G201 - visibleSQL is built by visibleClause
+	rows, err := s.db().QueryContext(ctx, fmt.Sprintf(`
+		SELECT issue_id, depends_on_id, type, created_at, created_by, note
 		FROM dependencies
+		WHERE 1 = 1%s
 		ORDER BY issue_id, created_at ASC
-	`)
+	`, visibleSQL), args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get all dependency records: %w", err)
 	}
@@ -419,6 +482,7 @@ func (s *SQLiteStorage) GetAllDependencyRecords(ctx context.Context) (map[string
 			&dep.Type,
 			&dep.CreatedAt,
 			&dep.CreatedBy,
+			&dep.Note,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan dependency: %w", err)
@@ -429,12 +493,73 @@ func (s *SQLiteStorage) GetAllDependencyRecords(ctx context.Context) (map[string
 	return depsMap, nil
 }
 
+// ExportDependencies returns every dependency edge in the database, of all
+// relation types, ordered by (from, to, type) so repeated exports are identical.
+func (s *SQLiteStorage) ExportDependencies(ctx context.Context) ([]types.DepEdge, error) {
+	var edges []types.DepEdge
+	err := s.forEachDependencyEdge(ctx, func(edge types.DepEdge) error {
+		edges = append(edges, edge)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return edges, nil
+}
+
+// ExportDependenciesJSONL streams every dependency edge to w as one JSON
+// object per line, in the same order as ExportDependencies. Edges are written
+// as they are read, so the full graph is never held in memory.
+func (s *SQLiteStorage) ExportDependenciesJSONL(ctx context.Context, w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	return s.forEachDependencyEdge(ctx, func(edge types.DepEdge) error {
+		if err := encoder.Encode(edge); err != nil {
+			return fmt.Errorf("failed to write dependency edge %s -> %s: %w", edge.From, edge.To, err)
+		}
+		return nil
+	})
+}
+
+// forEachDependencyEdge calls fn for each dependency edge in deterministic order,
+// stopping at the first error.
+func (s *SQLiteStorage) forEachDependencyEdge(ctx context.Context, fn func(types.DepEdge) error) error {
+	visibleSQL, args := visibleEdgeClause(ctx, "issue_id", "depends_on_id")
+	// #nosec G201 - visibleSQL is built by visibleClause
+	rows, err := s.db().QueryContext(ctx, fmt.Sprintf(`
+		SELECT issue_id, depends_on_id, type
+		FROM dependencies
+		WHERE 1 = 1%s
+		ORDER BY issue_id, depends_on_id, type
+	`, visibleSQL), args...)
+	if err != nil {
+		return fmt.Errorf("failed to export dependencies: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var edge types.DepEdge
+		if err := rows.Scan(&edge.From, &edge.To, &edge.Type); err != nil {
+			return fmt.Errorf("failed to scan dependency edge: %w", err)
+		}
+		if err := fn(edge); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate dependency edges: %w", err)
+	}
+	return nil
+}
+
 // GetDependencyTree returns the full dependency tree with optional deduplication
 // When showAllPaths is false (default), nodes appearing via multiple paths (diamond dependencies)
 // appear only once at their shallowest depth in the tree.
 // When showAllPaths is true, all paths are shown with duplicate nodes at different depths.
 // When reverse is true, shows dependent tree (what was discovered from this) instead of dependency tree (what blocks this).
 func (s *SQLiteStorage) GetDependencyTree(ctx context.Context, issueID string, maxDepth int, showAllPaths bool, reverse bool) ([]*types.TreeNode, error) {
+	ctx, cancel := s.withQueryTimeout(ctx)
+	defer cancel()
+
 	if maxDepth <= 0 {
 		maxDepth = 50
 	}
@@ -527,11 +652,19 @@ func (s *SQLiteStorage) GetDependencyTree(ctx context.Context, issueID string, m
 		`
 	}
 
+	args := []interface{}{issueID, maxDepth}
+	if visible, visibleArgs := visibleClause(ctx, "i.id"); visible != "" {
+		// Neither start from nor walk through issues the requesting actor can't see
+		query = strings.Replace(query, "WHERE i.id = ?", "WHERE i.id = ? AND "+visible, 1)
+		query = strings.Replace(query, "WHERE t.depth < ?", "WHERE t.depth < ? AND "+visible, 1)
+		args = append(append(append([]interface{}{issueID}, visibleArgs...), maxDepth), visibleArgs...)
+	}
+
 	// First, build the complete tree with all paths using recursive CTE
 	// We need to track the full path to handle proper tree structure
-	rows, err := s.db.QueryContext(ctx, query, issueID, maxDepth)
+	rows, err := s.db().QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get dependency tree: %w", err)
+		return nil, withContextError(ctx, fmt.Errorf("failed to get dependency tree: %w", err))
 	}
 	defer func() { _ = rows.Close() }()
 
@@ -558,6 +691,9 @@ func (s *SQLiteStorage) GetDependencyTree(ctx context.Context, issueID string, m
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan tree node: %w", err)
 		}
+		if err := decodeDescription(&node.Description); err != nil {
+			return nil, err
+		}
 		node.ParentID = parentID
 
 		if closedAt.Valid {
@@ -592,15 +728,21 @@ func (s *SQLiteStorage) GetDependencyTree(ctx context.Context, issueID string, m
 		}
 		nodes = append(nodes, &node)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, withContextError(ctx, fmt.Errorf("failed to get dependency tree: %w", err))
+	}
 
 	return nodes, nil
 }
 
 // DetectCycles finds circular dependencies and returns the actual cycle paths
 func (s *SQLiteStorage) DetectCycles(ctx context.Context) ([][]*types.Issue, error) {
+	ctx, cancel := s.withQueryTimeout(ctx)
+	defer cancel()
+
 	// Use recursive CTE to find cycles with full paths
 	// We track the path as a string to work around SQLite's lack of arrays
-	rows, err := s.db.QueryContext(ctx, `
+	rows, err := s.db().QueryContext(ctx, `
 		WITH RECURSIVE paths AS (
 			SELECT
 				issue_id,
@@ -629,7 +771,7 @@ func (s *SQLiteStorage) DetectCycles(ctx context.Context) ([][]*types.Issue, err
 		ORDER BY cycle_path
 	`, maxDependencyDepth)
 	if err != nil {
-		return nil, fmt.Errorf("failed to detect cycles: %w", err)
+		return nil, withContextError(ctx, fmt.Errorf("failed to detect cycles: %w", err))
 	}
 	defer func() { _ = rows.Close() }()
 
@@ -672,12 +814,20 @@ func (s *SQLiteStorage) DetectCycles(ctx context.Context) ([][]*types.Issue, err
 			cycles = append(cycles, cycleIssues)
 		}
 	}
+	if err := rows.Err(); err != nil {
+		return nil, withContextError(ctx, fmt.Errorf("failed to detect cycles: %w", err))
+	}
 
 	return cycles, nil
 }
 
 // Helper function to scan issues from rows
 func (s *SQLiteStorage) scanIssues(ctx context.Context, rows *sql.Rows) ([]*types.Issue, error) {
+	return scanIssuesOn(ctx, s.db(), rows)
+}
+
+// scanIssuesOn is scanIssues, loading labels from q
+func scanIssuesOn(ctx context.Context, q queryer, rows *sql.Rows) ([]*types.Issue, error) {
 	var issues []*types.Issue
 	var issueIDs []string
 
@@ -701,11 +851,14 @@ func (s *SQLiteStorage) scanIssues(ctx context.Context, rows *sql.Rows) ([]*type
 			&issue.AcceptanceCriteria, &issue.Notes, &issue.Status,
 			&issue.Priority, &issue.IssueType, &assignee, &estimatedMinutes,
 			&issue.CreatedAt, &issue.UpdatedAt, &closedAt, &externalRef, &sourceRepo, &closeReason,
-			&deletedAt, &deletedBy, &deleteReason, &originalType,
+			&deletedAt, &deletedBy, &deleteReason, &originalType, &issue.Draft, &issue.PercentComplete, &issue.ExternalBlockedReason, &issue.SnoozedUntil, &issue.DueDate, &issue.Version,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan issue: %w", err)
 		}
+		if err := decodeDescription(&issue.Description); err != nil {
+			return nil, err
+		}
 
 		if contentHash.Valid {
 			issue.ContentHash = contentHash.String
@@ -743,9 +896,12 @@ func (s *SQLiteStorage) scanIssues(ctx context.Context, rows *sql.Rows) ([]*type
 		issues = append(issues, &issue)
 		issueIDs = append(issueIDs, issue.ID)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, withContextError(ctx, fmt.Errorf("failed to iterate issues: %w", err))
+	}
 
 	// Second pass: batch-load labels for all issues
-	labelsMap, err := s.GetLabelsForIssues(ctx, issueIDs)
+	labelsMap, err := labelsForIssues(ctx, q, issueIDs)
 	if err != nil {
 		return nil, fmt.Errorf("failed to batch get labels: %w", err)
 	}
@@ -760,8 +916,9 @@ func (s *SQLiteStorage) scanIssues(ctx context.Context, rows *sql.Rows) ([]*type
 	return issues, nil
 }
 
-// Helper function to scan issues with dependency type from rows
-func (s *SQLiteStorage) scanIssuesWithDependencyType(ctx context.Context, rows *sql.Rows) ([]*types.IssueWithDependencyMetadata, error) {
+// Helper function to scan issues with dependency type from rows, loading
+// their labels from q
+func scanIssuesWithDependencyType(ctx context.Context, q queryer, rows *sql.Rows) ([]*types.IssueWithDependencyMetadata, error) {
 	var results []*types.IssueWithDependencyMetadata
 	for rows.Next() {
 		var issue types.Issue
@@ -782,12 +939,15 @@ func (s *SQLiteStorage) scanIssuesWithDependencyType(ctx context.Context, rows *
 			&issue.AcceptanceCriteria, &issue.Notes, &issue.Status,
 			&issue.Priority, &issue.IssueType, &assignee, &estimatedMinutes,
 			&issue.CreatedAt, &issue.UpdatedAt, &closedAt, &externalRef, &sourceRepo,
-			&deletedAt, &deletedBy, &deleteReason, &originalType,
+			&deletedAt, &deletedBy, &deleteReason, &originalType, &issue.Draft, &issue.PercentComplete, &issue.ExternalBlockedReason, &issue.SnoozedUntil, &issue.DueDate, &issue.Version,
 			&depType,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan issue with dependency type: %w", err)
 		}
+		if err := decodeDescription(&issue.Description); err != nil {
+			return nil, err
+		}
 
 		if contentHash.Valid {
 			issue.ContentHash = contentHash.String
@@ -819,19 +979,28 @@ func (s *SQLiteStorage) scanIssuesWithDependencyType(ctx context.Context, rows *
 			issue.OriginalType = originalType.String
 		}
 
-		// Fetch labels for this issue
-		labels, err := s.GetLabels(ctx, issue.ID)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get labels for issue %s: %w", issue.ID, err)
-		}
-		issue.Labels = labels
-
 		result := &types.IssueWithDependencyMetadata{
 			Issue:          issue,
 			DependencyType: depType,
 		}
 		results = append(results, result)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, withContextError(ctx, fmt.Errorf("failed to iterate issues: %w", err))
+	}
+
+	// Batch-load labels once the rows are done
+	ids := make([]string, len(results))
+	for i, result := range results {
+		ids[i] = result.ID
+	}
+	labelsMap, err := labelsForIssues(ctx, q, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to batch get labels: %w", err)
+	}
+	for _, result := range results {
+		result.Labels = labelsMap[result.ID]
+	}
 
 	return results, nil
 }
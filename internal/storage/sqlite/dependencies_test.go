@@ -2,11 +2,14 @@ package sqlite
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -169,6 +172,7 @@ func TestAddDependencyPreservesProvidedMetadata(t *testing.T) {
 		Type:        types.DepParentChild,
 		CreatedAt:   customTime,
 		CreatedBy:   "import",
+		Note:        "shared schema",
 	}
 
 	if err := store.AddDependency(ctx, dep, "test-user"); err != nil {
@@ -189,6 +193,17 @@ func TestAddDependencyPreservesProvidedMetadata(t *testing.T) {
 	if got.CreatedBy != "import" {
 		t.Fatalf("Expected CreatedBy 'import', got %q", got.CreatedBy)
 	}
+	if got.Note != "shared schema" {
+		t.Fatalf("Expected Note 'shared schema', got %q", got.Note)
+	}
+
+	all, err := store.GetAllDependencyRecords(ctx)
+	if err != nil {
+		t.Fatalf("GetAllDependencyRecords failed: %v", err)
+	}
+	if deps := all[child.ID]; len(deps) != 1 || deps[0].Note != "shared schema" {
+		t.Fatalf("Expected note in bulk records, got %+v", deps)
+	}
 }
 
 func TestGetDependents(t *testing.T) {
@@ -464,6 +479,21 @@ func TestDetectCycles(t *testing.T) {
 	if err == nil {
 		t.Fatal("Expected error when creating cycle, but got none")
 	}
+	var cycleErr *CycleError
+	if !errors.As(err, &cycleErr) || !errors.Is(err, ErrCycle) {
+		t.Fatalf("Expected a CycleError, got %v", err)
+	}
+	if want := []string{issue3.ID, issue1.ID, issue2.ID, issue3.ID}; !reflect.DeepEqual(cycleErr.Path, want) {
+		t.Errorf("Expected cycle path %v, got %v", want, cycleErr.Path)
+	}
+
+	// Transactions run the same check
+	err = store.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		return tx.AddDependency(ctx, &types.Dependency{IssueID: issue3.ID, DependsOnID: issue1.ID, Type: types.DepRelated}, "test-user")
+	})
+	if !errors.As(err, &cycleErr) || len(cycleErr.Path) != 4 {
+		t.Errorf("Expected a CycleError from the transaction, got %v", err)
+	}
 
 	// Verify no cycles exist
 	cycles, err := store.DetectCycles(ctx)
@@ -602,6 +632,10 @@ func TestSelfDependencyPrevention(t *testing.T) {
 	if !strings.Contains(err.Error(), "cannot depend on itself") {
 		t.Errorf("Expected self-dependency error message, got: %v", err)
 	}
+	var cycleErr *CycleError
+	if !errors.As(err, &cycleErr) || !reflect.DeepEqual(cycleErr.Path, []string{issue.ID, issue.ID}) {
+		t.Errorf("Expected a self-edge CycleError, got %v", err)
+	}
 }
 
 func TestRelatedTypeCyclePrevention(t *testing.T) {
@@ -1215,3 +1249,79 @@ func TestGetDependenciesWithMetadataMultipleTypes(t *testing.T) {
 		t.Errorf("Expected discovered dependency type 'discovered-from', got %s", typeMap[discovered.ID])
 	}
 }
+
+func TestExportDependencies(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	var ids []string
+	for i := 0; i < 4; i++ {
+		issue := &types.Issue{Title: fmt.Sprintf("Issue %d", i), Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		ids = append(ids, issue.ID)
+	}
+
+	// One edge of every relation kind, added out of order
+	deps := []*types.Dependency{
+		{IssueID: ids[3], DependsOnID: ids[0], Type: types.DepRelated},
+		{IssueID: ids[1], DependsOnID: ids[0], Type: types.DepBlocks},
+		{IssueID: ids[2], DependsOnID: ids[1], Type: types.DepDiscoveredFrom},
+		{IssueID: ids[1], DependsOnID: ids[3], Type: types.DepParentChild},
+	}
+	for _, dep := range deps {
+		if err := store.AddDependency(ctx, dep, "test-user"); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+	}
+
+	edges, err := store.ExportDependencies(ctx)
+	if err != nil {
+		t.Fatalf("ExportDependencies failed: %v", err)
+	}
+	if len(edges) != len(deps) {
+		t.Fatalf("Expected %d edges, got %d", len(deps), len(edges))
+	}
+
+	seenTypes := make(map[types.DependencyType]bool)
+	for i, edge := range edges {
+		seenTypes[edge.Type] = true
+		if i == 0 {
+			continue
+		}
+		prev := edges[i-1]
+		if prev.From > edge.From || (prev.From == edge.From && prev.To > edge.To) {
+			t.Errorf("Edges not ordered: %+v before %+v", prev, edge)
+		}
+	}
+	for _, depType := range []types.DependencyType{types.DepBlocks, types.DepRelated, types.DepParentChild, types.DepDiscoveredFrom} {
+		if !seenTypes[depType] {
+			t.Errorf("Expected an edge of type %s", depType)
+		}
+	}
+
+	var buf strings.Builder
+	if err := store.ExportDependenciesJSONL(ctx, &buf); err != nil {
+		t.Fatalf("ExportDependenciesJSONL failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != len(edges) {
+		t.Fatalf("Expected %d JSONL lines, got %d", len(edges), len(lines))
+	}
+	want := fmt.Sprintf(`{"from":%q,"to":%q,"type":%q}`, edges[0].From, edges[0].To, edges[0].Type)
+	if lines[0] != want {
+		t.Errorf("Expected first line %s, got %s", want, lines[0])
+	}
+
+	// A second export must be byte-for-byte identical
+	var again strings.Builder
+	if err := store.ExportDependenciesJSONL(ctx, &again); err != nil {
+		t.Fatalf("ExportDependenciesJSONL failed: %v", err)
+	}
+	if again.String() != buf.String() {
+		t.Error("Expected repeated exports to be identical")
+	}
+}
@@ -0,0 +1,112 @@
+// Package sqlite - dependency changes across many edges
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// RemoveDependencies removes every dependency edge matching filter in a
+// single transaction and returns how many were removed, e.g. to clean up
+// after a bad import. Each removed edge gets the same dependency_removed
+// event RemoveDependency records, so Undo can restore it. To guard against
+// wiping the graph by accident, filter must set at least one criterion.
+func (s *SQLiteStorage) RemoveDependencies(ctx context.Context, filter types.DepFilter, actor string) (int, error) {
+	if filter.IsEmpty() {
+		return 0, fmt.Errorf("removing dependencies requires at least one filter criterion")
+	}
+
+	var where []string
+	var args []interface{}
+	if filter.IssueID != "" {
+		where = append(where, "d.issue_id = ?")
+		args = append(args, filter.IssueID)
+	}
+	if filter.DependsOnID != "" {
+		where = append(where, "d.depends_on_id = ?")
+		args = append(args, filter.DependsOnID)
+	}
+	if filter.Type != nil {
+		where = append(where, "d.type = ?")
+		args = append(args, *filter.Type)
+	}
+	if filter.Tombstoned {
+		where = append(where, `EXISTS (
+			SELECT 1 FROM issues i
+			WHERE i.id IN (d.issue_id, d.depends_on_id) AND i.status = ?
+		)`)
+		args = append(args, types.StatusTombstone)
+	}
+
+	count := 0
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		// #nosec G201 - safe SQL with controlled formatting
+		rows, err := tx.QueryContext(ctx, fmt.Sprintf(`
+			SELECT d.issue_id, d.depends_on_id, d.type
+			FROM dependencies d
+			WHERE %s
+			ORDER BY d.issue_id, d.depends_on_id
+		`, strings.Join(where, " AND ")), args...)
+		if err != nil {
+			return wrapDBError("query dependencies to remove", err)
+		}
+		var edges []types.Dependency
+		for rows.Next() {
+			var dep types.Dependency
+			if err := rows.Scan(&dep.IssueID, &dep.DependsOnID, &dep.Type); err != nil {
+				_ = rows.Close()
+				return wrapDBError("scan dependency", err)
+			}
+			edges = append(edges, dep)
+		}
+		_ = rows.Close()
+		if err := rows.Err(); err != nil {
+			return wrapDBError("iterate dependencies", err)
+		}
+
+		var dirty, blocking []string
+		for _, dep := range edges {
+			if _, err := tx.ExecContext(ctx, `
+				DELETE FROM dependencies WHERE issue_id = ? AND depends_on_id = ?
+			`, dep.IssueID, dep.DependsOnID); err != nil {
+				return fmt.Errorf("failed to remove dependency: %w", err)
+			}
+
+			// old_value holds the removed edge so Undo can restore it
+			if _, err := tx.ExecContext(ctx, `
+				INSERT INTO events (issue_id, event_type, actor, old_value, comment)
+				VALUES (?, ?, ?, ?, ?)
+			`, dep.IssueID, types.EventDependencyRemoved, actor, depEdgeJSON(dep.IssueID, dep.DependsOnID, dep.Type),
+				fmt.Sprintf("Removed dependency on %s", dep.DependsOnID)); err != nil {
+				return fmt.Errorf("failed to record event: %w", err)
+			}
+
+			dirty = append(dirty, dep.IssueID, dep.DependsOnID)
+			if dep.Type == types.DepBlocks || dep.Type == types.DepParentChild {
+				blocking = append(blocking, dep.IssueID)
+			}
+		}
+		if len(edges) == 0 {
+			return nil
+		}
+
+		if err := markIssuesDirtyTx(ctx, tx, dirty); err != nil {
+			return wrapDBError("mark issues dirty after removing dependencies", err)
+		}
+		if len(blocking) > 0 {
+			if err := s.invalidateBlockedCache(ctx, tx, blocking...); err != nil {
+				return fmt.Errorf("failed to invalidate blocked cache: %w", err)
+			}
+		}
+		count = len(edges)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
@@ -0,0 +1,101 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestRemoveDependencies(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	var ids []string
+	for _, title := range []string{"A", "B", "C", "D"} {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		ids = append(ids, issue.ID)
+	}
+	a, b, c, d := ids[0], ids[1], ids[2], ids[3]
+	for _, dep := range []*types.Dependency{
+		{IssueID: a, DependsOnID: b, Type: types.DepBlocks},
+		{IssueID: a, DependsOnID: c, Type: types.DepRelated},
+		{IssueID: b, DependsOnID: c, Type: types.DepBlocks},
+		{IssueID: d, DependsOnID: c, Type: types.DepBlocks},
+	} {
+		if err := store.AddDependency(ctx, dep, "test"); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+	}
+
+	remaining := func() int {
+		all, err := store.GetAllDependencyRecords(ctx)
+		if err != nil {
+			t.Fatalf("GetAllDependencyRecords failed: %v", err)
+		}
+		n := 0
+		for _, deps := range all {
+			n += len(deps)
+		}
+		return n
+	}
+
+	if _, err := store.RemoveDependencies(ctx, types.DepFilter{}, "test"); err == nil {
+		t.Error("Expected an empty filter to be rejected")
+	}
+
+	blocks := types.DepBlocks
+	count, err := store.RemoveDependencies(ctx, types.DepFilter{IssueID: a, Type: &blocks}, "test")
+	if err != nil {
+		t.Fatalf("RemoveDependencies failed: %v", err)
+	}
+	if count != 1 || remaining() != 3 {
+		t.Errorf("Expected only a's blocks edge removed, got count %d with %d left", count, remaining())
+	}
+	ready, err := store.GetReadyWork(ctx, types.WorkFilter{})
+	if err != nil {
+		t.Fatalf("GetReadyWork failed: %v", err)
+	}
+	aReady := false
+	for _, issue := range ready {
+		aReady = aReady || issue.ID == a
+	}
+	if !aReady {
+		t.Error("Expected a ready once its blocker edge was removed")
+	}
+
+	events, err := store.GetEvents(ctx, a, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	recorded := false
+	for _, e := range events {
+		recorded = recorded || e.EventType == types.EventDependencyRemoved
+	}
+	if !recorded {
+		t.Error("Expected the removal recorded in history")
+	}
+
+	// Edges with a soft-deleted end
+	if err := store.CreateTombstone(ctx, d, "test", "bad import"); err != nil {
+		t.Fatalf("CreateTombstone failed: %v", err)
+	}
+	count, err = store.RemoveDependencies(ctx, types.DepFilter{Tombstoned: true}, "test")
+	if err != nil {
+		t.Fatalf("RemoveDependencies failed: %v", err)
+	}
+	if count != 1 || remaining() != 2 {
+		t.Errorf("Expected the tombstone's edge removed, got count %d with %d left", count, remaining())
+	}
+
+	count, err = store.RemoveDependencies(ctx, types.DepFilter{DependsOnID: c}, "test")
+	if err != nil {
+		t.Fatalf("RemoveDependencies failed: %v", err)
+	}
+	if count != 2 || remaining() != 0 {
+		t.Errorf("Expected both edges to c removed, got count %d with %d left", count, remaining())
+	}
+}
@@ -0,0 +1,100 @@
+// Package sqlite - transparent compression of large issue descriptions
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/compression"
+)
+
+// descriptionThreshold returns the configured DescriptionCompressionConfigKey
+// threshold in bytes, or 0 (compression disabled) if unset or invalid. It runs
+// on q so writes see the setting within their own transaction.
+func descriptionThreshold(ctx context.Context, q queryer) int {
+	var value string
+	err := q.QueryRowContext(ctx, `SELECT value FROM config WHERE key = ?`, DescriptionCompressionConfigKey).Scan(&value)
+	if err != nil {
+		return 0
+	}
+	threshold, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || threshold < 0 {
+		return 0
+	}
+	return threshold
+}
+
+// storedDescription returns description as it should be written to the
+// issues table: compressed if it reaches the configured threshold, plain
+// otherwise. Compressed input (e.g. from a compressed export) is normalized
+// the same way.
+func storedDescription(ctx context.Context, q queryer, description string) (string, error) {
+	stored, err := compression.CompressAbove(description, descriptionThreshold(ctx, q))
+	if err != nil {
+		return "", fmt.Errorf("failed to encode description: %w", err)
+	}
+	return stored, nil
+}
+
+// decodeDescription replaces a description read from the issues table with
+// its plain text
+func decodeDescription(description *string) error {
+	plain, err := compression.Decompress(*description)
+	if err != nil {
+		return fmt.Errorf("failed to decode description: %w", err)
+	}
+	*description = plain
+	return nil
+}
+
+// RecompressDescriptions rewrites every stored description to match the
+// current DescriptionCompressionConfigKey threshold: descriptions at or above
+// it are compressed, and the rest (including all of them when compression is
+// disabled) are stored plain. Use it after changing the threshold to migrate
+// existing issues. Content hashes are unaffected since they cover the plain
+// text. Returns the number of issues rewritten.
+func (s *SQLiteStorage) RecompressDescriptions(ctx context.Context) (int, error) {
+	changed := 0
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		threshold := descriptionThreshold(ctx, tx)
+
+		rows, err := tx.QueryContext(ctx, `SELECT id, description FROM issues`)
+		if err != nil {
+			return wrapDBError("query descriptions", err)
+		}
+		updates := make(map[string]string)
+		for rows.Next() {
+			var id, description string
+			if err := rows.Scan(&id, &description); err != nil {
+				_ = rows.Close()
+				return wrapDBError("scan description", err)
+			}
+			stored, err := compression.CompressAbove(description, threshold)
+			if err != nil {
+				_ = rows.Close()
+				return fmt.Errorf("issue %s: %w", id, err)
+			}
+			if stored != description {
+				updates[id] = stored
+			}
+		}
+		if err := rows.Err(); err != nil {
+			_ = rows.Close()
+			return wrapDBError("iterate descriptions", err)
+		}
+		_ = rows.Close()
+
+		// updated_at is left alone: the content did not change
+		for id, stored := range updates {
+			if _, err := tx.ExecContext(ctx, `UPDATE issues SET description = ? WHERE id = ?`, stored, id); err != nil {
+				return wrapDBError("rewrite description", err)
+			}
+		}
+		changed = len(updates)
+		return nil
+	})
+	return changed, err
+}
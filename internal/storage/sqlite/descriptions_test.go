@@ -0,0 +1,128 @@
+package sqlite
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/compression"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func storedDescriptionOf(t *testing.T, store *SQLiteStorage, id string) string {
+	t.Helper()
+	var raw string
+	if err := store.db().QueryRow(`SELECT description FROM issues WHERE id = ?`, id).Scan(&raw); err != nil {
+		t.Fatalf("failed to read raw description: %v", err)
+	}
+	return raw
+}
+
+func TestDescriptionCompression(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	if err := store.SetConfig(ctx, DescriptionCompressionConfigKey, "1024"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+
+	trace := strings.Repeat("panic: nil map\n\tgoroutine 7 [running]:\n\tmain.handle(0x0)\n", 200)
+	big := &types.Issue{Title: "Crash", Description: trace, Status: types.StatusOpen, Priority: 1, IssueType: types.TypeBug}
+	small := &types.Issue{Title: "Typo", Description: "fix the typo", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{big, small} {
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	raw := storedDescriptionOf(t, store, big.ID)
+	if !compression.IsCompressed(raw) {
+		t.Fatal("Expected large description to be stored compressed")
+	}
+	if len(raw) >= len(trace) {
+		t.Errorf("Expected stored description smaller than %d bytes, got %d", len(trace), len(raw))
+	}
+	if compression.IsCompressed(storedDescriptionOf(t, store, small.ID)) {
+		t.Error("Expected small description to be stored plain")
+	}
+
+	got, err := store.GetIssue(ctx, big.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.Description != trace {
+		t.Error("Expected GetIssue to return the original description")
+	}
+	if got.ContentHash != big.ContentHash {
+		t.Errorf("Expected content hash %s, got %s", big.ContentHash, got.ContentHash)
+	}
+
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	for _, issue := range issues {
+		if compression.IsCompressed(issue.Description) {
+			t.Errorf("Expected SearchIssues to decompress %s", issue.ID)
+		}
+	}
+
+	// Updates are compressed too
+	longer := trace + "more context\n"
+	if err := store.UpdateIssue(ctx, small.ID, map[string]interface{}{"description": longer}, "test"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if !compression.IsCompressed(storedDescriptionOf(t, store, small.ID)) {
+		t.Error("Expected updated description to be stored compressed")
+	}
+	got, err = store.GetIssue(ctx, small.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.Description != longer {
+		t.Error("Expected GetIssue to return the updated description")
+	}
+}
+
+func TestRecompressDescriptions(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	trace := strings.Repeat("at com.example.Service.call(Service.java:99)\n", 100)
+	issue := &types.Issue{Title: "Crash", Description: trace, Status: types.StatusOpen, Priority: 1, IssueType: types.TypeBug}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if compression.IsCompressed(storedDescriptionOf(t, store, issue.ID)) {
+		t.Fatal("Expected description stored plain with compression disabled")
+	}
+
+	// Enabling compression migrates existing rows
+	if err := store.SetConfig(ctx, DescriptionCompressionConfigKey, "512"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	changed, err := store.RecompressDescriptions(ctx)
+	if err != nil {
+		t.Fatalf("RecompressDescriptions failed: %v", err)
+	}
+	if changed != 1 {
+		t.Errorf("Expected 1 description rewritten, got %d", changed)
+	}
+	if !compression.IsCompressed(storedDescriptionOf(t, store, issue.ID)) {
+		t.Error("Expected description compressed after RecompressDescriptions")
+	}
+	if changed, _ := store.RecompressDescriptions(ctx); changed != 0 {
+		t.Errorf("Expected second run to rewrite nothing, got %d", changed)
+	}
+
+	// Disabling it restores plain text
+	if err := store.SetConfig(ctx, DescriptionCompressionConfigKey, "0"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	if _, err := store.RecompressDescriptions(ctx); err != nil {
+		t.Fatalf("RecompressDescriptions failed: %v", err)
+	}
+	if raw := storedDescriptionOf(t, store, issue.ID); raw != trace {
+		t.Error("Expected description stored plain after disabling compression")
+	}
+}
@@ -0,0 +1,47 @@
+// Package sqlite - at-close issue digests
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// DigestForIssue summarizes an issue for an at-close notification: title,
+// final status and close reason, time from creation to close, and the most
+// recent comment. It can be built for open issues too, in which case
+// TimeToClose is zero.
+func (s *SQLiteStorage) DigestForIssue(ctx context.Context, id string) (types.Digest, error) {
+	issue, err := s.GetIssue(ctx, id)
+	if err != nil {
+		return types.Digest{}, fmt.Errorf("failed to get issue: %w", err)
+	}
+	if issue == nil {
+		return types.Digest{}, fmt.Errorf("issue %s: %w", id, ErrNotFound)
+	}
+
+	comments, err := s.GetIssueComments(ctx, id)
+	if err != nil {
+		return types.Digest{}, err
+	}
+
+	digest := types.Digest{
+		IssueID:     issue.ID,
+		Title:       issue.Title,
+		Status:      issue.Status,
+		CloseReason: issue.CloseReason,
+		CreatedAt:   issue.CreatedAt,
+		ClosedAt:    issue.ClosedAt,
+	}
+	if issue.ClosedAt != nil {
+		digest.TimeToClose = issue.ClosedAt.Sub(issue.CreatedAt)
+	}
+	// Comments added within the same second share created_at; the higher ID is later
+	for _, comment := range comments {
+		if digest.LastComment == nil || !comment.CreatedAt.Before(digest.LastComment.CreatedAt) && comment.ID > digest.LastComment.ID {
+			digest.LastComment = comment
+		}
+	}
+	return digest, nil
+}
@@ -0,0 +1,54 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestDigestForIssue(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Ship it", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	digest, err := store.DigestForIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("DigestForIssue failed: %v", err)
+	}
+	if digest.Status != types.StatusOpen || digest.TimeToClose != 0 || digest.LastComment != nil {
+		t.Errorf("Expected open digest without comment, got %+v", digest)
+	}
+
+	for _, text := range []string{"first", "last"} {
+		if _, err := store.AddIssueComment(ctx, issue.ID, "alice", text); err != nil {
+			t.Fatalf("AddIssueComment failed: %v", err)
+		}
+	}
+	if err := store.CloseIssue(ctx, issue.ID, "shipped", "test"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+
+	digest, err = store.DigestForIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("DigestForIssue failed: %v", err)
+	}
+	if digest.Title != "Ship it" || digest.Status != types.StatusClosed || digest.CloseReason != "shipped" {
+		t.Errorf("Expected closed digest with reason, got %+v", digest)
+	}
+	if digest.ClosedAt == nil || digest.TimeToClose != digest.ClosedAt.Sub(digest.CreatedAt) || digest.TimeToClose < 0 {
+		t.Errorf("Expected time to close from created_at to closed_at, got %v", digest.TimeToClose)
+	}
+	if digest.LastComment == nil || digest.LastComment.Text != "last" {
+		t.Errorf("Expected last comment, got %+v", digest.LastComment)
+	}
+
+	if _, err := store.DigestForIssue(ctx, "bd-missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
@@ -11,7 +11,7 @@ import (
 // MarkIssueDirty marks an issue as dirty (needs to be exported to JSONL)
 // This should be called whenever an issue is created, updated, or has dependencies changed
 func (s *SQLiteStorage) MarkIssueDirty(ctx context.Context, issueID string) error {
-	_, err := s.db.ExecContext(ctx, `
+	_, err := s.db().ExecContext(ctx, `
 		INSERT INTO dirty_issues (issue_id, marked_at)
 		VALUES (?, ?)
 		ON CONFLICT (issue_id) DO UPDATE SET marked_at = excluded.marked_at
@@ -50,7 +50,7 @@ func (s *SQLiteStorage) MarkIssuesDirty(ctx context.Context, issueIDs []string)
 
 // GetDirtyIssues returns the list of issue IDs that need to be exported
 func (s *SQLiteStorage) GetDirtyIssues(ctx context.Context) ([]string, error) {
-	rows, err := s.db.QueryContext(ctx, `
+	rows, err := s.db().QueryContext(ctx, `
 		SELECT issue_id FROM dirty_issues
 		ORDER BY marked_at ASC
 	`)
@@ -77,7 +77,7 @@ func (s *SQLiteStorage) GetDirtyIssues(ctx context.Context) ([]string, error) {
 // GetDirtyIssueHash returns the stored content hash for a dirty issue, if it exists
 func (s *SQLiteStorage) GetDirtyIssueHash(ctx context.Context, issueID string) (string, error) {
 	var hash sql.NullString
-	err := s.db.QueryRowContext(ctx, `
+	err := s.db().QueryRowContext(ctx, `
 		SELECT content_hash FROM dirty_issues WHERE issue_id = ?
 	`, issueID).Scan(&hash)
 
@@ -101,7 +101,7 @@ func (s *SQLiteStorage) GetDirtyIssueHash(ctx context.Context, issueID string) (
 // WARNING: This has a race condition (bd-52). Use ClearDirtyIssuesByID instead
 // to only clear specific issues that were actually exported.
 func (s *SQLiteStorage) ClearDirtyIssues(ctx context.Context) error {
-	_, err := s.db.ExecContext(ctx, `DELETE FROM dirty_issues`)
+	_, err := s.db().ExecContext(ctx, `DELETE FROM dirty_issues`)
 	if err != nil {
 		return fmt.Errorf("failed to clear dirty issues: %w", err)
 	}
@@ -135,7 +135,7 @@ func (s *SQLiteStorage) ClearDirtyIssuesByID(ctx context.Context, issueIDs []str
 // GetDirtyIssueCount returns the count of dirty issues (for monitoring/debugging)
 func (s *SQLiteStorage) GetDirtyIssueCount(ctx context.Context) (int, error) {
 	var count int
-	err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM dirty_issues`).Scan(&count)
+	err := s.db().QueryRowContext(ctx, `SELECT COUNT(*) FROM dirty_issues`).Scan(&count)
 	if IsNotFound(wrapDBError("count dirty issues", err)) {
 		return 0, nil
 	}
@@ -10,7 +10,7 @@ import (
 )
 
 // markDirty marks a single issue as dirty for incremental export
-func markDirty(ctx context.Context, conn *sql.Conn, issueID string) error {
+func markDirty(ctx context.Context, conn execer, issueID string) error {
 	_, err := conn.ExecContext(ctx, `
 		INSERT INTO dirty_issues (issue_id, marked_at)
 		VALUES (?, ?)
@@ -0,0 +1,45 @@
+// Package sqlite - local-only draft issues
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// PublishIssue turns a draft issue into a normal one so it is included in
+// the next JSONL export. Publishing an issue that is not a draft is a no-op.
+func (s *SQLiteStorage) PublishIssue(ctx context.Context, id, actor string) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		if err := requireIssue(ctx, tx, id); err != nil {
+			return err
+		}
+
+		var draft bool
+		if err := tx.QueryRowContext(ctx, `SELECT draft FROM issues WHERE id = ?`, id).Scan(&draft); err != nil {
+			return wrapDBError("get draft flag", err)
+		}
+		if !draft {
+			return nil
+		}
+
+		if _, err := tx.ExecContext(ctx, `UPDATE issues SET draft = 0, updated_at = ? WHERE id = ?`, s.now(), id); err != nil {
+			return wrapDBError("publish issue", err)
+		}
+
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO events (issue_id, event_type, actor, comment)
+			VALUES (?, ?, ?, ?)
+		`, id, types.EventPublished, actor, "Published draft")
+		if err != nil {
+			return fmt.Errorf("failed to record event: %w", err)
+		}
+
+		if err := markDirty(ctx, tx, id); err != nil {
+			return fmt.Errorf("failed to mark issue dirty: %w", err)
+		}
+		return nil
+	})
+}
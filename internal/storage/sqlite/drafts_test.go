@@ -0,0 +1,129 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestDraftIssues(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	draft := &types.Issue{Title: "Scratchpad", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, Draft: true}
+	if err := store.CreateIssue(ctx, draft, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	normal := &types.Issue{Title: "Shared", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, normal, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	got, err := store.GetIssue(ctx, draft.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if !got.Draft {
+		t.Error("Expected issue to be a draft")
+	}
+
+	results, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != normal.ID {
+		t.Errorf("Expected only %s by default, got %d issues", normal.ID, len(results))
+	}
+
+	results, err = store.SearchIssues(ctx, "", types.IssueFilter{IncludeDrafts: true})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Errorf("Expected 2 issues with IncludeDrafts, got %d", len(results))
+	}
+
+	if err := store.ClearDirtyIssuesByID(ctx, []string{draft.ID, normal.ID}); err != nil {
+		t.Fatalf("ClearDirtyIssuesByID failed: %v", err)
+	}
+	if err := store.PublishIssue(ctx, draft.ID, "test"); err != nil {
+		t.Fatalf("PublishIssue failed: %v", err)
+	}
+	got, err = store.GetIssue(ctx, draft.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.Draft {
+		t.Error("Expected published issue not to be a draft")
+	}
+	dirty, err := store.GetDirtyIssues(ctx)
+	if err != nil {
+		t.Fatalf("GetDirtyIssues failed: %v", err)
+	}
+	if len(dirty) != 1 || dirty[0] != draft.ID {
+		t.Errorf("Expected %s to be dirty after publish, got %v", draft.ID, dirty)
+	}
+
+	results, err = store.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Errorf("Expected 2 issues after publish, got %d", len(results))
+	}
+
+	// Publishing again is a no-op
+	if err := store.PublishIssue(ctx, draft.ID, "test"); err != nil {
+		t.Fatalf("PublishIssue failed: %v", err)
+	}
+	if err := store.PublishIssue(ctx, "bd-missing", "test"); err == nil {
+		t.Error("Expected error publishing a missing issue")
+	}
+}
+
+func TestUpdateByFilterDrafts(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	draft := &types.Issue{Title: "Scratchpad", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, Draft: true}
+	if err := store.CreateIssue(ctx, draft, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	normal := &types.Issue{Title: "Shared", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, normal, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	priority := func(id string) int {
+		issue, err := store.GetIssue(ctx, id)
+		if err != nil {
+			t.Fatalf("GetIssue failed: %v", err)
+		}
+		return issue.Priority
+	}
+
+	// IncludeDrafts widens the match set; on its own it selects everything
+	if _, err := store.UpdateByFilter(ctx, types.IssueFilter{IncludeDrafts: true}, map[string]interface{}{"priority": 0}, "bulk"); err == nil {
+		t.Error("Expected a filter with only IncludeDrafts to be rejected")
+	}
+	if priority(draft.ID) != 2 || priority(normal.ID) != 2 {
+		t.Error("Expected the rejected update to change nothing")
+	}
+
+	open := types.StatusOpen
+	count, err := store.UpdateByFilter(ctx, types.IssueFilter{Status: &open}, map[string]interface{}{"priority": 1}, "bulk")
+	if err != nil {
+		t.Fatalf("UpdateByFilter failed: %v", err)
+	}
+	if count != 1 || priority(draft.ID) != 2 || priority(normal.ID) != 1 {
+		t.Errorf("Expected only %s updated without IncludeDrafts, got %d updated", normal.ID, count)
+	}
+
+	count, err = store.UpdateByFilter(ctx, types.IssueFilter{Status: &open, IncludeDrafts: true}, map[string]interface{}{"priority": 0}, "bulk")
+	if err != nil {
+		t.Fatalf("UpdateByFilter failed: %v", err)
+	}
+	if count != 2 || priority(draft.ID) != 0 || priority(normal.ID) != 0 {
+		t.Errorf("Expected both issues updated with IncludeDrafts, got %d updated", count)
+	}
+}
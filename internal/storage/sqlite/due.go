@@ -0,0 +1,60 @@
+// Package sqlite - due dates and overdue notifications
+package sqlite
+
+import (
+	"context"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// MarkOverdueActor is the actor recorded on the overdue events of MarkOverdue
+const MarkOverdueActor = "beads-due"
+
+// MarkOverdue records an overdue event for every issue, not closed, whose
+// due date has passed by now, and returns their IDs. Each due date is
+// reported once: an issue is marked again only if its due date is moved and
+// passes again. The events show up in ChangesSince's event log and in
+// Subscribe, as ChangeOverdue. Issues without a due date are never overdue.
+// It is meant to run periodically from the daemon, like WakeSnoozed.
+func (s *SQLiteStorage) MarkOverdue(ctx context.Context, now time.Time) ([]string, error) {
+	var marked []string
+	err := s.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		t := tx.(*sqliteTxStorage)
+
+		rows, err := t.conn.QueryContext(ctx, `
+			SELECT id FROM issues
+			WHERE due_date IS NOT NULL AND julianday(due_date) < julianday(?)
+			  AND status NOT IN (?, ?)
+			  AND NOT EXISTS (
+				SELECT 1 FROM events e
+				WHERE e.issue_id = issues.id AND e.event_type = ?
+				  AND julianday(e.new_value) = julianday(issues.due_date)
+			  )
+			ORDER BY id
+		`, now.UTC().Format(time.RFC3339Nano), types.StatusClosed, types.StatusTombstone, types.EventOverdue)
+		if err != nil {
+			return wrapDBError("query overdue issues", err)
+		}
+		overdue, err := scanStrings(rows)
+		if err != nil {
+			return wrapDBError("scan overdue issues", err)
+		}
+
+		for _, id := range overdue {
+			if _, err := t.conn.ExecContext(ctx, `
+				INSERT INTO events (issue_id, event_type, actor, new_value, comment)
+				SELECT id, ?, ?, due_date, 'Past due' FROM issues WHERE id = ?
+			`, types.EventOverdue, MarkOverdueActor, id); err != nil {
+				return wrapDBErrorf(err, "record overdue event for %s", id)
+			}
+		}
+		marked = overdue
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return marked, nil
+}
@@ -0,0 +1,137 @@
+package sqlite
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestDueDates(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+	now := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	store.SetClock(func() time.Time { return now })
+
+	yesterday := now.Add(-24 * time.Hour)
+	nextWeek := now.Add(7 * 24 * time.Hour)
+	late := &types.Issue{Title: "Late", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, DueDate: &yesterday}
+	upcoming := &types.Issue{Title: "Upcoming", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, DueDate: &nextWeek}
+	undated := &types.Issue{Title: "Undated", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	done := &types.Issue{Title: "Done late", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, DueDate: &yesterday}
+	for _, i := range []*types.Issue{late, upcoming, undated, done} {
+		if err := store.CreateIssue(ctx, i, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	if err := store.CloseIssue(ctx, done.ID, "shipped", "test"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+
+	got, err := store.GetIssue(ctx, late.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.DueDate == nil || !got.DueDate.Equal(yesterday) {
+		t.Errorf("Expected due date %v, got %v", yesterday, got.DueDate)
+	}
+
+	search := func(filter types.IssueFilter) []string {
+		issues, err := store.SearchIssues(ctx, "", filter)
+		if err != nil {
+			t.Fatalf("SearchIssues failed: %v", err)
+		}
+		var ids []string
+		for _, i := range issues {
+			ids = append(ids, i.ID)
+		}
+		return ids
+	}
+	if ids := search(types.IssueFilter{Overdue: true}); !reflect.DeepEqual(ids, []string{late.ID}) {
+		t.Errorf("Expected only %s overdue, got %v", late.ID, ids)
+	}
+	before := now.Add(30 * 24 * time.Hour)
+	if ids := search(types.IssueFilter{DueBefore: &before}); len(ids) != 3 {
+		t.Errorf("Expected the 3 dated issues due within a month, got %v", ids)
+	}
+
+	// Each due date is reported once
+	marked, err := store.MarkOverdue(ctx, now)
+	if err != nil {
+		t.Fatalf("MarkOverdue failed: %v", err)
+	}
+	if !reflect.DeepEqual(marked, []string{late.ID}) {
+		t.Errorf("Expected %s to be marked overdue, got %v", late.ID, marked)
+	}
+	if marked, _ := store.MarkOverdue(ctx, now); len(marked) != 0 {
+		t.Errorf("Expected no new overdue issues, got %v", marked)
+	}
+	events, err := store.EventsSince(ctx, 0, 0)
+	if err != nil {
+		t.Fatalf("EventsSince failed: %v", err)
+	}
+	last := events[len(events)-1]
+	if change, ok := changeEventFor(last); !ok || change.Kind != types.ChangeOverdue || change.IssueID != late.ID {
+		t.Errorf("Expected an overdue change for %s, got %+v", late.ID, change)
+	}
+
+	// Moving the due date arms it again
+	if err := store.UpdateIssue(ctx, upcoming.ID, map[string]interface{}{"due_date": now.Add(-time.Hour)}, "test"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if err := store.UpdateIssue(ctx, late.ID, map[string]interface{}{"due_date": now.Add(-2 * time.Hour)}, "test"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	marked, err = store.MarkOverdue(ctx, now)
+	if err != nil {
+		t.Fatalf("MarkOverdue failed: %v", err)
+	}
+	sort.Strings(marked)
+	want := []string{late.ID, upcoming.ID}
+	sort.Strings(want)
+	if !reflect.DeepEqual(marked, want) {
+		t.Errorf("Expected %v to be marked overdue, got %v", want, marked)
+	}
+	if err := store.UpdateIssue(ctx, late.ID, map[string]interface{}{"due_date": "tomorrow"}, "test"); err == nil {
+		t.Error("Expected a non-time due date to be rejected")
+	}
+	if err := store.UpdateIssue(ctx, late.ID, map[string]interface{}{"due_date": nil}, "test"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if ids := search(types.IssueFilter{Overdue: true}); !reflect.DeepEqual(ids, []string{upcoming.ID}) {
+		t.Errorf("Expected only %s overdue once %s has no due date, got %v", upcoming.ID, late.ID, ids)
+	}
+
+	// Due dates round-trip through JSON as RFC3339, and through archives
+	data, err := json.Marshal(upcoming)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if _, err := time.Parse(time.RFC3339, fields["due_date"].(string)); err != nil {
+		t.Errorf("Expected an RFC3339 due_date, got %v", fields["due_date"])
+	}
+	var archive bytes.Buffer
+	if err := store.ExportArchive(ctx, &archive); err != nil {
+		t.Fatalf("ExportArchive failed: %v", err)
+	}
+	dst := newTestStore(t, "")
+	if _, err := dst.ImportArchive(ctx, bytes.NewReader(archive.Bytes()), ImportReplace); err != nil {
+		t.Fatalf("ImportArchive failed: %v", err)
+	}
+	imported, err := dst.GetIssue(ctx, upcoming.ID)
+	if err != nil || imported == nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if imported.DueDate == nil || !imported.DueDate.Equal(now.Add(-time.Hour)) {
+		t.Errorf("Expected the due date to survive the archive, got %v", imported.DueDate)
+	}
+}
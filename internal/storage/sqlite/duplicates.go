@@ -0,0 +1,148 @@
+// Package sqlite - exact duplicate detection and merging
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// DuplicateNormalizationConfigKey is the config key for how FindExactDuplicates
+// normalizes titles and descriptions before comparing them: a comma-separated
+// subset of "trim" (surrounding whitespace), "case" (case folding) and
+// "whitespace" (runs of whitespace collapsed to one space), or "none" for
+// byte-for-byte comparison. Unset or empty applies all three; unknown entries
+// are ignored.
+const DuplicateNormalizationConfigKey = "duplicates.normalize"
+
+// duplicateNormalization is the parsed DuplicateNormalizationConfigKey
+type duplicateNormalization struct {
+	trim       bool
+	foldCase   bool
+	whitespace bool
+}
+
+// parseDuplicateNormalization parses a DuplicateNormalizationConfigKey value
+func parseDuplicateNormalization(value string) duplicateNormalization {
+	if strings.TrimSpace(value) == "" {
+		return duplicateNormalization{trim: true, foldCase: true, whitespace: true}
+	}
+	var n duplicateNormalization
+	for _, part := range strings.Split(value, ",") {
+		switch strings.ToLower(strings.TrimSpace(part)) {
+		case "trim":
+			n.trim = true
+		case "case":
+			n.foldCase = true
+		case "whitespace":
+			n.whitespace = true
+		}
+	}
+	return n
+}
+
+// apply returns text normalized for comparison
+func (n duplicateNormalization) apply(text string) string {
+	if n.whitespace {
+		text = strings.Join(strings.Fields(text), " ")
+	} else if n.trim {
+		text = strings.TrimSpace(text)
+	}
+	if n.foldCase {
+		text = strings.ToLower(text)
+	}
+	return text
+}
+
+// FindExactDuplicates returns groups of issues whose title, description and
+// type are identical once normalized (see DuplicateNormalizationConfigKey),
+// the kind of copies merges and imports leave behind under different IDs.
+// Closed and tombstoned issues are ignored, so groups collapsed with
+// MergeIssues don't come back. IDs are sorted within each group and groups
+// by their first ID.
+func (s *SQLiteStorage) FindExactDuplicates(ctx context.Context) ([][]string, error) {
+	value, err := s.GetConfig(ctx, DuplicateNormalizationConfigKey)
+	if err != nil {
+		return nil, err
+	}
+	normalize := parseDuplicateNormalization(value)
+
+	query := `
+		SELECT id, title, description, issue_type FROM issues
+		WHERE status NOT IN (?, ?)`
+	args := []interface{}{types.StatusClosed, types.StatusTombstone}
+	if visible, visibleArgs := visibleClause(ctx, "issues.id"); visible != "" {
+		query += " AND " + visible
+		args = append(args, visibleArgs...)
+	}
+	rows, err := s.db().QueryContext(ctx, query+` ORDER BY id`, args...)
+	if err != nil {
+		return nil, wrapDBError("query issues for duplicates", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	type contentKey struct {
+		title       string
+		description string
+		issueType   string
+	}
+	groups := make(map[contentKey][]string)
+	for rows.Next() {
+		var id, title, description, issueType string
+		if err := rows.Scan(&id, &title, &description, &issueType); err != nil {
+			return nil, wrapDBError("scan issue for duplicates", err)
+		}
+		if err := decodeDescription(&description); err != nil {
+			return nil, fmt.Errorf("issue %s: %w", id, err)
+		}
+		key := contentKey{normalize.apply(title), normalize.apply(description), issueType}
+		groups[key] = append(groups[key], id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, wrapDBError("iterate issues for duplicates", err)
+	}
+
+	var duplicates [][]string
+	for _, ids := range groups {
+		if len(ids) > 1 {
+			duplicates = append(duplicates, ids)
+		}
+	}
+	sort.Slice(duplicates, func(i, j int) bool { return duplicates[i][0] < duplicates[j][0] })
+	return duplicates, nil
+}
+
+// MergeIssues collapses duplicates into targetID: each source is closed as a
+// duplicate of the target and linked to it with a related dependency, so
+// references to the source still lead somewhere. Everything happens in one
+// transaction; if any source can't be merged, none are.
+func (s *SQLiteStorage) MergeIssues(ctx context.Context, targetID string, sourceIDs []string, actor string) error {
+	if len(sourceIDs) == 0 {
+		return fmt.Errorf("no issues to merge into %s", targetID)
+	}
+	return s.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		t := tx.(*sqliteTxStorage)
+
+		if err := requireIssue(ctx, t.conn, targetID); err != nil {
+			return err
+		}
+		reason := fmt.Sprintf("Duplicate of %s", targetID)
+		for _, sourceID := range sourceIDs {
+			if sourceID == targetID {
+				return fmt.Errorf("cannot merge %s into itself", targetID)
+			}
+			if err := t.CloseIssue(ctx, sourceID, reason, actor); err != nil {
+				return fmt.Errorf("failed to close %s: %w", sourceID, err)
+			}
+			dep := &types.Dependency{IssueID: sourceID, DependsOnID: targetID, Type: types.DepRelated}
+			if err := t.AddDependency(ctx, dep, actor); err != nil {
+				return fmt.Errorf("failed to link %s to %s: %w", sourceID, targetID, err)
+			}
+		}
+		return nil
+	})
+}
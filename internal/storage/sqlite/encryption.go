@@ -0,0 +1,276 @@
+// Package sqlite - encryption at rest
+package sqlite
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+
+	sqlite3 "github.com/ncruces/go-sqlite3"
+	"github.com/ncruces/go-sqlite3/util/vfsutil"
+	"github.com/ncruces/go-sqlite3/vfs"
+)
+
+// encryptedVFSName is the SQLite VFS that stores database, journal and WAL
+// files encrypted. It wraps the default VFS, so locking and shared memory
+// behave exactly as for plaintext databases.
+//
+// Files are encrypted with AES-256-XTS in 512-byte sectors, the construction
+// used for full disk encryption: it is length preserving, so SQLite's page
+// layout is unchanged, and deterministic, so someone holding several copies
+// of the file can tell which sectors changed between them. It keeps the data
+// confidential at rest but does not detect tampering.
+const encryptedVFSName = "beads-encrypted"
+
+// encryptionSectorSize is the unit of encryption, the smallest SQLite page size
+const encryptionSectorSize = 512
+
+// encryptionKeySalt salts the key derivation; changing it makes every
+// existing encrypted database unreadable
+const encryptionKeySalt = "github.com/steveyegge/beads/internal/storage/sqlite"
+
+func init() {
+	vfs.Register(encryptedVFSName, &encryptedVFS{VFS: vfs.Find("")})
+}
+
+// deriveEncryptionKey stretches a user supplied key into the 64 bytes of an
+// AES-256-XTS key, hex encoded for the hexkey URI parameter
+func deriveEncryptionKey(key string) (string, error) {
+	derived, err := pbkdf2.Key(sha512.New, key, []byte(encryptionKeySalt), 10_000, 64)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(derived), nil
+}
+
+type encryptedVFS struct {
+	vfs.VFS
+}
+
+func (v *encryptedVFS) Open(name string, flags vfs.OpenFlag) (vfs.File, vfs.OpenFlag, error) {
+	if name == "" {
+		return v.OpenFilename(nil, flags)
+	}
+	return nil, flags, sqlite3.CANTOPEN
+}
+
+func (v *encryptedVFS) OpenFilename(name *vfs.Filename, flags vfs.OpenFlag) (vfs.File, vfs.OpenFlag, error) {
+	file, flags, err := vfsutil.WrapOpenFilename(v.VFS, name, flags)
+	// Super journals only hold file names; memory files never reach disk
+	if err != nil || flags&(vfs.OPEN_SUPER_JOURNAL|vfs.OPEN_MEMORY) != 0 {
+		return file, flags, err
+	}
+
+	var c *xtsCipher
+	if f, ok := vfsutil.UnwrapFile[*encryptedFile](name.DatabaseFile()); ok {
+		// Journals and WAL files use the key of their database
+		c = f.cipher
+	} else {
+		var key []byte
+		if name == nil {
+			// Temporary files get a random key
+			key = make([]byte, 64)
+			_, _ = rand.Read(key)
+		} else {
+			key, _ = hex.DecodeString(name.URIParameter("hexkey"))
+		}
+		c = newXTSCipher(key)
+	}
+	if c == nil {
+		_ = file.Close()
+		return nil, flags, sqlite3.IOERR_BADKEY
+	}
+	return &encryptedFile{File: file, cipher: c}, flags, nil
+}
+
+// xtsCipher implements AES-XTS (IEEE 1619) for whole sectors
+type xtsCipher struct {
+	data, tweak cipher.Block
+}
+
+// newXTSCipher returns the cipher for a 32, 48 or 64 byte key (AES-128, -192
+// or -256), or nil if the key has any other length
+func newXTSCipher(key []byte) *xtsCipher {
+	if len(key) != 32 && len(key) != 48 && len(key) != 64 {
+		return nil
+	}
+	data, err := aes.NewCipher(key[:len(key)/2])
+	if err != nil {
+		return nil
+	}
+	tweak, err := aes.NewCipher(key[len(key)/2:])
+	if err != nil {
+		return nil
+	}
+	return &xtsCipher{data: data, tweak: tweak}
+}
+
+// crypt encrypts or decrypts sector in place
+func (c *xtsCipher) crypt(sector []byte, sectorNum uint64, decrypt bool) {
+	var t [aes.BlockSize]byte
+	binary.LittleEndian.PutUint64(t[:8], sectorNum)
+	c.tweak.Encrypt(t[:], t[:])
+
+	for i := 0; i < len(sector); i += aes.BlockSize {
+		block := sector[i : i+aes.BlockSize]
+		for j := range block {
+			block[j] ^= t[j]
+		}
+		if decrypt {
+			c.data.Decrypt(block, block)
+		} else {
+			c.data.Encrypt(block, block)
+		}
+		for j := range block {
+			block[j] ^= t[j]
+		}
+
+		// Multiply the tweak by x in GF(2^128)
+		var carry byte
+		for j := range t {
+			next := t[j] >> 7
+			t[j] = t[j]<<1 | carry
+			carry = next
+		}
+		if carry != 0 {
+			t[0] ^= 0x87
+		}
+	}
+}
+
+func sectorFloor(i int64) int64 {
+	return i &^ (encryptionSectorSize - 1)
+}
+
+func sectorCeil[T int | int64](i T) T {
+	return (i + (encryptionSectorSize - 1)) &^ (encryptionSectorSize - 1)
+}
+
+// encryptedFile encrypts a file of the wrapped VFS sector by sector
+type encryptedFile struct {
+	vfs.File
+	cipher *xtsCipher
+	sector [encryptionSectorSize]byte
+}
+
+func (f *encryptedFile) ReadAt(p []byte, off int64) (n int, err error) {
+	for pos := sectorFloor(off); pos < off+int64(len(p)); pos += encryptionSectorSize {
+		m, err := f.File.ReadAt(f.sector[:], pos)
+		if m != encryptionSectorSize {
+			return n, err
+		}
+		f.cipher.crypt(f.sector[:], uint64(pos/encryptionSectorSize), true)
+
+		data := f.sector[:]
+		if off > pos {
+			data = data[off-pos:]
+		}
+		n += copy(p[n:], data)
+	}
+	return n, nil
+}
+
+func (f *encryptedFile) WriteAt(p []byte, off int64) (n int, err error) {
+	for pos := sectorFloor(off); pos < off+int64(len(p)); pos += encryptionSectorSize {
+		sectorNum := uint64(pos / encryptionSectorSize)
+		data := f.sector[:]
+
+		if off > pos || len(p[n:]) < encryptionSectorSize {
+			// Partial sector: read, update, write back
+			m, err := f.File.ReadAt(f.sector[:], pos)
+			switch {
+			case m == encryptionSectorSize:
+				f.cipher.crypt(f.sector[:], sectorNum, true)
+			case err == io.EOF:
+				// Past the end of the file, or a torn final sector that can't
+				// be decrypted anyway: start from zeros
+				clear(f.sector[:])
+			default:
+				return n, err
+			}
+			if off > pos {
+				data = data[off-pos:]
+			}
+		}
+
+		written := copy(data, p[n:])
+		f.cipher.crypt(f.sector[:], sectorNum, false)
+		if m, err := f.File.WriteAt(f.sector[:], pos); m != encryptionSectorSize {
+			return n, err
+		}
+		n += written
+	}
+	return n, nil
+}
+
+func (f *encryptedFile) Truncate(size int64) error {
+	return f.File.Truncate(sectorCeil(size))
+}
+
+func (f *encryptedFile) SectorSize() int {
+	size := f.File.SectorSize()
+	if size < encryptionSectorSize {
+		return encryptionSectorSize
+	}
+	return size
+}
+
+func (f *encryptedFile) DeviceCharacteristics() vfs.DeviceCharacteristic {
+	// Writes smaller than a sector become read-modify-write cycles, so only
+	// keep the guarantees that still hold
+	return f.File.DeviceCharacteristics() & (vfs.IOCAP_ATOMIC512 |
+		vfs.IOCAP_IMMUTABLE |
+		vfs.IOCAP_SEQUENTIAL |
+		vfs.IOCAP_SUBPAGE_READ |
+		vfs.IOCAP_BATCH_ATOMIC |
+		vfs.IOCAP_UNDELETABLE_WHEN_OPEN)
+}
+
+func (f *encryptedFile) ChunkSize(size int) {
+	vfsutil.WrapChunkSize(f.File, sectorCeil(size))
+}
+
+func (f *encryptedFile) SizeHint(size int64) error {
+	return vfsutil.WrapSizeHint(f.File, sectorCeil(size))
+}
+
+// The remaining optional methods pass through to the wrapped file.
+
+func (f *encryptedFile) Unwrap() vfs.File { return f.File }
+
+func (f *encryptedFile) SharedMemory() vfs.SharedMemory { return vfsutil.WrapSharedMemory(f.File) }
+
+func (f *encryptedFile) LockState() vfs.LockLevel { return vfsutil.WrapLockState(f.File) }
+
+func (f *encryptedFile) PersistWAL() bool { return vfsutil.WrapPersistWAL(f.File) }
+
+func (f *encryptedFile) SetPersistWAL(keepWAL bool) { vfsutil.WrapSetPersistWAL(f.File, keepWAL) }
+
+func (f *encryptedFile) HasMoved() (bool, error) { return vfsutil.WrapHasMoved(f.File) }
+
+func (f *encryptedFile) Overwrite() error { return vfsutil.WrapOverwrite(f.File) }
+
+func (f *encryptedFile) SyncSuper(super string) error { return vfsutil.WrapSyncSuper(f.File, super) }
+
+func (f *encryptedFile) CommitPhaseTwo() error { return vfsutil.WrapCommitPhaseTwo(f.File) }
+
+func (f *encryptedFile) BeginAtomicWrite() error { return vfsutil.WrapBeginAtomicWrite(f.File) }
+
+func (f *encryptedFile) CommitAtomicWrite() error { return vfsutil.WrapCommitAtomicWrite(f.File) }
+
+func (f *encryptedFile) RollbackAtomicWrite() error { return vfsutil.WrapRollbackAtomicWrite(f.File) }
+
+func (f *encryptedFile) CheckpointStart() { vfsutil.WrapCheckpointStart(f.File) }
+
+func (f *encryptedFile) CheckpointDone() { vfsutil.WrapCheckpointDone(f.File) }
+
+func (f *encryptedFile) Pragma(name, value string) (string, error) {
+	return vfsutil.WrapPragma(f.File, name, value)
+}
+
+func (f *encryptedFile) BusyHandler(handler func() bool) { vfsutil.WrapBusyHandler(f.File, handler) }
@@ -0,0 +1,102 @@
+package sqlite
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestEncryptedStore(t *testing.T) {
+	ctx := context.Background()
+	dbPath := filepath.Join(t.TempDir(), "beads.db")
+
+	store, err := NewWithOptions(ctx, dbPath, StoreOptions{EncryptionKey: "correct horse"})
+	if err != nil {
+		t.Fatalf("NewWithOptions failed: %v", err)
+	}
+	if err := store.SetConfig(ctx, "issue_prefix", "bd"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	issue := &types.Issue{
+		Title:       "Secret plans",
+		Description: strings.Repeat("confidential ", 200),
+		Status:      types.StatusOpen,
+		Priority:    1,
+		IssueType:   types.TypeTask,
+	}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	data, err := os.ReadFile(dbPath)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if bytes.Contains(data, []byte("SQLite format 3")) || bytes.Contains(data, []byte("confidential")) {
+		t.Error("Expected database file to be encrypted")
+	}
+
+	// Reopening with the key sees the data
+	store, err = NewWithOptions(ctx, dbPath, StoreOptions{EncryptionKey: "correct horse"})
+	if err != nil {
+		t.Fatalf("NewWithOptions failed: %v", err)
+	}
+	got, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got == nil || got.Title != "Secret plans" {
+		t.Errorf("Expected issue to survive reopening, got %+v", got)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	// Without the key, or with the wrong one, the database can't be opened
+	if _, err := New(ctx, dbPath); !errors.Is(err, ErrBadKey) {
+		t.Errorf("Expected ErrBadKey without a key, got %v", err)
+	}
+	if _, err := NewWithOptions(ctx, dbPath, StoreOptions{EncryptionKey: "battery staple"}); !errors.Is(err, ErrBadKey) {
+		t.Errorf("Expected ErrBadKey with the wrong key, got %v", err)
+	}
+}
+
+func TestEncryptedStoreRejectsMemory(t *testing.T) {
+	if _, err := NewWithOptions(context.Background(), ":memory:", StoreOptions{EncryptionKey: "k"}); err == nil {
+		t.Error("Expected error encrypting an in-memory database")
+	}
+}
+
+func TestXTSCipherRoundTrip(t *testing.T) {
+	c := newXTSCipher(bytes.Repeat([]byte{7}, 64))
+	if c == nil {
+		t.Fatal("Expected cipher for a 64 byte key")
+	}
+	plain := bytes.Repeat([]byte("0123456789abcdef"), encryptionSectorSize/16)
+	sector := append([]byte(nil), plain...)
+	c.crypt(sector, 3, false)
+	if bytes.Equal(sector, plain) {
+		t.Fatal("Expected encryption to change the sector")
+	}
+	other := append([]byte(nil), plain...)
+	c.crypt(other, 4, false)
+	if bytes.Equal(sector, other) {
+		t.Error("Expected different sectors to encrypt differently")
+	}
+	c.crypt(sector, 3, true)
+	if !bytes.Equal(sector, plain) {
+		t.Error("Expected decryption to restore the sector")
+	}
+	if newXTSCipher(make([]byte, 20)) != nil {
+		t.Error("Expected no cipher for a bad key length")
+	}
+}
@@ -36,11 +36,14 @@ func (s *SQLiteStorage) GetEpicsEligibleForClosure(ctx context.Context) ([]*type
 		FROM issues i
 		LEFT JOIN epic_stats es ON es.epic_id = i.id
 		WHERE i.issue_type = 'epic'
-		  AND i.status != 'closed'
-		ORDER BY i.priority ASC, i.created_at ASC
-	`
+		  AND i.status != 'closed'`
+	visible, args := visibleClause(ctx, "i.id")
+	if visible != "" {
+		query += " AND " + visible
+	}
+	query += " ORDER BY i.priority ASC, i.created_at ASC"
 
-	rows, err := s.db.QueryContext(ctx, query)
+	rows, err := s.db().QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -63,6 +66,9 @@ func (s *SQLiteStorage) GetEpicsEligibleForClosure(ctx context.Context) ([]*type
 		if err != nil {
 			return nil, err
 		}
+		if err := decodeDescription(&epic.Description); err != nil {
+			return nil, err
+		}
 
 		// Convert sql.NullString to string
 		if assignee.Valid {
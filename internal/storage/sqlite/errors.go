@@ -1,6 +1,7 @@
 package sqlite
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -19,6 +20,29 @@ var (
 
 	// ErrCycle indicates a dependency cycle would be created
 	ErrCycle = errors.New("dependency cycle detected")
+
+	// ErrDuplicateID indicates a generated issue ID kept colliding with
+	// existing issues after all retries
+	ErrDuplicateID = errors.New("duplicate issue ID")
+
+	// ErrOpenChildren indicates a parent cannot be closed while it has open
+	// children (see OpenChildrenError for the list)
+	ErrOpenChildren = errors.New("issue has open children")
+
+	// ErrBadKey indicates an encrypted database could not be decrypted: the
+	// encryption key is wrong, or missing for an encrypted database
+	ErrBadKey = errors.New("wrong or missing encryption key")
+
+	// ErrFilterTooComplex indicates a search filter exceeds the configured
+	// complexity limits
+	ErrFilterTooComplex = errors.New("filter too complex")
+
+	// ErrWIPLimitExceeded indicates an assignment or status change would take
+	// an assignee past the configured work-in-progress limit
+	ErrWIPLimitExceeded = errors.New("WIP limit exceeded")
+
+	// ErrNoWork indicates NextAction found no issue the agent can pick up
+	ErrNoWork = errors.New("no work available")
 )
 
 // wrapDBError wraps a database error with operation context
@@ -46,6 +70,17 @@ func wrapDBErrorf(err error, format string, args ...interface{}) error {
 	return fmt.Errorf("%s: %w", op, err)
 }
 
+// withContextError returns ctx's error (wrapping the original) when a query
+// failed because ctx was canceled or timed out. The SQLite driver reports an
+// interrupted statement as a generic "interrupted" error, which would hide
+// context.Canceled / context.DeadlineExceeded from callers using errors.Is.
+func withContextError(ctx context.Context, err error) error {
+	if err == nil || ctx.Err() == nil || errors.Is(err, ctx.Err()) {
+		return err
+	}
+	return fmt.Errorf("%w: %v", ctx.Err(), err)
+}
+
 // IsNotFound checks if an error is or wraps ErrNotFound
 func IsNotFound(err error) bool {
 	return errors.Is(err, ErrNotFound)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/steveyegge/beads/internal/types"
@@ -56,6 +57,11 @@ func (s *SQLiteStorage) AddComment(ctx context.Context, issueID, actor, comment
 // GetEvents returns the event history for an issue
 func (s *SQLiteStorage) GetEvents(ctx context.Context, issueID string, limit int) ([]*types.Event, error) {
 	args := []interface{}{issueID}
+	visibleSQL := ""
+	if visible, visibleArgs := visibleClause(ctx, "events.issue_id"); visible != "" {
+		visibleSQL = " AND " + visible
+		args = append(args, visibleArgs...)
+	}
 	limitSQL := ""
 	if limit > 0 {
 		limitSQL = limitClause
@@ -66,15 +72,63 @@ func (s *SQLiteStorage) GetEvents(ctx context.Context, issueID string, limit int
 	query := fmt.Sprintf(`
 		SELECT id, issue_id, event_type, actor, old_value, new_value, comment, created_at
 		FROM events
-		WHERE issue_id = ?
+		WHERE issue_id = ?%s
 		ORDER BY created_at DESC
 		%s
-	`, limitSQL)
+	`, visibleSQL, limitSQL)
+
+	rows, err := s.db().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events: %w", err)
+	}
+	return scanEvents(rows)
+}
+
+// EventsSince returns the events recorded after sinceRevision, oldest first,
+// at most limit of them if limit > 0. A revision is an events table id, as
+// in ChangesSince; the ID of the last event returned is the revision to pass
+// next time.
+func (s *SQLiteStorage) EventsSince(ctx context.Context, sinceRevision int64, limit int) ([]*types.Event, error) {
+	args := []interface{}{sinceRevision}
+	visibleSQL := ""
+	if visible, visibleArgs := visibleClause(ctx, "events.issue_id"); visible != "" {
+		visibleSQL = " AND " + visible
+		args = append(args, visibleArgs...)
+	}
+	limitSQL := ""
+	if limit > 0 {
+		limitSQL = limitClause
+		args = append(args, limit)
+	}
+
+	// #nosec G201 - safe SQL with controlled formatting
+	query := fmt.Sprintf(`
+		SELECT id, issue_id, event_type, actor, old_value, new_value, comment, created_at
+		FROM events
+		WHERE id > ?%s
+		ORDER BY id
+		%s
+	`, visibleSQL, limitSQL)
 
-	rows, err := s.db.QueryContext(ctx, query, args...)
+	rows, err := s.db().QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get events: %w", err)
 	}
+	return scanEvents(rows)
+}
+
+// CurrentRevision returns the revision of the latest event (see ChangesSince),
+// or 0 if there are none
+func (s *SQLiteStorage) CurrentRevision(ctx context.Context) (int64, error) {
+	var revision sql.NullInt64
+	if err := s.db().QueryRowContext(ctx, `SELECT MAX(id) FROM events`).Scan(&revision); err != nil {
+		return 0, wrapDBError("get current revision", err)
+	}
+	return revision.Int64, nil
+}
+
+// scanEvents reads events rows and closes them
+func scanEvents(rows *sql.Rows) ([]*types.Event, error) {
 	defer func() { _ = rows.Close() }()
 
 	var events []*types.Event
@@ -103,15 +157,60 @@ func (s *SQLiteStorage) GetEvents(ctx context.Context, issueID string, limit int
 		events = append(events, &event)
 	}
 
-	return events, nil
+	return events, rows.Err()
+}
+
+// IssuesTouchedBy returns the distinct issues actor created, updated,
+// commented on or otherwise changed between from and to (inclusive), most
+// recently touched first. A zero from or to leaves that end of the window
+// open. Issues that have since been hard-deleted are not returned.
+func (s *SQLiteStorage) IssuesTouchedBy(ctx context.Context, actor string, from, to time.Time) ([]*types.Issue, error) {
+	where := []string{"e.actor = ?"}
+	args := []interface{}{actor}
+	if !from.IsZero() {
+		where = append(where, "julianday(e.created_at) >= julianday(?)")
+		args = append(args, from.UTC())
+	}
+	if !to.IsZero() {
+		where = append(where, "julianday(e.created_at) <= julianday(?)")
+		args = append(args, to.UTC())
+	}
+	if visible, visibleArgs := visibleClause(ctx, "e.issue_id"); visible != "" {
+		where = append(where, visible)
+		args = append(args, visibleArgs...)
+	}
+
+	// #nosec G201 - safe SQL with controlled formatting
+	rows, err := s.db().QueryContext(ctx, fmt.Sprintf(`
+		SELECT i.id, i.content_hash, i.title, i.description, i.design, i.acceptance_criteria, i.notes,
+		       i.status, i.priority, i.issue_type, i.assignee, i.estimated_minutes,
+		       i.created_at, i.updated_at, i.closed_at, i.external_ref, i.source_repo, i.close_reason,
+		       i.deleted_at, i.deleted_by, i.delete_reason, i.original_type, i.draft, i.percent_complete, i.external_blocked_reason, i.snoozed_until, i.due_date, i.version
+		FROM issues i
+		JOIN (
+			SELECT e.issue_id, MAX(e.id) AS last_event
+			FROM events e
+			WHERE %s
+			GROUP BY e.issue_id
+		) touched ON touched.issue_id = i.id
+		ORDER BY touched.last_event DESC
+	`, strings.Join(where, " AND ")), args...)
+	if err != nil {
+		return nil, withContextError(ctx, fmt.Errorf("failed to query touched issues: %w", err))
+	}
+	defer func() { _ = rows.Close() }()
+
+	return s.scanIssues(ctx, rows)
 }
 
 // GetStatistics returns aggregate statistics
 func (s *SQLiteStorage) GetStatistics(ctx context.Context) (*types.Statistics, error) {
+	defer s.beginRead()()
+
 	var stats types.Statistics
 
 	// Get counts (bd-nyt: exclude tombstones from TotalIssues, report separately)
-	err := s.db.QueryRowContext(ctx, `
+	err := s.db().QueryRowContext(ctx, `
 		SELECT
 			COALESCE(SUM(CASE WHEN status != 'tombstone' THEN 1 ELSE 0 END), 0) as total,
 			COALESCE(SUM(CASE WHEN status = 'open' THEN 1 ELSE 0 END), 0) as open,
@@ -125,21 +224,21 @@ func (s *SQLiteStorage) GetStatistics(ctx context.Context) (*types.Statistics, e
 	}
 
 	// Get blocked count
-	err = s.db.QueryRowContext(ctx, `
+	err = s.db().QueryRowContext(ctx, `
 		SELECT COUNT(DISTINCT i.id)
 		FROM issues i
 		JOIN dependencies d ON i.id = d.issue_id
 		JOIN issues blocker ON d.depends_on_id = blocker.id
 		WHERE i.status IN ('open', 'in_progress', 'blocked')
 		  AND d.type = 'blocks'
-		  AND blocker.status IN ('open', 'in_progress', 'blocked')
+		  AND blocker.status IN ('open', 'in_progress', 'blocked', 'snoozed')
 	`).Scan(&stats.BlockedIssues)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get blocked count: %w", err)
 	}
 
 	// Get ready count
-	err = s.db.QueryRowContext(ctx, `
+	err = s.db().QueryRowContext(ctx, `
 		SELECT COUNT(*)
 		FROM issues i
 		WHERE i.status = 'open'
@@ -157,7 +256,7 @@ func (s *SQLiteStorage) GetStatistics(ctx context.Context) (*types.Statistics, e
 
 	// Get average lead time (hours from created to closed)
 	var avgLeadTime sql.NullFloat64
-	err = s.db.QueryRowContext(ctx, `
+	err = s.db().QueryRowContext(ctx, `
 		SELECT AVG(
 			(julianday(closed_at) - julianday(created_at)) * 24
 		)
@@ -172,7 +271,7 @@ func (s *SQLiteStorage) GetStatistics(ctx context.Context) (*types.Statistics, e
 	}
 
 	// Get epics eligible for closure count
-	err = s.db.QueryRowContext(ctx, `
+	err = s.db().QueryRowContext(ctx, `
 		WITH epic_children AS (
 			SELECT 
 				d.depends_on_id AS epic_id,
@@ -363,3 +363,56 @@ func TestAddCommentNotFound(t *testing.T) {
 		t.Errorf("Expected error to contain %q, got %q", expectedError, err.Error())
 	}
 }
+
+func TestIssuesTouchedBy(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	start := time.Now().Add(-time.Minute)
+
+	var ids []string
+	for _, title := range []string{"First", "Second", "Third", "Other"} {
+		actor := testUserAlice
+		if title == "Other" {
+			actor = "bob"
+		}
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, actor); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		ids = append(ids, issue.ID)
+	}
+
+	// Touch First again so it becomes the most recent, repeatedly
+	for i := 0; i < 2; i++ {
+		if err := store.AddComment(ctx, ids[0], testUserAlice, "again"); err != nil {
+			t.Fatalf("AddComment failed: %v", err)
+		}
+	}
+	if err := store.DeleteIssue(ctx, ids[2]); err != nil {
+		t.Fatalf("DeleteIssue failed: %v", err)
+	}
+
+	issues, err := store.IssuesTouchedBy(ctx, testUserAlice, start, time.Now().Add(time.Minute))
+	if err != nil {
+		t.Fatalf("IssuesTouchedBy failed: %v", err)
+	}
+	var got []string
+	for _, issue := range issues {
+		got = append(got, issue.ID)
+	}
+	want := []string{ids[0], ids[1]}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	// Window entirely in the past matches nothing
+	issues, err = store.IssuesTouchedBy(ctx, testUserAlice, time.Time{}, start)
+	if err != nil {
+		t.Fatalf("IssuesTouchedBy failed: %v", err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("Expected no issues before %v, got %d", start, len(issues))
+	}
+}
@@ -0,0 +1,215 @@
+// Package sqlite - Markdown checklist exports
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// MarkdownGrouping selects how ExportMarkdown splits issues into sections
+type MarkdownGrouping string
+
+const (
+	// MarkdownByStatus gives each status its own section, in StatusMeta
+	// order (the default)
+	MarkdownByStatus MarkdownGrouping = "status"
+	// MarkdownByEpic gives each top-level epic with children its own section
+	// holding its parent-child descendants, followed by one for everything
+	// else
+	MarkdownByEpic MarkdownGrouping = "epic"
+)
+
+// MarkdownOptions configures ExportMarkdown
+type MarkdownOptions struct {
+	// GroupBy is how issues are split into sections. Empty means
+	// MarkdownByStatus.
+	GroupBy MarkdownGrouping
+
+	// Title, if set, is written as a top-level heading
+	Title string
+
+	// LinkTemplate turns issue IDs into links, with "{id}" replaced by the
+	// ID, e.g. "https://tracker.example.com/issues/{id}". When empty, issues
+	// whose external ref is an http(s) URL link there and others aren't
+	// linked.
+	LinkTemplate string
+
+	// NoPriority leaves out the priority badges
+	NoPriority bool
+}
+
+// ExportMarkdown writes the issues matching filter (see SearchIssues) to w as
+// Markdown checklists for pasting into PR descriptions and docs. Each issue
+// is a "[x]" item if its status is in the done category (see StatusMeta) and
+// "[ ]" otherwise, with a priority badge. Children are nested under their
+// parent-child parent when both are in the same section; an issue whose
+// parent isn't is listed at the top level. Empty sections and tombstones are
+// left out.
+func (s *SQLiteStorage) ExportMarkdown(ctx context.Context, w io.Writer, filter types.IssueFilter, opts MarkdownOptions) error {
+	switch opts.GroupBy {
+	case "":
+		opts.GroupBy = MarkdownByStatus
+	case MarkdownByStatus, MarkdownByEpic:
+	default:
+		return fmt.Errorf("invalid markdown grouping %q (must be %s or %s)", opts.GroupBy, MarkdownByStatus, MarkdownByEpic)
+	}
+
+	metas, err := s.StatusMeta(ctx)
+	if err != nil {
+		return err
+	}
+	filter.IncludeTombstones = false
+	issues, err := s.SearchIssues(ctx, "", filter)
+	if err != nil {
+		return fmt.Errorf("failed to query issues: %w", err)
+	}
+	allDeps, err := s.GetAllDependencyRecords(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get dependencies: %w", err)
+	}
+
+	m := markdownWriter{opts: opts, done: make(map[types.Status]bool), children: make(map[string][]*types.Issue)}
+	for _, meta := range metas {
+		m.done[meta.Status] = meta.Category == types.CategoryDone
+	}
+
+	byID := make(map[string]*types.Issue, len(issues))
+	for _, issue := range issues {
+		byID[issue.ID] = issue
+	}
+	// sameSection reports whether child can be nested under parent
+	sameSection := func(child, parent *types.Issue) bool {
+		return opts.GroupBy == MarkdownByEpic || child.Status == parent.Status
+	}
+	var roots []*types.Issue
+	for _, issue := range issues {
+		parent := markdownParent(issue, allDeps[issue.ID], byID)
+		if parent != nil && sameSection(issue, parent) {
+			m.children[parent.ID] = append(m.children[parent.ID], issue)
+		} else {
+			roots = append(roots, issue)
+		}
+	}
+
+	if opts.Title != "" {
+		fmt.Fprintf(&m.b, "# %s\n", markdownEscape(opts.Title))
+	}
+	if opts.GroupBy == MarkdownByStatus {
+		for _, meta := range metas {
+			var section []*types.Issue
+			for _, issue := range roots {
+				if issue.Status == meta.Status {
+					section = append(section, issue)
+				}
+			}
+			m.section(markdownStatusHeading(meta.Status), section)
+		}
+	} else {
+		var other []*types.Issue
+		for _, issue := range roots {
+			if issue.IssueType != types.TypeEpic || len(m.children[issue.ID]) == 0 {
+				other = append(other, issue)
+				continue
+			}
+			m.section(m.reference(issue)+": "+markdownEscape(issue.Title), m.children[issue.ID])
+		}
+		m.section("Other issues", other)
+	}
+
+	_, err = io.WriteString(w, m.b.String())
+	return err
+}
+
+// markdownWriter renders ExportMarkdown's output
+type markdownWriter struct {
+	opts     MarkdownOptions
+	done     map[types.Status]bool
+	children map[string][]*types.Issue
+	b        strings.Builder
+}
+
+// section writes a heading and the checklist of issues under it, unless
+// there are none
+func (m *markdownWriter) section(heading string, issues []*types.Issue) {
+	if len(issues) == 0 {
+		return
+	}
+	if m.b.Len() > 0 {
+		m.b.WriteString("\n")
+	}
+	fmt.Fprintf(&m.b, "## %s\n\n", heading)
+	visited := make(map[string]bool)
+	for _, issue := range issues {
+		m.item(issue, 0, visited)
+	}
+}
+
+// item writes issue as a checklist item and its children below it
+func (m *markdownWriter) item(issue *types.Issue, depth int, visited map[string]bool) {
+	if visited[issue.ID] {
+		return // parent-child cycle
+	}
+	visited[issue.ID] = true
+
+	check := " "
+	if m.done[issue.Status] {
+		check = "x"
+	}
+	fmt.Fprintf(&m.b, "%s- [%s] %s: %s", strings.Repeat("  ", depth), check, m.reference(issue), markdownEscape(issue.Title))
+	if !m.opts.NoPriority {
+		fmt.Fprintf(&m.b, " `P%d`", issue.Priority)
+	}
+	m.b.WriteString("\n")
+	for _, child := range m.children[issue.ID] {
+		m.item(child, depth+1, visited)
+	}
+}
+
+// reference returns issue's ID, linked per LinkTemplate or its external ref
+func (m *markdownWriter) reference(issue *types.Issue) string {
+	switch {
+	case m.opts.LinkTemplate != "":
+		return fmt.Sprintf("[%s](%s)", issue.ID, strings.ReplaceAll(m.opts.LinkTemplate, "{id}", issue.ID))
+	case issue.ExternalRef != nil && (strings.HasPrefix(*issue.ExternalRef, "https://") || strings.HasPrefix(*issue.ExternalRef, "http://")):
+		return fmt.Sprintf("[%s](%s)", issue.ID, *issue.ExternalRef)
+	}
+	return issue.ID
+}
+
+// markdownParent returns issue's parent-child parent among byID, if any
+func markdownParent(issue *types.Issue, deps []*types.Dependency, byID map[string]*types.Issue) *types.Issue {
+	for _, dep := range deps {
+		if dep.Type != types.DepParentChild {
+			continue
+		}
+		if parent, ok := byID[dep.DependsOnID]; ok && parent.ID != issue.ID {
+			return parent
+		}
+	}
+	return nil
+}
+
+// markdownStatusHeading turns a status like "in_progress" into "In progress"
+func markdownStatusHeading(status types.Status) string {
+	heading := strings.ReplaceAll(string(status), "_", " ")
+	if heading == "" {
+		return heading
+	}
+	return strings.ToUpper(heading[:1]) + heading[1:]
+}
+
+// markdownEscaper escapes the characters that would otherwise format or link
+// inline text
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`, "<", `\<`, ">", `\>`,
+	"\r\n", " ", "\n", " ",
+)
+
+// markdownEscape makes text safe to place inline in a list item or heading
+func markdownEscape(text string) string {
+	return markdownEscaper.Replace(text)
+}
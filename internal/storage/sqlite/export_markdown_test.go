@@ -0,0 +1,95 @@
+package sqlite
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestExportMarkdown(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	newIssue := func(id, title string, issueType types.IssueType, priority int) string {
+		issue := &types.Issue{ID: id, Title: title, Status: types.StatusOpen, Priority: priority, IssueType: issueType}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		return issue.ID
+	}
+	addChild := func(child, parent string) {
+		dep := &types.Dependency{IssueID: child, DependsOnID: parent, Type: types.DepParentChild}
+		if err := store.AddDependency(ctx, dep, "test"); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+	}
+	export := func(filter types.IssueFilter, opts MarkdownOptions) string {
+		t.Helper()
+		var buf bytes.Buffer
+		if err := store.ExportMarkdown(ctx, &buf, filter, opts); err != nil {
+			t.Fatalf("ExportMarkdown failed: %v", err)
+		}
+		return buf.String()
+	}
+
+	epic := newIssue("bd-1", "Login revamp", types.TypeEpic, 1)
+	form := newIssue("bd-2", "Redesign the *form*", types.TypeTask, 1)
+	validation := newIssue("bd-3", "Validate fields", types.TypeTask, 2)
+	newIssue("bd-4", "Fix typo", types.TypeBug, 3)
+	addChild(form, epic)
+	addChild(validation, form)
+	if err := store.CloseIssue(ctx, validation, "done", "test"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+
+	got := export(types.IssueFilter{}, MarkdownOptions{GroupBy: MarkdownByEpic, Title: "Release", LinkTemplate: "https://example.com/{id}"})
+	want := strings.Join([]string{
+		"# Release",
+		"",
+		"## [bd-1](https://example.com/bd-1): Login revamp",
+		"",
+		"- [ ] [bd-2](https://example.com/bd-2): Redesign the \\*form\\* `P1`",
+		"  - [x] [bd-3](https://example.com/bd-3): Validate fields `P2`",
+		"",
+		"## Other issues",
+		"",
+		"- [ ] [bd-4](https://example.com/bd-4): Fix typo `P3`",
+		"",
+	}, "\n")
+	if got != want {
+		t.Errorf("Expected epic export:\n%s\ngot:\n%s", want, got)
+	}
+
+	// By status, a child is only nested under a parent in the same section
+	got = export(types.IssueFilter{}, MarkdownOptions{NoPriority: true})
+	want = strings.Join([]string{
+		"## Open",
+		"",
+		"- [ ] bd-1: Login revamp",
+		"  - [ ] bd-2: Redesign the \\*form\\*",
+		"- [ ] bd-4: Fix typo",
+		"",
+		"## Closed",
+		"",
+		"- [x] bd-3: Validate fields",
+		"",
+	}, "\n")
+	if got != want {
+		t.Errorf("Expected status export:\n%s\ngot:\n%s", want, got)
+	}
+
+	// Filtering out the epic lifts its children to the top level
+	task := types.TypeTask
+	got = export(types.IssueFilter{IssueType: &task}, MarkdownOptions{GroupBy: MarkdownByEpic, NoPriority: true})
+	want = "## Other issues\n\n- [ ] bd-2: Redesign the \\*form\\*\n  - [x] bd-3: Validate fields\n"
+	if got != want {
+		t.Errorf("Expected filtered export:\n%s\ngot:\n%s", want, got)
+	}
+
+	if err := store.ExportMarkdown(ctx, &bytes.Buffer{}, types.IssueFilter{}, MarkdownOptions{GroupBy: "assignee"}); err == nil {
+		t.Error("Expected an error for an unknown grouping")
+	}
+}
@@ -0,0 +1,38 @@
+// Package sqlite - blockers outside beads
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// SetExternalBlock marks an issue as blocked by something that isn't a beads
+// issue, such as waiting on a vendor, recording reason. Externally blocked
+// issues are excluded from GetReadyWork even when their dependencies are
+// satisfied, and are listed by GetBlockedIssues. Setting a new reason
+// replaces the old one.
+func (s *SQLiteStorage) SetExternalBlock(ctx context.Context, id, reason, actor string) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return fmt.Errorf("external block reason is required")
+	}
+	return s.UpdateIssue(ctx, id, map[string]interface{}{"external_blocked_reason": reason}, actor)
+}
+
+// ClearExternalBlock removes an issue's external block, making it ready
+// again if nothing else blocks it. Clearing an issue that isn't externally
+// blocked is a no-op.
+func (s *SQLiteStorage) ClearExternalBlock(ctx context.Context, id, actor string) error {
+	issue, err := s.GetIssue(ctx, id)
+	if err != nil {
+		return err
+	}
+	if issue == nil {
+		return fmt.Errorf("issue %s: %w", id, ErrNotFound)
+	}
+	if !issue.IsExternallyBlocked() {
+		return nil
+	}
+	return s.UpdateIssue(ctx, id, map[string]interface{}{"external_blocked_reason": nil}, actor)
+}
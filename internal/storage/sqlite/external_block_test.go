@@ -0,0 +1,82 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestExternalBlock(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Integrate vendor API", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	isReady := func() bool {
+		ready, err := store.GetReadyWork(ctx, types.WorkFilter{})
+		if err != nil {
+			t.Fatalf("GetReadyWork failed: %v", err)
+		}
+		for _, r := range ready {
+			if r.ID == issue.ID {
+				return true
+			}
+		}
+		return false
+	}
+	if !isReady() {
+		t.Fatal("Expected issue to be ready before blocking")
+	}
+
+	if err := store.SetExternalBlock(ctx, issue.ID, "  ", "test"); err == nil {
+		t.Error("Expected an empty reason to be rejected")
+	}
+	if err := store.SetExternalBlock(ctx, issue.ID, "Waiting on vendor credentials", "test"); err != nil {
+		t.Fatalf("SetExternalBlock failed: %v", err)
+	}
+
+	got, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.ExternalBlockedReason == nil || *got.ExternalBlockedReason != "Waiting on vendor credentials" {
+		t.Errorf("Expected external blocked reason, got %v", got.ExternalBlockedReason)
+	}
+	if isReady() {
+		t.Error("Expected externally blocked issue not to be ready")
+	}
+
+	blocked, err := store.GetBlockedIssues(ctx)
+	if err != nil {
+		t.Fatalf("GetBlockedIssues failed: %v", err)
+	}
+	if len(blocked) != 1 || blocked[0].ID != issue.ID || blocked[0].ExternalBlockedReason == nil {
+		t.Errorf("Expected %s listed as externally blocked, got %+v", issue.ID, blocked)
+	}
+
+	if err := store.ClearExternalBlock(ctx, issue.ID, "test"); err != nil {
+		t.Fatalf("ClearExternalBlock failed: %v", err)
+	}
+	got, err = store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.IsExternallyBlocked() {
+		t.Errorf("Expected external block to be cleared, got %q", *got.ExternalBlockedReason)
+	}
+	if !isReady() {
+		t.Error("Expected issue to be ready again after clearing")
+	}
+
+	// Clearing again is a no-op; unknown issues are an error
+	if err := store.ClearExternalBlock(ctx, issue.ID, "test"); err != nil {
+		t.Errorf("Expected clearing an unblocked issue to succeed, got %v", err)
+	}
+	if err := store.ClearExternalBlock(ctx, "bd-missing", "test"); err == nil {
+		t.Error("Expected clearing a missing issue to fail")
+	}
+}
@@ -264,7 +264,7 @@ func TestExternalRefIndex(t *testing.T) {
 
 	// Verify that the external_ref index exists
 	var indexExists bool
-	err := s.db.QueryRowContext(ctx, `
+	err := s.db().QueryRowContext(ctx, `
 		SELECT EXISTS(
 			SELECT 1 FROM sqlite_master 
 			WHERE type='index' AND name='idx_issues_external_ref'
@@ -300,7 +300,7 @@ func TestExternalRefIndexUsage(t *testing.T) {
 		t.Fatalf("Failed to create issue: %v", err)
 	}
 
-	rows, err := s.db.QueryContext(ctx, `
+	rows, err := s.db().QueryContext(ctx, `
 		EXPLAIN QUERY PLAN
 		SELECT id, title, description, design, acceptance_criteria, notes, status, priority, issue_type, assignee,
 			created_at, updated_at, closed_at, external_ref,
@@ -0,0 +1,73 @@
+// Package sqlite - complexity limits for search filters
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// filterLimits are the configured search filter complexity limits; 0 means
+// unlimited
+type filterLimits struct {
+	labelClauses int
+	wildcards    int
+}
+
+// readFilterLimits reads the limits on q, falling back to the defaults for
+// any that are unset or invalid
+func readFilterLimits(ctx context.Context, q queryer) filterLimits {
+	limits := filterLimits{labelClauses: DefaultFilterMaxLabelClauses, wildcards: DefaultFilterMaxWildcards}
+	rows, err := q.QueryContext(ctx, `SELECT key, value FROM config WHERE key IN (?, ?)`,
+		FilterMaxLabelClausesConfigKey, FilterMaxWildcardsConfigKey)
+	if err != nil {
+		return limits
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return limits
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || n < 0 {
+			continue
+		}
+		switch key {
+		case FilterMaxLabelClausesConfigKey:
+			limits.labelClauses = n
+		case FilterMaxWildcardsConfigKey:
+			limits.wildcards = n
+		}
+	}
+	return limits
+}
+
+// checkFilterComplexity rejects a search whose label clauses or free-text
+// LIKE wildcards exceed the configured limits with ErrFilterTooComplex, so
+// untrusted callers of a shared daemon can't submit arbitrarily expensive
+// queries
+func checkFilterComplexity(ctx context.Context, q queryer, query string, filter types.IssueFilter) error {
+	limits := readFilterLimits(ctx, q)
+
+	if labels := len(filter.Labels) + len(filter.LabelsAny) + len(filter.LabelGlobs); limits.labelClauses > 0 && labels > limits.labelClauses {
+		return fmt.Errorf("%w: %d label clauses (limit %d)", ErrFilterTooComplex, labels, limits.labelClauses)
+	}
+
+	wildcards := 0
+	terms := []string{query, filter.TitleSearch, filter.TitleContains, filter.DescriptionContains, filter.NotesContains}
+	for _, text := range filter.SectionContains {
+		terms = append(terms, text)
+	}
+	for _, term := range terms {
+		wildcards += strings.Count(term, "%") + strings.Count(term, "_")
+	}
+	if limits.wildcards > 0 && wildcards > limits.wildcards {
+		return fmt.Errorf("%w: %d wildcards in search text (limit %d)", ErrFilterTooComplex, wildcards, limits.wildcards)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestSearchIssuesComplexityLimits(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	labels := make([]string, DefaultFilterMaxLabelClauses+1)
+	for i := range labels {
+		labels[i] = fmt.Sprintf("l%d", i)
+	}
+
+	// Generous defaults allow ordinary filters
+	if _, err := store.SearchIssues(ctx, "50%_done", types.IssueFilter{LabelsAny: labels[:10]}); err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+
+	_, err := store.SearchIssues(ctx, "", types.IssueFilter{LabelsAny: labels})
+	if !errors.Is(err, ErrFilterTooComplex) {
+		t.Errorf("Expected ErrFilterTooComplex for too many labels, got %v", err)
+	}
+	_, err = store.SearchIssues(ctx, strings.Repeat("%", DefaultFilterMaxWildcards+1), types.IssueFilter{})
+	if !errors.Is(err, ErrFilterTooComplex) {
+		t.Errorf("Expected ErrFilterTooComplex for too many wildcards, got %v", err)
+	}
+
+	// Limits are tunable, and apply inside transactions too
+	if err := store.SetConfig(ctx, FilterMaxLabelClausesConfigKey, "2"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	err = store.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		_, err := tx.SearchIssues(ctx, "", types.IssueFilter{Labels: labels[:2], LabelsAny: labels[2:3]})
+		return err
+	})
+	if !errors.Is(err, ErrFilterTooComplex) {
+		t.Errorf("Expected ErrFilterTooComplex in transaction, got %v", err)
+	}
+
+	// 0 disables the limit
+	if err := store.SetConfig(ctx, FilterMaxLabelClausesConfigKey, "0"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	if _, err := store.SearchIssues(ctx, "", types.IssueFilter{LabelsAny: labels}); err != nil {
+		t.Errorf("Expected no limit, got %v", err)
+	}
+}
@@ -0,0 +1,107 @@
+// Package sqlite - feature flags backed by config
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FeatureFlag describes a boolean toggle for optional store behavior. Its
+// Name is also the config key holding its value, so flags can be set with
+// SetFlag or `bd config set` alike.
+type FeatureFlag struct {
+	Name        string
+	Description string
+	Default     bool
+}
+
+// FeatureFlagState is a registered flag together with its current value
+type FeatureFlagState struct {
+	FeatureFlag
+	Enabled bool
+	// Set reports whether the value comes from config rather than Default
+	Set bool
+}
+
+// knownFlags is the registry of feature flags, keyed by name. Register new
+// toggles here so they show up in ListFlags.
+var knownFlags = map[string]FeatureFlag{
+	CaseInsensitiveLabelsConfigKey: {
+		Name:        CaseInsensitiveLabelsConfigKey,
+		Description: "Label filters ignore case and surrounding whitespace",
+	},
+	RequireClosedChildrenConfigKey: {
+		Name:        RequireClosedChildrenConfigKey,
+		Description: "Refuse to close a parent while it has open children",
+	},
+	AutoUnblockConfigKey: {
+		Name:        AutoUnblockConfigKey,
+		Description: "Reopen blocked issues when their last blocker closes",
+	},
+	AutoCloseEpicConfigKey: {
+		Name:        AutoCloseEpicConfigKey,
+		Description: "Close epics when their last open child closes",
+	},
+	CloseOnPercentCompleteConfigKey: {
+		Name:        CloseOnPercentCompleteConfigKey,
+		Description: "Close issues when their percent complete is set to 100",
+	},
+}
+
+// IsEnabled reports whether flag is on: its config value if set and a valid
+// boolean, otherwise the registered default (false for unregistered flags).
+func (s *SQLiteStorage) IsEnabled(ctx context.Context, flag string) bool {
+	enabled, err := flagEnabled(ctx, s.db(), flag)
+	if err != nil {
+		return knownFlags[flag].Default
+	}
+	return enabled
+}
+
+// SetFlag turns flag on or off. Only registered flags can be set, so typos
+// fail instead of silently creating a config entry nothing reads.
+func (s *SQLiteStorage) SetFlag(ctx context.Context, flag string, on bool) error {
+	if _, ok := knownFlags[flag]; !ok {
+		return fmt.Errorf("unknown feature flag: %s", flag)
+	}
+	return s.SetConfig(ctx, flag, strconv.FormatBool(on))
+}
+
+// ListFlags returns every registered flag with its current value, sorted by name
+func (s *SQLiteStorage) ListFlags(ctx context.Context) ([]FeatureFlagState, error) {
+	flags := make([]FeatureFlagState, 0, len(knownFlags))
+	for name, flag := range knownFlags {
+		value, err := s.GetConfig(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		state := FeatureFlagState{FeatureFlag: flag, Enabled: flag.Default}
+		if enabled, err := strconv.ParseBool(strings.TrimSpace(value)); err == nil {
+			state.Enabled = enabled
+			state.Set = true
+		}
+		flags = append(flags, state)
+	}
+	sort.Slice(flags, func(i, j int) bool { return flags[i].Name < flags[j].Name })
+	return flags, nil
+}
+
+// flagEnabled reads flag on q so checks inside a write see the setting within
+// their own transaction. Unset or unparseable values fall back to the
+// registered default.
+func flagEnabled(ctx context.Context, q queryer, flag string) (bool, error) {
+	var value string
+	err := q.QueryRowContext(ctx, `SELECT value FROM config WHERE key = ?`, flag).Scan(&value)
+	if err != nil && err != sql.ErrNoRows {
+		return false, wrapDBError("get config", err)
+	}
+	enabled, err := strconv.ParseBool(strings.TrimSpace(value))
+	if err != nil {
+		return knownFlags[flag].Default, nil
+	}
+	return enabled, nil
+}
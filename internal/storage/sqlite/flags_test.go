@@ -0,0 +1,55 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+)
+
+func TestFeatureFlags(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	if store.IsEnabled(ctx, CaseInsensitiveLabelsConfigKey) {
+		t.Error("Expected flag to default to off")
+	}
+	if err := store.SetFlag(ctx, CaseInsensitiveLabelsConfigKey, true); err != nil {
+		t.Fatalf("SetFlag failed: %v", err)
+	}
+	if !store.IsEnabled(ctx, CaseInsensitiveLabelsConfigKey) {
+		t.Error("Expected flag to be on after SetFlag")
+	}
+
+	// Flags and plain config share keys
+	if err := store.SetConfig(ctx, CaseInsensitiveLabelsConfigKey, "false"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	if store.IsEnabled(ctx, CaseInsensitiveLabelsConfigKey) {
+		t.Error("Expected flag to follow config value")
+	}
+
+	if err := store.SetFlag(ctx, "labels.case_insensitve", true); err == nil {
+		t.Error("Expected unknown flag to be rejected")
+	}
+	if store.IsEnabled(ctx, "no.such.flag") {
+		t.Error("Expected unknown flag to be off")
+	}
+
+	if err := store.SetFlag(ctx, RequireClosedChildrenConfigKey, true); err != nil {
+		t.Fatalf("SetFlag failed: %v", err)
+	}
+	flags, err := store.ListFlags(ctx)
+	if err != nil {
+		t.Fatalf("ListFlags failed: %v", err)
+	}
+	if len(flags) != len(knownFlags) {
+		t.Fatalf("Expected %d flags, got %d", len(knownFlags), len(flags))
+	}
+	for i, f := range flags {
+		if i > 0 && flags[i-1].Name >= f.Name {
+			t.Errorf("Expected flags sorted by name, got %s before %s", flags[i-1].Name, f.Name)
+		}
+		if f.Name == RequireClosedChildrenConfigKey && (!f.Enabled || !f.Set) {
+			t.Errorf("Expected %s enabled from config, got %+v", f.Name, f)
+		}
+	}
+}
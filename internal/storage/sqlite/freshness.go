@@ -0,0 +1,457 @@
+// Package sqlite - database file freshness checking
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/beads/internal/debug"
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+// FreshnessOptions configures EnableFreshnessChecking
+type FreshnessOptions = storage.FreshnessOptions
+
+// FreshnessChecker detects when the database file has been replaced on disk.
+//
+// A git merge or checkout can atomically swap in a different beads.db (new
+// inode) while a long-lived process such as the daemon still holds connections
+// to the old file. Those connections keep serving the pre-merge data, and an
+// auto-import running against that stale view can wrongly conclude that issues
+// created on the merged branch were deleted.
+//
+// The checker remembers the identity of the file it last connected to and
+// reports when the path now points at a different file, so the store can
+// reconnect before serving the next read.
+type FreshnessChecker struct {
+	path         string
+	pollInterval time.Duration                   // Background check period; 0 checks on every read
+	onReconnect  func(oldInode, newInode uint64) // FreshnessOptions.OnReconnect
+	stop         chan struct{}                   // Closed to stop the poller
+	done         chan struct{}                   // Closed when the poller exits
+	stopOnce     sync.Once
+
+	mu            sync.Mutex
+	info          os.FileInfo // Identity of the file we are connected to
+	lastCheck     time.Time
+	lastReconnect time.Time
+	reconnects    int
+	paused        int // Outstanding PauseFreshness calls
+}
+
+// newFreshnessChecker records the current identity of the database file at path.
+func newFreshnessChecker(path string, opts FreshnessOptions) (*FreshnessChecker, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat database file: %w", err)
+	}
+	return &FreshnessChecker{
+		path:         path,
+		pollInterval: opts.PollInterval,
+		onReconnect:  opts.OnReconnect,
+		info:         info,
+		lastCheck:    time.Now(),
+	}, nil
+}
+
+// replaced reports whether the path now refers to a different file than the one
+// we are connected to. It returns an error if the file can't be stat'ed (e.g.
+// mid-rename); only successful checks count towards sinceLastCheck.
+func (fc *FreshnessChecker) replaced() (bool, os.FileInfo, error) {
+	current, err := os.Stat(fc.path)
+	if err != nil {
+		return false, nil, fmt.Errorf("failed to stat database file: %w", err)
+	}
+
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	fc.lastCheck = time.Now()
+	return !os.SameFile(fc.info, current), current, nil
+}
+
+// sinceLastCheck returns how long ago the file identity was last verified
+func (fc *FreshnessChecker) sinceLastCheck() time.Duration {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	return time.Since(fc.lastCheck)
+}
+
+// isPaused reports whether checking is suspended by PauseFreshness
+func (fc *FreshnessChecker) isPaused() bool {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	return fc.paused > 0
+}
+
+// markReconnected records that the store is now connected to the file
+// described by info, and returns the file it was connected to before
+func (fc *FreshnessChecker) markReconnected(info os.FileInfo) os.FileInfo {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	old := fc.info
+	fc.info = info
+	fc.lastReconnect = time.Now()
+	fc.reconnects++
+	return old
+}
+
+// stopPolling stops the background poller, if there is one, and waits for
+// it to exit. It is safe to call more than once.
+func (fc *FreshnessChecker) stopPolling() {
+	if fc.stop == nil {
+		return
+	}
+	fc.stopOnce.Do(func() { close(fc.stop) })
+	<-fc.done
+}
+
+// EnableFreshnessChecking turns on detection of database file replacement.
+// Once enabled, read operations stat the database file first and transparently
+// reconnect if it was replaced (e.g. by a git merge); with
+// opts.PollInterval set, a background goroutine checks that often instead,
+// until Close. Intended for long-lived processes like the daemon;
+// short-lived CLI invocations don't need it. Calling it more than once is a
+// no-op. In-memory databases are never checked.
+func (s *SQLiteStorage) EnableFreshnessChecking(opts FreshnessOptions) error {
+	if s.isInMemory {
+		return nil
+	}
+	if opts.PollInterval < 0 {
+		return fmt.Errorf("invalid freshness poll interval %v", opts.PollInterval)
+	}
+	s.reconnectMu.Lock()
+	defer s.reconnectMu.Unlock()
+	if s.freshness != nil || s.closed.Load() {
+		return nil
+	}
+	fc, err := newFreshnessChecker(s.dbPath, opts)
+	if err != nil {
+		return err
+	}
+	s.freshness = fc
+	if fc.pollInterval > 0 {
+		fc.stop = make(chan struct{})
+		fc.done = make(chan struct{})
+		go s.pollFreshness(fc)
+	}
+	return nil
+}
+
+// pollFreshness checks fc every poll interval until stopPolling
+func (s *SQLiteStorage) pollFreshness(fc *FreshnessChecker) {
+	defer close(fc.done)
+	ticker := time.NewTicker(fc.pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-fc.stop:
+			return
+		case <-ticker.C:
+			s.refreshConnection(fc)
+		}
+	}
+}
+
+// FreshnessCheckingEnabled reports whether EnableFreshnessChecking has been called.
+func (s *SQLiteStorage) FreshnessCheckingEnabled() bool {
+	s.reconnectMu.RLock()
+	defer s.reconnectMu.RUnlock()
+	return s.freshness != nil
+}
+
+// checkFreshness reconnects if the database file was replaced since we last
+// connected. It is called at the start of read operations and is a no-op when
+// freshness checking is disabled or done by the background poller.
+func (s *SQLiteStorage) checkFreshness() {
+	s.reconnectMu.RLock()
+	fc := s.freshness
+	s.reconnectMu.RUnlock()
+	if fc == nil || fc.pollInterval > 0 {
+		return
+	}
+	s.refreshConnection(fc)
+}
+
+// beginRead starts a read operation: it checks freshness as checkFreshness
+// does, then pins the current connection pool until the returned function is
+// called, so that a reconnect can't close the pool under the read. Use it as
+// defer s.beginRead()().
+func (s *SQLiteStorage) beginRead() (release func()) {
+	s.checkFreshness()
+	_, release = s.pinPool()
+	return release
+}
+
+// pinPool returns the current connection pool, counted as in use until the
+// returned function is called. A pool retired by reconnect is closed when its
+// last pin is released (see snapshotReaders).
+func (s *SQLiteStorage) pinPool() (*sql.DB, func()) {
+	s.reconnectMu.RLock()
+	db := s.db()
+	s.snapshots.acquire(db)
+	s.reconnectMu.RUnlock()
+	return db, func() { s.snapshots.release(db) }
+}
+
+// refreshConnection reconnects if the database file was replaced. A missing
+// file is not treated as a replacement (the next check picks up the new
+// file once it lands), and reconnect failures are logged and the old
+// connection is kept, so reads degrade to stale rather than failing
+// outright.
+func (s *SQLiteStorage) refreshConnection(fc *FreshnessChecker) {
+	if s.closed.Load() || fc.isPaused() {
+		return
+	}
+
+	replaced, info, err := fc.replaced()
+	if err != nil || !replaced {
+		return
+	}
+	if err := s.reconnect(fc, info, false); err != nil {
+		debug.Logf("Debug: freshness reconnect to %s failed: %v\n", s.dbPath, err)
+	}
+}
+
+// ReadFresh runs fn after making sure the store's connection is no more than
+// maxStaleness behind the database file on disk. If the file identity was
+// last verified longer ago than that (always, for maxStaleness <= 0), it is
+// checked again and the store reconnects if the file was replaced.
+//
+// Unlike the implicit check made by every read, ReadFresh fails instead of
+// falling back to the old connection when the file can't be checked or the
+// reconnect fails, so fn never runs against data it can't vouch for. It
+// requires EnableFreshnessChecking and fails while checking is paused (see
+// PauseFreshness); in-memory databases are always fresh.
+func (s *SQLiteStorage) ReadFresh(ctx context.Context, maxStaleness time.Duration, fn func(ctx context.Context) error) error {
+	if s.isInMemory {
+		return fn(ctx)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.reconnectMu.RLock()
+	fc := s.freshness
+	s.reconnectMu.RUnlock()
+	if fc == nil {
+		return fmt.Errorf("fresh read requires freshness checking to be enabled")
+	}
+	if fc.isPaused() {
+		return fmt.Errorf("fresh read: freshness checking is paused")
+	}
+
+	if maxStaleness <= 0 || fc.sinceLastCheck() > maxStaleness {
+		replaced, info, err := fc.replaced()
+		if err != nil {
+			return fmt.Errorf("fresh read: %w", err)
+		}
+		if replaced {
+			if err := s.reconnect(fc, info, false); err != nil {
+				return fmt.Errorf("fresh read: failed to reconnect to replaced database: %w", err)
+			}
+		}
+	}
+	return fn(ctx)
+}
+
+// reconnect opens a fresh connection pool to the (replaced) database file and
+// swaps it in, retiring the old pool. Reads, transactions and Snapshots that
+// pinned the old pool (see pinPool) keep it open until they complete. Unless force is set, it does nothing if the store
+// is already connected to the file described by info. The OnReconnect
+// callback runs after the swap, outside the store's locks, so it may use the
+// store.
+func (s *SQLiteStorage) reconnect(fc *FreshnessChecker, info os.FileInfo, force bool) error {
+	old, err := s.swapConnection(fc, info, force)
+	if err != nil || old == nil {
+		return err
+	}
+	if fc.onReconnect != nil {
+		fc.onReconnect(fileInode(old), fileInode(info))
+	}
+	return nil
+}
+
+// swapConnection does the work of reconnect, returning the file the store
+// was connected to before, or nil if it didn't reconnect
+func (s *SQLiteStorage) swapConnection(fc *FreshnessChecker, info os.FileInfo, force bool) (os.FileInfo, error) {
+	s.reconnectMu.Lock()
+	defer s.reconnectMu.Unlock()
+	if s.closed.Load() {
+		return nil, nil
+	}
+	// Another reader may have reconnected while we waited for the lock
+	fc.mu.Lock()
+	alreadyCurrent := os.SameFile(fc.info, info)
+	fc.mu.Unlock()
+	if alreadyCurrent && !force {
+		return nil, nil
+	}
+
+	db, err := openDB(s.connStr, s.isInMemory)
+	if err != nil {
+		return nil, err
+	}
+	// The new file may come from an older bd (or a newer one, which is
+	// refused and leaves the store on the old file)
+	if err := initSchema(context.Background(), db); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to reconnect: %w", err)
+	}
+	oldDB := s.db()
+	s.setPool(db)
+	oldInfo := fc.markReconnected(info)
+
+	// Reads still running on the old pool keep it open until they finish
+	s.snapshots.retire(oldDB)
+	return oldInfo, nil
+}
+
+// PauseFreshness suspends freshness checking, for bulk operations such as a
+// large import that rewrite the database file: reconnecting in the middle
+// would churn connections and could see the file half-written. Reads keep
+// using the current connection until the matching ResumeFreshness. Pauses
+// nest, and are no-ops when checking isn't enabled.
+func (s *SQLiteStorage) PauseFreshness() {
+	s.reconnectMu.RLock()
+	fc := s.freshness
+	s.reconnectMu.RUnlock()
+	if fc == nil {
+		return
+	}
+	fc.mu.Lock()
+	fc.paused++
+	fc.mu.Unlock()
+}
+
+// ResumeFreshness ends a PauseFreshness. When the last pause ends, the store
+// reconnects once to the database file as it now stands, whether or not it
+// was replaced, so reads see everything the bulk operation wrote. Calls
+// without a matching pause are ignored.
+func (s *SQLiteStorage) ResumeFreshness() error {
+	s.reconnectMu.RLock()
+	fc := s.freshness
+	s.reconnectMu.RUnlock()
+	if fc == nil {
+		return nil
+	}
+	fc.mu.Lock()
+	if fc.paused == 0 {
+		fc.mu.Unlock()
+		return nil
+	}
+	fc.paused--
+	resumed := fc.paused == 0
+	fc.mu.Unlock()
+	if !resumed {
+		return nil
+	}
+
+	_, info, err := fc.replaced()
+	if err != nil {
+		return fmt.Errorf("failed to resume freshness checking: %w", err)
+	}
+	if err := s.reconnect(fc, info, true); err != nil {
+		return fmt.Errorf("failed to reconnect after resuming freshness checking: %w", err)
+	}
+	return nil
+}
+
+// WithFreshnessPaused runs fn with freshness checking paused (see
+// PauseFreshness) and resumes it afterwards, even if fn fails. fn's error
+// takes precedence over a failure to resume.
+func (s *SQLiteStorage) WithFreshnessPaused(fn func() error) error {
+	s.PauseFreshness()
+	err := fn()
+	if resumeErr := s.ResumeFreshness(); err == nil {
+		err = resumeErr
+	}
+	return err
+}
+
+// ReadSnapshot runs fn in a read-only transaction, so every query fn makes
+// sees the same consistent snapshot of the database.
+//
+// If the database file is replaced while fn is running, fn keeps reading the
+// old file to completion; reads started afterwards go to the new file. The old
+// connection pool is closed once its last snapshot read finishes.
+func (s *SQLiteStorage) ReadSnapshot(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	s.checkFreshness()
+
+	db, release := s.pinPool()
+	defer release()
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return wrapDBError("begin read transaction", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return wrapDBError("end read transaction", tx.Commit())
+}
+
+// snapshotReaders counts the pins on each connection pool (see pinPool): the
+// in-flight reads, transactions and ReadSnapshot calls and the open
+// Snapshots using it, so that a pool replaced by reconnect is closed as soon
+// as its last user is done.
+type snapshotReaders struct {
+	mu      sync.Mutex
+	readers map[*sql.DB]int
+	retired map[*sql.DB]bool
+}
+
+func (r *snapshotReaders) acquire(db *sql.DB) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.readers == nil {
+		r.readers = make(map[*sql.DB]int)
+	}
+	r.readers[db]++
+}
+
+// release ends a read on db, closing db if it was retired and this was its last reader
+func (r *snapshotReaders) release(db *sql.DB) {
+	r.mu.Lock()
+	r.readers[db]--
+	drained := r.readers[db] == 0
+	if drained {
+		delete(r.readers, db)
+	}
+	closeNow := drained && r.retired[db]
+	if closeNow {
+		delete(r.retired, db)
+	}
+	r.mu.Unlock()
+
+	if closeNow {
+		closeStalePool(db)
+	}
+}
+
+// retire closes db now if nothing has it pinned, or else when its last pin
+// is released
+func (r *snapshotReaders) retire(db *sql.DB) {
+	r.mu.Lock()
+	if r.readers[db] > 0 {
+		if r.retired == nil {
+			r.retired = make(map[*sql.DB]bool)
+		}
+		r.retired[db] = true
+		r.mu.Unlock()
+		return
+	}
+	r.mu.Unlock()
+
+	closeStalePool(db)
+}
+
+func closeStalePool(db *sql.DB) {
+	if err := db.Close(); err != nil {
+		debug.Logf("Debug: failed to close stale connection pool: %v\n", err)
+	}
+}
@@ -0,0 +1,10 @@
+//go:build !unix
+
+package sqlite
+
+import "os"
+
+// fileInode returns 0: inode numbers aren't exposed on this platform
+func fileInode(info os.FileInfo) uint64 {
+	return 0
+}
@@ -0,0 +1,16 @@
+//go:build unix
+
+package sqlite
+
+import (
+	"os"
+	"syscall"
+)
+
+// fileInode returns the inode number behind info, or 0 if unavailable
+func fileInode(info os.FileInfo) uint64 {
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+		return uint64(stat.Ino) // #nosec G115 -- Ino is unsigned on every unix
+	}
+	return 0
+}
@@ -0,0 +1,129 @@
+// Package sqlite - freshness diagnostics
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+)
+
+// FreshnessReport describes how the store's connection relates to the
+// database file on disk. Inodes are 0 on platforms that don't expose them.
+type FreshnessReport struct {
+	Path            string `json:"path"`
+	CheckingEnabled bool   `json:"checking_enabled"`
+	CheckingPaused  bool   `json:"checking_paused"` // See PauseFreshness
+
+	// The file at Path now
+	Inode   uint64    `json:"inode"`
+	ModTime time.Time `json:"mod_time"`
+
+	// The file the store is connected to (known only with checking enabled)
+	ConnectedInode   uint64    `json:"connected_inode,omitempty"`
+	ConnectedModTime time.Time `json:"connected_mod_time,omitempty"`
+
+	// ReplacementPending is set when Path holds a different file than the
+	// one the store is connected to, and the next read will reconnect
+	ReplacementPending bool `json:"replacement_pending"`
+
+	// ChangedIssues are the IDs of issues that differ (added, removed, or
+	// with a different content hash or update time) between the store's
+	// connection and a fresh read of Path
+	ChangedIssues []string `json:"changed_issues"`
+
+	LastReconnect *time.Time `json:"last_reconnect,omitempty"`
+	Reconnects    int        `json:"reconnects"`
+}
+
+// FreshnessReport reports what a freshness reconnect would change, without
+// reconnecting: the identity of the file on disk and of the one the store is
+// connected to, and which issues a fresh read of the file sees differently.
+// It is a debugging aid for confirming that a git merge replacing the
+// database was picked up. In-memory databases report nothing changed.
+func (s *SQLiteStorage) FreshnessReport(ctx context.Context) (FreshnessReport, error) {
+	report := FreshnessReport{Path: s.dbPath, ChangedIssues: []string{}}
+	if s.isInMemory {
+		return report, nil
+	}
+
+	current, err := os.Stat(s.dbPath)
+	if err != nil {
+		return report, fmt.Errorf("failed to stat database file: %w", err)
+	}
+	report.Inode = fileInode(current)
+	report.ModTime = current.ModTime()
+
+	s.reconnectMu.RLock()
+	fc := s.freshness
+	s.reconnectMu.RUnlock()
+	db, release := s.pinPool()
+	defer release()
+
+	if fc != nil {
+		report.CheckingEnabled = true
+		fc.mu.Lock()
+		report.ConnectedInode = fileInode(fc.info)
+		report.ConnectedModTime = fc.info.ModTime()
+		report.ReplacementPending = !os.SameFile(fc.info, current)
+		if !fc.lastReconnect.IsZero() {
+			lastReconnect := fc.lastReconnect
+			report.LastReconnect = &lastReconnect
+		}
+		report.Reconnects = fc.reconnects
+		report.CheckingPaused = fc.paused > 0
+		fc.mu.Unlock()
+	}
+
+	connected, err := issueVersions(ctx, db)
+	if err != nil {
+		return report, err
+	}
+	freshDB, err := openDB(s.connStr, false)
+	if err != nil {
+		return report, fmt.Errorf("failed to open database file for a fresh read: %w", err)
+	}
+	defer func() { _ = freshDB.Close() }()
+	fresh, err := issueVersions(ctx, freshDB)
+	if err != nil {
+		return report, err
+	}
+
+	for id, version := range connected {
+		if fresh[id] != version {
+			report.ChangedIssues = append(report.ChangedIssues, id)
+		}
+	}
+	for id := range fresh {
+		if _, ok := connected[id]; !ok {
+			report.ChangedIssues = append(report.ChangedIssues, id)
+		}
+	}
+	sort.Strings(report.ChangedIssues)
+	return report, nil
+}
+
+// issueVersions returns each issue's content hash and update time on q
+func issueVersions(ctx context.Context, q queryer) (map[string]string, error) {
+	rows, err := q.QueryContext(ctx, `
+		SELECT id, COALESCE(content_hash, '') || '|' || CAST(updated_at AS TEXT) FROM issues
+	`)
+	if err != nil {
+		return nil, wrapDBError("read issue versions", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	versions := make(map[string]string)
+	for rows.Next() {
+		var id, version string
+		if err := rows.Scan(&id, &version); err != nil {
+			return nil, wrapDBError("scan issue version", err)
+		}
+		versions[id] = version
+	}
+	if err := rows.Err(); err != nil {
+		return nil, wrapDBError("iterate issue versions", err)
+	}
+	return versions, nil
+}
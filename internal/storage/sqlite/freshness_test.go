@@ -2,8 +2,14 @@ package sqlite
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
 	"syscall"
 	"testing"
 	"time"
@@ -118,8 +124,11 @@ func TestBranchMergeNoErroneousDeletion(t *testing.T) {
 	}
 	defer daemonStore.Close()
 
-	// NOTE: On the fix branch, EnableFreshnessChecking() is called here.
-	// Without the fix, this method doesn't exist and the daemon will have stale data.
+	// Enable freshness checking so the daemon notices the DB file being replaced.
+	// Without it, the daemon keeps reading the old inode and has stale data.
+	if err := daemonStore.EnableFreshnessChecking(FreshnessOptions{}); err != nil {
+		t.Fatalf("failed to enable freshness checking: %v", err)
+	}
 	daemonStore.SetConfig(ctx, "issue_prefix", "bd")
 
 	// Verify daemon sees only issue A initially
@@ -199,3 +208,772 @@ func TestBranchMergeNoErroneousDeletion(t *testing.T) {
 		t.Error("ERRONEOUS DELETION: Issue B was deleted!")
 	}
 }
+
+// TestReadSnapshotSurvivesReconnect replaces the database file in the middle of
+// a snapshot read. The in-flight read must finish against the old file, reads
+// started afterwards must see the new file, and the old pool must be closed
+// once the snapshot read is done.
+func TestReadSnapshotSurvivesReconnect(t *testing.T) {
+	ctx := context.Background()
+	tmpDir := t.TempDir()
+	mainDBPath := filepath.Join(tmpDir, "beads.db")
+	branchDBPath := filepath.Join(tmpDir, "branch", "beads.db")
+
+	createIssues := func(path string, n int) {
+		s, err := New(ctx, path)
+		if err != nil {
+			t.Fatalf("failed to create store: %v", err)
+		}
+		defer s.Close()
+		if err := s.SetConfig(ctx, "issue_prefix", "bd"); err != nil {
+			t.Fatalf("SetConfig failed: %v", err)
+		}
+		for i := 0; i < n; i++ {
+			issue := &types.Issue{Title: "Issue", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+			if err := s.CreateIssue(ctx, issue, "test"); err != nil {
+				t.Fatalf("CreateIssue failed: %v", err)
+			}
+		}
+	}
+	createIssues(mainDBPath, 5)
+	createIssues(branchDBPath, 2)
+
+	daemonStore, err := New(ctx, mainDBPath)
+	if err != nil {
+		t.Fatalf("failed to open daemon store: %v", err)
+	}
+	defer daemonStore.Close()
+	if err := daemonStore.EnableFreshnessChecking(FreshnessOptions{}); err != nil {
+		t.Fatalf("failed to enable freshness checking: %v", err)
+	}
+	oldDB := daemonStore.UnderlyingDB()
+
+	var seen []string
+	err = daemonStore.ReadSnapshot(ctx, func(tx *sql.Tx) error {
+		rows, err := tx.QueryContext(ctx, `SELECT id FROM issues ORDER BY id`)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var id string
+			if err := rows.Scan(&id); err != nil {
+				return err
+			}
+			seen = append(seen, id)
+
+			if len(seen) != 1 {
+				continue
+			}
+
+			// Simulate a git merge replacing the file mid-iteration
+			os.Remove(mainDBPath + "-wal")
+			os.Remove(mainDBPath + "-shm")
+			content, err := os.ReadFile(branchDBPath)
+			if err != nil {
+				t.Fatalf("failed to read branch DB: %v", err)
+			}
+			if err := os.WriteFile(mainDBPath+".new", content, 0644); err != nil {
+				t.Fatalf("failed to write temp file: %v", err)
+			}
+			if err := os.Rename(mainDBPath+".new", mainDBPath); err != nil {
+				t.Fatalf("failed to rename: %v", err)
+			}
+
+			// A new read reconnects and sees the replaced file
+			issues, err := daemonStore.SearchIssues(ctx, "", types.IssueFilter{})
+			if err != nil {
+				t.Fatalf("SearchIssues during snapshot failed: %v", err)
+			}
+			if len(issues) != 2 {
+				t.Errorf("Expected new read to see 2 issues, got %d", len(issues))
+			}
+			if daemonStore.UnderlyingDB() == oldDB {
+				t.Fatal("Expected store to reconnect to the replaced file")
+			}
+			if err := oldDB.PingContext(ctx); err != nil {
+				t.Errorf("Old pool closed while a snapshot read was in flight: %v", err)
+			}
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		// Later queries in the same snapshot still see the old file
+		var count int
+		if err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM issues`).Scan(&count); err != nil {
+			return err
+		}
+		if count != 5 {
+			t.Errorf("Expected snapshot to still see 5 issues, got %d", count)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ReadSnapshot failed across reconnect: %v", err)
+	}
+	if len(seen) != 5 {
+		t.Errorf("Expected snapshot iteration to see all 5 original issues, got %d", len(seen))
+	}
+
+	if err := oldDB.PingContext(ctx); err == nil {
+		t.Error("Expected old pool to be closed after its last snapshot read finished")
+	}
+
+	issues, err := daemonStore.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(issues) != 2 {
+		t.Errorf("Expected 2 issues after snapshot read, got %d", len(issues))
+	}
+}
+
+// TestReadFresh checks that ReadFresh reconnects to a replaced file before
+// running fn, honors the staleness budget, and refuses to run when it can't
+// verify freshness.
+func TestReadFresh(t *testing.T) {
+	ctx := context.Background()
+	tmpDir := t.TempDir()
+	mainDBPath := filepath.Join(tmpDir, "beads.db")
+	branchDBPath := filepath.Join(tmpDir, "branch", "beads.db")
+
+	for _, path := range []string{mainDBPath, branchDBPath} {
+		s, err := New(ctx, path)
+		if err != nil {
+			t.Fatalf("failed to create store: %v", err)
+		}
+		if err := s.SetConfig(ctx, "issue_prefix", "bd"); err != nil {
+			t.Fatalf("SetConfig failed: %v", err)
+		}
+		s.Close()
+	}
+
+	store, err := New(ctx, mainDBPath)
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+	defer store.Close()
+
+	noop := func(ctx context.Context) error { return nil }
+	if err := store.ReadFresh(ctx, 0, noop); err == nil {
+		t.Error("Expected ReadFresh to fail without freshness checking enabled")
+	}
+	if err := store.EnableFreshnessChecking(FreshnessOptions{}); err != nil {
+		t.Fatalf("failed to enable freshness checking: %v", err)
+	}
+
+	// Replace the file; nothing has read since, so only ReadFresh can notice
+	oldDB := store.UnderlyingDB()
+	os.Remove(mainDBPath + "-wal")
+	os.Remove(mainDBPath + "-shm")
+	content, err := os.ReadFile(branchDBPath)
+	if err != nil {
+		t.Fatalf("failed to read branch DB: %v", err)
+	}
+	if err := os.WriteFile(mainDBPath+".new", content, 0644); err != nil {
+		t.Fatalf("failed to write temp file: %v", err)
+	}
+	if err := os.Rename(mainDBPath+".new", mainDBPath); err != nil {
+		t.Fatalf("failed to rename: %v", err)
+	}
+
+	// Within the staleness budget the file is not re-checked
+	err = store.ReadFresh(ctx, time.Hour, func(ctx context.Context) error {
+		if store.UnderlyingDB() != oldDB {
+			t.Error("Expected no reconnect within the staleness budget")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ReadFresh failed: %v", err)
+	}
+
+	err = store.ReadFresh(ctx, 0, func(ctx context.Context) error {
+		if store.UnderlyingDB() == oldDB {
+			t.Error("Expected ReadFresh to reconnect before running fn")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ReadFresh failed: %v", err)
+	}
+
+	// A file that can't be checked is an error, not a stale read
+	if err := os.Rename(mainDBPath, mainDBPath+".moved"); err != nil {
+		t.Fatalf("failed to move database: %v", err)
+	}
+	ran := false
+	err = store.ReadFresh(ctx, 0, func(ctx context.Context) error {
+		ran = true
+		return nil
+	})
+	if err == nil || ran {
+		t.Errorf("Expected ReadFresh to fail without running fn, got err=%v ran=%v", err, ran)
+	}
+}
+
+func TestFreshnessReport(t *testing.T) {
+	ctx := context.Background()
+	tmpDir := t.TempDir()
+	mainDBPath := filepath.Join(tmpDir, "beads.db")
+	branchDBPath := filepath.Join(tmpDir, "branch", "beads.db")
+
+	// Both files share bd-same; each has one issue the other lacks
+	for path, id := range map[string]string{mainDBPath: "bd-main", branchDBPath: "bd-branch"} {
+		s, err := New(ctx, path)
+		if err != nil {
+			t.Fatalf("failed to create store: %v", err)
+		}
+		if err := s.SetConfig(ctx, "issue_prefix", "bd"); err != nil {
+			t.Fatalf("SetConfig failed: %v", err)
+		}
+		for _, issueID := range []string{"bd-same", id} {
+			issue := &types.Issue{ID: issueID, Title: issueID, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+			if err := s.CreateIssue(ctx, issue, "test"); err != nil {
+				t.Fatalf("CreateIssue failed: %v", err)
+			}
+		}
+		s.Close()
+	}
+	// Keep bd-same identical in both files
+	same, err := New(ctx, mainDBPath)
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+	var hash, updatedAt string
+	if err := same.db().QueryRowContext(ctx, `SELECT content_hash, updated_at FROM issues WHERE id = 'bd-same'`).Scan(&hash, &updatedAt); err != nil {
+		t.Fatalf("failed to read bd-same: %v", err)
+	}
+	same.Close()
+	branch, err := New(ctx, branchDBPath)
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+	if _, err := branch.db().ExecContext(ctx, `UPDATE issues SET content_hash = ?, updated_at = ? WHERE id = 'bd-same'`, hash, updatedAt); err != nil {
+		t.Fatalf("failed to sync bd-same: %v", err)
+	}
+	branch.Close()
+
+	store, err := New(ctx, mainDBPath)
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+	defer store.Close()
+	if err := store.EnableFreshnessChecking(FreshnessOptions{}); err != nil {
+		t.Fatalf("failed to enable freshness checking: %v", err)
+	}
+
+	report, err := store.FreshnessReport(ctx)
+	if err != nil {
+		t.Fatalf("FreshnessReport failed: %v", err)
+	}
+	if !report.CheckingEnabled || report.ReplacementPending || len(report.ChangedIssues) != 0 {
+		t.Errorf("Expected an enabled, up-to-date report, got %+v", report)
+	}
+	if report.LastReconnect != nil || report.Reconnects != 0 {
+		t.Errorf("Expected no reconnects yet, got %v (%d)", report.LastReconnect, report.Reconnects)
+	}
+	if report.Inode != getInode(mainDBPath) || report.ConnectedInode != report.Inode {
+		t.Errorf("Expected inode %d, got %d (connected %d)", getInode(mainDBPath), report.Inode, report.ConnectedInode)
+	}
+
+	os.Remove(mainDBPath + "-wal")
+	os.Remove(mainDBPath + "-shm")
+	content, err := os.ReadFile(branchDBPath)
+	if err != nil {
+		t.Fatalf("failed to read branch DB: %v", err)
+	}
+	if err := os.WriteFile(mainDBPath+".new", content, 0644); err != nil {
+		t.Fatalf("failed to write temp file: %v", err)
+	}
+	if err := os.Rename(mainDBPath+".new", mainDBPath); err != nil {
+		t.Fatalf("failed to rename: %v", err)
+	}
+
+	// The report must not itself reconnect
+	oldDB := store.UnderlyingDB()
+	report, err = store.FreshnessReport(ctx)
+	if err != nil {
+		t.Fatalf("FreshnessReport failed: %v", err)
+	}
+	if store.UnderlyingDB() != oldDB {
+		t.Error("Expected FreshnessReport not to reconnect")
+	}
+	if !report.ReplacementPending {
+		t.Error("Expected a pending replacement")
+	}
+	if report.Inode == report.ConnectedInode {
+		t.Errorf("Expected the file and connection inodes to differ, both %d", report.Inode)
+	}
+	if !sameIDs(report.ChangedIssues, []string{"bd-branch", "bd-main"}) {
+		t.Errorf("Expected changed issues [bd-branch bd-main], got %v", report.ChangedIssues)
+	}
+
+	// A read picks up the replacement
+	if _, err := store.GetIssue(ctx, "bd-branch"); err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	report, err = store.FreshnessReport(ctx)
+	if err != nil {
+		t.Fatalf("FreshnessReport failed: %v", err)
+	}
+	if report.ReplacementPending || len(report.ChangedIssues) != 0 {
+		t.Errorf("Expected no pending changes after reconnecting, got %+v", report)
+	}
+	if report.LastReconnect == nil || report.Reconnects != 1 {
+		t.Errorf("Expected one recorded reconnect, got %v (%d)", report.LastReconnect, report.Reconnects)
+	}
+}
+
+func TestPauseFreshness(t *testing.T) {
+	ctx := context.Background()
+	tmpDir := t.TempDir()
+	mainDBPath := filepath.Join(tmpDir, "beads.db")
+	branchDBPath := filepath.Join(tmpDir, "branch", "beads.db")
+
+	for _, path := range []string{mainDBPath, branchDBPath} {
+		s, err := New(ctx, path)
+		if err != nil {
+			t.Fatalf("failed to create store: %v", err)
+		}
+		if err := s.SetConfig(ctx, "issue_prefix", "bd"); err != nil {
+			t.Fatalf("SetConfig failed: %v", err)
+		}
+		s.Close()
+	}
+
+	store, err := New(ctx, mainDBPath)
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+	defer store.Close()
+	if err := store.EnableFreshnessChecking(FreshnessOptions{}); err != nil {
+		t.Fatalf("failed to enable freshness checking: %v", err)
+	}
+
+	oldDB := store.UnderlyingDB()
+	err = store.WithFreshnessPaused(func() error {
+		// Pauses nest; only the outermost resume reconnects
+		store.PauseFreshness()
+		os.Remove(mainDBPath + "-wal")
+		os.Remove(mainDBPath + "-shm")
+		content, err := os.ReadFile(branchDBPath)
+		if err != nil {
+			t.Fatalf("failed to read branch DB: %v", err)
+		}
+		if err := os.WriteFile(mainDBPath+".new", content, 0644); err != nil {
+			t.Fatalf("failed to write temp file: %v", err)
+		}
+		if err := os.Rename(mainDBPath+".new", mainDBPath); err != nil {
+			t.Fatalf("failed to rename: %v", err)
+		}
+
+		if _, err := store.GetIssue(ctx, "bd-missing"); err != nil {
+			t.Fatalf("GetIssue failed: %v", err)
+		}
+		if store.UnderlyingDB() != oldDB {
+			t.Error("Expected no reconnect while paused")
+		}
+		if err := store.ReadFresh(ctx, 0, func(ctx context.Context) error { return nil }); err == nil {
+			t.Error("Expected ReadFresh to fail while paused")
+		}
+		report, err := store.FreshnessReport(ctx)
+		if err != nil {
+			t.Fatalf("FreshnessReport failed: %v", err)
+		}
+		if !report.CheckingPaused || !report.ReplacementPending {
+			t.Errorf("Expected a paused report with a pending replacement, got %+v", report)
+		}
+
+		if err := store.ResumeFreshness(); err != nil {
+			t.Fatalf("ResumeFreshness failed: %v", err)
+		}
+		if store.UnderlyingDB() != oldDB {
+			t.Error("Expected no reconnect until the outermost resume")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WithFreshnessPaused failed: %v", err)
+	}
+
+	resumedDB := store.UnderlyingDB()
+	if resumedDB == oldDB {
+		t.Fatal("Expected a reconnect on resume")
+	}
+	report, err := store.FreshnessReport(ctx)
+	if err != nil {
+		t.Fatalf("FreshnessReport failed: %v", err)
+	}
+	if report.CheckingPaused || report.ReplacementPending || report.Reconnects != 1 {
+		t.Errorf("Expected one reconnect and nothing pending, got %+v", report)
+	}
+
+	// Resuming forces a reconnect even when the file wasn't replaced
+	if err := store.WithFreshnessPaused(func() error { return nil }); err != nil {
+		t.Fatalf("WithFreshnessPaused failed: %v", err)
+	}
+	if store.UnderlyingDB() == resumedDB {
+		t.Error("Expected a forced reconnect on resume")
+	}
+	if err := store.ResumeFreshness(); err != nil {
+		t.Errorf("Expected an unmatched resume to be ignored, got %v", err)
+	}
+}
+
+func TestFreshnessPolling(t *testing.T) {
+	ctx := context.Background()
+	tmpDir := t.TempDir()
+	mainDBPath := filepath.Join(tmpDir, "beads.db")
+	branchDBPath := filepath.Join(tmpDir, "branch", "beads.db")
+
+	for _, path := range []string{mainDBPath, branchDBPath} {
+		s, err := New(ctx, path)
+		if err != nil {
+			t.Fatalf("failed to create store: %v", err)
+		}
+		if err := s.SetConfig(ctx, "issue_prefix", "bd"); err != nil {
+			t.Fatalf("SetConfig failed: %v", err)
+		}
+		s.Close()
+	}
+
+	store, err := New(ctx, mainDBPath)
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+	if err := store.EnableFreshnessChecking(FreshnessOptions{PollInterval: -time.Second}); err == nil {
+		t.Error("Expected a negative poll interval to be rejected")
+	}
+	reconnected := make(chan [2]uint64, 1)
+	err = store.EnableFreshnessChecking(FreshnessOptions{
+		PollInterval: 10 * time.Millisecond,
+		OnReconnect: func(oldInode, newInode uint64) {
+			reconnected <- [2]uint64{oldInode, newInode}
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to enable freshness checking: %v", err)
+	}
+
+	oldInode := getInode(mainDBPath)
+	os.Remove(mainDBPath + "-wal")
+	os.Remove(mainDBPath + "-shm")
+	content, err := os.ReadFile(branchDBPath)
+	if err != nil {
+		t.Fatalf("failed to read branch DB: %v", err)
+	}
+	if err := os.WriteFile(mainDBPath+".new", content, 0644); err != nil {
+		t.Fatalf("failed to write temp file: %v", err)
+	}
+	if err := os.Rename(mainDBPath+".new", mainDBPath); err != nil {
+		t.Fatalf("failed to rename: %v", err)
+	}
+
+	// The poller reconnects without any reads
+	select {
+	case inodes := <-reconnected:
+		if inodes != [2]uint64{oldInode, getInode(mainDBPath)} {
+			t.Errorf("Expected OnReconnect(%d, %d), got %v", oldInode, getInode(mainDBPath), inodes)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the poller to reconnect")
+	}
+
+	if err := store.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if freshnessPollerRunning() {
+		t.Error("Expected Close to stop the freshness poller")
+	}
+}
+
+// freshnessPollerRunning reports whether any goroutine is in pollFreshness
+func freshnessPollerRunning() bool {
+	buf := make([]byte, 1<<20)
+	return strings.Contains(string(buf[:runtime.Stack(buf, true)]), ".pollFreshness(")
+}
+
+func TestReconnectMigrates(t *testing.T) {
+	ctx := context.Background()
+	tmpDir := t.TempDir()
+	mainDBPath := filepath.Join(tmpDir, "beads.db")
+	branchDBPath := filepath.Join(tmpDir, "branch", "beads.db")
+
+	for _, path := range []string{mainDBPath, branchDBPath} {
+		s, err := New(ctx, path)
+		if err != nil {
+			t.Fatalf("failed to create store: %v", err)
+		}
+		if err := s.SetConfig(ctx, "issue_prefix", "bd"); err != nil {
+			t.Fatalf("SetConfig failed: %v", err)
+		}
+		s.Close()
+	}
+
+	// Roll the branch database back to before the latest migration, as if an
+	// older bd had written it
+	older, err := sql.Open("sqlite3", branchDBPath)
+	if err != nil {
+		t.Fatalf("failed to open branch DB: %v", err)
+	}
+	if _, err := older.Exec(`DROP TABLE issue_watchers`); err != nil {
+		t.Fatalf("failed to drop table: %v", err)
+	}
+	if _, err := older.Exec(`DELETE FROM schema_migrations WHERE version = ?`, LatestSchemaVersion()); err != nil {
+		t.Fatalf("failed to roll back version: %v", err)
+	}
+	older.Close()
+
+	store, err := New(ctx, mainDBPath)
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+	defer store.Close()
+	if err := store.EnableFreshnessChecking(FreshnessOptions{}); err != nil {
+		t.Fatalf("failed to enable freshness checking: %v", err)
+	}
+
+	replace := func(src string) {
+		t.Helper()
+		os.Remove(mainDBPath + "-wal")
+		os.Remove(mainDBPath + "-shm")
+		content, err := os.ReadFile(src)
+		if err != nil {
+			t.Fatalf("failed to read DB: %v", err)
+		}
+		if err := os.WriteFile(mainDBPath+".new", content, 0644); err != nil {
+			t.Fatalf("failed to write temp file: %v", err)
+		}
+		if err := os.Rename(mainDBPath+".new", mainDBPath); err != nil {
+			t.Fatalf("failed to rename: %v", err)
+		}
+	}
+	noop := func(ctx context.Context) error { return nil }
+
+	replace(branchDBPath)
+	if err := store.ReadFresh(ctx, 0, noop); err != nil {
+		t.Fatalf("ReadFresh failed: %v", err)
+	}
+	if version, err := store.SchemaVersion(ctx); err != nil || version != LatestSchemaVersion() {
+		t.Errorf("Expected reconnecting to migrate to version %d, got %d (err %v)", LatestSchemaVersion(), version, err)
+	}
+	issue := &types.Issue{Title: "Watched", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := store.WatchIssue(ctx, issue.ID, "alice"); err != nil {
+		t.Errorf("Expected the migrated database to support watchers, got %v", err)
+	}
+
+	// A database from a newer bd is refused, and the store stays on the
+	// file it has
+	future, err := sql.Open("sqlite3", branchDBPath)
+	if err != nil {
+		t.Fatalf("failed to open branch DB: %v", err)
+	}
+	if _, err := future.Exec(`INSERT INTO schema_migrations (version, name) VALUES (?, 'from_the_future')`, LatestSchemaVersion()+1); err != nil {
+		t.Fatalf("failed to insert future version: %v", err)
+	}
+	future.Close()
+	oldDB := store.UnderlyingDB()
+	replace(branchDBPath)
+	if err := store.ReadFresh(ctx, 0, noop); !errors.Is(err, ErrSchemaTooNew) {
+		t.Errorf("Expected ErrSchemaTooNew reconnecting to a newer database, got %v", err)
+	}
+	if store.UnderlyingDB() != oldDB {
+		t.Error("Expected the store to keep its connection")
+	}
+	if got, err := store.GetIssue(ctx, issue.ID); err != nil || got == nil {
+		t.Errorf("Expected reads to keep working, got %v (err %v)", got, err)
+	}
+}
+
+// TestReconnectDuringReads reconnects over and over while other goroutines
+// read; run with -race to check the pool swap is safe.
+func TestReconnectDuringReads(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t, filepath.Join(t.TempDir(), "beads.db"))
+	if err := store.EnableFreshnessChecking(FreshnessOptions{}); err != nil {
+		t.Fatalf("failed to enable freshness checking: %v", err)
+	}
+	issue := &types.Issue{Title: "Read me", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	done := make(chan struct{})
+	errs := make(chan error, 5)
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				if got, err := store.GetIssue(ctx, issue.ID); err != nil || got == nil {
+					errs <- fmt.Errorf("GetIssue = %v, %v", got, err)
+					return
+				}
+				if _, err := store.SearchIssues(ctx, "Read", types.IssueFilter{}); err != nil {
+					errs <- fmt.Errorf("SearchIssues: %w", err)
+					return
+				}
+			}
+		}()
+	}
+	// Writes pin the pool too, so a swap doesn't close it under them
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			if err := store.AddComment(ctx, issue.ID, "test", "Still here"); err != nil {
+				errs <- fmt.Errorf("AddComment: %w", err)
+				return
+			}
+		}
+	}()
+
+	for i := 0; i < 20; i++ {
+		// Resuming always reconnects, replaced file or not
+		store.PauseFreshness()
+		if err := store.ResumeFreshness(); err != nil {
+			t.Errorf("ResumeFreshness failed: %v", err)
+			break
+		}
+	}
+	close(done)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("Read failed during reconnects: %v", err)
+	}
+}
+
+func TestRetiredPoolClosesOnLastRelease(t *testing.T) {
+	open := func(name string) *sql.DB {
+		db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), name))
+		if err != nil {
+			t.Fatalf("failed to open %s: %v", name, err)
+		}
+		t.Cleanup(func() { _ = db.Close() })
+		return db
+	}
+	var readers snapshotReaders
+
+	// Nothing pinned: retiring closes the pool straight away
+	idle := open("idle.db")
+	readers.retire(idle)
+	if err := idle.Ping(); err == nil {
+		t.Error("Expected an unpinned retired pool to be closed")
+	}
+
+	// Pinned: it stays open until the last pin is released
+	busy := open("busy.db")
+	readers.acquire(busy)
+	readers.acquire(busy)
+	readers.retire(busy)
+	readers.release(busy)
+	if err := busy.Ping(); err != nil {
+		t.Fatalf("Expected a pinned retired pool to stay open, got %v", err)
+	}
+	readers.release(busy)
+	if err := busy.Ping(); err == nil {
+		t.Error("Expected the retired pool closed after its last release")
+	}
+}
+
+// TestFreshnessPollingDuringReads has the background poller swap pools
+// while other goroutines read; run with -race.
+func TestFreshnessPollingDuringReads(t *testing.T) {
+	ctx := context.Background()
+	mainDBPath := filepath.Join(t.TempDir(), "beads.db")
+
+	s, err := New(ctx, mainDBPath)
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	if err := s.SetConfig(ctx, "issue_prefix", "bd"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	issue := &types.Issue{ID: "bd-read", Title: "Read me", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := s.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	s.Close()
+	content, err := os.ReadFile(mainDBPath)
+	if err != nil {
+		t.Fatalf("failed to read DB: %v", err)
+	}
+
+	store, err := New(ctx, mainDBPath)
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+	defer store.Close()
+	reconnected := make(chan struct{}, 1)
+	err = store.EnableFreshnessChecking(FreshnessOptions{
+		PollInterval: time.Millisecond,
+		OnReconnect: func(oldInode, newInode uint64) {
+			select {
+			case reconnected <- struct{}{}:
+			default:
+			}
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to enable freshness checking: %v", err)
+	}
+
+	done := make(chan struct{})
+	errs := make(chan error, 4)
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				if got, err := store.GetIssue(ctx, issue.ID); err != nil || got == nil {
+					errs <- fmt.Errorf("GetIssue = %v, %v", got, err)
+					return
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < 10; i++ {
+		if err := os.WriteFile(mainDBPath+".new", content, 0644); err != nil {
+			t.Fatalf("failed to write temp file: %v", err)
+		}
+		if err := os.Rename(mainDBPath+".new", mainDBPath); err != nil {
+			t.Fatalf("failed to rename: %v", err)
+		}
+		select {
+		case <-reconnected:
+		case <-time.After(5 * time.Second):
+			t.Fatal("Timed out waiting for the poller to reconnect")
+		}
+	}
+	close(done)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("Read failed while the poller reconnected: %v", err)
+	}
+}
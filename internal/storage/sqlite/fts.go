@@ -0,0 +1,198 @@
+// Package sqlite - full-text search
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/steveyegge/beads/internal/compression"
+	"github.com/steveyegge/beads/internal/debug"
+)
+
+// ftsRankSQL ranks issues_fts matches with BM25, lower being more relevant.
+// Title matches count five times as much as description and comment matches.
+const ftsRankSQL = `bm25(issues_fts, 0.0, 5.0, 1.0, 1.0)`
+
+// ftsFallbackOnce logs the first search that can't use issues_fts
+var ftsFallbackOnce sync.Once
+
+// ftsIndexState caches hasFTSIndex for the process
+var ftsIndexState atomic.Int32
+
+const (
+	ftsIndexUnknown int32 = iota
+	ftsIndexPresent
+	ftsIndexAbsent
+)
+
+// textSearch is how SearchIssues matches its query text
+type textSearch struct {
+	join  string        // Joins the ranked matches onto issues, if ranking
+	where string        // Restricts issues to the matches
+	order string        // ORDER BY terms putting the best matches first, if ranking
+	args  []interface{} // Args for join and then where
+}
+
+// buildTextSearch matches query against the issues_fts index (see
+// syncFTS): every word must prefix-match a word of the title, description or
+// comments, and matches are ranked by BM25. Issues whose ID contains query
+// also match, ahead of the ranked ones. Without the index (SQLite built
+// without FTS5) it falls back to unranked substring matching of the title,
+// description and ID, decompressing compressed descriptions to match them.
+func buildTextSearch(ctx context.Context, q queryer, query string) (textSearch, error) {
+	pattern := "%" + query + "%"
+	match := ftsMatchQuery(query)
+	if match == "" || !hasFTSIndex(ctx, q) {
+		compressed, err := compressedDescriptionMatches(ctx, q, query)
+		if err != nil {
+			return textSearch{}, err
+		}
+		where := "(title LIKE ? OR description LIKE ? OR id LIKE ?"
+		args := []interface{}{pattern, pattern, pattern}
+		if len(compressed) > 0 {
+			where += " OR id IN (" + buildPlaceholders(len(compressed)) + ")"
+			for _, id := range compressed {
+				args = append(args, id)
+			}
+		}
+		return textSearch{where: where + ")", args: args}, nil
+	}
+	return textSearch{
+		join: `LEFT JOIN (
+			SELECT issue_id AS fts_issue_id, ` + ftsRankSQL + ` AS fts_rank
+			FROM issues_fts WHERE issues_fts MATCH ?
+		) fts ON fts.fts_issue_id = issues.id`,
+		where: "(fts.fts_rank IS NOT NULL OR id LIKE ?)",
+		order: "fts.fts_rank IS NOT NULL, fts.fts_rank, ",
+		args:  []interface{}{match, pattern},
+	}, nil
+}
+
+// compressedDescriptionMatches returns the IDs of issues whose compressed
+// description contains query, ignoring ASCII case like LIKE does. SQL only
+// sees their encoded form, so they are decompressed here.
+func compressedDescriptionMatches(ctx context.Context, q queryer, query string) ([]string, error) {
+	rows, err := q.QueryContext(ctx, `SELECT id, description FROM issues WHERE substr(description, 1, ?) = ?`,
+		len(compression.Marker), compression.Marker)
+	if err != nil {
+		return nil, wrapDBError("query compressed descriptions", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	needle := asciiLower(query)
+	var ids []string
+	for rows.Next() {
+		var id, description string
+		if err := rows.Scan(&id, &description); err != nil {
+			return nil, wrapDBError("scan compressed description", err)
+		}
+		if err := decodeDescription(&description); err != nil {
+			return nil, fmt.Errorf("issue %s: %w", id, err)
+		}
+		if strings.Contains(asciiLower(description), needle) {
+			ids = append(ids, id)
+		}
+	}
+	return ids, wrapDBError("iterate compressed descriptions", rows.Err())
+}
+
+// asciiLower lowercases the ASCII letters of s, the only ones LIKE folds
+func asciiLower(s string) string {
+	return strings.Map(func(r rune) rune {
+		if 'A' <= r && r <= 'Z' {
+			return r + 'a' - 'A'
+		}
+		return r
+	}, s)
+}
+
+// ftsMatchQuery turns free text into an FTS5 query matching every word as a
+// prefix. Words are quoted so punctuation and FTS5 operators in them are
+// matched literally rather than parsed.
+func ftsMatchQuery(query string) string {
+	words := strings.Fields(query)
+	for i, word := range words {
+		words[i] = `"` + strings.ReplaceAll(word, `"`, `""`) + `"*`
+	}
+	return strings.Join(words, " ")
+}
+
+// hasFTSIndex reports whether the issues_fts index exists. Migrations create
+// it in every database whenever the SQLite build has FTS5, so the answer is
+// the same for every store in the process and is only looked up once.
+func hasFTSIndex(ctx context.Context, q queryer) bool {
+	switch ftsIndexState.Load() {
+	case ftsIndexPresent:
+		return true
+	case ftsIndexAbsent:
+		return false
+	}
+
+	var n int
+	err := q.QueryRowContext(ctx, `SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'issues_fts'`).Scan(&n)
+	if err != nil {
+		return false
+	}
+	if n == 0 {
+		ftsFallbackOnce.Do(func() {
+			debug.Logf("full-text index unavailable (SQLite built without FTS5?), search falls back to substring matching")
+		})
+		ftsIndexState.Store(ftsIndexAbsent)
+		return false
+	}
+	ftsIndexState.Store(ftsIndexPresent)
+	return true
+}
+
+// syncFTS brings the issues_fts entries of ids in line with their issues as
+// q sees them: title, plain-text description and comments. IDs with no issue
+// lose their entry. Every write that creates, renames or deletes issues, or
+// changes their title, description or comments, calls it in the same
+// transaction; it does nothing without the index.
+func syncFTS(ctx context.Context, q queryExecer, ids ...string) error {
+	if len(ids) == 0 || !hasFTSIndex(ctx, q) {
+		return nil
+	}
+	for _, id := range ids {
+		if _, err := q.ExecContext(ctx, `DELETE FROM issues_fts WHERE issue_id = ?`, id); err != nil {
+			return wrapDBError("clear full-text index entry", err)
+		}
+
+		var title, description string
+		var comments sql.NullString
+		err := q.QueryRowContext(ctx, `
+			SELECT title, description,
+			       (SELECT group_concat(text, char(10)) FROM comments WHERE issue_id = issues.id)
+			FROM issues WHERE id = ?
+		`, id).Scan(&title, &description, &comments)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return wrapDBError("read issue for full-text index", err)
+		}
+		if err := decodeDescription(&description); err != nil {
+			return fmt.Errorf("issue %s: %w", id, err)
+		}
+		_, err = q.ExecContext(ctx, `INSERT INTO issues_fts (issue_id, title, description, comments) VALUES (?, ?, ?, ?)`,
+			id, title, description, comments.String)
+		if err != nil {
+			return wrapDBError("update full-text index", err)
+		}
+	}
+	return nil
+}
+
+// clearFTS empties the issues_fts index, for writes that delete every issue
+func clearFTS(ctx context.Context, q queryExecer) error {
+	if !hasFTSIndex(ctx, q) {
+		return nil
+	}
+	_, err := q.ExecContext(ctx, `DELETE FROM issues_fts`)
+	return wrapDBError("clear full-text index", err)
+}
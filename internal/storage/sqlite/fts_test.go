@@ -0,0 +1,147 @@
+package sqlite
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/compression"
+	"github.com/steveyegge/beads/internal/storage/sqlite/migrations"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestSearchIssuesFullText(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	create := func(title, description string, status types.Status, priority int) string {
+		t.Helper()
+		issue := &types.Issue{Title: title, Description: description, Status: status, Priority: priority, IssueType: types.TypeTask}
+		if status == types.StatusClosed {
+			issue.ClosedAt = &issue.CreatedAt
+		}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		return issue.ID
+	}
+	titleMatch := create("Auth token expiry", "Tokens never expire", types.StatusOpen, 1)
+	descMatch := create("Login page", "Broken since the auth refactor", types.StatusOpen, 1)
+	otherPriority := create("Authentication docs", "", types.StatusOpen, 2)
+	create("Auth cleanup", "", types.StatusClosed, 1)
+	unrelated := create("Dark mode", "Theme support", types.StatusOpen, 1)
+
+	search := func(query string, filter types.IssueFilter) []string {
+		t.Helper()
+		issues, err := store.SearchIssues(ctx, query, filter)
+		if err != nil {
+			t.Fatalf("SearchIssues(%q) failed: %v", query, err)
+		}
+		var ids []string
+		for _, issue := range issues {
+			ids = append(ids, issue.ID)
+		}
+		return ids
+	}
+
+	// The filter narrows the matches, and title matches rank first
+	open, p1 := types.StatusOpen, 1
+	if got, want := search("auth", types.IssueFilter{Status: &open, Priority: &p1}), []string{titleMatch, descMatch}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected open P1 matches %v, got %v", want, got)
+	}
+	// Words match as prefixes, and every word must match
+	if got := search("authent", types.IssueFilter{}); !sameIDs(got, []string{otherPriority}) {
+		t.Errorf("Expected prefix match %s, got %v", otherPriority, got)
+	}
+	if got := search("auth docs", types.IssueFilter{}); !sameIDs(got, []string{otherPriority}) {
+		t.Errorf("Expected only %s to match both words, got %v", otherPriority, got)
+	}
+	// FTS5 syntax in the query is matched literally
+	if got := search(`"auth OR (`, types.IssueFilter{}); len(got) != 0 {
+		t.Errorf("Expected no matches for punctuation, got %v", got)
+	}
+	// IDs still match
+	if got := search(unrelated, types.IssueFilter{}); !sameIDs(got, []string{unrelated}) {
+		t.Errorf("Expected ID match %s, got %v", unrelated, got)
+	}
+
+	// The index follows updates, comments and deletes
+	if err := store.UpdateIssue(ctx, unrelated, map[string]interface{}{"title": "Dark mode breaks OAuth popup"}, "test"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if got := search("oauth", types.IssueFilter{}); !sameIDs(got, []string{unrelated}) {
+		t.Errorf("Expected updated title to match, got %v", got)
+	}
+	if got := search("theme", types.IssueFilter{}); !sameIDs(got, []string{unrelated}) {
+		t.Errorf("Expected unchanged description to still match, got %v", got)
+	}
+	if _, err := store.AddIssueComment(ctx, descMatch, "test", "Reproduced on Safari"); err != nil {
+		t.Fatalf("AddIssueComment failed: %v", err)
+	}
+	if got := search("safari", types.IssueFilter{}); !sameIDs(got, []string{descMatch}) {
+		t.Errorf("Expected comment to match, got %v", got)
+	}
+	if err := store.DeleteIssue(ctx, titleMatch); err != nil {
+		t.Fatalf("DeleteIssue failed: %v", err)
+	}
+	if got := search("expiry", types.IssueFilter{IncludeTombstones: true}); len(got) != 0 {
+		t.Errorf("Expected deleted issue to drop out of the index, got %v", got)
+	}
+	var rows int
+	if err := store.db().QueryRowContext(ctx, `SELECT COUNT(*) FROM issues_fts WHERE issue_id = ?`, titleMatch).Scan(&rows); err != nil || rows != 0 {
+		t.Errorf("Expected no index rows for %s, got %d (err %v)", titleMatch, rows, err)
+	}
+}
+
+func TestSearchIssuesCompressedDescriptions(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	if err := store.SetConfig(ctx, DescriptionCompressionConfigKey, "1024"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	trace := strings.Repeat("panic: nil map\n\tgoroutine 7 [running]:\n\tmain.handle(0x0)\n", 200) + "Segfault in ledger"
+	issue := &types.Issue{Title: "Crash", Description: trace, Status: types.StatusOpen, Priority: 1, IssueType: types.TypeBug}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if !compression.IsCompressed(storedDescriptionOf(t, store, issue.ID)) {
+		t.Fatal("Expected the description to be stored compressed")
+	}
+
+	search := func(query string) []string {
+		t.Helper()
+		issues, err := store.SearchIssues(ctx, query, types.IssueFilter{})
+		if err != nil {
+			t.Fatalf("SearchIssues(%q) failed: %v", query, err)
+		}
+		var ids []string
+		for _, issue := range issues {
+			ids = append(ids, issue.ID)
+		}
+		return ids
+	}
+
+	if got := search("ledger"); !sameIDs(got, []string{issue.ID}) {
+		t.Errorf("Expected the compressed description to be indexed, got %v", got)
+	}
+
+	// Rebuilding the index, as the migration does, decompresses it too
+	if err := migrations.MigrateIssuesFTSGoSync(store.db()); err != nil {
+		t.Fatalf("MigrateIssuesFTSGoSync failed: %v", err)
+	}
+	if got := search("segfault"); !sameIDs(got, []string{issue.ID}) {
+		t.Errorf("Expected the rebuilt index to hold the compressed description, got %v", got)
+	}
+
+	// Without the index, substring matching decompresses it
+	ftsIndexState.Store(ftsIndexAbsent)
+	t.Cleanup(func() { ftsIndexState.Store(ftsIndexUnknown) })
+	if got := search("SEGFAULT IN"); !sameIDs(got, []string{issue.ID}) {
+		t.Errorf("Expected the substring fallback to match the compressed description, got %v", got)
+	}
+	if got := search("nothing like it"); len(got) != 0 {
+		t.Errorf("Expected no fallback matches, got %v", got)
+	}
+}
@@ -10,7 +10,7 @@ import (
 // Returns empty string if no hash is stored (first export).
 func (s *SQLiteStorage) GetExportHash(ctx context.Context, issueID string) (string, error) {
 	var hash string
-	err := s.db.QueryRowContext(ctx, `
+	err := s.db().QueryRowContext(ctx, `
 		SELECT content_hash FROM export_hashes WHERE issue_id = ?
 	`, issueID).Scan(&hash)
 	
@@ -26,7 +26,7 @@ func (s *SQLiteStorage) GetExportHash(ctx context.Context, issueID string) (stri
 
 // SetExportHash stores the content hash of an issue after successful export.
 func (s *SQLiteStorage) SetExportHash(ctx context.Context, issueID, contentHash string) error {
-	_, err := s.db.ExecContext(ctx, `
+	_, err := s.db().ExecContext(ctx, `
 		INSERT INTO export_hashes (issue_id, content_hash, exported_at)
 		VALUES (?, ?, CURRENT_TIMESTAMP)
 		ON CONFLICT(issue_id) DO UPDATE SET
@@ -44,7 +44,7 @@ func (s *SQLiteStorage) SetExportHash(ctx context.Context, issueID, contentHash
 // ClearAllExportHashes removes all export hashes from the database.
 // This is primarily used for test isolation to force re-export of issues.
 func (s *SQLiteStorage) ClearAllExportHashes(ctx context.Context) error {
-	_, err := s.db.ExecContext(ctx, `DELETE FROM export_hashes`)
+	_, err := s.db().ExecContext(ctx, `DELETE FROM export_hashes`)
 	if err != nil {
 		return fmt.Errorf("failed to clear export hashes: %w", err)
 	}
@@ -55,7 +55,7 @@ func (s *SQLiteStorage) ClearAllExportHashes(ctx context.Context) error {
 // Returns empty string if no hash is stored (bd-160).
 func (s *SQLiteStorage) GetJSONLFileHash(ctx context.Context) (string, error) {
 	var hash string
-	err := s.db.QueryRowContext(ctx, `
+	err := s.db().QueryRowContext(ctx, `
 		SELECT value FROM metadata WHERE key = 'jsonl_file_hash'
 	`).Scan(&hash)
 	
@@ -71,7 +71,7 @@ func (s *SQLiteStorage) GetJSONLFileHash(ctx context.Context) (string, error) {
 
 // SetJSONLFileHash stores the hash of the JSONL file after export (bd-160).
 func (s *SQLiteStorage) SetJSONLFileHash(ctx context.Context, fileHash string) error {
-	_, err := s.db.ExecContext(ctx, `
+	_, err := s.db().ExecContext(ctx, `
 		INSERT INTO metadata (key, value)
 		VALUES ('jsonl_file_hash', ?)
 		ON CONFLICT(key) DO UPDATE SET value = excluded.value
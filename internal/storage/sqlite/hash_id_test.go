@@ -2,9 +2,16 @@ package sqlite
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -197,3 +204,226 @@ func TestHashIDBatchCreation(t *testing.T) {
 		}
 	}
 }
+
+// stubIssueIDGenerator replaces issueIDGenerator for the duration of the test.
+// The stub returns collideWith for the first n calls, then defers to the real
+// generator; it reports how many times it was called.
+func stubIssueIDGenerator(t *testing.T, collideWith string, n int) *int {
+	t.Helper()
+	calls := 0
+	orig := issueIDGenerator
+	issueIDGenerator = func(ctx context.Context, conn *sql.Conn, prefix string, issue *types.Issue, actor string) (string, error) {
+		calls++
+		if calls <= n {
+			return collideWith, nil
+		}
+		return orig(ctx, conn, prefix, issue, actor)
+	}
+	t.Cleanup(func() { issueIDGenerator = orig })
+	return &calls
+}
+
+func TestCreateIssueRetriesIDCollision(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	existing := &types.Issue{Title: "Existing", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, existing, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	// The first generated ID collides; the retry must pick a fresh one
+	calls := stubIssueIDGenerator(t, existing.ID, 1)
+	issue := &types.Issue{Title: "New", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("Expected collision to be retried, got %v", err)
+	}
+	if *calls != 2 {
+		t.Errorf("Expected generator to be called twice, got %d", *calls)
+	}
+	if issue.ID == existing.ID {
+		t.Errorf("Expected a new ID, got the colliding %s", issue.ID)
+	}
+	if got, _ := store.GetIssue(ctx, existing.ID); got == nil || got.Title != "Existing" {
+		t.Errorf("Existing issue must be untouched, got %+v", got)
+	}
+}
+
+func TestCreateIssueIDCollisionExhausted(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	existing := &types.Issue{Title: "Existing", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, existing, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := store.SetConfig(ctx, IDCollisionRetriesConfigKey, "2"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+
+	calls := stubIssueIDGenerator(t, existing.ID, 100)
+	issue := &types.Issue{Title: "New", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	err := store.CreateIssue(ctx, issue, "test")
+	if !errors.Is(err, ErrDuplicateID) {
+		t.Fatalf("Expected ErrDuplicateID, got %v", err)
+	}
+	if *calls != 3 {
+		t.Errorf("Expected 1 attempt plus 2 retries, got %d generator calls", *calls)
+	}
+}
+
+func TestCreateIssueSuppliedIDNotRetried(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	existing := &types.Issue{Title: "Existing", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, existing, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	calls := stubIssueIDGenerator(t, existing.ID, 100)
+	dup := &types.Issue{ID: existing.ID, Title: "Copy", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	err := store.CreateIssue(ctx, dup, "test")
+	if err == nil || errors.Is(err, ErrDuplicateID) {
+		t.Fatalf("Expected a plain unique-constraint error for a supplied ID, got %v", err)
+	}
+	if *calls != 0 {
+		t.Errorf("Expected no ID generation for a supplied ID, got %d calls", *calls)
+	}
+	if dup.ID != existing.ID {
+		t.Errorf("Supplied ID must not be changed, got %s", dup.ID)
+	}
+}
+
+func TestIDSeedDeterministic(t *testing.T) {
+	ctx := context.Background()
+
+	// createAll opens a store with seed and returns the IDs generated by a
+	// fixed sequence of single, batch and transactional creates
+	createAll := func(seed int64) []string {
+		store, err := NewWithOptions(ctx, t.TempDir()+"/test.db", StoreOptions{IDSeed: seed})
+		if err != nil {
+			t.Fatalf("NewWithOptions failed: %v", err)
+		}
+		defer func() { _ = store.Close() }()
+		if err := store.SetConfig(ctx, "issue_prefix", "bd"); err != nil {
+			t.Fatalf("Failed to set prefix: %v", err)
+		}
+
+		newIssue := func(title string) *types.Issue {
+			return &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		}
+		var ids []string
+		// Same title twice: only the sequence tells them apart
+		for _, title := range []string{"First", "Second", "Second"} {
+			issue := newIssue(title)
+			if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+				t.Fatalf("CreateIssue failed: %v", err)
+			}
+			ids = append(ids, issue.ID)
+		}
+		batch := []*types.Issue{newIssue("Batch A"), newIssue("Batch B")}
+		if err := store.CreateIssues(ctx, batch, "test"); err != nil {
+			t.Fatalf("CreateIssues failed: %v", err)
+		}
+		ids = append(ids, batch[0].ID, batch[1].ID)
+		inTx := newIssue("In transaction")
+		if err := store.RunInTransaction(ctx, func(tx storage.Transaction) error {
+			return tx.CreateIssue(ctx, inTx, "test")
+		}); err != nil {
+			t.Fatalf("RunInTransaction failed: %v", err)
+		}
+		return append(ids, inTx.ID)
+	}
+
+	first := createAll(42)
+	if again := createAll(42); !reflect.DeepEqual(first, again) {
+		t.Errorf("Expected the same seed to yield the same IDs, got %v and %v", first, again)
+	}
+	if other := createAll(7); reflect.DeepEqual(first, other) {
+		t.Errorf("Expected a different seed to yield different IDs, got %v twice", first)
+	}
+	seen := make(map[string]bool)
+	for _, id := range first {
+		if seen[id] {
+			t.Errorf("Expected unique IDs, got %v", first)
+		}
+		seen[id] = true
+	}
+}
+
+func TestConcurrentCreatesUniqueIDs(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	// Identical titles and actors leave only the creation time and nonce to
+	// tell the two writers' issues apart
+	const writers, perWriter = 2, 500
+	ids := make([][]string, writers)
+	errs := make(chan error, writers)
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWriter; i++ {
+				issue := &types.Issue{Title: "Same title", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+				if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+					errs <- fmt.Errorf("writer %d: CreateIssue failed: %w", w, err)
+					return
+				}
+				ids[w] = append(ids[w], issue.ID)
+			}
+		}(w)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatal(err)
+	}
+
+	seen := make(map[string]bool, writers*perWriter)
+	for _, writerIDs := range ids {
+		for _, id := range writerIDs {
+			if seen[id] {
+				t.Errorf("Duplicate ID %s", id)
+			}
+			seen[id] = true
+		}
+	}
+	var count int
+	if err := store.db().QueryRowContext(ctx, `SELECT COUNT(*) FROM issues`).Scan(&count); err != nil {
+		t.Fatalf("Failed to count issues: %v", err)
+	}
+	if len(seen) != writers*perWriter || count != writers*perWriter {
+		t.Errorf("Expected %d distinct stored issues, got %d IDs and %d rows", writers*perWriter, len(seen), count)
+	}
+}
+
+func TestLegacySequentialIDsRoundTrip(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	for _, id := range []string{"bd-1", "bd-2", "bd-10"} {
+		issue := &types.Issue{ID: id, Title: "Legacy " + id, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue(%s) failed: %v", id, err)
+		}
+		if issue.ID != id {
+			t.Errorf("Expected supplied ID %s to be kept, got %s", id, issue.ID)
+		}
+		got, err := store.GetIssue(ctx, id)
+		if err != nil || got == nil {
+			t.Fatalf("GetIssue(%s) failed: %v", id, err)
+		}
+	}
+
+	// New issues get hash IDs alongside the legacy ones
+	issue := &types.Issue{Title: "New", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if suffix := strings.TrimPrefix(issue.ID, "bd-"); suffix == issue.ID || !isValidBase36(suffix) || len(suffix) < 3 {
+		t.Errorf("Expected a hash ID, got %s", issue.ID)
+	}
+}
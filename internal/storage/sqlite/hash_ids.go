@@ -10,7 +10,7 @@ import (
 // Uses INSERT...ON CONFLICT to ensure atomicity without explicit locking.
 func (s *SQLiteStorage) getNextChildNumber(ctx context.Context, parentID string) (int, error) {
 	var nextChild int
-	err := s.db.QueryRowContext(ctx, `
+	err := s.db().QueryRowContext(ctx, `
 		INSERT INTO child_counters (parent_id, last_child)
 		VALUES (?, 1)
 		ON CONFLICT(parent_id) DO UPDATE SET
@@ -29,7 +29,7 @@ func (s *SQLiteStorage) getNextChildNumber(ctx context.Context, parentID string)
 func (s *SQLiteStorage) GetNextChildID(ctx context.Context, parentID string) (string, error) {
 	// Validate parent exists
 	var count int
-	err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM issues WHERE id = ?`, parentID).Scan(&count)
+	err := s.db().QueryRowContext(ctx, `SELECT COUNT(*) FROM issues WHERE id = ?`, parentID).Scan(&count)
 	if err != nil {
 		return "", fmt.Errorf("failed to check parent existence: %w", err)
 	}
@@ -0,0 +1,109 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestPing(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	if err := store.Ping(ctx); err != nil {
+		t.Fatalf("Ping failed on open store: %v", err)
+	}
+
+	if err := store.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if err := store.Ping(ctx); err == nil {
+		t.Error("Expected Ping to fail after Close")
+	}
+}
+
+func TestSelfTest(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	report, err := store.SelfTest(ctx)
+	if err != nil {
+		t.Fatalf("SelfTest failed: %v", err)
+	}
+	if !report.OK {
+		t.Fatalf("Expected healthy report, got %+v", report)
+	}
+	if !report.SchemaCompatible || !report.IntegrityOK || !report.RoundTripOK {
+		t.Errorf("Expected all checks to pass, got %+v", report)
+	}
+	if report.FreshnessChecking {
+		t.Error("Expected freshness checking to be reported disabled by default")
+	}
+
+	// The scratch table must not survive the rolled-back round-trip
+	var count int
+	err = store.db().QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'selftest_scratch'
+	`).Scan(&count)
+	if err != nil {
+		t.Fatalf("Failed to query sqlite_master: %v", err)
+	}
+	if count != 0 {
+		t.Error("SelfTest left its scratch table behind")
+	}
+
+	if err := store.EnableFreshnessChecking(FreshnessOptions{}); err != nil {
+		t.Fatalf("EnableFreshnessChecking failed: %v", err)
+	}
+	report, err = store.SelfTest(ctx)
+	if err != nil {
+		t.Fatalf("SelfTest failed: %v", err)
+	}
+	if !report.FreshnessChecking {
+		t.Error("Expected freshness checking to be reported enabled")
+	}
+}
+
+func TestSelfTestConcurrentWithTraffic(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+
+	for i := 0; i < 5; i++ {
+		wg.Add(2)
+		go func(n int) {
+			defer wg.Done()
+			issue := &types.Issue{
+				Title:     fmt.Sprintf("Concurrent issue %d", n),
+				Status:    types.StatusOpen,
+				Priority:  2,
+				IssueType: types.TypeTask,
+			}
+			if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+				errs <- fmt.Errorf("CreateIssue: %w", err)
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			report, err := store.SelfTest(ctx)
+			if err != nil {
+				errs <- fmt.Errorf("SelfTest: %w", err)
+				return
+			}
+			if !report.OK {
+				errs <- fmt.Errorf("SelfTest reported unhealthy: %+v", report)
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,176 @@
+// Package sqlite - per-field change history of an issue
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// History returns the changes made to the fields of issue id, oldest first,
+// each with the actor and time of the event recording it. It reads the same
+// events as ChangesSince and Subscribe, so every mutating call that records
+// an event shows up: field updates, closes and reopens, label and dependency
+// changes, deletion (status becoming tombstone), publishing, visibility
+// changes and renames. Events carry over in archives and reconciles (see
+// ExportArchive and Reconcile), so a merged database keeps the history of
+// both sides. Comments, links and compaction aren't field changes and are
+// left out.
+func (s *SQLiteStorage) History(ctx context.Context, id string) ([]types.FieldChange, error) {
+	if err := requireVisibleIssue(ctx, s.db(), id); err != nil {
+		return nil, err
+	}
+	// Order by time rather than id: events imported from another database
+	// get new ids
+	rows, err := s.db().QueryContext(ctx, `
+		SELECT id, issue_id, event_type, actor, old_value, new_value, comment, created_at
+		FROM events
+		WHERE issue_id = ?
+		ORDER BY julianday(created_at), id
+	`, id)
+	if err != nil {
+		return nil, wrapDBError("get history", err)
+	}
+	events, err := scanEvents(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []types.FieldChange
+	// last holds each field's latest known value, to fill in the prior value
+	// of events that don't record it
+	last := make(map[string]interface{})
+	for _, event := range events {
+		if event.EventType == types.EventCreated {
+			if event.NewValue != nil {
+				_ = json.Unmarshal([]byte(*event.NewValue), &last)
+			}
+			continue
+		}
+		for _, change := range eventFieldChanges(event) {
+			if change.Old == nil && change.Field != "labels" && change.Field != "dependencies" {
+				change.Old = last[change.Field]
+			}
+			last[change.Field] = change.New
+			change.EventID = event.ID
+			change.Actor = event.Actor
+			change.ChangedAt = event.CreatedAt
+			changes = append(changes, change)
+		}
+	}
+	return changes, nil
+}
+
+// eventFieldChanges returns the field changes event records, without the
+// event's ID, actor and time
+func eventFieldChanges(event *types.Event) []types.FieldChange {
+	value := func(v *string) interface{} {
+		if v == nil {
+			return nil
+		}
+		return *v
+	}
+
+	switch event.EventType {
+	case types.EventUpdated, types.EventStatusChanged, types.EventPriorityChanged, types.EventClosed, types.EventReopened:
+		var snapshot, updates map[string]interface{}
+		if event.OldValue != nil {
+			_ = json.Unmarshal([]byte(*event.OldValue), &snapshot)
+		}
+		if event.NewValue != nil {
+			_ = json.Unmarshal([]byte(*event.NewValue), &updates)
+		}
+		// CloseIssue records only the reason
+		if event.EventType == types.EventClosed && updates == nil {
+			changes := []types.FieldChange{{Field: "status", New: string(types.StatusClosed)}}
+			if event.Comment != nil && *event.Comment != "" {
+				changes = append(changes, types.FieldChange{Field: "close_reason", New: *event.Comment})
+			}
+			return changes
+		}
+
+		fields := make([]string, 0, len(updates))
+		for field := range updates {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		var changes []types.FieldChange
+		for _, field := range fields {
+			old, now := snapshot[field], updates[field]
+			if reflect.DeepEqual(old, now) {
+				continue
+			}
+			changes = append(changes, types.FieldChange{Field: field, Old: old, New: now})
+		}
+		return changes
+
+	case "deleted":
+		return []types.FieldChange{{Field: "status", New: string(types.StatusTombstone)}}
+	case types.EventPublished:
+		return []types.FieldChange{{Field: "draft", Old: true, New: false}}
+	case types.EventVisibilityChanged:
+		return []types.FieldChange{{Field: "visibility", Old: value(event.OldValue), New: value(event.NewValue)}}
+	case "renamed":
+		return []types.FieldChange{{Field: "id", Old: value(event.OldValue), New: value(event.NewValue)}}
+
+	case types.EventLabelAdded, types.EventLabelRemoved:
+		if event.Comment == nil {
+			return nil
+		}
+		if label, ok := strings.CutPrefix(*event.Comment, "Added label: "); ok {
+			return []types.FieldChange{{Field: "labels", New: label}}
+		}
+		if label, ok := strings.CutPrefix(*event.Comment, "Removed label: "); ok {
+			return []types.FieldChange{{Field: "labels", Old: label}}
+		}
+
+	case types.EventDependencyAdded, types.EventDependencyRemoved:
+		data := event.NewValue
+		if event.EventType == types.EventDependencyRemoved {
+			data = event.OldValue
+		}
+		var edge types.DepEdge
+		if data == nil || json.Unmarshal([]byte(*data), &edge) != nil {
+			return nil
+		}
+		if event.EventType == types.EventDependencyAdded {
+			return []types.FieldChange{{Field: "dependencies", New: edge.To}}
+		}
+		return []types.FieldChange{{Field: "dependencies", Old: edge.To}}
+	}
+	return nil
+}
+
+// copyEvents inserts events into the events table on conn, skipping any
+// already recorded there (same issue, type, actor, values and time) so
+// re-importing or reconciling twice doesn't duplicate history. The copies
+// get new ids, as ids are local to each database.
+func copyEvents(ctx context.Context, conn *sql.Conn, events []*types.Event) (int, error) {
+	copied := 0
+	for _, event := range events {
+		result, err := conn.ExecContext(ctx, `
+			INSERT INTO events (issue_id, event_type, actor, old_value, new_value, comment, created_at)
+			SELECT ?, ?, ?, ?, ?, ?, ?
+			WHERE NOT EXISTS (
+				SELECT 1 FROM events
+				WHERE issue_id = ? AND event_type = ? AND actor = ?
+				  AND old_value IS ? AND new_value IS ? AND comment IS ?
+				  AND julianday(created_at) = julianday(?)
+			)
+		`, event.IssueID, event.EventType, event.Actor, event.OldValue, event.NewValue, event.Comment, event.CreatedAt,
+			event.IssueID, event.EventType, event.Actor, event.OldValue, event.NewValue, event.Comment, event.CreatedAt)
+		if err != nil {
+			return copied, wrapDBError(fmt.Sprintf("copy event of %s", event.IssueID), err)
+		}
+		if n, _ := result.RowsAffected(); n > 0 {
+			copied++
+		}
+	}
+	return copied, nil
+}
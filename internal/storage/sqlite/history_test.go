@@ -0,0 +1,96 @@
+package sqlite
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestHistory(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Original", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	blocker := &types.Issue{Title: "Blocker", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	for _, i := range []*types.Issue{issue, blocker} {
+		if err := store.CreateIssue(ctx, i, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"title": "Renamed", "priority": 2}, "alice"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if err := store.AddLabel(ctx, issue.ID, "backend", "bob"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+	if err := store.RemoveLabel(ctx, issue.ID, "backend", "bob"); err != nil {
+		t.Fatalf("RemoveLabel failed: %v", err)
+	}
+	dep := &types.Dependency{IssueID: issue.ID, DependsOnID: blocker.ID, Type: types.DepBlocks}
+	if err := store.AddDependency(ctx, dep, "carol"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+	if err := store.CloseIssue(ctx, issue.ID, "done", "alice"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+
+	changes, err := store.History(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("History failed: %v", err)
+	}
+	want := []types.FieldChange{
+		{Field: "title", Old: "Original", New: "Renamed", Actor: "alice"},
+		{Field: "labels", New: "backend", Actor: "bob"},
+		{Field: "labels", Old: "backend", Actor: "bob"},
+		{Field: "dependencies", New: blocker.ID, Actor: "carol"},
+		{Field: "status", Old: "open", New: "closed", Actor: "alice"},
+		{Field: "close_reason", New: "done", Actor: "alice"},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("Expected %d changes, got %d: %+v", len(want), len(changes), changes)
+	}
+	for i, w := range want {
+		got := changes[i]
+		if got.Field != w.Field || got.Old != w.Old || got.New != w.New || got.Actor != w.Actor {
+			t.Errorf("Change %d: expected %+v, got %+v", i, w, got)
+		}
+		if got.EventID == 0 || got.ChangedAt.IsZero() {
+			t.Errorf("Change %d: expected an event id and time, got %+v", i, got)
+		}
+		if i > 0 && got.ChangedAt.Before(changes[i-1].ChangedAt) {
+			t.Errorf("Change %d is out of order", i)
+		}
+	}
+
+	if _, err := store.History(ctx, "bd-missing"); err == nil {
+		t.Error("Expected an error for a missing issue")
+	}
+
+	// History survives an archive round trip, and importing twice doesn't
+	// duplicate it
+	var archive bytes.Buffer
+	if err := store.ExportArchive(ctx, &archive); err != nil {
+		t.Fatalf("ExportArchive failed: %v", err)
+	}
+	dst := newTestStore(t, "")
+	for i := 0; i < 2; i++ {
+		if _, err := dst.ImportArchive(ctx, bytes.NewReader(archive.Bytes()), ImportMerge); err != nil {
+			t.Fatalf("ImportArchive failed: %v", err)
+		}
+	}
+	imported, err := dst.History(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("History after import failed: %v", err)
+	}
+	if len(imported) != len(changes) {
+		t.Fatalf("Expected %d changes after import, got %d: %+v", len(changes), len(imported), imported)
+	}
+	for i := range changes {
+		if imported[i].Field != changes[i].Field || imported[i].Actor != changes[i].Actor || !imported[i].ChangedAt.Equal(changes[i].ChangedAt) {
+			t.Errorf("Imported change %d: expected %+v, got %+v", i, changes[i], imported[i])
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// Package sqlite - idempotent issue creation
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+type idempotencyKeyCtx struct{}
+
+// WithIdempotencyKey returns a context under which CreateIssue is idempotent
+// in key: the first create with the key records it, and a later create with
+// the same key returns the issue the first one created, filled into the
+// issue passed in, instead of creating another. Callers generate a fresh key
+// per logical create and reuse it on every retry, so a create whose outcome
+// was lost to a flaky connection can be retried safely.
+//
+// Keys are forgotten after IdempotencyWindowConfigKey. An empty key turns
+// the mechanism off.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyCtx{}, key)
+}
+
+func idempotencyKey(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKeyCtx{}).(string)
+	return key
+}
+
+// IdempotentIssue returns the issue created under idempotency key, or nil if
+// the key is unknown or has expired (see WithIdempotencyKey). It lets callers
+// that do more than CreateIssue skip the rest of a retried operation too.
+func (s *SQLiteStorage) IdempotentIssue(ctx context.Context, key string) (*types.Issue, error) {
+	window, err := readIdempotencyWindow(ctx, s.db())
+	if err != nil {
+		return nil, err
+	}
+	var issueID string
+	err = s.db().QueryRowContext(ctx, `
+		SELECT issue_id FROM idempotency_keys
+		WHERE key = ? AND julianday(created_at) > julianday(?)
+	`, key, s.now().Add(-window).UTC()).Scan(&issueID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, wrapDBError("look up idempotency key", err)
+	}
+	return s.GetIssue(ctx, issueID)
+}
+
+// replayIdempotentCreate forgets expired keys and returns the issue an
+// earlier create recorded under key, or nil if there is none. It runs inside
+// CreateIssue's transaction on conn so concurrent retries can't both create.
+func (s *SQLiteStorage) replayIdempotentCreate(ctx context.Context, conn *sql.Conn, key string) (*types.Issue, error) {
+	window, err := readIdempotencyWindow(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := conn.ExecContext(ctx, `
+		DELETE FROM idempotency_keys WHERE julianday(created_at) <= julianday(?)
+	`, s.now().Add(-window).UTC()); err != nil {
+		return nil, wrapDBError("expire idempotency keys", err)
+	}
+
+	var issueID string
+	err = conn.QueryRowContext(ctx, `SELECT issue_id FROM idempotency_keys WHERE key = ?`, key).Scan(&issueID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, wrapDBError("look up idempotency key", err)
+	}
+	return (&sqliteTxStorage{conn: conn, parent: s}).GetIssue(ctx, issueID)
+}
+
+// recordIdempotencyKey records that key created issueID, replacing a record
+// whose issue no longer exists
+func (s *SQLiteStorage) recordIdempotencyKey(ctx context.Context, conn *sql.Conn, key, issueID string) error {
+	if _, err := conn.ExecContext(ctx, `
+		INSERT OR REPLACE INTO idempotency_keys (key, issue_id, created_at) VALUES (?, ?, ?)
+	`, key, issueID, s.now().UTC()); err != nil {
+		return wrapDBError("record idempotency key", err)
+	}
+	return nil
+}
+
+// readIdempotencyWindow returns IdempotencyWindowConfigKey, or
+// DefaultIdempotencyWindow if it is unset or invalid
+func readIdempotencyWindow(ctx context.Context, q queryer) (time.Duration, error) {
+	var value string
+	err := q.QueryRowContext(ctx, `SELECT value FROM config WHERE key = ?`, IdempotencyWindowConfigKey).Scan(&value)
+	if err == sql.ErrNoRows {
+		return DefaultIdempotencyWindow, nil
+	} else if err != nil {
+		return 0, wrapDBError("read idempotency window", err)
+	}
+	window, err := time.ParseDuration(strings.TrimSpace(value))
+	if err != nil || window <= 0 {
+		return DefaultIdempotencyWindow, nil
+	}
+	return window, nil
+}
@@ -0,0 +1,106 @@
+package sqlite
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestCreateIssueIdempotencyKey(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	start := time.Now()
+	store.SetClock(func() time.Time { return start })
+
+	create := func(ctx context.Context, title string) *types.Issue {
+		t.Helper()
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		return issue
+	}
+	countIssues := func() int {
+		t.Helper()
+		issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+		if err != nil {
+			t.Fatalf("SearchIssues failed: %v", err)
+		}
+		return len(issues)
+	}
+
+	keyed := WithIdempotencyKey(ctx, "create-1")
+	first := create(keyed, "Flaky network")
+	retry := create(keyed, "Flaky network")
+	if retry.ID != first.ID || retry.Title != first.Title {
+		t.Errorf("Expected the retry to return %s, got %s (%q)", first.ID, retry.ID, retry.Title)
+	}
+	if n := countIssues(); n != 1 {
+		t.Errorf("Expected 1 issue after a retry, got %d", n)
+	}
+
+	found, err := store.IdempotentIssue(ctx, "create-1")
+	if err != nil {
+		t.Fatalf("IdempotentIssue failed: %v", err)
+	}
+	if found == nil || found.ID != first.ID {
+		t.Errorf("Expected IdempotentIssue to return %s, got %v", first.ID, found)
+	}
+	if found, err := store.IdempotentIssue(ctx, "unknown"); err != nil || found != nil {
+		t.Errorf("Expected no issue for an unknown key, got %v (err %v)", found, err)
+	}
+
+	// Other keys and unkeyed creates are independent
+	if other := create(WithIdempotencyKey(ctx, "create-2"), "Flaky network"); other.ID == first.ID {
+		t.Error("Expected a different key to create a new issue")
+	}
+	create(ctx, "No key")
+	if n := countIssues(); n != 3 {
+		t.Errorf("Expected 3 issues, got %d", n)
+	}
+
+	// Keys expire after the window
+	if err := store.SetConfig(ctx, IdempotencyWindowConfigKey, "1h"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	store.SetClock(func() time.Time { return start.Add(2 * time.Hour) })
+	if found, err := store.IdempotentIssue(ctx, "create-1"); err != nil || found != nil {
+		t.Errorf("Expected an expired key to be forgotten, got %v (err %v)", found, err)
+	}
+	if expired := create(keyed, "Flaky network"); expired.ID == first.ID {
+		t.Error("Expected an expired key to create a new issue")
+	}
+}
+
+func TestCreateIssueIdempotencyKeyConcurrent(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := WithIdempotencyKey(context.Background(), "concurrent")
+
+	const retries = 5
+	ids := make([]string, retries)
+	var wg sync.WaitGroup
+	for i := 0; i < retries; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			issue := &types.Issue{Title: "Raced", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+			if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+				t.Errorf("CreateIssue failed: %v", err)
+				return
+			}
+			ids[i] = issue.ID
+		}(i)
+	}
+	wg.Wait()
+
+	for _, id := range ids[1:] {
+		if id != ids[0] {
+			t.Errorf("Expected every retry to get %s, got %v", ids[0], ids)
+			break
+		}
+	}
+}
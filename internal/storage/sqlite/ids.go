@@ -2,11 +2,14 @@ package sqlite
 
 import (
 	"context"
+	"crypto/rand"
 	"crypto/sha256"
 	"database/sql"
 	"fmt"
 	"math/big"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/steveyegge/beads/internal/types"
@@ -99,10 +102,11 @@ func GenerateIssueID(ctx context.Context, conn *sql.Conn, prefix string, issue *
 		baseLength = maxLength
 	}
 	
+	hashTime := idHashTime(ctx, issue.CreatedAt)
 	for length := baseLength; length <= maxLength; length++ {
 		// Try up to 10 nonces at each length
 		for nonce := 0; nonce < 10; nonce++ {
-			candidate := generateHashID(prefix, issue.Title, issue.Description, actor, issue.CreatedAt, length, nonce)
+			candidate := generateHashID(prefix, issue.Title, issue.Description, actor, hashTime, length, nonce)
 			
 			// Check if this ID already exists
 			var count int
@@ -120,6 +124,90 @@ func GenerateIssueID(ctx context.Context, conn *sql.Conn, prefix string, issue *
 	return "", fmt.Errorf("failed to generate unique ID after trying lengths %d-%d with 10 nonces each", baseLength, maxLength)
 }
 
+type idSeedCtx struct{}
+
+// seededIDs is the deterministic ID state of a store opened with
+// StoreOptions.IDSeed
+type seededIDs struct {
+	seed int64
+	next *atomic.Int64
+}
+
+// seedIDs returns ctx carrying the store's ID seed, if it has one, for the
+// ID generators below
+func (s *SQLiteStorage) seedIDs(ctx context.Context) context.Context {
+	if s.idSeed == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, idSeedCtx{}, seededIDs{seed: s.idSeed, next: &s.idSeq})
+}
+
+// idHashTime returns the time hashed into a generated ID: the issue's
+// creation time, or under seedIDs a stand-in drawn from the seed and the
+// number of IDs generated so far, so the same sequence of creates yields the
+// same IDs whatever the clock says
+func idHashTime(ctx context.Context, createdAt time.Time) time.Time {
+	ids, ok := ctx.Value(idSeedCtx{}).(seededIDs)
+	if !ok {
+		return createdAt
+	}
+	return time.Unix(ids.seed, ids.next.Add(1))
+}
+
+// issueIDGenerator generates IDs for CreateIssue. It is a variable so tests
+// can force collisions.
+var issueIDGenerator = GenerateIssueID
+
+// insertGeneratedIssue inserts an issue whose ID was auto-generated. If the
+// insert hits a unique violation on the ID (e.g. a row appeared that the
+// generator's existence check didn't see), it regenerates the ID with added
+// entropy and retries, up to the configured number of times, before returning
+// ErrDuplicateID.
+func insertGeneratedIssue(ctx context.Context, conn *sql.Conn, prefix string, issue *types.Issue, actor string) error {
+	retries := idCollisionRetries(ctx, conn)
+	for attempt := 0; ; attempt++ {
+		err := insertIssue(ctx, conn, issue)
+		if err == nil || !isIDCollision(err) {
+			return err
+		}
+		if attempt >= retries {
+			return fmt.Errorf("%w: %s still collides after %d retries", ErrDuplicateID, issue.ID, retries)
+		}
+
+		// Salting the creator changes the hash input, so the generator can't
+		// land on the same candidate again
+		salt := make([]byte, 8)
+		if _, err := rand.Read(salt); err != nil {
+			return fmt.Errorf("failed to generate ID entropy: %w", err)
+		}
+		id, err := issueIDGenerator(ctx, conn, prefix, issue, fmt.Sprintf("%s|%x", actor, salt))
+		if err != nil {
+			return fmt.Errorf("failed to regenerate issue ID: %w", err)
+		}
+		issue.ID = id
+	}
+}
+
+// isIDCollision reports whether err is a unique violation on issues.id (as
+// opposed to another unique column such as external_ref)
+func isIDCollision(err error) bool {
+	return IsUniqueConstraintError(err) && strings.Contains(err.Error(), "issues.id")
+}
+
+// idCollisionRetries reads IDCollisionRetriesConfigKey within the caller's transaction
+func idCollisionRetries(ctx context.Context, conn *sql.Conn) int {
+	var value string
+	err := conn.QueryRowContext(ctx, `SELECT value FROM config WHERE key = ?`, IDCollisionRetriesConfigKey).Scan(&value)
+	if err != nil {
+		return DefaultIDCollisionRetries
+	}
+	retries, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || retries < 0 {
+		return DefaultIDCollisionRetries
+	}
+	return retries
+}
+
 // GenerateBatchIssueIDs generates unique IDs for multiple issues in a single batch
 // Tracks used IDs to prevent intra-batch collisions
 func GenerateBatchIssueIDs(ctx context.Context, conn *sql.Conn, prefix string, issues []*types.Issue, actor string, usedIDs map[string]bool) error {
@@ -139,10 +227,11 @@ func GenerateBatchIssueIDs(ctx context.Context, conn *sql.Conn, prefix string, i
 	for i := range issues {
 		if issues[i].ID == "" {
 			var generated bool
+			hashTime := idHashTime(ctx, issues[i].CreatedAt)
 			// Try lengths from baseLength to maxLength with progressive fallback
 			for length := baseLength; length <= maxLength && !generated; length++ {
 				for nonce := 0; nonce < 10; nonce++ {
-					candidate := generateHashID(prefix, issues[i].Title, issues[i].Description, actor, issues[i].CreatedAt, length, nonce)
+					candidate := generateHashID(prefix, issues[i].Title, issues[i].Description, actor, hashTime, length, nonce)
 					
 					// Check if this ID is already used in this batch or in the database
 					if usedIDs[candidate] {
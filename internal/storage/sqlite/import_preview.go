@@ -0,0 +1,191 @@
+// Package sqlite - import previews (impact analysis without writing)
+package sqlite
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ImportPreview describes what importing a JSONL stream would do to the
+// database. IDs are listed in input order.
+type ImportPreview struct {
+	Created   []string `json:"created"`   // Issues not in the database
+	Updated   []string `json:"updated"`   // Existing issues whose content would change
+	Deleted   []string `json:"deleted"`   // Existing issues the input tombstones
+	Unchanged int      `json:"unchanged"` // Identical, or not newer than the local copy
+	Skipped   int      `json:"skipped"`   // Locally deleted issues, which import never resurrects
+
+	StatusChanges       []StatusChange      `json:"status_changes"`       // Existing issues whose status would change
+	Cycles              [][]string          `json:"cycles"`               // Dependency cycles the import would introduce, as ID paths
+	MissingDependencies []*types.Dependency `json:"missing_dependencies"` // Incoming edges to or from issues that would not exist
+}
+
+// PreviewImport reads JSONL issues from r and reports what importing them
+// would change, without writing anything. It is a dry run of ImportIssues
+// for reviewing a teammate's export before a git-sync merge.
+//
+// Issues are matched to existing ones by ID, following the importer: a
+// differing issue is only updated if it is newer than the local copy, and
+// locally tombstoned issues are never resurrected. Incoming dependencies are
+// added to the existing ones when looking for cycles.
+func (s *SQLiteStorage) PreviewImport(ctx context.Context, r io.Reader) (ImportPreview, error) {
+	var incoming []*types.Issue
+	decoder := json.NewDecoder(r)
+	for {
+		var issue types.Issue
+		if err := decoder.Decode(&issue); err == io.EOF {
+			break
+		} else if err != nil {
+			return ImportPreview{}, fmt.Errorf("failed to parse issue %d: %w", len(incoming)+1, err)
+		}
+		incoming = append(incoming, &issue)
+	}
+	return s.PreviewImportIssues(ctx, incoming)
+}
+
+// PreviewImportIssues is PreviewImport for already parsed issues
+func (s *SQLiteStorage) PreviewImportIssues(ctx context.Context, incoming []*types.Issue) (ImportPreview, error) {
+	preview := ImportPreview{
+		Created:             []string{},
+		Updated:             []string{},
+		Deleted:             []string{},
+		StatusChanges:       []StatusChange{},
+		Cycles:              [][]string{},
+		MissingDependencies: []*types.Dependency{},
+	}
+
+	existing, err := s.SearchIssues(ctx, "", types.IssueFilter{IncludeTombstones: true, IncludeSnoozed: true})
+	if err != nil {
+		return preview, err
+	}
+	byID := make(map[string]*types.Issue, len(existing))
+	live := make(map[string]bool, len(existing))
+	for _, issue := range existing {
+		byID[issue.ID] = issue
+		live[issue.ID] = issue.Status != types.StatusTombstone
+	}
+
+	// Classify against the database, tracking which issues exist afterwards
+	seen := make(map[string]bool, len(incoming))
+	for _, issue := range incoming {
+		if seen[issue.ID] {
+			preview.Skipped++
+			continue
+		}
+		seen[issue.ID] = true
+
+		current, found := byID[issue.ID]
+		switch {
+		case !found:
+			preview.Created = append(preview.Created, issue.ID)
+			live[issue.ID] = issue.Status != types.StatusTombstone
+		case current.Status == types.StatusTombstone:
+			preview.Skipped++
+		case issue.ComputeContentHash() == current.ComputeContentHash() || !issue.UpdatedAt.After(current.UpdatedAt):
+			preview.Unchanged++
+		case issue.Status == types.StatusTombstone:
+			preview.Deleted = append(preview.Deleted, issue.ID)
+			live[issue.ID] = false
+		default:
+			preview.Updated = append(preview.Updated, issue.ID)
+			if issue.Status != current.Status {
+				preview.StatusChanges = append(preview.StatusChanges, StatusChange{
+					IssueID: issue.ID, Title: current.Title, From: current.Status, To: issue.Status,
+				})
+			}
+		}
+	}
+
+	records, err := s.GetAllDependencyRecords(ctx)
+	if err != nil {
+		return preview, err
+	}
+	graph := make(map[string][]string)
+	edges := make(map[[2]string]bool)
+	for _, deps := range records {
+		for _, dep := range deps {
+			graph[dep.IssueID] = append(graph[dep.IssueID], dep.DependsOnID)
+			edges[[2]string{dep.IssueID, dep.DependsOnID}] = true
+		}
+	}
+
+	var added [][2]string
+	for _, issue := range incoming {
+		if issue.Status == types.StatusTombstone {
+			continue
+		}
+		for _, dep := range issue.Dependencies {
+			if dep.IssueID == "" {
+				dep.IssueID = issue.ID
+			}
+			if !live[dep.IssueID] || !live[dep.DependsOnID] {
+				preview.MissingDependencies = append(preview.MissingDependencies, dep)
+				continue
+			}
+			edge := [2]string{dep.IssueID, dep.DependsOnID}
+			if edges[edge] {
+				continue
+			}
+			edges[edge] = true
+			graph[dep.IssueID] = append(graph[dep.IssueID], dep.DependsOnID)
+			added = append(added, edge)
+		}
+	}
+
+	// A new edge from -> to closes a cycle if from is reachable from to
+	reported := make(map[string]bool)
+	for _, edge := range added {
+		path := findDependencyPath(graph, edge[1], edge[0])
+		if path == nil {
+			continue
+		}
+		cycle := append([]string{edge[0]}, path...)
+		key := cycleKey(cycle)
+		if reported[key] {
+			continue
+		}
+		reported[key] = true
+		preview.Cycles = append(preview.Cycles, cycle)
+	}
+
+	return preview, nil
+}
+
+// findDependencyPath returns the shortest path of IDs from -> ... -> to in
+// graph, or nil if to is unreachable
+func findDependencyPath(graph map[string][]string, from, to string) []string {
+	parent := map[string]string{from: ""}
+	queue := []string{from}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if id == to {
+			var path []string
+			for ; id != ""; id = parent[id] {
+				path = append([]string{id}, path...)
+			}
+			return path
+		}
+		for _, next := range graph[id] {
+			if _, visited := parent[next]; !visited {
+				parent[next] = id
+				queue = append(queue, next)
+			}
+		}
+	}
+	return nil
+}
+
+// cycleKey identifies a cycle path (first ID repeated last) regardless of
+// which of its issues it starts from
+func cycleKey(cycle []string) string {
+	ids := append([]string(nil), cycle[:len(cycle)-1]...)
+	sort.Strings(ids)
+	return strings.Join(ids, "\x00")
+}
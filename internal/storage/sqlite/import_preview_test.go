@@ -0,0 +1,103 @@
+package sqlite
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestPreviewImport(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	newIssue := func(title string) *types.Issue {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		return issue
+	}
+	same := newIssue("Same")
+	closing := newIssue("Closing")
+	stale := newIssue("Stale")
+	doomed := newIssue("Doomed")
+	blocker := newIssue("Blocker")
+	if err := store.AddDependency(ctx, &types.Dependency{IssueID: blocker.ID, DependsOnID: closing.ID, Type: types.DepBlocks}, "test"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+
+	later := time.Now().Add(time.Hour)
+	closed := *closing
+	closed.Status = types.StatusClosed
+	closed.ClosedAt = &later
+	closed.UpdatedAt = later
+	// A closing -> blocker edge closes the cycle blocker -> closing -> blocker
+	closed.Dependencies = []*types.Dependency{{IssueID: closing.ID, DependsOnID: blocker.ID, Type: types.DepBlocks}}
+
+	older := *stale
+	older.Title = "Stale edit"
+	older.UpdatedAt = stale.UpdatedAt.Add(-time.Hour)
+
+	deleted := *doomed
+	deleted.Status = types.StatusTombstone
+	deleted.DeletedAt = &later
+	deleted.UpdatedAt = later
+
+	created := &types.Issue{ID: "bd-new", Title: "New", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask,
+		Dependencies: []*types.Dependency{
+			{IssueID: "bd-new", DependsOnID: same.ID, Type: types.DepBlocks},
+			{IssueID: "bd-new", DependsOnID: "bd-missing", Type: types.DepBlocks},
+		}}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, issue := range []*types.Issue{same, &closed, &older, &deleted, created} {
+		if err := encoder.Encode(issue); err != nil {
+			t.Fatalf("Encode failed: %v", err)
+		}
+	}
+
+	preview, err := store.PreviewImport(ctx, &buf)
+	if err != nil {
+		t.Fatalf("PreviewImport failed: %v", err)
+	}
+
+	if len(preview.Created) != 1 || preview.Created[0] != "bd-new" {
+		t.Errorf("Expected created [bd-new], got %v", preview.Created)
+	}
+	if len(preview.Updated) != 1 || preview.Updated[0] != closing.ID {
+		t.Errorf("Expected updated [%s], got %v", closing.ID, preview.Updated)
+	}
+	if len(preview.Deleted) != 1 || preview.Deleted[0] != doomed.ID {
+		t.Errorf("Expected deleted [%s], got %v", doomed.ID, preview.Deleted)
+	}
+	if preview.Unchanged != 2 {
+		t.Errorf("Expected 2 unchanged (identical and older), got %d", preview.Unchanged)
+	}
+	if len(preview.StatusChanges) != 1 || preview.StatusChanges[0].IssueID != closing.ID ||
+		preview.StatusChanges[0].From != types.StatusOpen || preview.StatusChanges[0].To != types.StatusClosed {
+		t.Errorf("Expected %s open -> closed, got %+v", closing.ID, preview.StatusChanges)
+	}
+	if len(preview.Cycles) != 1 || len(preview.Cycles[0]) != 3 ||
+		preview.Cycles[0][0] != closing.ID || preview.Cycles[0][1] != blocker.ID || preview.Cycles[0][2] != closing.ID {
+		t.Errorf("Expected cycle %s -> %s -> %s, got %v", closing.ID, blocker.ID, closing.ID, preview.Cycles)
+	}
+	if len(preview.MissingDependencies) != 1 || preview.MissingDependencies[0].DependsOnID != "bd-missing" {
+		t.Errorf("Expected missing dependency on bd-missing, got %+v", preview.MissingDependencies)
+	}
+
+	// Nothing was written
+	if issue, err := store.GetIssue(ctx, "bd-new"); err != nil || issue != nil {
+		t.Errorf("Expected bd-new not to be created, got %+v (%v)", issue, err)
+	}
+	if issue, err := store.GetIssue(ctx, closing.ID); err != nil || issue.Status != types.StatusOpen {
+		t.Errorf("Expected %s to stay open, got %+v (%v)", closing.ID, issue, err)
+	}
+	if cycles, err := store.DetectCycles(ctx); err != nil || len(cycles) != 0 {
+		t.Errorf("Expected no cycles in the store, got %v (%v)", cycles, err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 
+	"github.com/steveyegge/beads/internal/compression"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -15,25 +16,31 @@ func insertIssue(ctx context.Context, conn *sql.Conn, issue *types.Issue) error
 		sourceRepo = "." // Default to primary repo
 	}
 
-	_, err := conn.ExecContext(ctx, `
+	description, err := storedDescription(ctx, conn, issue.Description)
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.ExecContext(ctx, `
 		INSERT INTO issues (
 			id, content_hash, title, description, design, acceptance_criteria, notes,
 			status, priority, issue_type, assignee, estimated_minutes,
 			created_at, updated_at, closed_at, external_ref, source_repo, close_reason,
-			deleted_at, deleted_by, delete_reason, original_type
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			deleted_at, deleted_by, delete_reason, original_type, draft, percent_complete, external_blocked_reason, snoozed_until, due_date
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`,
-		issue.ID, issue.ContentHash, issue.Title, issue.Description, issue.Design,
+		issue.ID, issue.ContentHash, issue.Title, description, issue.Design,
 		issue.AcceptanceCriteria, issue.Notes, issue.Status,
 		issue.Priority, issue.IssueType, issue.Assignee,
 		issue.EstimatedMinutes, issue.CreatedAt, issue.UpdatedAt,
 		issue.ClosedAt, issue.ExternalRef, sourceRepo, issue.CloseReason,
-		issue.DeletedAt, issue.DeletedBy, issue.DeleteReason, issue.OriginalType,
+		issue.DeletedAt, issue.DeletedBy, issue.DeleteReason, issue.OriginalType, issue.Draft, issue.PercentComplete, issue.ExternalBlockedReason, issue.SnoozedUntil, issue.DueDate,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to insert issue: %w", err)
 	}
-	return nil
+	issue.Version = 1 // The column default
+	return syncFTS(ctx, conn, issue.ID)
 }
 
 // insertIssues bulk inserts multiple issues using a prepared statement
@@ -43,31 +50,41 @@ func insertIssues(ctx context.Context, conn *sql.Conn, issues []*types.Issue) er
 			id, content_hash, title, description, design, acceptance_criteria, notes,
 			status, priority, issue_type, assignee, estimated_minutes,
 			created_at, updated_at, closed_at, external_ref, source_repo, close_reason,
-			deleted_at, deleted_by, delete_reason, original_type
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			deleted_at, deleted_by, delete_reason, original_type, draft, percent_complete, external_blocked_reason, snoozed_until, due_date
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`)
 	if err != nil {
 		return fmt.Errorf("failed to prepare statement: %w", err)
 	}
 	defer func() { _ = stmt.Close() }()
 
+	threshold := descriptionThreshold(ctx, conn)
 	for _, issue := range issues {
 		sourceRepo := issue.SourceRepo
 		if sourceRepo == "" {
 			sourceRepo = "." // Default to primary repo
 		}
 
+		description, err := compression.CompressAbove(issue.Description, threshold)
+		if err != nil {
+			return fmt.Errorf("failed to encode description for %s: %w", issue.ID, err)
+		}
+
 		_, err = stmt.ExecContext(ctx,
-			issue.ID, issue.ContentHash, issue.Title, issue.Description, issue.Design,
+			issue.ID, issue.ContentHash, issue.Title, description, issue.Design,
 			issue.AcceptanceCriteria, issue.Notes, issue.Status,
 			issue.Priority, issue.IssueType, issue.Assignee,
 			issue.EstimatedMinutes, issue.CreatedAt, issue.UpdatedAt,
 			issue.ClosedAt, issue.ExternalRef, sourceRepo, issue.CloseReason,
-			issue.DeletedAt, issue.DeletedBy, issue.DeleteReason, issue.OriginalType,
+			issue.DeletedAt, issue.DeletedBy, issue.DeleteReason, issue.OriginalType, issue.Draft, issue.PercentComplete, issue.ExternalBlockedReason, issue.SnoozedUntil, issue.DueDate,
 		)
 		if err != nil {
 			return fmt.Errorf("failed to insert issue %s: %w", issue.ID, err)
 		}
+		issue.Version = 1
+		if err := syncFTS(ctx, conn, issue.ID); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -0,0 +1,183 @@
+// Package sqlite - streaming JSONL export and import
+package sqlite
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+
+	"github.com/steveyegge/beads/internal/export"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// jsonlExportPageSize is how many issues ExportJSONL reads at a time
+const jsonlExportPageSize = 500
+
+// JSONLExportOptions controls how ExportJSONL writes issues
+type JSONLExportOptions struct {
+	Gzip bool // Compress the output; ImportJSONL detects it on its own
+}
+
+// ExportJSONL streams every issue, tombstones included, to w as one JSON
+// object per line in ID order, with its labels, dependencies, comments and
+// links, in the export timestamp format. Local-only drafts are left out, as
+// are issues ctx's actor can't see (see WithActor). Issues are read a page at
+// a time, so memory use doesn't grow with the size of the database, but all
+// pages come from one read transaction, so the export is a consistent
+// snapshot even while others write.
+func (s *SQLiteStorage) ExportJSONL(ctx context.Context, w io.Writer, opts JSONLExportOptions) error {
+	var gz *gzip.Writer
+	if opts.Gzip {
+		gz = gzip.NewWriter(w)
+		w = gz
+	}
+	bw := bufio.NewWriter(w)
+	encoder := export.NewIssueEncoder(bw, export.LoadTimestampFormat(ctx, s))
+
+	err := s.ReadSnapshot(ctx, func(tx *sql.Tx) error {
+		after := ""
+		for {
+			issues, err := jsonlExportPage(ctx, tx, after)
+			if err != nil {
+				return err
+			}
+			for _, issue := range issues {
+				if err := encoder.Encode(issue); err != nil {
+					return fmt.Errorf("failed to encode issue %s: %w", issue.ID, err)
+				}
+			}
+			if len(issues) < jsonlExportPageSize {
+				return nil
+			}
+			after = issues[len(issues)-1].ID
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("failed to write JSONL: %w", err)
+	}
+	if gz != nil {
+		if err := gz.Close(); err != nil {
+			return fmt.Errorf("failed to finish gzip stream: %w", err)
+		}
+	}
+	return nil
+}
+
+// jsonlExportPage returns the next page of exported issues with IDs after
+// after, read from q, with their relations attached
+func jsonlExportPage(ctx context.Context, q queryer, after string) ([]*types.Issue, error) {
+	args := []interface{}{after}
+	visible, visibleArgs := visibleClause(ctx, "issues.id")
+	if visible != "" {
+		visible = " AND " + visible
+		args = append(args, visibleArgs...)
+	}
+	args = append(args, jsonlExportPageSize)
+
+	// #nosec G201 - visible is built by visibleClause
+	rows, err := q.QueryContext(ctx, fmt.Sprintf(`
+		SELECT id, content_hash, title, description, design, acceptance_criteria, notes,
+		       status, priority, issue_type, assignee, estimated_minutes,
+		       created_at, updated_at, closed_at, external_ref, source_repo, close_reason,
+		       deleted_at, deleted_by, delete_reason, original_type, draft, percent_complete, external_blocked_reason, snoozed_until, due_date, version
+		FROM issues
+		WHERE id > ? AND draft = 0%s
+		ORDER BY id
+		LIMIT ?
+	`, visible), args...)
+	if err != nil {
+		return nil, withContextError(ctx, fmt.Errorf("failed to query issues: %w", err))
+	}
+	issues, err := scanIssuesOn(ctx, q, rows)
+	_ = rows.Close()
+	if err != nil {
+		return nil, err
+	}
+	if len(issues) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	deps, err := dependencyRecordsForIssues(ctx, q, ids)
+	if err != nil {
+		return nil, err
+	}
+	comments, err := commentsForIssues(ctx, q, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comments: %w", err)
+	}
+	links, err := linksForIssues(ctx, q, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get links: %w", err)
+	}
+	for _, issue := range issues {
+		issue.Dependencies = deps[issue.ID]
+		issue.Comments = comments[issue.ID]
+		issue.Links = links[issue.ID]
+	}
+	return issues, nil
+}
+
+// dependencyRecordsForIssues returns the outgoing dependencies of each of
+// issueIDs, read from q, like GetAllDependencyRecords restricted to those
+// issues. Dependencies on issues ctx's actor can't see are left out.
+func dependencyRecordsForIssues(ctx context.Context, q queryer, issueIDs []string) (map[string][]*types.Dependency, error) {
+	args := make([]interface{}, len(issueIDs))
+	for i, id := range issueIDs {
+		args[i] = id
+	}
+	visible, visibleArgs := visibleClause(ctx, "dependencies.depends_on_id")
+	if visible != "" {
+		visible = " AND " + visible
+		args = append(args, visibleArgs...)
+	}
+	query := fmt.Sprintf(`
+		SELECT issue_id, depends_on_id, type, created_at, created_by, note
+		FROM dependencies
+		WHERE issue_id IN (%s)%s
+		ORDER BY issue_id, created_at ASC
+	`, buildPlaceholders(len(issueIDs)), visible) // #nosec G201 -- placeholders are generated internally
+
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dependency records: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	depsMap := make(map[string][]*types.Dependency)
+	for rows.Next() {
+		var dep types.Dependency
+		if err := rows.Scan(&dep.IssueID, &dep.DependsOnID, &dep.Type, &dep.CreatedAt, &dep.CreatedBy, &dep.Note); err != nil {
+			return nil, fmt.Errorf("failed to scan dependency: %w", err)
+		}
+		depsMap[dep.IssueID] = append(depsMap[dep.IssueID], &dep)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate dependency records: %w", err)
+	}
+	return depsMap, nil
+}
+
+// ImportJSONL reads issues from r, as written by ExportJSONL, and upserts
+// them with their dependencies, labels and comments in a single transaction,
+// the way multi-repo hydration imports a repo's issues.jsonl. Gzip-compressed
+// input is detected from its magic bytes and decompressed on the fly. Issues
+// are decoded and written one line at a time, so the input is never held in
+// memory. Returns the number of issues imported.
+func (s *SQLiteStorage) ImportJSONL(ctx context.Context, r io.Reader) (int, error) {
+	in, err := export.NewJSONLReader(r)
+	if err != nil {
+		return 0, err
+	}
+	return s.importJSONL(ctx, in, "")
+}
@@ -0,0 +1,290 @@
+package sqlite
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestJSONLRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	src := newTestStore(t, "")
+
+	a := &types.Issue{Title: "Blocked", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeBug}
+	b := &types.Issue{Title: "Blocker", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{a, b} {
+		if err := src.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	if err := src.AddDependency(ctx, &types.Dependency{IssueID: a.ID, DependsOnID: b.ID, Type: types.DepBlocks}, "test"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+	if err := src.AddLabel(ctx, a.ID, "backend", "test"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+	if _, err := src.AddIssueComment(ctx, a.ID, "alice", "Looking into it"); err != nil {
+		t.Fatalf("AddIssueComment failed: %v", err)
+	}
+
+	for _, gzipped := range []bool{false, true} {
+		t.Run(fmt.Sprintf("gzip=%v", gzipped), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := src.ExportJSONL(ctx, &buf, JSONLExportOptions{Gzip: gzipped}); err != nil {
+				t.Fatalf("ExportJSONL failed: %v", err)
+			}
+			if isGzip := bytes.HasPrefix(buf.Bytes(), []byte{0x1f, 0x8b}); isGzip != gzipped {
+				t.Fatalf("gzip magic present = %v, want %v", isGzip, gzipped)
+			}
+
+			dst := newTestStore(t, "")
+			count, err := dst.ImportJSONL(ctx, &buf)
+			if err != nil {
+				t.Fatalf("ImportJSONL failed: %v", err)
+			}
+			if count != 2 {
+				t.Fatalf("imported %d issues, want 2", count)
+			}
+
+			got, err := dst.GetIssue(ctx, a.ID)
+			if err != nil || got == nil {
+				t.Fatalf("GetIssue(%s) = %v, %v", a.ID, got, err)
+			}
+			if got.Title != "Blocked" || got.Priority != 1 || got.IssueType != types.TypeBug {
+				t.Errorf("imported issue = %+v", got)
+			}
+			if labels, _ := dst.GetLabels(ctx, a.ID); len(labels) != 1 || labels[0] != "backend" {
+				t.Errorf("labels = %v, want [backend]", labels)
+			}
+			if deps, _ := dst.GetDependencyRecords(ctx, a.ID); len(deps) != 1 || deps[0].DependsOnID != b.ID {
+				t.Errorf("dependencies = %v, want one on %s", deps, b.ID)
+			}
+			if comments, _ := dst.GetIssueComments(ctx, a.ID); len(comments) != 1 || comments[0].Text != "Looking into it" {
+				t.Errorf("comments = %v", comments)
+			}
+		})
+	}
+}
+
+func TestJSONLImportRejectsCorruptGzip(t *testing.T) {
+	store := newTestStore(t, "")
+	_, err := store.ImportJSONL(context.Background(), bytes.NewReader([]byte{0x1f, 0x8b, 0x00, 0x01}))
+	if err == nil {
+		t.Fatal("ImportJSONL accepted a corrupt gzip stream")
+	}
+}
+
+// TestJSONLStreamingMemory round-trips a 50MB export through a gzip file
+// and checks the heap never grows anywhere near the size of the data.
+func TestJSONLExportSkipsDraftsAndHiddenIssues(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t, "")
+
+	public := &types.Issue{Title: "Public", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	secret := &types.Issue{Title: "Secret", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	draft := &types.Issue{Title: "Scratchpad", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, Draft: true}
+	for _, issue := range []*types.Issue{public, secret, draft} {
+		if err := store.CreateIssue(ctx, issue, "alice"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	if err := store.AddDependency(ctx, &types.Dependency{IssueID: public.ID, DependsOnID: secret.ID, Type: types.DepBlocks}, "alice"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+	if err := store.SetVisibility(ctx, secret.ID, types.VisibilityPrivate, nil, "alice"); err != nil {
+		t.Fatalf("SetVisibility failed: %v", err)
+	}
+
+	exported := func(ctx context.Context) map[string]*types.Issue {
+		t.Helper()
+		var buf bytes.Buffer
+		if err := store.ExportJSONL(ctx, &buf, JSONLExportOptions{}); err != nil {
+			t.Fatalf("ExportJSONL failed: %v", err)
+		}
+		issues := make(map[string]*types.Issue)
+		for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+			var issue types.Issue
+			if err := json.Unmarshal([]byte(line), &issue); err != nil {
+				t.Fatalf("failed to decode %q: %v", line, err)
+			}
+			issues[issue.ID] = &issue
+		}
+		return issues
+	}
+
+	alice := exported(WithActor(ctx, "alice"))
+	if len(alice) != 2 || alice[public.ID] == nil || alice[secret.ID] == nil {
+		t.Errorf("Expected alice's export to hold %s and %s but not the draft, got %v", public.ID, secret.ID, alice)
+	}
+	if got := alice[public.ID]; got != nil && len(got.Dependencies) != 1 {
+		t.Errorf("Expected alice to see the dependency on %s, got %v", secret.ID, got.Dependencies)
+	}
+
+	bob := exported(WithActor(ctx, "bob"))
+	if len(bob) != 1 || bob[public.ID] == nil {
+		t.Fatalf("Expected bob's export to hold only %s, got %v", public.ID, bob)
+	}
+	if deps := bob[public.ID].Dependencies; len(deps) != 0 {
+		t.Errorf("Expected bob not to see the dependency on %s, got %v", secret.ID, deps)
+	}
+}
+
+func TestJSONLStreamingMemory(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping 50MB round trip in short mode")
+	}
+	ctx := context.Background()
+	const (
+		issueCount = 5400
+		exportSize = 50 << 20
+		heapLimit  = 20 << 20
+	)
+
+	// Feed the source store from a generator rather than a buffer so the
+	// input itself never sits in memory.
+	pr, pw := io.Pipe()
+	go func() {
+		encoder := json.NewEncoder(pw)
+		created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		for i := 0; i < issueCount; i++ {
+			issue := &types.Issue{
+				ID:          fmt.Sprintf("bd-%05d", i),
+				Title:       fmt.Sprintf("Issue %d", i),
+				Description: strings.Repeat(fmt.Sprintf("line %d of a long description; ", i), 300),
+				Status:      types.StatusOpen,
+				Priority:    i % 5,
+				IssueType:   types.TypeTask,
+				CreatedAt:   created,
+				UpdatedAt:   created,
+				Labels:      []string{fmt.Sprintf("batch-%d", i%10)},
+			}
+			if err := encoder.Encode(issue); err != nil {
+				_ = pw.CloseWithError(err)
+				return
+			}
+		}
+		_ = pw.Close()
+	}()
+
+	peak := watchHeap(t)
+
+	src := newTestStore(t, "")
+	if n, err := src.ImportJSONL(ctx, pr); err != nil || n != issueCount {
+		t.Fatalf("seeding ImportJSONL = %d, %v", n, err)
+	}
+
+	counter := &countingWriter{}
+	if err := src.ExportJSONL(ctx, counter, JSONLExportOptions{}); err != nil {
+		t.Fatalf("ExportJSONL failed: %v", err)
+	}
+	if counter.n < exportSize {
+		t.Fatalf("export is %d bytes, want at least %d", counter.n, exportSize)
+	}
+
+	path := filepath.Join(t.TempDir(), "issues.jsonl.gz")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("failed to create export file: %v", err)
+	}
+	if err := src.ExportJSONL(ctx, f, JSONLExportOptions{Gzip: true}); err != nil {
+		t.Fatalf("gzip ExportJSONL failed: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatalf("failed to close export file: %v", err)
+	}
+
+	f, err = os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open export file: %v", err)
+	}
+	defer f.Close()
+	dst := newTestStore(t, "")
+	if n, err := dst.ImportJSONL(ctx, f); err != nil || n != issueCount {
+		t.Fatalf("ImportJSONL = %d, %v", n, err)
+	}
+
+	if growth := peak(); growth > heapLimit {
+		t.Errorf("heap grew by %d bytes during a %d byte round trip, want at most %d", growth, counter.n, heapLimit)
+	}
+
+	last := fmt.Sprintf("bd-%05d", issueCount-1)
+	got, err := dst.GetIssue(ctx, last)
+	if err != nil || got == nil {
+		t.Fatalf("GetIssue(%s) = %v, %v", last, got, err)
+	}
+	if want := strings.Repeat(fmt.Sprintf("line %d of a long description; ", issueCount-1), 300); got.Description != want {
+		t.Errorf("description of %s didn't survive the round trip", last)
+	}
+	if labels, _ := dst.GetLabels(ctx, last); len(labels) != 1 || labels[0] != fmt.Sprintf("batch-%d", (issueCount-1)%10) {
+		t.Errorf("labels of %s = %v", last, labels)
+	}
+}
+
+// watchHeap samples the heap until the test ends and returns a func that
+// reports the largest growth seen over the heap at the time of the call
+func watchHeap(t *testing.T) func() uint64 {
+	t.Helper()
+	// Collect often so the samples track live memory rather than garbage
+	// waiting for the next cycle.
+	gcPercent := debug.SetGCPercent(10)
+	t.Cleanup(func() { debug.SetGCPercent(gcPercent) })
+	runtime.GC()
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	base := stats.HeapAlloc
+
+	var mu sync.Mutex
+	var max uint64
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(5 * time.Millisecond)
+		defer ticker.Stop()
+		var s runtime.MemStats
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				runtime.ReadMemStats(&s)
+				mu.Lock()
+				if s.HeapAlloc > base && s.HeapAlloc-base > max {
+					max = s.HeapAlloc - base
+				}
+				mu.Unlock()
+			}
+		}
+	}()
+	t.Cleanup(func() {
+		close(done)
+		<-stopped
+	})
+
+	return func() uint64 {
+		mu.Lock()
+		defer mu.Unlock()
+		return max
+	}
+}
+
+type countingWriter struct {
+	n int
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += len(p)
+	return len(p), nil
+}
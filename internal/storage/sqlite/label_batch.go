@@ -0,0 +1,81 @@
+// Package sqlite - label changes across many issues
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// AddLabelToIssues adds label to every issue in ids in a single transaction
+// and returns how many issues gained it. Issues that already have the label
+// are skipped: they don't count and get no event. Each changed issue gets the
+// same label_added event AddLabel records. If any ID doesn't exist, nothing is
+// changed and the error wraps ErrNotFound.
+func (s *SQLiteStorage) AddLabelToIssues(ctx context.Context, ids []string, label, actor string) (int, error) {
+	return s.batchLabelOperation(ctx, ids, label, actor,
+		`INSERT OR IGNORE INTO labels (issue_id, label) VALUES (?, ?)`,
+		types.EventLabelAdded, fmt.Sprintf("Added label: %s", label))
+}
+
+// RemoveLabelFromIssues removes label from every issue in ids in a single
+// transaction and returns how many issues lost it, following the same rules
+// as AddLabelToIssues.
+func (s *SQLiteStorage) RemoveLabelFromIssues(ctx context.Context, ids []string, label, actor string) (int, error) {
+	return s.batchLabelOperation(ctx, ids, label, actor,
+		`DELETE FROM labels WHERE issue_id = ? AND label = ?`,
+		types.EventLabelRemoved, fmt.Sprintf("Removed label: %s", label))
+}
+
+// batchLabelOperation runs labelSQL (taking issue ID and label) for each
+// issue, recording an event and marking dirty only where a row changed
+func (s *SQLiteStorage) batchLabelOperation(ctx context.Context, ids []string, label, actor, labelSQL string, eventType types.EventType, eventComment string) (int, error) {
+	if strings.TrimSpace(label) == "" {
+		return 0, fmt.Errorf("label is required")
+	}
+
+	count := 0
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		seen := make(map[string]bool, len(ids))
+		for _, id := range ids {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+
+			if err := requireIssue(ctx, tx, id); err != nil {
+				return err
+			}
+			result, err := tx.ExecContext(ctx, labelSQL, id, label)
+			if err != nil {
+				return wrapDBErrorf(err, "update label on %s", id)
+			}
+			rows, err := result.RowsAffected()
+			if err != nil {
+				return fmt.Errorf("failed to check rows affected: %w", err)
+			}
+			if rows == 0 {
+				continue
+			}
+
+			if _, err := tx.ExecContext(ctx, `
+				INSERT INTO events (issue_id, event_type, actor, comment)
+				VALUES (?, ?, ?, ?)
+			`, id, eventType, actor, eventComment); err != nil {
+				return fmt.Errorf("failed to record event: %w", err)
+			}
+			if err := markDirty(ctx, tx, id); err != nil {
+				return err
+			}
+			count++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
@@ -0,0 +1,89 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestBatchLabelOperations(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	var ids []string
+	for _, title := range []string{"One", "Two", "Three"} {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		ids = append(ids, issue.ID)
+	}
+	if err := store.AddLabel(ctx, ids[0], "stale", "test"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+
+	countLabelEvents := func(id string, eventType types.EventType) int {
+		events, err := store.GetEvents(ctx, id, 0)
+		if err != nil {
+			t.Fatalf("GetEvents failed: %v", err)
+		}
+		n := 0
+		for _, e := range events {
+			if e.EventType == eventType {
+				n++
+			}
+		}
+		return n
+	}
+
+	// Duplicates and issues that already have the label don't count
+	count, err := store.AddLabelToIssues(ctx, append(ids, ids[1]), "stale", "bulk")
+	if err != nil {
+		t.Fatalf("AddLabelToIssues failed: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected 2 issues labeled, got %d", count)
+	}
+	for _, id := range ids {
+		labels, err := store.GetLabels(ctx, id)
+		if err != nil {
+			t.Fatalf("GetLabels failed: %v", err)
+		}
+		if len(labels) != 1 || labels[0] != "stale" {
+			t.Errorf("Expected %s labeled stale, got %v", id, labels)
+		}
+		if n := countLabelEvents(id, types.EventLabelAdded); n != 1 {
+			t.Errorf("Expected one label_added event on %s, got %d", id, n)
+		}
+	}
+
+	count, err = store.RemoveLabelFromIssues(ctx, ids[:2], "stale", "bulk")
+	if err != nil {
+		t.Fatalf("RemoveLabelFromIssues failed: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected 2 issues unlabeled, got %d", count)
+	}
+	if n := countLabelEvents(ids[2], types.EventLabelRemoved); n != 0 {
+		t.Errorf("Expected untouched issue to have no label_removed event, got %d", n)
+	}
+
+	// A missing issue aborts the whole batch
+	_, err = store.AddLabelToIssues(ctx, []string{ids[0], "bd-missing"}, "urgent", "bulk")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+	labels, err := store.GetLabels(ctx, ids[0])
+	if err != nil {
+		t.Fatalf("GetLabels failed: %v", err)
+	}
+	if len(labels) != 0 {
+		t.Errorf("Expected failed batch to change nothing, got %v", labels)
+	}
+
+	if _, err := store.AddLabelToIssues(ctx, ids, " ", "bulk"); err == nil {
+		t.Error("Expected error for empty label")
+	}
+}
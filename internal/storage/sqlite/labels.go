@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/steveyegge/beads/internal/types"
@@ -82,7 +83,7 @@ func (s *SQLiteStorage) RemoveLabel(ctx context.Context, issueID, label, actor s
 
 // GetLabels returns all labels for an issue
 func (s *SQLiteStorage) GetLabels(ctx context.Context, issueID string) ([]string, error) {
-	rows, err := s.db.QueryContext(ctx, `
+	rows, err := s.stmts().QueryContext(ctx, `
 		SELECT label FROM labels WHERE issue_id = ? ORDER BY label
 	`, issueID)
 	if err != nil {
@@ -105,6 +106,11 @@ func (s *SQLiteStorage) GetLabels(ctx context.Context, issueID string) ([]string
 // GetLabelsForIssues fetches labels for multiple issues in a single query
 // Returns a map of issue_id -> []labels
 func (s *SQLiteStorage) GetLabelsForIssues(ctx context.Context, issueIDs []string) (map[string][]string, error) {
+	return labelsForIssues(ctx, s.db(), issueIDs)
+}
+
+// labelsForIssues is GetLabelsForIssues on q
+func labelsForIssues(ctx context.Context, q queryer, issueIDs []string) (map[string][]string, error) {
 	if len(issueIDs) == 0 {
 		return make(map[string][]string), nil
 	}
@@ -122,7 +128,7 @@ func (s *SQLiteStorage) GetLabelsForIssues(ctx context.Context, issueIDs []strin
 		ORDER BY issue_id, label
 	`, buildPlaceholders(len(issueIDs))) // #nosec G201 -- placeholders are generated internally
 
-	rows, err := s.db.QueryContext(ctx, query, placeholders...)
+	rows, err := q.QueryContext(ctx, query, placeholders...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to batch get labels: %w", err)
 	}
@@ -140,6 +146,88 @@ func (s *SQLiteStorage) GetLabelsForIssues(ctx context.Context, issueIDs []strin
 	return result, nil
 }
 
+// labelColumn returns the SQL expression to compare against when filtering on
+// the label column. With caseInsensitive set, the stored label is trimmed and
+// lowercased so that variants like "Backend" and " backend" compare equal.
+func labelColumn(column string, caseInsensitive bool) string {
+	if caseInsensitive {
+		return "LOWER(TRIM(" + column + "))"
+	}
+	return column
+}
+
+// labelArg returns the filter value to bind against labelColumn.
+func labelArg(label string, caseInsensitive bool) string {
+	if caseInsensitive {
+		return normalizeLabel(label)
+	}
+	return label
+}
+
+// labelGlobPattern translates a label glob into a LIKE pattern for use with
+// ESCAPE '\': '*' becomes '%', '?' becomes '_', and LIKE's own wildcards in
+// the glob are escaped so they match literally. As with any LIKE, ASCII case
+// is ignored.
+func labelGlobPattern(glob string, caseInsensitive bool) string {
+	glob = labelArg(glob, caseInsensitive)
+	var b strings.Builder
+	for _, r := range glob {
+		switch r {
+		case '\\', '%', '_':
+			b.WriteRune('\\')
+			b.WriteRune(r)
+		case '*':
+			b.WriteRune('%')
+		case '?':
+			b.WriteRune('_')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// normalizeLabel trims surrounding whitespace and lowercases a label
+func normalizeLabel(label string) string {
+	return strings.ToLower(strings.TrimSpace(label))
+}
+
+// ListLabels returns each distinct label in use with the number of issues
+// carrying it, sorted by label. Surrounding whitespace is always ignored; when
+// labels.case_insensitive is enabled, labels differing only in case are merged
+// and reported in lowercase. Deleted (tombstoned) issues are not counted.
+func (s *SQLiteStorage) ListLabels(ctx context.Context) ([]types.LabelCount, error) {
+	column := "TRIM(l.label)"
+	if s.caseInsensitiveLabels(ctx) {
+		column = labelColumn("l.label", true)
+	}
+
+	// #nosec G201 -- column is one of two fixed expressions
+	rows, err := s.db().QueryContext(ctx, fmt.Sprintf(`
+		SELECT %s AS name, COUNT(DISTINCT l.issue_id)
+		FROM labels l
+		JOIN issues i ON i.id = l.issue_id
+		WHERE i.status != 'tombstone'
+		GROUP BY name
+		HAVING name != ''
+		ORDER BY name
+	`, column))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list labels: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var result []types.LabelCount
+	for rows.Next() {
+		var lc types.LabelCount
+		if err := rows.Scan(&lc.Label, &lc.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan label count: %w", err)
+		}
+		result = append(result, lc)
+	}
+	return result, rows.Err()
+}
+
 // buildPlaceholders creates a comma-separated list of SQL placeholders
 func buildPlaceholders(count int) string {
 	if count == 0 {
@@ -152,18 +240,27 @@ func buildPlaceholders(count int) string {
 	return result
 }
 
-// GetIssuesByLabel returns issues with a specific label
+// GetIssuesByLabel returns issues with a specific label.
+// Honors labels.case_insensitive (see CaseInsensitiveLabelsConfigKey).
 func (s *SQLiteStorage) GetIssuesByLabel(ctx context.Context, label string) ([]*types.Issue, error) {
-	rows, err := s.db.QueryContext(ctx, `
+	ci := s.caseInsensitiveLabels(ctx)
+	args := []interface{}{labelArg(label, ci)}
+	visibleSQL := ""
+	if visible, visibleArgs := visibleClause(ctx, "i.id"); visible != "" {
+		visibleSQL = " AND " + visible
+		args = append(args, visibleArgs...)
+	}
+	// #nosec G201 -- labelColumn and visibleClause return fixed expressions
+	rows, err := s.db().QueryContext(ctx, fmt.Sprintf(`
 		SELECT i.id, i.content_hash, i.title, i.description, i.design, i.acceptance_criteria, i.notes,
 		       i.status, i.priority, i.issue_type, i.assignee, i.estimated_minutes,
 		       i.created_at, i.updated_at, i.closed_at, i.external_ref, i.source_repo, i.close_reason,
-		       i.deleted_at, i.deleted_by, i.delete_reason, i.original_type
+		       i.deleted_at, i.deleted_by, i.delete_reason, i.original_type, i.draft, i.percent_complete, i.external_blocked_reason, i.snoozed_until, i.due_date, i.version
 		FROM issues i
 		JOIN labels l ON i.id = l.issue_id
-		WHERE l.label = ?
+		WHERE %s = ?%s
 		ORDER BY i.priority ASC, i.created_at DESC
-	`, label)
+	`, labelColumn("l.label", ci), visibleSQL), args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get issues by label: %w", err)
 	}
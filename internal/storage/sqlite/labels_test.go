@@ -392,3 +392,159 @@ func TestLabelMarksDirty(t *testing.T) {
 		t.Error("Expected issue to be marked dirty after removing label")
 	}
 }
+
+func TestCaseInsensitiveLabels(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// Three issues carrying variants of the same label, one with an unrelated label
+	variants := []string{"Backend", "backend", " backend"}
+	var ids []string
+	for _, label := range append(variants, "frontend") {
+		issue := &types.Issue{Title: "Issue " + label, Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		if err := store.AddLabel(ctx, issue.ID, label, "test-user"); err != nil {
+			t.Fatalf("AddLabel failed: %v", err)
+		}
+		ids = append(ids, issue.ID)
+	}
+
+	// Default: exact matching
+	results, err := store.SearchIssues(ctx, "", types.IssueFilter{Labels: []string{"backend"}})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(results) != 1 {
+		t.Errorf("Expected 1 exact match, got %d", len(results))
+	}
+
+	labels, err := store.ListLabels(ctx)
+	if err != nil {
+		t.Fatalf("ListLabels failed: %v", err)
+	}
+	// " backend" is trimmed and merged with "backend"; "Backend" stays distinct
+	want := []types.LabelCount{{Label: "Backend", Count: 1}, {Label: "backend", Count: 2}, {Label: "frontend", Count: 1}}
+	if len(labels) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, labels)
+	}
+	for i := range want {
+		if labels[i] != want[i] {
+			t.Errorf("Expected %v at %d, got %v", want[i], i, labels[i])
+		}
+	}
+
+	if err := store.SetConfig(ctx, CaseInsensitiveLabelsConfigKey, "true"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+
+	results, err = store.SearchIssues(ctx, "", types.IssueFilter{Labels: []string{"BACKEND "}})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(results) != len(variants) {
+		t.Errorf("Expected %d case-insensitive matches, got %d", len(variants), len(results))
+	}
+
+	results, err = store.SearchIssues(ctx, "", types.IssueFilter{LabelsAny: []string{"backend", "Frontend"}})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(results) != len(ids) {
+		t.Errorf("Expected %d matches for LabelsAny, got %d", len(ids), len(results))
+	}
+
+	ready, err := store.GetReadyWork(ctx, types.WorkFilter{Labels: []string{"backend"}})
+	if err != nil {
+		t.Fatalf("GetReadyWork failed: %v", err)
+	}
+	if len(ready) != len(variants) {
+		t.Errorf("Expected %d ready matches, got %d", len(variants), len(ready))
+	}
+
+	byLabel, err := store.GetIssuesByLabel(ctx, "Backend")
+	if err != nil {
+		t.Fatalf("GetIssuesByLabel failed: %v", err)
+	}
+	if len(byLabel) != len(variants) {
+		t.Errorf("Expected %d issues by label, got %d", len(variants), len(byLabel))
+	}
+
+	labels, err = store.ListLabels(ctx)
+	if err != nil {
+		t.Fatalf("ListLabels failed: %v", err)
+	}
+	want = []types.LabelCount{{Label: "backend", Count: 3}, {Label: "frontend", Count: 1}}
+	if len(labels) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, labels)
+	}
+	for i := range want {
+		if labels[i] != want[i] {
+			t.Errorf("Expected %v at %d, got %v", want[i], i, labels[i])
+		}
+	}
+}
+
+func TestSearchLabelGlobs(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	byLabels := map[string][]string{
+		"backend":   {"area/backend", "urgent"},
+		"ui":        {"area/ui"},
+		"nested":    {"area/ui/forms"},
+		"under":     {"area_x"},
+		"percent":   {"100%done"},
+		"unrelated": {"ops"},
+	}
+	ids := make(map[string]string)
+	for name, labels := range byLabels {
+		issue := &types.Issue{Title: name, Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		for _, label := range labels {
+			if err := store.AddLabel(ctx, issue.ID, label, "test-user"); err != nil {
+				t.Fatalf("AddLabel failed: %v", err)
+			}
+		}
+		ids[name] = issue.ID
+	}
+
+	tests := []struct {
+		name   string
+		filter types.IssueFilter
+		want   []string
+	}{
+		{"prefix", types.IssueFilter{LabelGlobs: []string{"area/*"}}, []string{"backend", "ui", "nested"}},
+		{"with exact label", types.IssueFilter{LabelGlobs: []string{"area/*"}, Labels: []string{"urgent"}}, []string{"backend"}},
+		{"single character", types.IssueFilter{LabelGlobs: []string{"area/u?"}}, []string{"ui"}},
+		{"underscore is literal", types.IssueFilter{LabelGlobs: []string{"area_*"}}, []string{"under"}},
+		{"percent is literal", types.IssueFilter{LabelGlobs: []string{"100%*"}}, []string{"percent"}},
+		{"every glob must match", types.IssueFilter{LabelGlobs: []string{"area/*", "urg*"}}, []string{"backend"}},
+		{"no match", types.IssueFilter{LabelGlobs: []string{"team/*"}}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := store.SearchIssues(ctx, "", tt.filter)
+			if err != nil {
+				t.Fatalf("SearchIssues failed: %v", err)
+			}
+			var got, want []string
+			for _, issue := range results {
+				got = append(got, issue.ID)
+			}
+			for _, name := range tt.want {
+				want = append(want, ids[name])
+			}
+			if !sameIDs(got, want) {
+				t.Errorf("Expected %v, got %v", want, got)
+			}
+		})
+	}
+}
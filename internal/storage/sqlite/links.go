@@ -0,0 +1,165 @@
+// Package sqlite - external URL links on issues
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// DefaultLinkKind is used when AddLink is called without a kind
+const DefaultLinkKind = "link"
+
+// linkKindPattern restricts kinds to short lowercase tokens like "pr" or "runbook"
+var linkKindPattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{0,31}$`)
+
+// validateLink normalizes and checks a link before it is stored
+func validateLink(link *types.Link) error {
+	link.URL = strings.TrimSpace(link.URL)
+	link.Title = strings.TrimSpace(link.Title)
+	link.Kind = strings.ToLower(strings.TrimSpace(link.Kind))
+	if link.Kind == "" {
+		link.Kind = DefaultLinkKind
+	}
+
+	u, err := url.Parse(link.URL)
+	if err != nil {
+		return fmt.Errorf("invalid link URL %q: %w", link.URL, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid link URL %q: must be an absolute http(s) URL", link.URL)
+	}
+	if !linkKindPattern.MatchString(link.Kind) {
+		return fmt.Errorf("invalid link kind %q: must be a short lowercase word (e.g. doc, pr, runbook)", link.Kind)
+	}
+	return nil
+}
+
+// AddLink attaches an external URL to an issue. Links are unique per issue
+// by URL: adding a URL that is already linked updates its title and kind.
+// The URL must be an absolute http(s) URL; an empty kind defaults to
+// DefaultLinkKind.
+func (s *SQLiteStorage) AddLink(ctx context.Context, issueID, rawURL, title, kind, actor string) (*types.Link, error) {
+	link := &types.Link{IssueID: issueID, URL: rawURL, Title: title, Kind: kind}
+	if err := validateLink(link); err != nil {
+		return nil, err
+	}
+
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		if err := requireIssue(ctx, tx, issueID); err != nil {
+			return err
+		}
+
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO issue_links (issue_id, url, title, kind)
+			VALUES (?, ?, ?, ?)
+			ON CONFLICT (issue_id, url) DO UPDATE SET title = excluded.title, kind = excluded.kind
+			RETURNING id, created_at
+		`, issueID, link.URL, link.Title, link.Kind).Scan(&link.ID, &link.CreatedAt)
+		if err != nil {
+			return wrapDBError("add link", err)
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO events (issue_id, event_type, actor, comment)
+			VALUES (?, ?, ?, ?)
+		`, issueID, types.EventLinkAdded, actor, fmt.Sprintf("Added %s link: %s", link.Kind, link.URL))
+		if err != nil {
+			return fmt.Errorf("failed to record event: %w", err)
+		}
+
+		if err := markDirty(ctx, tx, issueID); err != nil {
+			return fmt.Errorf("failed to mark issue dirty: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return link, nil
+}
+
+// RemoveLink detaches url from an issue. Removing a URL that isn't linked is
+// a no-op.
+func (s *SQLiteStorage) RemoveLink(ctx context.Context, issueID, rawURL, actor string) error {
+	rawURL = strings.TrimSpace(rawURL)
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, `DELETE FROM issue_links WHERE issue_id = ? AND url = ?`, issueID, rawURL)
+		if err != nil {
+			return wrapDBError("remove link", err)
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to check rows affected: %w", err)
+		}
+		if rows == 0 {
+			return nil
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO events (issue_id, event_type, actor, comment)
+			VALUES (?, ?, ?, ?)
+		`, issueID, types.EventLinkRemoved, actor, fmt.Sprintf("Removed link: %s", rawURL))
+		if err != nil {
+			return fmt.Errorf("failed to record event: %w", err)
+		}
+
+		if err := markDirty(ctx, tx, issueID); err != nil {
+			return fmt.Errorf("failed to mark issue dirty: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetLinks returns the links attached to an issue, oldest first
+func (s *SQLiteStorage) GetLinks(ctx context.Context, issueID string) ([]*types.Link, error) {
+	links, err := s.GetLinksForIssues(ctx, []string{issueID})
+	if err != nil {
+		return nil, err
+	}
+	return links[issueID], nil
+}
+
+// GetLinksForIssues fetches links for multiple issues in a single query
+// Returns a map of issue_id -> []*Link
+func (s *SQLiteStorage) GetLinksForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.Link, error) {
+	return linksForIssues(ctx, s.db(), issueIDs)
+}
+
+// linksForIssues is GetLinksForIssues on q
+func linksForIssues(ctx context.Context, q queryer, issueIDs []string) (map[string][]*types.Link, error) {
+	result := make(map[string][]*types.Link)
+	if len(issueIDs) == 0 {
+		return result, nil
+	}
+
+	args := make([]interface{}, len(issueIDs))
+	for i, id := range issueIDs {
+		args[i] = id
+	}
+
+	rows, err := q.QueryContext(ctx, fmt.Sprintf(`
+		SELECT id, issue_id, url, title, kind, created_at
+		FROM issue_links
+		WHERE issue_id IN (%s)
+		ORDER BY issue_id, id
+	`, buildPlaceholders(len(issueIDs))), args...) // #nosec G201 -- placeholders are generated internally
+	if err != nil {
+		return nil, wrapDBError("query links", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		link := &types.Link{}
+		if err := rows.Scan(&link.ID, &link.IssueID, &link.URL, &link.Title, &link.Kind, &link.CreatedAt); err != nil {
+			return nil, wrapDBError("scan link", err)
+		}
+		result[link.IssueID] = append(result[link.IssueID], link)
+	}
+	return result, wrapDBError("iterate links", rows.Err())
+}
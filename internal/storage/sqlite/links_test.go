@@ -0,0 +1,75 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestIssueLinks(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Task", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "agent"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	link, err := store.AddLink(ctx, issue.ID, " https://github.com/org/repo/pull/42 ", "Fix PR", "PR", "agent")
+	if err != nil {
+		t.Fatalf("AddLink failed: %v", err)
+	}
+	if link.URL != "https://github.com/org/repo/pull/42" || link.Kind != "pr" || link.ID == 0 {
+		t.Errorf("Expected normalized link with ID, got %+v", link)
+	}
+	if _, err := store.AddLink(ctx, issue.ID, "https://wiki.example.com/runbook", "", "", "agent"); err != nil {
+		t.Fatalf("AddLink failed: %v", err)
+	}
+
+	// Re-adding a URL updates it in place
+	if _, err := store.AddLink(ctx, issue.ID, "https://github.com/org/repo/pull/42", "Fix PR (merged)", "pr", "agent"); err != nil {
+		t.Fatalf("AddLink failed: %v", err)
+	}
+
+	links, err := store.GetLinks(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetLinks failed: %v", err)
+	}
+	if len(links) != 2 {
+		t.Fatalf("Expected 2 links, got %d", len(links))
+	}
+	if links[0].Title != "Fix PR (merged)" || links[1].Kind != DefaultLinkKind {
+		t.Errorf("Unexpected links: %+v, %+v", links[0], links[1])
+	}
+
+	for _, bad := range []struct{ url, kind string }{
+		{"not a url", ""},
+		{"ftp://example.com/file", ""},
+		{"/relative/path", ""},
+		{"https://example.com", "has spaces"},
+	} {
+		if _, err := store.AddLink(ctx, issue.ID, bad.url, "", bad.kind, "agent"); err == nil {
+			t.Errorf("Expected AddLink(%q, kind %q) to be rejected", bad.url, bad.kind)
+		}
+	}
+	if _, err := store.AddLink(ctx, "bd-missing", "https://example.com", "", "", "agent"); !IsNotFound(err) {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+
+	if err := store.RemoveLink(ctx, issue.ID, "https://wiki.example.com/runbook", "agent"); err != nil {
+		t.Fatalf("RemoveLink failed: %v", err)
+	}
+	links, _ = store.GetLinks(ctx, issue.ID)
+	if len(links) != 1 {
+		t.Errorf("Expected 1 link after removal, got %d", len(links))
+	}
+
+	dirty, err := store.GetDirtyIssues(ctx)
+	if err != nil {
+		t.Fatalf("GetDirtyIssues failed: %v", err)
+	}
+	if len(dirty) != 1 || dirty[0] != issue.ID {
+		t.Errorf("Expected %s to be dirty, got %v", issue.ID, dirty)
+	}
+}
@@ -256,5 +256,5 @@ func setupInvariantTestDB(t *testing.T) *sql.DB {
 	t.Cleanup(func() { _ = store.Close() })
 
 	// Return the underlying database connection
-	return store.db
+	return store.db()
 }
@@ -2,8 +2,11 @@
 package sqlite
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/steveyegge/beads/internal/storage/sqlite/migrations"
 )
@@ -11,11 +14,17 @@ import (
 // Migration represents a single database migration
 type Migration struct {
 	Name string
-	Func func(*sql.DB) error
+	Func func(migrations.DB) error
 }
 
+// ErrSchemaTooNew is returned when a database was migrated by a newer bd than
+// this binary. Opening it could silently drop data in columns we don't know about.
+var ErrSchemaTooNew = errors.New("database schema is newer than this version of bd supports")
+
 // migrations is the ordered list of all migrations to run
-// Migrations are run in order during database initialization
+// Migrations are run in order during database initialization.
+// A migration's schema version is its 1-based position in this list, so
+// new migrations must only ever be appended.
 var migrationsList = []Migration{
 	{"dirty_issues_table", migrations.MigrateDirtyIssuesTable},
 	{"external_ref_column", migrations.MigrateExternalRefColumn},
@@ -35,20 +44,47 @@ var migrationsList = []Migration{
 	{"orphan_detection", migrations.MigrateOrphanDetection},
 	{"close_reason_column", migrations.MigrateCloseReasonColumn},
 	{"tombstone_columns", migrations.MigrateTombstoneColumns},
+	{"issue_claims_table", migrations.MigrateIssueClaimsTable},
+	{"issue_links_table", migrations.MigrateIssueLinksTable},
+	{"draft_column", migrations.MigrateDraftColumn},
+	{"sequences_table", migrations.MigrateSequencesTable},
+	{"recurrence_tables", migrations.MigrateRecurrenceTables},
+	{"backfill_updated_at", migrations.MigrateBackfillUpdatedAt},
+	{"dependency_note_column", migrations.MigrateDependencyNoteColumn},
+	{"issue_aliases_table", migrations.MigrateIssueAliasesTable},
+	{"percent_complete_column", migrations.MigratePercentCompleteColumn},
+	{"external_blocked_reason_column", migrations.MigrateExternalBlockedReasonColumn},
+	{"idempotency_keys_table", migrations.MigrateIdempotencyKeysTable},
+	{"snoozed_until_column", migrations.MigrateSnoozedUntilColumn},
+	{"issue_acl_table", migrations.MigrateIssueACLTable},
+	{"issue_sections_table", migrations.MigrateIssueSectionsTable},
+	{"issues_fts", migrations.MigrateIssuesFTS},
+	{"issue_version_column", migrations.MigrateIssueVersionColumn},
+	{"issue_watchers_table", migrations.MigrateIssueWatchersTable},
+	{"due_date_column", migrations.MigrateDueDateColumn},
+	{"issues_fts_go_sync", migrations.MigrateIssuesFTSGoSync},
+	{"issue_version_user_columns", migrations.MigrateIssueVersionUserColumns},
 }
 
 // MigrationInfo contains metadata about a migration for inspection
 type MigrationInfo struct {
+	Version     int    `json:"version"`
 	Name        string `json:"name"`
 	Description string `json:"description"`
 }
 
+// LatestSchemaVersion returns the schema version this binary migrates databases to.
+func LatestSchemaVersion() int {
+	return len(migrationsList)
+}
+
 // ListMigrations returns list of all registered migrations with descriptions
-// Note: This returns ALL registered migrations, not just pending ones (all are idempotent)
+// Note: This returns ALL registered migrations, not just pending ones
 func ListMigrations() []MigrationInfo {
 	result := make([]MigrationInfo, len(migrationsList))
 	for i, m := range migrationsList {
 		result[i] = MigrationInfo{
+			Version:     i + 1,
 			Name:        m.Name,
 			Description: getMigrationDescription(m.Name),
 		}
@@ -77,6 +113,26 @@ func getMigrationDescription(name string) string {
 		"orphan_detection":             "Detects orphaned child issues and logs them for user action (bd-3852)",
 		"close_reason_column":          "Adds close_reason column to issues table for storing closure explanations (bd-uyu)",
 		"tombstone_columns":            "Adds tombstone columns (deleted_at, deleted_by, delete_reason, original_type) for inline soft-delete (bd-vw8)",
+		"issue_claims_table":           "Adds issue_claims table for cooperative per-issue claims between agents",
+		"issue_links_table":            "Adds issue_links table for external URLs attached to issues",
+		"draft_column":                 "Adds draft column to issues table for local-only issues excluded from export",
+		"sequences_table":              "Adds sequences table for named monotonic counters",
+		"recurrence_tables":            "Adds recurrences and recurrence_instances tables for recurring issues",
+		"backfill_updated_at":          "Backfills missing or zero updated_at from the latest event or created_at",
+		"dependency_note_column":       "Adds note column to dependencies table for recording why an edge exists",
+		"issue_aliases_table":          "Adds issue_aliases table so old IDs of renamed issues still resolve",
+		"percent_complete_column":      "Adds percent_complete column to issues table for partial progress",
+		"external_blocked_reason_column": "Adds external_blocked_reason column to issues table for blockers outside beads",
+		"idempotency_keys_table":         "Adds idempotency_keys table so retried creates return the issue already created",
+		"snoozed_until_column":           "Adds snoozed_until column to issues table for the wake date of snoozed issues",
+		"issue_acl_table":                "Adds issue_acl table listing the owners of private issues",
+		"issue_sections_table":           "Adds issue_sections table for structured issue descriptions",
+		"issues_fts":                     "Adds issues_fts full-text index over titles, descriptions and comments for ranked search",
+		"issue_version_column":           "Adds version column to issues table for optimistic concurrency on updates",
+		"issue_watchers_table":           "Adds issue_watchers table recording who follows each issue",
+		"due_date_column":                "Adds due_date column to issues table for scheduling and overdue queries",
+		"issues_fts_go_sync":             "Drops the issues_fts triggers and reindexes compressed descriptions as plain text",
+		"issue_version_user_columns":     "Limits the issue version bump to updates of user-editable columns",
 	}
 	
 	if desc, ok := descriptions[name]; ok {
@@ -85,15 +141,45 @@ func getMigrationDescription(name string) string {
 	return "Unknown migration"
 }
 
-// RunMigrations executes all registered migrations in order with invariant checking
+// RunMigrations applies all pending migrations in order with invariant checking.
+//
+// Applied migrations are recorded in the schema_migrations table, so each one
+// runs once per database. Each migration runs in its own IMMEDIATE transaction
+// together with its schema_migrations row, which also serializes concurrent
+// processes opening the same database. Databases created before versioning
+// existed start at version 0; since every legacy migration is idempotent they
+// are simply re-applied and recorded.
+//
+// Returns ErrSchemaTooNew if the database was migrated by a newer bd.
 func RunMigrations(db *sql.DB) error {
+	return runMigrations(db, false)
+}
+
+// runMigrations applies pending migrations. If force is true, every migration
+// is re-run regardless of recorded version (used to repair a database whose
+// schema probe failed despite being marked up to date).
+func runMigrations(db *sql.DB, force bool) error {
+	if err := ensureMigrationsTable(db); err != nil {
+		return err
+	}
+	version, err := schemaVersion(db)
+	if err != nil {
+		return err
+	}
+	if err := checkVersionSupported(version); err != nil {
+		return err
+	}
+	if version == len(migrationsList) && !force {
+		return nil
+	}
+
 	snapshot, err := captureSnapshot(db)
 	if err != nil {
 		return fmt.Errorf("failed to capture pre-migration snapshot: %w", err)
 	}
 
-	for _, migration := range migrationsList {
-		if err := migration.Func(db); err != nil {
+	for i, migration := range migrationsList {
+		if err := applyMigration(db, i+1, migration, force); err != nil {
 			return fmt.Errorf("migration %s failed: %w", migration.Name, err)
 		}
 	}
@@ -104,3 +190,138 @@ func RunMigrations(db *sql.DB) error {
 
 	return nil
 }
+
+// ensureMigrationsTable creates the schema_migrations table if needed
+func ensureMigrationsTable(db *sql.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY,
+			name TEXT NOT NULL,
+			applied_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+	return nil
+}
+
+// schemaVersion returns the highest applied migration version (0 if none).
+// A missing schema_migrations table also means version 0.
+func schemaVersion(db *sql.DB) (int, error) {
+	var exists bool
+	err := db.QueryRow(`
+		SELECT COUNT(*) > 0 FROM sqlite_master
+		WHERE type = 'table' AND name = 'schema_migrations'
+	`).Scan(&exists)
+	if err != nil {
+		return 0, fmt.Errorf("failed to check schema_migrations table: %w", err)
+	}
+	if !exists {
+		return 0, nil
+	}
+
+	var version int
+	if err := db.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&version); err != nil {
+		return 0, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	return version, nil
+}
+
+// checkSchemaVersionSupported returns ErrSchemaTooNew if the database was
+// migrated past what this binary understands. It must run before anything
+// else touches the schema.
+func checkSchemaVersionSupported(db *sql.DB) error {
+	version, err := schemaVersion(db)
+	if err != nil {
+		return err
+	}
+	return checkVersionSupported(version)
+}
+
+// checkVersionSupported returns ErrSchemaTooNew if version is past LatestSchemaVersion
+func checkVersionSupported(version int) error {
+	if version > len(migrationsList) {
+		return fmt.Errorf("%w: database is at version %d, this bd supports up to %d (upgrade bd)",
+			ErrSchemaTooNew, version, len(migrationsList))
+	}
+	return nil
+}
+
+// applyMigration runs a single migration and records its version in one
+// IMMEDIATE transaction. The applied check is repeated after acquiring the
+// write lock so a concurrent process that got there first is not re-applied.
+func applyMigration(db *sql.DB, version int, migration Migration, force bool) error {
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	if err := beginImmediateWithRetry(ctx, conn, 5, 10*time.Millisecond); err != nil {
+		return fmt.Errorf("failed to begin migration transaction: %w", err)
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			_, _ = conn.ExecContext(context.Background(), "ROLLBACK")
+		}
+	}()
+
+	var applied bool
+	err = conn.QueryRowContext(ctx, `SELECT COUNT(*) > 0 FROM schema_migrations WHERE version = ?`, version).Scan(&applied)
+	if err != nil {
+		return fmt.Errorf("failed to check migration version: %w", err)
+	}
+	if applied && !force {
+		return nil
+	}
+
+	if err := migration.Func(migrationConn{ctx: ctx, conn: conn}); err != nil {
+		return err
+	}
+
+	_, err = conn.ExecContext(ctx, `
+		INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)
+		ON CONFLICT (version) DO NOTHING
+	`, version, migration.Name, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to record migration version: %w", err)
+	}
+
+	if _, err := conn.ExecContext(ctx, "COMMIT"); err != nil {
+		return fmt.Errorf("failed to commit migration: %w", err)
+	}
+	committed = true
+	return nil
+}
+
+// migrationConn adapts a connection holding an open transaction to migrations.DB
+type migrationConn struct {
+	ctx  context.Context
+	conn *sql.Conn
+}
+
+func (m migrationConn) Exec(query string, args ...any) (sql.Result, error) {
+	return m.conn.ExecContext(m.ctx, query, args...)
+}
+
+func (m migrationConn) Query(query string, args ...any) (*sql.Rows, error) {
+	return m.conn.QueryContext(m.ctx, query, args...)
+}
+
+func (m migrationConn) QueryRow(query string, args ...any) *sql.Row {
+	return m.conn.QueryRowContext(m.ctx, query, args...)
+}
+
+// SchemaVersion returns the database's current schema version: the number of
+// the last migration applied. Compare with LatestSchemaVersion().
+func (s *SQLiteStorage) SchemaVersion(ctx context.Context) (int, error) {
+	var version int
+	err := s.db().QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&version)
+	if err != nil {
+		return 0, wrapDBError("get schema version", err)
+	}
+	return version, nil
+}
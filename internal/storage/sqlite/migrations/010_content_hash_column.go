@@ -7,7 +7,7 @@ import (
 	"github.com/steveyegge/beads/internal/types"
 )
 
-func MigrateContentHashColumn(db *sql.DB) error {
+func MigrateContentHashColumn(db DB) error {
 	var colName string
 	err := db.QueryRow(`
 		SELECT name FROM pragma_table_info('issues')
@@ -61,28 +61,12 @@ func MigrateContentHashColumn(db *sql.DB) error {
 			return fmt.Errorf("error iterating issues: %w", err)
 		}
 
-		tx, err := db.Begin()
-		if err != nil {
-			return fmt.Errorf("failed to begin transaction: %w", err)
-		}
-		defer tx.Rollback()
-
-		stmt, err := tx.Prepare(`UPDATE issues SET content_hash = ? WHERE id = ?`)
-		if err != nil {
-			return fmt.Errorf("failed to prepare update statement: %w", err)
-		}
-		defer stmt.Close()
-
 		for id, hash := range updates {
-			if _, err := stmt.Exec(hash, id); err != nil {
+			if _, err := db.Exec(`UPDATE issues SET content_hash = ? WHERE id = ?`, hash, id); err != nil {
 				return fmt.Errorf("failed to update content_hash for issue %s: %w", id, err)
 			}
 		}
 
-		if err := tx.Commit(); err != nil {
-			return fmt.Errorf("failed to commit transaction: %w", err)
-		}
-
 		return nil
 	}
 
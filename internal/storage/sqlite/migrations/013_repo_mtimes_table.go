@@ -5,7 +5,7 @@ import (
 	"fmt"
 )
 
-func MigrateRepoMtimesTable(db *sql.DB) error {
+func MigrateRepoMtimesTable(db DB) error {
 	var tableName string
 	err := db.QueryRow(`
 		SELECT name FROM sqlite_master
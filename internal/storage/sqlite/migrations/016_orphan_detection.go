@@ -1,7 +1,6 @@
 package migrations
 
 import (
-	"database/sql"
 	"fmt"
 	"log"
 )
@@ -15,7 +14,7 @@ import (
 // - Delete the orphans if they're no longer needed
 // - Convert them to top-level issues by renaming them
 // - Restore the missing parent issues
-func MigrateOrphanDetection(db *sql.DB) error {
+func MigrateOrphanDetection(db DB) error {
 	// Query for orphaned children using the pattern from the issue description:
 	// SELECT id FROM issues WHERE id LIKE '%.%'
 	// AND substr(id, 1, instr(id || '.', '.') - 1) NOT IN (SELECT id FROM issues)
@@ -0,0 +1,23 @@
+package migrations
+
+import (
+	"fmt"
+)
+
+// MigrateIssueClaimsTable adds the issue_claims table used for cooperative
+// per-issue claims between agents (see ClaimIssue).
+func MigrateIssueClaimsTable(db DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS issue_claims (
+			issue_id TEXT PRIMARY KEY,
+			agent TEXT NOT NULL,
+			claimed_at DATETIME NOT NULL,
+			expires_at DATETIME NOT NULL,
+			FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create issue_claims table: %w", err)
+	}
+	return nil
+}
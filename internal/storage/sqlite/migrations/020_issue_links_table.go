@@ -0,0 +1,26 @@
+package migrations
+
+import (
+	"fmt"
+)
+
+// MigrateIssueLinksTable adds the issue_links table holding external URLs
+// (docs, PRs, runbooks) attached to issues (see AddLink).
+func MigrateIssueLinksTable(db DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS issue_links (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			issue_id TEXT NOT NULL,
+			url TEXT NOT NULL,
+			title TEXT NOT NULL DEFAULT '',
+			kind TEXT NOT NULL,
+			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE (issue_id, url),
+			FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create issue_links table: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"fmt"
+)
+
+// MigrateDraftColumn adds the draft column to the issues table. Draft issues
+// are local scratchpad issues that are never exported to JSONL.
+func MigrateDraftColumn(db DB) error {
+	var columnExists bool
+	err := db.QueryRow(`
+		SELECT COUNT(*) > 0
+		FROM pragma_table_info('issues')
+		WHERE name = 'draft'
+	`).Scan(&columnExists)
+	if err != nil {
+		return fmt.Errorf("failed to check draft column: %w", err)
+	}
+
+	if columnExists {
+		return nil
+	}
+
+	_, err = db.Exec(`ALTER TABLE issues ADD COLUMN draft INTEGER NOT NULL DEFAULT 0`)
+	if err != nil {
+		return fmt.Errorf("failed to add draft column: %w", err)
+	}
+
+	return nil
+}
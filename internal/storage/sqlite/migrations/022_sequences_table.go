@@ -0,0 +1,20 @@
+package migrations
+
+import (
+	"fmt"
+)
+
+// MigrateSequencesTable adds the sequences table backing named monotonic
+// counters (see NextSeq).
+func MigrateSequencesTable(db DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS sequences (
+			name TEXT PRIMARY KEY,
+			value INTEGER NOT NULL DEFAULT 0
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create sequences table: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package migrations
+
+import (
+	"fmt"
+)
+
+// MigrateRecurrenceTables adds the recurrences table holding the schedule of
+// recurring issues and recurrence_instances linking each generated instance
+// to its template (see GenerateRecurring).
+func MigrateRecurrenceTables(db DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS recurrences (
+			issue_id TEXT PRIMARY KEY,
+			freq TEXT NOT NULL,
+			interval INTEGER NOT NULL DEFAULT 1,
+			next_due TEXT NOT NULL,
+			FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+		);
+
+		CREATE TABLE IF NOT EXISTS recurrence_instances (
+			issue_id TEXT PRIMARY KEY,
+			template_id TEXT NOT NULL,
+			due TEXT NOT NULL,
+			UNIQUE (template_id, due),
+			FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE,
+			FOREIGN KEY (template_id) REFERENCES issues(id) ON DELETE CASCADE
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create recurrence tables: %w", err)
+	}
+	return nil
+}
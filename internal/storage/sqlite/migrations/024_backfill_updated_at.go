@@ -0,0 +1,38 @@
+package migrations
+
+import (
+	"fmt"
+	"log"
+)
+
+// MigrateBackfillUpdatedAt fills in updated_at on issues from older databases
+// where it is missing or the zero time, which breaks staleness filters and
+// ordering by update time. The latest event recorded for the issue is used
+// when there is one, otherwise created_at. Rows whose created_at is missing
+// too are left alone.
+func MigrateBackfillUpdatedAt(db DB) error {
+	result, err := db.Exec(`
+		UPDATE issues
+		SET updated_at = COALESCE(
+			(SELECT e.created_at FROM events e
+			 WHERE e.issue_id = issues.id
+			 ORDER BY julianday(e.created_at) DESC LIMIT 1),
+			created_at)
+		WHERE (updated_at IS NULL OR updated_at = '' OR updated_at = 0
+		       OR CAST(updated_at AS TEXT) LIKE '0001-01-01%')
+		  AND created_at IS NOT NULL AND created_at != ''
+		  AND CAST(created_at AS TEXT) NOT LIKE '0001-01-01%'
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to backfill updated_at: %w", err)
+	}
+
+	backfilled, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to count backfilled rows: %w", err)
+	}
+	if backfilled > 0 {
+		log.Printf("Backfilled missing updated_at on %d issue(s) from their latest event or creation time", backfilled)
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"fmt"
+)
+
+// MigrateDependencyNoteColumn adds the note column to the dependencies table
+// for recording why an edge exists
+func MigrateDependencyNoteColumn(db DB) error {
+	var columnExists bool
+	err := db.QueryRow(`
+		SELECT COUNT(*) > 0
+		FROM pragma_table_info('dependencies')
+		WHERE name = 'note'
+	`).Scan(&columnExists)
+	if err != nil {
+		return fmt.Errorf("failed to check note column: %w", err)
+	}
+
+	if columnExists {
+		return nil
+	}
+
+	_, err = db.Exec(`ALTER TABLE dependencies ADD COLUMN note TEXT NOT NULL DEFAULT ''`)
+	if err != nil {
+		return fmt.Errorf("failed to add note column: %w", err)
+	}
+
+	return nil
+}
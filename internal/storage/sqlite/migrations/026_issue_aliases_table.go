@@ -0,0 +1,22 @@
+package migrations
+
+import (
+	"fmt"
+)
+
+// MigrateIssueAliasesTable adds the issue_aliases table mapping the old IDs
+// of renamed issues to their current ID (see RenameIssue).
+func MigrateIssueAliasesTable(db DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS issue_aliases (
+			alias TEXT PRIMARY KEY,
+			issue_id TEXT NOT NULL,
+			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE INDEX IF NOT EXISTS idx_issue_aliases_issue ON issue_aliases(issue_id);
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create issue_aliases table: %w", err)
+	}
+	return nil
+}
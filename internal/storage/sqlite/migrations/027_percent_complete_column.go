@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"fmt"
+)
+
+// MigratePercentCompleteColumn adds the percent_complete column to the issues
+// table for tracking partial progress beyond open/closed.
+func MigratePercentCompleteColumn(db DB) error {
+	var columnExists bool
+	err := db.QueryRow(`
+		SELECT COUNT(*) > 0
+		FROM pragma_table_info('issues')
+		WHERE name = 'percent_complete'
+	`).Scan(&columnExists)
+	if err != nil {
+		return fmt.Errorf("failed to check percent_complete column: %w", err)
+	}
+
+	if columnExists {
+		return nil
+	}
+
+	_, err = db.Exec(`ALTER TABLE issues ADD COLUMN percent_complete INTEGER NOT NULL DEFAULT 0`)
+	if err != nil {
+		return fmt.Errorf("failed to add percent_complete column: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"fmt"
+)
+
+// MigrateExternalBlockedReasonColumn adds the external_blocked_reason column
+// to the issues table for blockers that aren't beads issues.
+func MigrateExternalBlockedReasonColumn(db DB) error {
+	var columnExists bool
+	err := db.QueryRow(`
+		SELECT COUNT(*) > 0
+		FROM pragma_table_info('issues')
+		WHERE name = 'external_blocked_reason'
+	`).Scan(&columnExists)
+	if err != nil {
+		return fmt.Errorf("failed to check external_blocked_reason column: %w", err)
+	}
+
+	if columnExists {
+		return nil
+	}
+
+	_, err = db.Exec(`ALTER TABLE issues ADD COLUMN external_blocked_reason TEXT`)
+	if err != nil {
+		return fmt.Errorf("failed to add external_blocked_reason column: %w", err)
+	}
+
+	return nil
+}
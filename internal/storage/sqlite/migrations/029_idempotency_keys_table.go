@@ -0,0 +1,23 @@
+package migrations
+
+import (
+	"fmt"
+)
+
+// MigrateIdempotencyKeysTable adds the idempotency_keys table recording the
+// issue each recent idempotency key created (see WithIdempotencyKey).
+func MigrateIdempotencyKeysTable(db DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS idempotency_keys (
+			key TEXT PRIMARY KEY,
+			issue_id TEXT NOT NULL,
+			created_at DATETIME NOT NULL,
+			FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+		);
+		CREATE INDEX IF NOT EXISTS idx_idempotency_keys_created_at ON idempotency_keys(created_at);
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create idempotency_keys table: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"fmt"
+)
+
+// MigrateSnoozedUntilColumn adds the snoozed_until column to the issues
+// table, holding the wake date of snoozed issues.
+func MigrateSnoozedUntilColumn(db DB) error {
+	var columnExists bool
+	err := db.QueryRow(`
+		SELECT COUNT(*) > 0
+		FROM pragma_table_info('issues')
+		WHERE name = 'snoozed_until'
+	`).Scan(&columnExists)
+	if err != nil {
+		return fmt.Errorf("failed to check snoozed_until column: %w", err)
+	}
+
+	if columnExists {
+		return nil
+	}
+
+	_, err = db.Exec(`ALTER TABLE issues ADD COLUMN snoozed_until DATETIME`)
+	if err != nil {
+		return fmt.Errorf("failed to add snoozed_until column: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,22 @@
+package migrations
+
+import (
+	"fmt"
+)
+
+// MigrateIssueACLTable adds the issue_acl table listing the owners of private
+// issues (see SetVisibility).
+func MigrateIssueACLTable(db DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS issue_acl (
+			issue_id TEXT NOT NULL,
+			actor TEXT NOT NULL,
+			PRIMARY KEY (issue_id, actor),
+			FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create issue_acl table: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package migrations
+
+import (
+	"fmt"
+)
+
+// MigrateIssueSectionsTable adds the issue_sections table holding the
+// structured descriptions of issues as JSON objects (see SetSection).
+func MigrateIssueSectionsTable(db DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS issue_sections (
+			issue_id TEXT PRIMARY KEY,
+			sections TEXT NOT NULL,
+			FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create issue_sections table: %w", err)
+	}
+	return nil
+}
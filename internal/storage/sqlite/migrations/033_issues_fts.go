@@ -0,0 +1,77 @@
+package migrations
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/compression"
+)
+
+// MigrateIssuesFTS adds the issues_fts FTS5 index over issue titles,
+// descriptions and comments that SearchIssues ranks matches with. Writes
+// keep it in step from Go, inside the writing transaction, so compressed
+// descriptions are indexed as plain text. SQLite builds without FTS5 skip the
+// index and SearchIssues falls back to LIKE. The index is rebuilt from
+// scratch, so re-running the migration repairs it.
+func MigrateIssuesFTS(db DB) error {
+	_, err := db.Exec(`
+		CREATE VIRTUAL TABLE IF NOT EXISTS issues_fts USING fts5(
+			issue_id UNINDEXED,
+			title,
+			description,
+			comments
+		)
+	`)
+	if err != nil {
+		if strings.Contains(err.Error(), "no such module") {
+			return nil
+		}
+		return fmt.Errorf("failed to create issues_fts table: %w", err)
+	}
+	return populateIssuesFTS(db)
+}
+
+// populateIssuesFTS rebuilds issues_fts from every issue, decompressing
+// compressed descriptions
+func populateIssuesFTS(db DB) error {
+	if _, err := db.Exec(`DELETE FROM issues_fts`); err != nil {
+		return fmt.Errorf("failed to clear issues_fts: %w", err)
+	}
+
+	rows, err := db.Query(`
+		SELECT id, title, description,
+		       COALESCE((SELECT group_concat(text, char(10)) FROM comments WHERE issue_id = issues.id), '')
+		FROM issues
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to read issues for issues_fts: %w", err)
+	}
+	type entry struct{ id, title, description, comments string }
+	var entries []entry
+	for rows.Next() {
+		var e entry
+		if err := rows.Scan(&e.id, &e.title, &e.description, &e.comments); err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("failed to scan issue for issues_fts: %w", err)
+		}
+		if e.description, err = compression.Decompress(e.description); err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("failed to decode description of %s: %w", e.id, err)
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		_ = rows.Close()
+		return fmt.Errorf("failed to read issues for issues_fts: %w", err)
+	}
+	_ = rows.Close()
+
+	for _, e := range entries {
+		_, err := db.Exec(`INSERT INTO issues_fts (issue_id, title, description, comments) VALUES (?, ?, ?, ?)`,
+			e.id, e.title, e.description, e.comments)
+		if err != nil {
+			return fmt.Errorf("failed to populate issues_fts: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package migrations
+
+import (
+	"fmt"
+)
+
+// MigrateIssueVersionColumn adds the version column to the issues table, the
+// per-issue counter optimistic updates compare against (see
+// WithExpectedVersion), and the trigger that bumps it on every update of an
+// issue row, whichever code path makes it. Updates that set version
+// themselves are left alone.
+func MigrateIssueVersionColumn(db DB) error {
+	var columnExists bool
+	err := db.QueryRow(`
+		SELECT COUNT(*) > 0
+		FROM pragma_table_info('issues')
+		WHERE name = 'version'
+	`).Scan(&columnExists)
+	if err != nil {
+		return fmt.Errorf("failed to check version column: %w", err)
+	}
+
+	if !columnExists {
+		_, err = db.Exec(`ALTER TABLE issues ADD COLUMN version INTEGER NOT NULL DEFAULT 1`)
+		if err != nil {
+			return fmt.Errorf("failed to add version column: %w", err)
+		}
+	}
+
+	_, err = db.Exec(`
+		CREATE TRIGGER IF NOT EXISTS issues_version_bump AFTER UPDATE ON issues
+		WHEN new.version = old.version
+		BEGIN
+			UPDATE issues SET version = old.version + 1 WHERE id = new.id;
+		END
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create version trigger: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package migrations
+
+import (
+	"fmt"
+)
+
+// MigrateIssueWatchersTable adds the issue_watchers table recording who
+// follows each issue (see WatchIssue).
+func MigrateIssueWatchersTable(db DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS issue_watchers (
+			issue_id TEXT NOT NULL,
+			watcher TEXT NOT NULL,
+			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (issue_id, watcher),
+			FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create issue_watchers table: %w", err)
+	}
+	_, err = db.Exec(`CREATE INDEX IF NOT EXISTS idx_issue_watchers_watcher ON issue_watchers(watcher)`)
+	if err != nil {
+		return fmt.Errorf("failed to create issue_watchers index: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package migrations
+
+import (
+	"fmt"
+)
+
+// MigrateDueDateColumn adds the due_date column to the issues table, and an
+// index on it for overdue queries.
+func MigrateDueDateColumn(db DB) error {
+	var columnExists bool
+	err := db.QueryRow(`
+		SELECT COUNT(*) > 0
+		FROM pragma_table_info('issues')
+		WHERE name = 'due_date'
+	`).Scan(&columnExists)
+	if err != nil {
+		return fmt.Errorf("failed to check due_date column: %w", err)
+	}
+
+	if !columnExists {
+		if _, err := db.Exec(`ALTER TABLE issues ADD COLUMN due_date DATETIME`); err != nil {
+			return fmt.Errorf("failed to add due_date column: %w", err)
+		}
+	}
+
+	if _, err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_issues_due_date ON issues(due_date)`); err != nil {
+		return fmt.Errorf("failed to create due_date index: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package migrations
+
+import (
+	"fmt"
+)
+
+// issuesFTSTriggers are the triggers an earlier issues_fts migration kept the
+// index in step with. They indexed compressed descriptions as empty text.
+var issuesFTSTriggers = []string{
+	"issues_fts_insert",
+	"issues_fts_update",
+	"issues_fts_delete",
+	"issues_fts_comment_insert",
+	"issues_fts_comment_update",
+	"issues_fts_comment_delete",
+}
+
+// MigrateIssuesFTSGoSync drops the issues_fts triggers, now that writes keep
+// the index in step from Go, and rebuilds the index so compressed
+// descriptions are searchable.
+func MigrateIssuesFTSGoSync(db DB) error {
+	for _, trigger := range issuesFTSTriggers {
+		if _, err := db.Exec(`DROP TRIGGER IF EXISTS ` + trigger); err != nil {
+			return fmt.Errorf("failed to drop %s trigger: %w", trigger, err)
+		}
+	}
+
+	var exists bool
+	err := db.QueryRow(`SELECT COUNT(*) > 0 FROM sqlite_master WHERE type = 'table' AND name = 'issues_fts'`).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("failed to check issues_fts table: %w", err)
+	}
+	if !exists {
+		return nil
+	}
+	return populateIssuesFTS(db)
+}
@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"fmt"
+	"strings"
+)
+
+// issueVersionColumns are the issue columns users edit. Only updates of these
+// bump the version: bookkeeping writes such as content hashes, updated_at
+// touches and compaction metadata don't change what an optimistic update
+// would overwrite, so they shouldn't make it stale.
+var issueVersionColumns = []string{
+	"title", "description", "design", "acceptance_criteria", "notes",
+	"status", "priority", "issue_type", "assignee", "estimated_minutes",
+	"closed_at", "close_reason", "external_ref", "draft", "percent_complete",
+	"external_blocked_reason", "snoozed_until", "due_date",
+}
+
+// MigrateIssueVersionUserColumns recreates the issues_version_bump trigger
+// to fire only on updates of issueVersionColumns, where the issue_version_column
+// migration made it fire on every update of an issue row.
+func MigrateIssueVersionUserColumns(db DB) error {
+	if _, err := db.Exec(`DROP TRIGGER IF EXISTS issues_version_bump`); err != nil {
+		return fmt.Errorf("failed to drop version trigger: %w", err)
+	}
+
+	// #nosec G202 - column names are constants
+	_, err := db.Exec(`
+		CREATE TRIGGER issues_version_bump AFTER UPDATE OF ` + strings.Join(issueVersionColumns, ", ") + ` ON issues
+		WHEN new.version = old.version
+		BEGIN
+			UPDATE issues SET version = old.version + 1 WHERE id = new.id;
+		END
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create version trigger: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,12 @@
+package migrations
+
+import "database/sql"
+
+// DB is the subset of *sql.DB and *sql.Tx that migrations use.
+// RunMigrations passes a transaction so each migration and the record of
+// its version are applied atomically; tests may pass a *sql.DB directly.
+type DB interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	Query(query string, args ...any) (*sql.Rows, error)
+	QueryRow(query string, args ...any) *sql.Row
+}
@@ -3,6 +3,8 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -14,7 +16,7 @@ func TestMigrateDirtyIssuesTable(t *testing.T) {
 	t.Run("creates dirty_issues table if not exists", func(t *testing.T) {
 		store, cleanup := setupTestDB(t)
 		defer cleanup()
-		db := store.db
+		db := store.db()
 
 		// Drop table if exists
 		_, _ = db.Exec("DROP TABLE IF EXISTS dirty_issues")
@@ -38,7 +40,7 @@ func TestMigrateDirtyIssuesTable(t *testing.T) {
 	t.Run("adds content_hash column to existing table", func(t *testing.T) {
 		store, cleanup := setupTestDB(t)
 		defer cleanup()
-		db := store.db
+		db := store.db()
 
 		// Drop and create table without content_hash
 		_, _ = db.Exec("DROP TABLE IF EXISTS dirty_issues")
@@ -75,7 +77,7 @@ func TestMigrateDirtyIssuesTable(t *testing.T) {
 func TestMigrateExternalRefColumn(t *testing.T) {
 	store, cleanup := setupTestDB(t)
 	defer cleanup()
-	db := store.db
+	db := store.db()
 
 	// Run migration
 	if err := migrations.MigrateExternalRefColumn(db); err != nil {
@@ -112,7 +114,7 @@ func TestMigrateExternalRefColumn(t *testing.T) {
 func TestMigrateCompositeIndexes(t *testing.T) {
 	store, cleanup := setupTestDB(t)
 	defer cleanup()
-	db := store.db
+	db := store.db()
 
 	// Drop index if exists
 	_, _ = db.Exec("DROP INDEX IF EXISTS idx_dependencies_depends_on_type")
@@ -148,7 +150,7 @@ func TestMigrateClosedAtConstraint(t *testing.T) {
 	}
 
 	// Run migration (should succeed with no inconsistent data)
-	if err := migrations.MigrateClosedAtConstraint(s.db); err != nil {
+	if err := migrations.MigrateClosedAtConstraint(s.db()); err != nil {
 		t.Fatalf("failed to migrate closed_at constraint: %v", err)
 	}
 
@@ -167,17 +169,17 @@ func TestMigrateCompactionColumns(t *testing.T) {
 	defer cleanup()
 
 	// Remove compaction columns if they exist
-	_, _ = s.db.Exec(`ALTER TABLE issues DROP COLUMN compaction_level`)
-	_, _ = s.db.Exec(`ALTER TABLE issues DROP COLUMN compacted_at`)
-	_, _ = s.db.Exec(`ALTER TABLE issues DROP COLUMN original_size`)
+	_, _ = s.db().Exec(`ALTER TABLE issues DROP COLUMN compaction_level`)
+	_, _ = s.db().Exec(`ALTER TABLE issues DROP COLUMN compacted_at`)
+	_, _ = s.db().Exec(`ALTER TABLE issues DROP COLUMN original_size`)
 
 	// Run migration (will fail since columns don't exist, but that's okay for this test)
 	// The migration should handle this gracefully
-	_ = migrations.MigrateCompactionColumns(s.db)
+	_ = migrations.MigrateCompactionColumns(s.db())
 
 	// Verify at least one column exists by querying
 	var exists bool
-	err := s.db.QueryRow(`
+	err := s.db().QueryRow(`
 		SELECT COUNT(*) > 0
 		FROM pragma_table_info('issues')
 		WHERE name = 'compaction_level'
@@ -193,7 +195,7 @@ func TestMigrateCompactionColumns(t *testing.T) {
 func TestMigrateSnapshotsTable(t *testing.T) {
 	store, cleanup := setupTestDB(t)
 	defer cleanup()
-	db := store.db
+	db := store.db()
 
 	// Drop table if exists
 	_, _ = db.Exec("DROP TABLE IF EXISTS issue_snapshots")
@@ -221,7 +223,7 @@ func TestMigrateSnapshotsTable(t *testing.T) {
 func TestMigrateCompactionConfig(t *testing.T) {
 	store, cleanup := setupTestDB(t)
 	defer cleanup()
-	db := store.db
+	db := store.db()
 
 	// Clear config table
 	_, _ = db.Exec("DELETE FROM config WHERE key LIKE 'compact%'")
@@ -245,7 +247,7 @@ func TestMigrateCompactionConfig(t *testing.T) {
 func TestMigrateCompactedAtCommitColumn(t *testing.T) {
 	store, cleanup := setupTestDB(t)
 	defer cleanup()
-	db := store.db
+	db := store.db()
 
 	// Run migration
 	if err := migrations.MigrateCompactedAtCommitColumn(db); err != nil {
@@ -270,7 +272,7 @@ func TestMigrateCompactedAtCommitColumn(t *testing.T) {
 func TestMigrateExportHashesTable(t *testing.T) {
 	store, cleanup := setupTestDB(t)
 	defer cleanup()
-	db := store.db
+	db := store.db()
 
 	// Drop table if exists
 	_, _ = db.Exec("DROP TABLE IF EXISTS export_hashes")
@@ -295,7 +297,7 @@ func TestMigrateExternalRefUnique(t *testing.T) {
 	t.Run("creates unique index on external_ref", func(t *testing.T) {
 		store, cleanup := setupTestDB(t)
 		defer cleanup()
-		db := store.db
+		db := store.db()
 
 		externalRef1 := "JIRA-1"
 		externalRef2 := "JIRA-2"
@@ -323,7 +325,7 @@ func TestMigrateExternalRefUnique(t *testing.T) {
 	t.Run("fails if duplicates exist", func(t *testing.T) {
 		store, cleanup := setupTestDB(t)
 		defer cleanup()
-		db := store.db
+		db := store.db()
 
 		_, err := db.Exec(`DROP INDEX IF EXISTS idx_issues_external_ref_unique`)
 		if err != nil {
@@ -355,7 +357,7 @@ func TestMigrateRepoMtimesTable(t *testing.T) {
 	t.Run("creates repo_mtimes table if not exists", func(t *testing.T) {
 		store, cleanup := setupTestDB(t)
 		defer cleanup()
-		db := store.db
+		db := store.db()
 
 		// Drop table if exists
 		_, _ = db.Exec("DROP TABLE IF EXISTS repo_mtimes")
@@ -379,7 +381,7 @@ func TestMigrateRepoMtimesTable(t *testing.T) {
 	t.Run("is idempotent", func(t *testing.T) {
 		store, cleanup := setupTestDB(t)
 		defer cleanup()
-		db := store.db
+		db := store.db()
 
 		// Run migration twice
 		if err := migrations.MigrateRepoMtimesTable(db); err != nil {
@@ -407,13 +409,13 @@ func TestMigrateContentHashColumn(t *testing.T) {
 		defer cleanup()
 
 		// Run migration (should be idempotent)
-		if err := migrations.MigrateContentHashColumn(s.db); err != nil {
+		if err := migrations.MigrateContentHashColumn(s.db()); err != nil {
 			t.Fatalf("failed to migrate content_hash column: %v", err)
 		}
 
 		// Verify column exists
 		var colName string
-		err := s.db.QueryRow(`
+		err := s.db().QueryRow(`
 			SELECT name FROM pragma_table_info('issues')
 			WHERE name = 'content_hash'
 		`).Scan(&colName)
@@ -438,14 +440,14 @@ func TestMigrateContentHashColumn(t *testing.T) {
 		}
 
 		// Clear its content_hash directly in DB
-		_, err = s.db.Exec(`UPDATE issues SET content_hash = NULL WHERE id = ?`, issue.ID)
+		_, err = s.db().Exec(`UPDATE issues SET content_hash = NULL WHERE id = ?`, issue.ID)
 		if err != nil {
 			t.Fatalf("failed to clear content_hash: %v", err)
 		}
 
 		// Verify it's cleared
 		var hash sql.NullString
-		err = s.db.QueryRow(`SELECT content_hash FROM issues WHERE id = ?`, issue.ID).Scan(&hash)
+		err = s.db().QueryRow(`SELECT content_hash FROM issues WHERE id = ?`, issue.ID).Scan(&hash)
 		if err != nil {
 			t.Fatalf("failed to verify cleared hash: %v", err)
 		}
@@ -454,7 +456,7 @@ func TestMigrateContentHashColumn(t *testing.T) {
 		}
 
 		// Drop the column to simulate fresh migration
-		_, err = s.db.Exec(`
+		_, err = s.db().Exec(`
 			CREATE TABLE issues_backup AS SELECT * FROM issues;
 			DROP TABLE issues;
 			CREATE TABLE issues (
@@ -483,9 +485,15 @@ func TestMigrateContentHashColumn(t *testing.T) {
 				deleted_by TEXT DEFAULT '',
 				delete_reason TEXT DEFAULT '',
 				original_type TEXT DEFAULT '',
+				draft INTEGER NOT NULL DEFAULT 0,
+				percent_complete INTEGER NOT NULL DEFAULT 0,
+				external_blocked_reason TEXT,
+				snoozed_until DATETIME,
+				version INTEGER NOT NULL DEFAULT 1,
+				due_date DATETIME,
 				CHECK ((status = 'closed') = (closed_at IS NOT NULL))
 			);
-			INSERT INTO issues SELECT id, title, description, design, acceptance_criteria, notes, status, priority, issue_type, assignee, estimated_minutes, created_at, updated_at, closed_at, external_ref, compaction_level, compacted_at, original_size, compacted_at_commit, source_repo, '', NULL, '', '', '' FROM issues_backup;
+			INSERT INTO issues SELECT id, title, description, design, acceptance_criteria, notes, status, priority, issue_type, assignee, estimated_minutes, created_at, updated_at, closed_at, external_ref, compaction_level, compacted_at, original_size, compacted_at_commit, source_repo, '', NULL, '', '', '', 0, 0, NULL, NULL, 1, NULL FROM issues_backup;
 			DROP TABLE issues_backup;
 		`)
 		if err != nil {
@@ -493,7 +501,7 @@ func TestMigrateContentHashColumn(t *testing.T) {
 		}
 
 		// Run migration - this should add the column and populate it
-		if err := migrations.MigrateContentHashColumn(s.db); err != nil {
+		if err := migrations.MigrateContentHashColumn(s.db()); err != nil {
 			t.Fatalf("failed to migrate content_hash column: %v", err)
 		}
 
@@ -512,7 +520,7 @@ func TestMigrateOrphanDetection(t *testing.T) {
 	t.Run("detects orphaned child issues", func(t *testing.T) {
 		store, cleanup := setupTestDB(t)
 		defer cleanup()
-		db := store.db
+		db := store.db()
 		ctx := context.Background()
 
 		// Create a parent issue
@@ -576,7 +584,7 @@ func TestMigrateOrphanDetection(t *testing.T) {
 	t.Run("no orphans found in clean database", func(t *testing.T) {
 		store, cleanup := setupTestDB(t)
 		defer cleanup()
-		db := store.db
+		db := store.db()
 		ctx := context.Background()
 
 		// Create a parent with valid children
@@ -611,7 +619,7 @@ func TestMigrateOrphanDetection(t *testing.T) {
 	t.Run("is idempotent", func(t *testing.T) {
 		store, cleanup := setupTestDB(t)
 		defer cleanup()
-		db := store.db
+		db := store.db()
 
 		// Run migration multiple times
 		for i := 0; i < 3; i++ {
@@ -621,3 +629,168 @@ func TestMigrateOrphanDetection(t *testing.T) {
 		}
 	})
 }
+
+func TestMigrateBackfillUpdatedAt(t *testing.T) {
+	s, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	noEvents := &types.Issue{Title: "no events", Priority: 1, IssueType: "task", Status: "open"}
+	withEvents := &types.Issue{Title: "with events", Priority: 1, IssueType: "task", Status: "open"}
+	for _, issue := range []*types.Issue{noEvents, withEvents} {
+		if err := s.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("failed to create issue: %v", err)
+		}
+	}
+
+	// Simulate legacy rows with empty and zero updated_at
+	if _, err := s.db().Exec(`DELETE FROM events WHERE issue_id = ?`, noEvents.ID); err != nil {
+		t.Fatalf("failed to delete events: %v", err)
+	}
+	if _, err := s.db().Exec(`UPDATE issues SET updated_at = '' WHERE id = ?`, noEvents.ID); err != nil {
+		t.Fatalf("failed to clear updated_at: %v", err)
+	}
+	if _, err := s.db().Exec(`UPDATE issues SET updated_at = '0001-01-01T00:00:00Z' WHERE id = ?`, withEvents.ID); err != nil {
+		t.Fatalf("failed to zero updated_at: %v", err)
+	}
+
+	if err := migrations.MigrateBackfillUpdatedAt(s.db()); err != nil {
+		t.Fatalf("failed to backfill updated_at: %v", err)
+	}
+
+	got, err := s.GetIssue(ctx, noEvents.ID)
+	if err != nil {
+		t.Fatalf("failed to get issue: %v", err)
+	}
+	if !got.UpdatedAt.Equal(got.CreatedAt) {
+		t.Errorf("Expected updated_at to fall back to created_at %v, got %v", got.CreatedAt, got.UpdatedAt)
+	}
+
+	got, err = s.GetIssue(ctx, withEvents.ID)
+	if err != nil {
+		t.Fatalf("failed to get issue: %v", err)
+	}
+	if got.UpdatedAt.Year() < 2000 {
+		t.Errorf("Expected updated_at from the latest event, got %v", got.UpdatedAt)
+	}
+
+	// Ordering by updated_at treats any remaining empty values like created_at
+	issues, err := s.SearchIssues(ctx, "", types.IssueFilter{SortBy: []types.SortKey{{Field: types.SortByUpdatedAt}}})
+	if err != nil {
+		t.Fatalf("failed to search: %v", err)
+	}
+	if len(issues) != 2 {
+		t.Errorf("Expected 2 issues, got %d", len(issues))
+	}
+}
+
+func TestSchemaVersioning(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("new database is at latest version", func(t *testing.T) {
+		store := newTestStore(t, "")
+
+		version, err := store.SchemaVersion(ctx)
+		if err != nil {
+			t.Fatalf("SchemaVersion failed: %v", err)
+		}
+		if version != LatestSchemaVersion() {
+			t.Errorf("expected version %d, got %d", LatestSchemaVersion(), version)
+		}
+
+		var rows int
+		if err := store.db().QueryRow(`SELECT COUNT(*) FROM schema_migrations`).Scan(&rows); err != nil {
+			t.Fatalf("failed to count schema_migrations: %v", err)
+		}
+		if rows != LatestSchemaVersion() {
+			t.Errorf("expected one schema_migrations row per migration (%d), got %d", LatestSchemaVersion(), rows)
+		}
+	})
+
+	t.Run("reopening does not re-apply migrations", func(t *testing.T) {
+		dbPath := filepath.Join(t.TempDir(), "beads.db")
+		store, err := New(ctx, dbPath)
+		if err != nil {
+			t.Fatalf("New failed: %v", err)
+		}
+		var appliedAt string
+		if err := store.db().QueryRow(`SELECT applied_at FROM schema_migrations WHERE version = 1`).Scan(&appliedAt); err != nil {
+			t.Fatalf("failed to read applied_at: %v", err)
+		}
+		store.Close()
+
+		store, err = New(ctx, dbPath)
+		if err != nil {
+			t.Fatalf("reopen failed: %v", err)
+		}
+		defer store.Close()
+		var appliedAtAfter string
+		if err := store.db().QueryRow(`SELECT applied_at FROM schema_migrations WHERE version = 1`).Scan(&appliedAtAfter); err != nil {
+			t.Fatalf("failed to read applied_at: %v", err)
+		}
+		if appliedAt != appliedAtAfter {
+			t.Errorf("migration 1 was re-applied on reopen: %s -> %s", appliedAt, appliedAtAfter)
+		}
+	})
+
+	t.Run("legacy database without versioning is migrated forward", func(t *testing.T) {
+		dbPath := filepath.Join(t.TempDir(), "beads.db")
+		store, err := New(ctx, dbPath)
+		if err != nil {
+			t.Fatalf("New failed: %v", err)
+		}
+		if err := store.SetConfig(ctx, "issue_prefix", "bd"); err != nil {
+			t.Fatalf("SetConfig failed: %v", err)
+		}
+		issue := &types.Issue{Title: "Legacy", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		// Simulate a database created before schema versioning existed
+		if _, err := store.db().Exec(`DROP TABLE schema_migrations`); err != nil {
+			t.Fatalf("failed to drop schema_migrations: %v", err)
+		}
+		store.Close()
+
+		store, err = New(ctx, dbPath)
+		if err != nil {
+			t.Fatalf("reopen of legacy database failed: %v", err)
+		}
+		defer store.Close()
+
+		version, err := store.SchemaVersion(ctx)
+		if err != nil {
+			t.Fatalf("SchemaVersion failed: %v", err)
+		}
+		if version != LatestSchemaVersion() {
+			t.Errorf("expected legacy database to be migrated to %d, got %d", LatestSchemaVersion(), version)
+		}
+		got, err := store.GetIssue(ctx, issue.ID)
+		if err != nil || got == nil {
+			t.Fatalf("issue lost during migration: %v", err)
+		}
+	})
+
+	t.Run("database newer than binary is rejected", func(t *testing.T) {
+		dbPath := filepath.Join(t.TempDir(), "beads.db")
+		store, err := New(ctx, dbPath)
+		if err != nil {
+			t.Fatalf("New failed: %v", err)
+		}
+		future := LatestSchemaVersion() + 1
+		if _, err := store.db().Exec(`INSERT INTO schema_migrations (version, name) VALUES (?, 'from_the_future')`, future); err != nil {
+			t.Fatalf("failed to insert future version: %v", err)
+		}
+		store.Close()
+
+		store, err = New(ctx, dbPath)
+		if err == nil {
+			store.Close()
+			t.Fatal("expected opening a newer database to fail")
+		}
+		if !errors.Is(err, ErrSchemaTooNew) {
+			t.Errorf("expected ErrSchemaTooNew, got %v", err)
+		}
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -86,7 +87,7 @@ func (s *SQLiteStorage) hydrateFromRepo(ctx context.Context, repoPath, sourceRep
 
 	// Check cached mtime
 	var cachedMtime int64
-	err = s.db.QueryRowContext(ctx, `
+	err = s.db().QueryRowContext(ctx, `
 		SELECT mtime_ns FROM repo_mtimes WHERE repo_path = ?
 	`, absRepoPath).Scan(&cachedMtime)
 
@@ -106,7 +107,7 @@ func (s *SQLiteStorage) hydrateFromRepo(ctx context.Context, repoPath, sourceRep
 	}
 
 	// Update mtime cache
-	_, err = s.db.ExecContext(ctx, `
+	_, err = s.db().ExecContext(ctx, `
 		INSERT OR REPLACE INTO repo_mtimes (repo_path, jsonl_path, mtime_ns, last_checked)
 		VALUES (?, ?, ?, ?)
 	`, absRepoPath, jsonlPath, currentMtime, time.Now())
@@ -126,13 +127,19 @@ func (s *SQLiteStorage) importJSONLFile(ctx context.Context, jsonlPath, sourceRe
 	}
 	defer file.Close()
 
+	return s.importJSONL(ctx, file, sourceRepo)
+}
+
+// importJSONL upserts the issues read line by line from r in a single
+// transaction. A non-empty sourceRepo overrides each issue's source_repo.
+func (s *SQLiteStorage) importJSONL(ctx context.Context, r io.Reader, sourceRepo string) (int, error) {
 	// Fetch custom statuses for validation (bd-1pj6)
 	customStatuses, err := s.GetCustomStatuses(ctx)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get custom statuses: %w", err)
 	}
 
-	scanner := bufio.NewScanner(file)
+	scanner := bufio.NewScanner(r)
 	// Increase buffer size for large issues
 	buf := make([]byte, 0, 64*1024)
 	scanner.Buffer(buf, 10*1024*1024) // 10MB max line size
@@ -141,7 +148,7 @@ func (s *SQLiteStorage) importJSONLFile(ctx context.Context, jsonlPath, sourceRe
 	lineNum := 0
 
 	// Get exclusive connection to ensure PRAGMA applies
-	conn, err := s.db.Conn(ctx)
+	conn, err := s.db().Conn(ctx)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get connection: %w", err)
 	}
@@ -176,7 +183,9 @@ func (s *SQLiteStorage) importJSONLFile(ctx context.Context, jsonlPath, sourceRe
 		}
 
 		// Set source_repo field
-		issue.SourceRepo = sourceRepo
+		if sourceRepo != "" {
+			issue.SourceRepo = sourceRepo
+		}
 
 		// Compute content hash if missing
 		if issue.ContentHash == "" {
@@ -192,7 +201,7 @@ func (s *SQLiteStorage) importJSONLFile(ctx context.Context, jsonlPath, sourceRe
 	}
 
 	if err := scanner.Err(); err != nil {
-		return 0, fmt.Errorf("failed to read JSONL file: %w", err)
+		return 0, fmt.Errorf("failed to read JSONL: %w", err)
 	}
 
 	// Re-enable foreign keys before commit to validate data integrity
@@ -232,9 +241,14 @@ func (s *SQLiteStorage) upsertIssueInTx(ctx context.Context, tx *sql.Tx, issue *
 		return fmt.Errorf("validation failed: %w", err)
 	}
 
+	description, err := storedDescription(ctx, tx, issue.Description)
+	if err != nil {
+		return err
+	}
+
 	// Check if issue exists
 	var existingID string
-	err := tx.QueryRowContext(ctx, `SELECT id FROM issues WHERE id = ?`, issue.ID).Scan(&existingID)
+	err = tx.QueryRowContext(ctx, `SELECT id FROM issues WHERE id = ?`, issue.ID).Scan(&existingID)
 
 	if err == sql.ErrNoRows {
 		// Issue doesn't exist - insert it
@@ -246,7 +260,7 @@ func (s *SQLiteStorage) upsertIssueInTx(ctx context.Context, tx *sql.Tx, issue *
 				deleted_at, deleted_by, delete_reason, original_type
 			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		`,
-			issue.ID, issue.ContentHash, issue.Title, issue.Description, issue.Design,
+			issue.ID, issue.ContentHash, issue.Title, description, issue.Design,
 			issue.AcceptanceCriteria, issue.Notes, issue.Status,
 			issue.Priority, issue.IssueType, issue.Assignee,
 			issue.EstimatedMinutes, issue.CreatedAt, issue.UpdatedAt,
@@ -277,7 +291,7 @@ func (s *SQLiteStorage) upsertIssueInTx(ctx context.Context, tx *sql.Tx, issue *
 					deleted_at = ?, deleted_by = ?, delete_reason = ?, original_type = ?
 				WHERE id = ?
 			`,
-				issue.ContentHash, issue.Title, issue.Description, issue.Design,
+				issue.ContentHash, issue.Title, description, issue.Design,
 				issue.AcceptanceCriteria, issue.Notes, issue.Status, issue.Priority,
 				issue.IssueType, issue.Assignee, issue.EstimatedMinutes,
 				issue.UpdatedAt, issue.ClosedAt, issue.ExternalRef, issue.SourceRepo,
@@ -293,9 +307,9 @@ func (s *SQLiteStorage) upsertIssueInTx(ctx context.Context, tx *sql.Tx, issue *
 	// Import dependencies if present
 	for _, dep := range issue.Dependencies {
 		_, err = tx.ExecContext(ctx, `
-			INSERT OR IGNORE INTO dependencies (issue_id, depends_on_id, type, created_at, created_by)
-			VALUES (?, ?, ?, ?, ?)
-		`, dep.IssueID, dep.DependsOnID, dep.Type, dep.CreatedAt, dep.CreatedBy)
+			INSERT OR IGNORE INTO dependencies (issue_id, depends_on_id, type, created_at, created_by, note)
+			VALUES (?, ?, ?, ?, ?, ?)
+		`, dep.IssueID, dep.DependsOnID, dep.Type, dep.CreatedAt, dep.CreatedBy, dep.Note)
 		if err != nil {
 			return fmt.Errorf("failed to import dependency: %w", err)
 		}
@@ -323,7 +337,7 @@ func (s *SQLiteStorage) upsertIssueInTx(ctx context.Context, tx *sql.Tx, issue *
 		}
 	}
 
-	return nil
+	return syncFTS(ctx, tx, issue.ID)
 }
 
 // expandTilde expands ~ in a file path to the user's home directory.
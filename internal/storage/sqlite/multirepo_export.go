@@ -3,7 +3,6 @@ package sqlite
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -11,6 +10,7 @@ import (
 
 	"github.com/steveyegge/beads/internal/config"
 	"github.com/steveyegge/beads/internal/debug"
+	"github.com/steveyegge/beads/internal/export"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -26,8 +26,11 @@ func (s *SQLiteStorage) ExportToMultiRepo(ctx context.Context) (map[string]int,
 		return nil, nil
 	}
 
+	// The repos' JSONL is shared through git, so private issues stay out of it
+	ctx = WithPublicOnly(ctx)
+
 	// Get all issues including tombstones for sync propagation (bd-dve)
-	allIssues, err := s.SearchIssues(ctx, "", types.IssueFilter{IncludeTombstones: true})
+	allIssues, err := s.SearchIssues(ctx, "", types.IssueFilter{IncludeTombstones: true, IncludeSnoozed: true})
 	if err != nil {
 		return nil, fmt.Errorf("failed to query issues: %w", err)
 	}
@@ -50,6 +53,19 @@ func (s *SQLiteStorage) ExportToMultiRepo(ctx context.Context) (map[string]int,
 		issue.Labels = labels
 	}
 
+	// Populate links for all issues
+	issueIDs := make([]string, len(allIssues))
+	for i, issue := range allIssues {
+		issueIDs[i] = issue.ID
+	}
+	allLinks, err := s.GetLinksForIssues(ctx, issueIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get links: %w", err)
+	}
+	for _, issue := range allIssues {
+		issue.Links = allLinks[issue.ID]
+	}
+
 	// Group issues by source_repo
 	issuesByRepo := make(map[string][]*types.Issue)
 	for _, issue := range allIssues {
@@ -142,7 +158,7 @@ func (s *SQLiteStorage) exportToRepo(ctx context.Context, repoPath string, issue
 	}()
 
 	// Write JSONL
-	encoder := json.NewEncoder(f)
+	encoder := export.NewIssueEncoder(f, export.LoadTimestampFormat(ctx, s))
 	for _, issue := range issues {
 		if err := encoder.Encode(issue); err != nil {
 			return 0, fmt.Errorf("failed to encode issue %s: %w", issue.ID, err)
@@ -175,7 +191,7 @@ func (s *SQLiteStorage) exportToRepo(ctx context.Context, repoPath string, issue
 	// Use Lstat to get the symlink's own mtime, not the target's (NixOS fix).
 	fileInfo, err := os.Lstat(jsonlPath)
 	if err == nil {
-		_, err = s.db.ExecContext(ctx, `
+		_, err = s.db().ExecContext(ctx, `
 			INSERT OR REPLACE INTO repo_mtimes (repo_path, jsonl_path, mtime_ns, last_checked)
 			VALUES (?, ?, ?, datetime('now'))
 		`, absRepoPath, jsonlPath, fileInfo.ModTime().UnixNano())
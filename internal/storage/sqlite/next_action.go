@@ -0,0 +1,236 @@
+// Package sqlite - next-action recommendation for agents
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// DefaultNextActionClaimTTL is the claim length NextAction uses when
+// NextActionOptions.Claim is set without a ClaimTTL
+const DefaultNextActionClaimTTL = 30 * time.Minute
+
+// nextActionRecentIssues is how many of the agent's most recently touched
+// issues NextAction looks at for related work
+const nextActionRecentIssues = 20
+
+// NextActionOptions configures NextAction
+type NextActionOptions struct {
+	Labels   []string      // Only consider issues with all of these labels
+	Claim    bool          // Claim the returned issue for the agent (see ClaimIssue)
+	ClaimTTL time.Duration // Claim length; 0 means DefaultNextActionClaimTTL
+}
+
+// nextCandidate is a ready issue with the ranking inputs NextAction uses
+type nextCandidate struct {
+	issue   *types.Issue
+	rank    int // position in ready score order
+	held    bool
+	related bool
+}
+
+// NextAction returns the single ready issue agent should work on next, or
+// ErrNoWork if there is none. Ready issues claimed by another agent, or
+// assigned to or in progress for someone else, are skipped. Among the rest:
+//
+//   - work the agent already holds (its claims and its in-progress issues)
+//     comes first, so agents finish what they started
+//   - then higher priority
+//   - then issues related to the agent's recent activity: sharing a label
+//     with, or a dependency edge to, an issue it recently touched
+//   - then ready score
+//
+// An agent at its WIP limit (see WIPLimitConfigKey) is only offered work it
+// already holds. With opts.Claim the issue is claimed before it is returned;
+// if another agent wins the race the next candidate is tried.
+func (s *SQLiteStorage) NextAction(ctx context.Context, agent string, opts NextActionOptions) (*types.Issue, error) {
+	if agent == "" {
+		return nil, fmt.Errorf("next action requires an agent")
+	}
+
+	ready, err := s.GetReadyWork(ctx, types.WorkFilter{Labels: opts.Labels, SortPolicy: types.SortPolicyReadyScore})
+	if err != nil {
+		return nil, err
+	}
+	if len(ready) == 0 {
+		return nil, ErrNoWork
+	}
+
+	claims, err := s.activeClaims(ctx)
+	if err != nil {
+		return nil, err
+	}
+	limit, err := readWIPLimit(ctx, s.db())
+	if err != nil {
+		return nil, err
+	}
+	var wip int
+	if limit > 0 {
+		if err := s.db().QueryRowContext(ctx, `SELECT COUNT(*) FROM issues WHERE assignee = ? AND status = ?`,
+			agent, types.StatusInProgress).Scan(&wip); err != nil {
+			return nil, wrapDBError("count work in progress", err)
+		}
+	}
+	atLimit := limit > 0 && wip >= limit
+
+	var candidates []*nextCandidate
+	for i, issue := range ready {
+		claimedBy, claimed := claims[issue.ID]
+		if claimed && claimedBy != agent {
+			continue
+		}
+		if issue.Assignee != "" && issue.Assignee != agent {
+			continue
+		}
+		mine := issue.Status == types.StatusInProgress && issue.Assignee == agent
+		if atLimit && !mine {
+			continue
+		}
+		candidates = append(candidates, &nextCandidate{issue: issue, rank: i, held: mine || claimed})
+	}
+	if len(candidates) == 0 {
+		return nil, ErrNoWork
+	}
+
+	if err := s.markRelatedCandidates(ctx, agent, candidates); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.held != b.held {
+			return a.held
+		}
+		if a.issue.Priority != b.issue.Priority {
+			return a.issue.Priority < b.issue.Priority
+		}
+		if a.related != b.related {
+			return a.related
+		}
+		return a.rank < b.rank
+	})
+
+	if !opts.Claim {
+		return candidates[0].issue, nil
+	}
+	ttl := opts.ClaimTTL
+	if ttl <= 0 {
+		ttl = DefaultNextActionClaimTTL
+	}
+	for _, c := range candidates {
+		claimed, err := s.ClaimIssue(ctx, c.issue.ID, agent, ttl)
+		if err != nil {
+			return nil, err
+		}
+		if claimed {
+			return c.issue, nil
+		}
+	}
+	return nil, ErrNoWork
+}
+
+// activeClaims returns the agent holding each unexpired claim, by issue ID
+func (s *SQLiteStorage) activeClaims(ctx context.Context) (map[string]string, error) {
+	rows, err := s.db().QueryContext(ctx, `
+		SELECT issue_id, agent FROM issue_claims WHERE julianday(expires_at) > julianday(?)
+	`, time.Now().UTC())
+	if err != nil {
+		return nil, wrapDBError("query claims", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	claims := make(map[string]string)
+	for rows.Next() {
+		var id, agent string
+		if err := rows.Scan(&id, &agent); err != nil {
+			return nil, wrapDBError("scan claim", err)
+		}
+		claims[id] = agent
+	}
+	return claims, wrapDBError("iterate claims", rows.Err())
+}
+
+// markRelatedCandidates sets related on candidates that share a label with,
+// or have a dependency edge to, one of the issues agent touched most recently
+func (s *SQLiteStorage) markRelatedCandidates(ctx context.Context, agent string, candidates []*nextCandidate) error {
+	rows, err := s.db().QueryContext(ctx, `
+		SELECT issue_id FROM events WHERE actor = ?
+		GROUP BY issue_id ORDER BY MAX(id) DESC LIMIT ?
+	`, agent, nextActionRecentIssues)
+	if err != nil {
+		return wrapDBError("query recent activity", err)
+	}
+	recent, err := scanStrings(rows)
+	if err != nil {
+		return wrapDBError("scan recent activity", err)
+	}
+	if len(recent) == 0 {
+		return nil
+	}
+
+	recentLabels, err := s.GetLabelsForIssues(ctx, recent)
+	if err != nil {
+		return err
+	}
+	labels := make(map[string]bool)
+	for _, issueLabels := range recentLabels {
+		for _, label := range issueLabels {
+			labels[label] = true
+		}
+	}
+
+	placeholders := buildPlaceholders(len(recent))
+	args := append(stringArgs(recent), stringArgs(recent)...)
+	// #nosec G201 - placeholders only
+	rows, err = s.db().QueryContext(ctx, fmt.Sprintf(`
+		SELECT depends_on_id FROM dependencies WHERE issue_id IN (%s)
+		UNION
+		SELECT issue_id FROM dependencies WHERE depends_on_id IN (%s)
+	`, placeholders, placeholders), args...)
+	if err != nil {
+		return wrapDBError("query related issues", err)
+	}
+	neighbours, err := scanStrings(rows)
+	if err != nil {
+		return wrapDBError("scan related issues", err)
+	}
+	related := make(map[string]bool, len(neighbours))
+	for _, id := range neighbours {
+		related[id] = true
+	}
+
+	ids := make([]string, len(candidates))
+	for i, c := range candidates {
+		ids[i] = c.issue.ID
+	}
+	candidateLabels, err := s.GetLabelsForIssues(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, c := range candidates {
+		c.related = related[c.issue.ID]
+		for _, label := range candidateLabels[c.issue.ID] {
+			c.related = c.related || labels[label]
+		}
+	}
+	return nil
+}
+
+// scanStrings reads a single string column from rows and closes them
+func scanStrings(rows *sql.Rows) ([]string, error) {
+	defer func() { _ = rows.Close() }()
+	var values []string
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, rows.Err()
+}
@@ -0,0 +1,104 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestNextAction(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	if _, err := store.NextAction(ctx, "agent-1", NextActionOptions{}); !errors.Is(err, ErrNoWork) {
+		t.Fatalf("Expected ErrNoWork on an empty store, got %v", err)
+	}
+
+	newIssue := func(title string, priority int, labels ...string) *types.Issue {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: priority, IssueType: types.TypeTask, Labels: labels}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		for _, label := range labels {
+			if err := store.AddLabel(ctx, issue.ID, label, "test"); err != nil {
+				t.Fatalf("AddLabel failed: %v", err)
+			}
+		}
+		return issue
+	}
+	urgent := newIssue("Urgent", 0)
+	frontend := newIssue("Frontend", 1, "frontend")
+	backend := newIssue("Backend", 1, "backend")
+	touched := newIssue("Touched", 3, "backend")
+
+	next, err := store.NextAction(ctx, "agent-1", NextActionOptions{})
+	if err != nil || next.ID != urgent.ID {
+		t.Fatalf("Expected highest priority issue %s, got %+v (%v)", urgent.ID, next, err)
+	}
+
+	// Another agent's claim hides the issue
+	if _, err := store.ClaimIssue(ctx, urgent.ID, "agent-2", time.Hour); err != nil {
+		t.Fatalf("ClaimIssue failed: %v", err)
+	}
+
+	// Recent activity on a backend issue breaks the priority tie
+	if err := store.UpdateIssue(ctx, touched.ID, map[string]interface{}{"notes": "looked"}, "agent-1"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	next, err = store.NextAction(ctx, "agent-1", NextActionOptions{})
+	if err != nil || next.ID != backend.ID {
+		t.Fatalf("Expected related issue %s, got %+v (%v)", backend.ID, next, err)
+	}
+	next, err = store.NextAction(ctx, "agent-3", NextActionOptions{})
+	if err != nil || next.ID != frontend.ID {
+		t.Fatalf("Expected ready score order for another agent (%s), got %+v (%v)", frontend.ID, next, err)
+	}
+
+	// Work the agent already has in progress comes first
+	if err := store.UpdateIssue(ctx, touched.ID, map[string]interface{}{
+		"status": string(types.StatusInProgress), "assignee": "agent-1"}, "agent-1"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	next, err = store.NextAction(ctx, "agent-1", NextActionOptions{})
+	if err != nil || next.ID != touched.ID {
+		t.Fatalf("Expected in-progress issue %s, got %+v (%v)", touched.ID, next, err)
+	}
+	next, err = store.NextAction(ctx, "agent-3", NextActionOptions{})
+	if err != nil || next.ID == touched.ID {
+		t.Fatalf("Expected another agent to skip %s, got %+v (%v)", touched.ID, next, err)
+	}
+
+	// At the WIP limit only held work is offered
+	if err := store.SetConfig(ctx, WIPLimitConfigKey, "1"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	if err := store.CloseIssue(ctx, touched.ID, "done", "agent-1"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	if err := store.UpdateIssue(ctx, frontend.ID, map[string]interface{}{
+		"status": string(types.StatusInProgress), "assignee": "agent-1"}, "agent-1"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	next, err = store.NextAction(ctx, "agent-1", NextActionOptions{})
+	if err != nil || next.ID != frontend.ID {
+		t.Fatalf("Expected only held issue %s at WIP limit, got %+v (%v)", frontend.ID, next, err)
+	}
+	if err := store.CloseIssue(ctx, frontend.ID, "done", "agent-1"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+
+	// Claiming takes the issue and hides it from other agents
+	next, err = store.NextAction(ctx, "agent-1", NextActionOptions{Claim: true})
+	if err != nil || next.ID != backend.ID {
+		t.Fatalf("Expected to claim %s, got %+v (%v)", backend.ID, next, err)
+	}
+	if claim, _ := store.GetClaim(ctx, backend.ID); claim == nil || claim.Agent != "agent-1" {
+		t.Errorf("Expected claim by agent-1, got %+v", claim)
+	}
+	if _, err := store.NextAction(ctx, "agent-3", NextActionOptions{}); !errors.Is(err, ErrNoWork) {
+		t.Errorf("Expected ErrNoWork with everything claimed, got %v", err)
+	}
+}
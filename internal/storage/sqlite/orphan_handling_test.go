@@ -217,7 +217,8 @@ func TestOrphanHandling_Config(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			if tt.configValue != "" {
-				if err := store.SetConfig(ctx, "import.orphan_handling", tt.configValue); err != nil {
+				// Written directly, since SetConfig refuses invalid modes
+				if _, err := store.db().ExecContext(ctx, `INSERT OR REPLACE INTO config (key, value) VALUES (?, ?)`, "import.orphan_handling", tt.configValue); err != nil {
 					t.Fatalf("Failed to set config: %v", err)
 				}
 			} else {
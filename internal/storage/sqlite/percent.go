@@ -0,0 +1,75 @@
+// Package sqlite - percent-complete progress tracking
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// withPercentAutoClose returns updates with status set to closed if they set
+// percent_complete to 100 on an issue that is not closed, leave status alone,
+// and CloseOnPercentCompleteConfigKey is enabled. Otherwise updates is
+// returned unchanged.
+func withPercentAutoClose(ctx context.Context, q queryer, oldIssue *types.Issue, updates map[string]interface{}) (map[string]interface{}, error) {
+	if percent, ok := updates["percent_complete"].(int); !ok || percent != 100 {
+		return updates, nil
+	}
+	if _, hasStatus := updates["status"]; hasStatus || oldIssue.Status == types.StatusClosed {
+		return updates, nil
+	}
+	enabled, err := flagEnabled(ctx, q, CloseOnPercentCompleteConfigKey)
+	if err != nil || !enabled {
+		return updates, err
+	}
+
+	closing := make(map[string]interface{}, len(updates)+1)
+	for key, value := range updates {
+		closing[key] = value
+	}
+	closing["status"] = string(types.StatusClosed)
+	return closing, nil
+}
+
+// EpicProgress returns how far along epic epicID is across its parent-child
+// children. By default PercentComplete is the share of children that are
+// closed; with byPercent it is the average of the children's percent
+// complete, counting closed children as 100. An epic without children
+// reports its own percent complete.
+func (s *SQLiteStorage) EpicProgress(ctx context.Context, epicID string, byPercent bool) (*types.EpicProgress, error) {
+	var own int
+	err := s.db().QueryRowContext(ctx, `SELECT percent_complete FROM issues WHERE id = ?`, epicID).Scan(&own)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("issue %s: %w", epicID, ErrNotFound)
+	}
+	if err != nil {
+		return nil, wrapDBError("get epic", err)
+	}
+
+	progress := &types.EpicProgress{EpicID: epicID, ByPercent: byPercent}
+	var percentSum int
+	err = s.db().QueryRowContext(ctx, `
+		SELECT COUNT(*),
+		       COALESCE(SUM(CASE WHEN i.status = ? THEN 1 ELSE 0 END), 0),
+		       COALESCE(SUM(CASE WHEN i.status = ? THEN 100 ELSE i.percent_complete END), 0)
+		FROM dependencies d
+		JOIN issues i ON i.id = d.issue_id
+		WHERE d.depends_on_id = ? AND d.type = ? AND i.status != ?
+	`, types.StatusClosed, types.StatusClosed, epicID, types.DepParentChild, types.StatusTombstone).Scan(
+		&progress.TotalChildren, &progress.ClosedChildren, &percentSum)
+	if err != nil {
+		return nil, wrapDBError("count epic children", err)
+	}
+
+	switch {
+	case progress.TotalChildren == 0:
+		progress.PercentComplete = own
+	case byPercent:
+		progress.PercentComplete = percentSum / progress.TotalChildren
+	default:
+		progress.PercentComplete = progress.ClosedChildren * 100 / progress.TotalChildren
+	}
+	return progress, nil
+}
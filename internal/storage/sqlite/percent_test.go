@@ -0,0 +1,96 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestPercentComplete(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	newIssue := func(title string, issueType types.IssueType, percent int) *types.Issue {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: issueType, PercentComplete: percent}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		return issue
+	}
+
+	invalid := &types.Issue{Title: "Overdone", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, PercentComplete: 150}
+	if err := store.CreateIssue(ctx, invalid, "test"); err == nil {
+		t.Fatal("Expected CreateIssue to reject percent_complete 150")
+	}
+
+	epic := newIssue("Epic", types.TypeEpic, 10)
+	half := newIssue("Half", types.TypeTask, 50)
+	started := newIssue("Started", types.TypeTask, 20)
+	done := newIssue("Done", types.TypeTask, 0)
+	for _, child := range []*types.Issue{half, started, done} {
+		dep := &types.Dependency{IssueID: child.ID, DependsOnID: epic.ID, Type: types.DepParentChild}
+		if err := store.AddDependency(ctx, dep, "test"); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+	}
+
+	if err := store.UpdateIssue(ctx, half.ID, map[string]interface{}{"percent_complete": 101}, "test"); err == nil {
+		t.Error("Expected UpdateIssue to reject percent_complete 101")
+	}
+	if err := store.CloseIssue(ctx, done.ID, "done", "test"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+
+	got, err := store.GetIssue(ctx, half.ID)
+	if err != nil || got.PercentComplete != 50 {
+		t.Fatalf("Expected %s at 50%%, got %+v (%v)", half.ID, got, err)
+	}
+
+	minPercent := 20
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{MinPercent: &minPercent})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(issues) != 2 {
+		t.Errorf("Expected 2 issues at least 20%% complete, got %d", len(issues))
+	}
+
+	// By status only the closed child counts; by percent it counts as 100
+	progress, err := store.EpicProgress(ctx, epic.ID, false)
+	if err != nil {
+		t.Fatalf("EpicProgress failed: %v", err)
+	}
+	if progress.TotalChildren != 3 || progress.ClosedChildren != 1 || progress.PercentComplete != 33 {
+		t.Errorf("Expected 1/3 closed (33%%), got %+v", progress)
+	}
+	progress, err = store.EpicProgress(ctx, epic.ID, true)
+	if err != nil {
+		t.Fatalf("EpicProgress failed: %v", err)
+	}
+	if progress.PercentComplete != (50+20+100)/3 || !progress.ByPercent {
+		t.Errorf("Expected %d%% by percent, got %+v", (50+20+100)/3, progress)
+	}
+	progress, err = store.EpicProgress(ctx, half.ID, true)
+	if err != nil || progress.PercentComplete != 50 {
+		t.Errorf("Expected a childless issue to report its own 50%%, got %+v (%v)", progress, err)
+	}
+
+	// Reaching 100 only closes the issue when configured
+	if err := store.UpdateIssue(ctx, half.ID, map[string]interface{}{"percent_complete": 100}, "test"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if got, _ := store.GetIssue(ctx, half.ID); got.Status != types.StatusOpen {
+		t.Errorf("Expected %s to stay open with the flag off, got %s", half.ID, got.Status)
+	}
+	if err := store.SetFlag(ctx, CloseOnPercentCompleteConfigKey, true); err != nil {
+		t.Fatalf("SetFlag failed: %v", err)
+	}
+	if err := store.UpdateIssue(ctx, started.ID, map[string]interface{}{"percent_complete": 100}, "test"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	got, err = store.GetIssue(ctx, started.ID)
+	if err != nil || got.Status != types.StatusClosed || got.ClosedAt == nil || got.PercentComplete != 100 {
+		t.Errorf("Expected %s closed at 100%%, got %+v (%v)", started.ID, got, err)
+	}
+}
@@ -0,0 +1,111 @@
+// Package sqlite - auditable priority changes
+package sqlite
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Priority bounds accepted by SetPriority (0 = highest)
+const (
+	minPriority = 0
+	maxPriority = 4
+)
+
+// SetPriority changes the priority of issue id and records a
+// priority_changed event carrying the old and new priority with reason as
+// its comment, so the history explains why the priority moved. Setting the
+// current priority again is a no-op and records nothing.
+func (s *SQLiteStorage) SetPriority(ctx context.Context, id string, priority int, reason, actor string) error {
+	if err := validatePriority(priority); err != nil {
+		return err
+	}
+	return s.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		return tx.(*sqliteTxStorage).setPriority(ctx, id, priority, reason, actor)
+	})
+}
+
+// BumpPriority moves the priority of issue id by delta relative to its
+// current value, clamping at the bounds: a negative delta promotes (P2 -> P1)
+// and a positive one demotes. The change is recorded like SetPriority.
+// Returns the resulting priority.
+func (s *SQLiteStorage) BumpPriority(ctx context.Context, id string, delta int, reason, actor string) (int, error) {
+	var priority int
+	err := s.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		t := tx.(*sqliteTxStorage)
+		issue, err := t.GetIssue(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get issue: %w", err)
+		}
+		if issue == nil {
+			return fmt.Errorf("issue %s: %w", id, ErrNotFound)
+		}
+		priority = issue.Priority + delta
+		if priority < minPriority {
+			priority = minPriority
+		}
+		if priority > maxPriority {
+			priority = maxPriority
+		}
+		return t.setPriority(ctx, id, priority, reason, actor)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return priority, nil
+}
+
+// setPriority updates the priority within the transaction and records the
+// priority_changed event
+func (t *sqliteTxStorage) setPriority(ctx context.Context, id string, priority int, reason, actor string) error {
+	oldIssue, err := t.GetIssue(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get issue: %w", err)
+	}
+	if oldIssue == nil {
+		return fmt.Errorf("issue %s: %w", id, ErrNotFound)
+	}
+	if oldIssue.Priority == priority {
+		return nil
+	}
+
+	updates := map[string]interface{}{"priority": priority}
+	updatedIssue := *oldIssue
+	applyUpdatesToIssue(&updatedIssue, updates)
+
+	_, err = t.conn.ExecContext(ctx, `
+		UPDATE issues SET priority = ?, updated_at = ?, content_hash = ?
+		WHERE id = ?
+	`, priority, time.Now(), updatedIssue.ComputeContentHash(), id)
+	if err != nil {
+		return fmt.Errorf("failed to update priority: %w", err)
+	}
+
+	// old_value/new_value follow the UpdateIssue event layout so undo and
+	// change feeds treat priority changes like any other update
+	oldData, err := json.Marshal(oldIssue)
+	if err != nil {
+		oldData = []byte(fmt.Sprintf(`{"id":"%s"}`, id))
+	}
+	newData, err := json.Marshal(updates)
+	if err != nil {
+		newData = []byte(`{}`)
+	}
+	_, err = t.conn.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, old_value, new_value, comment)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, id, types.EventPriorityChanged, actor, string(oldData), string(newData), reason)
+	if err != nil {
+		return fmt.Errorf("failed to record event: %w", err)
+	}
+
+	if err := markDirty(ctx, t.conn, id); err != nil {
+		return fmt.Errorf("failed to mark issue dirty: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestSetPriority(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Task", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "agent"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	if err := store.SetPriority(ctx, issue.ID, 5, "too high", "agent"); err == nil {
+		t.Error("Expected out-of-range priority to be rejected")
+	}
+	if err := store.SetPriority(ctx, issue.ID, 0, "customer outage", "agent"); err != nil {
+		t.Fatalf("SetPriority failed: %v", err)
+	}
+
+	got, _ := store.GetIssue(ctx, issue.ID)
+	if got.Priority != 0 {
+		t.Errorf("Expected priority 0, got %d", got.Priority)
+	}
+
+	events, err := store.GetEvents(ctx, issue.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	var changed *types.Event
+	for _, e := range events {
+		if e.EventType == types.EventPriorityChanged {
+			changed = e
+		}
+	}
+	if changed == nil {
+		t.Fatal("Expected priority_changed event")
+	}
+	if changed.Comment == nil || *changed.Comment != "customer outage" {
+		t.Errorf("Expected reason as event comment, got %v", changed.Comment)
+	}
+
+	// Undo restores the previous priority
+	if _, err := store.Undo(ctx, "agent"); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	got, _ = store.GetIssue(ctx, issue.ID)
+	if got.Priority != 2 {
+		t.Errorf("Expected priority 2 after undo, got %d", got.Priority)
+	}
+
+	if err := store.SetPriority(ctx, "bd-missing", 1, "", "agent"); !IsNotFound(err) {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+}
+
+func TestBumpPriority(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Task", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "agent"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	tests := []struct {
+		delta int
+		want  int
+	}{
+		{-1, 0},
+		{-1, 0}, // clamped at P0
+		{2, 2},
+		{10, 4}, // clamped at P4
+	}
+	for _, tt := range tests {
+		got, err := store.BumpPriority(ctx, issue.ID, tt.delta, "triage", "agent")
+		if err != nil {
+			t.Fatalf("BumpPriority(%d) failed: %v", tt.delta, err)
+		}
+		if got != tt.want {
+			t.Errorf("BumpPriority(%d): expected %d, got %d", tt.delta, tt.want, got)
+		}
+	}
+
+	events, err := store.GetEvents(ctx, issue.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	changes := 0
+	for _, e := range events {
+		if e.EventType == types.EventPriorityChanged {
+			changes++
+		}
+	}
+	if changes != 3 {
+		t.Errorf("Expected 3 priority_changed events (no-op bump skipped), got %d", changes)
+	}
+}
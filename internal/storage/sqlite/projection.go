@@ -0,0 +1,143 @@
+// Package sqlite - field projection for searches
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// projectionColumns returns the issues columns to select for fields (see
+// IssueFilter.Fields), always starting with id, and whether labels were
+// requested. Labels live in their own table and are loaded after the scan.
+func projectionColumns(fields []string) ([]string, bool, error) {
+	if err := types.ValidateFields(fields); err != nil {
+		return nil, false, err
+	}
+	columns := []string{"id"}
+	withLabels := false
+	seen := map[string]bool{"id": true}
+	for _, field := range fields {
+		if seen[field] {
+			continue
+		}
+		seen[field] = true
+		if field == "labels" {
+			withLabels = true
+			continue
+		}
+		// Projectable field names match their column names
+		columns = append(columns, field)
+	}
+	return columns, withLabels, nil
+}
+
+// scanProjectedIssues scans rows selected with columns from
+// projectionColumns, leaving every other field zero-valued
+func scanProjectedIssues(ctx context.Context, rows *sql.Rows, columns []string) ([]*types.Issue, error) {
+	var issues []*types.Issue
+	for rows.Next() {
+		var issue types.Issue
+		var assignee, closeReason, externalRef sql.NullString
+		var estimatedMinutes sql.NullInt64
+		var closedAt sql.NullTime
+
+		dests := make([]interface{}, len(columns))
+		for i, column := range columns {
+			switch column {
+			case "id":
+				dests[i] = &issue.ID
+			case "title":
+				dests[i] = &issue.Title
+			case "description":
+				dests[i] = &issue.Description
+			case "design":
+				dests[i] = &issue.Design
+			case "acceptance_criteria":
+				dests[i] = &issue.AcceptanceCriteria
+			case "notes":
+				dests[i] = &issue.Notes
+			case "status":
+				dests[i] = &issue.Status
+			case "priority":
+				dests[i] = &issue.Priority
+			case "issue_type":
+				dests[i] = &issue.IssueType
+			case "assignee":
+				dests[i] = &assignee
+			case "estimated_minutes":
+				dests[i] = &estimatedMinutes
+			case "created_at":
+				dests[i] = &issue.CreatedAt
+			case "updated_at":
+				dests[i] = &issue.UpdatedAt
+			case "closed_at":
+				dests[i] = &closedAt
+			case "close_reason":
+				dests[i] = &closeReason
+			case "external_ref":
+				dests[i] = &externalRef
+			case "draft":
+				dests[i] = &issue.Draft
+			case "percent_complete":
+				dests[i] = &issue.PercentComplete
+			case "external_blocked_reason":
+				dests[i] = &issue.ExternalBlockedReason
+			case "snoozed_until":
+				dests[i] = &issue.SnoozedUntil
+			case "due_date":
+				dests[i] = &issue.DueDate
+			case "version":
+				dests[i] = &issue.Version
+			default:
+				return nil, fmt.Errorf("unsupported projection column %q", column)
+			}
+		}
+		if err := rows.Scan(dests...); err != nil {
+			return nil, fmt.Errorf("failed to scan issue: %w", err)
+		}
+		if err := decodeDescription(&issue.Description); err != nil {
+			return nil, err
+		}
+
+		if assignee.Valid {
+			issue.Assignee = assignee.String
+		}
+		if estimatedMinutes.Valid {
+			mins := int(estimatedMinutes.Int64)
+			issue.EstimatedMinutes = &mins
+		}
+		if closedAt.Valid {
+			issue.ClosedAt = &closedAt.Time
+		}
+		if closeReason.Valid {
+			issue.CloseReason = closeReason.String
+		}
+		if externalRef.Valid {
+			issue.ExternalRef = &externalRef.String
+		}
+		issues = append(issues, &issue)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, withContextError(ctx, fmt.Errorf("failed to iterate issues: %w", err))
+	}
+	return issues, nil
+}
+
+// issueIDs returns the IDs of issues
+func issueIDs(issues []*types.Issue) []string {
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	return ids
+}
+
+// attachLabels sets Labels on issues from labelsMap (issue ID -> labels)
+func attachLabels(issues []*types.Issue, labelsMap map[string][]string) {
+	for _, issue := range issues {
+		issue.Labels = labelsMap[issue.ID]
+	}
+}
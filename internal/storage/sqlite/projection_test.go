@@ -0,0 +1,82 @@
+package sqlite
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestSearchIssuesFieldProjection(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	issue := &types.Issue{
+		Title:       "Picker entry",
+		Description: strings.Repeat("long text ", 100),
+		Notes:       "notes",
+		Assignee:    "alice",
+		Status:      types.StatusOpen,
+		Priority:    1,
+		IssueType:   types.TypeBug,
+		Labels:      []string{"ui"},
+	}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	check := func(name string, issues []*types.Issue, err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("%s: SearchIssues failed: %v", name, err)
+		}
+		if len(issues) != 1 {
+			t.Fatalf("%s: Expected 1 issue, got %d", name, len(issues))
+		}
+		got := issues[0]
+		if got.ID != issue.ID || got.Title != "Picker entry" || got.Status != types.StatusOpen {
+			t.Errorf("%s: Expected requested fields, got %+v", name, got)
+		}
+		if got.Description != "" || got.Notes != "" || got.Assignee != "" || got.Priority != 0 || !got.CreatedAt.IsZero() {
+			t.Errorf("%s: Expected unrequested fields to be zero, got %+v", name, got)
+		}
+		if !reflect.DeepEqual(got.Labels, []string{"ui"}) {
+			t.Errorf("%s: Expected labels [ui], got %v", name, got.Labels)
+		}
+	}
+
+	filter := types.IssueFilter{Fields: []string{"title", "status", "labels"}}
+	issues, err := store.SearchIssues(ctx, "", filter)
+	check("store", issues, err)
+
+	err = store.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		issues, err := tx.SearchIssues(ctx, "", filter)
+		check("transaction", issues, err)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("RunInTransaction failed: %v", err)
+	}
+
+	// Sorting and filtering still use unprojected columns
+	issues, err = store.SearchIssues(ctx, "", types.IssueFilter{
+		Fields:   []string{"title"},
+		Assignee: &issue.Assignee,
+		SortBy:   []types.SortKey{{Field: types.SortByReadyScore}},
+	})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(issues) != 1 || issues[0].Title != "Picker entry" || issues[0].ReadyScore == nil {
+		t.Errorf("Expected projected issue with ready score, got %+v", issues)
+	}
+
+	for _, fields := range [][]string{{"comments"}, {"bogus"}} {
+		if _, err := store.SearchIssues(ctx, "", types.IssueFilter{Fields: fields}); err == nil {
+			t.Errorf("Expected error projecting %v", fields)
+		}
+	}
+}
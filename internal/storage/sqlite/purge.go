@@ -0,0 +1,61 @@
+// Package sqlite - purging old tombstones
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// PurgeDeleted permanently removes tombstones (see CreateTombstone) deleted
+// more than olderThan ago by the store's clock, along with their
+// dependencies, labels and events, and returns the IDs it removed. Unlike
+// DeleteIssues, it leaves nothing behind. Tombstones without a deletion time
+// are kept. Purged issues drop out of the next export, so only purge
+// tombstones old enough that every clone has already imported them.
+func (s *SQLiteStorage) PurgeDeleted(ctx context.Context, olderThan time.Duration) ([]string, error) {
+	if olderThan < 0 {
+		return nil, fmt.Errorf("invalid purge age %v", olderThan)
+	}
+	cutoff := s.now().Add(-olderThan)
+
+	var ids []string
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		rows, err := tx.QueryContext(ctx, `SELECT id, deleted_at FROM issues WHERE status = ? ORDER BY id`, types.StatusTombstone)
+		if err != nil {
+			return wrapDBError("query tombstones", err)
+		}
+		for rows.Next() {
+			var id string
+			var deletedAt sql.NullString // TEXT column, not DATETIME - must parse manually
+			if err := rows.Scan(&id, &deletedAt); err != nil {
+				_ = rows.Close()
+				return wrapDBError("scan tombstone", err)
+			}
+			if at := parseNullableTimeString(deletedAt); at != nil && at.Before(cutoff) {
+				ids = append(ids, id)
+			}
+		}
+		_ = rows.Close()
+		if err := rows.Err(); err != nil {
+			return wrapDBError("query tombstones", err)
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		// Dependencies, labels, events and the rest cascade
+		inClause, args := buildSQLInClause(ids)
+		if _, err := tx.ExecContext(ctx, `DELETE FROM issues WHERE id IN (`+inClause+`)`, args...); err != nil {
+			return wrapDBError("purge tombstones", err)
+		}
+		return syncFTS(ctx, tx, ids...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
@@ -0,0 +1,82 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestPurgeDeleted(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	var ids []string
+	for _, title := range []string{"Old tombstone", "New tombstone", "Live"} {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		ids = append(ids, issue.ID)
+	}
+	oldID, newID, liveID := ids[0], ids[1], ids[2]
+	for _, id := range []string{oldID, newID} {
+		if err := store.CreateTombstone(ctx, id, "test", "cleanup"); err != nil {
+			t.Fatalf("CreateTombstone failed: %v", err)
+		}
+	}
+	if _, err := store.db().ExecContext(ctx, `UPDATE issues SET deleted_at = ? WHERE id = ?`, time.Now().Add(-40*24*time.Hour), oldID); err != nil {
+		t.Fatalf("failed to age tombstone: %v", err)
+	}
+
+	// Tombstones still read back, but are hidden from searches
+	got, err := store.GetIssue(ctx, newID)
+	if err != nil || got == nil || got.DeletedAt == nil {
+		t.Fatalf("Expected tombstone %s with a deletion time, got %+v (err %v)", newID, got, err)
+	}
+	search := func(filter types.IssueFilter) []string {
+		issues, err := store.SearchIssues(ctx, "", filter)
+		if err != nil {
+			t.Fatalf("SearchIssues failed: %v", err)
+		}
+		var ids []string
+		for _, i := range issues {
+			ids = append(ids, i.ID)
+		}
+		return ids
+	}
+	if found := search(types.IssueFilter{}); !sameIDs(found, []string{liveID}) {
+		t.Errorf("Expected only %s in search, got %v", liveID, found)
+	}
+	if found := search(types.IssueFilter{IncludeTombstones: true}); !sameIDs(found, ids) {
+		t.Errorf("Expected tombstones included, got %v", found)
+	}
+
+	if _, err := store.PurgeDeleted(ctx, -time.Hour); err == nil {
+		t.Error("Expected a negative age to be rejected")
+	}
+	purged, err := store.PurgeDeleted(ctx, 30*24*time.Hour)
+	if err != nil {
+		t.Fatalf("PurgeDeleted failed: %v", err)
+	}
+	if !sameIDs(purged, []string{oldID}) {
+		t.Errorf("Expected %s purged, got %v", oldID, purged)
+	}
+	if got, _ := store.GetIssue(ctx, oldID); got != nil {
+		t.Errorf("Expected %s gone, got %+v", oldID, got)
+	}
+
+	// The clock decides what counts as old
+	store.SetClock(func() time.Time { return time.Now().Add(31 * 24 * time.Hour) })
+	purged, err = store.PurgeDeleted(ctx, 30*24*time.Hour)
+	if err != nil {
+		t.Fatalf("PurgeDeleted failed: %v", err)
+	}
+	if !sameIDs(purged, []string{newID}) {
+		t.Errorf("Expected %s purged, got %v", newID, purged)
+	}
+	if found := search(types.IssueFilter{IncludeTombstones: true}); !sameIDs(found, []string{liveID}) {
+		t.Errorf("Expected only %s left, got %v", liveID, found)
+	}
+}
@@ -50,6 +50,11 @@ func (s *SQLiteStorage) CreateIssue(ctx context.Context, issue *types.Issue, act
 		return fmt.Errorf("failed to get custom statuses: %w", err)
 	}
 
+	// Fill unset fields from the project's creation defaults before validating
+	if err := applyCreateDefaults(ctx, s.db(), issue); err != nil {
+		return err
+	}
+
 	// Validate issue before creating (with custom status support)
 	if err := issue.ValidateWithCustomStatuses(customStatuses); err != nil {
 		return fmt.Errorf("validation failed: %w", err)
@@ -69,7 +74,7 @@ func (s *SQLiteStorage) CreateIssue(ctx context.Context, issue *types.Issue, act
 	// This is necessary because we need to execute raw SQL ("BEGIN IMMEDIATE", "COMMIT")
 	// on the same connection, and database/sql's connection pool would otherwise
 	// use different connections for different queries.
-	conn, err := s.db.Conn(ctx)
+	conn, err := s.db().Conn(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to acquire connection: %w", err)
 	}
@@ -82,8 +87,8 @@ func (s *SQLiteStorage) CreateIssue(ctx context.Context, issue *types.Issue, act
 	// We use raw Exec instead of BeginTx because database/sql doesn't support transaction
 	// modes in BeginTx, and modernc.org/sqlite's BeginTx always uses DEFERRED mode.
 	//
-	// Use retry logic with exponential backoff to handle SQLITE_BUSY under concurrent load (bd-ola6)
-	if err := beginImmediateWithRetry(ctx, conn, 5, 10*time.Millisecond); err != nil {
+	// Retry SQLITE_BUSY under concurrent load per the write retry policy (bd-ola6)
+	if err := s.beginImmediate(ctx, conn); err != nil {
 		return fmt.Errorf("failed to begin immediate transaction: %w", err)
 	}
 
@@ -107,10 +112,34 @@ func (s *SQLiteStorage) CreateIssue(ctx context.Context, issue *types.Issue, act
 		return fmt.Errorf("failed to get config: %w", err)
 	}
 
+	// A retry of a create that already committed gets that issue back
+	// instead of a duplicate (see WithIdempotencyKey)
+	key := idempotencyKey(ctx)
+	if key != "" {
+		existing, err := s.replayIdempotentCreate(ctx, conn, key)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			if _, err := conn.ExecContext(ctx, "COMMIT"); err != nil {
+				return fmt.Errorf("failed to commit transaction: %w", err)
+			}
+			committed = true
+			*issue = *existing
+			return nil
+		}
+	}
+
+	// Refuse to create an in-progress issue past its assignee's WIP limit
+	if err := checkWIPLimit(ctx, conn, issue.ID, issue.Assignee, issue.Status); err != nil {
+		return err
+	}
+
 	// Generate or validate ID
-	if issue.ID == "" {
+	generated := issue.ID == ""
+	if generated {
 		// Generate hash-based ID with adaptive length based on database size (bd-ea2a13)
-		generatedID, err := GenerateIssueID(ctx, conn, prefix, issue, actor)
+		generatedID, err := issueIDGenerator(s.seedIDs(ctx), conn, prefix, issue, actor)
 		if err != nil {
 			return wrapDBError("generate issue ID", err)
 		}
@@ -138,11 +167,22 @@ func (s *SQLiteStorage) CreateIssue(ctx context.Context, issue *types.Issue, act
 		}
 	}
 
-	// Insert issue
-	if err := insertIssue(ctx, conn, issue); err != nil {
+	// Insert issue. Only generated IDs are retried on collision; a caller-supplied
+	// ID that already exists is the caller's error.
+	if generated {
+		err = insertGeneratedIssue(ctx, conn, prefix, issue, actor)
+	} else {
+		err = insertIssue(ctx, conn, issue)
+	}
+	if err != nil {
 		return wrapDBError("insert issue", err)
 	}
 
+	// Store the labels the issue was created with (explicit or defaulted)
+	if err := insertIssueLabels(ctx, conn, issue); err != nil {
+		return wrapDBError("insert labels", err)
+	}
+
 	// Record creation event
 	if err := recordCreatedEvent(ctx, conn, issue, actor); err != nil {
 		return wrapDBError("record creation event", err)
@@ -153,6 +193,12 @@ func (s *SQLiteStorage) CreateIssue(ctx context.Context, issue *types.Issue, act
 		return wrapDBError("mark issue dirty", err)
 	}
 
+	if key != "" {
+		if err := s.recordIdempotencyKey(ctx, conn, key, issue.ID); err != nil {
+			return err
+		}
+	}
+
 	// Commit the transaction
 	if _, err := conn.ExecContext(ctx, "COMMIT"); err != nil {
 		return fmt.Errorf("failed to commit transaction: %w", err)
@@ -166,6 +212,19 @@ func (s *SQLiteStorage) CreateIssue(ctx context.Context, issue *types.Issue, act
 
 // GetIssue retrieves an issue by ID
 func (s *SQLiteStorage) GetIssue(ctx context.Context, id string) (*types.Issue, error) {
+	var result *types.Issue
+	err := s.withReadRetry(ctx, func() error {
+		var err error
+		result, err = s.getIssue(ctx, id)
+		return err
+	})
+	return result, err
+}
+
+// getIssue is a single attempt at GetIssue; busy errors are retried by the caller
+func (s *SQLiteStorage) getIssue(ctx context.Context, id string) (*types.Issue, error) {
+	defer s.beginRead()()
+
 	var issue types.Issue
 	var closedAt sql.NullTime
 	var estimatedMinutes sql.NullInt64
@@ -182,21 +241,28 @@ func (s *SQLiteStorage) GetIssue(ctx context.Context, id string) (*types.Issue,
 
 	var contentHash sql.NullString
 	var compactedAtCommit sql.NullString
-	err := s.db.QueryRowContext(ctx, `
+	query := `
 		SELECT id, content_hash, title, description, design, acceptance_criteria, notes,
 		       status, priority, issue_type, assignee, estimated_minutes,
 		       created_at, updated_at, closed_at, external_ref,
 		       compaction_level, compacted_at, compacted_at_commit, original_size, source_repo, close_reason,
-		       deleted_at, deleted_by, delete_reason, original_type
+		       deleted_at, deleted_by, delete_reason, original_type, draft, percent_complete, external_blocked_reason, snoozed_until, due_date, version
 		FROM issues
-		WHERE id = ?
-	`, id).Scan(
+		WHERE id = ?`
+	args := []interface{}{id}
+	// Private issues the requesting actor doesn't own read as missing
+	if visible, visibleArgs := visibleClause(ctx, "issues.id"); visible != "" {
+		query += " AND " + visible
+		args = append(args, visibleArgs...)
+	}
+	stmts := s.stmts()
+	err := stmts.QueryRowContext(ctx, query, args...).Scan(
 		&issue.ID, &contentHash, &issue.Title, &issue.Description, &issue.Design,
 		&issue.AcceptanceCriteria, &issue.Notes, &issue.Status,
 		&issue.Priority, &issue.IssueType, &assignee, &estimatedMinutes,
 		&issue.CreatedAt, &issue.UpdatedAt, &closedAt, &externalRef,
 		&issue.CompactionLevel, &compactedAt, &compactedAtCommit, &originalSize, &sourceRepo, &closeReason,
-		&deletedAt, &deletedBy, &deleteReason, &originalType,
+		&deletedAt, &deletedBy, &deleteReason, &originalType, &issue.Draft, &issue.PercentComplete, &issue.ExternalBlockedReason, &issue.SnoozedUntil, &issue.DueDate, &issue.Version,
 	)
 
 	if err == sql.ErrNoRows {
@@ -205,6 +271,9 @@ func (s *SQLiteStorage) GetIssue(ctx context.Context, id string) (*types.Issue,
 	if err != nil {
 		return nil, fmt.Errorf("failed to get issue: %w", err)
 	}
+	if err := decodeDescription(&issue.Description); err != nil {
+		return nil, err
+	}
 
 	if contentHash.Valid {
 		issue.ContentHash = contentHash.String
@@ -255,13 +324,23 @@ func (s *SQLiteStorage) GetIssue(ctx context.Context, id string) (*types.Issue,
 	}
 	issue.Labels = labels
 
+	if issue.Recurrence, err = getRecurrence(ctx, stmts, issue.ID); err != nil {
+		return nil, err
+	}
+	if issue.Sections, err = getSections(ctx, stmts, issue.ID); err != nil {
+		return nil, err
+	}
+	if issue.Watchers, err = getWatchers(ctx, stmts, issue.ID); err != nil {
+		return nil, err
+	}
+
 	return &issue, nil
 }
 
 // GetCloseReason retrieves the close reason from the most recent closed event for an issue
 func (s *SQLiteStorage) GetCloseReason(ctx context.Context, issueID string) (string, error) {
 	var comment sql.NullString
-	err := s.db.QueryRowContext(ctx, `
+	err := s.db().QueryRowContext(ctx, `
 		SELECT comment FROM events
 		WHERE issue_id = ? AND event_type = ?
 		ORDER BY created_at DESC
@@ -313,7 +392,7 @@ func (s *SQLiteStorage) GetCloseReasonsForIssues(ctx context.Context, issueIDs [
 	// Append event_type again for the outer WHERE clause
 	args = append(args, types.EventClosed)
 
-	rows, err := s.db.QueryContext(ctx, query, args...)
+	rows, err := s.db().QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get close reasons: %w", err)
 	}
@@ -335,6 +414,8 @@ func (s *SQLiteStorage) GetCloseReasonsForIssues(ctx context.Context, issueIDs [
 
 // GetIssueByExternalRef retrieves an issue by external reference
 func (s *SQLiteStorage) GetIssueByExternalRef(ctx context.Context, externalRef string) (*types.Issue, error) {
+	defer s.beginRead()()
+
 	var issue types.Issue
 	var closedAt sql.NullTime
 	var estimatedMinutes sql.NullInt64
@@ -351,21 +432,27 @@ func (s *SQLiteStorage) GetIssueByExternalRef(ctx context.Context, externalRef s
 	var deleteReason sql.NullString
 	var originalType sql.NullString
 
-	err := s.db.QueryRowContext(ctx, `
+	query := `
 		SELECT id, content_hash, title, description, design, acceptance_criteria, notes,
 		       status, priority, issue_type, assignee, estimated_minutes,
 		       created_at, updated_at, closed_at, external_ref,
 		       compaction_level, compacted_at, compacted_at_commit, original_size, source_repo, close_reason,
-		       deleted_at, deleted_by, delete_reason, original_type
+		       deleted_at, deleted_by, delete_reason, original_type, draft, percent_complete, external_blocked_reason, snoozed_until, due_date, version
 		FROM issues
-		WHERE external_ref = ?
-	`, externalRef).Scan(
+		WHERE external_ref = ?`
+	args := []interface{}{externalRef}
+	// Private issues the requesting actor doesn't own read as missing
+	if visible, visibleArgs := visibleClause(ctx, "issues.id"); visible != "" {
+		query += " AND " + visible
+		args = append(args, visibleArgs...)
+	}
+	err := s.db().QueryRowContext(ctx, query, args...).Scan(
 		&issue.ID, &contentHash, &issue.Title, &issue.Description, &issue.Design,
 		&issue.AcceptanceCriteria, &issue.Notes, &issue.Status,
 		&issue.Priority, &issue.IssueType, &assignee, &estimatedMinutes,
 		&issue.CreatedAt, &issue.UpdatedAt, &closedAt, &externalRefCol,
 		&issue.CompactionLevel, &compactedAt, &compactedAtCommit, &originalSize, &sourceRepo, &closeReason,
-		&deletedAt, &deletedBy, &deleteReason, &originalType,
+		&deletedAt, &deletedBy, &deleteReason, &originalType, &issue.Draft, &issue.PercentComplete, &issue.ExternalBlockedReason, &issue.SnoozedUntil, &issue.DueDate, &issue.Version,
 	)
 
 	if err == sql.ErrNoRows {
@@ -374,6 +461,9 @@ func (s *SQLiteStorage) GetIssueByExternalRef(ctx context.Context, externalRef s
 	if err != nil {
 		return nil, fmt.Errorf("failed to get issue by external_ref: %w", err)
 	}
+	if err := decodeDescription(&issue.Description); err != nil {
+		return nil, err
+	}
 
 	if contentHash.Valid {
 		issue.ContentHash = contentHash.String
@@ -429,18 +519,22 @@ func (s *SQLiteStorage) GetIssueByExternalRef(ctx context.Context, externalRef s
 
 // Allowed fields for update to prevent SQL injection
 var allowedUpdateFields = map[string]bool{
-	"status":              true,
-	"priority":            true,
-	"title":               true,
-	"assignee":            true,
-	"description":         true,
-	"design":              true,
-	"acceptance_criteria": true,
-	"notes":               true,
-	"issue_type":          true,
-	"estimated_minutes":   true,
-	"external_ref":        true,
-	"closed_at":           true,
+	"status":                  true,
+	"priority":                true,
+	"title":                   true,
+	"assignee":                true,
+	"description":             true,
+	"design":                  true,
+	"acceptance_criteria":     true,
+	"notes":                   true,
+	"issue_type":              true,
+	"estimated_minutes":       true,
+	"percent_complete":        true,
+	"external_blocked_reason": true,
+	"snoozed_until":           true,
+	"due_date":                true,
+	"external_ref":            true,
+	"closed_at":               true,
 }
 
 // validatePriority validates a priority value
@@ -512,6 +606,33 @@ func manageClosedAt(oldIssue *types.Issue, updates map[string]interface{}, setCl
 	return setClauses, args
 }
 
+// manageSnoozedUntil clears snoozed_until when an update moves an issue out
+// of the snoozed status without setting it explicitly
+func manageSnoozedUntil(oldIssue *types.Issue, updates map[string]interface{}, setClauses []string, args []interface{}) ([]string, []interface{}) {
+	if _, hasExplicit := updates["snoozed_until"]; hasExplicit || oldIssue.SnoozedUntil == nil {
+		return setClauses, args
+	}
+	statusVal, hasStatus := updates["status"]
+	if !hasStatus {
+		return setClauses, args
+	}
+	var newStatus types.Status
+	switch v := statusVal.(type) {
+	case string:
+		newStatus = types.Status(v)
+	case types.Status:
+		newStatus = v
+	default:
+		return setClauses, args
+	}
+	if newStatus != types.StatusSnoozed {
+		updates["snoozed_until"] = nil
+		setClauses = append(setClauses, "snoozed_until = ?")
+		args = append(args, nil)
+	}
+	return setClauses, args
+}
+
 // UpdateIssue updates fields on an issue
 func (s *SQLiteStorage) UpdateIssue(ctx context.Context, id string, updates map[string]interface{}, actor string) error {
 	// Get old issue for event
@@ -522,6 +643,9 @@ func (s *SQLiteStorage) UpdateIssue(ctx context.Context, id string, updates map[
 	if oldIssue == nil {
 		return fmt.Errorf("issue %s not found", id)
 	}
+	if err := checkExpectedVersion(ctx, oldIssue); err != nil {
+		return err
+	}
 
 	// Fetch custom statuses for validation (bd-1pj6)
 	customStatuses, err := s.GetCustomStatuses(ctx)
@@ -529,6 +653,11 @@ func (s *SQLiteStorage) UpdateIssue(ctx context.Context, id string, updates map[
 		return wrapDBError("get custom statuses", err)
 	}
 
+	// Close issues that reach 100 percent complete if configured
+	if updates, err = withPercentAutoClose(ctx, s.db(), oldIssue, updates); err != nil {
+		return wrapDBError("check percent auto-close", err)
+	}
+
 	// Build update query with validated field names
 	setClauses := []string{"updated_at = ?"}
 	args := []interface{}{time.Now()}
@@ -544,16 +673,25 @@ func (s *SQLiteStorage) UpdateIssue(ctx context.Context, id string, updates map[
 			return wrapDBError("validate field update", err)
 		}
 
+		if key == "description" {
+			if description, ok := value.(string); ok {
+				if value, err = storedDescription(ctx, s.db(), description); err != nil {
+					return err
+				}
+			}
+		}
+
 		setClauses = append(setClauses, fmt.Sprintf("%s = ?", key))
 		args = append(args, value)
 	}
 
 	// Auto-manage closed_at when status changes (enforce invariant)
 	setClauses, args = manageClosedAt(oldIssue, updates, setClauses, args)
+	setClauses, args = manageSnoozedUntil(oldIssue, updates, setClauses, args)
 
 	// Recompute content_hash if any content fields changed (bd-95)
 	contentChanged := false
-	contentFields := []string{"title", "description", "design", "acceptance_criteria", "notes", "status", "priority", "issue_type", "assignee", "external_ref"}
+	contentFields := []string{"title", "description", "design", "acceptance_criteria", "notes", "status", "priority", "issue_type", "assignee", "external_ref", "due_date"}
 	for _, field := range contentFields {
 		if _, exists := updates[field]; exists {
 			contentChanged = true
@@ -611,6 +749,12 @@ func (s *SQLiteStorage) UpdateIssue(ctx context.Context, id string, updates map[
 						return fmt.Errorf("external_ref must be string or *string, got %T", value)
 					}
 				}
+			case "due_date":
+				if t, ok := value.(time.Time); ok {
+					updatedIssue.DueDate = &t
+				} else {
+					updatedIssue.DueDate = nil
+				}
 			}
 		}
 		newHash := updatedIssue.ComputeContentHash()
@@ -621,18 +765,54 @@ func (s *SQLiteStorage) UpdateIssue(ctx context.Context, id string, updates map[
 	args = append(args, id)
 
 	// Start transaction
-	tx, err := s.db.BeginTx(ctx, nil)
+	tx, err := s.db().BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer func() { _ = tx.Rollback() }()
 
-	// Update issue
+	// Refuse to close a parent with open children if configured
+	if determineEventType(oldIssue, updates) == types.EventClosed && oldIssue.Status != types.StatusClosed {
+		if err := checkOpenChildren(ctx, tx, id); err != nil {
+			return err
+		}
+	}
+
+	// Refuse to take the assignee past their WIP limit if configured
+	if err := checkWIPUpdate(ctx, tx, oldIssue, updates); err != nil {
+		return err
+	}
+
+	// Update issue. Under WithExpectedVersion, the version is checked again
+	// here, since another writer may have got in after oldIssue was read.
 	query := fmt.Sprintf("UPDATE issues SET %s WHERE id = ?", strings.Join(setClauses, ", ")) // #nosec G201 - safe SQL with controlled column names
-	_, err = tx.ExecContext(ctx, query, args...)
+	version, checkVersion := expectedVersion(ctx)
+	if checkVersion {
+		query += " AND version = ?"
+		args = append(args, version)
+	}
+	result, err := tx.ExecContext(ctx, query, args...)
 	if err != nil {
 		return fmt.Errorf("failed to update issue: %w", err)
 	}
+	if checkVersion {
+		if n, err := result.RowsAffected(); err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		} else if n == 0 {
+			_ = tx.Rollback()
+			current, err := s.GetIssue(ctx, id)
+			if err != nil {
+				return wrapDBError("get issue after stale update", err)
+			}
+			if current == nil {
+				return fmt.Errorf("issue %s not found", id)
+			}
+			return &StaleUpdateError{Expected: version, Current: current}
+		}
+	}
+	if err := syncFTS(ctx, tx, id); err != nil {
+		return err
+	}
 
 	// Record event
 	oldData, err := json.Marshal(oldIssue)
@@ -671,9 +851,15 @@ func (s *SQLiteStorage) UpdateIssue(ctx context.Context, id string, updates map[
 	// Invalidate blocked issues cache if status changed (bd-5qim)
 	// Status changes affect which issues are blocked (blockers must be open/in_progress/blocked)
 	if _, statusChanged := updates["status"]; statusChanged {
-		if err := s.invalidateBlockedCache(ctx, tx); err != nil {
+		if err := s.invalidateBlockedCache(ctx, tx, id); err != nil {
 			return fmt.Errorf("failed to invalidate blocked cache: %w", err)
 		}
+		if err := s.autoUnblockDependents(ctx, tx, id); err != nil {
+			return err
+		}
+		if err := s.autoCloseEpics(ctx, tx, id); err != nil {
+			return err
+		}
 	}
 
 	return tx.Commit()
@@ -682,7 +868,7 @@ func (s *SQLiteStorage) UpdateIssue(ctx context.Context, id string, updates map[
 // UpdateIssueID updates an issue ID and all its text fields in a single transaction
 func (s *SQLiteStorage) UpdateIssueID(ctx context.Context, oldID, newID string, issue *types.Issue, actor string) error {
 	// Get exclusive connection to ensure PRAGMA applies
-	conn, err := s.db.Conn(ctx)
+	conn, err := s.db().Conn(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get connection: %w", err)
 	}
@@ -700,11 +886,16 @@ func (s *SQLiteStorage) UpdateIssueID(ctx context.Context, oldID, newID string,
 	}
 	defer func() { _ = tx.Rollback() }()
 
+	description, err := storedDescription(ctx, tx, issue.Description)
+	if err != nil {
+		return err
+	}
+
 	result, err := tx.ExecContext(ctx, `
 		UPDATE issues
 		SET id = ?, title = ?, description = ?, design = ?, acceptance_criteria = ?, notes = ?, updated_at = ?
 		WHERE id = ?
-	`, newID, issue.Title, issue.Description, issue.Design, issue.AcceptanceCriteria, issue.Notes, time.Now(), oldID)
+	`, newID, issue.Title, description, issue.Design, issue.AcceptanceCriteria, issue.Notes, time.Now(), oldID)
 	if err != nil {
 		return fmt.Errorf("failed to update issue ID: %w", err)
 	}
@@ -776,6 +967,10 @@ func (s *SQLiteStorage) UpdateIssueID(ctx context.Context, oldID, newID string,
 		return fmt.Errorf("failed to record rename event: %w", err)
 	}
 
+	if err := syncFTS(ctx, tx, oldID, newID); err != nil {
+		return err
+	}
+
 	return tx.Commit()
 }
 
@@ -803,12 +998,16 @@ func (s *SQLiteStorage) CloseIssue(ctx context.Context, id string, reason string
 	now := time.Now()
 
 	// Update with special event handling
-	tx, err := s.db.BeginTx(ctx, nil)
+	tx, err := s.db().BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer func() { _ = tx.Rollback() }()
 
+	if err := checkOpenChildren(ctx, tx, id); err != nil {
+		return err
+	}
+
 	// NOTE: close_reason is stored in two places:
 	// 1. issues.close_reason - for direct queries (bd show --json, exports)
 	// 2. events.comment - for audit history (when was it closed, by whom)
@@ -849,9 +1048,15 @@ func (s *SQLiteStorage) CloseIssue(ctx context.Context, id string, reason string
 
 	// Invalidate blocked issues cache since status changed to closed (bd-5qim)
 	// Closed issues don't block others, so this affects blocking calculations
-	if err := s.invalidateBlockedCache(ctx, tx); err != nil {
+	if err := s.invalidateBlockedCache(ctx, tx, id); err != nil {
 		return fmt.Errorf("failed to invalidate blocked cache: %w", err)
 	}
+	if err := s.autoUnblockDependents(ctx, tx, id); err != nil {
+		return err
+	}
+	if err := s.autoCloseEpics(ctx, tx, id); err != nil {
+		return err
+	}
 
 	return tx.Commit()
 }
@@ -870,7 +1075,7 @@ func (s *SQLiteStorage) CreateTombstone(ctx context.Context, id string, actor st
 		return fmt.Errorf("issue not found: %s", id)
 	}
 
-	tx, err := s.db.BeginTx(ctx, nil)
+	tx, err := s.db().BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -918,7 +1123,7 @@ func (s *SQLiteStorage) CreateTombstone(ctx context.Context, id string, actor st
 
 	// Invalidate blocked issues cache since status changed (bd-5qim)
 	// Tombstone issues don't block others, so this affects blocking calculations
-	if err := s.invalidateBlockedCache(ctx, tx); err != nil {
+	if err := s.invalidateBlockedCache(ctx, tx, id); err != nil {
 		return fmt.Errorf("failed to invalidate blocked cache: %w", err)
 	}
 
@@ -931,7 +1136,7 @@ func (s *SQLiteStorage) CreateTombstone(ctx context.Context, id string, actor st
 
 // DeleteIssue permanently removes an issue from the database
 func (s *SQLiteStorage) DeleteIssue(ctx context.Context, id string) error {
-	tx, err := s.db.BeginTx(ctx, nil)
+	tx, err := s.db().BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -969,6 +1174,10 @@ func (s *SQLiteStorage) DeleteIssue(ctx context.Context, id string) error {
 		return fmt.Errorf("issue not found: %s", id)
 	}
 
+	if err := syncFTS(ctx, tx, id); err != nil {
+		return err
+	}
+
 	if err := tx.Commit(); err != nil {
 		return wrapDBError("commit delete transaction", err)
 	}
@@ -996,7 +1205,7 @@ func (s *SQLiteStorage) DeleteIssues(ctx context.Context, ids []string, cascade
 		return &DeleteIssuesResult{}, nil
 	}
 
-	tx, err := s.db.BeginTx(ctx, nil)
+	tx, err := s.db().BeginTx(ctx, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -1305,13 +1514,81 @@ func (s *SQLiteStorage) findAllDependentsRecursive(ctx context.Context, tx *sql.
 
 // SearchIssues finds issues matching query and filters
 func (s *SQLiteStorage) SearchIssues(ctx context.Context, query string, filter types.IssueFilter) ([]*types.Issue, error) {
+	var result []*types.Issue
+	err := s.withReadRetry(ctx, func() error {
+		var err error
+		result, err = s.searchIssues(ctx, query, filter, nil)
+		return err
+	})
+	return result, err
+}
+
+// SearchIssuesPage is SearchIssues returning the page of results selected by
+// filter.Limit and filter.Offset along with the total number of matches, so
+// callers can show "1-50 of 1240" and page through large result sets. The
+// order (filter.SortBy) always ends with ID, so pages don't shuffle between
+// calls, though writes between calls can still shift issues across pages.
+func (s *SQLiteStorage) SearchIssuesPage(ctx context.Context, query string, filter types.IssueFilter) (*types.IssuePage, error) {
+	page := &types.IssuePage{Offset: filter.Offset}
+	err := s.withReadRetry(ctx, func() error {
+		var err error
+		page.Issues, err = s.searchIssues(ctx, query, filter, &page.Total)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// searchIssues is a single attempt at SearchIssues; busy errors are retried
+// by the caller. If total is not nil, it is set to the number of matches
+// ignoring Limit and Offset.
+func (s *SQLiteStorage) searchIssues(ctx context.Context, query string, filter types.IssueFilter, total *int) ([]*types.Issue, error) {
+	defer s.beginRead()()
+	ctx, cancel := s.withQueryTimeout(ctx)
+	defer cancel()
+
+	if err := checkFilterComplexity(ctx, s.db(), query, filter); err != nil {
+		return nil, err
+	}
+
+	var scoreExpr string
+	if sortsByReadyScore(filter.SortBy) {
+		scoreExpr = readyScoreSQL(readyScoreWeights(ctx, s.db()), s.now(), "issues")
+	}
+	orderSQL, err := buildSearchOrderBy(filter.SortBy, scoreExpr)
+	if err != nil {
+		return nil, err
+	}
+
+	selectSQL := `id, content_hash, title, description, design, acceptance_criteria, notes,
+		       status, priority, issue_type, assignee, estimated_minutes,
+		       created_at, updated_at, closed_at, external_ref, source_repo, close_reason,
+		       deleted_at, deleted_by, delete_reason, original_type, draft, percent_complete, external_blocked_reason, snoozed_until, due_date, version`
+	var columns []string
+	var withLabels bool
+	if len(filter.Fields) > 0 {
+		if columns, withLabels, err = projectionColumns(filter.Fields); err != nil {
+			return nil, err
+		}
+		selectSQL = strings.Join(columns, ", ")
+	}
+
 	whereClauses := []string{}
 	args := []interface{}{}
 
+	var search textSearch
 	if query != "" {
-		whereClauses = append(whereClauses, "(title LIKE ? OR description LIKE ? OR id LIKE ?)")
-		pattern := "%" + query + "%"
-		args = append(args, pattern, pattern, pattern)
+		var err error
+		if search, err = buildTextSearch(ctx, s.db(), query); err != nil {
+			return nil, err
+		}
+		whereClauses = append(whereClauses, search.where)
+		args = append(args, search.args...)
+		if len(filter.SortBy) == 0 {
+			orderSQL = search.order + orderSQL
+		}
 	}
 
 	if filter.TitleSearch != "" {
@@ -1329,6 +1606,14 @@ func (s *SQLiteStorage) SearchIssues(ctx context.Context, query string, filter t
 		whereClauses = append(whereClauses, "description LIKE ?")
 		args = append(args, "%"+filter.DescriptionContains+"%")
 	}
+	if len(filter.SectionContains) > 0 {
+		sectionClauses, sectionArgs, err := sectionContainsClauses(filter.SectionContains)
+		if err != nil {
+			return nil, err
+		}
+		whereClauses = append(whereClauses, sectionClauses...)
+		args = append(args, sectionArgs...)
+	}
 	if filter.NotesContains != "" {
 		whereClauses = append(whereClauses, "notes LIKE ?")
 		args = append(args, "%"+filter.NotesContains+"%")
@@ -1342,6 +1627,20 @@ func (s *SQLiteStorage) SearchIssues(ctx context.Context, query string, filter t
 		whereClauses = append(whereClauses, "status != ?")
 		args = append(args, types.StatusTombstone)
 	}
+	if filter.Status == nil && !filter.IncludeSnoozed {
+		// Snoozed issues stay out of sight until they wake
+		whereClauses = append(whereClauses, "status != ?")
+		args = append(args, types.StatusSnoozed)
+	}
+	if visible, visibleArgs := visibleClause(ctx, "issues.id"); visible != "" {
+		whereClauses = append(whereClauses, visible)
+		args = append(args, visibleArgs...)
+	}
+
+	// Drafts are local-only and hidden unless asked for
+	if !filter.IncludeDrafts {
+		whereClauses = append(whereClauses, "draft = 0")
+	}
 
 	if filter.Priority != nil {
 		whereClauses = append(whereClauses, "priority = ?")
@@ -1357,6 +1656,10 @@ func (s *SQLiteStorage) SearchIssues(ctx context.Context, query string, filter t
 		whereClauses = append(whereClauses, "priority <= ?")
 		args = append(args, *filter.PriorityMax)
 	}
+	if filter.MinPercent != nil {
+		whereClauses = append(whereClauses, "percent_complete >= ?")
+		args = append(args, *filter.MinPercent)
+	}
 
 	if filter.IssueType != nil {
 		whereClauses = append(whereClauses, "issue_type = ?")
@@ -1367,6 +1670,10 @@ func (s *SQLiteStorage) SearchIssues(ctx context.Context, query string, filter t
 		whereClauses = append(whereClauses, "assignee = ?")
 		args = append(args, *filter.Assignee)
 	}
+	if filter.Watcher != nil {
+		whereClauses = append(whereClauses, "id IN (SELECT issue_id FROM issue_watchers WHERE watcher = ?)")
+		args = append(args, *filter.Watcher)
+	}
 
 	// Date ranges
 	if filter.CreatedAfter != nil {
@@ -1394,6 +1701,26 @@ func (s *SQLiteStorage) SearchIssues(ctx context.Context, query string, filter t
 		args = append(args, filter.ClosedBefore.Format(time.RFC3339))
 	}
 
+	// Age ranges, relative to the store's clock
+	if filter.OlderThan > 0 {
+		whereClauses = append(whereClauses, "julianday(created_at) < julianday(?)")
+		args = append(args, s.now().Add(-filter.OlderThan).UTC().Format(time.RFC3339Nano))
+	}
+	if filter.StalerThan > 0 {
+		whereClauses = append(whereClauses, "julianday(updated_at) < julianday(?)")
+		args = append(args, s.now().Add(-filter.StalerThan).UTC().Format(time.RFC3339Nano))
+	}
+
+	// Scheduling; a NULL due_date never compares true
+	if filter.DueBefore != nil {
+		whereClauses = append(whereClauses, "julianday(due_date) < julianday(?)")
+		args = append(args, filter.DueBefore.UTC().Format(time.RFC3339Nano))
+	}
+	if filter.Overdue {
+		whereClauses = append(whereClauses, "julianday(due_date) < julianday(?) AND status NOT IN ('closed', 'tombstone')")
+		args = append(args, s.now().UTC().Format(time.RFC3339Nano))
+	}
+
 	// Empty/null checks
 	if filter.EmptyDescription {
 		whereClauses = append(whereClauses, "(description IS NULL OR description = '')")
@@ -1405,11 +1732,23 @@ func (s *SQLiteStorage) SearchIssues(ctx context.Context, query string, filter t
 		whereClauses = append(whereClauses, "id NOT IN (SELECT DISTINCT issue_id FROM labels)")
 	}
 
+	// Dependency graph position
+	if filter.IsRoot {
+		whereClauses = append(whereClauses, "NOT EXISTS (SELECT 1 FROM dependencies d WHERE d.issue_id = issues.id)")
+	}
+	if filter.IsLeaf {
+		whereClauses = append(whereClauses, "NOT EXISTS (SELECT 1 FROM dependencies d WHERE d.depends_on_id = issues.id)")
+	}
+
 	// Label filtering: issue must have ALL specified labels
+	var ciLabels bool
+	if len(filter.Labels) > 0 || len(filter.LabelsAny) > 0 || len(filter.LabelGlobs) > 0 {
+		ciLabels = s.caseInsensitiveLabels(ctx)
+	}
 	if len(filter.Labels) > 0 {
 		for _, label := range filter.Labels {
-			whereClauses = append(whereClauses, "id IN (SELECT issue_id FROM labels WHERE label = ?)")
-			args = append(args, label)
+			whereClauses = append(whereClauses, fmt.Sprintf("id IN (SELECT issue_id FROM labels WHERE %s = ?)", labelColumn("label", ciLabels)))
+			args = append(args, labelArg(label, ciLabels))
 		}
 	}
 
@@ -1418,9 +1757,15 @@ func (s *SQLiteStorage) SearchIssues(ctx context.Context, query string, filter t
 		placeholders := make([]string, len(filter.LabelsAny))
 		for i, label := range filter.LabelsAny {
 			placeholders[i] = "?"
-			args = append(args, label)
+			args = append(args, labelArg(label, ciLabels))
 		}
-		whereClauses = append(whereClauses, fmt.Sprintf("id IN (SELECT issue_id FROM labels WHERE label IN (%s))", strings.Join(placeholders, ", ")))
+		whereClauses = append(whereClauses, fmt.Sprintf("id IN (SELECT issue_id FROM labels WHERE %s IN (%s))", labelColumn("label", ciLabels), strings.Join(placeholders, ", ")))
+	}
+
+	// Label filtering (glob): issue must have a label matching EACH glob
+	for _, glob := range filter.LabelGlobs {
+		whereClauses = append(whereClauses, fmt.Sprintf(`id IN (SELECT issue_id FROM labels WHERE %s LIKE ? ESCAPE '\')`, labelColumn("label", ciLabels)))
+		args = append(args, labelGlobPattern(glob, ciLabels))
 	}
 
 	// ID filtering: match specific issue IDs
@@ -1438,29 +1783,111 @@ func (s *SQLiteStorage) SearchIssues(ctx context.Context, query string, filter t
 		whereSQL = "WHERE " + strings.Join(whereClauses, " AND ")
 	}
 
-	limitSQL := ""
-	if filter.Limit > 0 {
-		limitSQL = " LIMIT ?"
-		args = append(args, filter.Limit)
+	// Run through cached statements unless the SQL inlines values (the ready
+	// score's clock) or varies its placeholders with list lengths, as those
+	// shapes would rarely repeat
+	var q queryer = s.stmts()
+	if scoreExpr != "" || len(filter.IDs) > 0 || len(filter.LabelsAny) > 0 {
+		q = s.db()
 	}
 
+	if total != nil {
+		// #nosec G201 - safe SQL with controlled formatting
+		countSQL := fmt.Sprintf(`SELECT COUNT(*) FROM issues %s %s`, search.join, whereSQL)
+		if err := q.QueryRowContext(ctx, countSQL, args...).Scan(total); err != nil {
+			return nil, withContextError(ctx, fmt.Errorf("failed to count issues: %w", err))
+		}
+	}
+
+	limitSQL, limitArgs := searchLimitSQL(filter)
+	args = append(args, limitArgs...)
+
 	// #nosec G201 - safe SQL with controlled formatting
 	querySQL := fmt.Sprintf(`
-		SELECT id, content_hash, title, description, design, acceptance_criteria, notes,
-		       status, priority, issue_type, assignee, estimated_minutes,
-		       created_at, updated_at, closed_at, external_ref, source_repo, close_reason,
-		       deleted_at, deleted_by, delete_reason, original_type
+		SELECT %s
 		FROM issues
 		%s
-		ORDER BY priority ASC, created_at DESC
 		%s
-	`, whereSQL, limitSQL)
+		ORDER BY %s
+		%s
+	`, selectSQL, search.join, whereSQL, orderSQL, limitSQL)
 
-	rows, err := s.db.QueryContext(ctx, querySQL, args...)
+	rows, err := q.QueryContext(ctx, querySQL, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to search issues: %w", err)
+		return nil, withContextError(ctx, fmt.Errorf("failed to search issues: %w", err))
 	}
 	defer func() { _ = rows.Close() }()
 
-	return s.scanIssues(ctx, rows)
+	var issues []*types.Issue
+	if columns == nil {
+		issues, err = s.scanIssues(ctx, rows)
+	} else if issues, err = scanProjectedIssues(ctx, rows, columns); err == nil && withLabels {
+		var labelsMap map[string][]string
+		if labelsMap, err = s.GetLabelsForIssues(ctx, issueIDs(issues)); err == nil {
+			attachLabels(issues, labelsMap)
+		}
+	}
+	if err == nil && scoreExpr != "" {
+		err = fillReadyScores(ctx, s.db(), issues, scoreExpr, "issues")
+	}
+	// Canceling mid-scan interrupts the statement; report that as ctx's error
+	return issues, withContextError(ctx, err)
+}
+
+// searchLimitSQL returns the LIMIT clause, and its args, for filter.Limit
+// and filter.Offset
+func searchLimitSQL(filter types.IssueFilter) (string, []interface{}) {
+	if filter.Offset > 0 {
+		limit := filter.Limit
+		if limit <= 0 {
+			limit = -1 // SQLite needs a LIMIT for OFFSET; negative means none
+		}
+		return " LIMIT ? OFFSET ?", []interface{}{limit, filter.Offset}
+	}
+	if filter.Limit > 0 {
+		return " LIMIT ?", []interface{}{filter.Limit}
+	}
+	return "", nil
+}
+
+// buildSearchOrderBy returns the ORDER BY expression for SearchIssues. Sort
+// fields map directly to issues columns, except SortByReadyScore which uses
+// scoreExpr (see readyScoreSQL); ID is appended as a tie-breaker so results
+// are deterministic.
+func buildSearchOrderBy(keys []types.SortKey, scoreExpr string) (string, error) {
+	if len(keys) == 0 {
+		keys = types.DefaultSortKeys
+	}
+	if err := types.ValidateSortKeys(keys); err != nil {
+		return "", err
+	}
+
+	terms := make([]string, 0, len(keys)+1)
+	hasID := false
+	for _, key := range keys {
+		dir := "ASC"
+		if key.Descending {
+			dir = "DESC"
+		}
+		if key.Field == types.SortByReadyScore {
+			// Highest score is the most important, so it leads ascending
+			dir = "DESC"
+			if key.Descending {
+				dir = "ASC"
+			}
+			terms = append(terms, scoreExpr+" "+dir)
+			continue
+		}
+		if key.Field == types.SortByUpdatedAt {
+			// Legacy rows may lack updated_at; order them by creation instead
+			terms = append(terms, "COALESCE(NULLIF(updated_at, ''), created_at) "+dir)
+			continue
+		}
+		terms = append(terms, string(key.Field)+" "+dir)
+		hasID = hasID || key.Field == types.SortByID
+	}
+	if !hasID {
+		terms = append(terms, "id ASC")
+	}
+	return strings.Join(terms, ", "), nil
 }
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -13,6 +14,25 @@ import (
 // By default, shows both 'open' and 'in_progress' issues so epics/tasks
 // ready to close are visible (bd-165)
 func (s *SQLiteStorage) GetReadyWork(ctx context.Context, filter types.WorkFilter) ([]*types.Issue, error) {
+	var result []*types.Issue
+	err := s.withReadRetry(ctx, func() error {
+		var err error
+		result, err = s.getReadyWork(ctx, filter)
+		return err
+	})
+	return result, err
+}
+
+// getReadyWork is a single attempt at GetReadyWork; busy errors are retried by the caller
+func (s *SQLiteStorage) getReadyWork(ctx context.Context, filter types.WorkFilter) ([]*types.Issue, error) {
+	defer s.beginRead()()
+	ctx, cancel := s.withQueryTimeout(ctx)
+	defer cancel()
+	return s.readyWork(ctx, s.db(), filter)
+}
+
+// readyWork runs GetReadyWork's query on q
+func (s *SQLiteStorage) readyWork(ctx context.Context, q queryer, filter types.WorkFilter) ([]*types.Issue, error) {
 	whereClauses := []string{}
 	args := []interface{}{}
 
@@ -29,6 +49,10 @@ func (s *SQLiteStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 		args = append(args, *filter.Priority)
 	}
 
+	// Issues waiting on something outside beads are never ready, even with
+	// all their dependencies satisfied (see SetExternalBlock)
+	whereClauses = append(whereClauses, "i.external_blocked_reason IS NULL")
+
 	// Unassigned takes precedence over Assignee filter
 	if filter.Unassigned {
 		whereClauses = append(whereClauses, "(i.assignee IS NULL OR i.assignee = '')")
@@ -38,15 +62,19 @@ func (s *SQLiteStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 	}
 
 	// Label filtering (AND semantics)
+	var ciLabels bool
+	if len(filter.Labels) > 0 || len(filter.LabelsAny) > 0 {
+		ciLabels = s.caseInsensitiveLabels(ctx)
+	}
 	if len(filter.Labels) > 0 {
 		for _, label := range filter.Labels {
-			whereClauses = append(whereClauses, `
+			whereClauses = append(whereClauses, fmt.Sprintf(`
 				EXISTS (
 					SELECT 1 FROM labels
-					WHERE issue_id = i.id AND label = ?
+					WHERE issue_id = i.id AND %s = ?
 				)
-			`)
-			args = append(args, label)
+			`, labelColumn("label", ciLabels)))
+			args = append(args, labelArg(label, ciLabels))
 		}
 	}
 
@@ -59,14 +87,31 @@ func (s *SQLiteStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 		whereClauses = append(whereClauses, fmt.Sprintf(`
 			EXISTS (
 				SELECT 1 FROM labels
-				WHERE issue_id = i.id AND label IN (%s)
+				WHERE issue_id = i.id AND %s IN (%s)
 			)
-		`, strings.Join(placeholders, ",")))
+		`, labelColumn("label", ciLabels), strings.Join(placeholders, ",")))
 		for _, label := range filter.LabelsAny {
-			args = append(args, label)
+			args = append(args, labelArg(label, ciLabels))
 		}
 	}
 
+	// Skip issues another agent is working on (see ClaimIssue)
+	if filter.ExcludeClaimed {
+		whereClauses = append(whereClauses, `
+			NOT EXISTS (
+				SELECT 1 FROM issue_claims
+				WHERE issue_id = i.id AND julianday(expires_at) > julianday(?)
+			)
+		`)
+		args = append(args, time.Now().UTC())
+	}
+
+	// Leave out private issues the requesting actor doesn't own
+	if visible, visibleArgs := visibleClause(ctx, "i.id"); visible != "" {
+		whereClauses = append(whereClauses, visible)
+		args = append(args, visibleArgs...)
+	}
+
 	// Build WHERE clause properly
 	whereSQL := strings.Join(whereClauses, " AND ")
 
@@ -82,7 +127,11 @@ func (s *SQLiteStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 	if sortPolicy == "" {
 		sortPolicy = types.SortPolicyHybrid
 	}
-	orderBySQL := buildOrderByClause(sortPolicy)
+	var scoreExpr string
+	if sortPolicy == types.SortPolicyReadyScore {
+		scoreExpr = readyScoreSQL(readyScoreWeights(ctx, q), s.now(), "i")
+	}
+	orderBySQL := buildOrderByClause(sortPolicy, scoreExpr)
 
 	// Use blocked_issues_cache for performance (bd-5qim)
 	// This optimization replaces the recursive CTE that computed blocked issues on every query.
@@ -93,14 +142,14 @@ func (s *SQLiteStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 	//   - When any issue status changes
 	//   - When closing any issue
 	//
-	// Cache rebuild is fast (<50ms) and happens within the same transaction as the
+	// Only the affected issues are recomputed, within the same transaction as the
 	// triggering change, ensuring consistency. See blocked_cache.go for full details.
 	// #nosec G201 - safe SQL with controlled formatting
 	query := fmt.Sprintf(`
 		SELECT i.id, i.content_hash, i.title, i.description, i.design, i.acceptance_criteria, i.notes,
 		i.status, i.priority, i.issue_type, i.assignee, i.estimated_minutes,
 		i.created_at, i.updated_at, i.closed_at, i.external_ref, i.source_repo, i.close_reason,
-		i.deleted_at, i.deleted_by, i.delete_reason, i.original_type
+		i.deleted_at, i.deleted_by, i.delete_reason, i.original_type, i.draft, i.percent_complete, i.external_blocked_reason, i.snoozed_until, i.due_date, i.version
 		FROM issues i
 		WHERE %s
 		AND NOT EXISTS (
@@ -110,13 +159,17 @@ func (s *SQLiteStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 		%s
 	`, whereSQL, orderBySQL, limitSQL)
 
-	rows, err := s.db.QueryContext(ctx, query, args...)
+	rows, err := q.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get ready work: %w", err)
+		return nil, withContextError(ctx, fmt.Errorf("failed to get ready work: %w", err))
 	}
 	defer func() { _ = rows.Close() }()
 
-	return s.scanIssues(ctx, rows)
+	issues, err := scanIssuesOn(ctx, q, rows)
+	if err == nil && scoreExpr != "" {
+		err = fillReadyScores(ctx, q, issues, scoreExpr, "i")
+	}
+	return issues, withContextError(ctx, err)
 }
 
 // GetStaleIssues returns issues that haven't been updated recently
@@ -128,7 +181,7 @@ func (s *SQLiteStorage) GetStaleIssues(ctx context.Context, filter types.StaleFi
 			status, priority, issue_type, assignee, estimated_minutes,
 			created_at, updated_at, closed_at, external_ref, source_repo,
 			compaction_level, compacted_at, compacted_at_commit, original_size, close_reason,
-			deleted_at, deleted_by, delete_reason, original_type
+			deleted_at, deleted_by, delete_reason, original_type, draft, percent_complete, external_blocked_reason, snoozed_until, due_date, version
 		FROM issues
 		WHERE status != 'closed'
 		  AND datetime(updated_at) < datetime('now', '-' || ? || ' days')
@@ -141,6 +194,10 @@ func (s *SQLiteStorage) GetStaleIssues(ctx context.Context, filter types.StaleFi
 		query += " AND status = ?"
 		args = append(args, filter.Status)
 	}
+	if visible, visibleArgs := visibleClause(ctx, "issues.id"); visible != "" {
+		query += " AND " + visible
+		args = append(args, visibleArgs...)
+	}
 
 	query += " ORDER BY updated_at ASC"
 
@@ -150,9 +207,9 @@ func (s *SQLiteStorage) GetStaleIssues(ctx context.Context, filter types.StaleFi
 		args = append(args, filter.Limit)
 	}
 
-	rows, err := s.db.QueryContext(ctx, query, args...)
+	rows, err := s.db().QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query stale issues: %w", err)
+		return nil, withContextError(ctx, fmt.Errorf("failed to query stale issues: %w", err))
 	}
 	defer func() { _ = rows.Close() }()
 
@@ -181,11 +238,14 @@ func (s *SQLiteStorage) GetStaleIssues(ctx context.Context, filter types.StaleFi
 			&issue.Priority, &issue.IssueType, &assignee, &estimatedMinutes,
 			&issue.CreatedAt, &issue.UpdatedAt, &closedAt, &externalRef, &sourceRepo,
 			&compactionLevel, &compactedAt, &compactedAtCommit, &originalSize, &closeReason,
-			&deletedAt, &deletedBy, &deleteReason, &originalType,
+			&deletedAt, &deletedBy, &deleteReason, &originalType, &issue.Draft, &issue.PercentComplete, &issue.ExternalBlockedReason, &issue.SnoozedUntil, &issue.DueDate, &issue.Version,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan stale issue: %w", err)
 		}
+		if err := decodeDescription(&issue.Description); err != nil {
+			return nil, err
+		}
 
 		if contentHash.Valid {
 			issue.ContentHash = contentHash.String
@@ -235,20 +295,47 @@ func (s *SQLiteStorage) GetStaleIssues(ctx context.Context, filter types.StaleFi
 		issues = append(issues, &issue)
 	}
 
-	return issues, rows.Err()
+	return issues, withContextError(ctx, rows.Err())
 }
 
 // GetBlockedIssues returns issues that are blocked by dependencies or have status=blocked
 func (s *SQLiteStorage) GetBlockedIssues(ctx context.Context) ([]*types.BlockedIssue, error) {
+	var result []*types.BlockedIssue
+	err := s.withReadRetry(ctx, func() error {
+		var err error
+		result, err = s.getBlockedIssues(ctx)
+		return err
+	})
+	return result, err
+}
+
+// getBlockedIssues is a single attempt at GetBlockedIssues; busy errors are retried by the caller
+func (s *SQLiteStorage) getBlockedIssues(ctx context.Context) ([]*types.BlockedIssue, error) {
+	defer s.beginRead()()
+	ctx, cancel := s.withQueryTimeout(ctx)
+	defer cancel()
+	return blockedIssues(ctx, s.db())
+}
+
+// blockedIssues runs GetBlockedIssues' query on q
+func blockedIssues(ctx context.Context, q queryer) ([]*types.BlockedIssue, error) {
 	// Use UNION to combine:
 	// 1. Issues with open/in_progress/blocked status that have dependency blockers
 	// 2. Issues with status=blocked (even if they have no dependency blockers)
+	// 3. Issues blocked by something outside beads (external_blocked_reason)
 	// Use GROUP_CONCAT to get all blocker IDs in a single query (no N+1)
-	rows, err := s.db.QueryContext(ctx, `
+	visibleSQL := ""
+	visible, args := visibleClause(ctx, "i.id")
+	if visible != "" {
+		visibleSQL = "AND " + visible
+	}
+	// #nosec G201 - visibleSQL is built by visibleClause
+	rows, err := q.QueryContext(ctx, fmt.Sprintf(`
 		SELECT
 		    i.id, i.title, i.description, i.design, i.acceptance_criteria, i.notes,
 		    i.status, i.priority, i.issue_type, i.assignee, i.estimated_minutes,
 		    i.created_at, i.updated_at, i.closed_at, i.external_ref, i.source_repo,
+		    i.external_blocked_reason, i.snoozed_until, i.due_date, i.version,
 		    COALESCE(COUNT(d.depends_on_id), 0) as blocked_by_count,
 		    COALESCE(GROUP_CONCAT(d.depends_on_id, ','), '') as blocker_ids
 		FROM issues i
@@ -257,24 +344,26 @@ func (s *SQLiteStorage) GetBlockedIssues(ctx context.Context) ([]*types.BlockedI
 		    AND EXISTS (
 		        SELECT 1 FROM issues blocker
 		        WHERE blocker.id = d.depends_on_id
-		        AND blocker.status IN ('open', 'in_progress', 'blocked')
+		        AND blocker.status IN ('open', 'in_progress', 'blocked', 'snoozed')
 		    )
 		WHERE i.status IN ('open', 'in_progress', 'blocked')
 		  AND (
 		      i.status = 'blocked'
+		      OR i.external_blocked_reason IS NOT NULL
 		      OR EXISTS (
 		          SELECT 1 FROM dependencies d2
 		          JOIN issues blocker ON d2.depends_on_id = blocker.id
 		          WHERE d2.issue_id = i.id
 		            AND d2.type = 'blocks'
-		            AND blocker.status IN ('open', 'in_progress', 'blocked')
+		            AND blocker.status IN ('open', 'in_progress', 'blocked', 'snoozed')
 		      )
 		  )
+		  %s
 		GROUP BY i.id
 		ORDER BY i.priority ASC
-	`)
+	`, visibleSQL), args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get blocked issues: %w", err)
+		return nil, withContextError(ctx, fmt.Errorf("failed to get blocked issues: %w", err))
 	}
 	defer func() { _ = rows.Close() }()
 
@@ -292,12 +381,16 @@ func (s *SQLiteStorage) GetBlockedIssues(ctx context.Context) ([]*types.BlockedI
 			&issue.ID, &issue.Title, &issue.Description, &issue.Design,
 			&issue.AcceptanceCriteria, &issue.Notes, &issue.Status,
 			&issue.Priority, &issue.IssueType, &assignee, &estimatedMinutes,
-			&issue.CreatedAt, &issue.UpdatedAt, &closedAt, &externalRef, &sourceRepo, &issue.BlockedByCount,
+			&issue.CreatedAt, &issue.UpdatedAt, &closedAt, &externalRef, &sourceRepo,
+			&issue.ExternalBlockedReason, &issue.SnoozedUntil, &issue.DueDate, &issue.Version, &issue.BlockedByCount,
 			&blockerIDsStr,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan blocked issue: %w", err)
 		}
+		if err := decodeDescription(&issue.Description); err != nil {
+			return nil, err
+		}
 
 		if closedAt.Valid {
 			issue.ClosedAt = &closedAt.Time
@@ -325,13 +418,20 @@ func (s *SQLiteStorage) GetBlockedIssues(ctx context.Context) ([]*types.BlockedI
 
 		blocked = append(blocked, &issue)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, withContextError(ctx, fmt.Errorf("failed to get blocked issues: %w", err))
+	}
 
 	return blocked, nil
 }
 
-// buildOrderByClause generates the ORDER BY clause based on sort policy
-func buildOrderByClause(policy types.SortPolicy) string {
+// buildOrderByClause generates the ORDER BY clause based on sort policy.
+// scoreExpr is the readyScoreSQL expression used by SortPolicyReadyScore.
+func buildOrderByClause(policy types.SortPolicy, scoreExpr string) string {
 	switch policy {
+	case types.SortPolicyReadyScore:
+		return `ORDER BY ` + scoreExpr + ` DESC, i.priority ASC, i.created_at ASC, i.id ASC`
+
 	case types.SortPolicyPriority:
 		return `ORDER BY i.priority ASC, i.created_at ASC`
 
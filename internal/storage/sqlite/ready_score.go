@@ -0,0 +1,112 @@
+// Package sqlite - weighted ready score ordering
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ReadyScoreWeights returns the configured ready score weights, falling back
+// to types.DefaultReadyScoreWeights for any weight that is unset or invalid
+func (s *SQLiteStorage) ReadyScoreWeights(ctx context.Context) types.ReadyScoreWeights {
+	return readyScoreWeights(ctx, s.db())
+}
+
+// readyScoreWeights reads the weights on q
+func readyScoreWeights(ctx context.Context, q queryer) types.ReadyScoreWeights {
+	w := types.DefaultReadyScoreWeights
+	rows, err := q.QueryContext(ctx, `SELECT key, value FROM config WHERE key IN (?, ?, ?)`,
+		ReadyScorePriorityWeightConfigKey, ReadyScoreAgeWeightConfigKey, ReadyScoreDependentsWeightConfigKey)
+	if err != nil {
+		return w
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return w
+		}
+		weight, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || math.IsNaN(weight) || math.IsInf(weight, 0) {
+			continue
+		}
+		switch key {
+		case ReadyScorePriorityWeightConfigKey:
+			w.Priority = weight
+		case ReadyScoreAgeWeightConfigKey:
+			w.Age = weight
+		case ReadyScoreDependentsWeightConfigKey:
+			w.Dependents = weight
+		}
+	}
+	return w
+}
+
+// readyScoreSQL returns a SQL expression computing the ready score of the
+// issues row named table, matching types.ReadyScoreWeights.Score. Dependents
+// are open issues that depend on the row through a blocks dependency. Weights
+// and now are inlined as numeric literals so the expression can be used in
+// ORDER BY without threading arguments.
+func readyScoreSQL(w types.ReadyScoreWeights, now time.Time, table string) string {
+	f := func(v float64) string { return strconv.FormatFloat(v, 'f', -1, 64) }
+	// Julian day number of now, as returned by SQLite's julianday()
+	nowJD := float64(now.UnixNano())/float64(24*time.Hour) + 2440587.5
+	return fmt.Sprintf(`(%[1]s * (4 - %[4]s.priority)
+		+ %[2]s * (%[5]s - julianday(%[4]s.created_at))
+		+ %[3]s * (SELECT COUNT(*) FROM dependencies rs_d
+		           JOIN issues rs_i ON rs_i.id = rs_d.issue_id
+		           WHERE rs_d.depends_on_id = %[4]s.id AND rs_d.type = 'blocks'
+		             AND rs_i.status NOT IN ('closed', 'tombstone')))`,
+		f(w.Priority), f(w.Age), f(w.Dependents), table, f(nowJD))
+}
+
+// sortsByReadyScore reports whether keys include SortByReadyScore
+func sortsByReadyScore(keys []types.SortKey) bool {
+	for _, key := range keys {
+		if key.Field == types.SortByReadyScore {
+			return true
+		}
+	}
+	return false
+}
+
+// fillReadyScores sets ReadyScore on issues using scoreExpr from
+// readyScoreSQL, built for table name table
+func fillReadyScores(ctx context.Context, q queryer, issues []*types.Issue, scoreExpr, table string) error {
+	if len(issues) == 0 {
+		return nil
+	}
+	byID := make(map[string]*types.Issue, len(issues))
+	args := make([]interface{}, len(issues))
+	for i, issue := range issues {
+		byID[issue.ID] = issue
+		args[i] = issue.ID
+	}
+
+	// #nosec G201 -- scoreExpr and table are generated internally
+	rows, err := q.QueryContext(ctx, fmt.Sprintf(`SELECT %[1]s.id, %[2]s FROM issues %[1]s WHERE %[1]s.id IN (%[3]s)`,
+		table, scoreExpr, buildPlaceholders(len(issues))), args...)
+	if err != nil {
+		return wrapDBError("compute ready scores", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var id string
+		var score float64
+		if err := rows.Scan(&id, &score); err != nil {
+			return wrapDBError("scan ready score", err)
+		}
+		if issue := byID[id]; issue != nil {
+			issue.ReadyScore = &score
+		}
+	}
+	return wrapDBError("iterate ready scores", rows.Err())
+}
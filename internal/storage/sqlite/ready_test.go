@@ -596,7 +596,7 @@ func TestCompositeIndexExists(t *testing.T) {
 
 	// Query sqlite_master to check if the index exists
 	var indexName string
-	err := store.db.QueryRowContext(ctx, `
+	err := store.db().QueryRowContext(ctx, `
 		SELECT name FROM sqlite_master
 		WHERE type='index' AND name='idx_dependencies_depends_on_type'
 	`).Scan(&indexName)
@@ -646,7 +646,7 @@ func TestReadyIssuesViewMatchesGetReadyWork(t *testing.T) {
 	}
 
 	// Get ready work via VIEW
-	rows, err := store.db.QueryContext(ctx, `SELECT id FROM ready_issues ORDER BY id`)
+	rows, err := store.db().QueryContext(ctx, `SELECT id FROM ready_issues ORDER BY id`)
 	if err != nil {
 		t.Fatalf("Query ready_issues VIEW failed: %v", err)
 	}
@@ -912,7 +912,7 @@ func TestExplainQueryPlanReadyWork(t *testing.T) {
 		  i.created_at ASC
 	`
 
-	rows, err := store.db.QueryContext(ctx, query)
+	rows, err := store.db().QueryContext(ctx, query)
 	if err != nil {
 		t.Fatalf("EXPLAIN QUERY PLAN failed: %v", err)
 	}
@@ -1128,3 +1128,75 @@ func TestSortPolicyDefault(t *testing.T) {
 		t.Errorf("Expected P2 second, got P%d", ready[1].Priority)
 	}
 }
+
+func TestSortPolicyReadyScore(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// Same priority: the leaf is older, so priority/oldest ordering puts it
+	// first, but the hub blocks two open issues
+	leaf := &types.Issue{Title: "leaf", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	hub := &types.Issue{Title: "hub", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{leaf, hub} {
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	for i := 0; i < 2; i++ {
+		blocked := &types.Issue{Title: "blocked", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, blocked, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		dep := &types.Dependency{IssueID: blocked.ID, DependsOnID: hub.ID, Type: types.DepBlocks}
+		if err := store.AddDependency(ctx, dep, "test-user"); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+	}
+
+	ready, err := store.GetReadyWork(ctx, types.WorkFilter{SortPolicy: types.SortPolicyReadyScore})
+	if err != nil {
+		t.Fatalf("GetReadyWork failed: %v", err)
+	}
+	if len(ready) != 2 {
+		t.Fatalf("Expected 2 ready issues, got %d", len(ready))
+	}
+	if ready[0].ID != hub.ID {
+		t.Errorf("Expected hub %s first, got %s", hub.ID, ready[0].ID)
+	}
+	if ready[0].ReadyScore == nil || ready[1].ReadyScore == nil {
+		t.Fatal("Expected ready scores to be set")
+	}
+	// P2 with two dependents: 10*2 + 5*2, plus a negligible age term
+	if score := *ready[0].ReadyScore; score < 30 || score > 30.1 {
+		t.Errorf("Expected hub score ~30, got %f", score)
+	}
+
+	// The same ordering is available to SearchIssues
+	found, err := store.SearchIssues(ctx, "", types.IssueFilter{
+		IDs:    []string{leaf.ID, hub.ID},
+		SortBy: []types.SortKey{{Field: types.SortByReadyScore}},
+	})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(found) != 2 || found[0].ID != hub.ID || found[0].ReadyScore == nil {
+		t.Errorf("Expected hub first with a score, got %v", found)
+	}
+
+	// Weights come from config: ignore dependents and let age decide
+	if err := store.SetConfig(ctx, ReadyScoreDependentsWeightConfigKey, "0"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	if err := store.SetConfig(ctx, ReadyScoreAgeWeightConfigKey, "1e9"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	ready, err = store.GetReadyWork(ctx, types.WorkFilter{SortPolicy: types.SortPolicyReadyScore})
+	if err != nil {
+		t.Fatalf("GetReadyWork failed: %v", err)
+	}
+	if len(ready) != 2 || ready[0].ID != leaf.ID {
+		t.Errorf("Expected older leaf first with dependents weight 0, got %v", ready)
+	}
+}
@@ -0,0 +1,461 @@
+// Package sqlite - three-way reconciliation of beads databases
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// StorePath is the path to a beads database file
+type StorePath string
+
+// ReconcileConflict is a field both sides changed differently since the
+// base. Reconcile keeps the ours value; Field is the UpdateIssue key, so the
+// caller can resolve the conflict by updating it to the value it picks.
+type ReconcileConflict struct {
+	IssueID string      `json:"issue_id"`
+	Field   string      `json:"field"`
+	Base    interface{} `json:"base"`
+	Ours    interface{} `json:"ours"`
+	Theirs  interface{} `json:"theirs"`
+}
+
+// ReconcileResult reports what Reconcile changed in ours
+type ReconcileResult struct {
+	Added     []string            `json:"added"`     // Issues created only in theirs
+	Updated   []string            `json:"updated"`   // Issues that took changes from theirs
+	Deleted   []string            `json:"deleted"`   // Issues tombstoned in theirs
+	Events    int                 `json:"events"`    // History events copied from theirs
+	Conflicts []ReconcileConflict `json:"conflicts"` // Fields left for the caller to resolve
+}
+
+// reconcileField is an issue field merged as a unit. get returns a
+// comparable value, with pointers dereferenced and times in UTC, and set
+// copies the field from one issue to another.
+type reconcileField struct {
+	name string
+	get  func(issue *types.Issue) interface{}
+	set  func(dst, src *types.Issue)
+}
+
+var reconcileFields = []reconcileField{
+	{"title", func(i *types.Issue) interface{} { return i.Title }, func(d, s *types.Issue) { d.Title = s.Title }},
+	{"description", func(i *types.Issue) interface{} { return i.Description }, func(d, s *types.Issue) { d.Description = s.Description }},
+	{"design", func(i *types.Issue) interface{} { return i.Design }, func(d, s *types.Issue) { d.Design = s.Design }},
+	{"acceptance_criteria", func(i *types.Issue) interface{} { return i.AcceptanceCriteria }, func(d, s *types.Issue) { d.AcceptanceCriteria = s.AcceptanceCriteria }},
+	{"notes", func(i *types.Issue) interface{} { return i.Notes }, func(d, s *types.Issue) { d.Notes = s.Notes }},
+	// Closing sets closed_at and close_reason along with the status, so they
+	// merge together
+	{"status", func(i *types.Issue) interface{} { return string(i.Status) }, func(d, s *types.Issue) {
+		d.Status, d.ClosedAt, d.CloseReason = s.Status, s.ClosedAt, s.CloseReason
+	}},
+	{"priority", func(i *types.Issue) interface{} { return i.Priority }, func(d, s *types.Issue) { d.Priority = s.Priority }},
+	{"issue_type", func(i *types.Issue) interface{} { return string(i.IssueType) }, func(d, s *types.Issue) { d.IssueType = s.IssueType }},
+	{"assignee", func(i *types.Issue) interface{} { return i.Assignee }, func(d, s *types.Issue) { d.Assignee = s.Assignee }},
+	{"estimated_minutes", func(i *types.Issue) interface{} { return intValue(i.EstimatedMinutes) }, func(d, s *types.Issue) { d.EstimatedMinutes = s.EstimatedMinutes }},
+	{"external_ref", func(i *types.Issue) interface{} { return stringValue(i.ExternalRef) }, func(d, s *types.Issue) { d.ExternalRef = s.ExternalRef }},
+	{"percent_complete", func(i *types.Issue) interface{} { return i.PercentComplete }, func(d, s *types.Issue) { d.PercentComplete = s.PercentComplete }},
+	{"external_blocked_reason", func(i *types.Issue) interface{} { return stringValue(i.ExternalBlockedReason) }, func(d, s *types.Issue) { d.ExternalBlockedReason = s.ExternalBlockedReason }},
+	{"snoozed_until", func(i *types.Issue) interface{} { return timeValue(i.SnoozedUntil) }, func(d, s *types.Issue) { d.SnoozedUntil = s.SnoozedUntil }},
+	{"due_date", func(i *types.Issue) interface{} { return timeValue(i.DueDate) }, func(d, s *types.Issue) { d.DueDate = s.DueDate }},
+	{"draft", func(i *types.Issue) interface{} { return i.Draft }, func(d, s *types.Issue) { d.Draft = s.Draft }},
+}
+
+func intValue(p *int) interface{} {
+	if p == nil {
+		return nil
+	}
+	return *p
+}
+
+func stringValue(p *string) interface{} {
+	if p == nil {
+		return nil
+	}
+	return *p
+}
+
+func timeValue(p *time.Time) interface{} {
+	if p == nil {
+		return nil
+	}
+	return p.UTC().Format(time.RFC3339Nano)
+}
+
+// Reconcile three-way merges the issues of theirs into ours, given base,
+// their common ancestor, as a git merge driver would. Changes made on only
+// one side since base merge automatically, field by field; a field both
+// sides changed differently keeps the ours value and is reported in
+// Conflicts. Labels, dependencies and links merge as sets and comments are
+// unioned. An issue tombstoned on either side stays a tombstone in the
+// result, and rows are never dropped: an issue purged from one side since
+// base is left as it is in ours. The events of theirs are copied too, so
+// History shows the edits of both sides. base may be empty when there is no
+// common ancestor. ours is updated in a single transaction; base and theirs
+// are opened read-only, so they must already be at the current schema.
+func Reconcile(ctx context.Context, base, ours, theirs StorePath) (*ReconcileResult, error) {
+	if ours == "" || theirs == "" {
+		return nil, fmt.Errorf("reconcile needs both ours and theirs")
+	}
+	if ours == theirs || ours == base {
+		return nil, fmt.Errorf("reconcile needs distinct stores for ours, theirs and base")
+	}
+
+	baseIssues := map[string]*types.Issue{}
+	if base != "" {
+		var err error
+		if baseIssues, _, err = loadReconcileIssues(ctx, base, true); err != nil {
+			return nil, err
+		}
+	}
+	theirIssues, theirEvents, err := loadReconcileIssues(ctx, theirs, true)
+	if err != nil {
+		return nil, err
+	}
+	ourIssues, _, err := loadReconcileIssues(ctx, ours, false)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ReconcileResult{}
+	var changed []*types.Issue
+	ids := make([]string, 0, len(ourIssues)+len(theirIssues))
+	for id := range ourIssues {
+		ids = append(ids, id)
+	}
+	for id := range theirIssues {
+		if _, ok := ourIssues[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		b, o, t := baseIssues[id], ourIssues[id], theirIssues[id]
+		switch {
+		case t == nil:
+			// Unchanged in theirs, or purged there: keep ours
+		case o == nil:
+			// Purged in ours since base stays purged
+			if b == nil {
+				changed = append(changed, t)
+				result.Added = append(result.Added, id)
+			}
+		default:
+			merged, tombstoned, conflicts := reconcileIssue(b, o, t)
+			result.Conflicts = append(result.Conflicts, conflicts...)
+			if merged == nil {
+				continue
+			}
+			changed = append(changed, merged)
+			if tombstoned {
+				result.Deleted = append(result.Deleted, id)
+			} else {
+				result.Updated = append(result.Updated, id)
+			}
+		}
+	}
+	added := make(map[string]bool, len(result.Added))
+	for _, id := range result.Added {
+		added[id] = true
+	}
+	// Events of issues purged from ours can't be kept
+	var events []*types.Event
+	for _, event := range theirEvents {
+		if ourIssues[event.IssueID] != nil || added[event.IssueID] {
+			events = append(events, event)
+		}
+	}
+	if len(changed) == 0 && len(events) == 0 {
+		return result, nil
+	}
+
+	store, err := New(ctx, string(ours))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", ours, err)
+	}
+	defer func() { _ = store.Close() }()
+
+	err = store.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		t := tx.(*sqliteTxStorage)
+		// Added issues may depend on each other
+		if _, err := t.conn.ExecContext(ctx, `PRAGMA defer_foreign_keys = ON`); err != nil {
+			return wrapDBError("defer foreign keys", err)
+		}
+		for _, issue := range changed {
+			if err := restoreIssue(ctx, t, issue); err != nil {
+				return fmt.Errorf("failed to write %s: %w", issue.ID, err)
+			}
+		}
+		for _, issue := range changed {
+			for _, dep := range issue.Dependencies {
+				// Skip edges to issues purged from ours
+				if ourIssues[dep.DependsOnID] == nil && !added[dep.DependsOnID] {
+					continue
+				}
+				_, err := t.conn.ExecContext(ctx, `
+					INSERT OR IGNORE INTO dependencies (issue_id, depends_on_id, type, created_at, created_by, note)
+					VALUES (?, ?, ?, ?, ?, ?)
+				`, issue.ID, dep.DependsOnID, dep.Type, dep.CreatedAt, dep.CreatedBy, dep.Note)
+				if err != nil {
+					return wrapDBError(fmt.Sprintf("write dependency %s -> %s", issue.ID, dep.DependsOnID), err)
+				}
+			}
+		}
+		copied, err := copyEvents(ctx, t.conn, events)
+		if err != nil {
+			return err
+		}
+		result.Events = copied
+		return store.rebuildBlockedCache(ctx, t.conn)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// loadReconcileIssues reads every issue of the store at path, tombstones and
+// drafts included, with its labels, comments, links and dependencies, along
+// with its events. With readOnly, the store is opened read-only (see
+// openReadOnly) rather than migrated.
+func loadReconcileIssues(ctx context.Context, path StorePath, readOnly bool) (map[string]*types.Issue, []*types.Event, error) {
+	if _, err := os.Stat(string(path)); err != nil {
+		return nil, nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	open := New
+	if readOnly {
+		open = openReadOnly
+	}
+	s, err := open(ctx, string(path))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer func() { _ = s.Close() }()
+
+	issues, err := s.SearchIssues(ctx, "", types.IssueFilter{IncludeTombstones: true, IncludeDrafts: true, IncludeSnoozed: true})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query issues in %s: %w", path, err)
+	}
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	allDeps, err := s.GetAllDependencyRecords(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get dependencies in %s: %w", path, err)
+	}
+	allLabels, err := s.GetLabelsForIssues(ctx, ids)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get labels in %s: %w", path, err)
+	}
+	allComments, err := s.GetCommentsForIssues(ctx, ids)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get comments in %s: %w", path, err)
+	}
+	allLinks, err := s.GetLinksForIssues(ctx, ids)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get links in %s: %w", path, err)
+	}
+
+	byID := make(map[string]*types.Issue, len(issues))
+	for _, issue := range issues {
+		issue.Labels = allLabels[issue.ID]
+		issue.Comments = allComments[issue.ID]
+		issue.Links = allLinks[issue.ID]
+		issue.Dependencies = allDeps[issue.ID]
+		byID[issue.ID] = issue
+	}
+	events, err := s.EventsSince(ctx, 0, 0)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get events in %s: %w", path, err)
+	}
+	return byID, events, nil
+}
+
+// reconcileIssue merges an issue present in both ours and theirs. base is
+// nil if the issue was created on both sides independently. It returns the
+// merged issue, or nil if ours needs no change, and whether the merge
+// tombstoned it.
+func reconcileIssue(base, ours, theirs *types.Issue) (*types.Issue, bool, []ReconcileConflict) {
+	if ours.IsTombstone() {
+		return nil, false, nil
+	}
+	merged := *ours
+	if theirs.IsTombstone() {
+		// A deletion wins over edits on the other side, as in the JSONL merge
+		merged.Status = theirs.Status
+		merged.DeletedAt = theirs.DeletedAt
+		merged.DeletedBy = theirs.DeletedBy
+		merged.DeleteReason = theirs.DeleteReason
+		merged.OriginalType = theirs.OriginalType
+		merged.UpdatedAt = latestTime(ours.UpdatedAt, theirs.UpdatedAt)
+		merged.ContentHash = ""
+		return &merged, true, nil
+	}
+	if base == nil {
+		base = &types.Issue{}
+	}
+
+	var conflicts []ReconcileConflict
+	changed := false
+	for _, f := range reconcileFields {
+		b, o, t := f.get(base), f.get(ours), f.get(theirs)
+		switch {
+		case o == t, b == t:
+			// Same on both sides, or changed only in ours
+		case b == o:
+			f.set(&merged, theirs)
+			changed = true
+		default:
+			conflicts = append(conflicts, ReconcileConflict{IssueID: ours.ID, Field: f.name, Base: b, Ours: o, Theirs: t})
+		}
+	}
+
+	merged.Labels = mergeKeySets(base.Labels, ours.Labels, theirs.Labels)
+	merged.Dependencies = mergeDependencies(base.Dependencies, ours.Dependencies, theirs.Dependencies)
+	merged.Links = mergeLinks(base.Links, ours.Links, theirs.Links)
+	merged.Comments = mergeComments(ours.Comments, theirs.Comments)
+	changed = changed || !sameKeys(merged.Labels, ours.Labels) ||
+		!sameKeys(dependencyKeys(merged.Dependencies), dependencyKeys(ours.Dependencies)) ||
+		!sameKeys(linkKeys(merged.Links), linkKeys(ours.Links)) ||
+		len(merged.Comments) != len(ours.Comments)
+	if !changed {
+		return nil, false, conflicts
+	}
+	merged.UpdatedAt = latestTime(ours.UpdatedAt, theirs.UpdatedAt)
+	merged.ContentHash = ""
+	return &merged, false, conflicts
+}
+
+// mergeKeySets three-way merges sets of keys: a key added on either side is
+// kept and a key removed on either side is dropped. The result is sorted.
+func mergeKeySets(base, ours, theirs []string) []string {
+	inBase := make(map[string]bool, len(base))
+	for _, k := range base {
+		inBase[k] = true
+	}
+	inOurs := make(map[string]bool, len(ours))
+	for _, k := range ours {
+		inOurs[k] = true
+	}
+	inTheirs := make(map[string]bool, len(theirs))
+	for _, k := range theirs {
+		inTheirs[k] = true
+	}
+
+	var merged []string
+	for _, k := range ours {
+		if inTheirs[k] || !inBase[k] {
+			merged = append(merged, k)
+		}
+	}
+	for _, k := range theirs {
+		if !inOurs[k] && !inBase[k] {
+			merged = append(merged, k)
+		}
+	}
+	sort.Strings(merged)
+	return merged
+}
+
+func sameKeys(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a = append([]string(nil), a...)
+	b = append([]string(nil), b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func dependencyKeys(deps []*types.Dependency) []string {
+	keys := make([]string, len(deps))
+	for i, dep := range deps {
+		keys[i] = dep.DependsOnID
+	}
+	return keys
+}
+
+// mergeDependencies merges an issue's outgoing dependencies as a set keyed
+// by target, keeping the ours record of an edge on both sides
+func mergeDependencies(base, ours, theirs []*types.Dependency) []*types.Dependency {
+	byTarget := make(map[string]*types.Dependency, len(ours)+len(theirs))
+	for _, dep := range theirs {
+		byTarget[dep.DependsOnID] = dep
+	}
+	for _, dep := range ours {
+		byTarget[dep.DependsOnID] = dep
+	}
+	keys := mergeKeySets(dependencyKeys(base), dependencyKeys(ours), dependencyKeys(theirs))
+	merged := make([]*types.Dependency, len(keys))
+	for i, k := range keys {
+		merged[i] = byTarget[k]
+	}
+	return merged
+}
+
+func linkKeys(links []*types.Link) []string {
+	keys := make([]string, len(links))
+	for i, link := range links {
+		keys[i] = link.URL
+	}
+	return keys
+}
+
+// mergeLinks merges an issue's links as a set keyed by URL
+func mergeLinks(base, ours, theirs []*types.Link) []*types.Link {
+	byURL := make(map[string]*types.Link, len(ours)+len(theirs))
+	for _, link := range theirs {
+		byURL[link.URL] = link
+	}
+	for _, link := range ours {
+		byURL[link.URL] = link
+	}
+	keys := mergeKeySets(linkKeys(base), linkKeys(ours), linkKeys(theirs))
+	merged := make([]*types.Link, len(keys))
+	for i, k := range keys {
+		merged[i] = byURL[k]
+	}
+	return merged
+}
+
+// mergeComments appends the comments only theirs has to ours. Comments are
+// append-only, so there is nothing to remove.
+func mergeComments(ours, theirs []*types.Comment) []*types.Comment {
+	key := func(c *types.Comment) string {
+		return c.Author + "\x00" + c.Text + "\x00" + c.CreatedAt.UTC().Format(time.RFC3339Nano)
+	}
+	seen := make(map[string]bool, len(ours))
+	for _, c := range ours {
+		seen[key(c)] = true
+	}
+	merged := append([]*types.Comment(nil), ours...)
+	for _, c := range theirs {
+		if !seen[key(c)] {
+			merged = append(merged, c)
+		}
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].CreatedAt.Before(merged[j].CreatedAt)
+	})
+	return merged
+}
+
+func latestTime(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
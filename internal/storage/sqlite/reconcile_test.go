@@ -0,0 +1,333 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestReconcile(t *testing.T) {
+	ctx := context.Background()
+	tmpDir := t.TempDir()
+	basePath := filepath.Join(tmpDir, "base.db")
+	oursPath := filepath.Join(tmpDir, "ours.db")
+	theirsPath := filepath.Join(tmpDir, "theirs.db")
+
+	open := func(path string) *SQLiteStorage {
+		t.Helper()
+		s, err := New(ctx, path)
+		if err != nil {
+			t.Fatalf("failed to open %s: %v", path, err)
+		}
+		return s
+	}
+	create := func(s *SQLiteStorage, title string) *types.Issue {
+		t.Helper()
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := s.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		return issue
+	}
+	update := func(s *SQLiteStorage, id string, updates map[string]interface{}) {
+		t.Helper()
+		if err := s.UpdateIssue(ctx, id, updates, "test"); err != nil {
+			t.Fatalf("UpdateIssue failed: %v", err)
+		}
+	}
+
+	base := open(basePath)
+	if err := base.SetConfig(ctx, "issue_prefix", "bd"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	merged := create(base, "Merged")
+	conflicted := create(base, "Conflicted")
+	deleted := create(base, "Deleted")
+	if err := base.AddLabel(ctx, merged.ID, "shared", "test"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+	if err := base.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	content, err := os.ReadFile(basePath)
+	if err != nil {
+		t.Fatalf("failed to read base: %v", err)
+	}
+	for _, path := range []string{oursPath, theirsPath} {
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			t.Fatalf("failed to copy base: %v", err)
+		}
+	}
+
+	ours := open(oursPath)
+	update(ours, merged.ID, map[string]interface{}{"title": "Merged (ours)"})
+	update(ours, conflicted.ID, map[string]interface{}{"title": "Ours wins"})
+	update(ours, deleted.ID, map[string]interface{}{"notes": "Edited before the delete landed"})
+	if err := ours.AddLabel(ctx, merged.ID, "ours", "test"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+	if err := ours.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	theirs := open(theirsPath)
+	update(theirs, merged.ID, map[string]interface{}{"priority": 0})
+	update(theirs, conflicted.ID, map[string]interface{}{"title": "Theirs wins"})
+	if err := theirs.CreateTombstone(ctx, deleted.ID, "bob", "duplicate"); err != nil {
+		t.Fatalf("CreateTombstone failed: %v", err)
+	}
+	if err := theirs.AddLabel(ctx, merged.ID, "theirs", "test"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+	if err := theirs.RemoveLabel(ctx, merged.ID, "shared", "test"); err != nil {
+		t.Fatalf("RemoveLabel failed: %v", err)
+	}
+	added := create(theirs, "Added")
+	if err := theirs.AddDependency(ctx, &types.Dependency{IssueID: merged.ID, DependsOnID: added.ID, Type: types.DepBlocks}, "test"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+	if err := theirs.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	result, err := Reconcile(ctx, StorePath(basePath), StorePath(oursPath), StorePath(theirsPath))
+	if err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if !reflect.DeepEqual(result.Added, []string{added.ID}) {
+		t.Errorf("Expected %s to be added, got %v", added.ID, result.Added)
+	}
+	if !reflect.DeepEqual(result.Deleted, []string{deleted.ID}) {
+		t.Errorf("Expected %s to be deleted, got %v", deleted.ID, result.Deleted)
+	}
+	if !reflect.DeepEqual(result.Updated, []string{merged.ID}) {
+		t.Errorf("Expected %s to be updated, got %v", merged.ID, result.Updated)
+	}
+	want := []ReconcileConflict{{IssueID: conflicted.ID, Field: "title", Base: "Conflicted", Ours: "Ours wins", Theirs: "Theirs wins"}}
+	if !reflect.DeepEqual(result.Conflicts, want) {
+		t.Errorf("Expected conflicts %+v, got %+v", want, result.Conflicts)
+	}
+
+	ours = open(oursPath)
+	defer ours.Close()
+	got, err := ours.GetIssue(ctx, merged.ID)
+	if err != nil || got == nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.Title != "Merged (ours)" || got.Priority != 0 {
+		t.Errorf("Expected both sides' edits to merge, got title %q priority %d", got.Title, got.Priority)
+	}
+	if labels, _ := ours.GetLabels(ctx, merged.ID); !reflect.DeepEqual(labels, []string{"ours", "theirs"}) {
+		t.Errorf("Expected labels [ours theirs], got %v", labels)
+	}
+	if deps, _ := ours.GetDependencyRecords(ctx, merged.ID); len(deps) != 1 || deps[0].DependsOnID != added.ID {
+		t.Errorf("Expected the dependency on %s to merge, got %v", added.ID, deps)
+	}
+	if got, _ := ours.GetIssue(ctx, conflicted.ID); got == nil || got.Title != "Ours wins" {
+		t.Errorf("Expected the conflicting field to keep ours, got %+v", got)
+	}
+	got, err = ours.GetIssue(ctx, deleted.ID)
+	if err != nil || got == nil {
+		t.Fatalf("Expected %s to be kept as a tombstone (err %v)", deleted.ID, err)
+	}
+	if !got.IsTombstone() || got.DeletedBy != "bob" {
+		t.Errorf("Expected a tombstone deleted by bob, got %+v", got)
+	}
+	if got, _ := ours.GetIssue(ctx, added.ID); got == nil || got.Title != "Added" {
+		t.Errorf("Expected %s to be added, got %+v", added.ID, got)
+	}
+	// The history of theirs comes along
+	changes, err := ours.History(ctx, merged.ID)
+	if err != nil {
+		t.Fatalf("History failed: %v", err)
+	}
+	fromTheirs := false
+	for _, change := range changes {
+		if change.Field == "priority" {
+			fromTheirs = true
+		}
+	}
+	if result.Events == 0 || !fromTheirs {
+		t.Errorf("Expected the priority change of theirs in the history, got %+v", changes)
+	}
+
+	// Reconciling again is a no-op apart from the open conflict
+	result, err = Reconcile(ctx, StorePath(basePath), StorePath(oursPath), StorePath(theirsPath))
+	if err != nil {
+		t.Fatalf("Second Reconcile failed: %v", err)
+	}
+	if len(result.Added)+len(result.Updated)+len(result.Deleted)+result.Events != 0 || len(result.Conflicts) != 1 {
+		t.Errorf("Expected only the open conflict on a second run, got %+v", result)
+	}
+}
+
+// reconcileStores creates a base store with one issue and copies it to ours
+// and theirs, returning the three paths and the issue
+func reconcileStores(t *testing.T) (string, string, string, *types.Issue) {
+	t.Helper()
+	ctx := context.Background()
+	tmpDir := t.TempDir()
+	basePath := filepath.Join(tmpDir, "base.db")
+	oursPath := filepath.Join(tmpDir, "ours.db")
+	theirsPath := filepath.Join(tmpDir, "theirs.db")
+
+	base, err := New(ctx, basePath)
+	if err != nil {
+		t.Fatalf("failed to open base: %v", err)
+	}
+	if err := base.SetConfig(ctx, "issue_prefix", "bd"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	issue := &types.Issue{Title: "Shared", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := base.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := base.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	content, err := os.ReadFile(basePath)
+	if err != nil {
+		t.Fatalf("failed to read base: %v", err)
+	}
+	for _, path := range []string{oursPath, theirsPath} {
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			t.Fatalf("failed to copy base: %v", err)
+		}
+	}
+	return basePath, oursPath, theirsPath, issue
+}
+
+func TestReconcileOpensInputsReadOnly(t *testing.T) {
+	ctx := context.Background()
+	basePath, oursPath, theirsPath, issue := reconcileStores(t)
+
+	theirs, err := New(ctx, theirsPath)
+	if err != nil {
+		t.Fatalf("failed to open theirs: %v", err)
+	}
+	if err := theirs.UpdateIssue(ctx, issue.ID, map[string]interface{}{"priority": 0}, "test"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if err := theirs.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	// Inputs that can't be written still reconcile, and are left untouched
+	var before [][]byte
+	for _, path := range []string{basePath, theirsPath} {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", path, err)
+		}
+		before = append(before, content)
+		if err := os.Chmod(path, 0444); err != nil {
+			t.Fatalf("Chmod failed: %v", err)
+		}
+	}
+	result, err := Reconcile(ctx, StorePath(basePath), StorePath(oursPath), StorePath(theirsPath))
+	if err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if !reflect.DeepEqual(result.Updated, []string{issue.ID}) {
+		t.Errorf("Expected %s to be updated, got %v", issue.ID, result.Updated)
+	}
+	for i, path := range []string{basePath, theirsPath} {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", path, err)
+		}
+		if !reflect.DeepEqual(content, before[i]) {
+			t.Errorf("Expected %s to be left untouched", path)
+		}
+	}
+
+	// An input at an older schema is refused rather than migrated
+	if err := os.Chmod(theirsPath, 0644); err != nil {
+		t.Fatalf("Chmod failed: %v", err)
+	}
+	older, err := sql.Open("sqlite3", "file:"+theirsPath)
+	if err != nil {
+		t.Fatalf("failed to open theirs: %v", err)
+	}
+	if _, err := older.Exec(`DELETE FROM schema_migrations WHERE version = ?`, LatestSchemaVersion()); err != nil {
+		t.Fatalf("failed to roll back schema version: %v", err)
+	}
+	if _, err := Reconcile(ctx, StorePath(basePath), StorePath(oursPath), StorePath(theirsPath)); !errors.Is(err, ErrSchemaIncompatible) {
+		t.Errorf("Expected ErrSchemaIncompatible for an older input, got %v", err)
+	}
+	var version int
+	if err := older.QueryRow(`SELECT MAX(version) FROM schema_migrations`).Scan(&version); err != nil {
+		t.Fatalf("failed to read schema version: %v", err)
+	}
+	_ = older.Close()
+	if version != LatestSchemaVersion()-1 {
+		t.Errorf("Expected the refused input to stay at version %d, got %d", LatestSchemaVersion()-1, version)
+	}
+}
+
+func TestReconcileKeepsCommentIDs(t *testing.T) {
+	ctx := context.Background()
+	basePath, oursPath, theirsPath, issue := reconcileStores(t)
+
+	ours, err := New(ctx, oursPath)
+	if err != nil {
+		t.Fatalf("failed to open ours: %v", err)
+	}
+	for _, text := range []string{"First", "Second"} {
+		if _, err := ours.AddIssueComment(ctx, issue.ID, "alice", text); err != nil {
+			t.Fatalf("AddIssueComment failed: %v", err)
+		}
+	}
+	before, err := ours.GetIssueComments(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssueComments failed: %v", err)
+	}
+	if err := ours.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	theirs, err := New(ctx, theirsPath)
+	if err != nil {
+		t.Fatalf("failed to open theirs: %v", err)
+	}
+	if err := theirs.UpdateIssue(ctx, issue.ID, map[string]interface{}{"priority": 0}, "test"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if _, err := theirs.AddIssueComment(ctx, issue.ID, "bob", "From theirs"); err != nil {
+		t.Fatalf("AddIssueComment failed: %v", err)
+	}
+	if err := theirs.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	if _, err := Reconcile(ctx, StorePath(basePath), StorePath(oursPath), StorePath(theirsPath)); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+
+	ours, err = New(ctx, oursPath)
+	if err != nil {
+		t.Fatalf("failed to open ours: %v", err)
+	}
+	defer ours.Close()
+	after, err := ours.GetIssueComments(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssueComments failed: %v", err)
+	}
+	if len(after) != 3 || after[2].Text != "From theirs" {
+		t.Fatalf("Expected the comment of theirs added after ours, got %+v", after)
+	}
+	for i, comment := range before {
+		if after[i].ID != comment.ID || after[i].Text != comment.Text {
+			t.Errorf("Expected comment %q to keep ID %d, got %q with ID %d", comment.Text, comment.ID, after[i].Text, after[i].ID)
+		}
+	}
+	if after[2].ID == before[0].ID || after[2].ID == before[1].ID {
+		t.Errorf("Expected the comment of theirs to get its own ID, got %d", after[2].ID)
+	}
+}
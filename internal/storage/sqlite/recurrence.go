@@ -0,0 +1,247 @@
+// Package sqlite - recurring issues
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// RecurrenceActor is the actor recorded on issues created by GenerateRecurring
+const RecurrenceActor = "beads-recurrence"
+
+// SetRecurrence makes issue id recurring on schedule r, or stops it recurring
+// when r is nil. The issue acts as the template for the instances
+// GenerateRecurring creates. Instances can't recur themselves.
+func (s *SQLiteStorage) SetRecurrence(ctx context.Context, id string, r *types.Recurrence, actor string) error {
+	if r != nil {
+		if err := r.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		if err := requireIssue(ctx, tx, id); err != nil {
+			return err
+		}
+
+		var comment string
+		if r == nil {
+			result, err := tx.ExecContext(ctx, `DELETE FROM recurrences WHERE issue_id = ?`, id)
+			if err != nil {
+				return wrapDBError("clear recurrence", err)
+			}
+			if n, err := result.RowsAffected(); err != nil || n == 0 {
+				return err
+			}
+			comment = "Stopped recurring"
+		} else {
+			var templateID string
+			err := tx.QueryRowContext(ctx, `SELECT template_id FROM recurrence_instances WHERE issue_id = ?`, id).Scan(&templateID)
+			if err == nil {
+				return fmt.Errorf("issue %s is an instance of recurring issue %s and can't recur itself", id, templateID)
+			}
+			if err != sql.ErrNoRows {
+				return wrapDBError("check recurrence instance", err)
+			}
+
+			interval := r.Interval
+			if interval < 1 {
+				interval = 1
+			}
+			if _, err := tx.ExecContext(ctx, `
+				INSERT INTO recurrences (issue_id, freq, interval, next_due)
+				VALUES (?, ?, ?, ?)
+				ON CONFLICT (issue_id) DO UPDATE SET
+					freq = excluded.freq, interval = excluded.interval, next_due = excluded.next_due
+			`, id, r.Freq, interval, r.NextDue.UTC().Format(time.RFC3339Nano)); err != nil {
+				return wrapDBError("set recurrence", err)
+			}
+			comment = fmt.Sprintf("Recurs %s (every %d), next due %s", r.Freq, interval, r.NextDue.UTC().Format(time.RFC3339))
+		}
+
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO events (issue_id, event_type, actor, comment)
+			VALUES (?, ?, ?, ?)
+		`, id, types.EventUpdated, actor, comment); err != nil {
+			return fmt.Errorf("failed to record event: %w", err)
+		}
+		return nil
+	})
+}
+
+// getRecurrence returns the schedule of issue id on q, or nil if it doesn't
+// recur
+func getRecurrence(ctx context.Context, q queryer, id string) (*types.Recurrence, error) {
+	var r types.Recurrence
+	var nextDue string
+	err := q.QueryRowContext(ctx, `SELECT freq, interval, next_due FROM recurrences WHERE issue_id = ?`, id).
+		Scan(&r.Freq, &r.Interval, &nextDue)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, wrapDBError("get recurrence", err)
+	}
+	if r.NextDue, err = time.Parse(time.RFC3339Nano, nextDue); err != nil {
+		return nil, fmt.Errorf("invalid next due date %q for %s: %w", nextDue, id, err)
+	}
+	return &r, nil
+}
+
+// RecurrenceInstances returns the IDs of the instances generated from
+// recurring issue templateID, oldest due date first
+func (s *SQLiteStorage) RecurrenceInstances(ctx context.Context, templateID string) ([]string, error) {
+	rows, err := s.db().QueryContext(ctx, `
+		SELECT issue_id FROM recurrence_instances
+		WHERE template_id = ?
+		ORDER BY julianday(due), issue_id
+	`, templateID)
+	if err != nil {
+		return nil, wrapDBError("get recurrence instances", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, wrapDBError("scan recurrence instance", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, wrapDBError("iterate recurrence instances", rows.Err())
+}
+
+// recurringTemplate is a row of the recurrences table
+type recurringTemplate struct {
+	id         string
+	recurrence types.Recurrence
+}
+
+// GenerateRecurring creates the next instance of every recurring issue that
+// is due: its next due date is at or before now, or its current occurrence
+// (the latest instance, or the template itself before the first one) has
+// been closed. Instances copy the template's content and labels, start open,
+// and don't recur. The template's next due date then moves to the first one
+// after now, so periods missed while nothing ran are skipped rather than
+// backfilled.
+//
+// Everything runs in one IMMEDIATE transaction and each template gets at most
+// one instance per due date, so overlapping or repeated runs don't create
+// duplicates. Tombstoned templates are skipped. Returns the IDs created.
+func (s *SQLiteStorage) GenerateRecurring(ctx context.Context, now time.Time) ([]string, error) {
+	var created []string
+	err := s.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		t := tx.(*sqliteTxStorage)
+
+		templates, err := dueRecurringTemplates(ctx, t.conn, now)
+		if err != nil {
+			return err
+		}
+		for _, tmpl := range templates {
+			id, err := t.generateRecurrenceInstance(ctx, tmpl, now)
+			if err != nil {
+				return fmt.Errorf("failed to generate instance of %s: %w", tmpl.id, err)
+			}
+			created = append(created, id)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// dueRecurringTemplates returns the templates GenerateRecurring should create
+// an instance of at now
+func dueRecurringTemplates(ctx context.Context, q queryer, now time.Time) ([]recurringTemplate, error) {
+	rows, err := q.QueryContext(ctx, `
+		SELECT r.issue_id, r.freq, r.interval, r.next_due,
+		       COALESCE((SELECT i2.status FROM recurrence_instances ri
+		                 JOIN issues i2 ON i2.id = ri.issue_id
+		                 WHERE ri.template_id = r.issue_id
+		                 ORDER BY julianday(ri.due) DESC LIMIT 1), i.status)
+		FROM recurrences r
+		JOIN issues i ON i.id = r.issue_id
+		WHERE i.status != ?
+		ORDER BY r.issue_id
+	`, types.StatusTombstone)
+	if err != nil {
+		return nil, wrapDBError("get recurrences", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var due []recurringTemplate
+	for rows.Next() {
+		var tmpl recurringTemplate
+		var nextDue string
+		var currentStatus types.Status
+		if err := rows.Scan(&tmpl.id, &tmpl.recurrence.Freq, &tmpl.recurrence.Interval, &nextDue, &currentStatus); err != nil {
+			return nil, wrapDBError("scan recurrence", err)
+		}
+		if tmpl.recurrence.NextDue, err = time.Parse(time.RFC3339Nano, nextDue); err != nil {
+			return nil, fmt.Errorf("invalid next due date %q for %s: %w", nextDue, tmpl.id, err)
+		}
+		if !tmpl.recurrence.NextDue.After(now) || currentStatus == types.StatusClosed {
+			due = append(due, tmpl)
+		}
+	}
+	return due, wrapDBError("iterate recurrences", rows.Err())
+}
+
+// generateRecurrenceInstance creates the instance of tmpl due at its next due
+// date and advances the schedule past now
+func (t *sqliteTxStorage) generateRecurrenceInstance(ctx context.Context, tmpl recurringTemplate, now time.Time) (string, error) {
+	template, err := t.GetIssue(ctx, tmpl.id)
+	if err != nil {
+		return "", fmt.Errorf("failed to get issue: %w", err)
+	}
+	if template == nil {
+		return "", fmt.Errorf("issue %s: %w", tmpl.id, ErrNotFound)
+	}
+
+	labels := template.Labels
+	if labels == nil {
+		// Copy "no labels" as is rather than applying the default labels
+		labels = []string{}
+	}
+	instance := &types.Issue{
+		Title:              template.Title,
+		Description:        template.Description,
+		Design:             template.Design,
+		AcceptanceCriteria: template.AcceptanceCriteria,
+		Notes:              template.Notes,
+		Status:             types.StatusOpen,
+		Priority:           template.Priority,
+		IssueType:          template.IssueType,
+		Assignee:           template.Assignee,
+		EstimatedMinutes:   template.EstimatedMinutes,
+		Labels:             labels,
+	}
+	if err := t.CreateIssue(ctx, instance, RecurrenceActor); err != nil {
+		return "", err
+	}
+
+	due := tmpl.recurrence.NextDue.UTC()
+	if _, err := t.conn.ExecContext(ctx, `
+		INSERT INTO recurrence_instances (issue_id, template_id, due) VALUES (?, ?, ?)
+	`, instance.ID, tmpl.id, due.Format(time.RFC3339Nano)); err != nil {
+		return "", wrapDBError("record recurrence instance", err)
+	}
+
+	next := tmpl.recurrence.After(due)
+	for !next.After(now) {
+		next = tmpl.recurrence.After(next)
+	}
+	if _, err := t.conn.ExecContext(ctx, `UPDATE recurrences SET next_due = ? WHERE issue_id = ?`,
+		next.UTC().Format(time.RFC3339Nano), tmpl.id); err != nil {
+		return "", wrapDBError("advance recurrence", err)
+	}
+	return instance.ID, nil
+}
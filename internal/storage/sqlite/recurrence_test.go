@@ -0,0 +1,149 @@
+package sqlite
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestGenerateRecurring(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+	start := time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC)
+
+	template := &types.Issue{
+		Title:     "Rotate keys",
+		Status:    types.StatusOpen,
+		Priority:  2,
+		IssueType: types.TypeChore,
+		Labels:    []string{"ops"},
+	}
+	if err := store.CreateIssue(ctx, template, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	weekly := &types.Recurrence{Freq: types.RecurWeekly, NextDue: start}
+	if err := store.SetRecurrence(ctx, template.ID, weekly, "test"); err != nil {
+		t.Fatalf("SetRecurrence failed: %v", err)
+	}
+	got, err := store.GetIssue(ctx, template.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.Recurrence == nil || got.Recurrence.Freq != types.RecurWeekly || !got.Recurrence.NextDue.Equal(start) {
+		t.Errorf("Expected weekly recurrence due %v, got %+v", start, got.Recurrence)
+	}
+
+	// Nothing is due yet while the template is open
+	created, err := store.GenerateRecurring(ctx, start.Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("GenerateRecurring failed: %v", err)
+	}
+	if len(created) != 0 {
+		t.Errorf("Expected nothing before the due date, got %v", created)
+	}
+
+	// Once due, one instance is created; running again in the same window is a no-op
+	now := start.Add(time.Hour)
+	created, err = store.GenerateRecurring(ctx, now)
+	if err != nil {
+		t.Fatalf("GenerateRecurring failed: %v", err)
+	}
+	if len(created) != 1 {
+		t.Fatalf("Expected 1 instance, got %v", created)
+	}
+	again, err := store.GenerateRecurring(ctx, now)
+	if err != nil {
+		t.Fatalf("GenerateRecurring failed: %v", err)
+	}
+	if len(again) != 0 {
+		t.Errorf("Expected no duplicate instance, got %v", again)
+	}
+
+	instance, err := store.GetIssue(ctx, created[0])
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if instance.Title != "Rotate keys" || instance.IssueType != types.TypeChore || instance.Status != types.StatusOpen {
+		t.Errorf("Expected open copy of the template, got %+v", instance)
+	}
+	if !reflect.DeepEqual(instance.Labels, []string{"ops"}) {
+		t.Errorf("Expected labels [ops], got %v", instance.Labels)
+	}
+	if instance.Recurrence != nil {
+		t.Errorf("Expected instance not to recur, got %+v", instance.Recurrence)
+	}
+	if err := store.SetRecurrence(ctx, instance.ID, weekly, "test"); err == nil {
+		t.Error("Expected error making an instance recur")
+	}
+
+	got, err = store.GetIssue(ctx, template.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if want := start.AddDate(0, 0, 7); !got.Recurrence.NextDue.Equal(want) {
+		t.Errorf("Expected next due %v, got %v", want, got.Recurrence.NextDue)
+	}
+
+	// Closing the current instance creates the next one early
+	if err := store.CloseIssue(ctx, instance.ID, "done", "test"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	created, err = store.GenerateRecurring(ctx, now)
+	if err != nil {
+		t.Fatalf("GenerateRecurring failed: %v", err)
+	}
+	if len(created) != 1 {
+		t.Fatalf("Expected 1 instance after closing, got %v", created)
+	}
+	instances, err := store.RecurrenceInstances(ctx, template.ID)
+	if err != nil {
+		t.Fatalf("RecurrenceInstances failed: %v", err)
+	}
+	if !reflect.DeepEqual(instances, []string{instance.ID, created[0]}) {
+		t.Errorf("Expected instances [%s %s], got %v", instance.ID, created[0], instances)
+	}
+
+	// Missed periods are skipped, not backfilled
+	later := start.AddDate(0, 2, 0)
+	created, err = store.GenerateRecurring(ctx, later)
+	if err != nil {
+		t.Fatalf("GenerateRecurring failed: %v", err)
+	}
+	if len(created) != 1 {
+		t.Errorf("Expected 1 instance after a long gap, got %v", created)
+	}
+
+	// Clearing the recurrence stops generation
+	if err := store.SetRecurrence(ctx, template.ID, nil, "test"); err != nil {
+		t.Fatalf("SetRecurrence failed: %v", err)
+	}
+	created, err = store.GenerateRecurring(ctx, later.AddDate(1, 0, 0))
+	if err != nil {
+		t.Fatalf("GenerateRecurring failed: %v", err)
+	}
+	if len(created) != 0 {
+		t.Errorf("Expected no instances after clearing, got %v", created)
+	}
+}
+
+func TestSetRecurrenceValidation(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Chore", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeChore}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	for _, r := range []*types.Recurrence{
+		{Freq: "hourly", NextDue: time.Now()},
+		{Freq: types.RecurDaily, Interval: -1, NextDue: time.Now()},
+		{Freq: types.RecurDaily},
+	} {
+		if err := store.SetRecurrence(ctx, issue.ID, r, "test"); err == nil {
+			t.Errorf("Expected error for %+v", r)
+		}
+	}
+}
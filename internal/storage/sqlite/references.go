@@ -0,0 +1,184 @@
+// Package sqlite - dangling reference detection
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// DanglingReferences finds references from live issues to issue IDs that
+// don't exist or are tombstoned: dependency edges always, and, if scanText is
+// set, issue IDs mentioned in the description, design, acceptance criteria and
+// notes. Text scanning reads every issue's text and only recognizes IDs using
+// a prefix seen in the database (or the configured issue_prefix) whose suffix
+// contains a digit, so prose like "bd-style" isn't mistaken for an ID.
+// Results are sorted by referencing issue, then missing ID.
+func (s *SQLiteStorage) DanglingReferences(ctx context.Context, scanText bool) ([]types.DanglingRef, error) {
+	live := make(map[string]bool)
+	rows, err := s.db().QueryContext(ctx, `SELECT id FROM issues WHERE status != 'tombstone'`)
+	if err != nil {
+		return nil, wrapDBError("list issue IDs", err)
+	}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			_ = rows.Close()
+			return nil, wrapDBError("scan issue ID", err)
+		}
+		live[id] = true
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, wrapDBError("iterate issue IDs", err)
+	}
+
+	refs, err := s.danglingDependencies(ctx, live)
+	if err != nil {
+		return nil, err
+	}
+	if scanText {
+		textRefs, err := s.danglingTextReferences(ctx, live)
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, textRefs...)
+	}
+
+	sort.SliceStable(refs, func(i, j int) bool {
+		if refs[i].IssueID != refs[j].IssueID {
+			return refs[i].IssueID < refs[j].IssueID
+		}
+		return refs[i].MissingID < refs[j].MissingID
+	})
+	return refs, nil
+}
+
+// danglingDependencies returns dependency edges from live issues to IDs not in live
+func (s *SQLiteStorage) danglingDependencies(ctx context.Context, live map[string]bool) ([]types.DanglingRef, error) {
+	rows, err := s.db().QueryContext(ctx, `SELECT issue_id, depends_on_id, type FROM dependencies ORDER BY issue_id, depends_on_id`)
+	if err != nil {
+		return nil, wrapDBError("list dependencies", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var refs []types.DanglingRef
+	for rows.Next() {
+		var issueID, dependsOnID, depType string
+		if err := rows.Scan(&issueID, &dependsOnID, &depType); err != nil {
+			return nil, wrapDBError("scan dependency", err)
+		}
+		if live[issueID] && !live[dependsOnID] {
+			refs = append(refs, types.DanglingRef{
+				IssueID:   issueID,
+				MissingID: dependsOnID,
+				Source:    types.DanglingRefDependency,
+				Field:     depType,
+			})
+		}
+	}
+	return refs, wrapDBError("iterate dependencies", rows.Err())
+}
+
+// danglingTextReferences returns issue IDs mentioned in the text fields of
+// live issues that are not in live
+func (s *SQLiteStorage) danglingTextReferences(ctx context.Context, live map[string]bool) ([]types.DanglingRef, error) {
+	prefixes := make(map[string]bool)
+	if prefix, err := s.GetConfig(ctx, "issue_prefix"); err == nil && prefix != "" {
+		prefixes[strings.TrimSuffix(prefix, "-")] = true
+	}
+	for id := range live {
+		base, _, _ := strings.Cut(id, ".")
+		if i := strings.LastIndex(base, "-"); i > 0 {
+			prefixes[base[:i]] = true
+		}
+	}
+	if len(prefixes) == 0 {
+		return nil, nil
+	}
+	pattern := issueMentionPattern(prefixes)
+
+	rows, err := s.db().QueryContext(ctx, `
+		SELECT id, description, design, acceptance_criteria, notes
+		FROM issues
+		WHERE status != 'tombstone'
+		ORDER BY id
+	`)
+	if err != nil {
+		return nil, wrapDBError("list issue text", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var refs []types.DanglingRef
+	for rows.Next() {
+		var id, description, design, acceptance, notes string
+		if err := rows.Scan(&id, &description, &design, &acceptance, &notes); err != nil {
+			return nil, wrapDBError("scan issue text", err)
+		}
+		if err := decodeDescription(&description); err != nil {
+			return nil, fmt.Errorf("issue %s: %w", id, err)
+		}
+
+		seen := make(map[string]bool)
+		for _, field := range []struct{ name, text string }{
+			{"description", description},
+			{"design", design},
+			{"acceptance_criteria", acceptance},
+			{"notes", notes},
+		} {
+			for _, mention := range findIssueMentions(pattern, field.text) {
+				if live[mention] || mention == id || seen[mention] {
+					continue
+				}
+				seen[mention] = true
+				refs = append(refs, types.DanglingRef{
+					IssueID:   id,
+					MissingID: mention,
+					Source:    types.DanglingRefText,
+					Field:     field.name,
+				})
+			}
+		}
+	}
+	return refs, wrapDBError("iterate issue text", rows.Err())
+}
+
+// issueMentionPattern matches issue IDs with any of prefixes; the ID is the
+// first submatch
+func issueMentionPattern(prefixes map[string]bool) *regexp.Regexp {
+	quoted := make([]string, 0, len(prefixes))
+	for prefix := range prefixes {
+		quoted = append(quoted, regexp.QuoteMeta(prefix))
+	}
+	// Longest first so "beads-vscode" wins over "beads"
+	sort.Slice(quoted, func(i, j int) bool { return len(quoted[i]) > len(quoted[j]) })
+	return regexp.MustCompile(`(?:^|[^A-Za-z0-9_.-])((?:` + strings.Join(quoted, "|") + `)-[0-9a-z]+(?:\.[0-9]+)*)`)
+}
+
+// findIssueMentions returns the issue IDs matched by pattern in text, skipping
+// matches that run into a longer word or have no digit in their suffix
+func findIssueMentions(pattern *regexp.Regexp, text string) []string {
+	var mentions []string
+	for _, m := range pattern.FindAllStringSubmatchIndex(text, -1) {
+		start, end := m[2], m[3]
+		if end < len(text) && isIDChar(text[end]) {
+			continue
+		}
+		id := text[start:end]
+		base, _, _ := strings.Cut(id, ".")
+		if suffix := base[strings.LastIndex(base, "-")+1:]; !strings.ContainsAny(suffix, "0123456789") {
+			continue
+		}
+		mentions = append(mentions, id)
+	}
+	return mentions
+}
+
+// isIDChar reports whether c can continue an issue ID token
+func isIDChar(c byte) bool {
+	return c == '_' || c == '-' || (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
@@ -0,0 +1,60 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestDanglingReferences(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	newIssue := func(id, description string) *types.Issue {
+		issue := &types.Issue{ID: id, Title: id, Description: description, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		return issue
+	}
+	newIssue("bd-1", "See bd-2 and bd-999, not bd-style or bd-1x-y. Also bd-3.")
+	newIssue("bd-2", "")
+	newIssue("bd-3", "Child bd-2.1 was dropped")
+
+	if err := store.AddDependency(ctx, &types.Dependency{IssueID: "bd-2", DependsOnID: "bd-3", Type: types.DepBlocks}, "test"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+	if err := store.CreateTombstone(ctx, "bd-3", "test", "gone"); err != nil {
+		t.Fatalf("CreateTombstone failed: %v", err)
+	}
+
+	refs, err := store.DanglingReferences(ctx, false)
+	if err != nil {
+		t.Fatalf("DanglingReferences failed: %v", err)
+	}
+	want := []types.DanglingRef{
+		{IssueID: "bd-2", MissingID: "bd-3", Source: types.DanglingRefDependency, Field: string(types.DepBlocks)},
+	}
+	if len(refs) != len(want) || refs[0] != want[0] {
+		t.Errorf("Expected %v without text scanning, got %v", want, refs)
+	}
+
+	refs, err = store.DanglingReferences(ctx, true)
+	if err != nil {
+		t.Fatalf("DanglingReferences failed: %v", err)
+	}
+	want = []types.DanglingRef{
+		{IssueID: "bd-1", MissingID: "bd-3", Source: types.DanglingRefText, Field: "description"},
+		{IssueID: "bd-1", MissingID: "bd-999", Source: types.DanglingRefText, Field: "description"},
+		{IssueID: "bd-2", MissingID: "bd-3", Source: types.DanglingRefDependency, Field: string(types.DepBlocks)},
+	}
+	if len(refs) != len(want) {
+		t.Fatalf("Expected %d dangling references, got %d: %v", len(want), len(refs), refs)
+	}
+	for i := range want {
+		if refs[i] != want[i] {
+			t.Errorf("Expected %v at %d, got %v", want[i], i, refs[i])
+		}
+	}
+}
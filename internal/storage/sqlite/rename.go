@@ -0,0 +1,188 @@
+// Package sqlite - renaming issues while keeping references intact
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// issueReferences lists every table and column holding an issue ID. RenameIssue
+// repoints all of them; add new referencing columns here.
+var issueReferences = []struct{ table, column string }{
+	{"dependencies", "issue_id"},
+	{"dependencies", "depends_on_id"},
+	{"labels", "issue_id"},
+	{"comments", "issue_id"},
+	{"events", "issue_id"},
+	{"dirty_issues", "issue_id"},
+	{"export_hashes", "issue_id"},
+	{"child_counters", "parent_id"},
+	{"blocked_issues_cache", "issue_id"},
+	{"issue_snapshots", "issue_id"},
+	{"compaction_snapshots", "issue_id"},
+	{"issue_claims", "issue_id"},
+	{"issue_links", "issue_id"},
+	{"recurrences", "issue_id"},
+	{"recurrence_instances", "issue_id"},
+	{"recurrence_instances", "template_id"},
+	{"issue_aliases", "issue_id"},
+	{"idempotency_keys", "issue_id"},
+	{"issue_acl", "issue_id"},
+	{"issue_sections", "issue_id"},
+	{"issue_watchers", "issue_id"},
+}
+
+// RenameIssue changes the ID of issue oldID to newID in one transaction,
+// repointing every dependency, label, comment, event, claim, link and other
+// reference to it. Event values recording dependency edges are rewritten too,
+// so undo keeps working.
+//
+// A tombstone is left at oldID so clones importing the next export drop the
+// old row, and oldID becomes an alias of newID so ResolveID still finds the
+// issue. Renaming onto an ID that exists, even as a tombstone, fails with
+// ErrDuplicateID.
+func (s *SQLiteStorage) RenameIssue(ctx context.Context, oldID, newID string, actor string) error {
+	if newID == "" || strings.ContainsAny(newID, " \t\n") {
+		return fmt.Errorf("%w: %q", ErrInvalidID, newID)
+	}
+	if newID == oldID {
+		return fmt.Errorf("issue %s already has that ID", oldID)
+	}
+
+	issue, err := s.GetIssue(ctx, oldID)
+	if err != nil {
+		return wrapDBError("get issue for rename", err)
+	}
+	if issue == nil || issue.Status == types.StatusTombstone {
+		return fmt.Errorf("issue %s: %w", oldID, ErrNotFound)
+	}
+
+	// Get exclusive connection to ensure PRAGMA applies. Foreign keys are off
+	// while the primary key and its references move independently.
+	conn, err := s.db().Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get connection: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	if _, err := conn.ExecContext(ctx, `PRAGMA foreign_keys = OFF`); err != nil {
+		return fmt.Errorf("failed to disable foreign keys: %w", err)
+	}
+	defer func() { _, _ = conn.ExecContext(context.Background(), `PRAGMA foreign_keys = ON`) }()
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var taken bool
+	err = tx.QueryRowContext(ctx, `
+		SELECT EXISTS(SELECT 1 FROM issues WHERE id = ?) OR EXISTS(SELECT 1 FROM issue_aliases WHERE alias = ?)
+	`, newID, newID).Scan(&taken)
+	if err != nil {
+		return wrapDBError("check new issue ID", err)
+	}
+	if taken {
+		return fmt.Errorf("issue %s: %w", newID, ErrDuplicateID)
+	}
+
+	now := s.now()
+	result, err := tx.ExecContext(ctx, `UPDATE issues SET id = ?, updated_at = ? WHERE id = ?`, newID, now, oldID)
+	if err != nil {
+		return wrapDBError("rename issue", err)
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return wrapDBError("rename issue", err)
+	} else if n == 0 {
+		return fmt.Errorf("issue %s: %w", oldID, ErrNotFound)
+	}
+
+	for _, ref := range issueReferences {
+		query := fmt.Sprintf(`UPDATE %s SET %s = ? WHERE %s = ?`, ref.table, ref.column, ref.column) // #nosec G201 - fixed table and column names
+		if _, err := tx.ExecContext(ctx, query, newID, oldID); err != nil {
+			return wrapDBErrorf(err, "update %s.%s", ref.table, ref.column)
+		}
+	}
+
+	// Event values hold JSON (dependency edges, snapshots); match the quoted
+	// ID so other IDs containing it are left alone
+	for _, column := range []string{"old_value", "new_value"} {
+		query := fmt.Sprintf(`UPDATE events SET %s = replace(%s, ?, ?) WHERE instr(%s, ?) > 0`, column, column, column) // #nosec G201 - fixed column names
+		if _, err := tx.ExecContext(ctx, query, `"`+oldID+`"`, `"`+newID+`"`, `"`+oldID+`"`); err != nil {
+			return wrapDBErrorf(err, "update events.%s", column)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `INSERT INTO issue_aliases (alias, issue_id, created_at) VALUES (?, ?, ?)`,
+		oldID, newID, now); err != nil {
+		return wrapDBError("record issue alias", err)
+	}
+
+	tombstone := &types.Issue{
+		ID:           oldID,
+		Title:        issue.Title,
+		Status:       types.StatusTombstone,
+		Priority:     issue.Priority,
+		IssueType:    issue.IssueType,
+		CreatedAt:    issue.CreatedAt,
+		UpdatedAt:    now,
+		DeletedAt:    &now,
+		DeletedBy:    actor,
+		DeleteReason: fmt.Sprintf("renamed to %s", newID),
+		OriginalType: string(issue.IssueType),
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO issues (id, content_hash, title, status, priority, issue_type, created_at, updated_at,
+		                    deleted_at, deleted_by, delete_reason, original_type)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, tombstone.ID, tombstone.ComputeContentHash(), tombstone.Title, tombstone.Status, tombstone.Priority,
+		tombstone.IssueType, tombstone.CreatedAt, tombstone.UpdatedAt, tombstone.DeletedAt, tombstone.DeletedBy,
+		tombstone.DeleteReason, tombstone.OriginalType)
+	if err != nil {
+		return wrapDBError("create tombstone for renamed issue", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, old_value, new_value, comment)
+		VALUES (?, 'renamed', ?, ?, ?, ?)
+	`, newID, actor, oldID, newID, fmt.Sprintf("Renamed from %s", oldID))
+	if err != nil {
+		return fmt.Errorf("failed to record rename event: %w", err)
+	}
+
+	if err := markIssuesDirtyTx(ctx, tx, []string{oldID, newID}); err != nil {
+		return wrapDBError("mark renamed issue dirty", err)
+	}
+	if err := syncFTS(ctx, tx, oldID, newID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ResolveID returns the current ID of the issue known as id: id itself if an
+// issue has it, or the ID it was renamed to if id is an alias (see
+// RenameIssue). It returns ErrNotFound if neither applies.
+func (s *SQLiteStorage) ResolveID(ctx context.Context, id string) (string, error) {
+	var issueID string
+	err := s.db().QueryRowContext(ctx, `SELECT issue_id FROM issue_aliases WHERE alias = ?`, id).Scan(&issueID)
+	if err == nil {
+		return issueID, nil
+	} else if err != sql.ErrNoRows {
+		return "", wrapDBError("resolve issue alias", err)
+	}
+
+	var exists bool
+	if err := s.db().QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM issues WHERE id = ?)`, id).Scan(&exists); err != nil {
+		return "", wrapDBError("check issue", err)
+	}
+	if !exists {
+		return "", fmt.Errorf("issue %s: %w", id, ErrNotFound)
+	}
+	return id, nil
+}
@@ -0,0 +1,187 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestRenameIssue(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	newIssue := func(title string) *types.Issue {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		return issue
+	}
+	target := newIssue("Target")
+	dependent := newIssue("Dependent")
+	blocker := newIssue("Blocker")
+	oldID := target.ID
+	newID := "bd-renamed"
+
+	for _, dep := range []*types.Dependency{
+		{IssueID: dependent.ID, DependsOnID: oldID, Type: types.DepBlocks},
+		{IssueID: oldID, DependsOnID: blocker.ID, Type: types.DepBlocks},
+	} {
+		if err := store.AddDependency(ctx, dep, "test"); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+	}
+	if err := store.AddLabel(ctx, oldID, "backend", "test"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+	if _, err := store.AddIssueComment(ctx, oldID, "alice", "note"); err != nil {
+		t.Fatalf("AddIssueComment failed: %v", err)
+	}
+	if _, err := store.AddLink(ctx, oldID, "https://example.com/pr/1", "PR", "", "test"); err != nil {
+		t.Fatalf("AddLink failed: %v", err)
+	}
+	if _, err := store.ClaimIssue(ctx, oldID, "agent-1", time.Hour); err != nil {
+		t.Fatalf("ClaimIssue failed: %v", err)
+	}
+	if err := store.SetRecurrence(ctx, oldID, &types.Recurrence{Freq: types.RecurDaily, NextDue: time.Now().Add(time.Hour)}, "test"); err != nil {
+		t.Fatalf("SetRecurrence failed: %v", err)
+	}
+	if _, err := store.GetNextChildID(ctx, oldID); err != nil {
+		t.Fatalf("GetNextChildID failed: %v", err)
+	}
+
+	if err := store.RenameIssue(ctx, oldID, newID, "test"); err != nil {
+		t.Fatalf("RenameIssue failed: %v", err)
+	}
+
+	// Nothing but the tombstone and its dirty mark still points at the old ID
+	for _, ref := range issueReferences {
+		if ref.table == "dirty_issues" {
+			continue
+		}
+		var count int
+		query := fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE %s = ?`, ref.table, ref.column)
+		if err := store.db().QueryRowContext(ctx, query, oldID).Scan(&count); err != nil {
+			t.Fatalf("count %s.%s failed: %v", ref.table, ref.column, err)
+		}
+		if count != 0 {
+			t.Errorf("Expected no %s.%s rows for %s, got %d", ref.table, ref.column, oldID, count)
+		}
+	}
+	var stale int
+	if err := store.db().QueryRowContext(ctx, `SELECT COUNT(*) FROM events WHERE instr(old_value, ?) > 0 OR instr(new_value, ?) > 0`,
+		`"`+oldID+`"`, `"`+oldID+`"`).Scan(&stale); err != nil {
+		t.Fatalf("count event values failed: %v", err)
+	}
+	if stale != 0 {
+		t.Errorf("Expected no event values naming %s, got %d", oldID, stale)
+	}
+
+	renamed, err := store.GetIssue(ctx, newID)
+	if err != nil || renamed == nil {
+		t.Fatalf("GetIssue(%s) failed: %v", newID, err)
+	}
+	if renamed.Title != "Target" || renamed.Recurrence == nil || len(renamed.Labels) != 1 {
+		t.Errorf("Expected renamed issue with its label and recurrence, got %+v", renamed)
+	}
+	if comments, _ := store.GetIssueComments(ctx, newID); len(comments) != 1 {
+		t.Errorf("Expected 1 comment on %s, got %d", newID, len(comments))
+	}
+	if links, _ := store.GetLinks(ctx, newID); len(links) != 1 {
+		t.Errorf("Expected 1 link on %s, got %d", newID, len(links))
+	}
+	if claim, _ := store.GetClaim(ctx, newID); claim == nil || claim.Agent != "agent-1" {
+		t.Errorf("Expected claim by agent-1 on %s, got %+v", newID, claim)
+	}
+	deps, err := store.GetDependencyRecords(ctx, dependent.ID)
+	if err != nil || len(deps) != 1 || deps[0].DependsOnID != newID {
+		t.Errorf("Expected %s to depend on %s, got %+v (%v)", dependent.ID, newID, deps, err)
+	}
+	deps, err = store.GetDependencyRecords(ctx, newID)
+	if err != nil || len(deps) != 1 || deps[0].DependsOnID != blocker.ID {
+		t.Errorf("Expected %s to depend on %s, got %+v (%v)", newID, blocker.ID, deps, err)
+	}
+	var blocked bool
+	if err := store.db().QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM blocked_issues_cache WHERE issue_id = ?)`, newID).Scan(&blocked); err != nil || !blocked {
+		t.Errorf("Expected %s in blocked cache, got %v (%v)", newID, blocked, err)
+	}
+	if child, err := store.GetNextChildID(ctx, newID); err != nil || child != newID+".2" {
+		t.Errorf("Expected child counter to carry over, got %q (%v)", child, err)
+	}
+	events, err := store.GetEvents(ctx, newID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	sawRename := false
+	for _, event := range events {
+		sawRename = sawRename || event.EventType == "renamed"
+	}
+	if len(events) < 2 || !sawRename {
+		t.Errorf("Expected history plus a rename event on %s, got %d events", newID, len(events))
+	}
+
+	tombstone, err := store.GetIssue(ctx, oldID)
+	if err != nil || tombstone == nil {
+		t.Fatalf("GetIssue(%s) failed: %v", oldID, err)
+	}
+	if tombstone.Status != types.StatusTombstone || !strings.Contains(tombstone.DeleteReason, newID) {
+		t.Errorf("Expected tombstone pointing at %s, got %+v", newID, tombstone)
+	}
+
+	resolved, err := store.ResolveID(ctx, oldID)
+	if err != nil || resolved != newID {
+		t.Errorf("Expected %s to resolve to %s, got %q (%v)", oldID, newID, resolved, err)
+	}
+
+	// Renaming again keeps the first alias pointing at the current ID
+	if err := store.RenameIssue(ctx, newID, "bd-renamed2", "test"); err != nil {
+		t.Fatalf("RenameIssue failed: %v", err)
+	}
+	if resolved, _ := store.ResolveID(ctx, oldID); resolved != "bd-renamed2" {
+		t.Errorf("Expected %s to resolve to bd-renamed2, got %q", oldID, resolved)
+	}
+}
+
+func TestRenameIssueRejects(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	first := &types.Issue{Title: "First", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	second := &types.Issue{Title: "Second", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{first, second} {
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	if err := store.RenameIssue(ctx, first.ID, second.ID, "test"); !errors.Is(err, ErrDuplicateID) {
+		t.Errorf("Expected ErrDuplicateID renaming onto an existing issue, got %v", err)
+	}
+	if got, _ := store.GetIssue(ctx, first.ID); got == nil || got.Title != "First" {
+		t.Errorf("Expected %s untouched after failed rename, got %+v", first.ID, got)
+	}
+
+	if err := store.RenameIssue(ctx, first.ID, "bd-moved", "test"); err != nil {
+		t.Fatalf("RenameIssue failed: %v", err)
+	}
+	if err := store.RenameIssue(ctx, second.ID, first.ID, "test"); !errors.Is(err, ErrDuplicateID) {
+		t.Errorf("Expected ErrDuplicateID renaming onto an alias, got %v", err)
+	}
+	if err := store.RenameIssue(ctx, first.ID, "bd-again", "test"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound renaming a tombstone, got %v", err)
+	}
+	if err := store.RenameIssue(ctx, "bd-missing", "bd-other", "test"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound renaming a missing issue, got %v", err)
+	}
+	if err := store.RenameIssue(ctx, second.ID, "", "test"); !errors.Is(err, ErrInvalidID) {
+		t.Errorf("Expected ErrInvalidID for an empty ID, got %v", err)
+	}
+	if _, err := store.ResolveID(ctx, "bd-missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound resolving a missing ID, got %v", err)
+	}
+}
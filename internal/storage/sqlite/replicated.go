@@ -0,0 +1,548 @@
+// Package sqlite - write-through replication to secondary stores
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// DefaultReplicationPollInterval is how often a ReplicatedStore checks the
+// primary for new revisions when ReplicationOptions.PollInterval is unset
+const DefaultReplicationPollInterval = 200 * time.Millisecond
+
+// ReplicationOptions configures a ReplicatedStore
+type ReplicationOptions struct {
+	// PollInterval is how often the primary is checked for new revisions.
+	// WaitForReplica checks immediately. Zero means
+	// DefaultReplicationPollInterval.
+	PollInterval time.Duration
+
+	// ReadReplica is the index of the replica that serves reads
+	ReadReplica int
+}
+
+// ReplicaStatus describes how far one replica lags the primary
+type ReplicaStatus struct {
+	Path            string    `json:"path"`
+	Revision        int64     `json:"revision"`         // Latest primary revision applied
+	PrimaryRevision int64     `json:"primary_revision"` // Primary's revision when last checked
+	Lag             int64     `json:"lag"`              // Revisions not yet applied
+	LastApplied     time.Time `json:"last_applied,omitempty"`
+	LastError       string    `json:"last_error,omitempty"`
+	Serving         bool      `json:"serving"` // Whether this replica serves reads
+}
+
+// replicaState is the replication progress of one replica
+type replicaState struct {
+	store       *SQLiteStorage
+	revision    int64
+	lastApplied time.Time
+	lastErr     error
+	pending     map[string]bool // Issues changed outside the event stream
+}
+
+// ReplicatedStore writes through to a primary store and asynchronously
+// replicates its changes to one or more replica stores, serving reads from
+// one of them. Everything not overridden here, including all writes, events,
+// config and transactions, goes to the embedded primary.
+//
+// Replication follows the primary's event stream: each event past a
+// replica's revision (see ChangesSince) is copied along with the current
+// rows of the issue it touches (the issue itself, its labels, dependencies
+// and comments), in one transaction per replica. A replica's revision is
+// thus its own latest event id and survives restarts. Hard deletes and issue
+// comments don't record events, so DeleteIssue and AddIssueComment made
+// through the ReplicatedStore queue their issue for the next pass; changes
+// like these made to the primary directly only reach replicas with the next
+// event for the issue.
+//
+// Reads from the replica may be behind the primary. Use WaitForReplica with
+// the primary's CurrentRevision after a write to read it back.
+type ReplicatedStore struct {
+	*SQLiteStorage // Primary
+
+	opts     ReplicationOptions
+	replicas []*replicaState
+	read     *SQLiteStorage
+
+	mu      sync.Mutex
+	primary int64         // Primary revision when last checked
+	applied chan struct{} // Closed and replaced after every pass
+	nudge   chan struct{}
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// NewReplicatedStore returns a ReplicatedStore over primary and replicas and
+// starts replicating in the background. Replicas must be separate databases
+// at the primary's schema version; an empty replica is filled from the
+// primary on the first pass. The ReplicatedStore owns the stores and closes
+// them in Close.
+func NewReplicatedStore(ctx context.Context, primary *SQLiteStorage, replicas []*SQLiteStorage, opts ReplicationOptions) (*ReplicatedStore, error) {
+	if len(replicas) == 0 {
+		return nil, fmt.Errorf("replicated store requires at least one replica")
+	}
+	if opts.ReadReplica < 0 || opts.ReadReplica >= len(replicas) {
+		return nil, fmt.Errorf("read replica %d out of range (have %d replicas)", opts.ReadReplica, len(replicas))
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = DefaultReplicationPollInterval
+	}
+
+	r := &ReplicatedStore{
+		SQLiteStorage: primary,
+		opts:          opts,
+		read:          replicas[opts.ReadReplica],
+		applied:       make(chan struct{}),
+		nudge:         make(chan struct{}, 1),
+		stop:          make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+	for _, replica := range replicas {
+		if replica == primary || (replica.Path() == primary.Path() && !primary.isInMemory) {
+			return nil, fmt.Errorf("replica %s is the primary", replica.Path())
+		}
+		revision, err := replica.CurrentRevision(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read revision of replica %s: %w", replica.Path(), err)
+		}
+		r.replicas = append(r.replicas, &replicaState{store: replica, revision: revision, pending: make(map[string]bool)})
+	}
+
+	go r.run()
+	return r, nil
+}
+
+// run replicates every PollInterval, or sooner when nudged, until Close
+func (r *ReplicatedStore) run() {
+	defer close(r.done)
+	ticker := time.NewTicker(r.opts.PollInterval)
+	defer ticker.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-r.stop
+		cancel()
+	}()
+
+	for {
+		r.replicateOnce(ctx)
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+		case <-r.nudge:
+		}
+	}
+}
+
+// replicateOnce brings every replica up to the primary's current revision,
+// recording the outcome per replica, and wakes WaitForReplica callers
+func (r *ReplicatedStore) replicateOnce(ctx context.Context) {
+	target, err := r.SQLiteStorage.CurrentRevision(ctx)
+
+	r.mu.Lock()
+	if err == nil {
+		r.primary = target
+	}
+	replicas := r.replicas
+	r.mu.Unlock()
+
+	if err == nil {
+		for _, state := range replicas {
+			r.mu.Lock()
+			from := state.revision
+			pending := make([]string, 0, len(state.pending))
+			for id := range state.pending {
+				pending = append(pending, id)
+			}
+			r.mu.Unlock()
+			if from >= target && len(pending) == 0 {
+				continue
+			}
+
+			revision, applyErr := r.apply(ctx, state.store, from, pending)
+
+			r.mu.Lock()
+			state.lastErr = applyErr
+			if applyErr == nil {
+				state.revision = revision
+				state.lastApplied = r.SQLiteStorage.now()
+				for _, id := range pending {
+					delete(state.pending, id)
+				}
+			}
+			r.mu.Unlock()
+		}
+	}
+
+	r.mu.Lock()
+	close(r.applied)
+	r.applied = make(chan struct{})
+	r.mu.Unlock()
+}
+
+// replicatedTables are the per-issue tables copied to replicas, keyed by the
+// column naming the issue
+var replicatedTables = []struct{ table, column string }{
+	{"labels", "issue_id"},
+	{"dependencies", "issue_id"},
+	{"comments", "issue_id"},
+}
+
+// apply copies the events after from and the current rows of every issue
+// they or extra touch from the primary to replica, returning the revision
+// the replica is at afterwards. The primary is read in one transaction so
+// the copy is a consistent snapshot.
+func (r *ReplicatedStore) apply(ctx context.Context, replica *SQLiteStorage, from int64, extra []string) (int64, error) {
+	src, err := r.SQLiteStorage.db().BeginTx(ctx, nil)
+	if err != nil {
+		return from, wrapDBError("begin replication read", err)
+	}
+	defer func() { _ = src.Rollback() }()
+
+	var revision sql.NullInt64
+	if err := src.QueryRowContext(ctx, `SELECT MAX(id) FROM events`).Scan(&revision); err != nil {
+		return from, wrapDBError("get current revision", err)
+	}
+	to := revision.Int64
+	if to < from {
+		to = from // Latest events were hard-deleted with their issue
+	}
+
+	// An empty replica gets every issue, not only those with events
+	query := `SELECT DISTINCT issue_id FROM events WHERE id > ? AND id <= ?`
+	args := []interface{}{from, to}
+	if from == 0 {
+		query = `SELECT id FROM issues`
+		args = nil
+	}
+	rows, err := src.QueryContext(ctx, query, args...)
+	if err != nil {
+		return from, wrapDBError("query replicated issues", err)
+	}
+	ids, err := scanStrings(rows)
+	if err != nil {
+		return from, wrapDBError("scan replicated issues", err)
+	}
+	ids = append(ids, extra...)
+
+	err = replica.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		dst := tx.(*sqliteTxStorage).conn
+		// Issues may reference each other in any order within a pass
+		if _, err := dst.ExecContext(ctx, `PRAGMA defer_foreign_keys = ON`); err != nil {
+			return wrapDBError("defer foreign keys", err)
+		}
+
+		seen := make(map[string]bool, len(ids))
+		for _, id := range ids {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			if err := copyIssueRows(ctx, src, dst, id); err != nil {
+				return fmt.Errorf("failed to replicate %s: %w", id, err)
+			}
+		}
+		if err := copyRows(ctx, src, dst, "events", "", `id > ? AND id <= ?`, from, to); err != nil {
+			return fmt.Errorf("failed to replicate events: %w", err)
+		}
+		return replica.rebuildBlockedCache(ctx, dst)
+	})
+	if err != nil {
+		return from, err
+	}
+	return to, nil
+}
+
+// copyIssueRows makes issue id and its per-issue rows on dst match src,
+// deleting it from dst if it no longer exists on src
+func copyIssueRows(ctx context.Context, src *sql.Tx, dst *sql.Conn, id string) error {
+	var exists bool
+	if err := src.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM issues WHERE id = ?)`, id).Scan(&exists); err != nil {
+		return wrapDBError("check issue", err)
+	}
+	if !exists {
+		if _, err := dst.ExecContext(ctx, `DELETE FROM issues WHERE id = ?`, id); err != nil {
+			return wrapDBError("delete issue", err)
+		}
+		return syncFTS(ctx, dst, id)
+	}
+
+	// Upsert rather than replace, which would cascade-delete the rows of
+	// other issues that reference this one
+	if err := copyRows(ctx, src, dst, "issues", "id", `id = ?`, id); err != nil {
+		return err
+	}
+	for _, t := range replicatedTables {
+		if _, err := dst.ExecContext(ctx, fmt.Sprintf(`DELETE FROM %s WHERE %s = ?`, t.table, t.column), id); err != nil {
+			return wrapDBError("clear "+t.table, err)
+		}
+		if err := copyRows(ctx, src, dst, t.table, "", t.column+` = ?`, id); err != nil {
+			return err
+		}
+	}
+	return syncFTS(ctx, dst, id)
+}
+
+// copyRows copies the rows of table matching where from src to dst. Rows
+// conflicting on key are updated in place when key is set and left alone
+// otherwise.
+func copyRows(ctx context.Context, src *sql.Tx, dst *sql.Conn, table, key, where string, args ...interface{}) error {
+	// #nosec G201 - table, key and where are fixed by the callers
+	rows, err := src.QueryContext(ctx, fmt.Sprintf(`SELECT * FROM %s WHERE %s`, table, where), args...)
+	if err != nil {
+		return wrapDBError("read "+table, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return wrapDBError("read "+table+" columns", err)
+	}
+	var conflict string
+	if key != "" {
+		updates := make([]string, 0, len(columns))
+		for _, column := range columns {
+			if column != key {
+				updates = append(updates, fmt.Sprintf("%s = excluded.%s", column, column))
+			}
+		}
+		conflict = fmt.Sprintf("ON CONFLICT(%s) DO UPDATE SET %s", key, strings.Join(updates, ", "))
+	} else {
+		conflict = "ON CONFLICT DO NOTHING"
+	}
+	// #nosec G201 - column names come from the table itself
+	insert := fmt.Sprintf(`INSERT INTO %s (%s) VALUES (%s) %s`,
+		table, strings.Join(columns, ", "), buildPlaceholders(len(columns)), conflict)
+
+	values := make([]interface{}, len(columns))
+	dests := make([]interface{}, len(columns))
+	for i := range values {
+		dests[i] = &values[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(dests...); err != nil {
+			return wrapDBError("scan "+table, err)
+		}
+		if _, err := dst.ExecContext(ctx, insert, values...); err != nil {
+			return wrapDBError("write "+table, err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return wrapDBError("iterate "+table, err)
+	}
+	return nil
+}
+
+// WaitForReplica blocks until the read replica has applied revision, e.g.
+// the primary's CurrentRevision right after a write, along with any deletes
+// and comments queued for it, so the following reads see them. It returns
+// ctx's error if ctx ends first.
+func (r *ReplicatedStore) WaitForReplica(ctx context.Context, revision int64) error {
+	state := r.replicas[r.opts.ReadReplica]
+	for {
+		r.mu.Lock()
+		reached := state.revision >= revision && len(state.pending) == 0
+		applied := r.applied
+		r.mu.Unlock()
+		if reached {
+			return nil
+		}
+
+		select {
+		case r.nudge <- struct{}{}:
+		default:
+		}
+		select {
+		case <-applied:
+		case <-r.done:
+			return fmt.Errorf("replicated store is closed")
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// ReplicationStatus returns the replication progress of each replica, in
+// the order they were given to NewReplicatedStore
+func (r *ReplicatedStore) ReplicationStatus() []ReplicaStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	statuses := make([]ReplicaStatus, 0, len(r.replicas))
+	for i, state := range r.replicas {
+		status := ReplicaStatus{
+			Path:            state.store.Path(),
+			Revision:        state.revision,
+			PrimaryRevision: r.primary,
+			LastApplied:     state.lastApplied,
+			Serving:         i == r.opts.ReadReplica,
+		}
+		if r.primary > state.revision {
+			status.Lag = r.primary - state.revision
+		}
+		if state.lastErr != nil {
+			status.LastError = state.lastErr.Error()
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// queue marks issue id for the next replication pass on every replica and
+// triggers one
+func (r *ReplicatedStore) queue(id string) {
+	r.mu.Lock()
+	for _, state := range r.replicas {
+		state.pending[id] = true
+	}
+	r.mu.Unlock()
+	select {
+	case r.nudge <- struct{}{}:
+	default:
+	}
+}
+
+// DeleteIssue hard-deletes an issue on the primary and queues the delete
+// for the replicas
+func (r *ReplicatedStore) DeleteIssue(ctx context.Context, id string) error {
+	if err := r.SQLiteStorage.DeleteIssue(ctx, id); err != nil {
+		return err
+	}
+	r.queue(id)
+	return nil
+}
+
+// AddIssueComment adds a comment on the primary and queues it for the
+// replicas
+func (r *ReplicatedStore) AddIssueComment(ctx context.Context, issueID, author, text string) (*types.Comment, error) {
+	comment, err := r.SQLiteStorage.AddIssueComment(ctx, issueID, author, text)
+	if err != nil {
+		return nil, err
+	}
+	r.queue(issueID)
+	return comment, nil
+}
+
+// Close stops replication and closes the primary and every replica
+func (r *ReplicatedStore) Close() error {
+	select {
+	case <-r.stop:
+	default:
+		close(r.stop)
+	}
+	<-r.done
+
+	err := r.SQLiteStorage.Close()
+	for _, state := range r.replicas {
+		if closeErr := state.store.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+// Reads are served by the read replica
+
+// GetIssue retrieves an issue from the read replica
+func (r *ReplicatedStore) GetIssue(ctx context.Context, id string) (*types.Issue, error) {
+	return r.read.GetIssue(ctx, id)
+}
+
+// GetIssueByExternalRef retrieves an issue by external ref from the read
+// replica
+func (r *ReplicatedStore) GetIssueByExternalRef(ctx context.Context, externalRef string) (*types.Issue, error) {
+	return r.read.GetIssueByExternalRef(ctx, externalRef)
+}
+
+// SearchIssues searches the read replica
+func (r *ReplicatedStore) SearchIssues(ctx context.Context, query string, filter types.IssueFilter) ([]*types.Issue, error) {
+	return r.read.SearchIssues(ctx, query, filter)
+}
+
+// GetDependencies returns an issue's dependencies from the read replica
+func (r *ReplicatedStore) GetDependencies(ctx context.Context, issueID string) ([]*types.Issue, error) {
+	return r.read.GetDependencies(ctx, issueID)
+}
+
+// GetDependents returns an issue's dependents from the read replica
+func (r *ReplicatedStore) GetDependents(ctx context.Context, issueID string) ([]*types.Issue, error) {
+	return r.read.GetDependents(ctx, issueID)
+}
+
+// GetDependencyRecords returns an issue's dependency records from the read
+// replica
+func (r *ReplicatedStore) GetDependencyRecords(ctx context.Context, issueID string) ([]*types.Dependency, error) {
+	return r.read.GetDependencyRecords(ctx, issueID)
+}
+
+// GetAllDependencyRecords returns every dependency record from the read
+// replica
+func (r *ReplicatedStore) GetAllDependencyRecords(ctx context.Context) (map[string][]*types.Dependency, error) {
+	return r.read.GetAllDependencyRecords(ctx)
+}
+
+// GetDependencyCounts returns dependency counts from the read replica
+func (r *ReplicatedStore) GetDependencyCounts(ctx context.Context, issueIDs []string) (map[string]*types.DependencyCounts, error) {
+	return r.read.GetDependencyCounts(ctx, issueIDs)
+}
+
+// GetDependencyTree returns a dependency tree from the read replica
+func (r *ReplicatedStore) GetDependencyTree(ctx context.Context, issueID string, maxDepth int, showAllPaths bool, reverse bool) ([]*types.TreeNode, error) {
+	return r.read.GetDependencyTree(ctx, issueID, maxDepth, showAllPaths, reverse)
+}
+
+// GetLabels returns an issue's labels from the read replica
+func (r *ReplicatedStore) GetLabels(ctx context.Context, issueID string) ([]string, error) {
+	return r.read.GetLabels(ctx, issueID)
+}
+
+// GetLabelsForIssues returns labels for several issues from the read replica
+func (r *ReplicatedStore) GetLabelsForIssues(ctx context.Context, issueIDs []string) (map[string][]string, error) {
+	return r.read.GetLabelsForIssues(ctx, issueIDs)
+}
+
+// GetIssuesByLabel returns the issues with a label from the read replica
+func (r *ReplicatedStore) GetIssuesByLabel(ctx context.Context, label string) ([]*types.Issue, error) {
+	return r.read.GetIssuesByLabel(ctx, label)
+}
+
+// GetReadyWork returns ready work from the read replica
+func (r *ReplicatedStore) GetReadyWork(ctx context.Context, filter types.WorkFilter) ([]*types.Issue, error) {
+	return r.read.GetReadyWork(ctx, filter)
+}
+
+// GetBlockedIssues returns blocked issues from the read replica
+func (r *ReplicatedStore) GetBlockedIssues(ctx context.Context) ([]*types.BlockedIssue, error) {
+	return r.read.GetBlockedIssues(ctx)
+}
+
+// GetStaleIssues returns stale issues from the read replica
+func (r *ReplicatedStore) GetStaleIssues(ctx context.Context, filter types.StaleFilter) ([]*types.Issue, error) {
+	return r.read.GetStaleIssues(ctx, filter)
+}
+
+// GetIssueComments returns an issue's comments from the read replica
+func (r *ReplicatedStore) GetIssueComments(ctx context.Context, issueID string) ([]*types.Comment, error) {
+	return r.read.GetIssueComments(ctx, issueID)
+}
+
+// GetCommentsForIssues returns comments for several issues from the read
+// replica
+func (r *ReplicatedStore) GetCommentsForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.Comment, error) {
+	return r.read.GetCommentsForIssues(ctx, issueIDs)
+}
+
+// GetStatistics returns statistics from the read replica
+func (r *ReplicatedStore) GetStatistics(ctx context.Context) (*types.Statistics, error) {
+	return r.read.GetStatistics(ctx)
+}
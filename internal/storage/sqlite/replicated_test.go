@@ -0,0 +1,131 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestReplicatedStore(t *testing.T) {
+	ctx := context.Background()
+	primary := newTestStore(t, "")
+	replica := newTestStore(t, "")
+
+	// Existing issues reach an empty replica on the first pass
+	existing := &types.Issue{Title: "Existing", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := primary.CreateIssue(ctx, existing, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	r, err := NewReplicatedStore(ctx, primary, []*SQLiteStorage{replica}, ReplicationOptions{PollInterval: time.Hour})
+	if err != nil {
+		t.Fatalf("NewReplicatedStore failed: %v", err)
+	}
+	t.Cleanup(func() { _ = r.Close() })
+
+	// catchUp waits for the replica to apply everything written so far
+	catchUp := func() {
+		t.Helper()
+		revision, err := r.CurrentRevision(ctx)
+		if err != nil {
+			t.Fatalf("CurrentRevision failed: %v", err)
+		}
+		waitCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+		if err := r.WaitForReplica(waitCtx, revision); err != nil {
+			t.Fatalf("WaitForReplica failed: %v", err)
+		}
+	}
+
+	blocker := &types.Issue{Title: "Blocker", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	blocked := &types.Issue{Title: "Blocked", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{blocker, blocked} {
+		if err := r.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	if err := r.AddDependency(ctx, &types.Dependency{IssueID: blocked.ID, DependsOnID: blocker.ID, Type: types.DepBlocks}, "test"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+	if err := r.AddLabel(ctx, blocker.ID, "backend", "test"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+	if _, err := r.AddIssueComment(ctx, blocker.ID, "alice", "On it"); err != nil {
+		t.Fatalf("AddIssueComment failed: %v", err)
+	}
+	catchUp()
+
+	for _, id := range []string{existing.ID, blocker.ID, blocked.ID} {
+		got, err := replica.GetIssue(ctx, id)
+		if err != nil {
+			t.Fatalf("GetIssue failed: %v", err)
+		}
+		if got == nil {
+			t.Fatalf("Expected %s replicated", id)
+		}
+	}
+	if labels, err := r.GetLabels(ctx, blocker.ID); err != nil || len(labels) != 1 || labels[0] != "backend" {
+		t.Errorf("Expected replicated label, got %v (err %v)", labels, err)
+	}
+	if comments, err := r.GetIssueComments(ctx, blocker.ID); err != nil || len(comments) != 1 {
+		t.Errorf("Expected replicated comment, got %v (err %v)", comments, err)
+	}
+	ready, err := r.GetReadyWork(ctx, types.WorkFilter{})
+	if err != nil {
+		t.Fatalf("GetReadyWork failed: %v", err)
+	}
+	for _, issue := range ready {
+		if issue.ID == blocked.ID {
+			t.Error("Expected replica to know the blocked issue is blocked")
+		}
+	}
+
+	if err := r.UpdateIssue(ctx, blocker.ID, map[string]interface{}{"title": "Renamed blocker"}, "test"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if err := r.DeleteIssue(ctx, existing.ID); err != nil {
+		t.Fatalf("DeleteIssue failed: %v", err)
+	}
+	catchUp()
+	if got, err := r.GetIssue(ctx, blocker.ID); err != nil || got == nil || got.Title != "Renamed blocker" {
+		t.Errorf("Expected replicated update, got %+v (err %v)", got, err)
+	}
+	// Hard deletes don't record events, so wait for the queued pass
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		got, err := replica.GetIssue(ctx, existing.ID)
+		if err != nil {
+			t.Fatalf("GetIssue failed: %v", err)
+		}
+		if got == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Expected hard delete replicated")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	statuses := r.ReplicationStatus()
+	if len(statuses) != 1 || !statuses[0].Serving || statuses[0].LastError != "" || statuses[0].Revision == 0 {
+		t.Errorf("Unexpected replication status %+v", statuses)
+	}
+}
+
+func TestNewReplicatedStoreValidation(t *testing.T) {
+	ctx := context.Background()
+	primary := newTestStore(t, "")
+	replica := newTestStore(t, "")
+
+	if _, err := NewReplicatedStore(ctx, primary, nil, ReplicationOptions{}); err == nil {
+		t.Error("Expected an error without replicas")
+	}
+	if _, err := NewReplicatedStore(ctx, primary, []*SQLiteStorage{replica}, ReplicationOptions{ReadReplica: 1}); err == nil {
+		t.Error("Expected an error for an out-of-range read replica")
+	}
+	if _, err := NewReplicatedStore(ctx, primary, []*SQLiteStorage{primary}, ReplicationOptions{}); err == nil {
+		t.Error("Expected an error when the primary is its own replica")
+	}
+}
@@ -26,7 +26,7 @@ import (
 //   - error if resurrection failed for any other reason
 func (s *SQLiteStorage) TryResurrectParent(ctx context.Context, parentID string) (bool, error) {
 	// Get a connection for the entire resurrection operation
-	conn, err := s.db.Conn(ctx)
+	conn, err := s.db().Conn(ctx)
 	if err != nil {
 		return false, fmt.Errorf("failed to get database connection: %w", err)
 	}
@@ -194,7 +194,7 @@ func (s *SQLiteStorage) findIssueInJSONL(issueID string) (*types.Issue, error) {
 //   - error if resurrection failed for any other reason
 func (s *SQLiteStorage) TryResurrectParentChain(ctx context.Context, childID string) (bool, error) {
 	// Get a connection for the entire chain resurrection
-	conn, err := s.db.Conn(ctx)
+	conn, err := s.db().Conn(ctx)
 	if err != nil {
 		return false, fmt.Errorf("failed to get database connection: %w", err)
 	}
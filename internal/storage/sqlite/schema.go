@@ -27,12 +27,19 @@ CREATE TABLE IF NOT EXISTS issues (
     deleted_by TEXT DEFAULT '',
     delete_reason TEXT DEFAULT '',
     original_type TEXT DEFAULT '',
+    draft INTEGER NOT NULL DEFAULT 0,
+    percent_complete INTEGER NOT NULL DEFAULT 0,
+    external_blocked_reason TEXT,
+    snoozed_until DATETIME,
+    version INTEGER NOT NULL DEFAULT 1,
+    due_date DATETIME,
     CHECK ((status = 'closed') = (closed_at IS NOT NULL))
 );
 
 CREATE INDEX IF NOT EXISTS idx_issues_status ON issues(status);
 CREATE INDEX IF NOT EXISTS idx_issues_priority ON issues(priority);
 CREATE INDEX IF NOT EXISTS idx_issues_assignee ON issues(assignee);
+CREATE INDEX IF NOT EXISTS idx_issues_due_date ON issues(due_date);
 CREATE INDEX IF NOT EXISTS idx_issues_created_at ON issues(created_at);
 -- Note: idx_issues_external_ref is created in migrations/002_external_ref_column.go
 
@@ -43,6 +50,7 @@ CREATE TABLE IF NOT EXISTS dependencies (
     type TEXT NOT NULL DEFAULT 'blocks',
     created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
     created_by TEXT NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
     PRIMARY KEY (issue_id, depends_on_id),
     FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE,
     FOREIGN KEY (depends_on_id) REFERENCES issues(id) ON DELETE CASCADE
@@ -143,6 +151,98 @@ CREATE TABLE IF NOT EXISTS child_counters (
     FOREIGN KEY (parent_id) REFERENCES issues(id) ON DELETE CASCADE
 );
 
+-- Issue claims table (cooperative per-issue locks for multi-agent coordination)
+-- A claim is held until released or until expires_at passes
+CREATE TABLE IF NOT EXISTS issue_claims (
+    issue_id TEXT PRIMARY KEY,
+    agent TEXT NOT NULL,
+    claimed_at DATETIME NOT NULL,
+    expires_at DATETIME NOT NULL,
+    FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+);
+
+-- Issue links table (external URLs such as docs, PRs and runbooks)
+CREATE TABLE IF NOT EXISTS issue_links (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    issue_id TEXT NOT NULL,
+    url TEXT NOT NULL,
+    title TEXT NOT NULL DEFAULT '',
+    kind TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (issue_id, url),
+    FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+);
+
+-- Sequences table (named monotonic counters, see NextSeq)
+CREATE TABLE IF NOT EXISTS sequences (
+    name TEXT PRIMARY KEY,
+    value INTEGER NOT NULL DEFAULT 0
+);
+
+-- Recurring issue schedules (see GenerateRecurring)
+CREATE TABLE IF NOT EXISTS recurrences (
+    issue_id TEXT PRIMARY KEY,
+    freq TEXT NOT NULL,
+    interval INTEGER NOT NULL DEFAULT 1,
+    next_due TEXT NOT NULL,
+    FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+);
+
+-- Instances generated from recurring issues, one per template and due date
+CREATE TABLE IF NOT EXISTS recurrence_instances (
+    issue_id TEXT PRIMARY KEY,
+    template_id TEXT NOT NULL,
+    due TEXT NOT NULL,
+    UNIQUE (template_id, due),
+    FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE,
+    FOREIGN KEY (template_id) REFERENCES issues(id) ON DELETE CASCADE
+);
+
+-- Old IDs of renamed issues, pointing at their current ID (see RenameIssue)
+CREATE TABLE IF NOT EXISTS issue_aliases (
+    alias TEXT PRIMARY KEY,
+    issue_id TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_issue_aliases_issue ON issue_aliases(issue_id);
+
+-- Recent idempotency keys and the issue each created (see WithIdempotencyKey)
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    key TEXT PRIMARY KEY,
+    issue_id TEXT NOT NULL,
+    created_at DATETIME NOT NULL,
+    FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_created_at ON idempotency_keys(created_at);
+
+-- Owners of private issues; an issue with rows here is private (see SetVisibility)
+CREATE TABLE IF NOT EXISTS issue_acl (
+    issue_id TEXT NOT NULL,
+    actor TEXT NOT NULL,
+    PRIMARY KEY (issue_id, actor),
+    FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+);
+
+-- Structured descriptions, as a JSON object of section name to body (see SetSection)
+CREATE TABLE IF NOT EXISTS issue_sections (
+    issue_id TEXT PRIMARY KEY,
+    sections TEXT NOT NULL,
+    FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+);
+
+-- Who follows each issue (see WatchIssue)
+CREATE TABLE IF NOT EXISTS issue_watchers (
+    issue_id TEXT NOT NULL,
+    watcher TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (issue_id, watcher),
+    FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_issue_watchers_watcher ON issue_watchers(watcher);
+
 -- Issue snapshots table (for compaction)
 CREATE TABLE IF NOT EXISTS issue_snapshots (
     id INTEGER PRIMARY KEY AUTOINCREMENT,
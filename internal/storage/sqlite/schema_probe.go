@@ -17,9 +17,10 @@ var expectedSchema = map[string][]string{
 		"id", "title", "description", "design", "acceptance_criteria", "notes",
 		"status", "priority", "issue_type", "assignee", "estimated_minutes",
 		"created_at", "updated_at", "closed_at", "content_hash", "external_ref",
-		"compaction_level", "compacted_at", "compacted_at_commit", "original_size",
+		"compaction_level", "compacted_at", "compacted_at_commit", "original_size", "percent_complete",
+		"external_blocked_reason", "snoozed_until", "version", "due_date",
 	},
-	"dependencies":         {"issue_id", "depends_on_id", "type", "created_at", "created_by"},
+	"dependencies":         {"issue_id", "depends_on_id", "type", "created_at", "created_by", "note"},
 	"labels":               {"issue_id", "label"},
 	"comments":             {"id", "issue_id", "author", "text", "created_at"},
 	"events":               {"id", "issue_id", "event_type", "actor", "old_value", "new_value", "comment", "created_at"},
@@ -28,9 +29,20 @@ var expectedSchema = map[string][]string{
 	"dirty_issues":         {"issue_id", "marked_at"},
 	"export_hashes":        {"issue_id", "content_hash", "exported_at"},
 	"child_counters":       {"parent_id", "last_child"},
+	"issue_claims":         {"issue_id", "agent", "claimed_at", "expires_at"},
+	"issue_links":          {"id", "issue_id", "url", "title", "kind", "created_at"},
+	"sequences":            {"name", "value"},
+	"recurrences":          {"issue_id", "freq", "interval", "next_due"},
+	"recurrence_instances": {"issue_id", "template_id", "due"},
+	"issue_aliases":        {"alias", "issue_id", "created_at"},
+	"idempotency_keys":     {"key", "issue_id", "created_at"},
+	"issue_acl":            {"issue_id", "actor"},
+	"issue_sections":       {"issue_id", "sections"},
+	"issue_watchers":       {"issue_id", "watcher", "created_at"},
 	"issue_snapshots":      {"id", "issue_id", "snapshot_time", "compaction_level", "original_size", "compressed_size", "original_content", "archived_events"},
 	"compaction_snapshots": {"id", "issue_id", "compaction_level", "snapshot_json", "created_at"},
 	"repo_mtimes":          {"repo_path", "jsonl_path", "mtime_ns", "last_checked"},
+	"schema_migrations":    {"version", "name", "applied_at"},
 }
 
 // SchemaProbeResult contains the results of a schema compatibility check
@@ -0,0 +1,130 @@
+// Package sqlite - structured issue descriptions
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// SetSection sets the named section of issue id's structured description
+// (see Issue.Sections) to body, or removes it when body is blank, and
+// rewrites the description as types.FlattenSections of the result so
+// readers, exports and searches that only know descriptions see the
+// sections as Markdown headings. A plain description written before the
+// issue's first section is kept as its summary, unless that is the section
+// being set. Editing the description directly leaves the sections alone;
+// the next SetSection overwrites it.
+func (s *SQLiteStorage) SetSection(ctx context.Context, id, name, body, actor string) error {
+	if !types.IsValidSectionName(name) {
+		return fmt.Errorf("invalid section name %q (use lowercase letters, digits and underscores)", name)
+	}
+
+	return s.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		t := tx.(*sqliteTxStorage)
+
+		if err := requireVisibleIssue(ctx, t.conn, id); err != nil {
+			return err
+		}
+		sections, err := getSections(ctx, t.conn, id)
+		if err != nil {
+			return err
+		}
+		if sections == nil {
+			sections = make(map[string]string)
+			issue, err := t.GetIssue(ctx, id)
+			if err != nil {
+				return err
+			}
+			if description := strings.TrimSpace(issue.Description); description != "" && name != types.SectionSummary {
+				sections[types.SectionSummary] = description
+			}
+		}
+
+		body = strings.TrimSpace(body)
+		if body == "" {
+			delete(sections, name)
+		} else {
+			sections[name] = body
+		}
+
+		if len(sections) == 0 {
+			if _, err := t.conn.ExecContext(ctx, `DELETE FROM issue_sections WHERE issue_id = ?`, id); err != nil {
+				return wrapDBError("clear issue sections", err)
+			}
+		} else {
+			data, err := json.Marshal(sections)
+			if err != nil {
+				return fmt.Errorf("failed to encode sections: %w", err)
+			}
+			if _, err := t.conn.ExecContext(ctx, `
+				INSERT INTO issue_sections (issue_id, sections) VALUES (?, ?)
+				ON CONFLICT (issue_id) DO UPDATE SET sections = excluded.sections
+			`, id, string(data)); err != nil {
+				return wrapDBError("set issue sections", err)
+			}
+		}
+
+		return t.UpdateIssue(ctx, id, map[string]interface{}{
+			"description": types.FlattenSections(sections),
+		}, actor)
+	})
+}
+
+// GetSection returns the body of the named section of issue id, or "" if it
+// has no such section
+func (s *SQLiteStorage) GetSection(ctx context.Context, id, name string) (string, error) {
+	if err := requireVisibleIssue(ctx, s.db(), id); err != nil {
+		return "", err
+	}
+	sections, err := getSections(ctx, s.db(), id)
+	if err != nil {
+		return "", err
+	}
+	return sections[name], nil
+}
+
+// getSections returns the sections of issue id on q, or nil if it has none
+func getSections(ctx context.Context, q queryer, id string) (map[string]string, error) {
+	var data string
+	err := q.QueryRowContext(ctx, `SELECT sections FROM issue_sections WHERE issue_id = ?`, id).Scan(&data)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, wrapDBError("get issue sections", err)
+	}
+	var sections map[string]string
+	if err := json.Unmarshal([]byte(data), &sections); err != nil {
+		return nil, fmt.Errorf("failed to decode sections of %s: %w", id, err)
+	}
+	return sections, nil
+}
+
+// sectionContainsClauses returns the conditions, and their args, for
+// IssueFilter.SectionContains: one per section, in name order, holding for
+// issues whose section contains the text
+func sectionContainsClauses(filter map[string]string) ([]string, []interface{}, error) {
+	names := make([]string, 0, len(filter))
+	for name := range filter {
+		if !types.IsValidSectionName(name) {
+			return nil, nil, fmt.Errorf("invalid section name %q", name)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var clauses []string
+	var args []interface{}
+	for _, name := range names {
+		clauses = append(clauses, `EXISTS (SELECT 1 FROM issue_sections sec WHERE sec.issue_id = issues.id AND json_extract(sec.sections, ?) LIKE ?)`)
+		args = append(args, `$.`+name, "%"+filter[name]+"%")
+	}
+	return clauses, args, nil
+}
@@ -0,0 +1,92 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestSetSection(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Rate limit the API", Description: "Clients hammer /search", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeFeature}
+	other := &types.Issue{Title: "Unrelated", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	for _, i := range []*types.Issue{issue, other} {
+		if err := store.CreateIssue(ctx, i, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	if err := store.SetSection(ctx, issue.ID, "Acceptance Criteria", "429 after 100 req/min", "test"); err == nil {
+		t.Error("Expected an invalid section name to be rejected")
+	}
+	if err := store.SetSection(ctx, issue.ID, types.SectionAcceptanceCriteria, " 429 after 100 req/min\n", "test"); err != nil {
+		t.Fatalf("SetSection failed: %v", err)
+	}
+	if err := store.SetSection(ctx, issue.ID, "rollout", "Behind a flag", "test"); err != nil {
+		t.Fatalf("SetSection failed: %v", err)
+	}
+
+	got, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	// The plain description became the summary
+	want := map[string]string{
+		types.SectionSummary:            "Clients hammer /search",
+		types.SectionAcceptanceCriteria: "429 after 100 req/min",
+		"rollout":                       "Behind a flag",
+	}
+	if len(got.Sections) != len(want) {
+		t.Fatalf("Expected sections %v, got %v", want, got.Sections)
+	}
+	for name, body := range want {
+		if got.GetSection(name) != body {
+			t.Errorf("Expected section %s = %q, got %q", name, body, got.GetSection(name))
+		}
+	}
+	wantDescription := "## Summary\n\nClients hammer /search\n\n## Acceptance criteria\n\n429 after 100 req/min\n\n## Rollout\n\nBehind a flag"
+	if got.Description != wantDescription {
+		t.Errorf("Expected flattened description %q, got %q", wantDescription, got.Description)
+	}
+	if body, err := store.GetSection(ctx, issue.ID, "rollout"); err != nil || body != "Behind a flag" {
+		t.Errorf("Expected rollout section, got %q (err %v)", body, err)
+	}
+
+	search := func(filter types.IssueFilter) []string {
+		issues, err := store.SearchIssues(ctx, "", filter)
+		if err != nil {
+			t.Fatalf("SearchIssues failed: %v", err)
+		}
+		var ids []string
+		for _, i := range issues {
+			ids = append(ids, i.ID)
+		}
+		return ids
+	}
+	if ids := search(types.IssueFilter{SectionContains: map[string]string{types.SectionAcceptanceCriteria: "429"}}); !sameIDs(ids, []string{issue.ID}) {
+		t.Errorf("Expected section search to find %s, got %v", issue.ID, ids)
+	}
+	if ids := search(types.IssueFilter{SectionContains: map[string]string{types.SectionSummary: "429"}}); len(ids) != 0 {
+		t.Errorf("Expected text from another section not to match, got %v", ids)
+	}
+	if _, err := store.SearchIssues(ctx, "", types.IssueFilter{SectionContains: map[string]string{`x") OR 1=1 --`: ""}}); err == nil {
+		t.Error("Expected an invalid section name in a filter to be rejected")
+	}
+
+	// Removing every section empties the description
+	for name := range want {
+		if err := store.SetSection(ctx, issue.ID, name, " ", "test"); err != nil {
+			t.Fatalf("SetSection failed: %v", err)
+		}
+	}
+	got, err = store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.Sections != nil || got.Description != "" {
+		t.Errorf("Expected no sections or description, got %v and %q", got.Sections, got.Description)
+	}
+}
@@ -0,0 +1,55 @@
+// Package sqlite - named monotonic sequences
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// NextSeq allocates the next value of the named sequence. Sequences start at
+// 1 and are created on first use; values are never handed out twice, even
+// across processes sharing the database.
+func (s *SQLiteStorage) NextSeq(ctx context.Context, name string) (int64, error) {
+	return s.NextSeqN(ctx, name, 1)
+}
+
+// NextSeqN allocates n consecutive values of the named sequence in one round
+// trip and returns the first. The caller owns start through start+n-1.
+func (s *SQLiteStorage) NextSeqN(ctx context.Context, name string, n int) (int64, error) {
+	var start int64
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		start, err = nextSeqN(ctx, tx, name, n)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	return start, nil
+}
+
+// nextSeqN bumps the sequence on q by n and returns the first allocated value
+func nextSeqN(ctx context.Context, q queryer, name string, n int) (int64, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return 0, fmt.Errorf("sequence name is required")
+	}
+	if n < 1 {
+		return 0, fmt.Errorf("invalid sequence count %d: must be at least 1", n)
+	}
+
+	var last int64
+	err := q.QueryRowContext(ctx, `
+		INSERT INTO sequences (name, value)
+		VALUES (?, ?)
+		ON CONFLICT(name) DO UPDATE SET
+			value = value + excluded.value
+		RETURNING value
+	`, name, n).Scan(&last)
+	if err != nil {
+		return 0, wrapDBErrorf(err, "allocate sequence %s", name)
+	}
+	return last - int64(n) + 1, nil
+}
@@ -0,0 +1,96 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestNextSeq(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	for want := int64(1); want <= 3; want++ {
+		got, err := store.NextSeq(ctx, "display")
+		if err != nil {
+			t.Fatalf("NextSeq failed: %v", err)
+		}
+		if got != want {
+			t.Errorf("Expected %d, got %d", want, got)
+		}
+	}
+
+	start, err := store.NextSeqN(ctx, "display", 10)
+	if err != nil {
+		t.Fatalf("NextSeqN failed: %v", err)
+	}
+	if start != 4 {
+		t.Errorf("Expected batch to start at 4, got %d", start)
+	}
+	next, err := store.NextSeq(ctx, "display")
+	if err != nil {
+		t.Fatalf("NextSeq failed: %v", err)
+	}
+	if next != 14 {
+		t.Errorf("Expected 14 after batch, got %d", next)
+	}
+
+	// Sequences are independent
+	other, err := store.NextSeq(ctx, "revision")
+	if err != nil {
+		t.Fatalf("NextSeq failed: %v", err)
+	}
+	if other != 1 {
+		t.Errorf("Expected new sequence to start at 1, got %d", other)
+	}
+
+	if _, err := store.NextSeqN(ctx, "display", 0); err == nil {
+		t.Error("Expected error for zero count")
+	}
+	if _, err := store.NextSeq(ctx, " "); err == nil {
+		t.Error("Expected error for empty name")
+	}
+}
+
+func TestNextSeqConcurrent(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	const workers = 8
+	const perWorker = 5
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	seen := make(map[int64]bool)
+	errs := make(chan error, workers*perWorker)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < perWorker; j++ {
+				start, err := store.NextSeqN(ctx, "bulk", 2)
+				if err != nil {
+					errs <- err
+					return
+				}
+				mu.Lock()
+				for v := start; v < start+2; v++ {
+					if seen[v] {
+						errs <- fmt.Errorf("value %d allocated twice", v)
+					}
+					seen[v] = true
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatalf("NextSeqN failed: %v", err)
+	}
+
+	if len(seen) != workers*perWorker*2 {
+		t.Errorf("Expected %d distinct values, got %d", workers*perWorker*2, len(seen))
+	}
+}
@@ -0,0 +1,249 @@
+// Package sqlite - per-priority SLA tracking
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// SLAAtRiskFraction is the share of a target that can elapse before an
+// unmet target counts as at risk
+const SLAAtRiskFraction = 0.8
+
+// slaTargets are the response and resolution targets of one priority; zero
+// means no target
+type slaTargets struct {
+	response, resolution time.Duration
+}
+
+// SLAStatus evaluates issue id against the SLA targets of its priority (see
+// SLAConfigPrefix). The response target is met by the first response: the
+// first comment, or the first status change away from open (including
+// closing). The resolution target is met by closing; a reopened issue is
+// measured by its current close.
+func (s *SQLiteStorage) SLAStatus(ctx context.Context, id string) (types.SLAStatus, error) {
+	issue, err := s.GetIssue(ctx, id)
+	if err != nil {
+		return types.SLAStatus{}, err
+	}
+	if issue == nil {
+		return types.SLAStatus{}, fmt.Errorf("issue %s: %w", id, ErrNotFound)
+	}
+	targets, err := s.readSLATargets(ctx)
+	if err != nil {
+		return types.SLAStatus{}, err
+	}
+	responses, err := s.firstResponses(ctx, id)
+	if err != nil {
+		return types.SLAStatus{}, err
+	}
+	return evaluateSLA(issue, targets[issue.Priority], responses[id], s.now()), nil
+}
+
+// BreachedSLAs returns the issues breaching their response or resolution
+// target (see SLAStatus), by priority. Tombstones are not included.
+func (s *SQLiteStorage) BreachedSLAs(ctx context.Context) ([]*types.Issue, error) {
+	targets, err := s.readSLATargets(ctx)
+	if err != nil || len(targets) == 0 {
+		return nil, err
+	}
+	priorities := make([]interface{}, 0, len(targets))
+	for priority := range targets {
+		priorities = append(priorities, priority)
+	}
+	args := append(priorities, types.StatusTombstone)
+	visibleSQL := ""
+	if visible, visibleArgs := visibleClause(ctx, "i.id"); visible != "" {
+		visibleSQL = " AND " + visible
+		args = append(args, visibleArgs...)
+	}
+
+	// #nosec G201 -- only placeholders and visibleClause are formatted in
+	rows, err := s.db().QueryContext(ctx, fmt.Sprintf(`
+		SELECT i.id, i.content_hash, i.title, i.description, i.design, i.acceptance_criteria, i.notes,
+		       i.status, i.priority, i.issue_type, i.assignee, i.estimated_minutes,
+		       i.created_at, i.updated_at, i.closed_at, i.external_ref, i.source_repo, i.close_reason,
+		       i.deleted_at, i.deleted_by, i.delete_reason, i.original_type, i.draft, i.percent_complete, i.external_blocked_reason, i.snoozed_until, i.due_date, i.version
+		FROM issues i
+		WHERE i.priority IN (%s) AND i.status != ?%s
+		ORDER BY i.priority ASC, i.id ASC
+	`, buildPlaceholders(len(priorities)), visibleSQL), args...)
+	if err != nil {
+		return nil, wrapDBError("query SLA candidates", err)
+	}
+	issues, err := s.scanIssues(ctx, rows)
+	_ = rows.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	responses, err := s.firstResponses(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	var breached []*types.Issue
+	for _, issue := range issues {
+		if evaluateSLA(issue, targets[issue.Priority], responses[issue.ID], now).Breached() {
+			breached = append(breached, issue)
+		}
+	}
+	return breached, nil
+}
+
+// readSLATargets returns the configured targets by priority, leaving out
+// priorities with none
+func (s *SQLiteStorage) readSLATargets(ctx context.Context) (map[int]slaTargets, error) {
+	rows, err := s.db().QueryContext(ctx, `SELECT key, value FROM config WHERE key LIKE ?`, SLAConfigPrefix+"%")
+	if err != nil {
+		return nil, wrapDBError("read SLA config", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	targets := make(map[int]slaTargets)
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, wrapDBError("scan SLA config", err)
+		}
+		// sla.p<N>.response or sla.p<N>.resolution
+		parts := strings.Split(strings.TrimPrefix(key, SLAConfigPrefix), ".")
+		if len(parts) != 2 || !strings.HasPrefix(parts[0], "p") {
+			continue
+		}
+		priority, err := strconv.Atoi(parts[0][1:])
+		if err != nil || priority < 0 || priority > 4 {
+			continue
+		}
+		target, ok := parseSLADuration(value)
+		if !ok {
+			continue
+		}
+		t := targets[priority]
+		switch parts[1] {
+		case "response":
+			t.response = target
+		case "resolution":
+			t.resolution = target
+		default:
+			continue
+		}
+		targets[priority] = t
+	}
+	if err := rows.Err(); err != nil {
+		return nil, wrapDBError("iterate SLA config", err)
+	}
+	return targets, nil
+}
+
+// parseSLADuration parses a Go duration or a whole number of days ("3d") or
+// weeks ("2w"), accepting only positive values
+func parseSLADuration(value string) (time.Duration, bool) {
+	value = strings.TrimSpace(strings.ToLower(value))
+	unit := time.Duration(0)
+	switch {
+	case strings.HasSuffix(value, "d"):
+		unit = 24 * time.Hour
+	case strings.HasSuffix(value, "w"):
+		unit = 7 * 24 * time.Hour
+	}
+	if unit != 0 {
+		n, err := strconv.Atoi(value[:len(value)-1])
+		if err != nil || n <= 0 {
+			return 0, false
+		}
+		return time.Duration(n) * unit, true
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return 0, false
+	}
+	return d, true
+}
+
+// firstResponses returns when each issue was first responded to: its
+// earliest comment (in either comment store) or status change away from
+// open. An empty id returns every issue's; issues never responded to are
+// absent.
+func (s *SQLiteStorage) firstResponses(ctx context.Context, id string) (map[string]time.Time, error) {
+	var where string
+	args := []interface{}{types.EventCommented, types.EventStatusChanged, types.EventClosed}
+	if id != "" {
+		where = "WHERE issue_id = ?"
+		args = append(args, id)
+	}
+
+	// #nosec G201 -- where is a fixed clause
+	rows, err := s.db().QueryContext(ctx, fmt.Sprintf(`
+		SELECT issue_id, strftime('%%Y-%%m-%%dT%%H:%%M:%%fZ', MIN(julianday(created_at)))
+		FROM (
+			SELECT issue_id, created_at FROM events WHERE event_type IN (?, ?, ?)
+			UNION ALL
+			SELECT issue_id, created_at FROM comments
+		)
+		%s
+		GROUP BY issue_id
+	`, where), args...)
+	if err != nil {
+		return nil, wrapDBError("query first responses", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	responses := make(map[string]time.Time)
+	for rows.Next() {
+		var issueID, at string
+		if err := rows.Scan(&issueID, &at); err != nil {
+			return nil, wrapDBError("scan first response", err)
+		}
+		t, err := time.Parse("2006-01-02T15:04:05.000Z", at)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse first response time %q: %w", at, err)
+		}
+		responses[issueID] = t
+	}
+	if err := rows.Err(); err != nil {
+		return nil, wrapDBError("iterate first responses", err)
+	}
+	return responses, nil
+}
+
+// evaluateSLA measures issue against targets as of now. firstResponse is
+// zero if the issue has had no response.
+func evaluateSLA(issue *types.Issue, targets slaTargets, firstResponse time.Time, now time.Time) types.SLAStatus {
+	var responded *time.Time
+	if !firstResponse.IsZero() {
+		responded = &firstResponse
+	}
+	return types.SLAStatus{
+		IssueID:    issue.ID,
+		Priority:   issue.Priority,
+		Response:   evaluateSLATarget(issue.CreatedAt, targets.response, responded, now),
+		Resolution: evaluateSLATarget(issue.CreatedAt, targets.resolution, issue.ClosedAt, now),
+	}
+}
+
+// evaluateSLATarget measures one target running from start, met at metAt
+// (nil if not yet met)
+func evaluateSLATarget(start time.Time, target time.Duration, metAt *time.Time, now time.Time) types.SLATarget {
+	if target <= 0 {
+		return types.SLATarget{State: types.SLANone}
+	}
+	deadline := start.Add(target)
+	result := types.SLATarget{Target: target, Deadline: &deadline, MetAt: metAt}
+	switch {
+	case metAt != nil && !metAt.After(deadline):
+		result.State = types.SLAMet
+	case metAt != nil || now.After(deadline):
+		result.State = types.SLABreached
+	case now.Sub(start) >= time.Duration(float64(target)*SLAAtRiskFraction):
+		result.State = types.SLAAtRisk
+	default:
+		result.State = types.SLAOnTrack
+	}
+	return result
+}
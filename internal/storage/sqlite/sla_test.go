@@ -0,0 +1,101 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestSLAStatus(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	for key, value := range map[string]string{
+		"sla.p0.response":   "1h",
+		"sla.p0.resolution": "1d",
+		"sla.p1.response":   "not a duration",
+	} {
+		if err := store.SetConfig(ctx, key, value); err != nil {
+			t.Fatalf("SetConfig failed: %v", err)
+		}
+	}
+
+	newIssue := func(title string, priority int) *types.Issue {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: priority, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		return issue
+	}
+	status := func(issue *types.Issue) types.SLAStatus {
+		t.Helper()
+		sla, err := store.SLAStatus(ctx, issue.ID)
+		if err != nil {
+			t.Fatalf("SLAStatus failed: %v", err)
+		}
+		return sla
+	}
+
+	waiting := newIssue("Waiting", 0)
+	commented := newIssue("Commented", 0)
+	if _, err := store.AddIssueComment(ctx, commented.ID, "alice", "Looking into it"); err != nil {
+		t.Fatalf("AddIssueComment failed: %v", err)
+	}
+	closed := newIssue("Closed", 0)
+	if err := store.CloseIssue(ctx, closed.ID, "done", "test"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	untracked := newIssue("Untracked", 1)
+
+	start := time.Now()
+	for _, tt := range []struct {
+		after time.Duration
+		want  types.SLAState
+	}{
+		{30 * time.Minute, types.SLAOnTrack},
+		{50 * time.Minute, types.SLAAtRisk},
+		{2 * time.Hour, types.SLABreached},
+	} {
+		store.SetClock(func() time.Time { return start.Add(tt.after) })
+		if got := status(waiting).Response.State; got != tt.want {
+			t.Errorf("Expected response %s after %v, got %s", tt.want, tt.after, got)
+		}
+	}
+
+	if sla := status(commented); sla.Response.State != types.SLAMet || sla.Resolution.State != types.SLAOnTrack {
+		t.Errorf("Expected commented issue met/on track, got %+v", sla)
+	}
+	if sla := status(closed); sla.Response.State != types.SLAMet || sla.Resolution.State != types.SLAMet {
+		t.Errorf("Expected closed issue met/met, got %+v", sla)
+	}
+	if sla := status(untracked); sla.Response.State != types.SLANone || sla.Resolution.State != types.SLANone {
+		t.Errorf("Expected no targets for P1 with an invalid value, got %+v", sla)
+	}
+
+	// Responding after the deadline is still a breach
+	late := newIssue("Late", 0)
+	if _, err := store.db().ExecContext(ctx, `UPDATE issues SET created_at = ? WHERE id = ?`, start.Add(-3*time.Hour), late.ID); err != nil {
+		t.Fatalf("Failed to backdate issue: %v", err)
+	}
+	if err := store.UpdateIssue(ctx, late.ID, map[string]interface{}{"status": string(types.StatusInProgress)}, "test"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	store.SetClock(func() time.Time { return start.Add(2 * time.Hour) })
+	if sla := status(late); sla.Response.State != types.SLABreached || sla.Response.MetAt == nil {
+		t.Errorf("Expected late response breached, got %+v", sla)
+	}
+
+	breached, err := store.BreachedSLAs(ctx)
+	if err != nil {
+		t.Fatalf("BreachedSLAs failed: %v", err)
+	}
+	var ids []string
+	for _, issue := range breached {
+		ids = append(ids, issue.ID)
+	}
+	if !sameIDs(ids, []string{waiting.ID, late.ID}) {
+		t.Errorf("Expected %s and %s breached, got %v", waiting.ID, late.ID, ids)
+	}
+}
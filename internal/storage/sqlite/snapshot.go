@@ -0,0 +1,142 @@
+// Package sqlite - long-lived read snapshots
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// StoreSnapshot is a consistent read-only view of the store, pinned when
+// Snapshot was called. Every query sees the database as it was then,
+// whatever is written afterwards, so tooling can build large reports,
+// exports and diffs from many queries without mixing states. It holds a
+// connection and a read transaction until Close; in WAL mode writers aren't
+// blocked, but checkpoints can't reclaim the WAL past the snapshot, so close
+// it promptly. A StoreSnapshot is not safe for concurrent use.
+type StoreSnapshot struct {
+	tx     *sqliteTxStorage
+	db     *sql.DB
+	parent *SQLiteStorage
+
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// Snapshot opens a StoreSnapshot of the store as it is now. As with
+// ReadSnapshot, if the database file is replaced while the snapshot is open
+// (see EnableFreshnessChecking), the store reconnects to the new file for
+// other reads but the snapshot keeps reading the old one until Close.
+func (s *SQLiteStorage) Snapshot(ctx context.Context) (*StoreSnapshot, error) {
+	s.checkFreshness()
+
+	db, _ := s.pinPool()
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		s.snapshots.release(db)
+		return nil, fmt.Errorf("failed to acquire connection for snapshot: %w", err)
+	}
+	// A deferred transaction only takes its snapshot at its first read, so
+	// read straight away to pin it to now
+	var n int
+	if _, err = conn.ExecContext(ctx, "BEGIN DEFERRED"); err == nil {
+		err = conn.QueryRowContext(ctx, `SELECT COUNT(*) FROM sqlite_master`).Scan(&n)
+		if err != nil {
+			_, _ = conn.ExecContext(context.Background(), "ROLLBACK")
+		}
+	}
+	if err != nil {
+		_ = conn.Close()
+		s.snapshots.release(db)
+		return nil, wrapDBError("begin snapshot", err)
+	}
+
+	return &StoreSnapshot{
+		tx:     &sqliteTxStorage{conn: conn, parent: s},
+		db:     db,
+		parent: s,
+	}, nil
+}
+
+// GetIssue returns the issue with id as of the snapshot, or nil if it didn't
+// exist
+func (ss *StoreSnapshot) GetIssue(ctx context.Context, id string) (*types.Issue, error) {
+	return ss.tx.GetIssue(ctx, id)
+}
+
+// SearchIssues searches the issues as of the snapshot (see
+// SQLiteStorage.SearchIssues)
+func (ss *StoreSnapshot) SearchIssues(ctx context.Context, query string, filter types.IssueFilter) ([]*types.Issue, error) {
+	return ss.tx.SearchIssues(ctx, query, filter)
+}
+
+// GetLabels returns an issue's labels as of the snapshot
+func (ss *StoreSnapshot) GetLabels(ctx context.Context, issueID string) ([]string, error) {
+	return ss.tx.getLabels(ctx, issueID)
+}
+
+// GetDependencies returns the issues issueID depends on as of the snapshot
+// (see SQLiteStorage.GetDependencies)
+func (ss *StoreSnapshot) GetDependencies(ctx context.Context, issueID string) ([]*types.Issue, error) {
+	deps, err := dependenciesWithMetadata(ctx, ss.tx.conn, issueID)
+	if err != nil {
+		return nil, err
+	}
+	return plainIssues(deps), nil
+}
+
+// GetDependents returns the issues that depend on issueID as of the snapshot
+// (see SQLiteStorage.GetDependents)
+func (ss *StoreSnapshot) GetDependents(ctx context.Context, issueID string) ([]*types.Issue, error) {
+	deps, err := dependentsWithMetadata(ctx, ss.tx.conn, issueID)
+	if err != nil {
+		return nil, err
+	}
+	return plainIssues(deps), nil
+}
+
+// GetIssueComments returns an issue's comments as of the snapshot
+func (ss *StoreSnapshot) GetIssueComments(ctx context.Context, issueID string) ([]*types.Comment, error) {
+	return issueComments(ctx, ss.tx.conn, issueID)
+}
+
+// GetReadyWork returns the ready issues as of the snapshot (see
+// SQLiteStorage.GetReadyWork)
+func (ss *StoreSnapshot) GetReadyWork(ctx context.Context, filter types.WorkFilter) ([]*types.Issue, error) {
+	return ss.parent.readyWork(ctx, ss.tx.conn, filter)
+}
+
+// GetBlockedIssues returns the blocked issues as of the snapshot (see
+// SQLiteStorage.GetBlockedIssues)
+func (ss *StoreSnapshot) GetBlockedIssues(ctx context.Context) ([]*types.BlockedIssue, error) {
+	return blockedIssues(ctx, ss.tx.conn)
+}
+
+// GetConfig returns a config value as of the snapshot
+func (ss *StoreSnapshot) GetConfig(ctx context.Context, key string) (string, error) {
+	return ss.tx.GetConfig(ctx, key)
+}
+
+// GetMetadata returns a metadata value as of the snapshot
+func (ss *StoreSnapshot) GetMetadata(ctx context.Context, key string) (string, error) {
+	return ss.tx.GetMetadata(ctx, key)
+}
+
+// Close ends the snapshot and releases its connection. If the store has
+// reconnected to a replaced file since, the old connection pool is closed
+// once its last snapshot ends. Calling Close more than once is a no-op.
+func (ss *StoreSnapshot) Close() error {
+	ss.closeOnce.Do(func() {
+		_, err := ss.tx.conn.ExecContext(context.Background(), "ROLLBACK")
+		if closeErr := ss.tx.conn.Close(); err == nil {
+			err = closeErr
+		}
+		ss.parent.snapshots.release(ss.db)
+		ss.closeErr = wrapDBError("end snapshot", err)
+	})
+	return ss.closeErr
+}
@@ -0,0 +1,215 @@
+package sqlite
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestSnapshotIsolation(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	create := func(title string) *types.Issue {
+		t.Helper()
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		return issue
+	}
+	before := create("Before")
+
+	snap, err := store.Snapshot(ctx)
+	if err != nil {
+		t.Fatalf("Snapshot failed: %v", err)
+	}
+	defer snap.Close()
+
+	// Writes after the snapshot are visible to the store but not the snapshot
+	after := create("After")
+	if err := store.UpdateIssue(ctx, before.ID, map[string]interface{}{"title": "Renamed"}, "test"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if err := store.AddLabel(ctx, before.ID, "late", "test"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+	if err := store.SetConfig(ctx, "custom.report", "v2"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+
+	issues, err := snap.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(issues) != 1 || issues[0].ID != before.ID {
+		t.Errorf("Expected the snapshot to list only %s, got %v", before.ID, issues)
+	}
+	got, err := snap.GetIssue(ctx, before.ID)
+	if err != nil || got == nil || got.Title != "Before" {
+		t.Errorf("Expected the snapshot to see the original title, got %+v (err %v)", got, err)
+	}
+	if got, _ := snap.GetIssue(ctx, after.ID); got != nil {
+		t.Errorf("Expected the snapshot not to see %s", after.ID)
+	}
+	if labels, _ := snap.GetLabels(ctx, before.ID); len(labels) != 0 {
+		t.Errorf("Expected no labels in the snapshot, got %v", labels)
+	}
+	if value, _ := snap.GetConfig(ctx, "custom.report"); value != "" {
+		t.Errorf("Expected config set after the snapshot to be unseen, got %q", value)
+	}
+	if live, _ := store.GetIssue(ctx, before.ID); live == nil || live.Title != "Renamed" {
+		t.Errorf("Expected the store to see the update, got %+v", live)
+	}
+
+	if err := snap.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if err := snap.Close(); err != nil {
+		t.Errorf("Expected a second Close to be a no-op, got %v", err)
+	}
+}
+
+func TestSnapshotRelations(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	create := func(title string) *types.Issue {
+		t.Helper()
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		return issue
+	}
+	blocked := create("Blocked")
+	blocker := create("Blocker")
+	if err := store.AddDependency(ctx, &types.Dependency{IssueID: blocked.ID, DependsOnID: blocker.ID, Type: types.DepBlocks}, "test"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+
+	snap, err := store.Snapshot(ctx)
+	if err != nil {
+		t.Fatalf("Snapshot failed: %v", err)
+	}
+	defer snap.Close()
+
+	// Closing the blocker and commenting after the snapshot changes nothing in it
+	if err := store.CloseIssue(ctx, blocker.ID, "done", "test"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	if _, err := store.AddIssueComment(ctx, blocked.ID, "alice", "Unblocked now"); err != nil {
+		t.Fatalf("AddIssueComment failed: %v", err)
+	}
+
+	if deps, err := snap.GetDependencies(ctx, blocked.ID); err != nil || len(deps) != 1 || deps[0].ID != blocker.ID || deps[0].Status != types.StatusOpen {
+		t.Errorf("Expected the snapshot to see %s open as a dependency, got %v (err %v)", blocker.ID, deps, err)
+	}
+	if deps, err := snap.GetDependents(ctx, blocker.ID); err != nil || len(deps) != 1 || deps[0].ID != blocked.ID {
+		t.Errorf("Expected the snapshot to see %s as a dependent, got %v (err %v)", blocked.ID, deps, err)
+	}
+	if comments, err := snap.GetIssueComments(ctx, blocked.ID); err != nil || len(comments) != 0 {
+		t.Errorf("Expected no comments in the snapshot, got %v (err %v)", comments, err)
+	}
+	ready, err := snap.GetReadyWork(ctx, types.WorkFilter{})
+	if err != nil {
+		t.Fatalf("GetReadyWork failed: %v", err)
+	}
+	if len(ready) != 1 || ready[0].ID != blocker.ID {
+		t.Errorf("Expected only %s ready in the snapshot, got %v", blocker.ID, ready)
+	}
+	blockedIssues, err := snap.GetBlockedIssues(ctx)
+	if err != nil {
+		t.Fatalf("GetBlockedIssues failed: %v", err)
+	}
+	if len(blockedIssues) != 1 || blockedIssues[0].ID != blocked.ID {
+		t.Errorf("Expected %s blocked in the snapshot, got %v", blocked.ID, blockedIssues)
+	}
+
+	// The store itself has moved on
+	if live, _ := store.GetBlockedIssues(ctx); len(live) != 0 {
+		t.Errorf("Expected nothing blocked in the store, got %v", live)
+	}
+}
+
+func TestSnapshotSurvivesReconnect(t *testing.T) {
+	ctx := context.Background()
+	tmpDir := t.TempDir()
+	mainDBPath := filepath.Join(tmpDir, "beads.db")
+	branchDBPath := filepath.Join(tmpDir, "branch", "beads.db")
+
+	createIssues := func(path string, n int) {
+		s, err := New(ctx, path)
+		if err != nil {
+			t.Fatalf("failed to create store: %v", err)
+		}
+		defer s.Close()
+		if err := s.SetConfig(ctx, "issue_prefix", "bd"); err != nil {
+			t.Fatalf("SetConfig failed: %v", err)
+		}
+		for i := 0; i < n; i++ {
+			issue := &types.Issue{Title: "Issue", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+			if err := s.CreateIssue(ctx, issue, "test"); err != nil {
+				t.Fatalf("CreateIssue failed: %v", err)
+			}
+		}
+	}
+	createIssues(mainDBPath, 5)
+	createIssues(branchDBPath, 2)
+
+	store, err := New(ctx, mainDBPath)
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+	defer store.Close()
+	if err := store.EnableFreshnessChecking(FreshnessOptions{}); err != nil {
+		t.Fatalf("failed to enable freshness checking: %v", err)
+	}
+	oldDB := store.UnderlyingDB()
+
+	snap, err := store.Snapshot(ctx)
+	if err != nil {
+		t.Fatalf("Snapshot failed: %v", err)
+	}
+
+	// Simulate a git merge replacing the file while the snapshot is open
+	os.Remove(mainDBPath + "-wal")
+	os.Remove(mainDBPath + "-shm")
+	content, err := os.ReadFile(branchDBPath)
+	if err != nil {
+		t.Fatalf("failed to read branch DB: %v", err)
+	}
+	if err := os.WriteFile(mainDBPath+".new", content, 0644); err != nil {
+		t.Fatalf("failed to write temp file: %v", err)
+	}
+	if err := os.Rename(mainDBPath+".new", mainDBPath); err != nil {
+		t.Fatalf("failed to rename: %v", err)
+	}
+
+	// The store moves on to the new file...
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(issues) != 2 || store.UnderlyingDB() == oldDB {
+		t.Fatalf("Expected the store to reconnect and see 2 issues, got %d", len(issues))
+	}
+	// ...while the snapshot keeps its view of the old one
+	issues, err = snap.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("Snapshot SearchIssues failed after reconnect: %v", err)
+	}
+	if len(issues) != 5 {
+		t.Errorf("Expected the snapshot to still see 5 issues, got %d", len(issues))
+	}
+
+	if err := snap.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if err := oldDB.PingContext(ctx); err == nil {
+		t.Error("Expected the old pool to be closed once the snapshot closed")
+	}
+}
@@ -0,0 +1,80 @@
+// Package sqlite - snoozed issues
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// WakeSnoozedActor is the actor recorded on issues reopened by WakeSnoozed
+const WakeSnoozedActor = "beads-wake"
+
+// SnoozeIssue parks issue id until until: it moves to the snoozed status,
+// which keeps it out of GetReadyWork and of searches that don't set
+// IssueFilter.IncludeSnoozed, and WakeSnoozed reopens it once until has
+// passed. Snoozed issues still block their dependents. Snoozing a snoozed
+// issue moves its wake date. Closed issues can't be snoozed.
+func (s *SQLiteStorage) SnoozeIssue(ctx context.Context, id string, until time.Time, actor string) error {
+	if !until.After(s.now()) {
+		return fmt.Errorf("snooze date %s is not in the future", until.Format(time.RFC3339))
+	}
+	issue, err := s.GetIssue(ctx, id)
+	if err != nil {
+		return err
+	}
+	if issue == nil {
+		return fmt.Errorf("issue %s: %w", id, ErrNotFound)
+	}
+	if issue.Status == types.StatusClosed || issue.Status == types.StatusTombstone {
+		return fmt.Errorf("cannot snooze %s issue %s", issue.Status, id)
+	}
+	return s.UpdateIssue(ctx, id, map[string]interface{}{
+		"status":        string(types.StatusSnoozed),
+		"snoozed_until": until.UTC(),
+	}, actor)
+}
+
+// WakeSnoozed reopens every snoozed issue whose wake date is at or before
+// now, clearing the date, and returns their IDs. Issues snoozed without a
+// wake date stay snoozed. It is meant to run periodically from the daemon;
+// everything happens in one transaction, so overlapping runs don't wake an
+// issue twice.
+func (s *SQLiteStorage) WakeSnoozed(ctx context.Context, now time.Time) ([]string, error) {
+	var woken []string
+	err := s.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		t := tx.(*sqliteTxStorage)
+
+		rows, err := t.conn.QueryContext(ctx, `
+			SELECT id FROM issues
+			WHERE status = ? AND snoozed_until IS NOT NULL
+			  AND julianday(snoozed_until) <= julianday(?)
+			ORDER BY id
+		`, types.StatusSnoozed, now.UTC())
+		if err != nil {
+			return wrapDBError("query due snoozed issues", err)
+		}
+		due, err := scanStrings(rows)
+		if err != nil {
+			return wrapDBError("scan due snoozed issues", err)
+		}
+
+		for _, id := range due {
+			if err := t.UpdateIssue(ctx, id, map[string]interface{}{
+				"status":        string(types.StatusOpen),
+				"snoozed_until": nil,
+			}, WakeSnoozedActor); err != nil {
+				return fmt.Errorf("failed to wake %s: %w", id, err)
+			}
+		}
+		woken = due
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return woken, nil
+}
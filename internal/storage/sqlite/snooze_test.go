@@ -0,0 +1,150 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestSnoozeIssue(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+	now := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	store.SetClock(func() time.Time { return now })
+
+	issue := &types.Issue{Title: "Revisit after launch", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	dependent := &types.Issue{Title: "Follow-up", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	for _, i := range []*types.Issue{issue, dependent} {
+		if err := store.CreateIssue(ctx, i, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	if err := store.AddDependency(ctx, &types.Dependency{IssueID: dependent.ID, DependsOnID: issue.ID, Type: types.DepBlocks}, "test"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+
+	isReady := func(id string) bool {
+		ready, err := store.GetReadyWork(ctx, types.WorkFilter{})
+		if err != nil {
+			t.Fatalf("GetReadyWork failed: %v", err)
+		}
+		for _, r := range ready {
+			if r.ID == id {
+				return true
+			}
+		}
+		return false
+	}
+	search := func(filter types.IssueFilter) []string {
+		issues, err := store.SearchIssues(ctx, "", filter)
+		if err != nil {
+			t.Fatalf("SearchIssues failed: %v", err)
+		}
+		var ids []string
+		for _, i := range issues {
+			ids = append(ids, i.ID)
+		}
+		return ids
+	}
+
+	if err := store.SnoozeIssue(ctx, issue.ID, now.Add(-time.Hour), "test"); err == nil {
+		t.Error("Expected a past snooze date to be rejected")
+	}
+	wake := now.Add(48 * time.Hour)
+	if err := store.SnoozeIssue(ctx, issue.ID, wake, "test"); err != nil {
+		t.Fatalf("SnoozeIssue failed: %v", err)
+	}
+
+	got, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.Status != types.StatusSnoozed || got.SnoozedUntil == nil || !got.SnoozedUntil.Equal(wake) {
+		t.Fatalf("Expected issue snoozed until %v, got status %s until %v", wake, got.Status, got.SnoozedUntil)
+	}
+	if isReady(issue.ID) {
+		t.Error("Expected snoozed issue not to be ready")
+	}
+	if isReady(dependent.ID) {
+		t.Error("Expected snoozed issue to keep blocking its dependent")
+	}
+	if ids := search(types.IssueFilter{}); !sameIDs(ids, []string{dependent.ID}) {
+		t.Errorf("Expected snoozed issue hidden from search, got %v", ids)
+	}
+	if ids := search(types.IssueFilter{IncludeSnoozed: true}); !sameIDs(ids, []string{issue.ID, dependent.ID}) {
+		t.Errorf("Expected IncludeSnoozed to show snoozed issue, got %v", ids)
+	}
+	snoozed := types.StatusSnoozed
+	if ids := search(types.IssueFilter{Status: &snoozed}); !sameIDs(ids, []string{issue.ID}) {
+		t.Errorf("Expected status filter to find snoozed issue, got %v", ids)
+	}
+
+	woken, err := store.WakeSnoozed(ctx, now.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("WakeSnoozed failed: %v", err)
+	}
+	if len(woken) != 0 {
+		t.Errorf("Expected nothing woken before the wake date, got %v", woken)
+	}
+	woken, err = store.WakeSnoozed(ctx, wake)
+	if err != nil {
+		t.Fatalf("WakeSnoozed failed: %v", err)
+	}
+	if !sameIDs(woken, []string{issue.ID}) {
+		t.Fatalf("Expected %s woken, got %v", issue.ID, woken)
+	}
+
+	got, err = store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.Status != types.StatusOpen || got.SnoozedUntil != nil {
+		t.Errorf("Expected woken issue open without a wake date, got status %s until %v", got.Status, got.SnoozedUntil)
+	}
+	if !isReady(issue.ID) {
+		t.Error("Expected woken issue to be ready")
+	}
+	events, err := store.GetEvents(ctx, issue.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	recorded := false
+	for _, e := range events {
+		recorded = recorded || e.Actor == WakeSnoozedActor
+	}
+	if !recorded {
+		t.Errorf("Expected wake recorded as %s", WakeSnoozedActor)
+	}
+}
+
+func TestSnoozeIssueClearedOnStatusChange(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Parked", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := store.SnoozeIssue(ctx, issue.ID, time.Now().Add(time.Hour), "test"); err != nil {
+		t.Fatalf("SnoozeIssue failed: %v", err)
+	}
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"status": string(types.StatusInProgress)}, "test"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	got, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.SnoozedUntil != nil {
+		t.Errorf("Expected leaving snoozed to clear the wake date, got %v", got.SnoozedUntil)
+	}
+
+	if err := store.CloseIssue(ctx, issue.ID, "done", "test"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	if err := store.SnoozeIssue(ctx, issue.ID, time.Now().Add(time.Hour), "test"); err == nil {
+		t.Error("Expected snoozing a closed issue to fail")
+	}
+}
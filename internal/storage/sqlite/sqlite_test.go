@@ -2,8 +2,10 @@ package sqlite
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -982,6 +984,218 @@ func TestSearchIssues(t *testing.T) {
 	}
 }
 
+func TestSearchIssuesGraphPosition(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// epic <- task <- subtask, plus an isolated issue
+	epic := &types.Issue{Title: "Epic", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeEpic}
+	task := &types.Issue{Title: "Task", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	subtask := &types.Issue{Title: "Subtask", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	isolated := &types.Issue{Title: "Isolated", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{epic, task, subtask, isolated} {
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	for _, dep := range []*types.Dependency{
+		{IssueID: task.ID, DependsOnID: epic.ID, Type: types.DepParentChild},
+		{IssueID: subtask.ID, DependsOnID: task.ID, Type: types.DepBlocks},
+	} {
+		if err := store.AddDependency(ctx, dep, "test-user"); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+	}
+
+	ids := func(filter types.IssueFilter) map[string]bool {
+		results, err := store.SearchIssues(ctx, "", filter)
+		if err != nil {
+			t.Fatalf("SearchIssues failed: %v", err)
+		}
+		found := make(map[string]bool)
+		for _, issue := range results {
+			found[issue.ID] = true
+		}
+		return found
+	}
+
+	roots := ids(types.IssueFilter{IsRoot: true})
+	if len(roots) != 2 || !roots[epic.ID] || !roots[isolated.ID] {
+		t.Errorf("Expected roots %s and %s, got %v", epic.ID, isolated.ID, roots)
+	}
+	leaves := ids(types.IssueFilter{IsLeaf: true})
+	if len(leaves) != 2 || !leaves[subtask.ID] || !leaves[isolated.ID] {
+		t.Errorf("Expected leaves %s and %s, got %v", subtask.ID, isolated.ID, leaves)
+	}
+	both := ids(types.IssueFilter{IsRoot: true, IsLeaf: true})
+	if len(both) != 1 || !both[isolated.ID] {
+		t.Errorf("Expected only isolated issue %s to be both, got %v", isolated.ID, both)
+	}
+}
+
+func TestSearchIssuesAge(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	now := time.Now()
+	store.SetClock(func() time.Time { return now })
+
+	old := &types.Issue{Title: "Old", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	fresh := &types.Issue{Title: "Fresh", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{old, fresh} {
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	if _, err := store.db().ExecContext(ctx, `UPDATE issues SET created_at = ? WHERE id = ?`, now.Add(-60*24*time.Hour), old.ID); err != nil {
+		t.Fatalf("Failed to backdate issue: %v", err)
+	}
+
+	titles := func(filter types.IssueFilter) string {
+		results, err := store.SearchIssues(ctx, "", filter)
+		if err != nil {
+			t.Fatalf("SearchIssues failed: %v", err)
+		}
+		var out []string
+		for _, issue := range results {
+			out = append(out, issue.Title)
+		}
+		return strings.Join(out, ",")
+	}
+
+	month := 30 * 24 * time.Hour
+	if got := titles(types.IssueFilter{OlderThan: month}); got != "Old" {
+		t.Errorf("Expected only Old to be older than 30 days, got %q", got)
+	}
+	if got := titles(types.IssueFilter{StalerThan: month}); got != "" {
+		t.Errorf("Expected nothing stale yet, got %q", got)
+	}
+
+	// Advancing the clock ages everything
+	store.SetClock(func() time.Time { return now.Add(45 * 24 * time.Hour) })
+	if got := titles(types.IssueFilter{StalerThan: month, SortBy: []types.SortKey{{Field: types.SortByTitle}}}); got != "Fresh,Old" {
+		t.Errorf("Expected both issues stale after 45 days, got %q", got)
+	}
+
+	got, _ := store.GetIssue(ctx, old.ID)
+	if age := got.AgeDays(now); age != 60 {
+		t.Errorf("Expected AgeDays 60, got %d", age)
+	}
+	if stale := got.StaleDays(now); stale != 0 {
+		t.Errorf("Expected StaleDays 0, got %d", stale)
+	}
+}
+
+func TestSearchIssuesSortBy(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	for _, spec := range []struct {
+		title    string
+		priority int
+		assignee string
+	}{
+		{"Charlie", 2, "bob"},
+		{"Alpha", 1, "alice"},
+		{"Bravo", 2, "alice"},
+	} {
+		issue := &types.Issue{Title: spec.title, Status: types.StatusOpen, Priority: spec.priority, Assignee: spec.assignee, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	titles := func(keys ...types.SortKey) string {
+		results, err := store.SearchIssues(ctx, "", types.IssueFilter{SortBy: keys})
+		if err != nil {
+			t.Fatalf("SearchIssues failed: %v", err)
+		}
+		var out []string
+		for _, issue := range results {
+			out = append(out, issue.Title)
+		}
+		return strings.Join(out, ",")
+	}
+
+	// Default: priority ascending, then newest first
+	if got := titles(); got != "Alpha,Bravo,Charlie" {
+		t.Errorf("Expected default order Alpha,Bravo,Charlie, got %s", got)
+	}
+	if got := titles(types.SortKey{Field: types.SortByAssignee}, types.SortKey{Field: types.SortByPriority, Descending: true}); got != "Bravo,Alpha,Charlie" {
+		t.Errorf("Expected Bravo,Alpha,Charlie, got %s", got)
+	}
+	if got := titles(types.SortKey{Field: types.SortByTitle, Descending: true}); got != "Charlie,Bravo,Alpha" {
+		t.Errorf("Expected Charlie,Bravo,Alpha, got %s", got)
+	}
+
+	if _, err := store.SearchIssues(ctx, "", types.IssueFilter{SortBy: []types.SortKey{{Field: "due_at; DROP TABLE issues"}}}); err == nil {
+		t.Error("Expected unknown sort field to be rejected")
+	}
+}
+
+func TestSearchIssuesPage(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	for i := 0; i < 7; i++ {
+		// Equal priorities leave the order to the ID tie-breaker
+		issue := &types.Issue{Title: fmt.Sprintf("Issue %d", i), Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	sortBy := []types.SortKey{{Field: types.SortByPriority}}
+	all, err := store.SearchIssues(ctx, "", types.IssueFilter{SortBy: sortBy})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+
+	var paged []*types.Issue
+	for offset := 0; offset < len(all); offset += 3 {
+		page, err := store.SearchIssuesPage(ctx, "", types.IssueFilter{SortBy: sortBy, Limit: 3, Offset: offset})
+		if err != nil {
+			t.Fatalf("SearchIssuesPage failed: %v", err)
+		}
+		if page.Total != 7 || page.Offset != offset {
+			t.Errorf("Expected total 7 at offset %d, got %d at %d", offset, page.Total, page.Offset)
+		}
+		paged = append(paged, page.Issues...)
+	}
+	if len(paged) != len(all) {
+		t.Fatalf("Expected pages to cover %d issues, got %d", len(all), len(paged))
+	}
+	for i := range all {
+		if paged[i].ID != all[i].ID {
+			t.Errorf("Expected issue %d to be %s, got %s", i, all[i].ID, paged[i].ID)
+		}
+	}
+
+	// Offset without a limit returns the rest
+	rest, err := store.SearchIssues(ctx, "", types.IssueFilter{SortBy: sortBy, Offset: 5})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(rest) != 2 || rest[0].ID != all[5].ID {
+		t.Errorf("Expected the last 2 issues, got %d", len(rest))
+	}
+
+	// The total counts matches of the filter, not the whole store
+	page, err := store.SearchIssuesPage(ctx, "Issue 3", types.IssueFilter{Limit: 1})
+	if err != nil {
+		t.Fatalf("SearchIssuesPage failed: %v", err)
+	}
+	if page.Total != 1 || len(page.Issues) != 1 {
+		t.Errorf("Expected 1 match, got total %d with %d issues", page.Total, len(page.Issues))
+	}
+}
+
 func TestGetStatistics(t *testing.T) {
 	store, cleanup := setupTestDB(t)
 	defer cleanup()
@@ -1448,12 +1662,12 @@ func TestGetAllConfig(t *testing.T) {
 	ctx := context.Background()
 
 	// Set multiple config values
-	err := store.SetConfig(ctx, "key1", "value1")
+	err := store.SetConfig(ctx, "custom.key1", "value1")
 	if err != nil {
 		t.Fatalf("SetConfig key1 failed: %v", err)
 	}
 
-	err = store.SetConfig(ctx, "key2", "value2")
+	err = store.SetConfig(ctx, "custom.key2", "value2")
 	if err != nil {
 		t.Fatalf("SetConfig key2 failed: %v", err)
 	}
@@ -1468,12 +1682,12 @@ func TestGetAllConfig(t *testing.T) {
 		t.Errorf("Expected at least 2 config entries, got %d", len(allConfig))
 	}
 
-	if allConfig["key1"] != "value1" {
-		t.Errorf("Expected key1=value1, got %s", allConfig["key1"])
+	if allConfig["custom.key1"] != "value1" {
+		t.Errorf("Expected key1=value1, got %s", allConfig["custom.key1"])
 	}
 
-	if allConfig["key2"] != "value2" {
-		t.Errorf("Expected key2=value2, got %s", allConfig["key2"])
+	if allConfig["custom.key2"] != "value2" {
+		t.Errorf("Expected key2=value2, got %s", allConfig["custom.key2"])
 	}
 }
 
@@ -1484,13 +1698,13 @@ func TestDeleteConfig(t *testing.T) {
 	ctx := context.Background()
 
 	// Set a config value
-	err := store.SetConfig(ctx, "test-key", "test-value")
+	err := store.SetConfig(ctx, "custom.test_key", "test-value")
 	if err != nil {
 		t.Fatalf("SetConfig failed: %v", err)
 	}
 
 	// Verify it exists
-	value, err := store.GetConfig(ctx, "test-key")
+	value, err := store.GetConfig(ctx, "custom.test_key")
 	if err != nil {
 		t.Fatalf("GetConfig failed: %v", err)
 	}
@@ -1499,13 +1713,13 @@ func TestDeleteConfig(t *testing.T) {
 	}
 
 	// Delete it
-	err = store.DeleteConfig(ctx, "test-key")
+	err = store.DeleteConfig(ctx, "custom.test_key")
 	if err != nil {
 		t.Fatalf("DeleteConfig failed: %v", err)
 	}
 
 	// Verify it's gone
-	value, err = store.GetConfig(ctx, "test-key")
+	value, err = store.GetConfig(ctx, "custom.test_key")
 	if err != nil {
 		t.Fatalf("GetConfig failed: %v", err)
 	}
@@ -0,0 +1,142 @@
+// Package sqlite - status display metadata and board lanes
+package sqlite
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// defaultStatusMeta is the metadata of the built-in statuses before any
+// StatusMetaConfigPrefix overrides. Custom statuses default to the doing
+// category, ordered between blocked and snoozed in their configured order.
+var defaultStatusMeta = map[types.Status]types.StatusMeta{
+	types.StatusOpen:       {Status: types.StatusOpen, Category: types.CategoryTodo},
+	types.StatusInProgress: {Status: types.StatusInProgress, Category: types.CategoryDoing, Color: "yellow"},
+	types.StatusBlocked:    {Status: types.StatusBlocked, Category: types.CategoryDoing, Color: "red"},
+	types.StatusSnoozed:    {Status: types.StatusSnoozed, Category: types.CategoryTodo, Color: "gray"},
+	types.StatusClosed:     {Status: types.StatusClosed, Category: types.CategoryDone, Color: "green"},
+}
+
+// StatusMeta returns the display metadata of every status, built-in and
+// custom, sorted by Order. Defaults are overridden per status by the
+// StatusMetaConfigPrefix config keys; unknown fields, invalid values and
+// keys for statuses that don't exist are ignored.
+func (s *SQLiteStorage) StatusMeta(ctx context.Context) ([]types.StatusMeta, error) {
+	custom, err := s.GetCustomStatuses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := []types.Status{types.StatusOpen, types.StatusInProgress, types.StatusBlocked}
+	for _, status := range custom {
+		statuses = append(statuses, types.Status(status))
+	}
+	statuses = append(statuses, types.StatusSnoozed, types.StatusClosed)
+
+	metas := make(map[types.Status]*types.StatusMeta, len(statuses))
+	for i, status := range statuses {
+		meta, ok := defaultStatusMeta[status]
+		if !ok {
+			meta = types.StatusMeta{Status: status, Category: types.CategoryDoing}
+		}
+		meta.Order = i
+		metas[status] = &meta
+	}
+
+	rows, err := s.db().QueryContext(ctx, `SELECT key, value FROM config WHERE key LIKE ? ESCAPE '\'`,
+		strings.ReplaceAll(StatusMetaConfigPrefix, "_", `\_`)+"%")
+	if err != nil {
+		return nil, wrapDBError("query status metadata", err)
+	}
+	defer func() { _ = rows.Close() }()
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, wrapDBError("scan status metadata", err)
+		}
+		if meta, ok := metas[types.Status(strings.TrimPrefix(key, StatusMetaConfigPrefix))]; ok {
+			applyStatusMeta(meta, value)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, wrapDBError("iterate status metadata", err)
+	}
+
+	result := make([]types.StatusMeta, 0, len(statuses))
+	for _, status := range statuses {
+		result = append(result, *metas[status])
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Order < result[j].Order })
+	return result, nil
+}
+
+// applyStatusMeta applies a "category=doing,color=red,order=3" override to meta
+func applyStatusMeta(meta *types.StatusMeta, value string) {
+	for _, field := range strings.Split(value, ",") {
+		name, val, ok := strings.Cut(field, "=")
+		if !ok {
+			continue
+		}
+		val = strings.TrimSpace(val)
+		switch strings.TrimSpace(name) {
+		case "category":
+			if category := types.StatusCategory(val); category.IsValid() {
+				meta.Category = category
+			}
+		case "color":
+			meta.Color = val
+		case "order":
+			if order, err := strconv.Atoi(val); err == nil {
+				meta.Order = order
+			}
+		}
+	}
+}
+
+// Board returns the issues matching filter grouped into one lane per status
+// category (see types.StatusCategories), using StatusMeta for the mapping.
+// Within a lane issues are ordered by their status's Order, keeping
+// SearchIssues order within a status. Every lane is returned, even if empty.
+func (s *SQLiteStorage) Board(ctx context.Context, filter types.IssueFilter) ([]types.BoardLane, error) {
+	metas, err := s.StatusMeta(ctx)
+	if err != nil {
+		return nil, err
+	}
+	issues, err := s.SearchIssues(ctx, "", filter)
+	if err != nil {
+		return nil, err
+	}
+
+	lanes := make([]types.BoardLane, len(types.StatusCategories))
+	laneIndex := make(map[types.StatusCategory]int, len(lanes))
+	for i, category := range types.StatusCategories {
+		lanes[i] = types.BoardLane{Category: category, Statuses: []types.StatusMeta{}, Issues: []*types.Issue{}}
+		laneIndex[category] = i
+	}
+	byStatus := make(map[types.Status]types.StatusMeta, len(metas))
+	for _, meta := range metas {
+		byStatus[meta.Status] = meta
+		lane := &lanes[laneIndex[meta.Category]]
+		lane.Statuses = append(lane.Statuses, meta)
+	}
+
+	for _, issue := range issues {
+		meta, ok := byStatus[issue.Status]
+		if !ok {
+			continue
+		}
+		lane := &lanes[laneIndex[meta.Category]]
+		lane.Issues = append(lane.Issues, issue)
+	}
+	for i := range lanes {
+		issues := lanes[i].Issues
+		sort.SliceStable(issues, func(a, b int) bool {
+			return byStatus[issues[a].Status].Order < byStatus[issues[b].Status].Order
+		})
+	}
+	return lanes, nil
+}
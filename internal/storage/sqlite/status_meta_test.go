@@ -0,0 +1,83 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestStatusMetaAndBoard(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	if err := store.SetConfig(ctx, CustomStatusConfigKey, "review"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	for key, value := range map[string]string{
+		StatusMetaConfigPrefix + "review":  "color=#f0ad4e, order=10",
+		StatusMetaConfigPrefix + "blocked": "category=todo,order=oops",
+		StatusMetaConfigPrefix + "unknown": "category=done",
+	} {
+		if err := store.SetConfig(ctx, key, value); err != nil {
+			t.Fatalf("SetConfig failed: %v", err)
+		}
+	}
+
+	metas, err := store.StatusMeta(ctx)
+	if err != nil {
+		t.Fatalf("StatusMeta failed: %v", err)
+	}
+	var order []types.Status
+	byStatus := make(map[types.Status]types.StatusMeta)
+	for _, meta := range metas {
+		order = append(order, meta.Status)
+		byStatus[meta.Status] = meta
+	}
+	want := []types.Status{types.StatusOpen, types.StatusInProgress, types.StatusBlocked, types.StatusSnoozed, types.StatusClosed, "review"}
+	if len(order) != len(want) {
+		t.Fatalf("Expected statuses %v, got %v", want, order)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("Expected statuses %v, got %v", want, order)
+		}
+	}
+	if review := byStatus["review"]; review.Category != types.CategoryDoing || review.Color != "#f0ad4e" {
+		t.Errorf("Expected review in doing with its color, got %+v", review)
+	}
+	if blocked := byStatus[types.StatusBlocked]; blocked.Category != types.CategoryTodo || blocked.Order != 2 {
+		t.Errorf("Expected blocked moved to todo keeping its order, got %+v", blocked)
+	}
+
+	for _, status := range []types.Status{types.StatusOpen, types.StatusBlocked, "review", types.StatusInProgress} {
+		issue := &types.Issue{Title: string(status), Status: status, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	lanes, err := store.Board(ctx, types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("Board failed: %v", err)
+	}
+	if len(lanes) != 3 {
+		t.Fatalf("Expected 3 lanes, got %d", len(lanes))
+	}
+	titles := func(lane types.BoardLane) []string {
+		var result []string
+		for _, issue := range lane.Issues {
+			result = append(result, issue.Title)
+		}
+		return result
+	}
+	if got := titles(lanes[0]); lanes[0].Category != types.CategoryTodo || len(got) != 2 || got[0] != "open" || got[1] != "blocked" {
+		t.Errorf("Expected todo lane [open blocked], got %s %v", lanes[0].Category, got)
+	}
+	if got := titles(lanes[1]); lanes[1].Category != types.CategoryDoing || len(got) != 2 || got[0] != "in_progress" || got[1] != "review" {
+		t.Errorf("Expected doing lane [in_progress review], got %s %v", lanes[1].Category, got)
+	}
+	if lanes[2].Category != types.CategoryDone || len(lanes[2].Issues) != 0 || len(lanes[2].Statuses) != 1 {
+		t.Errorf("Expected empty done lane with the closed status, got %+v", lanes[2])
+	}
+}
@@ -0,0 +1,80 @@
+// Package sqlite - prepared statement cache for the read path
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+)
+
+// maxCachedStatements bounds the statements a stmtCache prepares. Search
+// queries vary in shape with their filters, so once the cache is full new
+// shapes run unprepared rather than evicting statements that may be in use.
+const maxCachedStatements = 256
+
+// stmtCache runs queries on one connection pool through prepared statements
+// keyed by SQL text. Args are bound to placeholders, so queries of the same
+// shape share a statement, which each connection of the pool parses once
+// instead of on every call. It satisfies queryer, so read helpers take it in
+// place of the pool. The store replaces it along with the pool on reconnect
+// (see swapConnection), and closing the old pool finalizes its statements.
+type stmtCache struct {
+	db    *sql.DB
+	max   int // Statements to cache at most; 0 runs every query unprepared
+	mu    sync.RWMutex
+	stmts map[string]*sql.Stmt
+}
+
+func newStmtCache(db *sql.DB, max int) *stmtCache {
+	return &stmtCache{db: db, max: max, stmts: make(map[string]*sql.Stmt)}
+}
+
+// stmt returns the prepared statement for query, preparing it on first use,
+// or nil if the cache is full or preparing failed (the caller then runs the
+// query unprepared, which reports any error in it)
+func (c *stmtCache) stmt(ctx context.Context, query string) *sql.Stmt {
+	c.mu.RLock()
+	st, ok := c.stmts[query]
+	c.mu.RUnlock()
+	if ok {
+		return st
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if st, ok := c.stmts[query]; ok {
+		return st
+	}
+	if len(c.stmts) >= c.max {
+		return nil
+	}
+	st, err := c.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil
+	}
+	c.stmts[query] = st
+	return st
+}
+
+// QueryContext runs query through its cached statement
+func (c *stmtCache) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if st := c.stmt(ctx, query); st != nil {
+		return st.QueryContext(ctx, args...)
+	}
+	return c.db.QueryContext(ctx, query, args...)
+}
+
+// QueryRowContext runs query through its cached statement
+func (c *stmtCache) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	if st := c.stmt(ctx, query); st != nil {
+		return st.QueryRowContext(ctx, args...)
+	}
+	return c.db.QueryRowContext(ctx, query, args...)
+}
+
+// len returns the number of cached statements
+func (c *stmtCache) len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.stmts)
+}
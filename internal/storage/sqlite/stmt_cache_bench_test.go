@@ -0,0 +1,50 @@
+//go:build bench
+
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// BenchmarkGetIssue compares GetIssue with cached prepared statements against
+// re-parsing its queries on every call, across parallel readers
+func BenchmarkGetIssue(b *testing.B) {
+	store, cleanup := setupBenchDB(b)
+	defer cleanup()
+	ctx := context.Background()
+
+	ids := make([]string, 100)
+	for i := range ids {
+		issue := &types.Issue{Title: "Benchmark issue", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			b.Fatalf("Failed to create issue: %v", err)
+		}
+		ids[i] = issue.ID
+	}
+
+	for _, bc := range []struct {
+		name string
+		max  int
+	}{
+		{"unprepared", 0},
+		{"cached", maxCachedStatements},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			store.pool.Store(&connPool{db: store.db(), stmts: newStmtCache(store.db(), bc.max)})
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				i := 0
+				for pb.Next() {
+					if _, err := store.GetIssue(ctx, ids[i%len(ids)]); err != nil {
+						b.Errorf("GetIssue failed: %v", err)
+						return
+					}
+					i++
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestStmtCache(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Cached", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	// Queries of one shape share a statement whatever their args
+	cache := newStmtCache(store.db(), 2)
+	for _, id := range []string{issue.ID, "bd-missing", issue.ID} {
+		var n int
+		if err := cache.QueryRowContext(ctx, `SELECT COUNT(*) FROM issues WHERE id = ?`, id).Scan(&n); err != nil {
+			t.Fatalf("QueryRowContext failed: %v", err)
+		}
+	}
+	if cache.len() != 1 {
+		t.Errorf("Expected 1 cached statement, got %d", cache.len())
+	}
+
+	// Past the limit, new shapes still run, unprepared
+	for _, query := range []string{`SELECT COUNT(*) FROM labels`, `SELECT COUNT(*) FROM comments`} {
+		var n int
+		if err := cache.QueryRowContext(ctx, query).Scan(&n); err != nil {
+			t.Fatalf("QueryRowContext failed for %q: %v", query, err)
+		}
+	}
+	if cache.len() != 2 {
+		t.Errorf("Expected the cache to stop at 2 statements, got %d", cache.len())
+	}
+
+	// Invalid SQL reports its error rather than being cached
+	if _, err := cache.QueryContext(ctx, `SELECT nope FROM nowhere`); err == nil {
+		t.Error("Expected an error for invalid SQL")
+	}
+
+	// Reconnecting replaces the cache along with the pool
+	if err := store.EnableFreshnessChecking(FreshnessOptions{}); err != nil {
+		t.Fatalf("EnableFreshnessChecking failed: %v", err)
+	}
+	if _, err := store.GetIssue(ctx, issue.ID); err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	oldCache := store.stmts()
+	store.PauseFreshness()
+	store.ResumeFreshness()
+	if store.stmts() == oldCache || store.stmts().db != store.db() {
+		t.Fatal("Expected reconnecting to start a cache on the new pool")
+	}
+	if got, err := store.GetIssue(ctx, issue.ID); err != nil || got == nil {
+		t.Errorf("Expected GetIssue to work after reconnecting, got %v (err %v)", got, err)
+	}
+}
@@ -4,11 +4,13 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -21,9 +23,34 @@ import (
 
 // SQLiteStorage implements the Storage interface using SQLite
 type SQLiteStorage struct {
-	db     *sql.DB
-	dbPath string
-	closed atomic.Bool // Tracks whether Close() has been called
+	pool       atomic.Pointer[connPool] // Current connection pool; see db()
+	dbPath     string
+	connStr    string      // Connection string used to (re)open db
+	isInMemory bool        // In-memory databases never need freshness checks
+	closed     atomic.Bool // Tracks whether Close() has been called
+
+	queryTimeout atomic.Int64 // Per-operation timeout in nanoseconds (see timeout.go)
+
+	// Busy-retry policies and counters (see busy_retry.go)
+	readRetry       atomic.Pointer[RetryPolicy]
+	writeRetry      atomic.Pointer[RetryPolicy]
+	readRetries     atomic.Int64
+	readBusyErrors  atomic.Int64
+	writeRetries    atomic.Int64
+	writeBusyErrors atomic.Int64
+
+	clock atomic.Pointer[func() time.Time] // Overrides time.Now (see clock.go)
+
+	// Freshness checking (see freshness.go). reconnectMu serializes swapping
+	// pool when the database file is replaced underneath us (e.g. by a git
+	// merge); readers load pool atomically without taking it.
+	freshness   *FreshnessChecker
+	reconnectMu sync.RWMutex
+	snapshots   snapshotReaders // Keeps replaced pools open for in-flight ReadSnapshot calls and open Snapshots
+
+	// Deterministic ID generation (see StoreOptions.IDSeed)
+	idSeed int64
+	idSeq  atomic.Int64
 }
 
 // setupWASMCache configures WASM compilation caching to reduce SQLite startup time.
@@ -81,6 +108,42 @@ func New(ctx context.Context, path string) (*SQLiteStorage, error) {
 // NewWithTimeout creates a new SQLite storage backend with configurable busy timeout.
 // A timeout of 0 means fail immediately if the database is locked.
 func NewWithTimeout(ctx context.Context, path string, busyTimeout time.Duration) (*SQLiteStorage, error) {
+	return newStorage(ctx, path, busyTimeout, "")
+}
+
+// StoreOptions configures NewWithOptions
+type StoreOptions struct {
+	// BusyTimeout is how long to wait for a locked database (default 30s)
+	BusyTimeout time.Duration
+
+	// EncryptionKey, if set, encrypts the database, its journal and its WAL
+	// at rest. The same key must be given on every open; opening with a
+	// different key or none at all fails with ErrBadKey. In-memory databases
+	// can't be encrypted.
+	EncryptionKey string
+
+	// IDSeed, if non-zero, makes generated issue IDs deterministic: a store
+	// opened with the same seed yields the same IDs for the same sequence of
+	// creates, for golden-file tests. Leave it zero in production, where IDs
+	// draw on the creation time.
+	IDSeed int64
+}
+
+// NewWithOptions creates a new SQLite storage backend configured by opts
+func NewWithOptions(ctx context.Context, path string, opts StoreOptions) (*SQLiteStorage, error) {
+	busyTimeout := opts.BusyTimeout
+	if busyTimeout == 0 {
+		busyTimeout = 30 * time.Second
+	}
+	store, err := newStorage(ctx, path, busyTimeout, opts.EncryptionKey)
+	if err != nil {
+		return nil, err
+	}
+	store.idSeed = opts.IDSeed
+	return store, nil
+}
+
+func newStorage(ctx context.Context, path string, busyTimeout time.Duration, encryptionKey string) (*SQLiteStorage, error) {
 	// Convert timeout to milliseconds for SQLite pragma
 	timeoutMs := int64(busyTimeout / time.Millisecond)
 
@@ -108,65 +171,40 @@ func NewWithTimeout(ctx context.Context, path string, busyTimeout time.Duration)
 		connStr = fmt.Sprintf("file:%s?_pragma=foreign_keys(ON)&_pragma=busy_timeout(%d)&_time_format=sqlite", path, timeoutMs)
 	}
 
-	db, err := sql.Open("sqlite3", connStr)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open database: %w", err)
-	}
-
 	// For all in-memory databases (including file::memory:), force single connection.
 	// SQLite's in-memory databases are isolated per connection by default.
 	// Without this, different connections in the pool can't see each other's writes (bd-b121, bd-yvlc).
 	isInMemory := path == ":memory:" ||
 		(strings.HasPrefix(path, "file:") && strings.Contains(path, "mode=memory"))
-	if isInMemory {
-		db.SetMaxOpenConns(1)
-		db.SetMaxIdleConns(1)
-	} else {
-		// For file-based databases in daemon mode, limit connection pool to prevent
-		// connection exhaustion under concurrent load. SQLite WAL mode supports
-		// 1 writer + unlimited readers, but we limit to prevent goroutine pile-up
-		// on write lock contention (bd-qhws).
-		maxConns := runtime.NumCPU() + 1 // 1 writer + N readers
-		db.SetMaxOpenConns(maxConns)
-		db.SetMaxIdleConns(2)
-		db.SetConnMaxLifetime(0) // SQLite doesn't need connection recycling
-	}
 
-	// For file-based databases, enable WAL mode once after opening the connection.
-	if !isInMemory {
-		if _, err := db.Exec("PRAGMA journal_mode=WAL"); err != nil {
-			return nil, fmt.Errorf("failed to enable WAL mode: %w", err)
+	if encryptionKey != "" {
+		if isInMemory {
+			return nil, fmt.Errorf("in-memory databases can't be encrypted")
 		}
+		hexKey, err := deriveEncryptionKey(encryptionKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive encryption key: %w", err)
+		}
+		sep := "?"
+		if strings.Contains(connStr, "?") {
+			sep = "&"
+		}
+		connStr += sep + "vfs=" + encryptedVFSName + "&hexkey=" + hexKey
 	}
 
-	// Test connection
-	if err := db.Ping(); err != nil {
-		return nil, fmt.Errorf("failed to ping database: %w", err)
-	}
-
-	// Initialize schema
-	if _, err := db.Exec(schema); err != nil {
-		return nil, fmt.Errorf("failed to initialize schema: %w", err)
-	}
-
-	// Run all migrations
-	if err := RunMigrations(db); err != nil {
+	db, err := openDB(connStr, isInMemory)
+	if err != nil {
+		// A page that doesn't decrypt to a valid header means the key is
+		// wrong, or the database is encrypted and no key was given
+		if errors.Is(err, sqlite3.NOTADB) {
+			return nil, fmt.Errorf("failed to open %s: %w", path, ErrBadKey)
+		}
 		return nil, err
 	}
 
-	// Verify schema compatibility after migrations (bd-ckvw)
-	// First attempt
-	if err := verifySchemaCompatibility(db); err != nil {
-		// Schema probe failed - retry migrations once
-		if retryErr := RunMigrations(db); retryErr != nil {
-			return nil, fmt.Errorf("migration retry failed after schema probe failure: %w (original: %w)", retryErr, err)
-		}
-
-		// Probe again after retry
-		if err := verifySchemaCompatibility(db); err != nil {
-			// Still failing - return fatal error with clear message
-			return nil, fmt.Errorf("schema probe failed after migration retry: %w. Database may be corrupted or from incompatible version. Run 'bd doctor' to diagnose", err)
-		}
+	if err := initSchema(ctx, db); err != nil {
+		_ = db.Close()
+		return nil, err
 	}
 
 	// Convert to absolute path for consistency (but keep :memory: as-is)
@@ -180,9 +218,11 @@ func NewWithTimeout(ctx context.Context, path string, busyTimeout time.Duration)
 	}
 
 	storage := &SQLiteStorage{
-		db:     db,
-		dbPath: absPath,
+		dbPath:     absPath,
+		connStr:    connStr,
+		isInMemory: isInMemory,
 	}
+	storage.setPool(db)
 
 	// Hydrate from multi-repo config if configured (bd-307)
 	// Skip for in-memory databases (used in tests)
@@ -196,14 +236,160 @@ func NewWithTimeout(ctx context.Context, path string, busyTimeout time.Duration)
 	return storage, nil
 }
 
+// openReadOnly opens the database at path for reading only, as Reconcile
+// opens its base and theirs inputs. Nothing in the file is created, migrated
+// or hydrated, so it must already be at this bd's schema version; an older
+// database is refused rather than upgraded.
+func openReadOnly(ctx context.Context, path string) (*SQLiteStorage, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get absolute path: %w", err)
+	}
+	connStr := fmt.Sprintf("file:%s?mode=ro&_pragma=foreign_keys(ON)&_pragma=busy_timeout(30000)&_time_format=sqlite", absPath)
+	db, err := sql.Open("sqlite3", connStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	db.SetMaxIdleConns(2)
+
+	version, err := schemaVersion(db)
+	if err == nil {
+		err = checkVersionSupported(version)
+	}
+	if err == nil && version < LatestSchemaVersion() {
+		err = fmt.Errorf("%w: database is at version %d, this bd reads version %d (open it with bd to migrate it)",
+			ErrSchemaIncompatible, version, LatestSchemaVersion())
+	}
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	storage := &SQLiteStorage{dbPath: absPath, connStr: connStr}
+	storage.setPool(db)
+	return storage, nil
+}
+
+// connPool is a connection pool and the prepared statements cached on it,
+// which a freshness reconnect swaps as a unit
+type connPool struct {
+	db    *sql.DB
+	stmts *stmtCache
+}
+
+// db returns the connection pool the store currently uses. A freshness
+// reconnect may swap it at any moment, so callers load it per query rather
+// than holding on to it.
+func (s *SQLiteStorage) db() *sql.DB {
+	return s.pool.Load().db
+}
+
+// stmts returns the prepared statement cache on the current pool
+func (s *SQLiteStorage) stmts() *stmtCache {
+	return s.pool.Load().stmts
+}
+
+// setPool makes db the store's connection pool, with an empty statement cache
+func (s *SQLiteStorage) setPool(db *sql.DB) {
+	s.pool.Store(&connPool{db: db, stmts: newStmtCache(db, maxCachedStatements)})
+}
+
+// initSchema brings the database on db up to the current schema: it creates
+// any missing tables, applies pending migrations and probes the result. It
+// runs when the store opens and again whenever it reconnects to a replaced
+// file, which may have been written by an older bd. A database migrated by a
+// newer bd is refused with ErrSchemaTooNew before anything in it is touched.
+func initSchema(ctx context.Context, db *sql.DB) error {
+	if err := checkSchemaVersionSupported(db); err != nil {
+		return err
+	}
+
+	// Initialize schema
+	if _, err := db.ExecContext(ctx, schema); err != nil {
+		return fmt.Errorf("failed to initialize schema: %w", err)
+	}
+
+	// Run all migrations
+	if err := RunMigrations(db); err != nil {
+		return err
+	}
+
+	// Verify schema compatibility after migrations (bd-ckvw)
+	// First attempt
+	if err := verifySchemaCompatibility(db); err != nil {
+		// Schema probe failed - re-run all migrations once, even ones recorded as applied
+		if retryErr := runMigrations(db, true); retryErr != nil {
+			return fmt.Errorf("migration retry failed after schema probe failure: %w (original: %w)", retryErr, err)
+		}
+
+		// Probe again after retry
+		if err := verifySchemaCompatibility(db); err != nil {
+			// Still failing - return fatal error with clear message
+			return fmt.Errorf("schema probe failed after migration retry: %w. Database may be corrupted or from incompatible version. Run 'bd doctor' to diagnose", err)
+		}
+	}
+	return nil
+}
+
+// openDB opens a connection pool for connStr, applies the pool limits and
+// journal mode appropriate for the database kind, and verifies the connection.
+// Used both for the initial open and when the freshness checker reconnects.
+func openDB(connStr string, isInMemory bool) (*sql.DB, error) {
+	db, err := sql.Open("sqlite3", connStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	if isInMemory {
+		db.SetMaxOpenConns(1)
+		db.SetMaxIdleConns(1)
+	} else {
+		// For file-based databases in daemon mode, limit connection pool to prevent
+		// connection exhaustion under concurrent load. SQLite WAL mode supports
+		// 1 writer + unlimited readers, but we limit to prevent goroutine pile-up
+		// on write lock contention (bd-qhws).
+		maxConns := runtime.NumCPU() + 1 // 1 writer + N readers
+		db.SetMaxOpenConns(maxConns)
+		db.SetMaxIdleConns(2)
+		db.SetConnMaxLifetime(0) // SQLite doesn't need connection recycling
+	}
+
+	// For file-based databases, enable WAL mode once after opening the connection.
+	if !isInMemory {
+		if _, err := db.Exec("PRAGMA journal_mode=WAL"); err != nil {
+			_ = db.Close()
+			return nil, fmt.Errorf("failed to enable WAL mode: %w", err)
+		}
+	}
+
+	// Test connection
+	if err := db.Ping(); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	return db, nil
+}
+
 // Close closes the database connection.
 // It checkpoints the WAL to ensure all writes are flushed to the main database file.
 func (s *SQLiteStorage) Close() error {
 	s.closed.Store(true)
+	// Stop the freshness poller first: it takes the reconnect lock
+	s.reconnectMu.RLock()
+	fc := s.freshness
+	s.reconnectMu.RUnlock()
+	if fc != nil {
+		fc.stopPolling()
+	}
+	// Hold the reconnect lock so a concurrent freshness reconnect can't swap
+	// the pool out from under us while we're closing it.
+	s.reconnectMu.Lock()
+	defer s.reconnectMu.Unlock()
 	// Checkpoint WAL to ensure all writes are persisted to the main database file.
 	// Without this, writes may be stranded in the WAL and lost between CLI invocations.
-	_, _ = s.db.Exec("PRAGMA wal_checkpoint(TRUNCATE)")
-	return s.db.Close()
+	_, _ = s.db().Exec("PRAGMA wal_checkpoint(TRUNCATE)")
+	return s.db().Close()
 }
 
 // Path returns the absolute path to the database file
@@ -268,7 +454,7 @@ func (s *SQLiteStorage) IsClosed() bool {
 //	    CREATE INDEX IF NOT EXISTS idx_vc_executions_issue ON vc_executions(issue_id);
 //	`)
 func (s *SQLiteStorage) UnderlyingDB() *sql.DB {
-	return s.db
+	return s.db()
 }
 
 // UnderlyingConn returns a single connection from the pool for scoped use.
@@ -305,7 +491,7 @@ func (s *SQLiteStorage) UnderlyingDB() *sql.DB {
 //	    )
 //	`)
 func (s *SQLiteStorage) UnderlyingConn(ctx context.Context) (*sql.Conn, error) {
-	return s.db.Conn(ctx)
+	return s.db().Conn(ctx)
 }
 
 // CheckpointWAL checkpoints the WAL file to flush changes to the main database file.
@@ -315,6 +501,6 @@ func (s *SQLiteStorage) UnderlyingConn(ctx context.Context) (*sql.Conn, error) {
 // - Reduces WAL file size
 // - Makes database safe for backup/copy operations
 func (s *SQLiteStorage) CheckpointWAL(ctx context.Context) error {
-	_, err := s.db.ExecContext(ctx, "PRAGMA wal_checkpoint(FULL)")
+	_, err := s.db().ExecContext(ctx, "PRAGMA wal_checkpoint(FULL)")
 	return err
 }
@@ -0,0 +1,138 @@
+// Package sqlite - change log subscriptions
+package sqlite
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/steveyegge/beads/internal/debug"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// subscribePollInterval is how often a Subscribe goroutine checks the events
+// table once it has caught up. Polling, rather than hooking writes, also
+// picks up changes made by other processes and survives freshness
+// reconnects, since every poll goes through the current connection.
+const subscribePollInterval = 200 * time.Millisecond
+
+// subscribeBatchSize is how many events a Subscribe goroutine reads per query
+const subscribeBatchSize = 500
+
+// Subscribe streams the creates, updates, status changes, deletions and
+// overdue notices (see MarkOverdue) recorded after afterSeq, oldest first,
+// and then follows new ones as they are written, until ctx is done or the
+// store is closed, when the channel is closed. Seq is the events table id (a
+// revision, as in ChangesSince), so a subscriber that persists the Seq of the
+// last event it handled resumes without gaps by passing it back, e.g. after a
+// restart or reconnect; 0 replays the whole log. Other events, such as
+// comments and label changes, are skipped.
+func (s *SQLiteStorage) Subscribe(ctx context.Context, afterSeq int64) (<-chan types.ChangeEvent, error) {
+	if afterSeq < 0 {
+		return nil, fmt.Errorf("invalid sequence number %d", afterSeq)
+	}
+	// Fail up front, rather than with a silently closed channel, if the log
+	// can't be read
+	if _, err := s.EventsSince(ctx, afterSeq, 1); err != nil {
+		return nil, err
+	}
+
+	ch := make(chan types.ChangeEvent, 64)
+	go s.followChanges(ctx, afterSeq, ch)
+	return ch, nil
+}
+
+// followChanges feeds ch for Subscribe and closes it when done
+func (s *SQLiteStorage) followChanges(ctx context.Context, last int64, ch chan<- types.ChangeEvent) {
+	defer close(ch)
+	ticker := time.NewTicker(subscribePollInterval)
+	defer ticker.Stop()
+
+	for {
+		if ctx.Err() != nil || s.closed.Load() {
+			return
+		}
+		s.checkFreshness()
+		events, err := s.EventsSince(ctx, last, subscribeBatchSize)
+		if err != nil {
+			if ctx.Err() != nil || s.closed.Load() {
+				return
+			}
+			// Transient (e.g. busy); try again on the next tick
+			debug.Logf("change subscription: failed to read events after %d: %v", last, err)
+		}
+		for _, event := range events {
+			last = event.ID
+			change, ok := changeEventFor(event)
+			if !ok {
+				continue
+			}
+			select {
+			case ch <- change:
+			case <-ctx.Done():
+				return
+			}
+		}
+		if len(events) == subscribeBatchSize {
+			continue // More are waiting
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// changeEventFor converts an events table entry to a ChangeEvent, or reports
+// false for events that aren't issue changes
+func changeEventFor(event *types.Event) (types.ChangeEvent, bool) {
+	change := types.ChangeEvent{
+		Seq:       event.ID,
+		IssueID:   event.IssueID,
+		Actor:     event.Actor,
+		Timestamp: event.CreatedAt,
+	}
+	switch event.EventType {
+	case types.EventCreated:
+		change.Kind = types.ChangeCreated
+	case types.EventUpdated, types.EventPriorityChanged:
+		change.Kind = types.ChangeUpdated
+		change.Fields = changedFields(event.NewValue)
+	case types.EventStatusChanged, types.EventClosed, types.EventReopened:
+		change.Kind = types.ChangeStatusChanged
+		change.Fields = changedFields(event.NewValue)
+		if len(change.Fields) == 0 {
+			// CloseIssue and ReopenIssue record only a reason
+			change.Fields = []string{"status"}
+		}
+	case "deleted":
+		change.Kind = types.ChangeDeleted
+	case types.EventOverdue:
+		change.Kind = types.ChangeOverdue
+	default:
+		return change, false
+	}
+	return change, true
+}
+
+// changedFields returns the sorted field names of an update event's
+// new_value, which holds the fields it set as a JSON object
+func changedFields(newValue *string) []string {
+	if newValue == nil {
+		return nil
+	}
+	var updates map[string]interface{}
+	if err := json.Unmarshal([]byte(*newValue), &updates); err != nil {
+		return nil
+	}
+	fields := make([]string, 0, len(updates))
+	for field := range updates {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
@@ -0,0 +1,215 @@
+package sqlite
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestSubscribe(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	issue := &types.Issue{Title: "Live board", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "alice"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	if _, err := store.Subscribe(ctx, -1); err == nil {
+		t.Error("Expected a negative sequence number to be rejected")
+	}
+	changes, err := store.Subscribe(ctx, 0)
+	if err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+	next := func() types.ChangeEvent {
+		t.Helper()
+		select {
+		case change, ok := <-changes:
+			if !ok {
+				t.Fatal("Subscription closed early")
+			}
+			return change
+		case <-time.After(5 * time.Second):
+			t.Fatal("Timed out waiting for a change")
+		}
+		return types.ChangeEvent{}
+	}
+
+	// Events written before subscribing are replayed
+	created := next()
+	if created.Kind != types.ChangeCreated || created.IssueID != issue.ID || created.Actor != "alice" || created.Seq == 0 {
+		t.Errorf("Unexpected created event %+v", created)
+	}
+
+	// Followed as they happen; comments aren't issue changes
+	if err := store.AddComment(ctx, issue.ID, "bob", "on it"); err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"title": "Live board v2", "priority": 1}, "bob"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	updated := next()
+	if updated.Kind != types.ChangeUpdated || !reflect.DeepEqual(updated.Fields, []string{"priority", "title"}) || updated.Actor != "bob" {
+		t.Errorf("Unexpected updated event %+v", updated)
+	}
+	if err := store.CloseIssue(ctx, issue.ID, "done", "bob"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	closed := next()
+	if closed.Kind != types.ChangeStatusChanged || !reflect.DeepEqual(closed.Fields, []string{"status"}) {
+		t.Errorf("Unexpected close event %+v", closed)
+	}
+	if err := store.CreateTombstone(ctx, issue.ID, "carol", "cleanup"); err != nil {
+		t.Fatalf("CreateTombstone failed: %v", err)
+	}
+	if deleted := next(); deleted.Kind != types.ChangeDeleted || deleted.Actor != "carol" {
+		t.Errorf("Unexpected deleted event %+v", deleted)
+	}
+	if !(created.Seq < updated.Seq && updated.Seq < closed.Seq) {
+		t.Errorf("Expected increasing sequence numbers, got %d, %d, %d", created.Seq, updated.Seq, closed.Seq)
+	}
+
+	cancel()
+	select {
+	case _, ok := <-changes:
+		if ok {
+			t.Error("Expected no more events after cancel")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the subscription to close on cancel")
+	}
+
+	// Resuming after the last acknowledged event replays only what followed
+	resumed, err := store.Subscribe(context.Background(), updated.Seq)
+	if err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+	changes = resumed
+	if got := next(); got.Seq != closed.Seq {
+		t.Errorf("Expected replay from seq %d, got %+v", closed.Seq, got)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	for range resumed {
+		// Drain until Close ends the subscription
+	}
+}
+
+func TestSubscribeResumesAfterReconnect(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	mainDBPath := filepath.Join(dir, "beads.db")
+	branchDBPath := filepath.Join(dir, "branch.db")
+
+	createIssue := func(path, title string) string {
+		t.Helper()
+		s, err := New(ctx, path)
+		if err != nil {
+			t.Fatalf("failed to open %s: %v", path, err)
+		}
+		defer s.Close()
+		if err := s.SetConfig(ctx, "issue_prefix", "bd"); err != nil {
+			t.Fatalf("SetConfig failed: %v", err)
+		}
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := s.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		return issue.ID
+	}
+	// The branch carries main's log forward with one more issue
+	first := createIssue(mainDBPath, "On main")
+	content, err := os.ReadFile(mainDBPath)
+	if err != nil {
+		t.Fatalf("failed to read main DB: %v", err)
+	}
+	if err := os.WriteFile(branchDBPath, content, 0644); err != nil {
+		t.Fatalf("failed to write branch DB: %v", err)
+	}
+	second := createIssue(branchDBPath, "On branch")
+
+	store, err := New(ctx, mainDBPath)
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+	defer store.Close()
+	if err := store.EnableFreshnessChecking(FreshnessOptions{}); err != nil {
+		t.Fatalf("failed to enable freshness checking: %v", err)
+	}
+
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	changes, err := store.Subscribe(subCtx, 0)
+	if err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+	next := func() types.ChangeEvent {
+		t.Helper()
+		select {
+		case change, ok := <-changes:
+			if !ok {
+				t.Fatal("Subscription closed early")
+			}
+			return change
+		case <-time.After(5 * time.Second):
+			t.Fatal("Timed out waiting for a change")
+		}
+		return types.ChangeEvent{}
+	}
+	seen := next()
+	if seen.IssueID != first {
+		t.Fatalf("Expected %s created, got %+v", first, seen)
+	}
+
+	// Simulate a git merge replacing the file under the subscription
+	oldDB := store.UnderlyingDB()
+	os.Remove(mainDBPath + "-wal")
+	os.Remove(mainDBPath + "-shm")
+	content, err = os.ReadFile(branchDBPath)
+	if err != nil {
+		t.Fatalf("failed to read branch DB: %v", err)
+	}
+	if err := os.WriteFile(mainDBPath+".new", content, 0644); err != nil {
+		t.Fatalf("failed to write temp file: %v", err)
+	}
+	if err := os.Rename(mainDBPath+".new", mainDBPath); err != nil {
+		t.Fatalf("failed to rename: %v", err)
+	}
+
+	// The live subscription follows the new file from where it was
+	merged := next()
+	if merged.IssueID != second || merged.Kind != types.ChangeCreated || merged.Seq <= seen.Seq {
+		t.Fatalf("Expected %s created after seq %d, got %+v", second, seen.Seq, merged)
+	}
+	if store.UnderlyingDB() == oldDB {
+		t.Error("Expected the store to have reconnected")
+	}
+
+	// A subscriber resuming from the last seq it handled before the
+	// reconnect gets only what the new file added
+	cancel()
+	for range changes {
+		// Drain until cancel ends the subscription
+	}
+	resumeCtx, stop := context.WithCancel(ctx)
+	defer stop()
+	if changes, err = store.Subscribe(resumeCtx, seen.Seq); err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+	if got := next(); got.Seq != merged.Seq || got.IssueID != second {
+		t.Errorf("Expected replay from seq %d, got %+v", merged.Seq, got)
+	}
+	select {
+	case change := <-changes:
+		t.Errorf("Expected nothing more after resuming, got %+v", change)
+	case <-time.After(2 * subscribePollInterval):
+	}
+}
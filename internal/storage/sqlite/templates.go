@@ -0,0 +1,134 @@
+// Package sqlite - instantiating multi-issue templates
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// templateVarPattern matches a {{variable}} reference in template text
+var templateVarPattern = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_.-]+)\s*\}\}`)
+
+// expandTemplateVars replaces every {{name}} in text with vars[name],
+// failing on names without a value
+func expandTemplateVars(text string, vars map[string]string) (string, error) {
+	var missing string
+	expanded := templateVarPattern.ReplaceAllStringFunc(text, func(ref string) string {
+		name := templateVarPattern.FindStringSubmatch(ref)[1]
+		value, ok := vars[name]
+		if !ok && missing == "" {
+			missing = name
+		}
+		return value
+	})
+	if missing != "" {
+		return "", fmt.Errorf("template variable %q is not set", missing)
+	}
+	return expanded, nil
+}
+
+// CreateFromTemplate creates every issue of tmpl and the dependencies between
+// them in one transaction, with {{variables}} in titles, descriptions,
+// design, acceptance criteria, assignees and labels replaced from vars. It
+// returns the created issues by their template-local names.
+//
+// The template is checked up front (unique names, edges between known
+// issues, valid dependency types, every variable set), and any failure while
+// creating, including an issue failing validation or an edge closing a
+// cycle, rolls back the whole graph: either everything is created or nothing
+// is.
+func (s *SQLiteStorage) CreateFromTemplate(ctx context.Context, tmpl *types.TemplateGraph, vars map[string]string, actor string) (map[string]*types.Issue, error) {
+	if len(tmpl.Issues) == 0 {
+		return nil, fmt.Errorf("template declares no issues")
+	}
+
+	issues := make(map[string]*types.Issue, len(tmpl.Issues))
+	for _, ti := range tmpl.Issues {
+		if ti.Name == "" {
+			return nil, fmt.Errorf("template issue %q has no name", ti.Title)
+		}
+		if _, dup := issues[ti.Name]; dup {
+			return nil, fmt.Errorf("template issue name %q is used twice", ti.Name)
+		}
+		issue, err := templateIssue(ti, vars)
+		if err != nil {
+			return nil, fmt.Errorf("template issue %s: %w", ti.Name, err)
+		}
+		issues[ti.Name] = issue
+	}
+	for _, edge := range tmpl.Edges {
+		for _, name := range []string{edge.From, edge.To} {
+			if _, ok := issues[name]; !ok {
+				return nil, fmt.Errorf("template edge %s -> %s: unknown issue %q", edge.From, edge.To, name)
+			}
+		}
+		if edge.Type != "" && !edge.Type.IsValid() {
+			return nil, fmt.Errorf("template edge %s -> %s: invalid dependency type %q", edge.From, edge.To, edge.Type)
+		}
+	}
+
+	err := s.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		for _, ti := range tmpl.Issues {
+			if err := tx.CreateIssue(ctx, issues[ti.Name], actor); err != nil {
+				return fmt.Errorf("template issue %s: %w", ti.Name, err)
+			}
+		}
+		for _, edge := range tmpl.Edges {
+			depType := edge.Type
+			if depType == "" {
+				depType = types.DepBlocks
+			}
+			dep := &types.Dependency{IssueID: issues[edge.From].ID, DependsOnID: issues[edge.To].ID, Type: depType}
+			if err := tx.AddDependency(ctx, dep, actor); err != nil {
+				return fmt.Errorf("template edge %s -> %s: %w", edge.From, edge.To, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return issues, nil
+}
+
+// templateIssue builds the issue to create for ti with vars expanded
+func templateIssue(ti types.TemplateIssue, vars map[string]string) (*types.Issue, error) {
+	issue := &types.Issue{
+		Status:    types.StatusOpen,
+		Priority:  types.PriorityUnset,
+		IssueType: types.IssueType(ti.Type),
+	}
+	if ti.Priority != nil {
+		issue.Priority = *ti.Priority
+	}
+
+	fields := []struct {
+		text string
+		dest *string
+	}{
+		{ti.Title, &issue.Title},
+		{ti.Description, &issue.Description},
+		{ti.Design, &issue.Design},
+		{ti.AcceptanceCriteria, &issue.AcceptanceCriteria},
+		{ti.Assignee, &issue.Assignee},
+	}
+	for _, field := range fields {
+		expanded, err := expandTemplateVars(field.text, vars)
+		if err != nil {
+			return nil, err
+		}
+		*field.dest = expanded
+	}
+	for _, label := range ti.Labels {
+		expanded, err := expandTemplateVars(label, vars)
+		if err != nil {
+			return nil, err
+		}
+		issue.Labels = append(issue.Labels, expanded)
+	}
+	return issue, nil
+}
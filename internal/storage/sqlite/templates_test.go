@@ -0,0 +1,111 @@
+package sqlite
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestCreateFromTemplate(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	p0 := 0
+	release := &types.TemplateGraph{
+		Issues: []types.TemplateIssue{
+			{Name: "epic", Title: "Release {{version}}", Type: "epic", Priority: &p0, Labels: []string{"release-{{version}}"}},
+			{Name: "freeze", Title: "Code freeze for {{version}}", Assignee: "{{owner}}"},
+			{Name: "notes", Title: "Write {{ version }} release notes", Description: "Summarize changes since {{previous}}"},
+		},
+		Edges: []types.TemplateEdge{
+			{From: "freeze", To: "epic", Type: types.DepParentChild},
+			{From: "notes", To: "epic", Type: types.DepParentChild},
+			{From: "notes", To: "freeze"},
+		},
+	}
+	vars := map[string]string{"version": "1.4", "previous": "1.3", "owner": "alice"}
+
+	created, err := store.CreateFromTemplate(ctx, release, vars, "test")
+	if err != nil {
+		t.Fatalf("CreateFromTemplate failed: %v", err)
+	}
+	if len(created) != 3 {
+		t.Fatalf("Expected 3 issues, got %d", len(created))
+	}
+
+	epic, err := store.GetIssue(ctx, created["epic"].ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if epic.Title != "Release 1.4" || epic.IssueType != types.TypeEpic || epic.Priority != 0 {
+		t.Errorf("Expected P0 epic 'Release 1.4', got %+v", epic)
+	}
+	if len(epic.Labels) != 1 || epic.Labels[0] != "release-1.4" {
+		t.Errorf("Expected label release-1.4, got %v", epic.Labels)
+	}
+	notes := created["notes"]
+	if notes.Title != "Write 1.4 release notes" || notes.Description != "Summarize changes since 1.3" {
+		t.Errorf("Expected variables substituted, got %q / %q", notes.Title, notes.Description)
+	}
+	if created["freeze"].Assignee != "alice" {
+		t.Errorf("Expected assignee alice, got %q", created["freeze"].Assignee)
+	}
+
+	deps, err := store.GetDependencyRecords(ctx, notes.ID)
+	if err != nil {
+		t.Fatalf("GetDependencyRecords failed: %v", err)
+	}
+	edges := make(map[string]types.DependencyType)
+	for _, dep := range deps {
+		edges[dep.DependsOnID] = dep.Type
+	}
+	if edges[epic.ID] != types.DepParentChild || edges[created["freeze"].ID] != types.DepBlocks {
+		t.Errorf("Expected notes to be a child of the epic blocked by the freeze, got %v", edges)
+	}
+
+	countIssues := func() int {
+		issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+		if err != nil {
+			t.Fatalf("SearchIssues failed: %v", err)
+		}
+		return len(issues)
+	}
+	before := countIssues()
+
+	// Failures leave nothing behind
+	tests := []struct {
+		name string
+		tmpl *types.TemplateGraph
+		vars map[string]string
+		want string
+	}{
+		{"missing variable", release, map[string]string{"version": "2.0"}, "not set"},
+		{"unknown edge target", &types.TemplateGraph{
+			Issues: []types.TemplateIssue{{Name: "a", Title: "A"}},
+			Edges:  []types.TemplateEdge{{From: "a", To: "b"}},
+		}, nil, "unknown issue"},
+		{"duplicate name", &types.TemplateGraph{
+			Issues: []types.TemplateIssue{{Name: "a", Title: "A"}, {Name: "a", Title: "B"}},
+		}, nil, "used twice"},
+		{"invalid issue", &types.TemplateGraph{
+			Issues: []types.TemplateIssue{{Name: "a", Title: "A"}, {Name: "b", Title: ""}},
+		}, nil, "title"},
+		{"cycle", &types.TemplateGraph{
+			Issues: []types.TemplateIssue{{Name: "a", Title: "A"}, {Name: "b", Title: "B"}},
+			Edges:  []types.TemplateEdge{{From: "a", To: "b"}, {From: "b", To: "a"}},
+		}, nil, "cycle"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := store.CreateFromTemplate(ctx, tt.tmpl, tt.vars, "test")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("Expected error containing %q, got %v", tt.want, err)
+			}
+			if after := countIssues(); after != before {
+				t.Errorf("Expected no issues created, got %d new", after-before)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+// Package sqlite - closed-issue throughput over time
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Throughput counts the issues closed in each bucket-long interval from
+// from to to, for velocity charts. Buckets start at from; the last one is cut
+// short at to. Every bucket is returned, including empty ones.
+//
+// Closes come from the event log, so an issue closed, reopened and closed
+// again is counted once, in the bucket of its most recent close within the
+// window, whether or not it is still closed. If filter is not empty, only
+// issues matching it (see SearchIssues) are counted.
+func (s *SQLiteStorage) Throughput(ctx context.Context, bucket time.Duration, from, to time.Time, filter types.IssueFilter) ([]types.ThroughputBucket, error) {
+	if bucket <= 0 {
+		return nil, fmt.Errorf("throughput bucket must be positive (got %v)", bucket)
+	}
+	if !to.After(from) {
+		return nil, fmt.Errorf("throughput window end %s is not after its start %s", to.Format(time.RFC3339), from.Format(time.RFC3339))
+	}
+
+	var matching map[string]bool
+	if !reflect.ValueOf(filter).IsZero() {
+		filter.Fields = []string{"id"}
+		issues, err := s.SearchIssues(ctx, "", filter)
+		if err != nil {
+			return nil, err
+		}
+		matching = make(map[string]bool, len(issues))
+		for _, issue := range issues {
+			matching[issue.ID] = true
+		}
+	}
+
+	ctx, cancel := s.withQueryTimeout(ctx)
+	defer cancel()
+
+	// Event IDs increase with time, so the highest close event per issue
+	// in the window is its most recent close there
+	rows, err := s.db().QueryContext(ctx, `
+		SELECT issue_id, created_at FROM events
+		WHERE id IN (
+			SELECT MAX(id) FROM events
+			WHERE event_type = ?
+			  AND julianday(created_at) >= julianday(?)
+			  AND julianday(created_at) < julianday(?)
+			GROUP BY issue_id
+		)
+	`, types.EventClosed, from.UTC(), to.UTC())
+	if err != nil {
+		return nil, withContextError(ctx, wrapDBError("query close events", err))
+	}
+	defer func() { _ = rows.Close() }()
+
+	var buckets []types.ThroughputBucket
+	for start := from; start.Before(to); start = start.Add(bucket) {
+		end := start.Add(bucket)
+		if end.After(to) {
+			end = to
+		}
+		buckets = append(buckets, types.ThroughputBucket{Start: start, End: end})
+	}
+
+	for rows.Next() {
+		var issueID string
+		var closedAt time.Time
+		if err := rows.Scan(&issueID, &closedAt); err != nil {
+			return nil, wrapDBError("scan close event", err)
+		}
+		if matching != nil && !matching[issueID] {
+			continue
+		}
+		i := int(closedAt.Sub(from) / bucket)
+		if i >= 0 && i < len(buckets) {
+			buckets[i].Closed++
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, withContextError(ctx, wrapDBError("iterate close events", err))
+	}
+	return buckets, nil
+}
@@ -0,0 +1,94 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestThroughput(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	week := 7 * 24 * time.Hour
+	from := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	to := from.Add(3 * week)
+
+	newIssue := func(title string, issueType types.IssueType) string {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: issueType}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		return issue.ID
+	}
+	// closeAt closes id and backdates the close event to at
+	closeAt := func(id string, at time.Time) {
+		if err := store.CloseIssue(ctx, id, "done", "test"); err != nil {
+			t.Fatalf("CloseIssue failed: %v", err)
+		}
+		if _, err := store.db().ExecContext(ctx, `
+			UPDATE events SET created_at = ?
+			WHERE id = (SELECT MAX(id) FROM events WHERE issue_id = ? AND event_type = ?)
+		`, at, id, types.EventClosed); err != nil {
+			t.Fatalf("Failed to backdate close: %v", err)
+		}
+	}
+	reopen := func(id string) {
+		if err := store.UpdateIssue(ctx, id, map[string]interface{}{"status": string(types.StatusOpen)}, "test"); err != nil {
+			t.Fatalf("Reopen failed: %v", err)
+		}
+	}
+
+	a, b, c, d := newIssue("A", types.TypeTask), newIssue("B", types.TypeBug), newIssue("C", types.TypeTask), newIssue("D", types.TypeTask)
+	closeAt(a, from.Add(time.Hour))
+	closeAt(b, from.Add(week+time.Hour))
+	// C is closed in week one, reopened, and closed again in week three
+	closeAt(c, from.Add(2*time.Hour))
+	reopen(c)
+	closeAt(c, from.Add(2*week+time.Hour))
+	// D closed before the window
+	closeAt(d, from.Add(-time.Hour))
+
+	buckets, err := store.Throughput(ctx, week, from, to, types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("Throughput failed: %v", err)
+	}
+	if len(buckets) != 3 {
+		t.Fatalf("Expected 3 buckets, got %d", len(buckets))
+	}
+	for i, want := range []int{1, 1, 1} {
+		if buckets[i].Closed != want {
+			t.Errorf("Expected %d closed in week %d, got %d", want, i+1, buckets[i].Closed)
+		}
+	}
+	if !buckets[1].Start.Equal(from.Add(week)) || !buckets[2].End.Equal(to) {
+		t.Errorf("Expected weekly bucket bounds, got %+v", buckets)
+	}
+
+	bug := types.TypeBug
+	buckets, err = store.Throughput(ctx, week, from, to, types.IssueFilter{IssueType: &bug})
+	if err != nil {
+		t.Fatalf("Throughput failed: %v", err)
+	}
+	if buckets[0].Closed != 0 || buckets[1].Closed != 1 || buckets[2].Closed != 0 {
+		t.Errorf("Expected only the bug counted in week two, got %+v", buckets)
+	}
+
+	// A partial last bucket
+	buckets, err = store.Throughput(ctx, 2*week, from, to, types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("Throughput failed: %v", err)
+	}
+	if len(buckets) != 2 || buckets[0].Closed != 2 || buckets[1].Closed != 1 || !buckets[1].End.Equal(to) {
+		t.Errorf("Expected 2 then 1 closed with a short last bucket, got %+v", buckets)
+	}
+
+	if _, err := store.Throughput(ctx, 0, from, to, types.IssueFilter{}); err == nil {
+		t.Error("Expected a zero bucket to be rejected")
+	}
+	if _, err := store.Throughput(ctx, week, to, from, types.IssueFilter{}); err == nil {
+		t.Error("Expected a reversed window to be rejected")
+	}
+}
@@ -0,0 +1,39 @@
+// Package sqlite - per-operation query timeouts
+package sqlite
+
+import (
+	"context"
+	"time"
+)
+
+// SetQueryTimeout bounds how long a single store operation may run. When
+// non-zero, reads (SearchIssues, GetReadyWork, GetBlockedIssues, dependency
+// walks) and write transactions run under a context deadline of at most d,
+// in addition to the caller's own context. The SQLite driver interrupts the
+// running statement when that context is done, so a runaway query returns
+// context.DeadlineExceeded instead of wedging a long-lived process.
+//
+// This complements busy_timeout (see NewWithTimeout), which only bounds how
+// long we wait for another connection's lock. A zero duration disables the
+// per-operation timeout; callers' contexts are still honored.
+func (s *SQLiteStorage) SetQueryTimeout(d time.Duration) {
+	if d < 0 {
+		d = 0
+	}
+	s.queryTimeout.Store(int64(d))
+}
+
+// QueryTimeout returns the per-operation timeout set by SetQueryTimeout (0 if disabled).
+func (s *SQLiteStorage) QueryTimeout() time.Duration {
+	return time.Duration(s.queryTimeout.Load())
+}
+
+// withQueryTimeout derives a context bounded by the configured query timeout.
+// The caller must always call the returned cancel function.
+func (s *SQLiteStorage) withQueryTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	timeout := s.QueryTimeout()
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
@@ -0,0 +1,150 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// createDependencyLattice builds a root issue over a lattice of layers, two
+// issues wide, where every issue depends on both issues in the next layer.
+// The number of distinct paths doubles with each layer, so an unbounded walk
+// from the root is far too slow to finish and must be interrupted.
+func createDependencyLattice(t *testing.T, store *SQLiteStorage, layers int) string {
+	t.Helper()
+	ctx := context.Background()
+
+	newIssue := func(title string) string {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		return issue.ID
+	}
+	addDep := func(from, to string) {
+		dep := &types.Dependency{IssueID: from, DependsOnID: to, Type: types.DepBlocks}
+		if err := store.AddDependency(ctx, dep, "test"); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+	}
+
+	root := newIssue("root")
+	prev := []string{root}
+	for layer := 0; layer < layers; layer++ {
+		next := []string{newIssue(fmt.Sprintf("L%d-a", layer)), newIssue(fmt.Sprintf("L%d-b", layer))}
+		for _, from := range prev {
+			for _, to := range next {
+				addDep(from, to)
+			}
+		}
+		prev = next
+	}
+	return root
+}
+
+func TestDependencyTreeHonorsCancellation(t *testing.T) {
+	store := newTestStore(t, "")
+	root := createDependencyLattice(t, store, 25)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err := store.GetDependencyTree(ctx, root, 50, true, false)
+	elapsed := time.Since(start)
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+	if elapsed > 5*time.Second {
+		t.Errorf("GetDependencyTree took %v to return after cancellation", elapsed)
+	}
+}
+
+func TestQueryTimeout(t *testing.T) {
+	store := newTestStore(t, "")
+	root := createDependencyLattice(t, store, 25)
+
+	if store.QueryTimeout() != 0 {
+		t.Fatalf("Expected no query timeout by default, got %v", store.QueryTimeout())
+	}
+	store.SetQueryTimeout(100 * time.Millisecond)
+
+	start := time.Now()
+	_, err := store.GetDependencyTree(context.Background(), root, 50, true, false)
+	elapsed := time.Since(start)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed > 5*time.Second {
+		t.Errorf("GetDependencyTree took %v to return after the query timeout", elapsed)
+	}
+
+	// Cheap operations are unaffected
+	if _, err := store.SearchIssues(context.Background(), "", types.IssueFilter{}); err != nil {
+		t.Errorf("SearchIssues failed under query timeout: %v", err)
+	}
+
+	store.SetQueryTimeout(0)
+	if _, err := store.GetDependencyTree(context.Background(), root, 3, true, false); err != nil {
+		t.Errorf("GetDependencyTree failed with timeout disabled: %v", err)
+	}
+}
+
+// insertManyIssues inserts n open issues, enough that scanning them all runs
+// far longer than the tests wait. They're inserted directly, as CreateIssues
+// would take as long.
+func insertManyIssues(t *testing.T, store *SQLiteStorage, n int) {
+	t.Helper()
+	if _, err := store.db().ExecContext(context.Background(), `
+		WITH RECURSIVE n(i) AS (SELECT 1 UNION ALL SELECT i + 1 FROM n WHERE i < ?)
+		INSERT INTO issues (id, title, status, priority, issue_type, created_at, updated_at)
+		SELECT 'bd-' || i, 'Issue ' || i, 'open', 2, 'task', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP FROM n
+	`, n); err != nil {
+		t.Fatalf("Failed to insert issues: %v", err)
+	}
+}
+
+func TestSearchIssuesHonorsDeadline(t *testing.T) {
+	store := newTestStore(t, "")
+	insertManyIssues(t, store, 30000)
+
+	// The deadline passes while the query is running, not before it starts
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	results, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	elapsed := time.Since(start)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected context.DeadlineExceeded, got %d results and err %v", len(results), err)
+	}
+	if elapsed > time.Second {
+		t.Errorf("SearchIssues took %v to return after the deadline", elapsed)
+	}
+}
+
+func TestSearchIssuesCanceledMidQuery(t *testing.T) {
+	store := newTestStore(t, "")
+	insertManyIssues(t, store, 30000)
+
+	canceled, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	results, err := store.SearchIssues(canceled, "", types.IssueFilter{})
+	elapsed := time.Since(start)
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %d results and err %v", len(results), err)
+	}
+	if elapsed > time.Second {
+		t.Errorf("SearchIssues took %v to return after cancellation", elapsed)
+	}
+}
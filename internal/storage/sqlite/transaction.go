@@ -41,15 +41,17 @@ type sqliteTxStorage struct {
 func (s *SQLiteStorage) RunInTransaction(ctx context.Context, fn func(tx storage.Transaction) error) error {
 	// Acquire a dedicated connection for the transaction.
 	// This ensures all operations in the transaction use the same connection.
-	conn, err := s.db.Conn(ctx)
+	db, release := s.pinPool()
+	defer release()
+	conn, err := db.Conn(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to acquire connection for transaction: %w", err)
 	}
 	defer func() { _ = conn.Close() }()
 
 	// Start IMMEDIATE transaction to acquire write lock early.
-	// Use retry logic with exponential backoff to handle SQLITE_BUSY (bd-ola6)
-	if err := beginImmediateWithRetry(ctx, conn, 5, 10*time.Millisecond); err != nil {
+	// Retry SQLITE_BUSY under the write retry policy (bd-ola6)
+	if err := s.beginImmediate(ctx, conn); err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
 
@@ -97,6 +99,11 @@ func (t *sqliteTxStorage) CreateIssue(ctx context.Context, issue *types.Issue, a
 		return fmt.Errorf("failed to get custom statuses: %w", err)
 	}
 
+	// Fill unset fields from the project's creation defaults before validating
+	if err := applyCreateDefaults(ctx, t.conn, issue); err != nil {
+		return err
+	}
+
 	// Validate issue before creating (with custom status support)
 	if err := issue.ValidateWithCustomStatuses(customStatuses); err != nil {
 		return fmt.Errorf("validation failed: %w", err)
@@ -122,10 +129,15 @@ func (t *sqliteTxStorage) CreateIssue(ctx context.Context, issue *types.Issue, a
 		return fmt.Errorf("failed to get config: %w", err)
 	}
 
+	// Refuse to create an in-progress issue past its assignee's WIP limit
+	if err := checkWIPLimit(ctx, t.conn, issue.ID, issue.Assignee, issue.Status); err != nil {
+		return err
+	}
+
 	// Generate or validate ID
 	if issue.ID == "" {
 		// Generate hash-based ID with adaptive length based on database size (bd-ea2a13)
-		generatedID, err := GenerateIssueID(ctx, t.conn, prefix, issue, actor)
+		generatedID, err := GenerateIssueID(t.parent.seedIDs(ctx), t.conn, prefix, issue, actor)
 		if err != nil {
 			return fmt.Errorf("failed to generate issue ID: %w", err)
 		}
@@ -157,6 +169,11 @@ func (t *sqliteTxStorage) CreateIssue(ctx context.Context, issue *types.Issue, a
 		return fmt.Errorf("failed to insert issue: %w", err)
 	}
 
+	// Store the labels the issue was created with (explicit or defaulted)
+	if err := insertIssueLabels(ctx, t.conn, issue); err != nil {
+		return fmt.Errorf("failed to insert labels: %w", err)
+	}
+
 	// Record creation event
 	if err := recordCreatedEvent(ctx, t.conn, issue, actor); err != nil {
 		return fmt.Errorf("failed to record creation event: %w", err)
@@ -207,7 +224,7 @@ func (t *sqliteTxStorage) CreateIssues(ctx context.Context, issues []*types.Issu
 	// Generate IDs for issues that don't have them
 	for _, issue := range issues {
 		if issue.ID == "" {
-			generatedID, err := GenerateIssueID(ctx, t.conn, prefix, issue, actor)
+			generatedID, err := GenerateIssueID(t.parent.seedIDs(ctx), t.conn, prefix, issue, actor)
 			if err != nil {
 				return fmt.Errorf("failed to generate issue ID: %w", err)
 			}
@@ -254,7 +271,7 @@ func (t *sqliteTxStorage) GetIssue(ctx context.Context, id string) (*types.Issue
 		       status, priority, issue_type, assignee, estimated_minutes,
 		       created_at, updated_at, closed_at, external_ref,
 		       compaction_level, compacted_at, compacted_at_commit, original_size, source_repo, close_reason,
-		       deleted_at, deleted_by, delete_reason, original_type
+		       deleted_at, deleted_by, delete_reason, original_type, draft, percent_complete, external_blocked_reason, snoozed_until, due_date, version
 		FROM issues
 		WHERE id = ?
 	`, id)
@@ -274,6 +291,16 @@ func (t *sqliteTxStorage) GetIssue(ctx context.Context, id string) (*types.Issue
 	}
 	issue.Labels = labels
 
+	if issue.Recurrence, err = getRecurrence(ctx, t.conn, issue.ID); err != nil {
+		return nil, err
+	}
+	if issue.Sections, err = getSections(ctx, t.conn, issue.ID); err != nil {
+		return nil, err
+	}
+	if issue.Watchers, err = getWatchers(ctx, t.conn, issue.ID); err != nil {
+		return nil, err
+	}
+
 	return issue, nil
 }
 
@@ -309,6 +336,10 @@ func (t *sqliteTxStorage) UpdateIssue(ctx context.Context, id string, updates ma
 	if oldIssue == nil {
 		return fmt.Errorf("issue %s not found", id)
 	}
+	// The transaction holds the write lock, so the issue can't change after this
+	if err := checkExpectedVersion(ctx, oldIssue); err != nil {
+		return err
+	}
 
 	// Fetch custom statuses for validation (bd-1pj6)
 	customStatuses, err := t.GetCustomStatuses(ctx)
@@ -316,6 +347,11 @@ func (t *sqliteTxStorage) UpdateIssue(ctx context.Context, id string, updates ma
 		return fmt.Errorf("failed to get custom statuses: %w", err)
 	}
 
+	// Close issues that reach 100 percent complete if configured
+	if updates, err = withPercentAutoClose(ctx, t.conn, oldIssue, updates); err != nil {
+		return fmt.Errorf("failed to check percent auto-close: %w", err)
+	}
+
 	// Build update query with validated field names
 	setClauses := []string{"updated_at = ?"}
 	args := []interface{}{time.Now()}
@@ -331,16 +367,25 @@ func (t *sqliteTxStorage) UpdateIssue(ctx context.Context, id string, updates ma
 			return fmt.Errorf("failed to validate field update: %w", err)
 		}
 
+		if key == "description" {
+			if description, ok := value.(string); ok {
+				if value, err = storedDescription(ctx, t.conn, description); err != nil {
+					return err
+				}
+			}
+		}
+
 		setClauses = append(setClauses, fmt.Sprintf("%s = ?", key))
 		args = append(args, value)
 	}
 
 	// Auto-manage closed_at when status changes
 	setClauses, args = manageClosedAt(oldIssue, updates, setClauses, args)
+	setClauses, args = manageSnoozedUntil(oldIssue, updates, setClauses, args)
 
 	// Recompute content_hash if any content fields changed (bd-95)
 	contentChanged := false
-	contentFields := []string{"title", "description", "design", "acceptance_criteria", "notes", "status", "priority", "issue_type", "assignee", "external_ref"}
+	contentFields := []string{"title", "description", "design", "acceptance_criteria", "notes", "status", "priority", "issue_type", "assignee", "external_ref", "due_date"}
 	for _, field := range contentFields {
 		if _, exists := updates[field]; exists {
 			contentChanged = true
@@ -357,12 +402,27 @@ func (t *sqliteTxStorage) UpdateIssue(ctx context.Context, id string, updates ma
 
 	args = append(args, id)
 
+	// Refuse to close a parent with open children if configured
+	if determineEventType(oldIssue, updates) == types.EventClosed && oldIssue.Status != types.StatusClosed {
+		if err := checkOpenChildren(ctx, t.conn, id); err != nil {
+			return err
+		}
+	}
+
+	// Refuse to take the assignee past their WIP limit if configured
+	if err := checkWIPUpdate(ctx, t.conn, oldIssue, updates); err != nil {
+		return err
+	}
+
 	// Update issue
 	query := fmt.Sprintf("UPDATE issues SET %s WHERE id = ?", strings.Join(setClauses, ", ")) // #nosec G201 - safe SQL with controlled column names
 	_, err = t.conn.ExecContext(ctx, query, args...)
 	if err != nil {
 		return fmt.Errorf("failed to update issue: %w", err)
 	}
+	if err := syncFTS(ctx, t.conn, id); err != nil {
+		return err
+	}
 
 	// Record event
 	oldData, err := json.Marshal(oldIssue)
@@ -392,9 +452,15 @@ func (t *sqliteTxStorage) UpdateIssue(ctx context.Context, id string, updates ma
 	// Invalidate blocked issues cache if status changed (bd-1c4h)
 	// Status changes affect which issues are blocked (blockers must be open/in_progress/blocked)
 	if _, statusChanged := updates["status"]; statusChanged {
-		if err := t.parent.invalidateBlockedCache(ctx, t.conn); err != nil {
+		if err := t.parent.invalidateBlockedCache(ctx, t.conn, id); err != nil {
 			return fmt.Errorf("failed to invalidate blocked cache: %w", err)
 		}
+		if err := t.parent.autoUnblockDependents(ctx, t.conn, id); err != nil {
+			return err
+		}
+		if err := t.parent.autoCloseEpics(ctx, t.conn, id); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -434,6 +500,10 @@ func applyUpdatesToIssue(issue *types.Issue, updates map[string]interface{}) {
 			if p, ok := value.(int); ok {
 				issue.Priority = p
 			}
+		case "percent_complete":
+			if p, ok := value.(int); ok {
+				issue.PercentComplete = p
+			}
 		case "issue_type":
 			if t, ok := value.(types.IssueType); ok {
 				issue.IssueType = t
@@ -457,6 +527,24 @@ func applyUpdatesToIssue(issue *types.Issue, updates map[string]interface{}) {
 					issue.ExternalRef = v
 				}
 			}
+		case "external_blocked_reason":
+			if value == nil {
+				issue.ExternalBlockedReason = nil
+			} else if s, ok := value.(string); ok {
+				issue.ExternalBlockedReason = &s
+			}
+		case "snoozed_until":
+			if value == nil {
+				issue.SnoozedUntil = nil
+			} else if t, ok := value.(time.Time); ok {
+				issue.SnoozedUntil = &t
+			}
+		case "due_date":
+			if value == nil {
+				issue.DueDate = nil
+			} else if t, ok := value.(time.Time); ok {
+				issue.DueDate = &t
+			}
 		}
 	}
 }
@@ -466,6 +554,10 @@ func applyUpdatesToIssue(issue *types.Issue, updates map[string]interface{}) {
 func (t *sqliteTxStorage) CloseIssue(ctx context.Context, id string, reason string, actor string) error {
 	now := time.Now()
 
+	if err := checkOpenChildren(ctx, t.conn, id); err != nil {
+		return err
+	}
+
 	result, err := t.conn.ExecContext(ctx, `
 		UPDATE issues SET status = ?, closed_at = ?, updated_at = ?, close_reason = ?
 		WHERE id = ?
@@ -497,9 +589,15 @@ func (t *sqliteTxStorage) CloseIssue(ctx context.Context, id string, reason stri
 
 	// Invalidate blocked issues cache since status changed to closed (bd-1c4h)
 	// Closed issues don't block others, so this affects blocking calculations
-	if err := t.parent.invalidateBlockedCache(ctx, t.conn); err != nil {
+	if err := t.parent.invalidateBlockedCache(ctx, t.conn, id); err != nil {
 		return fmt.Errorf("failed to invalidate blocked cache: %w", err)
 	}
+	if err := t.parent.autoUnblockDependents(ctx, t.conn, id); err != nil {
+		return err
+	}
+	if err := t.parent.autoCloseEpics(ctx, t.conn, id); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -538,7 +636,7 @@ func (t *sqliteTxStorage) DeleteIssue(ctx context.Context, id string) error {
 		return fmt.Errorf("issue not found: %s", id)
 	}
 
-	return nil
+	return syncFTS(ctx, t.conn, id)
 }
 
 // AddDependency adds a dependency between issues within the transaction.
@@ -567,7 +665,7 @@ func (t *sqliteTxStorage) AddDependency(ctx context.Context, dep *types.Dependen
 
 	// Prevent self-dependency
 	if dep.IssueID == dep.DependsOnID {
-		return fmt.Errorf("issue cannot depend on itself")
+		return &CycleError{Path: []string{dep.IssueID, dep.IssueID}}
 	}
 
 	// Validate parent-child dependency direction
@@ -586,55 +684,24 @@ func (t *sqliteTxStorage) AddDependency(ctx context.Context, dep *types.Dependen
 	}
 
 	// Cycle detection
-	var cycleExists bool
-	err = t.conn.QueryRowContext(ctx, `
-		WITH RECURSIVE paths AS (
-			SELECT
-				issue_id,
-				depends_on_id,
-				1 as depth
-			FROM dependencies
-			WHERE issue_id = ?
-
-			UNION ALL
-
-			SELECT
-				d.issue_id,
-				d.depends_on_id,
-				p.depth + 1
-			FROM dependencies d
-			JOIN paths p ON d.issue_id = p.depends_on_id
-			WHERE p.depth < 100
-		)
-		SELECT EXISTS(
-			SELECT 1 FROM paths
-			WHERE depends_on_id = ?
-		)
-	`, dep.DependsOnID, dep.IssueID).Scan(&cycleExists)
-
-	if err != nil {
-		return fmt.Errorf("failed to check for cycles: %w", err)
-	}
-
-	if cycleExists {
-		return fmt.Errorf("cannot add dependency: would create a cycle (%s → %s → ... → %s)",
-			dep.IssueID, dep.DependsOnID, dep.IssueID)
+	if err := checkNewDependencyCycle(ctx, t.conn, dep.IssueID, dep.DependsOnID); err != nil {
+		return err
 	}
 
 	// Insert dependency
 	_, err = t.conn.ExecContext(ctx, `
-		INSERT INTO dependencies (issue_id, depends_on_id, type, created_at, created_by)
-		VALUES (?, ?, ?, ?, ?)
-	`, dep.IssueID, dep.DependsOnID, dep.Type, dep.CreatedAt, dep.CreatedBy)
+		INSERT INTO dependencies (issue_id, depends_on_id, type, created_at, created_by, note)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, dep.IssueID, dep.DependsOnID, dep.Type, dep.CreatedAt, dep.CreatedBy, dep.Note)
 	if err != nil {
 		return fmt.Errorf("failed to add dependency: %w", err)
 	}
 
-	// Record event
+	// Record event (new_value holds the edge so Undo can remove it)
 	_, err = t.conn.ExecContext(ctx, `
-		INSERT INTO events (issue_id, event_type, actor, comment)
-		VALUES (?, ?, ?, ?)
-	`, dep.IssueID, types.EventDependencyAdded, actor,
+		INSERT INTO events (issue_id, event_type, actor, new_value, comment)
+		VALUES (?, ?, ?, ?, ?)
+	`, dep.IssueID, types.EventDependencyAdded, actor, depEdgeJSON(dep.IssueID, dep.DependsOnID, dep.Type),
 		fmt.Sprintf("Added dependency: %s %s %s", dep.IssueID, dep.Type, dep.DependsOnID))
 	if err != nil {
 		return fmt.Errorf("failed to record event: %w", err)
@@ -650,7 +717,7 @@ func (t *sqliteTxStorage) AddDependency(ctx context.Context, dep *types.Dependen
 
 	// Invalidate blocked cache for blocking dependencies (bd-1c4h)
 	if dep.Type == types.DepBlocks || dep.Type == types.DepParentChild {
-		if err := t.parent.invalidateBlockedCache(ctx, t.conn); err != nil {
+		if err := t.parent.invalidateBlockedCache(ctx, t.conn, dep.IssueID); err != nil {
 			return fmt.Errorf("failed to invalidate blocked cache: %w", err)
 		}
 	}
@@ -688,10 +755,11 @@ func (t *sqliteTxStorage) RemoveDependency(ctx context.Context, issueID, depends
 		return fmt.Errorf("dependency from %s to %s does not exist", issueID, dependsOnID)
 	}
 
+	// old_value holds the removed edge so Undo can restore it
 	_, err = t.conn.ExecContext(ctx, `
-		INSERT INTO events (issue_id, event_type, actor, comment)
-		VALUES (?, ?, ?, ?)
-	`, issueID, types.EventDependencyRemoved, actor,
+		INSERT INTO events (issue_id, event_type, actor, old_value, comment)
+		VALUES (?, ?, ?, ?, ?)
+	`, issueID, types.EventDependencyRemoved, actor, depEdgeJSON(issueID, dependsOnID, depType),
 		fmt.Sprintf("Removed dependency on %s", dependsOnID))
 	if err != nil {
 		return fmt.Errorf("failed to record event: %w", err)
@@ -707,7 +775,7 @@ func (t *sqliteTxStorage) RemoveDependency(ctx context.Context, issueID, depends
 
 	// Invalidate blocked cache if this was a blocking dependency (bd-1c4h)
 	if needsCacheInvalidation {
-		if err := t.parent.invalidateBlockedCache(ctx, t.conn); err != nil {
+		if err := t.parent.invalidateBlockedCache(ctx, t.conn, issueID); err != nil {
 			return fmt.Errorf("failed to invalidate blocked cache: %w", err)
 		}
 	}
@@ -785,8 +853,12 @@ func (t *sqliteTxStorage) RemoveLabel(ctx context.Context, issueID, label, actor
 	return nil
 }
 
-// SetConfig sets a configuration value within the transaction.
+// SetConfig sets a configuration value within the transaction, validated as
+// in SQLiteStorage.SetConfig.
 func (t *sqliteTxStorage) SetConfig(ctx context.Context, key, value string) error {
+	if err := storage.ValidateConfig(key, value); err != nil {
+		return err
+	}
 	_, err := t.conn.ExecContext(ctx, `
 		INSERT INTO config (key, value) VALUES (?, ?)
 		ON CONFLICT (key) DO UPDATE SET value = excluded.value
@@ -888,13 +960,47 @@ func (t *sqliteTxStorage) AddComment(ctx context.Context, issueID, actor, commen
 // SearchIssues finds issues matching query and filters within the transaction.
 // This enables read-your-writes semantics for searching within a transaction.
 func (t *sqliteTxStorage) SearchIssues(ctx context.Context, query string, filter types.IssueFilter) ([]*types.Issue, error) {
+	if err := checkFilterComplexity(ctx, t.conn, query, filter); err != nil {
+		return nil, err
+	}
+
+	var scoreExpr string
+	if sortsByReadyScore(filter.SortBy) {
+		scoreExpr = readyScoreSQL(readyScoreWeights(ctx, t.conn), t.parent.now(), "issues")
+	}
+	orderSQL, err := buildSearchOrderBy(filter.SortBy, scoreExpr)
+	if err != nil {
+		return nil, err
+	}
+
+	selectSQL := `id, content_hash, title, description, design, acceptance_criteria, notes,
+		       status, priority, issue_type, assignee, estimated_minutes,
+		       created_at, updated_at, closed_at, external_ref,
+		       compaction_level, compacted_at, compacted_at_commit, original_size, source_repo, close_reason,
+		       deleted_at, deleted_by, delete_reason, original_type, draft, percent_complete, external_blocked_reason, snoozed_until, due_date, version`
+	var columns []string
+	var withLabels bool
+	if len(filter.Fields) > 0 {
+		if columns, withLabels, err = projectionColumns(filter.Fields); err != nil {
+			return nil, err
+		}
+		selectSQL = strings.Join(columns, ", ")
+	}
+
 	whereClauses := []string{}
 	args := []interface{}{}
 
+	var search textSearch
 	if query != "" {
-		whereClauses = append(whereClauses, "(title LIKE ? OR description LIKE ? OR id LIKE ?)")
-		pattern := "%" + query + "%"
-		args = append(args, pattern, pattern, pattern)
+		var err error
+		if search, err = buildTextSearch(ctx, t.conn, query); err != nil {
+			return nil, err
+		}
+		whereClauses = append(whereClauses, search.where)
+		args = append(args, search.args...)
+		if len(filter.SortBy) == 0 {
+			orderSQL = search.order + orderSQL
+		}
 	}
 
 	if filter.TitleSearch != "" {
@@ -912,6 +1018,14 @@ func (t *sqliteTxStorage) SearchIssues(ctx context.Context, query string, filter
 		whereClauses = append(whereClauses, "description LIKE ?")
 		args = append(args, "%"+filter.DescriptionContains+"%")
 	}
+	if len(filter.SectionContains) > 0 {
+		sectionClauses, sectionArgs, err := sectionContainsClauses(filter.SectionContains)
+		if err != nil {
+			return nil, err
+		}
+		whereClauses = append(whereClauses, sectionClauses...)
+		args = append(args, sectionArgs...)
+	}
 	if filter.NotesContains != "" {
 		whereClauses = append(whereClauses, "notes LIKE ?")
 		args = append(args, "%"+filter.NotesContains+"%")
@@ -925,6 +1039,20 @@ func (t *sqliteTxStorage) SearchIssues(ctx context.Context, query string, filter
 		whereClauses = append(whereClauses, "status != ?")
 		args = append(args, types.StatusTombstone)
 	}
+	if filter.Status == nil && !filter.IncludeSnoozed {
+		// Snoozed issues stay out of sight until they wake
+		whereClauses = append(whereClauses, "status != ?")
+		args = append(args, types.StatusSnoozed)
+	}
+	if visible, visibleArgs := visibleClause(ctx, "issues.id"); visible != "" {
+		whereClauses = append(whereClauses, visible)
+		args = append(args, visibleArgs...)
+	}
+
+	// Drafts are local-only and hidden unless asked for
+	if !filter.IncludeDrafts {
+		whereClauses = append(whereClauses, "draft = 0")
+	}
 
 	if filter.Priority != nil {
 		whereClauses = append(whereClauses, "priority = ?")
@@ -940,6 +1068,10 @@ func (t *sqliteTxStorage) SearchIssues(ctx context.Context, query string, filter
 		whereClauses = append(whereClauses, "priority <= ?")
 		args = append(args, *filter.PriorityMax)
 	}
+	if filter.MinPercent != nil {
+		whereClauses = append(whereClauses, "percent_complete >= ?")
+		args = append(args, *filter.MinPercent)
+	}
 
 	if filter.IssueType != nil {
 		whereClauses = append(whereClauses, "issue_type = ?")
@@ -950,6 +1082,10 @@ func (t *sqliteTxStorage) SearchIssues(ctx context.Context, query string, filter
 		whereClauses = append(whereClauses, "assignee = ?")
 		args = append(args, *filter.Assignee)
 	}
+	if filter.Watcher != nil {
+		whereClauses = append(whereClauses, "id IN (SELECT issue_id FROM issue_watchers WHERE watcher = ?)")
+		args = append(args, *filter.Watcher)
+	}
 
 	// Date ranges
 	if filter.CreatedAfter != nil {
@@ -977,6 +1113,26 @@ func (t *sqliteTxStorage) SearchIssues(ctx context.Context, query string, filter
 		args = append(args, filter.ClosedBefore.Format(time.RFC3339))
 	}
 
+	// Age ranges, relative to the store's clock
+	if filter.OlderThan > 0 {
+		whereClauses = append(whereClauses, "julianday(created_at) < julianday(?)")
+		args = append(args, t.parent.now().Add(-filter.OlderThan).UTC().Format(time.RFC3339Nano))
+	}
+	if filter.StalerThan > 0 {
+		whereClauses = append(whereClauses, "julianday(updated_at) < julianday(?)")
+		args = append(args, t.parent.now().Add(-filter.StalerThan).UTC().Format(time.RFC3339Nano))
+	}
+
+	// Scheduling; a NULL due_date never compares true
+	if filter.DueBefore != nil {
+		whereClauses = append(whereClauses, "julianday(due_date) < julianday(?)")
+		args = append(args, filter.DueBefore.UTC().Format(time.RFC3339Nano))
+	}
+	if filter.Overdue {
+		whereClauses = append(whereClauses, "julianday(due_date) < julianday(?) AND status NOT IN ('closed', 'tombstone')")
+		args = append(args, t.parent.now().UTC().Format(time.RFC3339Nano))
+	}
+
 	// Empty/null checks
 	if filter.EmptyDescription {
 		whereClauses = append(whereClauses, "(description IS NULL OR description = '')")
@@ -988,6 +1144,14 @@ func (t *sqliteTxStorage) SearchIssues(ctx context.Context, query string, filter
 		whereClauses = append(whereClauses, "id NOT IN (SELECT DISTINCT issue_id FROM labels)")
 	}
 
+	// Dependency graph position
+	if filter.IsRoot {
+		whereClauses = append(whereClauses, "NOT EXISTS (SELECT 1 FROM dependencies d WHERE d.issue_id = issues.id)")
+	}
+	if filter.IsLeaf {
+		whereClauses = append(whereClauses, "NOT EXISTS (SELECT 1 FROM dependencies d WHERE d.depends_on_id = issues.id)")
+	}
+
 	// Label filtering: issue must have ALL specified labels
 	if len(filter.Labels) > 0 {
 		for _, label := range filter.Labels {
@@ -1006,6 +1170,12 @@ func (t *sqliteTxStorage) SearchIssues(ctx context.Context, query string, filter
 		whereClauses = append(whereClauses, fmt.Sprintf("id IN (SELECT issue_id FROM labels WHERE label IN (%s))", strings.Join(placeholders, ", ")))
 	}
 
+	// Label filtering (glob): issue must have a label matching EACH glob
+	for _, glob := range filter.LabelGlobs {
+		whereClauses = append(whereClauses, `id IN (SELECT issue_id FROM labels WHERE label LIKE ? ESCAPE '\')`)
+		args = append(args, labelGlobPattern(glob, false))
+	}
+
 	// ID filtering: match specific issue IDs
 	if len(filter.IDs) > 0 {
 		placeholders := make([]string, len(filter.IDs))
@@ -1021,24 +1191,18 @@ func (t *sqliteTxStorage) SearchIssues(ctx context.Context, query string, filter
 		whereSQL = "WHERE " + strings.Join(whereClauses, " AND ")
 	}
 
-	limitSQL := ""
-	if filter.Limit > 0 {
-		limitSQL = " LIMIT ?"
-		args = append(args, filter.Limit)
-	}
+	limitSQL, limitArgs := searchLimitSQL(filter)
+	args = append(args, limitArgs...)
 
 	// #nosec G201 - safe SQL with controlled formatting
 	querySQL := fmt.Sprintf(`
-		SELECT id, content_hash, title, description, design, acceptance_criteria, notes,
-		       status, priority, issue_type, assignee, estimated_minutes,
-		       created_at, updated_at, closed_at, external_ref,
-		       compaction_level, compacted_at, compacted_at_commit, original_size, source_repo, close_reason,
-		       deleted_at, deleted_by, delete_reason, original_type
+		SELECT %s
 		FROM issues
 		%s
-		ORDER BY priority ASC, created_at DESC
 		%s
-	`, whereSQL, limitSQL)
+		ORDER BY %s
+		%s
+	`, selectSQL, search.join, whereSQL, orderSQL, limitSQL)
 
 	rows, err := t.conn.QueryContext(ctx, querySQL, args...)
 	if err != nil {
@@ -1046,7 +1210,19 @@ func (t *sqliteTxStorage) SearchIssues(ctx context.Context, query string, filter
 	}
 	defer func() { _ = rows.Close() }()
 
-	return t.scanIssues(ctx, rows)
+	var issues []*types.Issue
+	if columns == nil {
+		issues, err = t.scanIssues(ctx, rows)
+	} else if issues, err = scanProjectedIssues(ctx, rows, columns); err == nil && withLabels {
+		var labelsMap map[string][]string
+		if labelsMap, err = t.getLabelsForIssues(ctx, issueIDs(issues)); err == nil {
+			attachLabels(issues, labelsMap)
+		}
+	}
+	if err != nil || scoreExpr == "" {
+		return issues, err
+	}
+	return issues, fillReadyScores(ctx, t.conn, issues, scoreExpr, "issues")
 }
 
 // scanner is an interface that both *sql.Row and *sql.Rows satisfy
@@ -1080,11 +1256,14 @@ func scanIssueRow(row scanner) (*types.Issue, error) {
 		&issue.Priority, &issue.IssueType, &assignee, &estimatedMinutes,
 		&issue.CreatedAt, &issue.UpdatedAt, &closedAt, &externalRef,
 		&issue.CompactionLevel, &compactedAt, &compactedAtCommit, &originalSize, &sourceRepo, &closeReason,
-		&deletedAt, &deletedBy, &deleteReason, &originalType,
+		&deletedAt, &deletedBy, &deleteReason, &originalType, &issue.Draft, &issue.PercentComplete, &issue.ExternalBlockedReason, &issue.SnoozedUntil, &issue.DueDate, &issue.Version,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to scan issue: %w", err)
 	}
+	if err := decodeDescription(&issue.Description); err != nil {
+		return nil, err
+	}
 
 	if contentHash.Valid {
 		issue.ContentHash = contentHash.String
@@ -858,7 +858,7 @@ func TestTransactionSetConfig(t *testing.T) {
 	defer cleanup()
 
 	err := store.RunInTransaction(ctx, func(tx storage.Transaction) error {
-		return tx.SetConfig(ctx, "test.key", "test-value")
+		return tx.SetConfig(ctx, "custom.test_key", "test-value")
 	})
 
 	if err != nil {
@@ -866,7 +866,7 @@ func TestTransactionSetConfig(t *testing.T) {
 	}
 
 	// Verify config was set
-	value, err := store.GetConfig(ctx, "test.key")
+	value, err := store.GetConfig(ctx, "custom.test_key")
 	if err != nil {
 		t.Fatalf("GetConfig failed: %v", err)
 	}
@@ -883,12 +883,12 @@ func TestTransactionGetConfig(t *testing.T) {
 
 	err := store.RunInTransaction(ctx, func(tx storage.Transaction) error {
 		// Set config
-		if err := tx.SetConfig(ctx, "test.key", "test-value"); err != nil {
+		if err := tx.SetConfig(ctx, "custom.test_key", "test-value"); err != nil {
 			return err
 		}
 
 		// Read it back within same transaction
-		value, err := tx.GetConfig(ctx, "test.key")
+		value, err := tx.GetConfig(ctx, "custom.test_key")
 		if err != nil {
 			return err
 		}
@@ -910,7 +910,7 @@ func TestTransactionConfigRollback(t *testing.T) {
 	defer cleanup()
 
 	err := store.RunInTransaction(ctx, func(tx storage.Transaction) error {
-		if err := tx.SetConfig(ctx, "test.key", "test-value"); err != nil {
+		if err := tx.SetConfig(ctx, "custom.test_key", "test-value"); err != nil {
 			return err
 		}
 		return &testError{msg: "intentional rollback"}
@@ -921,7 +921,7 @@ func TestTransactionConfigRollback(t *testing.T) {
 	}
 
 	// Verify config was NOT set (rolled back)
-	value, err := store.GetConfig(ctx, "test.key")
+	value, err := store.GetConfig(ctx, "custom.test_key")
 	if err != nil {
 		t.Fatalf("GetConfig failed: %v", err)
 	}
@@ -1181,7 +1181,7 @@ func TestTransactionAtomicConfigWithIssue(t *testing.T) {
 		issueID = issue.ID
 
 		// Set config referencing the issue
-		if err := tx.SetConfig(ctx, "last_created_issue", issue.ID); err != nil {
+		if err := tx.SetConfig(ctx, "custom.last_created_issue", issue.ID); err != nil {
 			return err
 		}
 
@@ -1203,7 +1203,7 @@ func TestTransactionAtomicConfigWithIssue(t *testing.T) {
 		t.Error("expected issue to exist")
 	}
 
-	configValue, err := store.GetConfig(ctx, "last_created_issue")
+	configValue, err := store.GetConfig(ctx, "custom.last_created_issue")
 	if err != nil {
 		t.Fatalf("GetConfig failed: %v", err)
 	}
@@ -1227,13 +1227,13 @@ func TestTransactionConfigOverwrite(t *testing.T) {
 	defer cleanup()
 
 	// Set initial value
-	if err := store.SetConfig(ctx, "test.key", "initial"); err != nil {
+	if err := store.SetConfig(ctx, "custom.test_key", "initial"); err != nil {
 		t.Fatalf("SetConfig failed: %v", err)
 	}
 
 	// Overwrite in transaction
 	err := store.RunInTransaction(ctx, func(tx storage.Transaction) error {
-		return tx.SetConfig(ctx, "test.key", "updated")
+		return tx.SetConfig(ctx, "custom.test_key", "updated")
 	})
 
 	if err != nil {
@@ -1241,7 +1241,7 @@ func TestTransactionConfigOverwrite(t *testing.T) {
 	}
 
 	// Verify overwrite
-	value, err := store.GetConfig(ctx, "test.key")
+	value, err := store.GetConfig(ctx, "custom.test_key")
 	if err != nil {
 		t.Fatalf("GetConfig failed: %v", err)
 	}
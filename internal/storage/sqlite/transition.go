@@ -0,0 +1,130 @@
+// Package sqlite - bulk status transitions
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// TransitionResult reports what TransitionStatus did with each issue
+type TransitionResult struct {
+	Transitioned []string              `json:"transitioned"`        // Moved to the target status
+	Unchanged    []string              `json:"unchanged,omitempty"` // Already in the target status
+	Rejected     []TransitionRejection `json:"rejected,omitempty"`  // Left as they were
+}
+
+// TransitionRejection is an issue TransitionStatus didn't move, and why
+type TransitionRejection struct {
+	IssueID string       `json:"issue_id"`
+	From    types.Status `json:"from,omitempty"` // Empty if the issue doesn't exist
+	Reason  string       `json:"reason"`
+}
+
+// statusTransitions maps a status to the statuses its issues may move to.
+// Statuses without an entry may move to any status.
+type statusTransitions map[types.Status]map[types.Status]bool
+
+// TransitionStatus moves every issue in ids to status to in a single
+// transaction, as UpdateIssue would one at a time, e.g. to close a batch of
+// finished issues. Each move is checked against the project's state machine:
+// by default an issue may move between any statuses except out of tombstone,
+// and StatusTransitionsConfigPrefix keys restrict where issues in a given
+// status may go. Issues the state machine forbids, and IDs that don't exist,
+// are rejected and left alone while the rest move; issues already in to are
+// unchanged. Any other failure, such as an invalid target status or a close
+// refused by close.require_closed_children, rolls back the whole batch.
+func (s *SQLiteStorage) TransitionStatus(ctx context.Context, ids []string, to types.Status, actor string) (*TransitionResult, error) {
+	customStatuses, err := s.GetCustomStatuses(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get custom statuses: %w", err)
+	}
+	if err := validateStatusWithCustom(string(to), customStatuses); err != nil {
+		return nil, err
+	}
+
+	var result *TransitionResult
+	err = s.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		t := tx.(*sqliteTxStorage)
+		transitions, err := readStatusTransitions(ctx, t.conn)
+		if err != nil {
+			return err
+		}
+
+		result = &TransitionResult{Transitioned: []string{}}
+		seen := make(map[string]bool, len(ids))
+		for _, id := range ids {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+
+			issue, err := t.GetIssue(ctx, id)
+			if err != nil {
+				return err
+			}
+			if issue == nil {
+				result.Rejected = append(result.Rejected, TransitionRejection{IssueID: id, Reason: "issue not found"})
+				continue
+			}
+			if issue.Status == to {
+				result.Unchanged = append(result.Unchanged, id)
+				continue
+			}
+			if reason := transitions.check(issue.Status, to); reason != "" {
+				result.Rejected = append(result.Rejected, TransitionRejection{IssueID: id, From: issue.Status, Reason: reason})
+				continue
+			}
+
+			if err := t.UpdateIssue(ctx, id, map[string]interface{}{"status": string(to)}, actor); err != nil {
+				return fmt.Errorf("failed to move %s to %s: %w", id, to, err)
+			}
+			result.Transitioned = append(result.Transitioned, id)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// readStatusTransitions reads the StatusTransitionsConfigPrefix keys on q
+func readStatusTransitions(ctx context.Context, q queryer) (statusTransitions, error) {
+	rows, err := q.QueryContext(ctx, `SELECT key, value FROM config WHERE key LIKE ?`, StatusTransitionsConfigPrefix+"%")
+	if err != nil {
+		return nil, wrapDBError("query status transitions", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	transitions := make(statusTransitions)
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, wrapDBError("scan status transition", err)
+		}
+		targets := make(map[types.Status]bool)
+		for _, status := range parseCustomStatuses(value) {
+			targets[types.Status(status)] = true
+		}
+		transitions[types.Status(strings.TrimPrefix(key, StatusTransitionsConfigPrefix))] = targets
+	}
+	if err := rows.Err(); err != nil {
+		return nil, wrapDBError("iterate status transitions", err)
+	}
+	return transitions, nil
+}
+
+// check returns why an issue can't move from from to to, or "" if it can
+func (st statusTransitions) check(from, to types.Status) string {
+	if from == types.StatusTombstone {
+		return "deleted issues can't change status"
+	}
+	if targets, ok := st[from]; ok && !targets[to] {
+		return fmt.Sprintf("%s issues can't move to %s (see %s%s)", from, to, StatusTransitionsConfigPrefix, from)
+	}
+	return ""
+}
@@ -0,0 +1,82 @@
+package sqlite
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestTransitionStatus(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	var ids []string
+	for _, title := range []string{"First", "Second", "Third", "Deleted"} {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		ids = append(ids, issue.ID)
+	}
+	first, second, third, deleted := ids[0], ids[1], ids[2], ids[3]
+	if err := store.CloseIssue(ctx, third, "done", "test"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	if err := store.CreateTombstone(ctx, deleted, "test", "duplicate"); err != nil {
+		t.Fatalf("CreateTombstone failed: %v", err)
+	}
+
+	result, err := store.TransitionStatus(ctx, []string{first, second, first, third, deleted, "bd-missing"}, types.StatusClosed, "alice")
+	if err != nil {
+		t.Fatalf("TransitionStatus failed: %v", err)
+	}
+	sort.Strings(result.Transitioned)
+	want := []string{first, second}
+	sort.Strings(want)
+	if !reflect.DeepEqual(result.Transitioned, want) {
+		t.Errorf("Expected %v to be closed, got %v", want, result.Transitioned)
+	}
+	if !reflect.DeepEqual(result.Unchanged, []string{third}) {
+		t.Errorf("Expected %s to be unchanged, got %v", third, result.Unchanged)
+	}
+	if len(result.Rejected) != 2 || result.Rejected[0].IssueID != deleted || result.Rejected[0].From != types.StatusTombstone ||
+		result.Rejected[1].IssueID != "bd-missing" {
+		t.Errorf("Expected the tombstone and the missing issue to be rejected, got %+v", result.Rejected)
+	}
+	got, err := store.GetIssue(ctx, first)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.Status != types.StatusClosed || got.ClosedAt == nil {
+		t.Errorf("Expected %s to be closed with a close time, got %s at %v", first, got.Status, got.ClosedAt)
+	}
+
+	// The configured state machine restricts moves out of closed
+	if err := store.SetConfig(ctx, StatusTransitionsConfigPrefix+"closed", "open"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	result, err = store.TransitionStatus(ctx, []string{first}, types.StatusInProgress, "alice")
+	if err != nil {
+		t.Fatalf("TransitionStatus failed: %v", err)
+	}
+	if len(result.Transitioned) != 0 || len(result.Rejected) != 1 || result.Rejected[0].From != types.StatusClosed {
+		t.Errorf("Expected closed -> in_progress to be rejected, got %+v", result)
+	}
+	result, err = store.TransitionStatus(ctx, []string{first}, types.StatusOpen, "alice")
+	if err != nil {
+		t.Fatalf("TransitionStatus failed: %v", err)
+	}
+	if !reflect.DeepEqual(result.Transitioned, []string{first}) {
+		t.Errorf("Expected closed -> open to be allowed, got %+v", result)
+	}
+
+	if _, err := store.TransitionStatus(ctx, []string{first}, types.StatusTombstone, "alice"); err == nil {
+		t.Error("Expected moving to tombstone to be refused")
+	}
+	if _, err := store.TransitionStatus(ctx, []string{first}, "nonsense", "alice"); err == nil {
+		t.Error("Expected an invalid status to be refused")
+	}
+}
@@ -0,0 +1,428 @@
+// Package sqlite - undo of an actor's most recent operation
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// undoableEvents lists the event types Undo knows how to revert
+var undoableEvents = []types.EventType{
+	types.EventCreated,
+	types.EventUpdated,
+	types.EventStatusChanged,
+	types.EventPriorityChanged,
+	types.EventClosed,
+	types.EventReopened,
+	types.EventDependencyAdded,
+	types.EventDependencyRemoved,
+	types.EventLabelAdded,
+	types.EventLabelRemoved,
+	"deleted", // Tombstone creation (see CreateTombstone)
+}
+
+// UndoResult describes the operation reverted by Undo
+type UndoResult struct {
+	EventID     int64           `json:"event_id"`   // The audit event that was reverted
+	EventType   types.EventType `json:"event_type"` // Type of the reverted event
+	IssueID     string          `json:"issue_id"`
+	Description string          `json:"description"` // Human-readable summary of what was reverted
+}
+
+// undoTarget is the audit event selected for reverting
+type undoTarget struct {
+	id        int64
+	issueID   string
+	eventType types.EventType
+	oldValue  sql.NullString
+	newValue  sql.NullString
+	comment   sql.NullString
+}
+
+// Undo reverts the most recent mutation made by actor, using the before/after
+// values recorded in the events table:
+//   - field updates, status changes, closes and reopens restore prior values
+//   - a created issue is tombstoned; a tombstoned issue is restored
+//   - added dependencies and labels are removed; removed ones are re-added
+//
+// The revert runs in a single transaction, is attributed to actor, and is
+// followed by an "undone" event so that calling Undo again reverts the
+// operation before it rather than redoing this one.
+//
+// Undo refuses (returning an error wrapping ErrConflict) if another actor has
+// touched the affected issue since, so it never clobbers someone else's work.
+// If actor has nothing left to undo, the error wraps ErrNotFound.
+func (s *SQLiteStorage) Undo(ctx context.Context, actor string) (UndoResult, error) {
+	var result UndoResult
+	err := s.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		t := tx.(*sqliteTxStorage)
+
+		target, err := findUndoTarget(ctx, t.conn, actor)
+		if err != nil {
+			return err
+		}
+
+		affected := []string{target.issueID}
+		var edge types.DepEdge
+		if target.eventType == types.EventDependencyAdded || target.eventType == types.EventDependencyRemoved {
+			edge, err = parseDepEdgeEvent(target)
+			if err != nil {
+				return err
+			}
+			affected = append(affected, edge.To)
+		}
+		if err := checkUndoConflict(ctx, t.conn, target, actor, affected); err != nil {
+			return err
+		}
+
+		// Events recorded by the revert itself are hidden from later Undo calls
+		// by the marker below, which remembers where they start.
+		var lastEventID int64
+		if err := t.conn.QueryRowContext(ctx, `SELECT COALESCE(MAX(id), 0) FROM events`).Scan(&lastEventID); err != nil {
+			return wrapDBError("read last event id", err)
+		}
+
+		description, err := t.revertEvent(ctx, target, edge, actor)
+		if err != nil {
+			return err
+		}
+
+		_, err = t.conn.ExecContext(ctx, `
+			INSERT INTO events (issue_id, event_type, actor, old_value, new_value, comment)
+			VALUES (?, ?, ?, ?, ?, ?)
+		`, target.issueID, types.EventUndone, actor, fmt.Sprint(target.id), fmt.Sprint(lastEventID), description)
+		if err != nil {
+			return fmt.Errorf("failed to record undo event: %w", err)
+		}
+
+		result = UndoResult{
+			EventID:     target.id,
+			EventType:   target.eventType,
+			IssueID:     target.issueID,
+			Description: description,
+		}
+		return nil
+	})
+	if err != nil {
+		return UndoResult{}, err
+	}
+	return result, nil
+}
+
+// findUndoTarget returns actor's most recent undoable event that has not
+// already been undone and was not itself recorded by an Undo.
+func findUndoTarget(ctx context.Context, conn *sql.Conn, actor string) (*undoTarget, error) {
+	placeholders := make([]string, len(undoableEvents))
+	args := []interface{}{actor}
+	for i, eventType := range undoableEvents {
+		placeholders[i] = "?"
+		args = append(args, eventType)
+	}
+	args = append(args, types.EventUndone)
+
+	// An "undone" marker stores the reverted event id in old_value and the last
+	// event id before the revert in new_value; events between that and the
+	// marker were written by the revert.
+	// #nosec G201 -- placeholders are generated internally
+	query := fmt.Sprintf(`
+		SELECT e.id, e.issue_id, e.event_type, e.old_value, e.new_value, e.comment
+		FROM events e
+		WHERE e.actor = ? AND e.event_type IN (%s)
+		  AND NOT EXISTS (
+			SELECT 1 FROM events u
+			WHERE u.event_type = ? AND u.actor = e.actor
+			  AND (e.id = CAST(u.old_value AS INTEGER)
+			       OR (e.id > CAST(u.new_value AS INTEGER) AND e.id < u.id))
+		  )
+		ORDER BY e.id DESC
+		LIMIT 1
+	`, strings.Join(placeholders, ", "))
+
+	var target undoTarget
+	err := conn.QueryRowContext(ctx, query, args...).Scan(
+		&target.id, &target.issueID, &target.eventType, &target.oldValue, &target.newValue, &target.comment,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("nothing to undo for %s: %w", actor, ErrNotFound)
+	}
+	if err != nil {
+		return nil, wrapDBError("find operation to undo", err)
+	}
+	return &target, nil
+}
+
+// checkUndoConflict fails if anyone other than actor has recorded an event on
+// the affected issues since the target event.
+func checkUndoConflict(ctx context.Context, conn *sql.Conn, target *undoTarget, actor string, issueIDs []string) error {
+	args := []interface{}{target.id, actor}
+	for _, id := range issueIDs {
+		args = append(args, id)
+	}
+
+	var other sql.NullString
+	// #nosec G201 -- placeholders are generated internally
+	err := conn.QueryRowContext(ctx, fmt.Sprintf(`
+		SELECT actor FROM events
+		WHERE id > ? AND actor != ? AND issue_id IN (%s)
+		ORDER BY id
+		LIMIT 1
+	`, buildPlaceholders(len(issueIDs))), args...).Scan(&other)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return wrapDBError("check for conflicting changes", err)
+	}
+	return fmt.Errorf("cannot undo %s on %s: modified by %s since: %w", target.eventType, target.issueID, other.String, ErrConflict)
+}
+
+// revertEvent applies the inverse of target and returns a description of it.
+func (t *sqliteTxStorage) revertEvent(ctx context.Context, target *undoTarget, edge types.DepEdge, actor string) (string, error) {
+	switch target.eventType {
+	case types.EventCreated:
+		if err := t.setTombstone(ctx, target.issueID, actor, true); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Deleted %s (undo create)", target.issueID), nil
+
+	case "deleted":
+		if err := t.setTombstone(ctx, target.issueID, actor, false); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Restored deleted issue %s", target.issueID), nil
+
+	case types.EventUpdated, types.EventStatusChanged, types.EventPriorityChanged, types.EventClosed, types.EventReopened:
+		return t.revertUpdate(ctx, target, actor)
+
+	case types.EventDependencyAdded:
+		if err := t.RemoveDependency(ctx, edge.From, edge.To, actor); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Removed dependency %s %s %s", edge.From, edge.Type, edge.To), nil
+
+	case types.EventDependencyRemoved:
+		dep := &types.Dependency{IssueID: edge.From, DependsOnID: edge.To, Type: edge.Type, CreatedBy: actor}
+		if err := t.AddDependency(ctx, dep, actor); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Restored dependency %s %s %s", edge.From, edge.Type, edge.To), nil
+
+	case types.EventLabelAdded:
+		label := strings.TrimPrefix(target.comment.String, "Added label: ")
+		if err := t.RemoveLabel(ctx, target.issueID, label, actor); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Removed label %s from %s", label, target.issueID), nil
+
+	case types.EventLabelRemoved:
+		label := strings.TrimPrefix(target.comment.String, "Removed label: ")
+		if err := t.AddLabel(ctx, target.issueID, label, actor); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Restored label %s on %s", label, target.issueID), nil
+	}
+	return "", fmt.Errorf("cannot undo %s events", target.eventType)
+}
+
+// revertUpdate restores the fields changed by an UpdateIssue or CloseIssue event.
+func (t *sqliteTxStorage) revertUpdate(ctx context.Context, target *undoTarget, actor string) (string, error) {
+	// CloseIssue records only the reason, not the prior state, so the status
+	// it closed from is found from the issue's earlier events
+	if !target.oldValue.Valid {
+		if target.eventType != types.EventClosed {
+			return "", fmt.Errorf("cannot undo %s event %d: prior values were not recorded", target.eventType, target.id)
+		}
+		status, err := statusBefore(ctx, t.conn, target.issueID, target.id)
+		if err != nil {
+			return "", err
+		}
+		if status == "" || status == types.StatusClosed {
+			status = types.StatusOpen
+		}
+		if err := t.UpdateIssue(ctx, target.issueID, map[string]interface{}{"status": string(status)}, actor); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Reopened %s as %s (undo close)", target.issueID, status), nil
+	}
+
+	var old types.Issue
+	if err := json.Unmarshal([]byte(target.oldValue.String), &old); err != nil {
+		return "", fmt.Errorf("failed to parse prior values of event %d: %w", target.id, err)
+	}
+	var changed map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(target.newValue.String), &changed); err != nil {
+		return "", fmt.Errorf("failed to parse changes of event %d: %w", target.id, err)
+	}
+
+	updates := make(map[string]interface{})
+	for field := range changed {
+		switch field {
+		case "title":
+			updates[field] = old.Title
+		case "description":
+			updates[field] = old.Description
+		case "design":
+			updates[field] = old.Design
+		case "acceptance_criteria":
+			updates[field] = old.AcceptanceCriteria
+		case "notes":
+			updates[field] = old.Notes
+		case "status":
+			updates[field] = string(old.Status)
+		case "priority":
+			updates[field] = old.Priority
+		case "issue_type":
+			updates[field] = string(old.IssueType)
+		case "assignee":
+			updates[field] = old.Assignee
+		case "percent_complete":
+			updates[field] = old.PercentComplete
+		case "estimated_minutes":
+			if old.EstimatedMinutes != nil {
+				updates[field] = *old.EstimatedMinutes
+			} else {
+				updates[field] = nil
+			}
+		case "external_ref":
+			if old.ExternalRef != nil {
+				updates[field] = *old.ExternalRef
+			} else {
+				updates[field] = nil
+			}
+		case "external_blocked_reason":
+			if old.ExternalBlockedReason != nil {
+				updates[field] = *old.ExternalBlockedReason
+			} else {
+				updates[field] = nil
+			}
+		case "snoozed_until":
+			if old.SnoozedUntil != nil {
+				updates[field] = *old.SnoozedUntil
+			} else {
+				updates[field] = nil
+			}
+		case "due_date":
+			if old.DueDate != nil {
+				updates[field] = *old.DueDate
+			} else {
+				updates[field] = nil
+			}
+		}
+		// closed_at and close_reason follow status (handled below)
+	}
+	if _, hasStatus := updates["status"]; hasStatus && old.Status == types.StatusClosed && old.ClosedAt != nil {
+		updates["closed_at"] = *old.ClosedAt
+	}
+	if len(updates) == 0 {
+		return "", fmt.Errorf("cannot undo %s event %d: no revertible fields", target.eventType, target.id)
+	}
+
+	if err := t.UpdateIssue(ctx, target.issueID, updates, actor); err != nil {
+		return "", err
+	}
+	if _, hasStatus := updates["status"]; hasStatus && old.Status == types.StatusClosed {
+		if _, err := t.conn.ExecContext(ctx, `UPDATE issues SET close_reason = ? WHERE id = ?`, old.CloseReason, target.issueID); err != nil {
+			return "", fmt.Errorf("failed to restore close reason: %w", err)
+		}
+	}
+
+	fields := make([]string, 0, len(updates))
+	for field := range updates {
+		if field != "closed_at" {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+	return fmt.Sprintf("Restored %s on %s", strings.Join(fields, ", "), target.issueID), nil
+}
+
+// setTombstone soft-deletes an issue (deleted=true) or restores a tombstone
+// to an open issue of its original type (deleted=false).
+func (t *sqliteTxStorage) setTombstone(ctx context.Context, id, actor string, deleted bool) error {
+	now := time.Now()
+	var result sql.Result
+	var err error
+	if deleted {
+		// closed_at must be NULL for non-closed statuses (CHECK constraint)
+		result, err = t.conn.ExecContext(ctx, `
+			UPDATE issues
+			SET status = ?, closed_at = NULL, deleted_at = ?, deleted_by = ?,
+			    delete_reason = ?, original_type = issue_type, updated_at = ?
+			WHERE id = ? AND status != ?
+		`, types.StatusTombstone, now, actor, "undo create", now, id, types.StatusTombstone)
+	} else {
+		result, err = t.conn.ExecContext(ctx, `
+			UPDATE issues
+			SET status = ?, issue_type = COALESCE(NULLIF(original_type, ''), issue_type),
+			    deleted_at = NULL, deleted_by = NULL, delete_reason = NULL,
+			    original_type = NULL, updated_at = ?
+			WHERE id = ? AND status = ?
+		`, types.StatusOpen, now, id, types.StatusTombstone)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update tombstone for %s: %w", id, err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("cannot undo on %s: issue is missing or already in the target state", id)
+	}
+
+	if !deleted {
+		issue, err := t.GetIssue(ctx, id)
+		if err != nil {
+			return err
+		}
+		if _, err := t.conn.ExecContext(ctx, `UPDATE issues SET content_hash = ? WHERE id = ?`, issue.ComputeContentHash(), id); err != nil {
+			return fmt.Errorf("failed to update content hash: %w", err)
+		}
+	}
+
+	if err := markDirty(ctx, t.conn, id); err != nil {
+		return fmt.Errorf("failed to mark issue dirty: %w", err)
+	}
+	if err := t.parent.invalidateBlockedCache(ctx, t.conn, id); err != nil {
+		return fmt.Errorf("failed to invalidate blocked cache: %w", err)
+	}
+	return nil
+}
+
+// parseDepEdgeEvent recovers the dependency edge from a dependency event.
+// Older dependency_added events only carry the edge in their comment.
+func parseDepEdgeEvent(target *undoTarget) (types.DepEdge, error) {
+	raw := target.newValue
+	if target.eventType == types.EventDependencyRemoved {
+		raw = target.oldValue
+	}
+	var edge types.DepEdge
+	if raw.Valid {
+		if err := json.Unmarshal([]byte(raw.String), &edge); err != nil {
+			return edge, fmt.Errorf("failed to parse dependency of event %d: %w", target.id, err)
+		}
+		return edge, nil
+	}
+
+	if target.eventType == types.EventDependencyAdded {
+		fields := strings.Fields(strings.TrimPrefix(target.comment.String, "Added dependency: "))
+		if len(fields) == 3 {
+			return types.DepEdge{From: fields[0], Type: types.DependencyType(fields[1]), To: fields[2]}, nil
+		}
+	}
+	return edge, fmt.Errorf("cannot undo %s event %d: dependency was not recorded", target.eventType, target.id)
+}
+
+// depEdgeJSON encodes a dependency edge for storage in an event's old/new value
+func depEdgeJSON(from, to string, depType types.DependencyType) string {
+	data, err := json.Marshal(types.DepEdge{From: from, To: to, Type: depType})
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
@@ -0,0 +1,214 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestUndoUpdate(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Original", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "agent"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"title": "Changed", "priority": 0}, "agent"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if err := store.CloseIssue(ctx, issue.ID, "done", "agent"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+
+	// First undo reopens
+	result, err := store.Undo(ctx, "agent")
+	if err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	if result.EventType != types.EventClosed || result.IssueID != issue.ID {
+		t.Errorf("Expected close of %s to be undone, got %+v", issue.ID, result)
+	}
+	got, _ := store.GetIssue(ctx, issue.ID)
+	if got.Status != types.StatusOpen || got.ClosedAt != nil {
+		t.Errorf("Expected reopened issue, got status %s closed_at %v", got.Status, got.ClosedAt)
+	}
+
+	// Second undo restores the prior fields rather than redoing the close
+	result, err = store.Undo(ctx, "agent")
+	if err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	if result.EventType != types.EventUpdated {
+		t.Errorf("Expected update to be undone, got %+v", result)
+	}
+	got, _ = store.GetIssue(ctx, issue.ID)
+	if got.Title != "Original" || got.Priority != 2 {
+		t.Errorf("Expected original title and priority, got %q P%d", got.Title, got.Priority)
+	}
+
+	// Third undo removes the created issue
+	if _, err := store.Undo(ctx, "agent"); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	got, _ = store.GetIssue(ctx, issue.ID)
+	if got.Status != types.StatusTombstone {
+		t.Errorf("Expected undo of create to tombstone the issue, got %s", got.Status)
+	}
+
+	if _, err := store.Undo(ctx, "agent"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound with nothing left to undo, got %v", err)
+	}
+}
+
+func TestUndoCloseRestoresPriorStatus(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "In flight", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "agent"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"status": string(types.StatusInProgress)}, "agent"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if err := store.CloseIssue(ctx, issue.ID, "done", "agent"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+
+	// The close records no prior values, so the status comes from the update before it
+	if _, err := store.Undo(ctx, "agent"); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	got, _ := store.GetIssue(ctx, issue.ID)
+	if got.Status != types.StatusInProgress || got.ClosedAt != nil {
+		t.Errorf("Expected the issue back in progress, got status %s closed_at %v", got.Status, got.ClosedAt)
+	}
+}
+
+func TestUndoRestoresClosedIssue(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Closed", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "setup"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := store.CloseIssue(ctx, issue.ID, "shipped", "setup"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	closed, _ := store.GetIssue(ctx, issue.ID)
+
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"status": string(types.StatusOpen)}, "agent"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if _, err := store.Undo(ctx, "agent"); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+
+	got, _ := store.GetIssue(ctx, issue.ID)
+	if got.Status != types.StatusClosed {
+		t.Fatalf("Expected closed status restored, got %s", got.Status)
+	}
+	if got.ClosedAt == nil || !got.ClosedAt.Equal(*closed.ClosedAt) {
+		t.Errorf("Expected closed_at %v restored, got %v", closed.ClosedAt, got.ClosedAt)
+	}
+	if got.CloseReason != "shipped" {
+		t.Errorf("Expected close reason restored, got %q", got.CloseReason)
+	}
+}
+
+func TestUndoDependencyAndLabel(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	a := &types.Issue{Title: "A", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	b := &types.Issue{Title: "B", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{a, b} {
+		if err := store.CreateIssue(ctx, issue, "setup"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	dep := &types.Dependency{IssueID: a.ID, DependsOnID: b.ID, Type: types.DepParentChild}
+	if err := store.AddDependency(ctx, dep, "setup"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+
+	// Removing a dependency and undoing restores it with its type
+	if err := store.RemoveDependency(ctx, a.ID, b.ID, "agent"); err != nil {
+		t.Fatalf("RemoveDependency failed: %v", err)
+	}
+	if _, err := store.Undo(ctx, "agent"); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	records, err := store.GetDependencyRecords(ctx, a.ID)
+	if err != nil {
+		t.Fatalf("GetDependencyRecords failed: %v", err)
+	}
+	if len(records) != 1 || records[0].Type != types.DepParentChild {
+		t.Fatalf("Expected restored parent-child dependency, got %+v", records)
+	}
+
+	// Adding a label and undoing removes it
+	if err := store.AddLabel(ctx, a.ID, "urgent", "agent"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+	if _, err := store.Undo(ctx, "agent"); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	labels, _ := store.GetLabels(ctx, a.ID)
+	if len(labels) != 0 {
+		t.Errorf("Expected label removed, got %v", labels)
+	}
+}
+
+func TestUndoRestoresTombstone(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Feature", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeFeature}
+	if err := store.CreateIssue(ctx, issue, "setup"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := store.CreateTombstone(ctx, issue.ID, "agent", "oops"); err != nil {
+		t.Fatalf("CreateTombstone failed: %v", err)
+	}
+
+	result, err := store.Undo(ctx, "agent")
+	if err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	if result.EventType != "deleted" {
+		t.Errorf("Expected delete to be undone, got %+v", result)
+	}
+	got, _ := store.GetIssue(ctx, issue.ID)
+	if got.Status != types.StatusOpen || got.IssueType != types.TypeFeature || got.DeletedAt != nil {
+		t.Errorf("Expected restored open feature, got status %s type %s deleted_at %v", got.Status, got.IssueType, got.DeletedAt)
+	}
+}
+
+func TestUndoRefusesAfterOtherActor(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Shared", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "setup"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"title": "Agent edit"}, "agent"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"notes": "Human edit"}, "human"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+
+	if _, err := store.Undo(ctx, "agent"); !errors.Is(err, ErrConflict) {
+		t.Fatalf("Expected ErrConflict, got %v", err)
+	}
+	got, _ := store.GetIssue(ctx, issue.ID)
+	if got.Title != "Agent edit" || got.Notes != "Human edit" {
+		t.Errorf("Refused undo must not change the issue, got title %q notes %q", got.Title, got.Notes)
+	}
+}
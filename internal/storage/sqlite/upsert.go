@@ -0,0 +1,124 @@
+// Package sqlite - idempotent create-or-update keyed by external ID
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// UpsertByExternalID makes sure an issue with external_ref externalID exists
+// with the fields of issue. If none exists it is created (with the project's
+// creation defaults for unset fields); otherwise the existing issue is updated
+// to match. Lookup and write run in one IMMEDIATE transaction, so concurrent
+// syncs of the same external item can't both create it.
+//
+// On update, the content fields (title, description, design, acceptance
+// criteria, notes, status, assignee) and percent complete are taken from
+// issue as given, except
+// that an empty title or status leaves the stored value alone. Priority,
+// issue type, estimate and due date are only changed when set (PriorityUnset,
+// "" and nil mean "keep"). Fields that already match are not written, so re-syncing an
+// unchanged item records no event. issue itself is not modified; the stored
+// issue is returned along with whether it was created.
+func (s *SQLiteStorage) UpsertByExternalID(ctx context.Context, externalID string, issue *types.Issue, actor string) (*types.Issue, bool, error) {
+	externalID = strings.TrimSpace(externalID)
+	if externalID == "" {
+		return nil, false, fmt.Errorf("external ID is required")
+	}
+	if issue == nil {
+		return nil, false, fmt.Errorf("issue is required")
+	}
+
+	var result *types.Issue
+	var created bool
+	err := s.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		t := tx.(*sqliteTxStorage)
+
+		var id string
+		err := t.conn.QueryRowContext(ctx, `SELECT id FROM issues WHERE external_ref = ?`, externalID).Scan(&id)
+		switch {
+		case err == sql.ErrNoRows:
+			newIssue := *issue
+			newIssue.ID = ""
+			newIssue.ExternalRef = &externalID
+			if newIssue.Status == "" {
+				newIssue.Status = types.StatusOpen
+			}
+			if err := t.CreateIssue(ctx, &newIssue, actor); err != nil {
+				return err
+			}
+			id = newIssue.ID
+			created = true
+		case err != nil:
+			return wrapDBError("look up external ID", err)
+		default:
+			existing, err := t.GetIssue(ctx, id)
+			if err != nil {
+				return fmt.Errorf("failed to get issue: %w", err)
+			}
+			if existing == nil {
+				return fmt.Errorf("issue %s: %w", id, ErrNotFound)
+			}
+			if updates := upsertUpdates(existing, issue); len(updates) > 0 {
+				if err := t.UpdateIssue(ctx, id, updates, actor); err != nil {
+					return err
+				}
+			}
+		}
+
+		result, err = t.GetIssue(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get issue: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return result, created, nil
+}
+
+// upsertUpdates returns the UpdateIssue map that brings existing in line
+// with incoming, following the rules documented on UpsertByExternalID
+func upsertUpdates(existing, incoming *types.Issue) map[string]interface{} {
+	updates := make(map[string]interface{})
+	setString := func(key, have, want string) {
+		if have != want {
+			updates[key] = want
+		}
+	}
+
+	if incoming.Title != "" {
+		setString("title", existing.Title, incoming.Title)
+	}
+	setString("description", existing.Description, incoming.Description)
+	setString("design", existing.Design, incoming.Design)
+	setString("acceptance_criteria", existing.AcceptanceCriteria, incoming.AcceptanceCriteria)
+	setString("notes", existing.Notes, incoming.Notes)
+	setString("assignee", existing.Assignee, incoming.Assignee)
+	if incoming.Status != "" {
+		setString("status", string(existing.Status), string(incoming.Status))
+	}
+	if incoming.IssueType != "" {
+		setString("issue_type", string(existing.IssueType), string(incoming.IssueType))
+	}
+	if incoming.Priority != types.PriorityUnset && incoming.Priority != existing.Priority {
+		updates["priority"] = incoming.Priority
+	}
+	if incoming.EstimatedMinutes != nil &&
+		(existing.EstimatedMinutes == nil || *existing.EstimatedMinutes != *incoming.EstimatedMinutes) {
+		updates["estimated_minutes"] = *incoming.EstimatedMinutes
+	}
+	if incoming.PercentComplete != existing.PercentComplete {
+		updates["percent_complete"] = incoming.PercentComplete
+	}
+	if incoming.DueDate != nil && (existing.DueDate == nil || !existing.DueDate.Equal(*incoming.DueDate)) {
+		updates["due_date"] = *incoming.DueDate
+	}
+	return updates
+}
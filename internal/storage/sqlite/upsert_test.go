@@ -0,0 +1,118 @@
+package sqlite
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestUpsertByExternalID(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+	const ref = "gh-42"
+
+	issue, created, err := store.UpsertByExternalID(ctx, ref, &types.Issue{
+		Title:    "From GitHub",
+		Priority: 1,
+	}, "sync")
+	if err != nil {
+		t.Fatalf("UpsertByExternalID failed: %v", err)
+	}
+	if !created {
+		t.Error("Expected first upsert to create")
+	}
+	if issue.ExternalRef == nil || *issue.ExternalRef != ref {
+		t.Errorf("Expected external_ref %s, got %v", ref, issue.ExternalRef)
+	}
+	if issue.Status != types.StatusOpen || issue.IssueType != types.DefaultIssueType {
+		t.Errorf("Expected open %s, got %s %s", types.DefaultIssueType, issue.Status, issue.IssueType)
+	}
+
+	updated, created, err := store.UpsertByExternalID(ctx, ref, &types.Issue{
+		Title:       "Renamed on GitHub",
+		Description: "now with details",
+		Priority:    types.PriorityUnset,
+	}, "sync")
+	if err != nil {
+		t.Fatalf("UpsertByExternalID failed: %v", err)
+	}
+	if created {
+		t.Error("Expected second upsert to update")
+	}
+	if updated.ID != issue.ID {
+		t.Errorf("Expected same issue %s, got %s", issue.ID, updated.ID)
+	}
+	if updated.Title != "Renamed on GitHub" || updated.Description != "now with details" {
+		t.Errorf("Expected fields to be updated, got %q / %q", updated.Title, updated.Description)
+	}
+	if updated.Priority != 1 {
+		t.Errorf("Expected unset priority to keep P1, got P%d", updated.Priority)
+	}
+
+	// Re-syncing the same state is a no-op
+	before, err := store.GetEvents(ctx, issue.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	if _, _, err := store.UpsertByExternalID(ctx, ref, &types.Issue{
+		Title:       "Renamed on GitHub",
+		Description: "now with details",
+		Priority:    types.PriorityUnset,
+	}, "sync"); err != nil {
+		t.Fatalf("UpsertByExternalID failed: %v", err)
+	}
+	after, err := store.GetEvents(ctx, issue.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	if len(after) != len(before) {
+		t.Errorf("Expected no new events, got %d -> %d", len(before), len(after))
+	}
+
+	if _, _, err := store.UpsertByExternalID(ctx, " ", &types.Issue{Title: "x"}, "sync"); err == nil {
+		t.Error("Expected error for empty external ID")
+	}
+}
+
+func TestUpsertByExternalIDConcurrent(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	const workers = 8
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	createdCount := 0
+	ids := make(map[string]bool)
+	errs := make(chan error, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			issue, created, err := store.UpsertByExternalID(ctx, "gh-7", &types.Issue{Title: "Racy", Priority: 2}, "sync")
+			if err != nil {
+				errs <- err
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if created {
+				createdCount++
+			}
+			ids[issue.ID] = true
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatalf("UpsertByExternalID failed: %v", err)
+	}
+
+	if createdCount != 1 {
+		t.Errorf("Expected exactly one create, got %d", createdCount)
+	}
+	if len(ids) != 1 {
+		t.Errorf("Expected all upserts to return the same issue, got %v", ids)
+	}
+}
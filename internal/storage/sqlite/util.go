@@ -9,20 +9,27 @@ import (
 
 // QueryContext exposes the underlying database QueryContext method for advanced queries
 func (s *SQLiteStorage) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
-	return s.db.QueryContext(ctx, query, args...)
+	return s.db().QueryContext(ctx, query, args...)
 }
 
 // BeginTx starts a new database transaction
 // This is used by commands that need to perform multiple operations atomically
 func (s *SQLiteStorage) BeginTx(ctx context.Context) (*sql.Tx, error) {
-	return s.db.BeginTx(ctx, nil)
+	return s.db().BeginTx(ctx, nil)
 }
 
 // withTx executes a function within a database transaction.
 // If the function returns an error, the transaction is rolled back.
 // Otherwise, the transaction is committed.
 func (s *SQLiteStorage) withTx(ctx context.Context, fn func(*sql.Tx) error) error {
-	tx, err := s.db.BeginTx(ctx, nil)
+	// The transaction is bound to ctx: if the query timeout expires, database/sql
+	// rolls it back and further statements fail instead of holding the write lock.
+	ctx, cancel := s.withQueryTimeout(ctx)
+	defer cancel()
+
+	db, release := s.pinPool()
+	defer release()
+	tx, err := db.BeginTx(ctx, nil)
 	if err != nil {
 		return wrapDBError("begin transaction", err)
 	}
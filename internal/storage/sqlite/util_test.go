@@ -114,7 +114,7 @@ func TestExecInTransaction(t *testing.T) {
 
 		// Verify the data was committed
 		var value string
-		err = store.db.QueryRowContext(ctx, "SELECT value FROM config WHERE key = ?", "test_key").Scan(&value)
+		err = store.db().QueryRowContext(ctx, "SELECT value FROM config WHERE key = ?", "test_key").Scan(&value)
 		if err != nil {
 			t.Errorf("Failed to query inserted value: %v", err)
 		}
@@ -138,7 +138,7 @@ func TestExecInTransaction(t *testing.T) {
 
 		// Verify the data was not committed
 		var value string
-		err = store.db.QueryRowContext(ctx, "SELECT value FROM config WHERE key = ?", "rollback_key").Scan(&value)
+		err = store.db().QueryRowContext(ctx, "SELECT value FROM config WHERE key = ?", "rollback_key").Scan(&value)
 		if err != sql.ErrNoRows {
 			t.Errorf("Expected no rows, but got value: %s (err: %v)", value, err)
 		}
@@ -168,7 +168,7 @@ func TestBeginTx(t *testing.T) {
 	}
 
 	var value string
-	err = store.db.QueryRowContext(ctx, "SELECT value FROM config WHERE key = ?", "tx_test").Scan(&value)
+	err = store.db().QueryRowContext(ctx, "SELECT value FROM config WHERE key = ?", "tx_test").Scan(&value)
 	if err != sql.ErrNoRows {
 		t.Errorf("Expected no rows after rollback, got: %s", value)
 	}
@@ -180,7 +180,7 @@ func TestQueryContext(t *testing.T) {
 	defer store.Close()
 
 	// Insert test data
-	_, err := store.db.ExecContext(ctx, "INSERT INTO config (key, value) VALUES (?, ?)", "query_test", "query_value")
+	_, err := store.db().ExecContext(ctx, "INSERT INTO config (key, value) VALUES (?, ?)", "query_test", "query_value")
 	if err != nil {
 		t.Fatalf("Failed to insert test data: %v", err)
 	}
@@ -263,7 +263,7 @@ func TestBeginImmediateWithRetry(t *testing.T) {
 	defer store.Close()
 
 	t.Run("successful on first try", func(t *testing.T) {
-		conn, err := store.db.Conn(ctx)
+		conn, err := store.db().Conn(ctx)
 		if err != nil {
 			t.Fatalf("Failed to acquire connection: %v", err)
 		}
@@ -279,7 +279,7 @@ func TestBeginImmediateWithRetry(t *testing.T) {
 	})
 
 	t.Run("context cancellation", func(t *testing.T) {
-		conn, err := store.db.Conn(ctx)
+		conn, err := store.db().Conn(ctx)
 		if err != nil {
 			t.Fatalf("Failed to acquire connection: %v", err)
 		}
@@ -299,7 +299,7 @@ func TestBeginImmediateWithRetry(t *testing.T) {
 	})
 
 	t.Run("defaults for invalid parameters", func(t *testing.T) {
-		conn, err := store.db.Conn(ctx)
+		conn, err := store.db().Conn(ctx)
 		if err != nil {
 			t.Fatalf("Failed to acquire connection: %v", err)
 		}
@@ -2,6 +2,8 @@ package sqlite
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -67,13 +69,46 @@ func validateEstimatedMinutes(value interface{}) error {
 	return nil
 }
 
+// validatePercentComplete validates a percent_complete value
+func validatePercentComplete(value interface{}) error {
+	if percent, ok := value.(int); ok {
+		if percent < 0 || percent > 100 {
+			return fmt.Errorf("percent_complete must be between 0 and 100 (got %d)", percent)
+		}
+	}
+	return nil
+}
+
+// validateExternalBlockedReason validates an external_blocked_reason value:
+// nil clears the block, anything else must be a non-empty reason
+func validateExternalBlockedReason(value interface{}) error {
+	if reason, ok := value.(string); ok && strings.TrimSpace(reason) == "" {
+		return fmt.Errorf("external_blocked_reason cannot be empty (clear the block instead)")
+	}
+	return nil
+}
+
+// validateDueDate validates a due_date value: nil clears it, anything else
+// must be a time
+func validateDueDate(value interface{}) error {
+	switch value.(type) {
+	case nil, time.Time:
+		return nil
+	default:
+		return fmt.Errorf("due_date must be a time (got %T)", value)
+	}
+}
+
 // fieldValidators maps field names to their validation functions
 var fieldValidators = map[string]func(interface{}) error{
-	"priority":          validatePriority,
-	"status":            validateStatus,
-	"issue_type":        validateIssueType,
-	"title":             validateTitle,
-	"estimated_minutes": validateEstimatedMinutes,
+	"priority":                validatePriority,
+	"status":                  validateStatus,
+	"issue_type":              validateIssueType,
+	"title":                   validateTitle,
+	"estimated_minutes":       validateEstimatedMinutes,
+	"percent_complete":        validatePercentComplete,
+	"external_blocked_reason": validateExternalBlockedReason,
+	"due_date":                validateDueDate,
 }
 
 // validateFieldUpdate validates a field update value (built-in statuses only)
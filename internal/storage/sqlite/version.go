@@ -0,0 +1,58 @@
+// Package sqlite - optimistic concurrency for issue updates
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ErrStaleUpdate indicates an update made under WithExpectedVersion was
+// refused because the issue changed after the caller read it (see
+// StaleUpdateError)
+var ErrStaleUpdate = errors.New("issue was updated since it was read")
+
+// StaleUpdateError is returned by UpdateIssue under WithExpectedVersion when
+// the issue is no longer at the expected version. Nothing is changed; the
+// caller can merge its edits into Current and retry with Current.Version.
+// It matches ErrStaleUpdate with errors.Is.
+type StaleUpdateError struct {
+	Expected int          // Version the caller read
+	Current  *types.Issue // The issue as it is now
+}
+
+func (e *StaleUpdateError) Error() string {
+	return fmt.Sprintf("cannot update %s: expected version %d but it is at version %d (re-read and retry)",
+		e.Current.ID, e.Expected, e.Current.Version)
+}
+
+// Is reports whether target is ErrStaleUpdate
+func (e *StaleUpdateError) Is(target error) bool {
+	return target == ErrStaleUpdate
+}
+
+type expectedVersionKey struct{}
+
+// WithExpectedVersion returns a context under which UpdateIssue only applies
+// its updates if the issue is still at version, the Version the caller read,
+// and otherwise fails with a *StaleUpdateError. Without it, the last update
+// wins.
+func WithExpectedVersion(ctx context.Context, version int) context.Context {
+	return context.WithValue(ctx, expectedVersionKey{}, version)
+}
+
+func expectedVersion(ctx context.Context) (int, bool) {
+	version, ok := ctx.Value(expectedVersionKey{}).(int)
+	return version, ok
+}
+
+// checkExpectedVersion returns a *StaleUpdateError if ctx expects a version
+// other than current's
+func checkExpectedVersion(ctx context.Context, current *types.Issue) error {
+	if version, ok := expectedVersion(ctx); ok && current.Version != version {
+		return &StaleUpdateError{Expected: version, Current: current}
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestIssueVersion(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Versioned", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	version := func() int {
+		t.Helper()
+		got, err := store.GetIssue(ctx, issue.ID)
+		if err != nil {
+			t.Fatalf("GetIssue failed: %v", err)
+		}
+		return got.Version
+	}
+	if v := version(); v != 1 {
+		t.Fatalf("Expected new issue at version 1, got %d", v)
+	}
+
+	// Every edit bumps the version, checked or not
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"priority": 1}, "test"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if err := store.UpdateIssue(WithExpectedVersion(ctx, 2), issue.ID, map[string]interface{}{"title": "Renamed"}, "test"); err != nil {
+		t.Fatalf("UpdateIssue at the current version failed: %v", err)
+	}
+	if err := store.CloseIssue(ctx, issue.ID, "done", "test"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	if v := version(); v != 4 {
+		t.Fatalf("Expected version 4 after three writes, got %d", v)
+	}
+
+	// Commenting only touches updated_at, which leaves the version alone
+	if err := store.AddComment(ctx, issue.ID, "test", "Looks good"); err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+	if v := version(); v != 4 {
+		t.Fatalf("Expected a comment to leave version 4, got %d", v)
+	}
+
+	// A stale version is refused with the current issue, and nothing changes
+	err := store.UpdateIssue(WithExpectedVersion(ctx, 2), issue.ID, map[string]interface{}{"title": "Stale"}, "test")
+	if !errors.Is(err, ErrStaleUpdate) {
+		t.Fatalf("Expected ErrStaleUpdate, got %v", err)
+	}
+	var stale *StaleUpdateError
+	if !errors.As(err, &stale) || stale.Expected != 2 || stale.Current.Version != 4 || stale.Current.Title != "Renamed" {
+		t.Fatalf("Expected StaleUpdateError from 2 with the issue at 4, got %+v", stale)
+	}
+	if v := version(); v != 4 {
+		t.Errorf("Expected refused update to leave version 4, got %d", v)
+	}
+
+	// Transactions check the version too
+	err = store.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		return tx.UpdateIssue(WithExpectedVersion(ctx, 3), issue.ID, map[string]interface{}{"title": "Stale"}, "test")
+	})
+	if !errors.Is(err, ErrStaleUpdate) {
+		t.Errorf("Expected ErrStaleUpdate in a transaction, got %v", err)
+	}
+}
+
+func TestIssueVersionRace(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Contended", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	// Both writers read version 1; only one may win
+	const writers = 2
+	errs := make([]error, writers)
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			updates := map[string]interface{}{"assignee": []string{"alice", "bob"}[i]}
+			errs[i] = store.UpdateIssue(WithExpectedVersion(ctx, 1), issue.ID, updates, "test")
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	var won, lost int
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			won++
+		case errors.Is(err, ErrStaleUpdate):
+			lost++
+		default:
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if won != 1 || lost != 1 {
+		t.Fatalf("Expected one winner and one stale update, got %d and %d", won, lost)
+	}
+	got, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.Version != 2 {
+		t.Errorf("Expected version 2 after one update, got %d", got.Version)
+	}
+}
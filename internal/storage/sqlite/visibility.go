@@ -0,0 +1,180 @@
+// Package sqlite - private issues and the actor reads are made for
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+type readActorCtx struct{}
+
+// WithActor returns a context under which reads are made on behalf of actor:
+// issue lookups, searches, ready and blocked work, dependency, comment,
+// event and history reads, and so exports, leave out private issues actor
+// doesn't own (see SetVisibility). Without an actor, reads see every issue,
+// as the daemon's own housekeeping and single-user stores need.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, readActorCtx{}, actor)
+}
+
+// ActorFromContext returns the actor set by WithActor, or "" if none
+func ActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(readActorCtx{}).(string)
+	return actor
+}
+
+type publicOnlyCtx struct{}
+
+// WithPublicOnly returns a context under which reads see public issues only,
+// whatever the actor. Exports bound for git, which everyone with the repo
+// can read, use it: private issues, with their comments, events and
+// dependency edges, stay in the local database, and imports leave local
+// private issues alone rather than taking their absence from the shared
+// JSONL as a deletion.
+func WithPublicOnly(ctx context.Context) context.Context {
+	return context.WithValue(ctx, publicOnlyCtx{}, true)
+}
+
+// visibleClause returns a condition, and its args, that holds for the issues
+// whose ID is in column and which ctx's actor can see: public issues and the
+// private issues the actor owns, or public issues alone under
+// WithPublicOnly. It returns "" when ctx has neither.
+func visibleClause(ctx context.Context, column string) (string, []interface{}) {
+	if publicOnly, _ := ctx.Value(publicOnlyCtx{}).(bool); publicOnly {
+		// #nosec G201 - column is fixed by the callers
+		return fmt.Sprintf(`NOT EXISTS (SELECT 1 FROM issue_acl acl WHERE acl.issue_id = %s)`, column), nil
+	}
+	actor := ActorFromContext(ctx)
+	if actor == "" {
+		return "", nil
+	}
+	// #nosec G201 - column is fixed by the callers
+	return fmt.Sprintf(`(NOT EXISTS (SELECT 1 FROM issue_acl acl WHERE acl.issue_id = %[1]s)
+		OR EXISTS (SELECT 1 FROM issue_acl acl WHERE acl.issue_id = %[1]s AND acl.actor = ?))`, column),
+		[]interface{}{actor}
+}
+
+// visibleEdgeClause returns an " AND ..." condition, and its args, that
+// holds for the dependency edges between fromColumn and toColumn whose ends
+// ctx's actor can both see, or "" when ctx has no actor
+func visibleEdgeClause(ctx context.Context, fromColumn, toColumn string) (string, []interface{}) {
+	from, args := visibleClause(ctx, fromColumn)
+	if from == "" {
+		return "", nil
+	}
+	to, toArgs := visibleClause(ctx, toColumn)
+	return " AND " + from + " AND " + to, append(args, toArgs...)
+}
+
+// requireVisibleIssue returns an ErrNotFound error unless issue id exists and
+// ctx's actor can see it
+func requireVisibleIssue(ctx context.Context, q queryer, id string) error {
+	query := `SELECT 1 FROM issues WHERE id = ?`
+	args := []interface{}{id}
+	if visible, visibleArgs := visibleClause(ctx, "issues.id"); visible != "" {
+		query += " AND " + visible
+		args = append(args, visibleArgs...)
+	}
+	var exists int
+	err := q.QueryRowContext(ctx, query, args...).Scan(&exists)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("issue %s: %w", id, ErrNotFound)
+	} else if err != nil {
+		return wrapDBError("check issue", err)
+	}
+	return nil
+}
+
+// SetVisibility makes issue id public, or private to owners. Private issues
+// are left out of the reads of every other actor (see WithActor); an empty
+// owners list means actor alone. Making an issue public drops its owners.
+// The visibility and owners are local to this database: the exports bound
+// for git read under WithPublicOnly, so private issues are left out of the
+// shared JSONL rather than exported with their owners. Changing visibility
+// marks the issue dirty, so the next flush adds or drops it. Under
+// WithActor, only someone who can see the issue can change it.
+func (s *SQLiteStorage) SetVisibility(ctx context.Context, id string, visibility types.Visibility, owners []string, actor string) error {
+	if !visibility.IsValid() {
+		return fmt.Errorf("invalid visibility %q (must be %s or %s)", visibility, types.VisibilityPublic, types.VisibilityPrivate)
+	}
+	var normalized []string
+	if visibility == types.VisibilityPrivate {
+		seen := make(map[string]bool)
+		for _, owner := range owners {
+			owner = strings.TrimSpace(owner)
+			if owner != "" && !seen[owner] {
+				seen[owner] = true
+				normalized = append(normalized, owner)
+			}
+		}
+		if len(normalized) == 0 {
+			normalized = []string{actor}
+		}
+		sort.Strings(normalized)
+	}
+
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		if err := requireVisibleIssue(ctx, tx, id); err != nil {
+			return err
+		}
+		oldVisibility, oldOwners, err := issueVisibility(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if oldVisibility == visibility && strings.Join(oldOwners, ",") == strings.Join(normalized, ",") {
+			return nil
+		}
+
+		if _, err := tx.ExecContext(ctx, `DELETE FROM issue_acl WHERE issue_id = ?`, id); err != nil {
+			return wrapDBError("clear issue owners", err)
+		}
+		for _, owner := range normalized {
+			if _, err := tx.ExecContext(ctx, `INSERT INTO issue_acl (issue_id, actor) VALUES (?, ?)`, id, owner); err != nil {
+				return wrapDBError("add issue owner", err)
+			}
+		}
+
+		comment := fmt.Sprintf("Made %s", visibility)
+		if len(normalized) > 0 {
+			comment += " to " + strings.Join(normalized, ", ")
+		}
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO events (issue_id, event_type, actor, old_value, new_value, comment)
+			VALUES (?, ?, ?, ?, ?, ?)
+		`, id, types.EventVisibilityChanged, actor, string(oldVisibility), string(visibility), comment); err != nil {
+			return fmt.Errorf("failed to record event: %w", err)
+		}
+		return markDirty(ctx, tx, id)
+	})
+}
+
+// Visibility returns whether issue id is public or private, and the owners
+// of a private issue, sorted. Under WithActor, issues the actor can't see
+// are reported as not found.
+func (s *SQLiteStorage) Visibility(ctx context.Context, id string) (types.Visibility, []string, error) {
+	if err := requireVisibleIssue(ctx, s.db(), id); err != nil {
+		return "", nil, err
+	}
+	return issueVisibility(ctx, s.db(), id)
+}
+
+// issueVisibility reads the visibility and owners of issue id on q
+func issueVisibility(ctx context.Context, q queryer, id string) (types.Visibility, []string, error) {
+	rows, err := q.QueryContext(ctx, `SELECT actor FROM issue_acl WHERE issue_id = ? ORDER BY actor`, id)
+	if err != nil {
+		return "", nil, wrapDBError("query issue owners", err)
+	}
+	owners, err := scanStrings(rows)
+	if err != nil {
+		return "", nil, wrapDBError("scan issue owners", err)
+	}
+	if len(owners) == 0 {
+		return types.VisibilityPublic, nil, nil
+	}
+	return types.VisibilityPrivate, owners, nil
+}
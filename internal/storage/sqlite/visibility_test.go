@@ -0,0 +1,252 @@
+package sqlite
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestSetVisibility(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	secret := &types.Issue{Title: "Salary bands", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	shared := &types.Issue{Title: "Team offsite", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{secret, shared} {
+		if err := store.CreateIssue(ctx, issue, "alice"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	if err := store.SetVisibility(ctx, secret.ID, "hidden", nil, "alice"); err == nil {
+		t.Error("Expected an invalid visibility to be rejected")
+	}
+	if err := store.SetVisibility(ctx, secret.ID, types.VisibilityPrivate, nil, "alice"); err != nil {
+		t.Fatalf("SetVisibility failed: %v", err)
+	}
+	visibility, owners, err := store.Visibility(ctx, secret.ID)
+	if err != nil {
+		t.Fatalf("Visibility failed: %v", err)
+	}
+	if visibility != types.VisibilityPrivate || !sameIDs(owners, []string{"alice"}) {
+		t.Errorf("Expected private to alice, got %s to %v", visibility, owners)
+	}
+
+	search := func(ctx context.Context) []string {
+		issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+		if err != nil {
+			t.Fatalf("SearchIssues failed: %v", err)
+		}
+		var ids []string
+		for _, issue := range issues {
+			ids = append(ids, issue.ID)
+		}
+		return ids
+	}
+	alice := WithActor(ctx, "alice")
+	bob := WithActor(ctx, "bob")
+
+	if got, err := store.GetIssue(bob, secret.ID); err != nil || got != nil {
+		t.Errorf("Expected private issue hidden from bob, got %+v (err %v)", got, err)
+	}
+	if ids := search(bob); !sameIDs(ids, []string{shared.ID}) {
+		t.Errorf("Expected bob to find only the public issue, got %v", ids)
+	}
+	if _, _, err := store.Visibility(bob, secret.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for bob, got %v", err)
+	}
+	if err := store.SetVisibility(bob, secret.ID, types.VisibilityPublic, nil, "bob"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected bob unable to change visibility, got %v", err)
+	}
+	if got, err := store.GetIssue(alice, secret.ID); err != nil || got == nil {
+		t.Errorf("Expected owner to see private issue, got %+v (err %v)", got, err)
+	}
+	if ids := search(alice); !sameIDs(ids, []string{secret.ID, shared.ID}) {
+		t.Errorf("Expected owner to find both issues, got %v", ids)
+	}
+	if ids := search(ctx); !sameIDs(ids, []string{secret.ID, shared.ID}) {
+		t.Errorf("Expected unrestricted reads without an actor, got %v", ids)
+	}
+
+	if err := store.SetVisibility(alice, secret.ID, types.VisibilityPrivate, []string{" bob ", "alice", "bob"}, "alice"); err != nil {
+		t.Fatalf("SetVisibility failed: %v", err)
+	}
+	if got, err := store.GetIssue(bob, secret.ID); err != nil || got == nil {
+		t.Errorf("Expected added owner to see private issue, got %+v (err %v)", got, err)
+	}
+
+	if err := store.SetVisibility(alice, secret.ID, types.VisibilityPublic, nil, "alice"); err != nil {
+		t.Fatalf("SetVisibility failed: %v", err)
+	}
+	if ids := search(WithActor(ctx, "carol")); !sameIDs(ids, []string{secret.ID, shared.ID}) {
+		t.Errorf("Expected public issue visible to everyone, got %v", ids)
+	}
+
+	events, err := store.GetEvents(ctx, secret.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	changes := 0
+	for _, e := range events {
+		if e.EventType == types.EventVisibilityChanged {
+			changes++
+		}
+	}
+	if changes != 3 {
+		t.Errorf("Expected 3 visibility events, got %d", changes)
+	}
+}
+
+func TestPrivateIssuesHiddenFromReads(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	shared := &types.Issue{Title: "Team offsite", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	secret := &types.Issue{Title: "Salary bands", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	waiting := &types.Issue{Title: "Salary letters", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{shared, secret, waiting} {
+		if err := store.CreateIssue(ctx, issue, "alice"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	for _, id := range []string{secret.ID, waiting.ID} {
+		if err := store.SetVisibility(ctx, id, types.VisibilityPrivate, nil, "alice"); err != nil {
+			t.Fatalf("SetVisibility failed: %v", err)
+		}
+	}
+	if err := store.AddDependency(ctx, &types.Dependency{IssueID: waiting.ID, DependsOnID: shared.ID, Type: types.DepBlocks}, "alice"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+	if _, err := store.AddIssueComment(ctx, secret.ID, "alice", "Draft numbers attached"); err != nil {
+		t.Fatalf("AddIssueComment failed: %v", err)
+	}
+
+	alice := WithActor(ctx, "alice")
+	bob := WithActor(ctx, "bob")
+	ids := func(issues []*types.Issue) []string {
+		var ids []string
+		for _, issue := range issues {
+			ids = append(ids, issue.ID)
+		}
+		return ids
+	}
+
+	for _, tc := range []struct {
+		name string
+		ctx  context.Context
+		want []string
+	}{
+		{"bob", bob, []string{shared.ID}},
+		{"alice", alice, []string{shared.ID, secret.ID}},
+	} {
+		ready, err := store.GetReadyWork(tc.ctx, types.WorkFilter{})
+		if err != nil {
+			t.Fatalf("GetReadyWork failed: %v", err)
+		}
+		if got := ids(ready); !sameIDs(got, tc.want) {
+			t.Errorf("Expected ready work %v for %s, got %v", tc.want, tc.name, got)
+		}
+	}
+
+	blocked, err := store.GetBlockedIssues(bob)
+	if err != nil {
+		t.Fatalf("GetBlockedIssues failed: %v", err)
+	}
+	if len(blocked) != 0 {
+		t.Errorf("Expected no blocked issues visible to bob, got %d", len(blocked))
+	}
+	if blocked, err := store.GetBlockedIssues(alice); err != nil || len(blocked) != 1 {
+		t.Errorf("Expected alice to see her blocked issue, got %d (err %v)", len(blocked), err)
+	}
+	dependents, err := store.GetDependents(bob, shared.ID)
+	if err != nil {
+		t.Fatalf("GetDependents failed: %v", err)
+	}
+	if len(dependents) != 0 {
+		t.Errorf("Expected no dependents visible to bob, got %v", ids(dependents))
+	}
+	comments, err := store.GetIssueComments(bob, secret.ID)
+	if err != nil {
+		t.Fatalf("GetIssueComments failed: %v", err)
+	}
+	if len(comments) != 0 {
+		t.Errorf("Expected no comments visible to bob, got %d", len(comments))
+	}
+	if _, err := store.History(bob, secret.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for bob's history read, got %v", err)
+	}
+
+	// Exports made for bob leave out alice's private issues and their edges
+	var markdown bytes.Buffer
+	if err := store.ExportMarkdown(bob, &markdown, types.IssueFilter{}, MarkdownOptions{}); err != nil {
+		t.Fatalf("ExportMarkdown failed: %v", err)
+	}
+	if strings.Contains(markdown.String(), "Salary") || !strings.Contains(markdown.String(), "Team offsite") {
+		t.Errorf("Expected bob's export to hold only the public issue, got:\n%s", markdown.String())
+	}
+	edges, err := store.ExportDependencies(bob)
+	if err != nil {
+		t.Fatalf("ExportDependencies failed: %v", err)
+	}
+	if len(edges) != 0 {
+		t.Errorf("Expected no dependency edges exported for bob, got %v", edges)
+	}
+	if edges, err := store.ExportDependencies(alice); err != nil || len(edges) != 1 {
+		t.Errorf("Expected alice's export to hold her edge, got %v (err %v)", edges, err)
+	}
+}
+
+func TestWithPublicOnly(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	shared := &types.Issue{Title: "Team offsite", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	secret := &types.Issue{Title: "Salary bands", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{shared, secret} {
+		if err := store.CreateIssue(ctx, issue, "alice"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	if err := store.AddDependency(ctx, &types.Dependency{IssueID: shared.ID, DependsOnID: secret.ID, Type: types.DepBlocks}, "alice"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+	if err := store.ClearDirtyIssues(ctx); err != nil {
+		t.Fatalf("ClearDirtyIssues failed: %v", err)
+	}
+	if err := store.SetVisibility(ctx, secret.ID, types.VisibilityPrivate, nil, "alice"); err != nil {
+		t.Fatalf("SetVisibility failed: %v", err)
+	}
+
+	// Changing visibility marks the issue dirty so the next export drops it
+	dirty, err := store.GetDirtyIssues(ctx)
+	if err != nil {
+		t.Fatalf("GetDirtyIssues failed: %v", err)
+	}
+	if !sameIDs(dirty, []string{secret.ID}) {
+		t.Errorf("Expected %s dirty after SetVisibility, got %v", secret.ID, dirty)
+	}
+
+	// Public-only reads hide private issues even from their owner
+	publicOnly := WithActor(WithPublicOnly(ctx), "alice")
+	issues, err := store.SearchIssues(publicOnly, "", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(issues) != 1 || issues[0].ID != shared.ID {
+		t.Errorf("Expected only %s, got %d issues", shared.ID, len(issues))
+	}
+	if issue, err := store.GetIssue(publicOnly, secret.ID); err != nil || issue != nil {
+		t.Errorf("Expected %s hidden, got %v (err %v)", secret.ID, issue, err)
+	}
+	deps, err := store.GetAllDependencyRecords(publicOnly)
+	if err != nil {
+		t.Fatalf("GetAllDependencyRecords failed: %v", err)
+	}
+	if len(deps) != 0 {
+		t.Errorf("Expected the edge to the private issue dropped, got %v", deps)
+	}
+}
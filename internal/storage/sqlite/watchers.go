@@ -0,0 +1,55 @@
+// Package sqlite - issue watchers
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+// WatchIssue adds user to the watchers of issue id (see Issue.Watchers).
+// Watching an issue already watched is a no-op.
+func (s *SQLiteStorage) WatchIssue(ctx context.Context, id, user string) error {
+	user = strings.TrimSpace(user)
+	if user == "" {
+		return fmt.Errorf("watcher cannot be empty")
+	}
+	return s.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		t := tx.(*sqliteTxStorage)
+		if err := requireVisibleIssue(ctx, t.conn, id); err != nil {
+			return err
+		}
+		_, err := t.conn.ExecContext(ctx, `INSERT OR IGNORE INTO issue_watchers (issue_id, watcher) VALUES (?, ?)`, id, user)
+		return wrapDBError("add watcher", err)
+	})
+}
+
+// UnwatchIssue removes user from the watchers of issue id. Unwatching an
+// issue not watched is a no-op.
+func (s *SQLiteStorage) UnwatchIssue(ctx context.Context, id, user string) error {
+	return s.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		t := tx.(*sqliteTxStorage)
+		if err := requireVisibleIssue(ctx, t.conn, id); err != nil {
+			return err
+		}
+		_, err := t.conn.ExecContext(ctx, `DELETE FROM issue_watchers WHERE issue_id = ? AND watcher = ?`, id, strings.TrimSpace(user))
+		return wrapDBError("remove watcher", err)
+	})
+}
+
+// GetWatchers returns the watchers of issue id, sorted
+func (s *SQLiteStorage) GetWatchers(ctx context.Context, id string) ([]string, error) {
+	return getWatchers(ctx, s.db(), id)
+}
+
+// getWatchers returns the watchers of issue id on q, sorted
+func getWatchers(ctx context.Context, q queryer, id string) ([]string, error) {
+	rows, err := q.QueryContext(ctx, `SELECT watcher FROM issue_watchers WHERE issue_id = ? ORDER BY watcher`, id)
+	if err != nil {
+		return nil, wrapDBError("get watchers", err)
+	}
+	watchers, err := scanStrings(rows)
+	return watchers, wrapDBError("get watchers", err)
+}
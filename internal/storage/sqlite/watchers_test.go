@@ -0,0 +1,114 @@
+package sqlite
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestWatchers(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	watched := &types.Issue{Title: "Watched", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	other := &types.Issue{Title: "Other", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{watched, other} {
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	for _, user := range []string{"bob", "alice", "alice"} {
+		if err := store.WatchIssue(ctx, watched.ID, user); err != nil {
+			t.Fatalf("WatchIssue failed: %v", err)
+		}
+	}
+	if err := store.WatchIssue(ctx, watched.ID, " "); err == nil {
+		t.Error("Expected an error for an empty watcher")
+	}
+	if err := store.WatchIssue(ctx, "bd-missing", "alice"); err == nil {
+		t.Error("Expected an error watching a missing issue")
+	}
+
+	got, err := store.GetIssue(ctx, watched.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if !reflect.DeepEqual(got.Watchers, []string{"alice", "bob"}) {
+		t.Errorf("Expected watchers [alice bob], got %v", got.Watchers)
+	}
+
+	alice := "alice"
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{Watcher: &alice})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(issues) != 1 || issues[0].ID != watched.ID {
+		t.Errorf("Expected only %s watched by alice, got %v", watched.ID, issues)
+	}
+
+	if err := store.UnwatchIssue(ctx, watched.ID, "alice"); err != nil {
+		t.Fatalf("UnwatchIssue failed: %v", err)
+	}
+	if err := store.UnwatchIssue(ctx, watched.ID, "alice"); err != nil {
+		t.Errorf("Expected unwatching twice to be a no-op, got %v", err)
+	}
+	if watchers, _ := store.GetWatchers(ctx, watched.ID); !reflect.DeepEqual(watchers, []string{"bob"}) {
+		t.Errorf("Expected watchers [bob], got %v", watchers)
+	}
+}
+
+func TestAssignIssueIdempotent(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	assigned := &types.Issue{Title: "Assigned", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	unassigned := &types.Issue{Title: "Unassigned", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{assigned, unassigned} {
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	if err := store.AssignIssue(ctx, assigned.ID, "alice", "test"); err != nil {
+		t.Fatalf("AssignIssue failed: %v", err)
+	}
+	before, err := store.GetIssue(ctx, assigned.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	events, err := store.GetEvents(ctx, assigned.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+
+	// Reassigning to the same user changes nothing
+	if err := store.AssignIssue(ctx, assigned.ID, "alice", "test"); err != nil {
+		t.Fatalf("Second AssignIssue failed: %v", err)
+	}
+	after, _ := store.GetIssue(ctx, assigned.ID)
+	if after.Version != before.Version || !after.UpdatedAt.Equal(before.UpdatedAt) {
+		t.Errorf("Expected reassigning to the same user to be a no-op, version %d -> %d", before.Version, after.Version)
+	}
+	if again, _ := store.GetEvents(ctx, assigned.ID, 0); len(again) != len(events) {
+		t.Errorf("Expected no new event, got %d events (was %d)", len(again), len(events))
+	}
+
+	alice := "alice"
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{Assignee: &alice})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(issues) != 1 || issues[0].ID != assigned.ID {
+		t.Errorf("Expected only %s assigned to alice, got %v", assigned.ID, issues)
+	}
+	issues, err = store.SearchIssues(ctx, "", types.IssueFilter{NoAssignee: true})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(issues) != 1 || issues[0].ID != unassigned.ID {
+		t.Errorf("Expected only %s unassigned, got %v", unassigned.ID, issues)
+	}
+}
@@ -0,0 +1,139 @@
+// Package sqlite - per-assignee work-in-progress limits
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+type wipOverrideKey struct{}
+
+// WithWIPOverride returns a context under which assignments and status
+// changes ignore WIPLimitConfigKey
+func WithWIPOverride(ctx context.Context) context.Context {
+	return context.WithValue(ctx, wipOverrideKey{}, true)
+}
+
+func isWIPOverride(ctx context.Context) bool {
+	override, _ := ctx.Value(wipOverrideKey{}).(bool)
+	return override
+}
+
+// readWIPLimit returns WIPLimitConfigKey, or 0 (no limit) if it is unset or
+// invalid
+func readWIPLimit(ctx context.Context, q queryer) (int, error) {
+	var value string
+	err := q.QueryRowContext(ctx, `SELECT value FROM config WHERE key = ?`, WIPLimitConfigKey).Scan(&value)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	} else if err != nil {
+		return 0, wrapDBError("read WIP limit", err)
+	}
+	limit, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || limit < 0 {
+		return 0, nil
+	}
+	return limit, nil
+}
+
+// checkWIPLimit returns ErrWIPLimitExceeded if issue id ending up in_progress
+// and assigned to assignee would take that assignee past WIPLimitConfigKey.
+// id itself is not counted, so re-saving an issue that is already in progress
+// never fails. It runs on q so the count sees the same transaction as the
+// write.
+func checkWIPLimit(ctx context.Context, q queryer, id, assignee string, status types.Status) error {
+	if assignee == "" || status != types.StatusInProgress || isWIPOverride(ctx) {
+		return nil
+	}
+	limit, err := readWIPLimit(ctx, q)
+	if err != nil || limit == 0 {
+		return err
+	}
+
+	var count int
+	err = q.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM issues WHERE assignee = ? AND status = ? AND id != ?
+	`, assignee, types.StatusInProgress, id).Scan(&count)
+	if err != nil {
+		return wrapDBError("count work in progress", err)
+	}
+	if count >= limit {
+		return fmt.Errorf("%w: %s already has %d issue(s) in progress (limit %d)",
+			ErrWIPLimitExceeded, assignee, count, limit)
+	}
+	return nil
+}
+
+// checkWIPUpdate applies checkWIPLimit to the state oldIssue ends up in after
+// updates, if the updates move it into progress or reassign it
+func checkWIPUpdate(ctx context.Context, q queryer, oldIssue *types.Issue, updates map[string]interface{}) error {
+	_, statusChanged := updates["status"]
+	_, assigneeChanged := updates["assignee"]
+	if !statusChanged && !assigneeChanged {
+		return nil
+	}
+	updated := *oldIssue
+	applyUpdatesToIssue(&updated, updates)
+	if updated.Status == oldIssue.Status && updated.Assignee == oldIssue.Assignee {
+		return nil
+	}
+	return checkWIPLimit(ctx, q, oldIssue.ID, updated.Assignee, updated.Status)
+}
+
+// AssignIssue sets the assignee of issue id, or clears it if assignee is
+// empty, recording the change as an event. Assigning an issue to its current
+// assignee is a no-op. Assigning an in-progress issue is subject to
+// WIPLimitConfigKey. The issue is read, checked against the limit and
+// updated in one write transaction, so concurrent assignments can't both
+// squeeze under the limit.
+func (s *SQLiteStorage) AssignIssue(ctx context.Context, id, assignee, actor string) error {
+	return s.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		issue, err := tx.GetIssue(ctx, id)
+		if err != nil {
+			return err
+		}
+		if issue != nil && issue.Assignee == assignee {
+			return nil
+		}
+		return tx.UpdateIssue(ctx, id, map[string]interface{}{"assignee": assignee}, actor)
+	})
+}
+
+// WIPStatus returns the number of in-progress issues per assignee.
+// Unassigned issues are not counted.
+func (s *SQLiteStorage) WIPStatus(ctx context.Context) (map[string]int, error) {
+	return queryWIPCounts(ctx, s.db())
+}
+
+// queryWIPCounts counts in-progress issues per assignee on q
+func queryWIPCounts(ctx context.Context, q queryer) (map[string]int, error) {
+	rows, err := q.QueryContext(ctx, `
+		SELECT assignee, COUNT(*) FROM issues
+		WHERE status = ? AND assignee IS NOT NULL AND assignee != ''
+		GROUP BY assignee
+	`, types.StatusInProgress)
+	if err != nil {
+		return nil, wrapDBError("query work in progress", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	wip := make(map[string]int)
+	for rows.Next() {
+		var assignee string
+		var count int
+		if err := rows.Scan(&assignee, &count); err != nil {
+			return nil, wrapDBError("scan work in progress", err)
+		}
+		wip[assignee] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, wrapDBError("iterate work in progress", err)
+	}
+	return wip, nil
+}
@@ -0,0 +1,123 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestWIPLimit(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	if err := store.SetConfig(ctx, WIPLimitConfigKey, "1"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+
+	first := &types.Issue{Title: "First", Status: types.StatusInProgress, Assignee: "alice", Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, first, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	second := &types.Issue{Title: "Second", Status: types.StatusOpen, Assignee: "alice", Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, second, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	err := store.UpdateIssue(ctx, second.ID, map[string]interface{}{"status": string(types.StatusInProgress)}, "test")
+	if !errors.Is(err, ErrWIPLimitExceeded) {
+		t.Errorf("Expected ErrWIPLimitExceeded moving into progress, got %v", err)
+	}
+
+	third := &types.Issue{Title: "Third", Status: types.StatusInProgress, Assignee: "alice", Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, third, "test"); !errors.Is(err, ErrWIPLimitExceeded) {
+		t.Errorf("Expected ErrWIPLimitExceeded creating in progress, got %v", err)
+	}
+
+	bobs := &types.Issue{Title: "Bob's", Status: types.StatusInProgress, Assignee: "bob", Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, bobs, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := store.AssignIssue(ctx, bobs.ID, "alice", "test"); !errors.Is(err, ErrWIPLimitExceeded) {
+		t.Errorf("Expected ErrWIPLimitExceeded assigning, got %v", err)
+	}
+
+	// Edits to an issue already in progress don't count it against itself
+	if err := store.UpdateIssue(ctx, first.ID, map[string]interface{}{"title": "First!"}, "test"); err != nil {
+		t.Errorf("Expected unrelated update to succeed, got %v", err)
+	}
+
+	if err := store.AssignIssue(WithWIPOverride(ctx), bobs.ID, "alice", "test"); err != nil {
+		t.Fatalf("Expected override to allow assignment, got %v", err)
+	}
+
+	wip, err := store.WIPStatus(ctx)
+	if err != nil {
+		t.Fatalf("WIPStatus failed: %v", err)
+	}
+	if len(wip) != 1 || wip["alice"] != 2 {
+		t.Errorf("Expected alice at 2 in progress, got %v", wip)
+	}
+
+	if err := store.SetConfig(ctx, WIPLimitConfigKey, "0"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	if err := store.UpdateIssue(ctx, second.ID, map[string]interface{}{"status": string(types.StatusInProgress)}, "test"); err != nil {
+		t.Errorf("Expected no limit at 0, got %v", err)
+	}
+}
+
+func TestAssignIssueConcurrentWIPLimit(t *testing.T) {
+	store := newTestStore(t, "")
+	ctx := context.Background()
+
+	if err := store.SetConfig(ctx, WIPLimitConfigKey, "1"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+
+	const issues = 8
+	ids := make([]string, issues)
+	for i := range ids {
+		issue := &types.Issue{Title: "Unassigned", Status: types.StatusInProgress, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		ids[i] = issue.ID
+	}
+
+	// Each assignment checks and writes under one transaction, so only one
+	// can take alice to the limit
+	var wg sync.WaitGroup
+	results := make(chan error, issues)
+	for _, id := range ids {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			results <- store.AssignIssue(ctx, id, "alice", "test")
+		}(id)
+	}
+	wg.Wait()
+	close(results)
+
+	assigned := 0
+	for err := range results {
+		switch {
+		case err == nil:
+			assigned++
+		case !errors.Is(err, ErrWIPLimitExceeded):
+			t.Errorf("Expected ErrWIPLimitExceeded, got %v", err)
+		}
+	}
+	if assigned != 1 {
+		t.Errorf("Expected exactly one assignment to succeed, got %d", assigned)
+	}
+	wip, err := store.WIPStatus(ctx)
+	if err != nil {
+		t.Fatalf("WIPStatus failed: %v", err)
+	}
+	if wip["alice"] != 1 {
+		t.Errorf("Expected alice at 1 in progress, got %v", wip)
+	}
+}
@@ -4,6 +4,11 @@ package storage
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -75,6 +80,50 @@ type Transaction interface {
 	AddComment(ctx context.Context, issueID, actor, comment string) error
 }
 
+// Store is the core of Storage that every backend implements, and all the
+// daemon needs to serve issues: create, read and search them, and keep
+// config. Backends other than SQLite (see ParseConnString) may implement only
+// Store; callers that need more should type-assert to Storage.
+type Store interface {
+	CreateIssue(ctx context.Context, issue *types.Issue, actor string) error
+	GetIssue(ctx context.Context, id string) (*types.Issue, error)
+	SearchIssues(ctx context.Context, query string, filter types.IssueFilter) ([]*types.Issue, error)
+	SetConfig(ctx context.Context, key, value string) error
+	GetConfig(ctx context.Context, key string) (string, error)
+	Close() error
+}
+
+// FreshnessChecker is implemented by backends whose data lives in a local
+// file that can be replaced underneath an open connection (e.g. by a git
+// merge), so long-running processes need to detect it and reopen
+type FreshnessChecker interface {
+	EnableFreshnessChecking(opts FreshnessOptions) error
+}
+
+// FreshnessOptions configures FreshnessChecker.EnableFreshnessChecking
+type FreshnessOptions struct {
+	// PollInterval, if positive, checks the database file on a background
+	// timer this often instead of before every read, trading up to one
+	// interval of staleness for fewer stat calls. Zero checks before every
+	// read.
+	PollInterval time.Duration
+
+	// OnReconnect, if set, is called after the store reconnects to the
+	// database file, with the inode numbers of the old and new file (0 where
+	// the platform has none), so callers can drop their own caches
+	OnReconnect func(oldInode, newInode uint64)
+}
+
+// EnableFreshnessChecking turns on freshness checking for s if it is a
+// FreshnessChecker. Server backends such as Postgres always serve current
+// data, so it is a no-op for them.
+func EnableFreshnessChecking(s Store, opts FreshnessOptions) error {
+	if fc, ok := s.(FreshnessChecker); ok {
+		return fc.EnableFreshnessChecking(opts)
+	}
+	return nil
+}
+
 // Storage defines the interface for issue storage backends
 type Storage interface {
 	// Issues
@@ -211,3 +260,52 @@ type Config struct {
 	Password string
 	SSLMode  string
 }
+
+// Backends selected by ParseConnString
+const (
+	BackendSQLite   = "sqlite"
+	BackendPostgres = "postgres"
+)
+
+// ParseConnString returns the Config for a connection string. The scheme
+// selects the backend: "sqlite://path/to/beads.db" (or a bare path, for
+// compatibility with --db) opens a SQLite file, and "postgres://" or
+// "postgresql://" URLs connect to a Postgres server.
+func ParseConnString(conn string) (*Config, error) {
+	if conn == "" {
+		return nil, fmt.Errorf("empty connection string")
+	}
+	scheme, rest, found := strings.Cut(conn, "://")
+	if !found {
+		return &Config{Backend: BackendSQLite, Path: conn}, nil
+	}
+
+	switch strings.ToLower(scheme) {
+	case "sqlite", "sqlite3":
+		if rest == "" {
+			return nil, fmt.Errorf("missing database path in %q", conn)
+		}
+		return &Config{Backend: BackendSQLite, Path: rest}, nil
+	case "postgres", "postgresql":
+		u, err := url.Parse(conn)
+		if err != nil {
+			return nil, fmt.Errorf("invalid postgres connection string: %w", err)
+		}
+		cfg := &Config{
+			Backend:  BackendPostgres,
+			Host:     u.Hostname(),
+			Database: strings.TrimPrefix(u.Path, "/"),
+			User:     u.User.Username(),
+			SSLMode:  u.Query().Get("sslmode"),
+		}
+		cfg.Password, _ = u.User.Password()
+		if port := u.Port(); port != "" {
+			if cfg.Port, err = strconv.Atoi(port); err != nil {
+				return nil, fmt.Errorf("invalid postgres port %q", port)
+			}
+		}
+		return cfg, nil
+	default:
+		return nil, fmt.Errorf("unsupported storage backend %q (use sqlite:// or postgres://)", scheme)
+	}
+}
@@ -25,6 +25,10 @@ const (
 // Based on git-check-ref-format rules
 var branchNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._/-]*[a-zA-Z0-9]$`)
 
+func init() {
+	storage.RegisterConfigKey(ConfigKey, ValidateBranchName)
+}
+
 // ValidateBranchName checks if a branch name is valid according to git rules
 func ValidateBranchName(name string) error {
 	if name == "" {
@@ -135,8 +135,8 @@ func TestGet(t *testing.T) {
 		defer store.Close()
 		
 		// Directly set invalid value (bypassing validation)
-		if err := store.SetConfig(ctx, ConfigKey, "invalid..branch"); err != nil {
-			t.Fatalf("SetConfig() error = %v", err)
+		if _, err := store.UnderlyingDB().ExecContext(ctx, `INSERT INTO config (key, value) VALUES (?, ?)`, ConfigKey, "invalid..branch"); err != nil {
+			t.Fatalf("failed to write config: %v", err)
 		}
 		
 		_, err := Get(ctx, store)
@@ -359,7 +359,7 @@ func generateFromJSONL(ctx context.Context, store storage.Storage, tempDir strin
 	}
 
 	// Clear all issues (we'll reimport them)
-	allIssues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	allIssues, err := store.SearchIssues(ctx, "", types.IssueFilter{IncludeSnoozed: true})
 	if err != nil {
 		return fmt.Errorf("failed to get all issues: %w", err)
 	}
@@ -381,7 +381,7 @@ func generateFromJSONL(ctx context.Context, store storage.Storage, tempDir strin
 // exportToJSONL exports all issues to a JSONL file
 func exportToJSONL(ctx context.Context, store storage.Storage, path string) error {
 	// Get all issues
-	allIssues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	allIssues, err := store.SearchIssues(ctx, "", types.IssueFilter{IncludeSnoozed: true})
 	if err != nil {
 		return fmt.Errorf("failed to query issues: %w", err)
 	}
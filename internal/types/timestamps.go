@@ -0,0 +1,170 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimestampFormat controls how timestamps are written to JSONL exports
+type TimestampFormat string
+
+// Timestamp format constants
+const (
+	// TimestampRFC3339Nano is Go's default time.Time encoding (e.g. "2025-01-02T15:04:05.123456789Z")
+	TimestampRFC3339Nano TimestampFormat = "rfc3339nano"
+	// TimestampRFC3339 drops sub-second precision (e.g. "2025-01-02T15:04:05Z")
+	TimestampRFC3339 TimestampFormat = "rfc3339"
+	// TimestampUnixMillis writes milliseconds since the Unix epoch as a JSON number
+	TimestampUnixMillis TimestampFormat = "unix_ms"
+)
+
+// IsValid checks if the timestamp format is supported
+func (f TimestampFormat) IsValid() bool {
+	switch f {
+	case TimestampRFC3339Nano, TimestampRFC3339, TimestampUnixMillis:
+		return true
+	}
+	return false
+}
+
+// FormatTimestamps rewrites every timestamp in JSON-encoded data (any
+// "*_at" field, including those of nested dependencies and comments) into
+// format. Field order and all other bytes are preserved, so the output is as
+// deterministic as the input. TimestampRFC3339Nano returns data unchanged.
+func FormatTimestamps(data []byte, format TimestampFormat) ([]byte, error) {
+	switch format {
+	case TimestampRFC3339Nano, "":
+		return data, nil
+	case TimestampRFC3339, TimestampUnixMillis:
+	default:
+		return nil, fmt.Errorf("invalid timestamp format: %s (valid: rfc3339nano, rfc3339, unix_ms)", format)
+	}
+
+	return rewriteTimestampFields(data, func(value []byte) ([]byte, error) {
+		var s string
+		if len(value) == 0 || value[0] != '"' {
+			return value, nil // null or already converted
+		}
+		if err := json.Unmarshal(value, &s); err != nil {
+			return nil, err
+		}
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timestamp %q: %w", s, err)
+		}
+		if format == TimestampUnixMillis {
+			return strconv.AppendInt(nil, t.UnixMilli(), 10), nil
+		}
+		return json.Marshal(t.Format(time.RFC3339))
+	})
+}
+
+// numericTimestamp matches a "*_at" field holding a number, i.e. Unix millis
+var numericTimestamp = regexp.MustCompile(`_at"\s*:\s*-?[0-9]`)
+
+// NormalizeTimestamps converts Unix-millis timestamps in data back to the
+// RFC 3339 strings time.Time decodes, leaving string timestamps alone. Issue
+// decoding applies it automatically; code decoding JSONL into its own types
+// should call it first.
+func NormalizeTimestamps(data []byte) ([]byte, error) {
+	if !numericTimestamp.Match(data) {
+		return data, nil
+	}
+	return rewriteTimestampFields(data, func(value []byte) ([]byte, error) {
+		var millis json.Number
+		if err := json.Unmarshal(value, &millis); err != nil {
+			return value, nil // RFC 3339 string or null
+		}
+		n, err := millis.Int64()
+		if err != nil {
+			return nil, fmt.Errorf("invalid Unix millisecond timestamp %s: %w", millis, err)
+		}
+		return json.Marshal(time.UnixMilli(n).UTC())
+	})
+}
+
+// rewriteTimestampFields walks JSON objects and arrays in data, replacing the
+// value of every key ending in "_at" with fn(value)
+func rewriteTimestampFields(data []byte, fn func(value []byte) ([]byte, error)) ([]byte, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return data, nil
+	}
+
+	switch trimmed[0] {
+	case '{':
+		dec := json.NewDecoder(bytes.NewReader(trimmed))
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		buf.WriteByte('{')
+		for dec.More() {
+			tok, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			key, _ := tok.(string)
+			var value json.RawMessage
+			if err := dec.Decode(&value); err != nil {
+				return nil, err
+			}
+			var rewritten []byte
+			if strings.HasSuffix(key, "_at") {
+				rewritten, err = fn(value)
+			} else {
+				rewritten, err = rewriteTimestampFields(value, fn)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", key, err)
+			}
+			if buf.Len() > 1 {
+				buf.WriteByte(',')
+			}
+			keyJSON, _ := json.Marshal(key)
+			buf.Write(keyJSON)
+			buf.WriteByte(':')
+			buf.Write(rewritten)
+		}
+		buf.WriteByte('}')
+		return buf.Bytes(), nil
+
+	case '[':
+		var elems []json.RawMessage
+		if err := json.Unmarshal(trimmed, &elems); err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		buf.WriteByte('[')
+		for i, elem := range elems {
+			rewritten, err := rewriteTimestampFields(elem, fn)
+			if err != nil {
+				return nil, err
+			}
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			buf.Write(rewritten)
+		}
+		buf.WriteByte(']')
+		return buf.Bytes(), nil
+	}
+	return data, nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Timestamps may be RFC 3339
+// strings or Unix milliseconds (see TimestampUnixMillis), so JSONL written
+// in any TimestampFormat imports the same way.
+func (i *Issue) UnmarshalJSON(data []byte) error {
+	type Alias Issue
+	data, err := NormalizeTimestamps(data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, (*Alias)(i))
+}
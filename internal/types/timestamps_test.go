@@ -0,0 +1,87 @@
+package types
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatTimestampsRoundTrip(t *testing.T) {
+	created := time.Date(2025, 3, 4, 5, 6, 7, 890123456, time.UTC)
+	closed := created.Add(time.Hour)
+	issue := &Issue{
+		ID:          "bd-1",
+		Title:       "Timestamps",
+		Description: `mentions "updated_at": 5 in text`,
+		Status:      StatusClosed,
+		Priority:    1,
+		IssueType:   TypeTask,
+		CreatedAt:   created,
+		UpdatedAt:   created,
+		ClosedAt:    &closed,
+		Dependencies: []*Dependency{
+			{IssueID: "bd-1", DependsOnID: "bd-2", Type: DepBlocks, CreatedAt: created},
+		},
+	}
+	data, err := json.Marshal(issue)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	tests := []struct {
+		format    TimestampFormat
+		contains  string
+		precision time.Duration
+	}{
+		{TimestampRFC3339Nano, `"created_at":"2025-03-04T05:06:07.890123456Z"`, time.Nanosecond},
+		{TimestampRFC3339, `"created_at":"2025-03-04T05:06:07Z"`, time.Second},
+		{TimestampUnixMillis, `"created_at":1741064767890`, time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			out, err := FormatTimestamps(data, tt.format)
+			if err != nil {
+				t.Fatalf("FormatTimestamps failed: %v", err)
+			}
+			if !strings.Contains(string(out), tt.contains) {
+				t.Errorf("Expected output to contain %s, got %s", tt.contains, out)
+			}
+			again, _ := FormatTimestamps(out, tt.format)
+			if string(again) != string(out) {
+				t.Error("Expected formatting to be deterministic")
+			}
+
+			var decoded Issue
+			if err := json.Unmarshal(out, &decoded); err != nil {
+				t.Fatalf("Unmarshal failed: %v", err)
+			}
+			want := created.Truncate(tt.precision)
+			if !decoded.CreatedAt.Equal(want) || !decoded.Dependencies[0].CreatedAt.Equal(want) {
+				t.Errorf("Expected created_at %v, got %v / %v", want, decoded.CreatedAt, decoded.Dependencies[0].CreatedAt)
+			}
+			if decoded.ClosedAt == nil || !decoded.ClosedAt.Equal(closed.Truncate(tt.precision)) {
+				t.Errorf("Expected closed_at %v, got %v", closed, decoded.ClosedAt)
+			}
+			if decoded.Description != issue.Description {
+				t.Errorf("Expected description untouched, got %q", decoded.Description)
+			}
+		})
+	}
+
+	if _, err := FormatTimestamps(data, "epoch"); err == nil {
+		t.Error("Expected error for invalid format")
+	}
+}
+
+func TestFormatTimestampsPreservesFieldOrder(t *testing.T) {
+	data := []byte(`{"id":"bd-1","created_at":"2025-01-02T03:04:05Z","title":"x","closed_at":null}`)
+	out, err := FormatTimestamps(data, TimestampUnixMillis)
+	if err != nil {
+		t.Fatalf("FormatTimestamps failed: %v", err)
+	}
+	want := `{"id":"bd-1","created_at":1735787045000,"title":"x","closed_at":null}`
+	if string(out) != want {
+		t.Errorf("Expected %s, got %s", want, out)
+	}
+}
@@ -4,6 +4,8 @@ package types
 import (
 	"crypto/sha256"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 )
 
@@ -34,11 +36,65 @@ type Issue struct {
 	Labels             []string       `json:"labels,omitempty"` // Populated only for export/import
 	Dependencies       []*Dependency  `json:"dependencies,omitempty"` // Populated only for export/import
 	Comments           []*Comment     `json:"comments,omitempty"`     // Populated only for export/import
+	Links              []*Link        `json:"links,omitempty"`        // Populated only for export/import
 	// Tombstone fields (bd-vw8): inline soft-delete support
 	DeletedAt     *time.Time `json:"deleted_at,omitempty"`     // When the issue was deleted
 	DeletedBy     string     `json:"deleted_by,omitempty"`     // Who deleted the issue
 	DeleteReason  string     `json:"delete_reason,omitempty"`  // Why the issue was deleted
 	OriginalType  string     `json:"original_type,omitempty"`  // Issue type before deletion (for tombstones)
+	// Draft issues are local-only: never exported to JSONL or recorded in
+	// deletions.jsonl, and hidden from searches unless IncludeDrafts is set.
+	// See PublishIssue.
+	Draft bool `json:"draft,omitempty"`
+	// PercentComplete (0-100) tracks partial progress on long-running issues
+	// beyond open/closed; reaching 100 closes the issue if
+	// close.on_percent_complete is enabled
+	PercentComplete int `json:"percent_complete,omitempty"`
+	// ExternalBlockedReason, when set, marks the issue as blocked by
+	// something outside beads (e.g. waiting on a vendor). Externally blocked
+	// issues are never ready, whatever their dependencies. Set it with
+	// SetExternalBlock and remove it with ClearExternalBlock.
+	ExternalBlockedReason *string `json:"external_blocked_reason,omitempty"`
+	// SnoozedUntil is the wake date of a snoozed issue: until then it is
+	// parked out of ready work and default searches, after which the daemon
+	// reopens it. Set it with SnoozeIssue; leaving the snoozed status
+	// clears it.
+	SnoozedUntil *time.Time `json:"snoozed_until,omitempty"`
+	// DueDate is when the issue should be done by; nil means no due date.
+	// An issue not closed by then is overdue (see IssueFilter.Overdue).
+	DueDate *time.Time `json:"due_date,omitempty"`
+	// Version counts the updates to the issue in this database, starting at
+	// 1. Passing the version read to an update makes it fail instead of
+	// overwriting someone else's newer edits (see sqlite.WithExpectedVersion).
+	// It is local to the database and not exported.
+	Version int `json:"version,omitempty"`
+	// ReadyScore is the weighted ready score (see ReadyScoreWeights), set
+	// only by queries that order by it
+	ReadyScore *float64 `json:"ready_score,omitempty"`
+	// Recurrence is the schedule of a recurring issue, set by GetIssue.
+	// Change it with SetRecurrence.
+	Recurrence *Recurrence `json:"recurrence,omitempty"`
+	// Sections is the structured description (summary, acceptance criteria,
+	// notes, ...), keyed by section name and set by GetIssue. Change it with
+	// SetSection, which keeps Description as its FlattenSections view; only
+	// that view is exported.
+	Sections map[string]string `json:"sections,omitempty"`
+	// Watchers are the users following the issue, set by GetIssue. Change
+	// them with WatchIssue and UnwatchIssue; they are local to the database
+	// and not exported.
+	Watchers []string `json:"watchers,omitempty"`
+}
+
+// GetSection returns the body of the named section, or "" if the issue has
+// no such section
+func (i *Issue) GetSection(name string) string {
+	return i.Sections[name]
+}
+
+// IsExternallyBlocked reports whether the issue is blocked by something
+// outside beads (see ExternalBlockedReason)
+func (i *Issue) IsExternallyBlocked() bool {
+	return i.ExternalBlockedReason != nil
 }
 
 // ComputeContentHash creates a deterministic hash of the issue's content.
@@ -70,6 +126,11 @@ func (i *Issue) ComputeContentHash() string {
 	if i.ExternalRef != nil {
 		h.Write([]byte(*i.ExternalRef))
 	}
+	// Only hashed when set, so issues without one keep their existing hashes
+	if i.DueDate != nil {
+		h.Write([]byte{0})
+		h.Write([]byte(i.DueDate.UTC().Format(time.RFC3339)))
+	}
 	
 	return fmt.Sprintf("%x", h.Sum(nil))
 }
@@ -83,6 +144,19 @@ const MinTombstoneTTL = 7 * 24 * time.Hour
 // ClockSkewGrace is added to TTL to handle clock drift between machines
 const ClockSkewGrace = 1 * time.Hour
 
+// PriorityUnset marks an issue's priority as not chosen by the caller, so
+// CreateIssue fills in the project default. 0 is a real priority (P0), hence
+// the sentinel.
+const PriorityUnset = -1
+
+// DefaultPriority is used for new issues when neither the caller nor the
+// project config sets a priority
+const DefaultPriority = 2
+
+// DefaultIssueType is used for new issues when neither the caller nor the
+// project config sets a type
+const DefaultIssueType = TypeTask
+
 // IsTombstone returns true if the issue has been soft-deleted (bd-vw8)
 func (i *Issue) IsTombstone() bool {
 	return i.Status == StatusTombstone
@@ -141,6 +215,9 @@ func (i *Issue) ValidateWithCustomStatuses(customStatuses []string) error {
 	if i.EstimatedMinutes != nil && *i.EstimatedMinutes < 0 {
 		return fmt.Errorf("estimated_minutes cannot be negative")
 	}
+	if i.PercentComplete < 0 || i.PercentComplete > 100 {
+		return fmt.Errorf("percent_complete must be between 0 and 100 (got %d)", i.PercentComplete)
+	}
 	// Enforce closed_at invariant: closed_at should be set if and only if status is closed
 	if i.Status == StatusClosed && i.ClosedAt == nil {
 		return fmt.Errorf("closed issues must have closed_at timestamp")
@@ -148,6 +225,9 @@ func (i *Issue) ValidateWithCustomStatuses(customStatuses []string) error {
 	if i.Status != StatusClosed && i.ClosedAt != nil {
 		return fmt.Errorf("non-closed issues cannot have closed_at timestamp")
 	}
+	if i.Status != StatusSnoozed && i.SnoozedUntil != nil {
+		return fmt.Errorf("non-snoozed issues cannot have snoozed_until timestamp")
+	}
 	// Enforce tombstone invariants (bd-md2): deleted_at must be set for tombstones, and only for tombstones
 	if i.Status == StatusTombstone && i.DeletedAt == nil {
 		return fmt.Errorf("tombstone issues must have deleted_at timestamp")
@@ -167,13 +247,14 @@ const (
 	StatusInProgress Status = "in_progress"
 	StatusBlocked    Status = "blocked"
 	StatusClosed     Status = "closed"
+	StatusSnoozed    Status = "snoozed"   // Parked until SnoozedUntil (see SnoozeIssue)
 	StatusTombstone  Status = "tombstone" // Soft-deleted issue (bd-vw8)
 )
 
 // IsValid checks if the status value is valid (built-in statuses only)
 func (s Status) IsValid() bool {
 	switch s {
-	case StatusOpen, StatusInProgress, StatusBlocked, StatusClosed, StatusTombstone:
+	case StatusOpen, StatusInProgress, StatusBlocked, StatusClosed, StatusTombstone, StatusSnoozed:
 		return true
 	}
 	return false
@@ -195,6 +276,75 @@ func (s Status) IsValidWithCustom(customStatuses []string) bool {
 	return false
 }
 
+// StatusCategory groups statuses into board swimlanes
+type StatusCategory string
+
+// Status category constants, in board order
+const (
+	CategoryTodo  StatusCategory = "todo"
+	CategoryDoing StatusCategory = "doing"
+	CategoryDone  StatusCategory = "done"
+)
+
+// StatusCategories lists the status categories in board order
+var StatusCategories = []StatusCategory{CategoryTodo, CategoryDoing, CategoryDone}
+
+// IsValid checks if the status category value is valid
+func (c StatusCategory) IsValid() bool {
+	switch c {
+	case CategoryTodo, CategoryDoing, CategoryDone:
+		return true
+	}
+	return false
+}
+
+// StatusMeta is display metadata for a status, built-in or custom
+type StatusMeta struct {
+	Status   Status         `json:"status"`
+	Category StatusCategory `json:"category"`
+	Color    string         `json:"color,omitempty"` // Any color clients understand, e.g. "red" or "#d9534f"
+	Order    int            `json:"order"`           // Position among statuses, lowest first
+}
+
+// BoardLane is one swimlane of a board: the issues whose status falls in
+// Category, with the metadata of the statuses in it
+type BoardLane struct {
+	Category StatusCategory `json:"category"`
+	Statuses []StatusMeta   `json:"statuses"`
+	Issues   []*Issue       `json:"issues"`
+}
+
+// TemplateGraph is a multi-issue template: issues identified by
+// template-local names and the dependency edges between them. Text fields
+// may reference {{variables}} filled in when the template is instantiated.
+type TemplateGraph struct {
+	Issues []TemplateIssue `yaml:"issues,omitempty" json:"issues"`
+	Edges  []TemplateEdge  `yaml:"edges,omitempty" json:"edges,omitempty"`
+}
+
+// TemplateIssue is one issue of a TemplateGraph. Unset priority and type
+// fall back to the project's creation defaults.
+type TemplateIssue struct {
+	Name               string   `yaml:"name" json:"name"` // Template-local name used by edges
+	Title              string   `yaml:"title" json:"title"`
+	Description        string   `yaml:"description" json:"description,omitempty"`
+	Design             string   `yaml:"design" json:"design,omitempty"`
+	AcceptanceCriteria string   `yaml:"acceptance_criteria" json:"acceptance_criteria,omitempty"`
+	Type               string   `yaml:"type" json:"type,omitempty"`
+	Priority           *int     `yaml:"priority" json:"priority,omitempty"`
+	Assignee           string   `yaml:"assignee" json:"assignee,omitempty"`
+	Labels             []string `yaml:"labels" json:"labels,omitempty"`
+}
+
+// TemplateEdge is a dependency between two issues of a TemplateGraph, by
+// name: From depends on To. Type defaults to blocks; for parent-child, From
+// is the child.
+type TemplateEdge struct {
+	From string         `yaml:"from" json:"from"`
+	To   string         `yaml:"to" json:"to"`
+	Type DependencyType `yaml:"type" json:"type,omitempty"`
+}
+
 // IssueType categorizes the kind of work
 type IssueType string
 
@@ -223,6 +373,33 @@ type Dependency struct {
 	Type        DependencyType `json:"type"`
 	CreatedAt   time.Time      `json:"created_at"`
 	CreatedBy   string         `json:"created_by"`
+	Note        string         `json:"note,omitempty"` // Why the edge exists, e.g. "shared schema"
+}
+
+// DepEdge is a single directed edge in the dependency graph, without the
+// audit metadata carried by Dependency. Used for bulk graph export.
+type DepEdge struct {
+	From string         `json:"from"` // The dependent issue (Dependency.IssueID)
+	To   string         `json:"to"`   // The issue depended on (Dependency.DependsOnID)
+	Type DependencyType `json:"type"`
+}
+
+// DanglingRefSource says where a dangling reference was found
+type DanglingRefSource string
+
+// Dangling reference sources
+const (
+	DanglingRefDependency DanglingRefSource = "dependency" // A dependency edge
+	DanglingRefText       DanglingRefSource = "text"       // An issue ID mentioned in a text field
+)
+
+// DanglingRef is a reference from an issue to an issue ID that doesn't
+// exist or has been deleted
+type DanglingRef struct {
+	IssueID   string            `json:"issue_id"`   // The referencing issue
+	MissingID string            `json:"missing_id"` // The referenced ID that doesn't resolve
+	Source    DanglingRefSource `json:"source"`
+	Field     string            `json:"field"` // Dependency type, or the text field containing the mention
 }
 
 // DependencyCounts holds counts for dependencies and dependents
@@ -243,6 +420,25 @@ type IssueWithCounts struct {
 	*Issue
 	DependencyCount int `json:"dependency_count"`
 	DependentCount  int `json:"dependent_count"`
+	AgeDays         int `json:"age_days"`   // Whole days since creation (see Issue.AgeDays)
+	StaleDays       int `json:"stale_days"` // Whole days since last update (see Issue.StaleDays)
+}
+
+// AgeDays returns the number of whole days between the issue's creation and now
+func (i *Issue) AgeDays(now time.Time) int {
+	return wholeDaysSince(i.CreatedAt, now)
+}
+
+// StaleDays returns the number of whole days between the issue's last update and now
+func (i *Issue) StaleDays(now time.Time) int {
+	return wholeDaysSince(i.UpdatedAt, now)
+}
+
+func wholeDaysSince(t, now time.Time) int {
+	if t.IsZero() || now.Before(t) {
+		return 0
+	}
+	return int(now.Sub(t) / (24 * time.Hour))
 }
 
 // DependencyType categorizes the relationship
@@ -271,6 +467,12 @@ type Label struct {
 	Label   string `json:"label"`
 }
 
+// LabelCount is a distinct label together with the number of issues carrying it
+type LabelCount struct {
+	Label string `json:"label"`
+	Count int    `json:"count"`
+}
+
 // Comment represents a comment on an issue
 type Comment struct {
 	ID        int64     `json:"id"`
@@ -280,6 +482,86 @@ type Comment struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
+// Digest summarizes an issue for an at-close notification
+type Digest struct {
+	IssueID     string        `json:"issue_id"`
+	Title       string        `json:"title"`
+	Status      Status        `json:"status"`
+	CloseReason string        `json:"close_reason,omitempty"`
+	CreatedAt   time.Time     `json:"created_at"`
+	ClosedAt    *time.Time    `json:"closed_at,omitempty"`
+	TimeToClose time.Duration `json:"time_to_close,omitempty"` // ClosedAt - CreatedAt; zero while open
+	LastComment *Comment      `json:"last_comment,omitempty"`
+}
+
+// RecurrenceFreq is the period of a recurring issue
+type RecurrenceFreq string
+
+// Recurrence frequencies
+const (
+	RecurDaily   RecurrenceFreq = "daily"
+	RecurWeekly  RecurrenceFreq = "weekly"
+	RecurMonthly RecurrenceFreq = "monthly"
+)
+
+// IsValid checks if the frequency is supported
+func (f RecurrenceFreq) IsValid() bool {
+	switch f {
+	case RecurDaily, RecurWeekly, RecurMonthly:
+		return true
+	}
+	return false
+}
+
+// Recurrence is the schedule of a recurring issue, like the FREQ and
+// INTERVAL parts of an RRULE: a new instance is due every Interval periods
+// of Freq, starting at NextDue.
+type Recurrence struct {
+	Freq     RecurrenceFreq `json:"freq"`
+	Interval int            `json:"interval,omitempty"` // 0 means 1
+	NextDue  time.Time      `json:"next_due"`
+}
+
+// Validate checks the recurrence is well formed
+func (r *Recurrence) Validate() error {
+	if !r.Freq.IsValid() {
+		return fmt.Errorf("invalid recurrence frequency %q (must be daily, weekly or monthly)", r.Freq)
+	}
+	if r.Interval < 0 {
+		return fmt.Errorf("recurrence interval must not be negative, got %d", r.Interval)
+	}
+	if r.NextDue.IsZero() {
+		return fmt.Errorf("recurrence next due date is required")
+	}
+	return nil
+}
+
+// After returns the due date one period after due
+func (r *Recurrence) After(due time.Time) time.Time {
+	n := r.Interval
+	if n < 1 {
+		n = 1
+	}
+	switch r.Freq {
+	case RecurDaily:
+		return due.AddDate(0, 0, n)
+	case RecurWeekly:
+		return due.AddDate(0, 0, 7*n)
+	default:
+		return due.AddDate(0, n, 0)
+	}
+}
+
+// Link is an external URL (doc, PR, runbook, ...) attached to an issue
+type Link struct {
+	ID        int64     `json:"id"`
+	IssueID   string    `json:"issue_id"`
+	URL       string    `json:"url"`
+	Title     string    `json:"title,omitempty"`
+	Kind      string    `json:"kind"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
 // Event represents an audit trail entry
 type Event struct {
 	ID        int64      `json:"id"`
@@ -292,6 +574,43 @@ type Event struct {
 	CreatedAt time.Time  `json:"created_at"`
 }
 
+// FieldChange is one change to one field of an issue, derived from its
+// events (see sqlite.History). Field, Old and New use the field's JSONL name
+// and encoding; for labels and dependencies, New is the label or target
+// added and Old the one removed. Old is nil when the prior value isn't known.
+type FieldChange struct {
+	EventID   int64       `json:"event_id"`
+	Field     string      `json:"field"`
+	Old       interface{} `json:"old"`
+	New       interface{} `json:"new"`
+	Actor     string      `json:"actor"`
+	ChangedAt time.Time   `json:"changed_at"`
+}
+
+// ChangeEvent is one entry in the change log external tools can tail (see
+// SQLiteStorage.Subscribe). Seq increases monotonically across the database;
+// a subscriber that records the last Seq it handled can resume from there.
+type ChangeEvent struct {
+	Seq       int64      `json:"seq"`
+	IssueID   string     `json:"issue_id"`
+	Kind      ChangeKind `json:"kind"`
+	Fields    []string   `json:"fields,omitempty"` // Fields changed, for updates and status changes
+	Actor     string     `json:"actor"`
+	Timestamp time.Time  `json:"timestamp"`
+}
+
+// ChangeKind classifies a ChangeEvent
+type ChangeKind string
+
+// Change kinds
+const (
+	ChangeCreated       ChangeKind = "created"
+	ChangeUpdated       ChangeKind = "updated"
+	ChangeStatusChanged ChangeKind = "status_changed" // Includes closes and reopens
+	ChangeDeleted       ChangeKind = "deleted"
+	ChangeOverdue       ChangeKind = "overdue" // Passed its due date while open
+)
+
 // EventType categorizes audit trail events
 type EventType string
 
@@ -300,6 +619,7 @@ const (
 	EventCreated           EventType = "created"
 	EventUpdated           EventType = "updated"
 	EventStatusChanged     EventType = "status_changed"
+	EventPriorityChanged   EventType = "priority_changed"
 	EventCommented         EventType = "commented"
 	EventClosed            EventType = "closed"
 	EventReopened          EventType = "reopened"
@@ -307,7 +627,13 @@ const (
 	EventDependencyRemoved EventType = "dependency_removed"
 	EventLabelAdded        EventType = "label_added"
 	EventLabelRemoved      EventType = "label_removed"
+	EventLinkAdded         EventType = "link_added"
+	EventLinkRemoved       EventType = "link_removed"
+	EventPublished         EventType = "published"
 	EventCompacted         EventType = "compacted"
+	EventUndone            EventType = "undone"
+	EventVisibilityChanged EventType = "visibility_changed"
+	EventOverdue           EventType = "overdue" // Passed its due date while open; new_value holds the due date
 )
 
 // BlockedIssue extends Issue with blocking information
@@ -317,6 +643,13 @@ type BlockedIssue struct {
 	BlockedBy      []string `json:"blocked_by"`
 }
 
+// AlmostReadyIssue is an open issue that becomes ready once its few
+// remaining blockers close
+type AlmostReadyIssue struct {
+	Issue
+	RemainingBlockers []string `json:"remaining_blockers"`
+}
+
 // TreeNode represents a node in a dependency tree
 type TreeNode struct {
 	Issue
@@ -344,16 +677,20 @@ type IssueFilter struct {
 	Priority    *int
 	IssueType   *IssueType
 	Assignee    *string
+	Watcher     *string   // Only issues this user watches
 	Labels      []string  // AND semantics: issue must have ALL these labels
 	LabelsAny   []string  // OR semantics: issue must have AT LEAST ONE of these labels
+	LabelGlobs  []string  // AND semantics: issue must have a label matching EACH glob ("area/*"); '*' matches any run, '?' one character
 	TitleSearch string
 	IDs         []string  // Filter by specific issue IDs
 	Limit       int
+	Offset      int       // Skip this many results first; with Limit, selects a page (see sqlite.SearchIssuesPage)
 	
 	// Pattern matching
 	TitleContains       string
 	DescriptionContains string
 	NotesContains       string
+	SectionContains     map[string]string // Section name -> text that section must contain (see Issue.Sections)
 	
 	// Date ranges
 	CreatedAfter  *time.Time
@@ -368,12 +705,47 @@ type IssueFilter struct {
 	NoAssignee       bool
 	NoLabels         bool
 	
+	// Dependency graph position: roots depend on nothing, leaves have nothing
+	// depending on them. Isolated issues are both.
+	IsRoot bool
+	IsLeaf bool
+
 	// Numeric ranges
 	PriorityMin *int
 	PriorityMax *int
+	MinPercent  *int // Only issues at least this percent complete
+
+	// Age: created (OlderThan) or last updated (StalerThan) longer ago than
+	// the duration, relative to the store's clock. Zero disables the filter.
+	OlderThan  time.Duration
+	StalerThan time.Duration
+
+	// Scheduling: due before DueBefore, or past due and not closed (Overdue),
+	// relative to the store's clock. Issues without a due date never match.
+	DueBefore *time.Time
+	Overdue   bool
 
 	// Tombstone filtering (bd-1bu)
 	IncludeTombstones bool // If false (default), exclude tombstones from results
+	IncludeDrafts     bool // If false (default), exclude draft issues from results
+	IncludeSnoozed    bool // If false (default), exclude snoozed issues unless Status asks for them
+
+	// SortBy orders results by each key in turn, with ID as the final
+	// tie-breaker. Empty means DefaultSortKeys.
+	SortBy []SortKey
+
+	// Fields, if set, limits the returned issues to these fields (JSON names,
+	// see ProjectableFields); every other field is left zero-valued. ID is
+	// always returned. Empty means all fields.
+	Fields []string
+}
+
+// IssuePage is one page of search results along with the total number of
+// matches across all pages
+type IssuePage struct {
+	Issues []*Issue `json:"issues"`
+	Total  int      `json:"total"`
+	Offset int      `json:"offset"`
 }
 
 // SortPolicy determines how ready work is ordered
@@ -393,17 +765,179 @@ const (
 	// SortPolicyOldest always sorts by creation date (oldest first)
 	// Use for backlog clearing, preventing issue starvation
 	SortPolicyOldest SortPolicy = "oldest"
+
+	// SortPolicyReadyScore sorts by ready score, highest first, mixing
+	// priority, age and how many open issues each one blocks (see
+	// ReadyScoreWeights)
+	SortPolicyReadyScore SortPolicy = "ready_score"
 )
 
 // IsValid checks if the sort policy value is valid
 func (s SortPolicy) IsValid() bool {
 	switch s {
-	case SortPolicyHybrid, SortPolicyPriority, SortPolicyOldest, "":
+	case SortPolicyHybrid, SortPolicyPriority, SortPolicyOldest, SortPolicyReadyScore, "":
+		return true
+	}
+	return false
+}
+
+// SortField names an issue field that search results can be ordered by
+type SortField string
+
+// Sort field constants
+const (
+	SortByPriority  SortField = "priority"
+	SortByCreatedAt SortField = "created_at"
+	SortByUpdatedAt SortField = "updated_at"
+	SortByClosedAt  SortField = "closed_at"
+	SortByStatus    SortField = "status"
+	SortByIssueType SortField = "issue_type"
+	SortByAssignee  SortField = "assignee"
+	SortByTitle     SortField = "title"
+	SortByID        SortField = "id"
+
+	// SortByReadyScore orders by ready score (see ReadyScoreWeights). Like
+	// priority, ascending puts the most important issue (highest score) first.
+	SortByReadyScore SortField = "ready_score"
+)
+
+// IsValid checks if the sort field is supported
+func (f SortField) IsValid() bool {
+	switch f {
+	case SortByPriority, SortByCreatedAt, SortByUpdatedAt, SortByClosedAt,
+		SortByStatus, SortByIssueType, SortByAssignee, SortByTitle, SortByID, SortByReadyScore:
 		return true
 	}
 	return false
 }
 
+// SortKey orders search results by one field. Issues without a value (e.g.
+// a nil closed_at) sort first ascending and last descending.
+type SortKey struct {
+	Field      SortField
+	Descending bool
+}
+
+// DefaultSortKeys is the order used when IssueFilter.SortBy is empty:
+// priority ascending (P0 first), then newest first
+var DefaultSortKeys = []SortKey{
+	{Field: SortByPriority},
+	{Field: SortByCreatedAt, Descending: true},
+}
+
+// ValidateSortKeys returns an error for the first key with an unknown field
+func ValidateSortKeys(keys []SortKey) error {
+	for _, key := range keys {
+		if !key.Field.IsValid() {
+			return fmt.Errorf("invalid sort field: %q", key.Field)
+		}
+	}
+	return nil
+}
+
+// ProjectableFields are the issue fields, by JSON name, that
+// IssueFilter.Fields can select. Dependencies, comments and links aren't
+// loaded by searches, so they can't be requested.
+var ProjectableFields = []string{
+	"id", "title", "description", "design", "acceptance_criteria", "notes",
+	"status", "priority", "issue_type", "assignee", "estimated_minutes",
+	"created_at", "updated_at", "closed_at", "close_reason", "external_ref",
+	"labels", "draft", "percent_complete", "external_blocked_reason", "snoozed_until", "due_date", "version",
+}
+
+// ValidateFields returns an error for the first field that can't be
+// projected
+func ValidateFields(fields []string) error {
+	for _, field := range fields {
+		if !slices.Contains(ProjectableFields, field) {
+			return fmt.Errorf("invalid field %q (valid: %s)", field, strings.Join(ProjectableFields, ", "))
+		}
+	}
+	return nil
+}
+
+// ProjectIssue returns a copy of issue with only fields (see
+// IssueFilter.Fields) set, plus ID and ReadyScore. Empty fields returns a
+// full copy.
+func ProjectIssue(issue *Issue, fields []string) *Issue {
+	if len(fields) == 0 {
+		c := *issue
+		return &c
+	}
+	p := &Issue{ID: issue.ID, ReadyScore: issue.ReadyScore}
+	for _, field := range fields {
+		switch field {
+		case "title":
+			p.Title = issue.Title
+		case "description":
+			p.Description = issue.Description
+		case "design":
+			p.Design = issue.Design
+		case "acceptance_criteria":
+			p.AcceptanceCriteria = issue.AcceptanceCriteria
+		case "notes":
+			p.Notes = issue.Notes
+		case "status":
+			p.Status = issue.Status
+		case "priority":
+			p.Priority = issue.Priority
+		case "issue_type":
+			p.IssueType = issue.IssueType
+		case "assignee":
+			p.Assignee = issue.Assignee
+		case "estimated_minutes":
+			p.EstimatedMinutes = issue.EstimatedMinutes
+		case "created_at":
+			p.CreatedAt = issue.CreatedAt
+		case "updated_at":
+			p.UpdatedAt = issue.UpdatedAt
+		case "closed_at":
+			p.ClosedAt = issue.ClosedAt
+		case "close_reason":
+			p.CloseReason = issue.CloseReason
+		case "external_ref":
+			p.ExternalRef = issue.ExternalRef
+		case "labels":
+			p.Labels = issue.Labels
+		case "draft":
+			p.Draft = issue.Draft
+		case "percent_complete":
+			p.PercentComplete = issue.PercentComplete
+		case "external_blocked_reason":
+			p.ExternalBlockedReason = issue.ExternalBlockedReason
+		case "snoozed_until":
+			p.SnoozedUntil = issue.SnoozedUntil
+		case "due_date":
+			p.DueDate = issue.DueDate
+		case "version":
+			p.Version = issue.Version
+		}
+	}
+	return p
+}
+
+// ReadyScoreWeights are the coefficients of the ready score:
+//
+//	Priority*(4-priority) + Age*days since creation + Dependents*open issues blocked
+//
+// so higher scores mean "do this first": urgent, long-waiting work that
+// unblocks the most other issues.
+type ReadyScoreWeights struct {
+	Priority   float64 `json:"priority"`
+	Age        float64 `json:"age"`
+	Dependents float64 `json:"dependents"`
+}
+
+// DefaultReadyScoreWeights are used for any weight not configured: one
+// priority level is worth two blocked issues or twenty days of waiting.
+var DefaultReadyScoreWeights = ReadyScoreWeights{Priority: 10, Age: 0.5, Dependents: 5}
+
+// Score computes the ready score of an issue with the given priority, age in
+// days and number of open issues it blocks
+func (w ReadyScoreWeights) Score(priority int, ageDays float64, dependents int) float64 {
+	return w.Priority*float64(4-priority) + w.Age*ageDays + w.Dependents*float64(dependents)
+}
+
 // WorkFilter is used to filter ready work queries
 type WorkFilter struct {
 	Status     Status
@@ -414,6 +948,9 @@ type WorkFilter struct {
 	LabelsAny  []string   // OR semantics: issue must have AT LEAST ONE of these labels
 	Limit      int
 	SortPolicy SortPolicy
+
+	// ExcludeClaimed omits issues with an unexpired claim (see ClaimIssue)
+	ExcludeClaimed bool
 }
 
 // StaleFilter is used to filter stale issue queries
@@ -423,6 +960,85 @@ type StaleFilter struct {
 	Limit  int    // Maximum issues to return
 }
 
+// Visibility controls which actors can see an issue
+type Visibility string
+
+// Visibility constants
+const (
+	VisibilityPublic  Visibility = "public"  // Everyone (the default)
+	VisibilityPrivate Visibility = "private" // Only the issue's owners
+)
+
+// IsValid checks if the visibility value is valid
+func (v Visibility) IsValid() bool {
+	return v == VisibilityPublic || v == VisibilityPrivate
+}
+
+// Well-known section names. Any name accepted by IsValidSectionName can be
+// used; these come first, in this order, when sections are flattened.
+const (
+	SectionSummary            = "summary"
+	SectionAcceptanceCriteria = "acceptance_criteria"
+	SectionNotes              = "notes"
+)
+
+var sectionOrder = []string{SectionSummary, SectionAcceptanceCriteria, SectionNotes}
+
+// IsValidSectionName reports whether name can name a section: lowercase
+// letters, digits and underscores, starting with a letter
+func IsValidSectionName(name string) bool {
+	if name == "" || name[0] < 'a' || name[0] > 'z' {
+		return false
+	}
+	for _, r := range name {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+// FlattenSections renders sections as the Markdown description shown to
+// readers that don't know about sections: each non-empty section under a
+// "## " heading made from its name ("acceptance_criteria" becomes
+// "Acceptance criteria"), well-known sections first and the rest by name.
+func FlattenSections(sections map[string]string) string {
+	var names []string
+	for name := range sections {
+		if !slices.Contains(sectionOrder, name) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	names = append(slices.Clone(sectionOrder), names...)
+
+	var parts []string
+	for _, name := range names {
+		body := strings.TrimSpace(sections[name])
+		if body == "" {
+			continue
+		}
+		heading := strings.ReplaceAll(name, "_", " ")
+		heading = strings.ToUpper(heading[:1]) + heading[1:]
+		parts = append(parts, "## "+heading+"\n\n"+body)
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// DepFilter selects dependency edges, e.g. for RemoveDependencies. Every
+// criterion that is set must match.
+type DepFilter struct {
+	IssueID     string          // Edges from this issue (the dependent side)
+	DependsOnID string          // Edges to this issue
+	Type        *DependencyType // Edges of this type
+	Tombstoned  bool            // Edges with a soft-deleted issue on either end
+}
+
+// IsEmpty reports whether f sets no criteria and so matches every edge
+func (f DepFilter) IsEmpty() bool {
+	return f.IssueID == "" && f.DependsOnID == "" && f.Type == nil && !f.Tombstoned
+}
+
 // EpicStatus represents an epic with its completion status
 type EpicStatus struct {
 	Epic            *Issue `json:"epic"`
@@ -430,3 +1046,55 @@ type EpicStatus struct {
 	ClosedChildren  int    `json:"closed_children"`
 	EligibleForClose bool  `json:"eligible_for_close"`
 }
+
+// EpicProgress is an epic's completion, either by closed children or by the
+// children's percent complete
+type EpicProgress struct {
+	EpicID          string `json:"epic_id"`
+	TotalChildren   int    `json:"total_children"`
+	ClosedChildren  int    `json:"closed_children"`
+	PercentComplete int    `json:"percent_complete"`
+	ByPercent       bool   `json:"by_percent"` // PercentComplete averages children's percent complete
+}
+
+// ThroughputBucket is the number of issues closed in [Start, End)
+type ThroughputBucket struct {
+	Start  time.Time `json:"start"`
+	End    time.Time `json:"end"`
+	Closed int       `json:"closed"`
+}
+
+// SLAState is where an issue stands against one SLA target
+type SLAState string
+
+// SLA states
+const (
+	SLANone     SLAState = "none"     // No target configured for the issue's priority
+	SLAOnTrack  SLAState = "on_track" // Not met yet, with time to spare
+	SLAAtRisk   SLAState = "at_risk"  // Not met yet, and most of the target has elapsed
+	SLAMet      SLAState = "met"      // Met before the deadline
+	SLABreached SLAState = "breached" // Met late, or past the deadline and still not met
+)
+
+// SLATarget is an issue's standing against one SLA target, measured from
+// its creation
+type SLATarget struct {
+	State    SLAState      `json:"state"`
+	Target   time.Duration `json:"target,omitempty"`
+	Deadline *time.Time    `json:"deadline,omitempty"`
+	MetAt    *time.Time    `json:"met_at,omitempty"`
+}
+
+// SLAStatus is an issue's standing against the response and resolution
+// targets of its priority
+type SLAStatus struct {
+	IssueID    string    `json:"issue_id"`
+	Priority   int       `json:"priority"`
+	Response   SLATarget `json:"response"`
+	Resolution SLATarget `json:"resolution"`
+}
+
+// Breached reports whether either target is breached
+func (s SLAStatus) Breached() bool {
+	return s.Response.State == SLABreached || s.Resolution.State == SLABreached
+}
@@ -808,3 +808,16 @@ func containsMiddle(s, substr string) bool {
 	}
 	return false
 }
+
+func TestFlattenSections(t *testing.T) {
+	got := FlattenSections(map[string]string{
+		"risks":       "None",
+		SectionNotes:  "See RFC",
+		"empty":       "  ",
+		"api_changes": "Adds /limits",
+	})
+	want := "## Notes\n\nSee RFC\n\n## Api changes\n\nAdds /limits\n\n## Risks\n\nNone"
+	if got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
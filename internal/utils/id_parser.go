@@ -26,6 +26,12 @@ func ParseIssueID(input string, prefix string) string {
 	return prefix + input
 }
 
+// idResolver is implemented by stores that can map an issue's old IDs to
+// its current one
+type idResolver interface {
+	ResolveID(ctx context.Context, id string) (string, error)
+}
+
 // ResolvePartialID resolves a potentially partial issue ID to a full ID.
 // Supports:
 // - Full IDs: "bd-a3f8e9" or "a3f8e9" → "bd-a3f8e9"
@@ -37,6 +43,14 @@ func ParseIssueID(input string, prefix string) string {
 // - No issue found matching the ID
 // - Multiple issues match (ambiguous prefix)
 func ResolvePartialID(ctx context.Context, store storage.Storage, input string) (string, error) {
+	// Follow renames: stores that keep aliases map an old ID to the issue's
+	// current ID (see sqlite.RenameIssue)
+	if resolver, ok := store.(idResolver); ok {
+		if id, err := resolver.ResolveID(ctx, input); err == nil {
+			return id, nil
+		}
+	}
+
 	// Fast path: if the user typed an exact ID that exists, return it as-is.
 	// This preserves behavior where issue IDs may not match the configured
 	// issue_prefix (e.g. cross-repo IDs like "ao-izl"), while still allowing